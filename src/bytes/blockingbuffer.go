@@ -0,0 +1,135 @@
+// Copyright 2018 Li Zebang. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package bytes
+
+import (
+	"io"
+	"sync"
+)
+
+// A BlockingBuffer connects a writing goroutine to a reading one like
+// io.Pipe, but stores what is written in a growable Buffer: Write never
+// waits for a reader, and Read blocks only while the buffer is empty and
+// has not been closed. It is safe for use by multiple goroutines. The zero
+// value is an empty buffer ready to use. A BlockingBuffer must not be
+// copied after first use.
+//
+// BlockingBuffer 像 io.Pipe 一样将写入的 goroutine 和读取的 goroutine 连接起来，但它将写入的
+// 内容保存在一个可以增长的 Buffer 中：Write 从不等待读取者，Read 只在缓冲区为空并且没有被关闭
+// 时阻塞。它可以被多个 goroutine 安全地使用。零值是一个可以使用的空缓冲区。BlockingBuffer 在
+// 第一次使用之后不能被复制。
+//
+// IMP: 和 io.Pipe 不同，Write 不会等待数据被读走，所以写入比读取快时缓冲区会一直增长。
+type BlockingBuffer struct {
+	mu sync.Mutex
+	// 在写入或者关闭时唤醒阻塞的 Read
+	cond sync.Cond // wakes blocked Reads on Write or close
+	buf  Buffer
+	// 是否已经被关闭
+	closed bool // whether the buffer has been closed
+	// 缓冲区读完之后 Read 返回的错误
+	err error // returned by Read once the buffer is drained
+}
+
+// lock locks b, setting up the condition on first use.
+//
+// lock 锁定 b，并在第一次使用时初始化条件变量。
+func (b *BlockingBuffer) lock() {
+	b.mu.Lock()
+	if b.cond.L == nil {
+		b.cond.L = &b.mu
+	}
+}
+
+// Write appends the contents of p to the buffer and wakes up a blocked
+// Read. It returns io.ErrClosedPipe if the buffer has been closed.
+//
+// Write 将 p 的内容追加到缓冲区中，并唤醒阻塞的 Read。如果缓冲区已经被关闭，它返回
+// io.ErrClosedPipe。
+func (b *BlockingBuffer) Write(p []byte) (n int, err error) {
+	b.lock()
+	defer b.mu.Unlock()
+	if b.closed {
+		return 0, io.ErrClosedPipe
+	}
+	n, err = b.buf.Write(p)
+	b.cond.Broadcast()
+	return n, err
+}
+
+// WriteString is like Write, but writes the contents of s.
+//
+// WriteString 类似于 Write，但写入的是 s 的内容。
+func (b *BlockingBuffer) WriteString(s string) (n int, err error) {
+	b.lock()
+	defer b.mu.Unlock()
+	if b.closed {
+		return 0, io.ErrClosedPipe
+	}
+	n, err = b.buf.WriteString(s)
+	b.cond.Broadcast()
+	return n, err
+}
+
+// Read reads up to len(p) bytes from the buffer, blocking until some data
+// has been written or the buffer has been closed. Once the buffer is
+// closed and drained, Read returns the error given to CloseWithError, or
+// io.EOF after Close.
+//
+// Read 从缓冲区中读取至多 len(p) 个字节，阻塞直到有数据被写入或者缓冲区被关闭。缓冲区被关闭
+// 并且读完之后，Read 返回传给 CloseWithError 的错误，Close 之后则返回 io.EOF。
+func (b *BlockingBuffer) Read(p []byte) (n int, err error) {
+	if len(p) == 0 {
+		return 0, nil
+	}
+	b.lock()
+	defer b.mu.Unlock()
+	for b.buf.Len() == 0 && !b.closed {
+		b.cond.Wait()
+	}
+	if b.buf.Len() == 0 {
+		return 0, b.err
+	}
+	return b.buf.Read(p)
+}
+
+// Len returns the number of bytes written but not read yet.
+//
+// Len 返回已经写入但还没有被读取的字节数。
+func (b *BlockingBuffer) Len() int {
+	b.lock()
+	defer b.mu.Unlock()
+	return b.buf.Len()
+}
+
+// Close closes the buffer; see CloseWithError. It always returns nil.
+//
+// Close 关闭缓冲区，参见 CloseWithError。它总是返回 nil。
+func (b *BlockingBuffer) Close() error {
+	return b.CloseWithError(nil)
+}
+
+// CloseWithError closes the buffer, so that later Writes fail and Reads,
+// once they have drained the buffer, return err, or io.EOF if err is nil.
+// Blocked Reads are woken up. Closing a closed buffer does not change the
+// error. It always returns nil.
+//
+// CloseWithError 关闭缓冲区，这样之后的 Write 会失败，而 Read 在读完缓冲区之后返回 err，如果
+// err 为 nil 则返回 io.EOF。阻塞的 Read 会被唤醒。再次关闭已经关闭的缓冲区不会改变错误。它总是
+// 返回 nil。
+func (b *BlockingBuffer) CloseWithError(err error) error {
+	b.lock()
+	defer b.mu.Unlock()
+	if b.closed {
+		return nil
+	}
+	if err == nil {
+		err = io.EOF
+	}
+	b.closed = true
+	b.err = err
+	b.cond.Broadcast()
+	return nil
+}
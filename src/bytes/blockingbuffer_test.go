@@ -0,0 +1,77 @@
+// Copyright 2018 Li Zebang. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package bytes_test
+
+import (
+	. "bytes"
+	"errors"
+	"io"
+	"io/ioutil"
+	"testing"
+	"time"
+)
+
+func TestBlockingBuffer(t *testing.T) {
+	var b BlockingBuffer
+	type result struct {
+		data string
+		err  error
+	}
+	done := make(chan result)
+	go func() {
+		data, err := ioutil.ReadAll(&b)
+		done <- result{string(data), err}
+	}()
+
+	// Write 不等待读取者
+	for _, s := range []string{"hello", ", ", "world"} { // Write does not wait for the reader
+		if _, err := b.WriteString(s); err != nil {
+			t.Fatal(err)
+		}
+	}
+	select {
+	case r := <-done:
+		t.Fatalf("ReadAll returned %q, %v before Close", r.data, r.err)
+	case <-time.After(10 * time.Millisecond):
+	}
+	b.Close()
+	select {
+	case r := <-done:
+		if r.data != "hello, world" || r.err != nil {
+			t.Errorf("ReadAll = %q, %v; want %q, nil", r.data, r.err, "hello, world")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Read still blocked after Close")
+	}
+	if _, err := b.Write([]byte("x")); err != io.ErrClosedPipe {
+		t.Errorf("Write after Close = %v; want %v", err, io.ErrClosedPipe)
+	}
+}
+
+func TestBlockingBufferCloseWithError(t *testing.T) {
+	var b BlockingBuffer
+	errBroken := errors.New("broken")
+	b.WriteString("tail")
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		b.CloseWithError(errBroken)
+	}()
+	p := make([]byte, 10)
+	// 关闭之前写入的数据仍然可以读到
+	if n, err := b.Read(p); string(p[:n]) != "tail" || err != nil { // data written before the close is still read
+		t.Errorf("Read = %q, %v", p[:n], err)
+	}
+	if n, err := b.Read(p); n != 0 || err != errBroken {
+		t.Errorf("Read = %d, %v; want 0, %v", n, err, errBroken)
+	}
+	// 再次关闭不会改变错误
+	b.Close() // closing again does not change the error
+	if _, err := b.Read(p); err != errBroken {
+		t.Errorf("Read after a second Close = %v; want %v", err, errBroken)
+	}
+	if n, err := b.Read(nil); n != 0 || err != nil {
+		t.Errorf("Read(nil) = %d, %v", n, err)
+	}
+}
@@ -67,8 +67,25 @@ const (
 //
 // 如果无法分配内存将数据储存到缓冲区中，则将 ErrTooLarge 传给 panic。
 var ErrTooLarge = errors.New("bytes.Buffer: too large")
+
+// ErrUnreadByte is returned by UnreadByte when the most recent operation
+// on the buffer was not a successful read.
+//
+// 当缓冲区上最近的操作不是一次成功的读取时，UnreadByte 返回 ErrUnreadByte。
+var ErrUnreadByte = errors.New("bytes.Buffer: UnreadByte: previous operation was not a successful read")
+
+// ErrUnreadRune is returned by UnreadRune when the most recent operation
+// on the buffer was not a successful ReadRune.
+//
+// 当缓冲区上最近的操作不是一次成功的 ReadRune 时，UnreadRune 返回 ErrUnreadRune。
+var ErrUnreadRune = errors.New("bytes.Buffer: UnreadRune: previous operation was not a successful ReadRune")
+
 var errNegativeRead = errors.New("bytes.Buffer: reader returned negative count from Read")
 
+var errNegativeBufferOffset = errors.New("bytes.Buffer: ReadAt: negative offset")
+
+var errNegativeWriteOffset = errors.New("bytes.Buffer: WriteAt: negative offset")
+
 // IMP: int 能表示的最大值。
 const maxInt = int(^uint(0) >> 1)
 
@@ -100,6 +117,53 @@ func (b *Buffer) String() string {
 	return string(b.buf[b.off:])
 }
 
+// Equal reports whether the unread portions of b and other hold the same
+// bytes. It compares them in place, without copying either buffer.
+//
+// Equal 报告 b 和 other 的未读部分是否包含相同的字节。它直接在原处比较，不会复制任何一个
+// 缓冲区。
+func (b *Buffer) Equal(other *Buffer) bool {
+	return Equal(b.buf[b.off:], other.buf[other.off:])
+}
+
+// Compare compares the unread portions of b and other lexicographically,
+// as Compare does for byte slices. The result is 0 if they are equal, -1
+// if b's is less than other's, and +1 if it is greater. It compares them
+// in place, without copying either buffer.
+//
+// Compare 像对字节切片的 Compare 一样，按字典序比较 b 和 other 的未读部分。相等时结果为 0，
+// b 的小于 other 的时结果为 -1，大于时结果为 +1。它直接在原处比较，不会复制任何一个缓冲区。
+func (b *Buffer) Compare(other *Buffer) int {
+	return Compare(b.buf[b.off:], other.buf[other.off:])
+}
+
+// IndexByte returns the index of the first instance of c in the unread
+// portion of the buffer, or -1 if c is not present. The index counts from
+// the start of the unread portion, so it can be passed to Next.
+//
+// IndexByte 返回 c 在缓冲区未读部分中第一次出现的位置，如果不存在则返回 -1。位置从未读部分的
+// 开头算起，所以可以将它传给 Next。
+func (b *Buffer) IndexByte(c byte) int {
+	return IndexByte(b.buf[b.off:], c)
+}
+
+// Index returns the index of the first instance of sep in the unread
+// portion of the buffer, or -1 if sep is not present, counting from the
+// start of the unread portion as IndexByte does.
+//
+// Index 返回 sep 在缓冲区未读部分中第一次出现的位置，如果不存在则返回 -1，位置和 IndexByte
+// 一样从未读部分的开头算起。
+func (b *Buffer) Index(sep []byte) int {
+	return Index(b.buf[b.off:], sep)
+}
+
+// Contains reports whether sep is within the unread portion of the buffer.
+//
+// Contains 报告 sep 是否在缓冲区的未读部分中。
+func (b *Buffer) Contains(sep []byte) bool {
+	return Index(b.buf[b.off:], sep) != -1
+}
+
 // empty returns whether the unread portion of the buffer is empty.
 //
 // empty 检测是否缓冲区未读部分为空。
@@ -185,6 +249,9 @@ func (b *Buffer) grow(n int) int {
 		buf := makeSlice(2*c + n)
 		copy(buf, b.buf[b.off:])
 		b.buf = buf
+		if diagEnabled(diagDebug) {
+			diagLog(diagDebug, "buffer grow", "len", m, "cap", c, "newcap", cap(buf))
+		}
 	}
 	// Restore b.off and len(b.buf).
 	b.off = 0
@@ -217,6 +284,37 @@ func (b *Buffer) Write(p []byte) (n int, err error) {
 	return copy(b.buf[m:], p), nil
 }
 
+// WriteAt implements the io.WriterAt interface. It writes p over the
+// unread portion of the buffer starting at offset off, the offset ReadAt
+// uses, so that a length prefix can be filled in once the body after it
+// has been written. If the write extends past the end of the buffer, the
+// buffer grows, and bytes between the old end and off read as zero. The
+// read offset is left alone. The return value n is the length of p. If the
+// buffer becomes too large, WriteAt will panic with ErrTooLarge.
+//
+// WriteAt 实现了 io.WriterAt 接口。它从缓冲区未读部分的偏移量 off（也就是 ReadAt 使用的
+// 偏移量）处开始用 p 覆盖缓冲区，这样在写入主体之后就可以回填它前面的长度前缀。如果写入超过
+// 了缓冲区的末尾，缓冲区会增长，原来的末尾和 off 之间的字节读出来都是零。读取的位置保持不变。
+// 返回值 n 是 p 的长度。如果缓冲区变得太大，WriteAt 会引发 ErrTooLarge 的 panic。
+func (b *Buffer) WriteAt(p []byte, off int64) (n int, err error) {
+	b.lastRead = opInvalid
+	if off < 0 {
+		return 0, errNegativeWriteOffset
+	}
+	if off > int64(maxInt-len(p)) {
+		panic(ErrTooLarge)
+	}
+	end := int(off) + len(p)
+	if l := b.Len(); end > l {
+		m := b.grow(end - l)
+		// 复用的存储空间中可能还留有旧的数据
+		for i := m; i < b.off+int(off); i++ { // reused storage may hold stale data
+			b.buf[i] = 0
+		}
+	}
+	return copy(b.buf[b.off+int(off):], p), nil
+}
+
 // WriteString appends the contents of s to the buffer, growing the buffer as
 // needed. The return value n is the length of s; err is always nil. If the
 // buffer becomes too large, WriteString will panic with ErrTooLarge.
@@ -354,6 +452,34 @@ func (b *Buffer) Read(p []byte) (n int, err error) {
 	return n, nil
 }
 
+// ReadAt implements the io.ReaderAt interface. It reads len(p) bytes of
+// the unread portion of the buffer starting at offset off, so that
+// b.ReadAt(p, 0) reads what b.Read(p) would. It does not modify the
+// buffer: the read offset and the state UnreadByte and UnreadRune depend
+// on are left alone. When fewer than len(p) bytes are read, err is io.EOF.
+//
+// ReadAt 实现了 io.ReaderAt 接口。它从缓冲区未读部分的偏移量 off 处开始读取 len(p) 个字节，
+// 所以 b.ReadAt(p, 0) 读取的是 b.Read(p) 会读取的内容。它不会修改缓冲区：读取的位置以及
+// UnreadByte 和 UnreadRune 所依赖的状态都保持不变。读取的字节少于 len(p) 时，err 为 io.EOF。
+//
+// IMP: 偏移量相对于未读部分，所以在 Read 之后，同样的 off 读到的是不同的内容。
+func (b *Buffer) ReadAt(p []byte, off int64) (n int, err error) {
+	// cannot modify state - see io.ReaderAt
+	//
+	// 不能修改状态 - 请看 io.ReaderAt
+	if off < 0 {
+		return 0, errNegativeBufferOffset
+	}
+	if off >= int64(b.Len()) {
+		return 0, io.EOF
+	}
+	n = copy(p, b.buf[b.off+int(off):])
+	if n < len(p) {
+		err = io.EOF
+	}
+	return n, err
+}
+
 // Next returns a slice containing the next n bytes from the buffer,
 // advancing the buffer as if the bytes had been returned by Read.
 // If there are fewer than n bytes in the buffer, Next returns the entire buffer.
@@ -416,7 +542,7 @@ func (b *Buffer) ReadRune() (r rune, size int, err error) {
 // from any read operation.)
 func (b *Buffer) UnreadRune() error {
 	if b.lastRead <= opInvalid {
-		return errors.New("bytes.Buffer: UnreadRune: previous operation was not a successful ReadRune")
+		return ErrUnreadRune
 	}
 	if b.off >= int(b.lastRead) {
 		b.off -= int(b.lastRead)
@@ -431,7 +557,7 @@ func (b *Buffer) UnreadRune() error {
 // bytes, UnreadByte returns an error.
 func (b *Buffer) UnreadByte() error {
 	if b.lastRead == opInvalid {
-		return errors.New("bytes.Buffer: UnreadByte: previous operation was not a successful read")
+		return ErrUnreadByte
 	}
 	b.lastRead = opInvalid
 	if b.off > 0 {
@@ -454,6 +580,86 @@ func (b *Buffer) ReadBytes(delim byte) (line []byte, err error) {
 	return line, err
 }
 
+// ReadLine reads the next line of the buffer and returns it without its
+// terminator, which is either "\n" or "\r\n". A last line that is not
+// terminated is returned with io.ErrUnexpectedEOF, so that the caller can
+// tell it from a complete one, and an empty buffer returns nil and io.EOF.
+// Like ReadBytes, it returns a copy of the line.
+//
+// ReadLine 读取缓冲区的下一行，并返回去掉行结束符（"\n" 或者 "\r\n"）之后的内容。没有结束符
+// 的最后一行和 io.ErrUnexpectedEOF 一起返回，这样调用者就可以将它和完整的行区分开，空的
+// 缓冲区返回 nil 和 io.EOF。和 ReadBytes 一样，它返回行的一个副本。
+//
+// NOTE: 单独的 "\r" 不是行结束符，它会留在行中。
+func (b *Buffer) ReadLine() (line []byte, err error) {
+	if b.empty() {
+		b.lastRead = opInvalid
+		return nil, io.EOF
+	}
+	slice, err := b.readSlice('\n')
+	if err != nil {
+		return append(line, slice...), io.ErrUnexpectedEOF
+	}
+	slice = slice[:len(slice)-1]
+	if len(slice) > 0 && slice[len(slice)-1] == '\r' {
+		slice = slice[:len(slice)-1]
+	}
+	return append([]byte{}, slice...), nil
+}
+
+// Lines returns an iterator over the lines of the unread portion of the
+// buffer, without their "\n" or "\r\n" terminators, as ReadLine reads
+// them; a last line that is not terminated is yielded too. With Go 1.23
+// or later it can be used as
+//
+//	for line := range b.Lines() { ... }
+//
+// Each line is consumed before it is yielded, so when the loop stops early
+// the buffer holds the lines after the last one yielded. A yielded slice
+// aliases the buffer content, as Bytes does, and is valid only until the
+// next iteration or buffer modification.
+//
+// Lines 返回一个遍历缓冲区未读部分的各行的迭代器，和 ReadLine 一样，行不包含 "\n" 或者
+// "\r\n" 结束符；没有结束符的最后一行也会被遍历。在 Go 1.23 或者更高的版本中可以像上面
+// 那样使用它。每一行在被遍历之前就已经被读取，所以循环提前结束时，缓冲区中保留的是最后遍历
+// 的那一行之后的各行。和 Bytes 一样，遍历得到的切片是缓冲区内容的别名，只在下一次迭代或者
+// 修改缓冲区之前有效。
+//
+// NOTE: 为了不依赖 iter 包，返回值的类型写作 func(yield func([]byte) bool)，它和
+// iter.Seq[[]byte] 是同一个类型。
+func (b *Buffer) Lines() func(yield func([]byte) bool) {
+	return func(yield func([]byte) bool) {
+		b.Split('\n')(func(line []byte) bool {
+			if len(line) > 0 && line[len(line)-1] == '\r' {
+				line = line[:len(line)-1]
+			}
+			return yield(line)
+		})
+	}
+}
+
+// Split returns an iterator over the records of the unread portion of the
+// buffer that are separated by delim, without the delimiter; a last record
+// that does not end in delim is yielded too. Records are consumed and
+// alias the buffer content as they do for Lines.
+//
+// Split 返回一个遍历缓冲区未读部分中以 delim 分隔的各个记录的迭代器，记录不包含分隔符；不以
+// delim 结尾的最后一个记录也会被遍历。和 Lines 一样，记录在遍历之前被读取，并且是缓冲区
+// 内容的别名。
+func (b *Buffer) Split(delim byte) func(yield func([]byte) bool) {
+	return func(yield func([]byte) bool) {
+		for !b.empty() {
+			record, err := b.readSlice(delim)
+			if err == nil {
+				record = record[:len(record)-1]
+			}
+			if !yield(record) {
+				return
+			}
+		}
+	}
+}
+
 // readSlice is like ReadBytes but returns a reference to internal buffer data.
 func (b *Buffer) readSlice(delim byte) (line []byte, err error) {
 	i := IndexByte(b.buf[b.off:], delim)
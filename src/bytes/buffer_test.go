@@ -5,9 +5,12 @@
 package bytes_test
 
 import (
+	"archive/zip"
 	. "bytes"
 	"io"
+	"io/ioutil"
 	"math/rand"
+	"reflect"
 	"runtime"
 	"testing"
 	"unicode/utf8"
@@ -668,3 +671,229 @@ func BenchmarkBufferFullSmallReads(b *testing.B) {
 		}
 	}
 }
+
+func TestBufferReadAt(t *testing.T) {
+	var b Buffer
+	b.WriteString("xhello, world")
+	if c, _ := b.ReadByte(); c != 'x' {
+		t.Fatalf("ReadByte = %q", c)
+	}
+	tests := []struct {
+		off  int64
+		n    int
+		want string
+		err  error
+	}{
+		{0, 5, "hello", nil},
+		{7, 5, "world", nil},
+		{7, 10, "world", io.EOF},
+		{12, 1, "", io.EOF},
+		{100, 1, "", io.EOF},
+		{3, 0, "", nil},
+	}
+	for _, tt := range tests {
+		p := make([]byte, tt.n)
+		n, err := b.ReadAt(p, tt.off)
+		if got := string(p[:n]); got != tt.want || err != tt.err {
+			t.Errorf("ReadAt(%d bytes, %d) = %q, %v; want %q, %v", tt.n, tt.off, got, err, tt.want, tt.err)
+		}
+	}
+	if _, err := b.ReadAt(make([]byte, 1), -1); err == nil || err.Error() != "bytes.Buffer: ReadAt: negative offset" {
+		t.Errorf("ReadAt at -1: %v", err)
+	}
+	// ReadAt 不改变读取的位置和 UnreadByte 的状态
+	if err := b.UnreadByte(); err != nil { // ReadAt leaves the read offset and the state of UnreadByte alone
+		t.Errorf("UnreadByte after ReadAt: %v", err)
+	}
+	if s := b.String(); s != "xhello, world" {
+		t.Errorf("String() = %q", s)
+	}
+	var _ io.ReaderAt = &b
+}
+
+func TestBufferReadAtZip(t *testing.T) {
+	var b Buffer
+	zw := zip.NewWriter(&b)
+	w, err := zw.Create("hello.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	io.WriteString(w, "hello, world\n")
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	zr, err := zip.NewReader(&b, int64(b.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	rc, err := zr.File[0].Open()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rc.Close()
+	got, err := ioutil.ReadAll(rc)
+	if err != nil || string(got) != "hello, world\n" {
+		t.Errorf("read %q, %v from the zip in a Buffer", got, err)
+	}
+}
+
+func TestBufferWriteAt(t *testing.T) {
+	var b Buffer
+	b.WriteString("xx")
+	b.Next(1)
+	// 先写入长度前缀的占位符，写入主体之后再回填
+	b.WriteString("????") // write a placeholder for the length prefix, and fill it in after the body
+	b.WriteString("payload")
+	if n, err := b.WriteAt([]byte("0007"), 1); n != 4 || err != nil {
+		t.Fatalf("WriteAt = %d, %v", n, err)
+	}
+	if s := b.String(); s != "x0007payload" {
+		t.Errorf("String() = %q; want %q", s, "x0007payload")
+	}
+
+	// 超过末尾的写入会增长缓冲区，中间用零填充
+	b.WriteAt([]byte("end"), 14) // a write past the end grows the buffer, filling the gap with zeros
+	if s := b.String(); s != "x0007payload\x00\x00end" {
+		t.Errorf("String() = %q", s)
+	}
+	if _, err := b.WriteAt([]byte("x"), -1); err == nil || err.Error() != "bytes.Buffer: WriteAt: negative offset" {
+		t.Errorf("WriteAt at -1: %v", err)
+	}
+
+	// 被复用的存储空间中的旧数据不会出现在空隙中
+	b.Reset() // stale data in reused storage does not show in the gap
+	b.WriteAt([]byte("!"), 4)
+	if s := b.String(); s != "\x00\x00\x00\x00!" {
+		t.Errorf("String() = %q after Reset", s)
+	}
+	var _ io.WriterAt = &b
+}
+
+func TestBufferEqualCompare(t *testing.T) {
+	tests := []struct {
+		a, b string
+		cmp  int
+	}{
+		{"", "", 0},
+		{"abc", "abc", 0},
+		{"abc", "abd", -1},
+		{"abd", "abc", 1},
+		{"ab", "abc", -1},
+		{"abc", "", 1},
+	}
+	for _, tt := range tests {
+		// 只比较未读部分
+		a := NewBufferString("?" + tt.a) // only the unread portions are compared
+		a.ReadByte()
+		b := NewBufferString(tt.b)
+		if cmp := a.Compare(b); cmp != tt.cmp {
+			t.Errorf("Compare(%q, %q) = %d; want %d", tt.a, tt.b, cmp, tt.cmp)
+		}
+		if eq := a.Equal(b); eq != (tt.cmp == 0) {
+			t.Errorf("Equal(%q, %q) = %v", tt.a, tt.b, eq)
+		}
+	}
+	var zero Buffer
+	if !zero.Equal(new(Buffer)) || zero.Compare(NewBufferString("a")) != -1 {
+		t.Error("zero Buffer does not compare as empty")
+	}
+	b := NewBufferString("payload")
+	if n := testing.AllocsPerRun(10, func() { b.Equal(b) }); n != 0 {
+		t.Errorf("Equal allocates %v times", n)
+	}
+}
+
+func TestBufferIndex(t *testing.T) {
+	b := NewBufferString("#\r\nheader: 1\r\n\r\nbody")
+	b.Next(3)
+	if i := b.IndexByte('\n'); i != 10 {
+		t.Errorf("IndexByte('\\n') = %d; want 10", i)
+	}
+	if i := b.IndexByte('#'); i != -1 {
+		t.Errorf("IndexByte('#') = %d; want -1 for a byte already read", i)
+	}
+	i := b.Index([]byte("\r\n\r\n"))
+	if i != 9 {
+		t.Fatalf("Index(CRLF CRLF) = %d; want 9", i)
+	}
+	if head := string(b.Next(i)); head != "header: 1" {
+		t.Errorf("Next(%d) = %q", i, head)
+	}
+	if !b.Contains([]byte("body")) || b.Contains([]byte("header")) || !b.Contains(nil) {
+		t.Error("Contains does not search the unread portion only")
+	}
+	if n := testing.AllocsPerRun(10, func() { b.Index([]byte("body")) }); n != 0 {
+		t.Errorf("Index allocates %v times", n)
+	}
+}
+
+func TestBufferReadLine(t *testing.T) {
+	b := NewBufferString("unix\nwindows\r\n\r\n\nbare\rcr\nlast\r")
+	tests := []struct {
+		line string
+		err  error
+	}{
+		{"unix", nil},
+		{"windows", nil},
+		{"", nil},
+		{"", nil},
+		{"bare\rcr", nil},
+		{"last\r", io.ErrUnexpectedEOF},
+		{"", io.EOF},
+	}
+	for i, tt := range tests {
+		line, err := b.ReadLine()
+		if string(line) != tt.line || err != tt.err {
+			t.Errorf("ReadLine #%d = %q, %v; want %q, %v", i, line, err, tt.line, tt.err)
+		}
+	}
+
+	// 返回的是副本，之后的写入不会改变它
+	b = NewBufferString("a\nb\n") // the line is a copy, unchanged by later writes
+	line, _ := b.ReadLine()
+	b.Reset()
+	b.WriteString("zz")
+	if string(line) != "a" {
+		t.Errorf("line = %q after later writes; want a", line)
+	}
+}
+
+// collect gathers the records an iterator yields, stopping after max of
+// them if max is positive.
+func collect(seq func(yield func([]byte) bool), max int) []string {
+	var records []string
+	seq(func(record []byte) bool {
+		records = append(records, string(record))
+		return len(records) != max
+	})
+	return records
+}
+
+func TestBufferLines(t *testing.T) {
+	b := NewBufferString("one\r\ntwo\n\nthree")
+	if got, want := collect(b.Lines(), 0), []string{"one", "two", "", "three"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Lines = %q; want %q", got, want)
+	}
+	if b.Len() != 0 {
+		t.Errorf("Len() = %d after Lines; want 0", b.Len())
+	}
+
+	// 提前结束的循环把剩下的行留在缓冲区中
+	b = NewBufferString("a\nb\nc\n") // a loop that stops early leaves the rest in the buffer
+	if got := collect(b.Lines(), 2); !reflect.DeepEqual(got, []string{"a", "b"}) {
+		t.Errorf("Lines stopped after 2 = %q", got)
+	}
+	if s := b.String(); s != "c\n" {
+		t.Errorf("String() = %q after stopping; want %q", s, "c\n")
+	}
+}
+
+func TestBufferSplit(t *testing.T) {
+	b := NewBufferString("a\x00bc\x00\x00d")
+	if got, want := collect(b.Split(0), 0), []string{"a", "bc", "", "d"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Split(0) = %q; want %q", got, want)
+	}
+	if got := collect(new(Buffer).Split(','), 0); got != nil {
+		t.Errorf("Split on an empty buffer = %q", got)
+	}
+}
@@ -0,0 +1,47 @@
+// Copyright 2018 Li Zebang. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+// +build !go1.12
+
+package bytes
+
+import "internal/bytealg"
+
+// When this tree is built as a GOROOT, its own Go 1.11 toolchain does not
+// satisfy the go1.12 release tag and bytes keeps using the assembly in
+// internal/bytealg. Any newer toolchain builds bytes as a module instead,
+// where internal/bytealg cannot be imported, and gets the portable versions
+// in bytealg_portable.go.
+//
+// 把本仓库当作 GOROOT 构建时，它自己的 Go 1.11 工具链不满足 go1.12 这个发布标签，bytes
+// 会继续使用 internal/bytealg 中的汇编实现。任何更新的工具链都会把 bytes 当作一个模块来
+// 构建，此时无法导入 internal/bytealg，使用的是 bytealg_portable.go 中的可移植版本。
+
+// maxLen is the maximum length of sep for which indexBytes can be used.
+//
+// maxLen 是可以使用 indexBytes 的 sep 的最大长度。
+var maxLen = bytealg.MaxLen
+
+// maxBruteForce is the length of s up to which indexBytes is used directly.
+//
+// maxBruteForce 是直接使用 indexBytes 的 s 的最大长度。
+const maxBruteForce = bytealg.MaxBruteForce
+
+// countByte counts the instances of c in b.
+//
+// countByte 计算 b 中 c 的个数。
+func countByte(b []byte, c byte) int { return bytealg.Count(b, c) }
+
+// indexBytes returns the index of the first instance of sep in s, or -1.
+// It requires 2 <= len(sep) <= maxLen.
+//
+// indexBytes 返回 s 中第一个 sep 的索引，或者 -1。
+// 它要求 2 <= len(sep) <= maxLen。
+func indexBytes(s, sep []byte) int { return bytealg.Index(s, sep) }
+
+// cutover reports the number of failures of IndexByte after which Index
+// switches to indexBytes, having found n matching bytes.
+//
+// cutover 返回在已经检查了 n 个字节时，IndexByte 失败多少次之后 Index 改用 indexBytes。
+func cutover(n int) int { return bytealg.Cutover(n) }
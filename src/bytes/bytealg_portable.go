@@ -0,0 +1,100 @@
+// Copyright 2018 Li Zebang. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+// +build go1.12
+
+package bytes
+
+// Portable versions of the functions that bytealg.go and bytes_decl.go take
+// from internal/bytealg, used when bytes is built as a module.
+//
+// bytealg.go 和 bytes_decl.go 从 internal/bytealg 中获取的函数的可移植版本，在 bytes
+// 被当作模块构建时使用。
+//
+// IMP: maxLen 为 0 时 Index 永远不会走 indexBytes 的分支，这和 internal/bytealg 在没有
+// 汇编实现的平台上（index_generic.go）的行为相同。
+
+// maxLen is the maximum length of sep for which indexBytes can be used.
+//
+// maxLen 是可以使用 indexBytes 的 sep 的最大长度。
+var maxLen int
+
+// maxBruteForce is the length of s up to which indexBytes is used directly.
+//
+// maxBruteForce 是直接使用 indexBytes 的 s 的最大长度。
+const maxBruteForce = 0
+
+// countByte counts the instances of c in b.
+//
+// countByte 计算 b 中 c 的个数。
+func countByte(b []byte, c byte) int {
+	n := 0
+	for _, x := range b {
+		if x == c {
+			n++
+		}
+	}
+	return n
+}
+
+func indexBytes(s, sep []byte) int {
+	panic("unimplemented")
+}
+
+func cutover(n int) int {
+	panic("unimplemented")
+}
+
+// IndexByte returns the index of the first instance of c in b, or -1 if c is not present in b.
+//
+// IndexByte 返回 b 中第一个 c 的索引，如果 b 中没有 c 则返回 -1。
+func IndexByte(b []byte, c byte) int {
+	for i, x := range b {
+		if x == c {
+			return i
+		}
+	}
+	return -1
+}
+
+// Equal returns a boolean reporting whether a and b
+// are the same length and contain the same bytes.
+// A nil argument is equivalent to an empty slice.
+//
+// Equal 返回一个布尔值，报告 a 和 b 的长度是否相同并且包含相同的字节。
+// nil 参数等价于空切片。
+//
+// IMP: 编译器会识别比较时的 string(a) 转换，不会分配内存。
+func Equal(a, b []byte) bool {
+	return string(a) == string(b)
+}
+
+// Compare returns an integer comparing two byte slices lexicographically.
+// The result will be 0 if a==b, -1 if a < b, and +1 if a > b.
+// A nil argument is equivalent to an empty slice.
+//
+// Compare 返回一个按字典序比较两个字节切片的整数。
+// 如果 a==b 结果为 0，如果 a < b 结果为 -1，如果 a > b 结果为 +1。
+// nil 参数等价于空切片。
+func Compare(a, b []byte) int {
+	l := len(a)
+	if len(b) < l {
+		l = len(b)
+	}
+	for i := 0; i < l; i++ {
+		switch c1, c2 := a[i], b[i]; {
+		case c1 < c2:
+			return -1
+		case c1 > c2:
+			return +1
+		}
+	}
+	switch {
+	case len(a) < len(b):
+		return -1
+	case len(a) > len(b):
+		return +1
+	}
+	return 0
+}
@@ -7,7 +7,6 @@
 package bytes
 
 import (
-	"internal/bytealg"
 	"unicode"
 	"unicode/utf8"
 )
@@ -55,7 +54,7 @@ func Count(s, sep []byte) int {
 		return utf8.RuneCount(s) + 1
 	}
 	if len(sep) == 1 {
-		return bytealg.Count(s, sep[0])
+		return countByte(s, sep[0])
 	}
 	n := 0
 	for {
@@ -844,10 +843,10 @@ func Index(s, sep []byte) int {
 		return -1
 	case n > len(s):
 		return -1
-	case n <= bytealg.MaxLen:
+	case n <= maxLen:
 		// Use brute force when s and sep both are small
-		if len(s) <= bytealg.MaxBruteForce {
-			return bytealg.Index(s, sep)
+		if len(s) <= maxBruteForce {
+			return indexBytes(s, sep)
 		}
 		c := sep[0]
 		i := 0
@@ -869,8 +868,8 @@ func Index(s, sep []byte) int {
 			fails++
 			i++
 			// Switch to bytealg.Index when IndexByte produces too many false positives.
-			if fails > bytealg.Cutover(i) {
-				r := bytealg.Index(s[i:], sep)
+			if fails > cutover(i) {
+				r := indexBytes(s[i:], sep)
 				if r >= 0 {
 					return r + i
 				}
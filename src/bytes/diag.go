@@ -0,0 +1,32 @@
+// Copyright 2018 Li Zebang. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+// +build !go1.12
+
+package bytes
+
+import "diag"
+
+// When this tree is built as a GOROOT, diag is a standard package. A module
+// build imports it by its module path in diag_portable.go instead.
+//
+// 把本仓库当作 GOROOT 构建时，diag 是一个标准库包。模块构建则在 diag_portable.go 中通过
+// 它的模块路径导入它。
+
+const (
+	diagDebug = diag.Debug
+	diagWarn  = diag.Warn
+)
+
+// diagEnabled reports whether the diagnostics of bytes at level are wanted.
+//
+// diagEnabled 报告是否需要 bytes 中 level 级别的诊断信息。
+func diagEnabled(level diag.Level) bool { return diag.Enabled("bytes", level) }
+
+// diagLog reports an event of bytes.
+//
+// diagLog 报告 bytes 中的一个事件。
+func diagLog(level diag.Level, msg string, keyvals ...interface{}) {
+	diag.Log("bytes", level, msg, keyvals...)
+}
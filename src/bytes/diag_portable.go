@@ -0,0 +1,30 @@
+// Copyright 2018 Li Zebang. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+// +build go1.12
+
+package bytes
+
+import "github.com/lizebang/annotate-go-sdk/src/diag"
+
+// The module build of diag.go, which imports diag by its module path.
+//
+// diag.go 的模块构建版本，它通过模块路径导入 diag。
+
+const (
+	diagDebug = diag.Debug
+	diagWarn  = diag.Warn
+)
+
+// diagEnabled reports whether the diagnostics of bytes at level are wanted.
+//
+// diagEnabled 报告是否需要 bytes 中 level 级别的诊断信息。
+func diagEnabled(level diag.Level) bool { return diag.Enabled("bytes", level) }
+
+// diagLog reports an event of bytes.
+//
+// diagLog 报告 bytes 中的一个事件。
+func diagLog(level diag.Level, msg string, keyvals ...interface{}) {
+	diag.Log("bytes", level, msg, keyvals...)
+}
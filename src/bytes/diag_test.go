@@ -0,0 +1,42 @@
+// Copyright 2018 Li Zebang. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package bytes_test
+
+import (
+	. "bytes"
+	"diag"
+	"fmt"
+	"testing"
+)
+
+type growLogger struct {
+	events []string
+}
+
+func (l *growLogger) Enabled(pkg string, level diag.Level) bool {
+	return pkg == "bytes"
+}
+
+func (l *growLogger) Log(pkg string, level diag.Level, msg string, keyvals ...interface{}) {
+	l.events = append(l.events, fmt.Sprint(msg, " ", keyvals))
+}
+
+func TestBufferGrowDiag(t *testing.T) {
+	l := new(growLogger)
+	diag.SetLogger(l)
+	defer diag.SetLogger(nil)
+
+	var b Buffer
+	// The bootstrap array holds the first 64 bytes without a report.
+	b.Write(make([]byte, 64))
+	if len(l.events) != 0 {
+		t.Fatalf("events = %q; want none", l.events)
+	}
+	b.Write(make([]byte, 10))
+	want := []string{"buffer grow [len 64 cap 64 newcap 138]"}
+	if fmt.Sprint(l.events) != fmt.Sprint(want) {
+		t.Errorf("events = %q; want %q", l.events, want)
+	}
+}
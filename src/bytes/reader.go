@@ -10,6 +10,15 @@ import (
 	"unicode/utf8"
 )
 
+// IMP: 使用包级别的错误变量而不是每次调用 errors.New，这样出错时不会分配内存。
+var (
+	errNegativeOffset   = errors.New("bytes.Reader.ReadAt: negative offset")
+	errAtBeginning      = errors.New("bytes.Reader.UnreadByte: at beginning of slice")
+	errUnreadRune       = errors.New("bytes.Reader.UnreadRune: previous operation was not ReadRune")
+	errInvalidWhence    = errors.New("bytes.Reader.Seek: invalid whence")
+	errNegativePosition = errors.New("bytes.Reader.Seek: negative position")
+)
+
 // A Reader implements the io.Reader, io.ReaderAt, io.WriterTo, io.Seeker,
 // io.ByteScanner, and io.RuneScanner interfaces by reading from
 // a byte slice.
@@ -50,7 +59,7 @@ func (r *Reader) Read(b []byte) (n int, err error) {
 func (r *Reader) ReadAt(b []byte, off int64) (n int, err error) {
 	// cannot modify state - see io.ReaderAt
 	if off < 0 {
-		return 0, errors.New("bytes.Reader.ReadAt: negative offset")
+		return 0, errNegativeOffset
 	}
 	if off >= int64(len(r.s)) {
 		return 0, io.EOF
@@ -77,7 +86,7 @@ func (r *Reader) ReadByte() (byte, error) {
 func (r *Reader) UnreadByte() error {
 	r.prevRune = -1
 	if r.i <= 0 {
-		return errors.New("bytes.Reader.UnreadByte: at beginning of slice")
+		return errAtBeginning
 	}
 	r.i--
 	return nil
@@ -102,7 +111,7 @@ func (r *Reader) ReadRune() (ch rune, size int, err error) {
 // UnreadRune complements ReadRune in implementing the io.RuneScanner interface.
 func (r *Reader) UnreadRune() error {
 	if r.prevRune < 0 {
-		return errors.New("bytes.Reader.UnreadRune: previous operation was not ReadRune")
+		return errUnreadRune
 	}
 	r.i = int64(r.prevRune)
 	r.prevRune = -1
@@ -121,10 +130,10 @@ func (r *Reader) Seek(offset int64, whence int) (int64, error) {
 	case io.SeekEnd:
 		abs = int64(len(r.s)) + offset
 	default:
-		return 0, errors.New("bytes.Reader.Seek: invalid whence")
+		return 0, errInvalidWhence
 	}
 	if abs < 0 {
-		return 0, errors.New("bytes.Reader.Seek: negative position")
+		return 0, errNegativePosition
 	}
 	r.i = abs
 	return abs, nil
@@ -0,0 +1,132 @@
+// Copyright 2018 Li Zebang. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package bytes
+
+import "sync"
+
+// A SyncBuffer is a Buffer guarded by a mutex, so that one goroutine can
+// write to it while another reads from it. Every method holds the lock for
+// the whole operation, including the state UnreadByte depends on, and
+// Bytes returns a copy, so that a result is never torn by a concurrent
+// write. The zero value is an empty buffer ready to use. A SyncBuffer must
+// not be copied after first use.
+//
+// SyncBuffer 是一个由互斥锁保护的 Buffer，这样一个 goroutine 可以在另一个 goroutine 读取
+// 它的同时向它写入。每个方法在整个操作期间都持有锁，包括 UnreadByte 所依赖的状态，并且 Bytes
+// 返回的是一个副本，所以结果永远不会被并发的写入撕裂。零值是一个可以使用的空缓冲区。SyncBuffer
+// 在第一次使用之后不能被复制。
+//
+// IMP: Read 在缓冲区为空时返回 io.EOF 而不是等待写入。
+type SyncBuffer struct {
+	mu  sync.Mutex
+	buf Buffer
+}
+
+// Write appends the contents of p to the buffer, as Buffer.Write does.
+//
+// Write 像 Buffer.Write 一样将 p 的内容追加到缓冲区中。
+func (b *SyncBuffer) Write(p []byte) (n int, err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+// WriteString appends the contents of s to the buffer, as
+// Buffer.WriteString does.
+//
+// WriteString 像 Buffer.WriteString 一样将 s 的内容追加到缓冲区中。
+func (b *SyncBuffer) WriteString(s string) (n int, err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.WriteString(s)
+}
+
+// WriteByte appends the byte c to the buffer, as Buffer.WriteByte does.
+//
+// WriteByte 像 Buffer.WriteByte 一样将字节 c 追加到缓冲区中。
+func (b *SyncBuffer) WriteByte(c byte) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.WriteByte(c)
+}
+
+// Read reads the next len(p) bytes from the buffer, as Buffer.Read does.
+//
+// Read 像 Buffer.Read 一样从缓冲区中读取接下来的 len(p) 个字节。
+func (b *SyncBuffer) Read(p []byte) (n int, err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Read(p)
+}
+
+// ReadByte reads and returns the next byte from the buffer, as
+// Buffer.ReadByte does.
+//
+// ReadByte 像 Buffer.ReadByte 一样从缓冲区中读取并返回下一个字节。
+func (b *SyncBuffer) ReadByte() (byte, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.ReadByte()
+}
+
+// UnreadByte unreads the last byte returned by the most recent successful
+// read operation, as Buffer.UnreadByte does.
+//
+// UnreadByte 像 Buffer.UnreadByte 一样撤销最近一次成功的读操作返回的最后一个字节。
+func (b *SyncBuffer) UnreadByte() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.UnreadByte()
+}
+
+// ReadLine reads the next line of the buffer, as Buffer.ReadLine does.
+//
+// ReadLine 像 Buffer.ReadLine 一样读取缓冲区的下一行。
+func (b *SyncBuffer) ReadLine() (line []byte, err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.ReadLine()
+}
+
+// Len returns the number of bytes of the unread portion of the buffer.
+//
+// Len 返回缓冲区未读部分的字节数。
+func (b *SyncBuffer) Len() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Len()
+}
+
+// Bytes returns a copy of the unread portion of the buffer.
+//
+// Bytes 返回缓冲区未读部分的一个副本。
+func (b *SyncBuffer) Bytes() []byte {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return append([]byte(nil), b.buf.Bytes()...)
+}
+
+// String returns the contents of the unread portion of the buffer as a
+// string. If the SyncBuffer is a nil pointer, it returns "<nil>".
+//
+// String 以字符串的形式返回缓冲区未读部分的内容。如果 SyncBuffer 是一个 nil 指针，它返回
+// "<nil>"。
+func (b *SyncBuffer) String() string {
+	if b == nil {
+		return "<nil>"
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+// Reset resets the buffer to be empty, as Buffer.Reset does.
+//
+// Reset 像 Buffer.Reset 一样将缓冲区重置为空。
+func (b *SyncBuffer) Reset() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.buf.Reset()
+}
@@ -0,0 +1,73 @@
+// Copyright 2018 Li Zebang. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package bytes_test
+
+import (
+	. "bytes"
+	"io"
+	"sync"
+	"testing"
+)
+
+func TestSyncBuffer(t *testing.T) {
+	var b SyncBuffer
+	const n = 1000
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < n; i++ {
+			b.WriteString("0123456789")
+		}
+	}()
+
+	// 和写入并发地读取，直到读完所有数据
+	var got []byte // read concurrently with the writes until all the data has been read
+	p := make([]byte, 7)
+	for len(got) < n*10 {
+		m, err := b.Read(p)
+		if err != nil && err != io.EOF {
+			t.Fatal(err)
+		}
+		got = append(got, p[:m]...)
+	}
+	wg.Wait()
+	for i, c := range got {
+		if c != '0'+byte(i%10) {
+			t.Fatalf("byte %d = %q; want %q", i, c, '0'+byte(i%10))
+		}
+	}
+	if b.Len() != 0 || b.String() != "" {
+		t.Errorf("Len() = %d, String() = %q after reading everything", b.Len(), b.String())
+	}
+}
+
+func TestSyncBufferMethods(t *testing.T) {
+	var b SyncBuffer
+	b.WriteString("ab\r\n")
+	b.WriteByte('c')
+	p := b.Bytes()
+	p[0] = 'x'
+	if s := b.String(); s != "ab\r\nc" {
+		t.Errorf("String() = %q; Bytes must return a copy", s)
+	}
+	if c, err := b.ReadByte(); c != 'a' || err != nil {
+		t.Errorf("ReadByte = %q, %v", c, err)
+	}
+	if err := b.UnreadByte(); err != nil {
+		t.Errorf("UnreadByte = %v", err)
+	}
+	if line, err := b.ReadLine(); string(line) != "ab" || err != nil {
+		t.Errorf("ReadLine = %q, %v", line, err)
+	}
+	b.Reset()
+	if b.Len() != 0 {
+		t.Errorf("Len() = %d after Reset", b.Len())
+	}
+	var nilBuf *SyncBuffer
+	if s := nilBuf.String(); s != "<nil>" {
+		t.Errorf("nil String() = %q", s)
+	}
+}
@@ -0,0 +1,129 @@
+// Copyright 2018 Li Zebang. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+)
+
+// parseBench returns the ns/op values of every benchmark in the output of
+// go test, keyed by pkg.BenchmarkName.
+//
+// parseBench 返回 go test 输出中每个基准测试的 ns/op 值，键为 pkg.BenchmarkName。
+//
+// IMP: 结果行的格式为
+//
+//	BenchmarkReadString-8   	 2000000	       763 ns/op	  98.27 MB/s
+//
+// 名字之后是迭代次数，之后是成对出现的值和单位。
+func parseBench(pkg string, out []byte) map[string][]float64 {
+	results := make(map[string][]float64)
+	s := bufio.NewScanner(bytes.NewReader(out))
+	for s.Scan() {
+		fields := strings.Fields(s.Text())
+		if len(fields) < 4 || !strings.HasPrefix(fields[0], "Benchmark") {
+			continue
+		}
+		for i := 2; i+1 < len(fields); i += 2 {
+			if fields[i+1] != "ns/op" {
+				continue
+			}
+			if ns, err := strconv.ParseFloat(fields[i], 64); err == nil {
+				name := pkg + "." + fields[0]
+				results[name] = append(results[name], ns)
+			}
+		}
+	}
+	return results
+}
+
+// A comparison is the result of one benchmark in both runs.
+// A zero value means the benchmark did not run.
+//
+// comparison 是一个基准测试在两次运行中的结果。
+// 零值表示该基准测试没有运行。
+type comparison struct {
+	name        string
+	base, annot float64
+}
+
+// delta returns the change from base to annot in percent.
+//
+// delta 返回从 base 到 annot 的变化百分比。
+func (c comparison) delta() float64 {
+	return (c.annot - c.base) / c.base * 100
+}
+
+// compare pairs up the results of both runs by benchmark name.
+//
+// compare 按基准测试的名字将两次运行的结果配对。
+func compare(base, annot map[string][]float64) []comparison {
+	names := make(map[string]bool)
+	for name := range base {
+		names[name] = true
+	}
+	for name := range annot {
+		names[name] = true
+	}
+	var cs []comparison
+	for name := range names {
+		cs = append(cs, comparison{name, median(base[name]), median(annot[name])})
+	}
+	sort.Slice(cs, func(i, j int) bool { return cs[i].name < cs[j].name })
+	return cs
+}
+
+// median returns the median of values, or 0 if there are none.
+// The median is less sensitive to an occasional slow run than the mean.
+//
+// median 返回 values 的中位数，如果没有值则返回 0。
+// 与平均值相比，中位数受偶尔一次较慢的运行的影响更小。
+func median(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	v := append([]float64(nil), values...)
+	sort.Float64s(v)
+	if n := len(v); n%2 == 0 {
+		return (v[n/2-1] + v[n/2]) / 2
+	}
+	return v[len(v)/2]
+}
+
+// report writes the comparison table to w. It reports whether no benchmark
+// slowed down by more than threshold percent; a threshold <= 0 disables the
+// check.
+//
+// report 将比较表格写入 w。它报告是否没有基准测试变慢超过 threshold 百分比；
+// threshold <= 0 时不做检查。
+func report(w io.Writer, cs []comparison, threshold float64) bool {
+	ok := true
+	tw := tabwriter.NewWriter(w, 0, 8, 2, ' ', 0)
+	fmt.Fprintf(tw, "name\tbase ns/op\tannotated ns/op\tdelta\n")
+	for _, c := range cs {
+		switch {
+		case c.base == 0:
+			fmt.Fprintf(tw, "%s\t-\t%.1f\t\n", c.name, c.annot)
+		case c.annot == 0:
+			fmt.Fprintf(tw, "%s\t%.1f\t-\t\n", c.name, c.base)
+		default:
+			mark := ""
+			if threshold > 0 && c.delta() > threshold {
+				mark = " !"
+				ok = false
+			}
+			fmt.Fprintf(tw, "%s\t%.1f\t%.1f\t%+.2f%%%s\n", c.name, c.base, c.annot, c.delta(), mark)
+		}
+	}
+	tw.Flush()
+	return ok
+}
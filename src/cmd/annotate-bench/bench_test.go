@@ -0,0 +1,73 @@
+// Copyright 2018 Li Zebang. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const baseOutput = `goos: linux
+goarch: amd64
+pkg: bytes
+BenchmarkReadString-8   	 2000000	       800 ns/op	  98.27 MB/s
+BenchmarkReadString-8   	 2000000	       600 ns/op	  98.27 MB/s
+BenchmarkReadString-8   	 2000000	       700 ns/op	  98.27 MB/s
+BenchmarkGone-8         	 1000000	      1000 ns/op
+PASS
+ok  	bytes	4.321s
+`
+
+const annotOutput = `pkg: bytes
+BenchmarkReadString-8   	 2000000	       770 ns/op	  98.27 MB/s	      64 B/op	       1 allocs/op
+BenchmarkNew-8          	 1000000	      1000 ns/op
+PASS
+`
+
+func TestParseBench(t *testing.T) {
+	got := parseBench("bytes", []byte(baseOutput))
+	want := map[string][]float64{
+		"bytes.BenchmarkReadString-8": {800, 600, 700},
+		"bytes.BenchmarkGone-8":       {1000},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("parseBench = %v; want %v", got, want)
+	}
+}
+
+func TestMedian(t *testing.T) {
+	for _, tt := range []struct {
+		values []float64
+		want   float64
+	}{
+		{nil, 0},
+		{[]float64{3}, 3},
+		{[]float64{3, 1, 2}, 2},
+		{[]float64{4, 1, 2, 3}, 2.5},
+	} {
+		if got := median(tt.values); got != tt.want {
+			t.Errorf("median(%v) = %v; want %v", tt.values, got, tt.want)
+		}
+	}
+}
+
+func TestReport(t *testing.T) {
+	cs := compare(parseBench("bytes", []byte(baseOutput)), parseBench("bytes", []byte(annotOutput)))
+	var buf bytes.Buffer
+	if report(&buf, cs, 5) {
+		t.Errorf("report with threshold 5%% passed a 10%% slowdown")
+	}
+	out := buf.String()
+	for _, want := range []string{"+10.00% !", "bytes.BenchmarkGone-8  1000.0  -", "bytes.BenchmarkNew-8  -  1000.0"} {
+		if !strings.Contains(strings.Join(strings.Fields(out), "  "), strings.Join(strings.Fields(want), "  ")) {
+			t.Errorf("report output does not contain %q:\n%s", want, out)
+		}
+	}
+	if !report(&buf, cs, 0) {
+		t.Errorf("report with threshold 0 failed")
+	}
+}
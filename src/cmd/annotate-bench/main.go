@@ -0,0 +1,108 @@
+// Copyright 2018 Li Zebang. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+// Annotate-bench runs the same benchmarks against the annotated sources and
+// against a released Go toolchain and prints a comparison report.
+//
+// Usage:
+//
+//	annotate-bench [-base go] [-go go] [-bench regexp] [-count n] [-threshold pct] [packages]
+//
+// The annotated packages keep their upstream import paths, so they cannot be
+// linked into one binary together with the standard library they replace.
+// Instead the benchmarks of each package are run twice: once by the -base
+// go command, which uses its own standard library, and once by the -go
+// command built from this repository (see "Tests" in README.md). The base
+// should be the release recorded in GO-VERSION: the upstream test files are
+// kept unchanged in this tree, so both runs execute the same workloads and
+// only the code under test differs. Benchmarks that exist in only one of
+// the runs are listed without a comparison. The packages default to flag,
+// bytes and sync.
+//
+// For every benchmark the report shows the median ns/op of both runs and
+// the change. The exit status is 1 if a benchmark got slower by more than
+// -threshold percent.
+//
+// Annotate-bench 分别使用注释过的源码和一个已发布的 Go 工具链运行相同的基准测试，并输出
+// 一份比较报告。
+//
+// 用法：
+//
+//	annotate-bench [-base go] [-go go] [-bench regexp] [-count n] [-threshold pct] [packages]
+//
+// 注释过的包保留了上游的导入路径，所以它们不能和被替代的标准库链接到同一个二进制文件中。
+// 因此每个包的基准测试会运行两次：一次由 -base 指定的 go 命令运行，它使用自己的标准库；
+// 另一次由本仓库构建出的 -go 命令运行（参见 README.md 中的 "Tests"）。base 应该是
+// GO-VERSION 中记录的发布版本：本仓库保留了上游的测试文件，所以两次运行执行的是相同的
+// 工作负载，唯一的区别就是被测试的代码。只在其中一次运行中存在的基准测试会被列出，但不做
+// 比较。packages 默认是 flag、bytes 和 sync。
+//
+// 对每个基准测试，报告会显示两次运行的 ns/op 中位数以及变化。如果某个基准测试变慢超过了
+// -threshold 百分比，退出状态为 1。
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/exec"
+	"path/filepath"
+)
+
+var (
+	baseGo    = flag.String("base", "go", "go `command` of the released toolchain")
+	annotGo   = flag.String("go", filepath.Join("bin", "go"), "go `command` built from the annotated tree")
+	bench     = flag.String("bench", ".", "run only benchmarks matching `regexp`")
+	count     = flag.Int("count", 5, "run each benchmark `n` times")
+	threshold = flag.Float64("threshold", 10, "report failure if a benchmark slows down by more than `pct` percent")
+)
+
+func usage() {
+	fmt.Fprintf(os.Stderr, "usage: annotate-bench [-base go] [-go go] [-bench regexp] [-count n] [-threshold pct] [packages]\n")
+	flag.PrintDefaults()
+	os.Exit(2)
+}
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("annotate-bench: ")
+	flag.Usage = usage
+	flag.Parse()
+
+	pkgs := flag.Args()
+	if len(pkgs) == 0 {
+		pkgs = []string{"flag", "bytes", "sync"}
+	}
+	base, err := run(*baseGo, pkgs)
+	if err != nil {
+		log.Fatal(err)
+	}
+	annot, err := run(*annotGo, pkgs)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if !report(os.Stdout, compare(base, annot), *threshold) {
+		os.Exit(1)
+	}
+}
+
+// run runs the benchmarks of pkgs with the go command and parses its output.
+//
+// run 使用 go 命令运行 pkgs 的基准测试并解析输出。
+func run(goCmd string, pkgs []string) (map[string][]float64, error) {
+	results := make(map[string][]float64)
+	for _, pkg := range pkgs {
+		cmd := exec.Command(goCmd, "test", "-run=NONE", "-bench="+*bench, fmt.Sprintf("-count=%d", *count), pkg)
+		cmd.Stderr = os.Stderr
+		out, err := cmd.Output()
+		if err != nil {
+			return nil, fmt.Errorf("%s test %s: %v", goCmd, pkg, err)
+		}
+		for name, ns := range parseBench(pkg, out) {
+			results[name] = append(results[name], ns...)
+		}
+	}
+	return results, nil
+}
@@ -0,0 +1,370 @@
+// Copyright 2018 Li Zebang. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/scanner"
+	"go/token"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// listPackages returns the import paths of the packages under src that
+// match patterns. With no patterns it returns every package outside cmd.
+//
+// listPackages 返回 src 下匹配 patterns 的包的导入路径。
+// 没有 patterns 时，它返回 cmd 之外的所有包。
+func listPackages(src string, patterns []string) ([]string, error) {
+	var pkgs []string
+	err := filepath.Walk(src, func(dir string, fi os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !fi.IsDir() || dir == src {
+			return nil
+		}
+		name := fi.Name()
+		if name == "testdata" || name == "vendor" || strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_") {
+			return filepath.SkipDir
+		}
+		rel, err := filepath.Rel(src, dir)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		if len(patterns) == 0 && rel == "cmd" {
+			return filepath.SkipDir
+		}
+		if !matchPatterns(patterns, rel) {
+			return nil
+		}
+		files, err := goFiles(dir)
+		if err != nil {
+			return err
+		}
+		if len(files) > 0 {
+			pkgs = append(pkgs, rel)
+		}
+		return nil
+	})
+	return pkgs, err
+}
+
+// matchPatterns reports whether the import path matches one of the patterns.
+// An empty pattern list matches everything.
+//
+// matchPatterns 报告导入路径是否匹配 patterns 中的某一个。
+// 空的 patterns 匹配所有路径。
+func matchPatterns(patterns []string, importPath string) bool {
+	if len(patterns) == 0 {
+		return true
+	}
+	for _, pat := range patterns {
+		if pat == "..." || pat == importPath {
+			return true
+		}
+		if prefix := strings.TrimSuffix(pat, "/..."); prefix != pat {
+			if importPath == prefix || strings.HasPrefix(importPath, prefix+"/") {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// goFiles returns the names of the non-test Go files in dir.
+// Build constraints are ignored, every file is compared.
+//
+// goFiles 返回 dir 中非测试的 Go 文件的名字。
+// 构建约束会被忽略，每个文件都会被比较。
+func goFiles(dir string) ([]string, error) {
+	fis, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	for _, fi := range fis {
+		name := fi.Name()
+		if fi.Mode().IsRegular() && strings.HasSuffix(name, ".go") && !strings.HasSuffix(name, "_test.go") {
+			names = append(names, name)
+		}
+	}
+	return names, nil
+}
+
+// A decl is a top-level declaration reduced to its tokens.
+//
+// decl 是一个被简化为其 token 的顶层声明。
+type decl struct {
+	name string
+	file string
+	line int
+	// code holds the tokens of the declaration without comments.
+	//
+	// code 保存了声明中除注释之外的 token。
+	code []string
+}
+
+// parseDecls returns the top-level declarations of the Go files in dir.
+// A const, var or type group yields one decl per spec. Imports are skipped.
+//
+// parseDecls 返回 dir 中 Go 文件的顶层声明。
+// const、var 或 type 组中的每个 spec 都会产生一个 decl。import 会被跳过。
+func parseDecls(dir string) ([]*decl, error) {
+	names, err := goFiles(dir)
+	if err != nil {
+		return nil, err
+	}
+	var decls []*decl
+	for _, name := range names {
+		src, err := ioutil.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			return nil, err
+		}
+		fset := token.NewFileSet()
+		f, err := parser.ParseFile(fset, name, src, 0)
+		if err != nil {
+			return nil, err
+		}
+		toks := scan(fset.File(f.Pos()), src)
+		seen := make(map[string]int)
+		add := func(id string, node ast.Node) {
+			// init functions and blank identifiers may be declared many times.
+			//
+			// init 函数和空白标识符可以被声明多次。
+			seen[id]++
+			if n := seen[id]; n > 1 {
+				id = fmt.Sprintf("%s#%d", id, n)
+			}
+			decls = append(decls, &decl{
+				name: id,
+				file: name,
+				line: fset.Position(node.Pos()).Line,
+				code: toks.between(node.Pos(), node.End()),
+			})
+		}
+		for _, d := range f.Decls {
+			switch d := d.(type) {
+			case *ast.FuncDecl:
+				add(funcName(d), d)
+			case *ast.GenDecl:
+				if d.Tok == token.IMPORT {
+					continue
+				}
+				for _, spec := range d.Specs {
+					switch s := spec.(type) {
+					case *ast.TypeSpec:
+						add(s.Name.Name, s)
+					case *ast.ValueSpec:
+						var names []string
+						for _, n := range s.Names {
+							names = append(names, n.Name)
+						}
+						add(strings.Join(names, ", "), s)
+					}
+				}
+			}
+		}
+	}
+	return decls, nil
+}
+
+// funcName returns Name for a function and Type.Name for a method.
+//
+// funcName 对函数返回 Name，对方法返回 Type.Name。
+func funcName(d *ast.FuncDecl) string {
+	if d.Recv == nil || len(d.Recv.List) == 0 {
+		return d.Name.Name
+	}
+	typ := d.Recv.List[0].Type
+	for {
+		switch t := typ.(type) {
+		case *ast.StarExpr:
+			typ = t.X
+			continue
+		case *ast.ParenExpr:
+			typ = t.X
+			continue
+		case *ast.IndexExpr:
+			// A method of a generic type in newer releases.
+			//
+			// 较新的发布版本中泛型类型的方法。
+			typ = t.X
+			continue
+		case *ast.Ident:
+			return t.Name + "." + d.Name.Name
+		}
+		return d.Name.Name
+	}
+}
+
+// tokens are the tokens of a file together with their positions.
+//
+// tokens 是一个文件的 token 以及它们的位置。
+type tokens struct {
+	pos  []token.Pos
+	text []string
+}
+
+// scan returns the tokens of src, skipping comments. Automatic semicolons
+// are written as ";" so that they equal explicit ones, and a semicolon
+// before a closing ")" or "}" is dropped, so that
+//
+//	func f() { return }
+//
+// and the same function on three lines have the same tokens.
+//
+// scan 返回 src 的 token，并跳过注释。自动插入的分号被写作 ";"，这样它们就和显式的分号
+// 相同了。")" 或 "}" 之前的分号会被丢弃，这样
+//
+//	func f() { return }
+//
+// 和写成三行的同一个函数有着相同的 token。
+//
+// IMP: 不能比较 go/printer 的输出，因为注释会改变行号，而 printer 会根据行号保留空行，
+// 只增加了注释的代码打印出来也可能不同。
+func scan(file *token.File, src []byte) *tokens {
+	var s scanner.Scanner
+	s.Init(file, src, nil, 0)
+	toks := new(tokens)
+	for {
+		pos, tok, lit := s.Scan()
+		if tok == token.EOF {
+			return toks
+		}
+		n := len(toks.text)
+		if (tok == token.RPAREN || tok == token.RBRACE) && n > 0 && toks.text[n-1] == ";" {
+			toks.pos = toks.pos[:n-1]
+			toks.text = toks.text[:n-1]
+		}
+		text := tok.String()
+		switch {
+		case tok == token.SEMICOLON:
+			text = ";"
+		case tok == token.IDENT || tok.IsLiteral():
+			text = lit
+		}
+		toks.pos = append(toks.pos, pos)
+		toks.text = append(toks.text, text)
+	}
+}
+
+// between returns the tokens in [start, end).
+//
+// between 返回位于 [start, end) 之间的 token。
+func (t *tokens) between(start, end token.Pos) []string {
+	i := sort.Search(len(t.pos), func(i int) bool { return t.pos[i] >= start })
+	j := sort.Search(len(t.pos), func(i int) bool { return t.pos[i] >= end })
+	return t.text[i:j]
+}
+
+// comparePackage compares the declarations of the package in dir with the
+// upstream ones and returns one line for each difference.
+//
+// comparePackage 比较 dir 中的包和上游的包中的声明，并为每个差异返回一行。
+func comparePackage(dir, importPath string, up upstream) ([]string, error) {
+	upDir, err := up.pkgDir(importPath)
+	if err == errNoPackage {
+		return []string{fmt.Sprintf("%s: not in %s", importPath, up.release())}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	local, err := parseDecls(dir)
+	if err != nil {
+		return nil, err
+	}
+	remote, err := parseDecls(upDir)
+	if err != nil {
+		return nil, err
+	}
+
+	type result struct {
+		d   *decl
+		msg string
+	}
+	var results []result
+	report := func(d *decl, msg string) {
+		results = append(results, result{d, msg})
+	}
+	compare := func(l, r *decl) {
+		if !equal(l.code, r.code) {
+			report(l, "changed")
+		}
+	}
+
+	// Match declarations in the same file first, then by name alone, so a
+	// declaration that moved to another file is still compared.
+	//
+	// 先匹配同一个文件中的声明，再只按名字匹配，这样移动到另一个文件中的声明仍然会被比较。
+	type key struct{ file, name string }
+	matched := make(map[*decl]bool)
+	byFile := make(map[key]*decl)
+	for _, r := range remote {
+		byFile[key{r.file, r.name}] = r
+	}
+	var rest []*decl
+	for _, l := range local {
+		if r := byFile[key{l.file, l.name}]; r != nil {
+			compare(l, r)
+			matched[r] = true
+			continue
+		}
+		rest = append(rest, l)
+	}
+	byName := make(map[string][]*decl)
+	for _, r := range remote {
+		if !matched[r] {
+			byName[r.name] = append(byName[r.name], r)
+		}
+	}
+	for _, l := range rest {
+		if rs := byName[l.name]; len(rs) > 0 {
+			compare(l, rs[0])
+			matched[rs[0]] = true
+			byName[l.name] = rs[1:]
+			continue
+		}
+		report(l, "only in src")
+	}
+	sort.Slice(results, func(i, j int) bool {
+		a, b := results[i].d, results[j].d
+		if a.file != b.file {
+			return a.file < b.file
+		}
+		return a.line < b.line
+	})
+	var diffs []string
+	for _, r := range results {
+		diffs = append(diffs, fmt.Sprintf("%s:%d: %s: %s", path.Join(importPath, r.d.file), r.d.line, r.d.name, r.msg))
+	}
+	var missing []string
+	for _, r := range remote {
+		if !matched[r] {
+			missing = append(missing, fmt.Sprintf("%s: %s: only in %s (%s)", importPath, r.name, up.release(), r.file))
+		}
+	}
+	sort.Strings(missing)
+	return append(diffs, missing...), nil
+}
+
+func equal(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
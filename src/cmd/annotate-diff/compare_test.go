@@ -0,0 +1,96 @@
+// Copyright 2018 Li Zebang. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestComparePackage(t *testing.T) {
+	up := &localUpstream{dir: "testdata/upstream/src", tag: "go1.x"}
+	diffs, err := comparePackage("testdata/src/p", "p", up)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{
+		"p/p.go:16: Changed: changed",
+		"p/p.go:19: Added: only in src",
+		"p: Gone: only in go1.x (p.go)",
+	}
+	if !reflect.DeepEqual(diffs, want) {
+		t.Errorf("comparePackage:\nhave %q\nwant %q", diffs, want)
+	}
+
+	diffs, err = comparePackage("testdata/src/p", "missing", up)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"missing: not in go1.x"}; !reflect.DeepEqual(diffs, want) {
+		t.Errorf("comparePackage(missing) = %q; want %q", diffs, want)
+	}
+}
+
+func TestListPackages(t *testing.T) {
+	pkgs, err := listPackages("testdata", []string{"src/..."})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"src/p"}; !reflect.DeepEqual(pkgs, want) {
+		t.Errorf("listPackages = %q; want %q", pkgs, want)
+	}
+}
+
+func TestExtractGoFiles(t *testing.T) {
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(zw)
+	for _, f := range []struct {
+		name string
+		typ  byte
+	}{
+		{"buffer.go", tar.TypeReg},
+		{"README", tar.TypeReg},
+		{"testdata/", tar.TypeDir},
+		{"testdata/x.go", tar.TypeReg},
+	} {
+		hdr := &tar.Header{Name: f.name, Typeflag: f.typ, Mode: 0666}
+		if f.typ == tar.TypeReg {
+			hdr.Size = int64(len("package p\n"))
+		}
+		if err := tw.WriteHeader(hdr); err != nil {
+			t.Fatal(err)
+		}
+		if f.typ == tar.TypeReg {
+			tw.Write([]byte("package p\n"))
+		}
+	}
+	tw.Close()
+	zw.Close()
+
+	dir, err := ioutil.TempDir("", "annotate-diff")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	if err := extractGoFiles(&buf, dir); err != nil {
+		t.Fatal(err)
+	}
+	names, err := filepath.Glob(filepath.Join(dir, "*"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	sort.Strings(names)
+	if want := []string{filepath.Join(dir, "buffer.go")}; !reflect.DeepEqual(names, want) {
+		t.Errorf("extracted %q; want %q", names, want)
+	}
+}
@@ -0,0 +1,119 @@
+// Copyright 2018 Li Zebang. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+// Annotate-diff reports the declarations of the annotated sources whose code
+// differs from an upstream Go release, so that the annotations can be
+// brought up to date when Go changes.
+//
+// Usage:
+//
+//	annotate-diff [-src dir] [-tag tag] [-goroot dir] [packages]
+//
+// Comments are ignored when comparing, so a declaration that only gained
+// annotations is reported as unchanged. Declarations are matched by name
+// within a package, which keeps a declaration that moved to another file
+// from being reported twice. For every difference it prints one line:
+//
+//	bytes/buffer.go:20: Buffer: changed
+//	bytes/buffer.go:72: Buffer.Available: only in src
+//	bytes: Buffer.AvailableBuffer: only in go1.21 (buffer.go)
+//
+// The upstream sources are read from the -goroot tree if it is set, for
+// example a checkout of the Go repository at the release tag. Otherwise the
+// packages of the release named by -tag are downloaded from
+// go.googlesource.com and cached in the user cache directory. The tag
+// defaults to the one recorded in GO-VERSION next to the source tree.
+//
+// Packages are import paths relative to the source tree, and a trailing
+// "/..." matches a path and all of its subdirectories. With no arguments
+// every package outside cmd is compared. The exit status is 1 if any
+// difference was found.
+//
+// Annotate-diff 报告注释过的源码中代码与上游 Go 发布版本不同的声明，这样在 Go 发生变化时
+// 可以及时更新注释。
+//
+// 用法：
+//
+//	annotate-diff [-src dir] [-tag tag] [-goroot dir] [packages]
+//
+// 比较时会忽略注释，所以只是增加了注释的声明会被认为没有变化。声明在包内按照名字进行匹配，
+// 这样移动到另一个文件中的声明不会被报告两次。每个差异会输出一行：
+//
+//	bytes/buffer.go:20: Buffer: changed
+//	bytes/buffer.go:72: Buffer.Available: only in src
+//	bytes: Buffer.AvailableBuffer: only in go1.21 (buffer.go)
+//
+// 如果设置了 -goroot，上游源码会从该目录树中读取，例如检出到发布标签的 Go 仓库。否则会从
+// go.googlesource.com 下载 -tag 指定的发布版本中的包，并缓存在用户缓存目录中。tag 默认是
+// 源码树旁边的 GO-VERSION 中记录的版本。
+//
+// packages 是相对于源码树的导入路径，末尾的 "/..." 匹配该路径及其所有子目录。没有参数时，
+// 会比较 cmd 之外的所有包。如果发现了任何差异，退出状态为 1。
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+var (
+	srcDir = flag.String("src", "src", "annotated source `dir`")
+	tag    = flag.String("tag", "", "upstream release `tag` (default from GO-VERSION)")
+	goroot = flag.String("goroot", "", "read upstream sources from the GOROOT `dir` instead of downloading them")
+)
+
+func usage() {
+	fmt.Fprintf(os.Stderr, "usage: annotate-diff [-src dir] [-tag tag] [-goroot dir] [packages]\n")
+	flag.PrintDefaults()
+	os.Exit(2)
+}
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("annotate-diff: ")
+	flag.Usage = usage
+	flag.Parse()
+
+	if *tag == "" {
+		data, err := ioutil.ReadFile(filepath.Join(*srcDir, "..", "GO-VERSION"))
+		if err != nil {
+			log.Fatalf("no -tag given: %v", err)
+		}
+		*tag = strings.TrimSpace(string(data))
+	}
+	var up upstream
+	if *goroot != "" {
+		up = &localUpstream{dir: filepath.Join(*goroot, "src"), tag: *tag}
+	} else {
+		cache, err := os.UserCacheDir()
+		if err != nil {
+			log.Fatal(err)
+		}
+		up = &remoteUpstream{cache: filepath.Join(cache, "annotate-diff", *tag), tag: *tag}
+	}
+
+	pkgs, err := listPackages(*srcDir, flag.Args())
+	if err != nil {
+		log.Fatal(err)
+	}
+	found := false
+	for _, pkg := range pkgs {
+		diffs, err := comparePackage(filepath.Join(*srcDir, filepath.FromSlash(pkg)), pkg, up)
+		if err != nil {
+			log.Fatal(err)
+		}
+		for _, d := range diffs {
+			fmt.Println(d)
+			found = true
+		}
+	}
+	if found {
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,24 @@
+package p
+
+// Same only gains annotations downstream.
+//
+// Same 在下游只增加了注释。
+func Same(x int) int {
+	// IMP: 返回绝对值。
+	if x > 0 {
+		return x // positive
+	}
+
+	return -x
+}
+
+// Changed is rewritten upstream.
+func Changed(x int) int { return x + x }
+
+// Added only exists downstream.
+func Added() {}
+
+type T struct {
+	// 计数
+	n int
+}
@@ -0,0 +1,5 @@
+package p
+
+func (t *T) Moved() int {
+	return t.n
+}
@@ -0,0 +1,19 @@
+package p
+
+// Same only gains annotations downstream.
+func Same(x int) int {
+	if x > 0 {
+		return x
+	}
+	return -x
+}
+
+// Changed is rewritten upstream.
+func Changed(x int) int { return x * 2 }
+
+// Gone only exists upstream.
+func Gone() {}
+
+type T struct{ n int }
+
+func (t *T) Moved() int { return t.n }
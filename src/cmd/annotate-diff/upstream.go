@@ -0,0 +1,141 @@
+// Copyright 2018 Li Zebang. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// errNoPackage is returned by pkgDir if the release has no such package.
+//
+// 如果发布版本中没有该包，pkgDir 返回 errNoPackage。
+var errNoPackage = errors.New("no such package")
+
+// An upstream provides the sources of a Go release.
+//
+// upstream 提供了一个 Go 发布版本的源码。
+type upstream interface {
+	// pkgDir returns the directory holding the sources of the package.
+	//
+	// pkgDir 返回存放该包源码的目录。
+	pkgDir(importPath string) (string, error)
+	// release returns the name of the release, such as go1.11.
+	//
+	// release 返回发布版本的名字，例如 go1.11。
+	release() string
+}
+
+// A localUpstream reads the sources from a GOROOT on disk.
+//
+// localUpstream 从磁盘上的 GOROOT 中读取源码。
+type localUpstream struct {
+	dir string
+	tag string
+}
+
+func (u *localUpstream) pkgDir(importPath string) (string, error) {
+	dir := filepath.Join(u.dir, filepath.FromSlash(importPath))
+	if fi, err := os.Stat(dir); err != nil || !fi.IsDir() {
+		return "", errNoPackage
+	}
+	return dir, nil
+}
+
+func (u *localUpstream) release() string { return u.tag }
+
+// archiveURL is the gitiles archive of a source directory at a release tag.
+//
+// archiveURL 是某个发布标签下一个源码目录的 gitiles 归档。
+const archiveURL = "https://go.googlesource.com/go/+archive/refs/tags/%s/src/%s.tar.gz"
+
+// A remoteUpstream downloads the sources of each package once and keeps
+// them in the cache directory.
+//
+// remoteUpstream 只下载每个包的源码一次，并将它们保存在缓存目录中。
+type remoteUpstream struct {
+	cache string
+	tag   string
+}
+
+func (u *remoteUpstream) release() string { return u.tag }
+
+func (u *remoteUpstream) pkgDir(importPath string) (string, error) {
+	dir := filepath.Join(u.cache, filepath.FromSlash(importPath))
+	if _, err := os.Stat(dir); err == nil {
+		return dir, nil
+	}
+
+	url := fmt.Sprintf(archiveURL, u.tag, importPath)
+	resp, err := http.Get(url)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound {
+		return "", errNoPackage
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("fetching %s: %s", url, resp.Status)
+	}
+
+	// IMP: 先解压到临时目录再重命名，这样中途失败不会留下一个不完整的缓存目录。
+	if err := os.MkdirAll(filepath.Dir(dir), 0777); err != nil {
+		return "", err
+	}
+	tmp, err := ioutil.TempDir(filepath.Dir(dir), ".download")
+	if err != nil {
+		return "", err
+	}
+	defer os.RemoveAll(tmp)
+	if err := extractGoFiles(resp.Body, tmp); err != nil {
+		return "", fmt.Errorf("fetching %s: %v", url, err)
+	}
+	if err := os.Rename(tmp, dir); err != nil {
+		return "", err
+	}
+	return dir, nil
+}
+
+// extractGoFiles writes the Go files at the top level of the gzipped tar
+// archive r to dir. Subdirectories are separate packages and are skipped.
+//
+// extractGoFiles 将 gzip 压缩的 tar 归档 r 中顶层的 Go 文件写入 dir。子目录是单独的包，
+// 会被跳过。
+func extractGoFiles(r io.Reader, dir string) error {
+	zr, err := gzip.NewReader(r)
+	if err != nil {
+		return err
+	}
+	tr := tar.NewReader(zr)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		name := strings.TrimPrefix(hdr.Name, "./")
+		if hdr.Typeflag != tar.TypeReg || strings.Contains(name, "/") || !strings.HasSuffix(name, ".go") {
+			continue
+		}
+		data, err := ioutil.ReadAll(tr)
+		if err != nil {
+			return err
+		}
+		if err := ioutil.WriteFile(filepath.Join(dir, name), data, 0666); err != nil {
+			return err
+		}
+	}
+}
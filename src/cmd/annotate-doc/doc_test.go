@@ -0,0 +1,102 @@
+// Copyright 2018 Li Zebang. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+var splitDocTests = []struct {
+	text   string
+	en, zh string
+}{
+	{"", "", ""},
+	{"English only.\n", "English only.", ""},
+	{
+		"Len returns the length.\n\nLen 返回长度。\n",
+		"Len returns the length.",
+		"Len 返回长度。",
+	},
+	{
+		"Example:\n\n\tx := 1\n\n示例：\n\n\tx := 1\n",
+		"Example:\n\n\tx := 1",
+		"示例：\n\n\tx := 1",
+	},
+	{
+		"Grow grows the buffer.\n\nIMP: only a note.\n",
+		"Grow grows the buffer.",
+		"IMP: only a note.",
+	},
+}
+
+func TestSplitDoc(t *testing.T) {
+	for _, tt := range splitDocTests {
+		en, zh := splitDoc(tt.text)
+		if en != tt.en || zh != tt.zh {
+			t.Errorf("splitDoc(%q) = %q, %q; want %q, %q", tt.text, en, zh, tt.en, tt.zh)
+		}
+	}
+}
+
+func TestUnindent(t *testing.T) {
+	got := unindent("\t\tif x {\n\t\t\ty()\n\n\t\t}")
+	want := "if x {\n\ty()\n\n}"
+	if got != want {
+		t.Errorf("unindent = %q; want %q", got, want)
+	}
+}
+
+func TestSite(t *testing.T) {
+	pkgs, err := loadPackages("testdata/src", nil, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Only p carries Chinese annotations.
+	if len(pkgs) != 1 || pkgs[0].ImportPath != "p" {
+		t.Fatalf("loadPackages found %d packages, want only p", len(pkgs))
+	}
+
+	pkgs, err = loadPackages("testdata/src", []string{"..."}, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir, err := ioutil.TempDir("", "annotate-doc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	if err := writeSite(dir, pkgs); err != nil {
+		t.Fatal(err)
+	}
+
+	read := func(name string) string {
+		data, err := ioutil.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return string(data)
+	}
+	checks := []struct {
+		file, want string
+	}{
+		{"index.html", `<a href="p/index.html">p</a>`},
+		{"index.html", `<td class="zh">Package p 用于测试 annotate-doc。</td>`},
+		{"p/index.html", `<span id="T.M"></span>`},
+		{"p/index.html", `<div class="en"><p>M returns t.F.</p>`},
+		{"p/index.html", `<p class="mark imp">IMP: 参见 <a href="../q/index.html#Q">q.Q</a>。</p>`},
+		{"p/index.html", "// 字段\n\tF int // field"},
+		{"p/index.html", `Imported by: <a href="../q/index.html">q</a>`},
+		{"q/index.html", `Q calls T.M, see <a href="../p/index.html#T.M">p.T.M</a>.`},
+	}
+	for _, c := range checks {
+		if !strings.Contains(read(c.file), c.want) {
+			t.Errorf("%s does not contain %q", c.file, c.want)
+		}
+	}
+}
@@ -0,0 +1,505 @@
+// Copyright 2018 Li Zebang. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"bytes"
+	"go/doc"
+	"html/template"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// A site holds every rendered package, so that pages can link to each other.
+//
+// site 保存了所有被渲染的包，这样页面之间可以互相链接。
+type site struct {
+	pkgs       []*Package
+	byPath     map[string]*Package
+	byName     map[string][]*Package
+	importedBy map[string][]string
+}
+
+func newSite(pkgs []*Package) *site {
+	s := &site{
+		pkgs:       pkgs,
+		byPath:     make(map[string]*Package),
+		byName:     make(map[string][]*Package),
+		importedBy: make(map[string][]string),
+	}
+	for _, p := range pkgs {
+		s.byPath[p.ImportPath] = p
+		s.byName[p.Doc.Name] = append(s.byName[p.Doc.Name], p)
+	}
+	for _, p := range pkgs {
+		for _, imp := range p.Imports {
+			if s.byPath[imp] != nil {
+				s.importedBy[imp] = append(s.importedBy[imp], p.ImportPath)
+			}
+		}
+	}
+	return s
+}
+
+// writeSite renders pkgs into the directory out.
+//
+// writeSite 将 pkgs 渲染到目录 out 中。
+func writeSite(out string, pkgs []*Package) error {
+	s := newSite(pkgs)
+	if err := os.MkdirAll(out, 0777); err != nil {
+		return err
+	}
+	if err := ioutil.WriteFile(filepath.Join(out, "style.css"), []byte(styleCSS), 0666); err != nil {
+		return err
+	}
+
+	index := indexPage{}
+	for _, p := range pkgs {
+		en, zh := splitDoc(p.Doc.Doc)
+		index.Packages = append(index.Packages, indexEntry{
+			Href:       p.ImportPath + "/index.html",
+			ImportPath: p.ImportPath,
+			En:         doc.Synopsis(en),
+			Zh:         synopsisZh(zh),
+		})
+
+		var buf bytes.Buffer
+		if err := pageTemplate.Execute(&buf, s.page(p)); err != nil {
+			return err
+		}
+		dir := filepath.Join(out, filepath.FromSlash(p.ImportPath))
+		if err := os.MkdirAll(dir, 0777); err != nil {
+			return err
+		}
+		if err := ioutil.WriteFile(filepath.Join(dir, "index.html"), buf.Bytes(), 0666); err != nil {
+			return err
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := indexTemplate.Execute(&buf, index); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(filepath.Join(out, "index.html"), buf.Bytes(), 0666)
+}
+
+// synopsisZh returns the first sentence of a Chinese doc comment.
+//
+// synopsisZh 返回中文文档注释的第一句话。
+func synopsisZh(zh string) string {
+	paras := paragraphs(zh)
+	if len(paras) == 0 {
+		return ""
+	}
+	s := strings.Join(strings.Fields(paras[0]), " ")
+	if i := strings.Index(s, "。"); i >= 0 {
+		s = s[:i+len("。")]
+	}
+	return s
+}
+
+type indexEntry struct {
+	Href, ImportPath string
+	En, Zh           string
+}
+
+type indexPage struct {
+	Root     string
+	Packages []indexEntry
+}
+
+type link struct {
+	Href, Text string
+}
+
+type docBlock struct {
+	En, Zh template.HTML
+}
+
+type entry struct {
+	// IDs are the anchors of the entry, the first one is used for the title.
+	//
+	// IDs 是该条目的锚点，标题使用第一个锚点。
+	IDs   []string
+	Title string
+	Code  string
+	Doc   docBlock
+}
+
+type typeEntry struct {
+	entry
+	Values  []entry
+	Funcs   []entry
+	Methods []entry
+}
+
+type packagePage struct {
+	Root       string
+	ImportPath string
+	Name       string
+	Doc        docBlock
+	Imports    []link
+	ImportedBy []link
+	Values     []entry
+	Funcs      []entry
+	Types      []typeEntry
+}
+
+// page builds the data of the page of p.
+//
+// page 构建 p 的页面数据。
+func (s *site) page(p *Package) *packagePage {
+	l := &linker{site: s, pkg: p, root: strings.Repeat("../", strings.Count(p.ImportPath, "/")+1)}
+	pg := &packagePage{
+		Root:       l.root,
+		ImportPath: p.ImportPath,
+		Name:       p.Doc.Name,
+		Doc:        l.doc(p.Doc.Doc),
+	}
+	for _, imp := range p.Imports {
+		if s.byPath[imp] != nil {
+			pg.Imports = append(pg.Imports, link{l.root + imp + "/index.html", imp})
+		}
+	}
+	for _, imp := range s.importedBy[p.ImportPath] {
+		pg.ImportedBy = append(pg.ImportedBy, link{l.root + imp + "/index.html", imp})
+	}
+
+	values := func(vs []*doc.Value) []entry {
+		var es []entry
+		for _, v := range vs {
+			es = append(es, entry{IDs: v.Names, Code: p.code(v.Decl), Doc: l.doc(v.Doc)})
+		}
+		return es
+	}
+	funcs := func(fs []*doc.Func, prefix string) []entry {
+		var es []entry
+		for _, f := range fs {
+			id := prefix + f.Name
+			es = append(es, entry{IDs: []string{id}, Title: id, Code: p.code(f.Decl), Doc: l.doc(f.Doc)})
+		}
+		return es
+	}
+	pg.Values = append(values(p.Doc.Consts), values(p.Doc.Vars)...)
+	pg.Funcs = funcs(p.Doc.Funcs, "")
+	for _, t := range p.Doc.Types {
+		pg.Types = append(pg.Types, typeEntry{
+			entry:   entry{IDs: []string{t.Name}, Title: t.Name, Code: p.code(t.Decl), Doc: l.doc(t.Doc)},
+			Values:  append(values(t.Consts), values(t.Vars)...),
+			Funcs:   funcs(t.Funcs, ""),
+			Methods: funcs(t.Methods, t.Name+"."),
+		})
+	}
+	return pg
+}
+
+// A linker renders comment text of one package, linking the symbols it mentions.
+//
+// linker 渲染一个包的注释文本，并为其中提到的符号添加链接。
+type linker struct {
+	site *site
+	pkg  *Package
+	root string
+}
+
+// doc splits a doc comment and renders both languages.
+//
+// doc 拆分一条文档注释并渲染两种语言。
+func (l *linker) doc(text string) docBlock {
+	en, zh := splitDoc(text)
+	return docBlock{En: l.render(en), Zh: l.render(zh)}
+}
+
+// render converts comment text to HTML. Indented paragraphs become
+// preformatted blocks and lines starting with a marker become notes.
+//
+// render 将注释文本转换为 HTML。有缩进的段落成为预格式化的块，以标记开头的行成为笔记。
+func (l *linker) render(text string) template.HTML {
+	var buf bytes.Buffer
+	paras := paragraphs(text)
+	for i := 0; i < len(paras); i++ {
+		if isCode(paras[i]) {
+			// Blank lines inside a code block split it into several paragraphs.
+			//
+			// 代码块中的空行会把它拆分成多个段落。
+			code := []string{paras[i]}
+			for i+1 < len(paras) && isCode(paras[i+1]) {
+				i++
+				code = append(code, paras[i])
+			}
+			buf.WriteString("<pre>")
+			buf.WriteString(template.HTMLEscapeString(unindent(strings.Join(code, "\n\n"))))
+			buf.WriteString("</pre>\n")
+			continue
+		}
+		for _, p := range splitMarkers(paras[i]) {
+			if m := markerOf(p); m != "" {
+				buf.WriteString(`<p class="mark ` + strings.ToLower(m) + `">`)
+			} else {
+				buf.WriteString("<p>")
+			}
+			l.linkify(&buf, p)
+			buf.WriteString("</p>\n")
+		}
+	}
+	return template.HTML(buf.String())
+}
+
+// splitMarkers splits a paragraph before every line that starts with a marker.
+//
+// splitMarkers 在每个以标记开头的行之前拆分段落。
+func splitMarkers(para string) []string {
+	var parts []string
+	var cur []string
+	for _, line := range strings.Split(para, "\n") {
+		if markerOf(line) != "" && len(cur) > 0 {
+			parts = append(parts, strings.Join(cur, "\n"))
+			cur = nil
+		}
+		cur = append(cur, line)
+	}
+	return append(parts, strings.Join(cur, "\n"))
+}
+
+// unindent removes the indentation shared by all lines of a code block.
+//
+// unindent 去掉代码块所有行共同的缩进。
+func unindent(code string) string {
+	lines := strings.Split(code, "\n")
+	prefix := ""
+	for i, line := range lines {
+		if line == "" {
+			continue
+		}
+		indent := line[:len(line)-len(strings.TrimLeft(line, " \t"))]
+		if i == 0 {
+			prefix = indent
+			continue
+		}
+		for !strings.HasPrefix(indent, prefix) {
+			prefix = prefix[:len(prefix)-1]
+		}
+	}
+	for i, line := range lines {
+		lines[i] = strings.TrimPrefix(line, prefix)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// qualifiedIdent matches references such as bytes.Buffer, Buffer.Grow or
+// bytes.Buffer.Grow.
+//
+// qualifiedIdent 匹配 bytes.Buffer、Buffer.Grow 或者 bytes.Buffer.Grow 这样的引用。
+var qualifiedIdent = regexp.MustCompile(`[A-Za-z_][A-Za-z0-9_]*(\.[A-Za-z_][A-Za-z0-9_]*){1,2}`)
+
+// linkify writes the escaped text to buf, turning references to rendered
+// symbols into links.
+//
+// linkify 将转义后的文本写入 buf，并将对已渲染符号的引用转换为链接。
+func (l *linker) linkify(buf *bytes.Buffer, text string) {
+	last := 0
+	for _, m := range qualifiedIdent.FindAllStringIndex(text, -1) {
+		ref := text[m[0]:m[1]]
+		href := l.resolve(ref)
+		if href == "" && strings.Count(ref, ".") == 2 {
+			// Fall back to pkg.Type or Type.Method.
+			//
+			// 退而使用 pkg.Type 或者 Type.Method。
+			ref = ref[:strings.LastIndex(ref, ".")]
+			m[1] = m[0] + len(ref)
+			href = l.resolve(ref)
+		}
+		if href == "" {
+			continue
+		}
+		buf.WriteString(template.HTMLEscapeString(text[last:m[0]]))
+		buf.WriteString(`<a href="` + template.HTMLEscapeString(href) + `">`)
+		buf.WriteString(template.HTMLEscapeString(ref))
+		buf.WriteString("</a>")
+		last = m[1]
+	}
+	buf.WriteString(template.HTMLEscapeString(text[last:]))
+}
+
+// resolve returns the link target of a reference of the form pkg.Name,
+// Type.Method or pkg.Type.Method, or "" if it does not name a rendered symbol.
+//
+// resolve 返回 pkg.Name、Type.Method 或 pkg.Type.Method 形式的引用的链接目标，如果它不是
+// 一个已渲染的符号则返回 ""。
+//
+// IMP: 包名可能重复（例如 math/rand 和 crypto/rand），此时只有恰好一个同名包定义了该符号，
+// 或者当前包导入了其中一个，才能确定链接目标。
+func (l *linker) resolve(ref string) string {
+	dot := strings.Index(ref, ".")
+	qual, name := ref[:dot], ref[dot+1:]
+	if l.pkg.symbols[ref] {
+		return "#" + ref
+	}
+	if qual == l.pkg.Doc.Name && l.pkg.symbols[name] {
+		return "#" + name
+	}
+	var found []*Package
+	for _, p := range l.site.byName[qual] {
+		if p != l.pkg && p.symbols[name] {
+			found = append(found, p)
+		}
+	}
+	if len(found) > 1 {
+		imported := found[:0]
+		for _, p := range found {
+			i := sort.SearchStrings(l.pkg.Imports, p.ImportPath)
+			if i < len(l.pkg.Imports) && l.pkg.Imports[i] == p.ImportPath {
+				imported = append(imported, p)
+			}
+		}
+		found = imported
+	}
+	if len(found) != 1 {
+		return ""
+	}
+	return l.root + found[0].ImportPath + "/index.html#" + name
+}
+
+var funcs = template.FuncMap{
+	"first": func(ids []string) string { return ids[0] },
+}
+
+const layoutHTML = `
+{{define "lang"}}<div class="lang">
+<button onclick="setLang('both')">中 / En</button>
+<button onclick="setLang('en')">English</button>
+<button onclick="setLang('zh')">中文</button>
+</div>{{end}}
+
+{{define "script"}}<script>
+function setLang(l) {
+	document.body.className = 'lang-' + l;
+	try { localStorage.setItem('annotate-doc-lang', l); } catch (e) {}
+}
+try { var l = localStorage.getItem('annotate-doc-lang'); if (l) setLang(l); } catch (e) {}
+</script>{{end}}
+
+{{define "doc"}}{{if or .En .Zh}}<div class="doc">
+<div class="en">{{.En}}</div>
+<div class="zh">{{.Zh}}</div>
+</div>{{end}}{{end}}
+
+{{define "entry"}}<div class="entry">{{range .IDs}}<span id="{{.}}"></span>{{end}}
+{{with .Title}}<h3><a href="#{{.}}">{{.}}</a></h3>{{end}}
+<pre class="code">{{.Code}}</pre>
+{{template "doc" .Doc}}
+</div>{{end}}
+`
+
+var pageTemplate = template.Must(template.Must(template.New("page").Funcs(funcs).Parse(layoutHTML)).Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{.ImportPath}}</title>
+<link rel="stylesheet" href="{{.Root}}style.css">
+</head>
+<body class="lang-both">
+{{template "lang"}}
+<p class="nav"><a href="{{.Root}}index.html">Packages</a></p>
+<h1 id="pkg-overview">package {{.Name}}</h1>
+<p><code>import "{{.ImportPath}}"</code></p>
+{{template "doc" .Doc}}
+{{with .Imports}}<p class="deps">Imports:{{range .}} <a href="{{.Href}}">{{.Text}}</a>{{end}}</p>{{end}}
+{{with .ImportedBy}}<p class="deps">Imported by:{{range .}} <a href="{{.Href}}">{{.Text}}</a>{{end}}</p>{{end}}
+<ul class="toc">
+{{range .Funcs}}<li><a href="#{{first .IDs}}">{{.Title}}</a></li>
+{{end}}{{range .Types}}<li><a href="#{{first .IDs}}">{{.Title}}</a>{{with .Methods}}<ul>{{range .}}<li><a href="#{{first .IDs}}">{{.Title}}</a></li>{{end}}</ul>{{end}}</li>
+{{end}}</ul>
+{{with .Values}}<h2 id="pkg-values">Constants and Variables</h2>
+{{range .}}{{template "entry" .}}
+{{end}}{{end}}
+{{with .Funcs}}<h2 id="pkg-funcs">Functions</h2>
+{{range .}}{{template "entry" .}}
+{{end}}{{end}}
+{{with .Types}}<h2 id="pkg-types">Types</h2>
+{{range .}}{{template "entry" .}}
+{{range .Values}}{{template "entry" .}}
+{{end}}{{range .Funcs}}{{template "entry" .}}
+{{end}}{{range .Methods}}{{template "entry" .}}
+{{end}}{{end}}{{end}}
+{{template "script"}}
+</body>
+</html>
+`))
+
+var indexTemplate = template.Must(template.Must(template.New("index").Funcs(funcs).Parse(layoutHTML)).Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>Annotate Go SDK</title>
+<link rel="stylesheet" href="style.css">
+</head>
+<body class="lang-both">
+{{template "lang"}}
+<h1>Packages</h1>
+<table class="packages">
+{{range .Packages}}<tr>
+<td><a href="{{.Href}}">{{.ImportPath}}</a></td>
+<td class="en">{{.En}}</td>
+<td class="zh">{{.Zh}}</td>
+</tr>
+{{end}}</table>
+{{template "script"}}
+</body>
+</html>
+`))
+
+// The colors of the notes follow the todo-tree settings in README.md.
+//
+// 笔记的颜色与 README.md 中 todo-tree 的设置一致。
+const styleCSS = `body {
+	font-family: sans-serif;
+	line-height: 1.5;
+	max-width: 90em;
+	margin: 0 auto;
+	padding: 1em;
+}
+pre {
+	background: #f4f4f4;
+	padding: 0.5em;
+	overflow-x: auto;
+}
+.lang {
+	float: right;
+}
+.doc {
+	display: grid;
+	grid-template-columns: 1fr 1fr;
+	grid-column-gap: 2em;
+}
+.lang-en .doc, .lang-zh .doc {
+	grid-template-columns: 1fr;
+}
+.lang-en .zh, .lang-zh .en {
+	display: none;
+}
+.entry {
+	margin-bottom: 2em;
+}
+.packages td {
+	padding: 0.2em 1em 0.2em 0;
+	vertical-align: top;
+}
+.mark {
+	border-left: 4px solid;
+	padding-left: 0.5em;
+}
+.mark.imp { border-color: #aa00aa; }
+.mark.note { border-color: #0052cc; }
+.mark.tsk { border-color: #d455d0; }
+.mark.ts { border-color: #d2b48c; }
+.mark.todo { border-color: #0ffa16; }
+.mark.fixme { border-color: #fbca04; }
+.mark.bug { border-color: #e11d21; }
+`
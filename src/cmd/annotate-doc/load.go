@@ -0,0 +1,234 @@
+// Copyright 2018 Li Zebang. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"bytes"
+	"go/ast"
+	"go/build"
+	"go/doc"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// A Package is a parsed package of the source tree.
+//
+// Package 是源码树中一个解析过的包。
+type Package struct {
+	ImportPath string
+	Imports    []string
+	Doc        *doc.Package
+
+	// annotated reports whether any comment of the package is in Chinese.
+	//
+	// annotated 表示该包中是否有中文注释。
+	annotated bool
+	// symbols holds the names that have an anchor on the package page.
+	//
+	// symbols 保存了在包页面上有锚点的名字。
+	symbols map[string]bool
+	fset    *token.FileSet
+}
+
+// loadPackages walks src and parses the packages matching patterns.
+// With no patterns it returns every annotated package outside cmd.
+//
+// loadPackages 遍历 src 并解析匹配 patterns 的包。
+// 没有 patterns 时，它返回 cmd 之外所有带有注释的包。
+func loadPackages(src string, patterns []string, all bool) ([]*Package, error) {
+	src, err := filepath.Abs(src)
+	if err != nil {
+		return nil, err
+	}
+	// IMP: 把 GOROOT 指向被注释的源码树，这样 ImportDir 会按 GOROOT 的规则计算导入路径，
+	// 而构建约束仍然按照当前的 GOOS/GOARCH 选择文件，避免同一个符号出现多次。
+	ctxt := build.Default
+	ctxt.GOROOT = filepath.Dir(src)
+	ctxt.GOPATH = ""
+
+	var pkgs []*Package
+	err = filepath.Walk(src, func(path string, fi os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !fi.IsDir() || path == src {
+			return nil
+		}
+		name := fi.Name()
+		if name == "testdata" || name == "vendor" || strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_") {
+			return filepath.SkipDir
+		}
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		if len(patterns) == 0 && rel == "cmd" {
+			return filepath.SkipDir
+		}
+		if !matchPatterns(patterns, rel) {
+			return nil
+		}
+		bp, err := ctxt.ImportDir(path, 0)
+		if err != nil {
+			if _, ok := err.(*build.NoGoError); !ok {
+				log.Printf("skipping %s: %v", rel, err)
+			}
+			return nil
+		}
+		p, err := parsePackage(bp, rel, all)
+		if err != nil {
+			return err
+		}
+		if len(patterns) == 0 && !p.annotated {
+			return nil
+		}
+		pkgs = append(pkgs, p)
+		return nil
+	})
+	return pkgs, err
+}
+
+// matchPatterns reports whether the import path matches one of the patterns.
+// An empty pattern list matches everything.
+//
+// matchPatterns 报告导入路径是否匹配 patterns 中的某一个。
+// 空的 patterns 匹配所有路径。
+func matchPatterns(patterns []string, importPath string) bool {
+	if len(patterns) == 0 {
+		return true
+	}
+	for _, pat := range patterns {
+		if pat == "..." || pat == importPath {
+			return true
+		}
+		if prefix := strings.TrimSuffix(pat, "/..."); prefix != pat {
+			if importPath == prefix || strings.HasPrefix(importPath, prefix+"/") {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// parsePackage parses the files selected by bp and computes its documentation.
+//
+// parsePackage 解析 bp 选中的文件，并计算它的文档。
+func parsePackage(bp *build.Package, importPath string, all bool) (*Package, error) {
+	fset := token.NewFileSet()
+	files := make(map[string]*ast.File)
+	p := &Package{
+		ImportPath: importPath,
+		Imports:    bp.Imports,
+		symbols:    make(map[string]bool),
+		fset:       fset,
+	}
+	names := append(append([]string(nil), bp.GoFiles...), bp.CgoFiles...)
+	sort.Strings(names)
+	for _, name := range names {
+		f, err := parser.ParseFile(fset, filepath.Join(bp.Dir, name), nil, parser.ParseComments)
+		if err != nil {
+			return nil, err
+		}
+		files[name] = f
+		for _, c := range f.Comments {
+			if hasHan(c.Text()) {
+				p.annotated = true
+				break
+			}
+		}
+	}
+
+	var mode doc.Mode
+	if all {
+		mode = doc.AllDecls | doc.AllMethods
+	}
+	p.Doc = doc.New(&ast.Package{Name: bp.Name, Files: files}, importPath, mode)
+
+	addValues := func(values []*doc.Value) {
+		for _, v := range values {
+			for _, name := range v.Names {
+				p.symbols[name] = true
+			}
+		}
+	}
+	addValues(p.Doc.Consts)
+	addValues(p.Doc.Vars)
+	for _, f := range p.Doc.Funcs {
+		p.symbols[f.Name] = true
+	}
+	for _, t := range p.Doc.Types {
+		p.symbols[t.Name] = true
+		addValues(t.Consts)
+		addValues(t.Vars)
+		for _, f := range t.Funcs {
+			p.symbols[f.Name] = true
+		}
+		for _, m := range t.Methods {
+			p.symbols[t.Name+"."+m.Name] = true
+		}
+	}
+	return p, nil
+}
+
+// code returns the source of decl without its doc comment and function body.
+// Only the comments attached to fields and specs are kept, so that the
+// bilingual comments of struct fields and constants stay with the code.
+//
+// code 返回 decl 的源码，不包括它的文档注释和函数体。
+// 只保留附加在字段和 spec 上的注释，这样结构体字段和常量的双语注释会和代码一起显示。
+//
+// IMP: 不能直接使用文件的全部注释，go/doc 过滤掉的未导出字段的注释仍然在 decl 的位置范围内，
+// 会被打印到错误的地方。
+func (p *Package) code(decl ast.Decl) string {
+	switch d := decl.(type) {
+	case *ast.FuncDecl:
+		fd := *d
+		fd.Doc = nil
+		fd.Body = nil
+		decl = &fd
+	case *ast.GenDecl:
+		gd := *d
+		gd.Doc = nil
+		decl = &gd
+	}
+
+	var comments []*ast.CommentGroup
+	add := func(groups ...*ast.CommentGroup) {
+		for _, g := range groups {
+			if g != nil {
+				comments = append(comments, g)
+			}
+		}
+	}
+	ast.Inspect(decl, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.Field:
+			add(n.Doc, n.Comment)
+		case *ast.ValueSpec:
+			add(n.Doc, n.Comment)
+		case *ast.TypeSpec:
+			// The doc of the type is rendered as prose.
+			//
+			// 类型的文档会以正文的形式显示。
+			add(n.Comment)
+		}
+		return true
+	})
+	sort.Slice(comments, func(i, j int) bool { return comments[i].Pos() < comments[j].Pos() })
+
+	var buf bytes.Buffer
+	cfg := printer.Config{Mode: printer.UseSpaces | printer.TabIndent, Tabwidth: 8}
+	if err := cfg.Fprint(&buf, p.fset, &printer.CommentedNode{Node: decl, Comments: comments}); err != nil {
+		return err.Error()
+	}
+	return buf.String()
+}
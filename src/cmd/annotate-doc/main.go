@@ -0,0 +1,71 @@
+// Copyright 2018 Li Zebang. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+// Annotate-doc renders the annotated sources as a static HTML site.
+//
+// Usage:
+//
+//	annotate-doc [-src dir] [-o dir] [-u] [packages]
+//
+// Packages are import paths relative to the source tree, and a trailing
+// "/..." matches a path and all of its subdirectories. With no arguments
+// every package that carries Chinese annotations is rendered, except for
+// the commands under cmd.
+//
+// Each doc comment is split into its English original and its Chinese
+// annotation, which are shown side by side; a switch at the top of every
+// page shows only one of the two languages. Every package-level symbol and
+// method has an anchor (#Name or #Type.Method), and references of the form
+// pkg.Name or Type.Method in comments link to the rendered symbol.
+//
+// Annotate-doc 将注释过的源码渲染成一个静态的 HTML 站点。
+//
+// 用法：
+//
+//	annotate-doc [-src dir] [-o dir] [-u] [packages]
+//
+// packages 是相对于源码树的导入路径，末尾的 "/..." 匹配该路径及其所有子目录。没有参数时，
+// 会渲染所有带有中文注释的包，cmd 下的命令除外。
+//
+// 每条文档注释都会被拆分成英文原文和中文注释，两者并排显示；每个页面顶部的开关可以只显示
+// 其中一种语言。每个包级别的符号和方法都有一个锚点（#Name 或 #Type.Method），注释中
+// pkg.Name 或 Type.Method 形式的引用会链接到对应的符号。
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+)
+
+var (
+	srcDir     = flag.String("src", "src", "annotated source `dir`")
+	outDir     = flag.String("o", "html", "output `dir`")
+	unexported = flag.Bool("u", false, "include unexported symbols")
+)
+
+func usage() {
+	fmt.Fprintf(os.Stderr, "usage: annotate-doc [-src dir] [-o dir] [-u] [packages]\n")
+	flag.PrintDefaults()
+	os.Exit(2)
+}
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("annotate-doc: ")
+	flag.Usage = usage
+	flag.Parse()
+
+	pkgs, err := loadPackages(*srcDir, flag.Args(), *unexported)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if len(pkgs) == 0 {
+		log.Fatal("no packages to render")
+	}
+	if err := writeSite(*outDir, pkgs); err != nil {
+		log.Fatal(err)
+	}
+}
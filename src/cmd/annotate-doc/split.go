@@ -0,0 +1,99 @@
+// Copyright 2018 Li Zebang. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"strings"
+	"unicode"
+)
+
+// markers are the tags that start an annotation note, see README.md.
+//
+// markers 是注释笔记开头使用的标记，参见 README.md。
+var markers = []string{"IMP:", "NOTE:", "TSK:", "TS:", "TODO:", "FIXME:", "BUG:"}
+
+// splitDoc splits the text of a doc comment into the English original and
+// the Chinese annotation that follows it.
+//
+// splitDoc 将一条文档注释的文本拆分成英文原文和跟在它后面的中文注释。
+//
+// IMP: 注释的格式是先英文段落，再中文段落，所以第一个包含汉字或者以标记开头的段落之前的
+// 都是英文，从它开始都是中文。中文部分中重复的代码块也会留在中文部分。
+func splitDoc(text string) (en, zh string) {
+	paras := paragraphs(text)
+	for i, p := range paras {
+		if !isCode(p) && isAnnotation(p) {
+			return strings.Join(paras[:i], "\n\n"), strings.Join(paras[i:], "\n\n")
+		}
+	}
+	return strings.Join(paras, "\n\n"), ""
+}
+
+// paragraphs splits text at blank lines.
+//
+// paragraphs 在空行处拆分 text。
+func paragraphs(text string) []string {
+	var paras []string
+	var cur []string
+	for _, line := range strings.Split(text, "\n") {
+		if strings.TrimSpace(line) == "" {
+			if len(cur) > 0 {
+				paras = append(paras, strings.Join(cur, "\n"))
+				cur = nil
+			}
+			continue
+		}
+		cur = append(cur, line)
+	}
+	if len(cur) > 0 {
+		paras = append(paras, strings.Join(cur, "\n"))
+	}
+	return paras
+}
+
+// isCode reports whether every line of the paragraph is indented,
+// which makes it a preformatted block in Go doc comments.
+//
+// isCode 报告段落的每一行是否都有缩进，在 Go 文档注释中这样的段落是预格式化的块。
+func isCode(para string) bool {
+	for _, line := range strings.Split(para, "\n") {
+		if line[0] != ' ' && line[0] != '\t' {
+			return false
+		}
+	}
+	return true
+}
+
+// isAnnotation reports whether the paragraph belongs to the annotation.
+//
+// isAnnotation 报告段落是否属于注释部分。
+func isAnnotation(para string) bool {
+	return hasHan(para) || markerOf(para) != ""
+}
+
+// hasHan reports whether s contains a Chinese character.
+//
+// hasHan 报告 s 中是否包含汉字。
+func hasHan(s string) bool {
+	for _, r := range s {
+		if unicode.Is(unicode.Han, r) {
+			return true
+		}
+	}
+	return false
+}
+
+// markerOf returns the marker that line starts with, without the colon,
+// or "" if there is none.
+//
+// markerOf 返回 line 开头的标记（不包括冒号），如果没有则返回 ""。
+func markerOf(line string) string {
+	for _, m := range markers {
+		if strings.HasPrefix(line, m) {
+			return m[:len(m)-1]
+		}
+	}
+	return ""
+}
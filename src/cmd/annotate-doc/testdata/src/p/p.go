@@ -0,0 +1,18 @@
+// Package p is used to test annotate-doc.
+//
+// Package p 用于测试 annotate-doc。
+package p
+
+// A T is a type.
+//
+// T 是一个类型。
+type T struct {
+	// 字段
+	F int // field
+}
+
+// M returns t.F.
+//
+// M 返回 t.F。
+// IMP: 参见 q.Q。
+func (t T) M() int { return t.F }
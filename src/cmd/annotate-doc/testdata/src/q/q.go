@@ -0,0 +1,7 @@
+// Package q is used to test annotate-doc.
+package q
+
+import "p"
+
+// Q calls T.M, see p.T.M.
+func Q(t p.T) int { return t.M() }
@@ -0,0 +1,107 @@
+// Copyright 2018 Li Zebang. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+// Annotate-notes extracts the marked notes, such as IMP: and TSK:, from the
+// comments of the annotated sources and writes them as a JSON or Markdown
+// report, so that open questions and key insights can be reviewed in one
+// place.
+//
+// Usage:
+//
+//	annotate-notes [-src dir] [-m markers] [-format md|json] [-o file] [-t] [packages]
+//
+// A note starts at a comment line beginning with a marker, optionally with
+// an owner as in TODO(rsc):, and runs until the next marker or the end of
+// the comment. Each note records its package, file, line, the top-level
+// declaration it belongs to and its text. The markers default to the
+// todo-tree tags of README.md; -m selects a comma-separated subset.
+//
+// Packages are import paths relative to the source tree, and a trailing
+// "/..." matches a path and all of its subdirectories. With no arguments
+// every package outside cmd is scanned. Test files are skipped unless -t
+// is set.
+//
+// Annotate-notes 从注释过的源码的注释中提取被标记的笔记，例如 IMP: 和 TSK:，并将它们输出
+// 为 JSON 或 Markdown 报告，这样可以在一个地方集中查看未解决的问题和关键的理解。
+//
+// 用法：
+//
+//	annotate-notes [-src dir] [-m markers] [-format md|json] [-o file] [-t] [packages]
+//
+// 一条笔记从以标记开头的注释行开始，标记后面可以带有负责人，例如 TODO(rsc):，它一直延续到
+// 下一个标记或者注释的末尾。每条笔记都记录了它的包、文件、行号、所属的顶层声明以及文本。
+// 标记默认是 README.md 中的 todo-tree 标签，-m 可以选择其中以逗号分隔的一部分。
+//
+// packages 是相对于源码树的导入路径，末尾的 "/..." 匹配该路径及其所有子目录。没有参数时，
+// 会扫描 cmd 之外的所有包。除非设置了 -t，否则会跳过测试文件。
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+
+	"cmd/internal/notes"
+)
+
+var (
+	srcDir  = flag.String("src", "src", "annotated source `dir`")
+	markers = flag.String("m", strings.Join(notes.Markers, ","), "comma-separated `markers` to extract")
+	format  = flag.String("format", "md", "report `format`: md or json")
+	outFile = flag.String("o", "", "write the report to `file` instead of standard output")
+	tests   = flag.Bool("t", false, "include test files")
+)
+
+func usage() {
+	fmt.Fprintf(os.Stderr, "usage: annotate-notes [-src dir] [-m markers] [-format md|json] [-o file] [-t] [packages]\n")
+	flag.PrintDefaults()
+	os.Exit(2)
+}
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("annotate-notes: ")
+	flag.Usage = usage
+	flag.Parse()
+
+	if *format != "md" && *format != "json" {
+		log.Fatalf("unknown format %q", *format)
+	}
+	var ms []string
+	for _, m := range strings.Split(*markers, ",") {
+		if m = strings.TrimSuffix(strings.TrimSpace(m), ":"); m != "" {
+			ms = append(ms, m)
+		}
+	}
+	if len(ms) == 0 {
+		log.Fatal("no markers")
+	}
+
+	ns, err := scan(*srcDir, flag.Args(), ms, *tests)
+	if err != nil {
+		log.Fatal(err)
+	}
+	notes.Sort(ns)
+
+	var w io.Writer = os.Stdout
+	if *outFile != "" {
+		f, err := os.Create(*outFile)
+		if err != nil {
+			log.Fatal(err)
+		}
+		defer f.Close()
+		w = f
+	}
+	if *format == "json" {
+		err = notes.WriteJSON(w, ns)
+	} else {
+		err = notes.WriteMarkdown(w, ns, ms)
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,85 @@
+// Copyright 2018 Li Zebang. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"cmd/internal/notes"
+)
+
+// scan walks src and returns the notes of the packages matching patterns.
+// With no patterns it scans every package outside cmd.
+//
+// scan 遍历 src 并返回匹配 patterns 的包中的笔记。没有 patterns 时，它扫描 cmd 之外的
+// 所有包。
+//
+// IMP: 不使用 go/build 按构建约束选择文件，因为每个平台的文件中都可能有笔记。
+func scan(src string, patterns, markers []string, tests bool) ([]*notes.Note, error) {
+	var ns []*notes.Note
+	fset := token.NewFileSet()
+	err := filepath.Walk(src, func(path string, fi os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if fi.IsDir() {
+			name := fi.Name()
+			if path != src && (name == "testdata" || name == "vendor" || strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_")) {
+				return filepath.SkipDir
+			}
+			if len(patterns) == 0 && path == filepath.Join(src, "cmd") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !strings.HasSuffix(path, ".go") || !tests && strings.HasSuffix(path, "_test.go") {
+			return nil
+		}
+		rel, err := filepath.Rel(src, filepath.Dir(path))
+		if err != nil {
+			return err
+		}
+		importPath := filepath.ToSlash(rel)
+		if !matchPatterns(patterns, importPath) {
+			return nil
+		}
+		f, err := parser.ParseFile(fset, filepath.ToSlash(path), nil, parser.ParseComments)
+		if err != nil {
+			return err
+		}
+		for _, n := range notes.Extract(fset, f, markers) {
+			n.Package = importPath
+			ns = append(ns, n)
+		}
+		return nil
+	})
+	return ns, err
+}
+
+// matchPatterns reports whether the import path matches one of the patterns.
+// An empty pattern list matches everything.
+//
+// matchPatterns 报告导入路径是否匹配 patterns 中的某一个。
+// 空的 patterns 匹配所有路径。
+func matchPatterns(patterns []string, importPath string) bool {
+	if len(patterns) == 0 {
+		return true
+	}
+	for _, pat := range patterns {
+		if pat == "..." || pat == importPath {
+			return true
+		}
+		if prefix := strings.TrimSuffix(pat, "/..."); prefix != pat {
+			if importPath == prefix || strings.HasPrefix(importPath, prefix+"/") {
+				return true
+			}
+		}
+	}
+	return false
+}
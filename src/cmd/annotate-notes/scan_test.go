@@ -0,0 +1,49 @@
+// Copyright 2018 Li Zebang. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"cmd/internal/notes"
+)
+
+func TestScan(t *testing.T) {
+	tests := []struct {
+		patterns []string
+		tests    bool
+		want     []string
+	}{
+		{nil, false, []string{
+			"p testdata/src/p/p.go:6 F IMP",
+			"p/q testdata/src/p/q/q.go:3 V FIXME",
+		}},
+		{nil, true, []string{
+			"p testdata/src/p/p.go:6 F IMP",
+			"p testdata/src/p/p_test.go:3  TSK",
+			"p/q testdata/src/p/q/q.go:3 V FIXME",
+		}},
+		{[]string{"p/q", "cmd/..."}, false, []string{
+			"cmd/c testdata/src/cmd/c/c.go:3 main IMP",
+			"p/q testdata/src/p/q/q.go:3 V FIXME",
+		}},
+	}
+	for _, tt := range tests {
+		ns, err := scan("testdata/src", tt.patterns, notes.Markers, tt.tests)
+		if err != nil {
+			t.Fatal(err)
+		}
+		notes.Sort(ns)
+		var got []string
+		for _, n := range ns {
+			got = append(got, fmt.Sprintf("%s %s:%d %s %s", n.Package, n.File, n.Line, n.Symbol, n.Marker))
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("scan(%q, tests=%v):\ngot  %q\nwant %q", tt.patterns, tt.tests, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,4 @@
+package main
+
+// IMP: command note.
+func main() {}
@@ -0,0 +1,7 @@
+// Package p is a test package.
+package p
+
+// F is a function.
+//
+// IMP: 一条笔记。
+func F() {}
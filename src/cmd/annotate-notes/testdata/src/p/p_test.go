@@ -0,0 +1,3 @@
+package p
+
+// TSK: test note.
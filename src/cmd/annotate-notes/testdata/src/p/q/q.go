@@ -0,0 +1,3 @@
+package q
+
+var V = 1 // FIXME: value note
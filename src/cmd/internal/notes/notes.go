@@ -0,0 +1,274 @@
+// Copyright 2018 Li Zebang. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+// Package notes extracts the notes marked with IMP:, TSK:, FIXME: and the
+// other todo-tree tags of README.md from the comments of Go source files,
+// and writes them as JSON or Markdown reports.
+//
+// A note starts at a comment line whose text begins with a marker, which
+// may carry an owner in parentheses as in TODO(rsc):, and runs until the
+// next marker or the end of the comment group.
+//
+// Package notes 从 Go 源文件的注释中提取用 IMP:、TSK:、FIXME: 以及 README.md 中其他
+// todo-tree 标签标记的笔记，并将它们输出为 JSON 或 Markdown 报告。
+//
+// 一条笔记从文本以标记开头的注释行开始，标记后面可以带有一个括号中的负责人，例如
+// TODO(rsc):，它一直延续到下一个标记或者注释组的末尾。
+package notes
+
+import (
+	"go/ast"
+	"go/token"
+	"sort"
+	"strings"
+)
+
+// Markers are the markers recognized by default, in the order of the
+// todo-tree settings in README.md.
+//
+// Markers 是默认识别的标记，顺序与 README.md 中的 todo-tree 设置相同。
+var Markers = []string{"TODO", "FIXME", "BUG", "NOTE", "TS", "IMP", "TSK"}
+
+// A Note is a marked comment.
+//
+// Note 是一条被标记的注释。
+type Note struct {
+	// Marker is the marker without its colon, such as IMP.
+	//
+	// Marker 是不带冒号的标记，例如 IMP。
+	Marker string `json:"marker"`
+
+	// Owner is the name in parentheses after the marker, if any.
+	//
+	// Owner 是标记后面括号中的名字，如果有的话。
+	Owner string `json:"owner,omitempty"`
+
+	// Package is the import path of the package, set by the caller.
+	//
+	// Package 是包的导入路径，由调用者设置。
+	Package string `json:"package,omitempty"`
+
+	File string `json:"file"`
+	Line int    `json:"line"`
+
+	// Symbol is the top-level declaration that contains the comment or is
+	// documented by it, as Name or Type.Method. It is empty for comments
+	// outside of declarations.
+	//
+	// Symbol 是包含这条注释或者由它注释的顶层声明，形式为 Name 或 Type.Method。
+	// 声明之外的注释的 Symbol 为空。
+	Symbol string `json:"symbol,omitempty"`
+
+	// Text is the text of the note without the marker. Its lines are
+	// separated by newlines, and indented lines are kept as they are.
+	//
+	// Text 是笔记中不包括标记的文本。各行之间以换行符分隔，有缩进的行保持原样。
+	Text string `json:"text"`
+}
+
+// Extract returns the notes in the comments of f, which must have been
+// parsed with parser.ParseComments. Only the given markers are recognized.
+//
+// Extract 返回 f 的注释中的笔记，f 必须是使用 parser.ParseComments 解析的。
+// 只会识别给定的标记。
+func Extract(fset *token.FileSet, f *ast.File, markers []string) []*Note {
+	syms := symbols(f)
+	var notes []*Note
+	for _, g := range f.Comments {
+		var cur *Note
+		var text []string
+		flush := func() {
+			if cur == nil {
+				return
+			}
+			// Blank lines between the note and the end of the group or the
+			// next marker are not part of it.
+			//
+			// 笔记与注释组末尾或下一个标记之间的空行不属于这条笔记。
+			for len(text) > 0 && strings.TrimSpace(text[len(text)-1]) == "" {
+				text = text[:len(text)-1]
+			}
+			cur.Text = strings.Join(text, "\n")
+			notes = append(notes, cur)
+			cur, text = nil, nil
+		}
+		for _, c := range g.List {
+			pos := fset.Position(c.Pos())
+			for i, line := range commentLines(c.Text) {
+				marker, owner, rest, ok := parseMarker(line, markers)
+				if !ok {
+					if cur != nil {
+						text = append(text, line)
+					}
+					continue
+				}
+				flush()
+				cur = &Note{
+					Marker: marker,
+					Owner:  owner,
+					File:   pos.Filename,
+					Line:   pos.Line + i,
+					Symbol: syms.lookup(c.Pos()),
+				}
+				text = []string{rest}
+			}
+		}
+		flush()
+	}
+	return notes
+}
+
+// commentLines returns the lines of a // or /* */ comment without the
+// comment markers, the space that usually follows // and trailing spaces.
+//
+// commentLines 返回 // 或 /* */ 注释的各行，不包括注释符号、通常跟在 // 后面的空格以及
+// 行尾的空白。
+func commentLines(text string) []string {
+	if strings.HasPrefix(text, "//") {
+		line := text[2:]
+		if strings.HasPrefix(line, " ") {
+			line = line[1:]
+		}
+		return []string{strings.TrimRight(line, " \t")}
+	}
+	text = strings.TrimSuffix(strings.TrimPrefix(text, "/*"), "*/")
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " \t")
+	}
+	return lines
+}
+
+// parseMarker reports whether line starts with one of the markers, which
+// may be followed by an owner in parentheses, and a colon.
+//
+// parseMarker 报告 line 是否以 markers 中的某一个开头，标记后面可以跟着一个括号中的
+// 负责人，然后是一个冒号。
+func parseMarker(line string, markers []string) (marker, owner, rest string, ok bool) {
+	line = strings.TrimSpace(line)
+	for _, m := range markers {
+		if !strings.HasPrefix(line, m) {
+			continue
+		}
+		s := line[len(m):]
+		if strings.HasPrefix(s, "(") {
+			i := strings.Index(s, ")")
+			if i < 0 {
+				continue
+			}
+			owner, s = s[1:i], s[i+1:]
+		}
+		if !strings.HasPrefix(s, ":") {
+			owner = ""
+			continue
+		}
+		return m, owner, strings.TrimSpace(s[1:]), true
+	}
+	return "", "", "", false
+}
+
+// A span is the source range of a declaration, including its doc comment.
+//
+// span 是一个声明的源码范围，包括它的文档注释。
+type span struct {
+	start, end token.Pos
+	name       string
+}
+
+type spans []span
+
+// symbols returns the spans of the top-level declarations of f. A grouped
+// const, var or type declaration has a span for each of its specs.
+//
+// symbols 返回 f 中顶层声明的范围。成组的 const、var 或 type 声明的每个 spec 都有一个范围。
+func symbols(f *ast.File) spans {
+	var s spans
+	add := func(doc *ast.CommentGroup, node ast.Node, comment *ast.CommentGroup, name string) {
+		sp := span{start: node.Pos(), end: node.End(), name: name}
+		if doc != nil {
+			sp.start = doc.Pos()
+		}
+		if comment != nil && comment.End() > sp.end {
+			sp.end = comment.End()
+		}
+		s = append(s, sp)
+	}
+	for _, d := range f.Decls {
+		switch d := d.(type) {
+		case *ast.FuncDecl:
+			add(d.Doc, d, nil, funcName(d))
+		case *ast.GenDecl:
+			if d.Tok == token.IMPORT {
+				continue
+			}
+			if d.Lparen.IsValid() {
+				// The doc of the group belongs to no single spec.
+				//
+				// 组的文档不属于任何一个 spec。
+				add(d.Doc, d, nil, "")
+			}
+			for _, spec := range d.Specs {
+				doc := d.Doc
+				if d.Lparen.IsValid() {
+					doc = nil
+				}
+				switch spec := spec.(type) {
+				case *ast.TypeSpec:
+					if spec.Doc != nil {
+						doc = spec.Doc
+					}
+					add(doc, spec, spec.Comment, spec.Name.Name)
+				case *ast.ValueSpec:
+					if spec.Doc != nil {
+						doc = spec.Doc
+					}
+					var names []string
+					for _, n := range spec.Names {
+						names = append(names, n.Name)
+					}
+					add(doc, spec, spec.Comment, strings.Join(names, ", "))
+				}
+			}
+		}
+	}
+	sort.Slice(s, func(i, j int) bool { return s[i].start < s[j].start })
+	return s
+}
+
+// lookup returns the name of the innermost span that contains pos.
+//
+// lookup 返回包含 pos 的最内层范围的名字。
+func (s spans) lookup(pos token.Pos) string {
+	var best *span
+	for i := range s {
+		sp := &s[i]
+		if sp.start > pos {
+			break
+		}
+		if pos < sp.end && (best == nil || sp.end-sp.start < best.end-best.start) {
+			best = sp
+		}
+	}
+	if best == nil {
+		return ""
+	}
+	return best.name
+}
+
+// funcName returns Name for a function and Type.Name for a method.
+//
+// funcName 对函数返回 Name，对方法返回 Type.Name。
+func funcName(d *ast.FuncDecl) string {
+	if d.Recv == nil || len(d.Recv.List) == 0 {
+		return d.Name.Name
+	}
+	typ := d.Recv.List[0].Type
+	if star, ok := typ.(*ast.StarExpr); ok {
+		typ = star.X
+	}
+	if id, ok := typ.(*ast.Ident); ok {
+		return id.Name + "." + d.Name.Name
+	}
+	return d.Name.Name
+}
@@ -0,0 +1,171 @@
+// Copyright 2018 Li Zebang. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package notes
+
+import (
+	"bytes"
+	"go/parser"
+	"go/token"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const src = `// Package p is a test package.
+//
+// TSK: 包级别的笔记。
+package p
+
+// IMP: file-level note,
+// on two lines.
+
+// T is a type.
+//
+// T 是一个类型。
+//
+// IMP: 第一条笔记。
+//
+//	code
+//
+// NOTE: second note.
+type T struct {
+	x int // TSK: field note
+}
+
+// M is a method.
+func (t *T) M() {
+	// FIXME(gopher): body note.
+	/* TODO: block
+	   comment */
+}
+
+const (
+	// IMPORTANT: not a marker.
+	A = 1 // TS: trailing note
+	B, C = 2, 3
+	// TSK(x) missing colon
+)
+
+var v = 1 // BUG(r): value note
+`
+
+func parse(t *testing.T) (*token.FileSet, []*Note) {
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, "p.go", src, parser.ParseComments)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return fset, Extract(fset, f, Markers)
+}
+
+func TestExtract(t *testing.T) {
+	_, notes := parse(t)
+	want := []Note{
+		{Marker: "TSK", File: "p.go", Line: 3, Text: "包级别的笔记。"},
+		{Marker: "IMP", File: "p.go", Line: 6, Text: "file-level note,\non two lines."},
+		{Marker: "IMP", File: "p.go", Line: 13, Symbol: "T", Text: "第一条笔记。\n\n\tcode"},
+		{Marker: "NOTE", File: "p.go", Line: 17, Symbol: "T", Text: "second note."},
+		{Marker: "TSK", File: "p.go", Line: 19, Symbol: "T", Text: "field note"},
+		{Marker: "FIXME", Owner: "gopher", File: "p.go", Line: 24, Symbol: "T.M", Text: "body note."},
+		{Marker: "TODO", File: "p.go", Line: 25, Symbol: "T.M", Text: "block\n\t   comment"},
+		{Marker: "TS", File: "p.go", Line: 31, Symbol: "A", Text: "trailing note"},
+		{Marker: "BUG", Owner: "r", File: "p.go", Line: 36, Symbol: "v", Text: "value note"},
+	}
+	var got []Note
+	for _, n := range notes {
+		got = append(got, *n)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Extract:\ngot  %+v\nwant %+v", got, want)
+	}
+}
+
+func TestExtractMarkers(t *testing.T) {
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, "p.go", src, parser.ParseComments)
+	if err != nil {
+		t.Fatal(err)
+	}
+	notes := Extract(fset, f, []string{"IMP"})
+	if len(notes) != 2 {
+		t.Fatalf("got %d notes; want 2", len(notes))
+	}
+	// The NOTE: paragraph is not a marker any more and continues the note.
+	if want := "第一条笔记。\n\n\tcode\n\nNOTE: second note."; notes[1].Text != want {
+		t.Errorf("Text = %q; want %q", notes[1].Text, want)
+	}
+}
+
+func TestWriteJSON(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteJSON(&buf, nil); err != nil {
+		t.Fatal(err)
+	}
+	if buf.String() != "[]\n" {
+		t.Errorf("empty report = %q; want []", buf.String())
+	}
+	buf.Reset()
+	n := &Note{Marker: "IMP", Package: "p", File: "p.go", Line: 3, Text: "x"}
+	if err := WriteJSON(&buf, []*Note{n}); err != nil {
+		t.Fatal(err)
+	}
+	const want = `[
+	{
+		"marker": "IMP",
+		"package": "p",
+		"file": "p.go",
+		"line": 3,
+		"text": "x"
+	}
+]
+`
+	if buf.String() != want {
+		t.Errorf("got %s\nwant %s", buf.String(), want)
+	}
+}
+
+func TestWriteMarkdown(t *testing.T) {
+	_, notes := parse(t)
+	for _, n := range notes {
+		n.Package = "p"
+	}
+	Sort(notes)
+	var buf bytes.Buffer
+	if err := WriteMarkdown(&buf, notes, []string{"IMP", "FIXME"}); err != nil {
+		t.Fatal(err)
+	}
+	const want = "# Notes\n" +
+		"\n## IMP (2)\n" +
+		"\n### p\n" +
+		"\n- [p.go:6](p.go#L6)\n" +
+		"\n  file-level note, on two lines.\n" +
+		"\n- [p.go:13](p.go#L13) `T`\n" +
+		"\n  第一条笔记。\n" +
+		"\n  ```\n  code\n  ```\n" +
+		"\n## FIXME (1)\n" +
+		"\n### p\n" +
+		"\n- [p.go:24](p.go#L24) `T.M` (gopher)\n" +
+		"\n  body note.\n"
+	if got := buf.String(); got != want {
+		t.Errorf("got:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestJoinLines(t *testing.T) {
+	tests := []struct {
+		lines []string
+		want  string
+	}{
+		{[]string{"a", "b"}, "a b"},
+		{[]string{"尾数最多保留", "19 位"}, "尾数最多保留 19 位"},
+		{[]string{"被丢弃并将", "置为"}, "被丢弃并将置为"},
+		{[]string{"结果；", "b"}, "结果；b"},
+	}
+	for _, tt := range tests {
+		if got := joinLines(tt.lines); got != tt.want {
+			t.Errorf("joinLines(%q) = %q; want %q", strings.Join(tt.lines, "|"), got, tt.want)
+		}
+	}
+}
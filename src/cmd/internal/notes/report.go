@@ -0,0 +1,168 @@
+// Copyright 2018 Li Zebang. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package notes
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Sort sorts notes by package, file and line.
+//
+// Sort 按照包、文件和行号对 notes 排序。
+func Sort(notes []*Note) {
+	sort.SliceStable(notes, func(i, j int) bool {
+		a, b := notes[i], notes[j]
+		if a.Package != b.Package {
+			return a.Package < b.Package
+		}
+		if a.File != b.File {
+			return a.File < b.File
+		}
+		return a.Line < b.Line
+	})
+}
+
+// WriteJSON writes notes to w as an indented JSON array.
+//
+// WriteJSON 将 notes 以缩进的 JSON 数组的形式写入 w。
+func WriteJSON(w io.Writer, notes []*Note) error {
+	if notes == nil {
+		// Write [] rather than null.
+		//
+		// 写入 [] 而不是 null。
+		notes = []*Note{}
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "\t")
+	return enc.Encode(notes)
+}
+
+// WriteMarkdown writes notes to w as a Markdown document with a section
+// for each marker, in the order of markers, and a subsection for each
+// package, so notes should be sorted with Sort. Every note links to its
+// line, with File taken as a path relative to the document.
+//
+// WriteMarkdown 将 notes 以 Markdown 文档的形式写入 w，按照 markers 的顺序每个标记一节，
+// 每个包一小节，所以 notes 应该已经用 Sort 排过序。每条笔记都链接到它所在的行，File 被
+// 当作相对于该文档的路径。
+func WriteMarkdown(w io.Writer, notes []*Note, markers []string) error {
+	bw := bufio.NewWriter(w)
+	byMarker := make(map[string][]*Note)
+	for _, n := range notes {
+		byMarker[n.Marker] = append(byMarker[n.Marker], n)
+	}
+	fmt.Fprintf(bw, "# Notes\n")
+	for _, m := range markers {
+		ns := byMarker[m]
+		if len(ns) == 0 {
+			continue
+		}
+		fmt.Fprintf(bw, "\n## %s (%d)\n", m, len(ns))
+		pkg := "\x00"
+		for _, n := range ns {
+			if n.Package != pkg {
+				pkg = n.Package
+				if pkg != "" {
+					fmt.Fprintf(bw, "\n### %s\n", pkg)
+				}
+			}
+			fmt.Fprintf(bw, "\n- [%s:%d](%s#L%d)", n.File, n.Line, n.File, n.Line)
+			if n.Symbol != "" {
+				fmt.Fprintf(bw, " `%s`", n.Symbol)
+			}
+			if n.Owner != "" {
+				fmt.Fprintf(bw, " (%s)", n.Owner)
+			}
+			fmt.Fprintf(bw, "\n")
+			writeText(bw, n.Text)
+		}
+	}
+	return bw.Flush()
+}
+
+// writeText writes the text of a note indented under its list item.
+// The lines of a paragraph are joined, and indented lines become a code
+// block.
+//
+// writeText 将笔记的文本缩进写在它的列表项下面。一个段落中的各行会被连接起来，有缩进的行
+// 会成为一个代码块。
+func writeText(w io.Writer, text string) {
+	var para []string
+	var code []string
+	flushPara := func() {
+		if len(para) > 0 {
+			fmt.Fprintf(w, "\n  %s\n", joinLines(para))
+			para = nil
+		}
+	}
+	flushCode := func() {
+		if len(code) > 0 {
+			fmt.Fprintf(w, "\n  ```\n")
+			for _, line := range code {
+				fmt.Fprintf(w, "  %s\n", strings.Replace(line, "\t", "    ", -1))
+			}
+			fmt.Fprintf(w, "  ```\n")
+			code = nil
+		}
+	}
+	for _, line := range strings.Split(text, "\n") {
+		switch {
+		case strings.TrimSpace(line) == "":
+			flushPara()
+			if len(code) > 0 {
+				code = append(code, "")
+			}
+		case line[0] == ' ' || line[0] == '\t':
+			flushPara()
+			code = append(code, line[1:])
+		default:
+			for len(code) > 0 && code[len(code)-1] == "" {
+				code = code[:len(code)-1]
+			}
+			flushCode()
+			para = append(para, strings.TrimSpace(line))
+		}
+	}
+	for len(code) > 0 && code[len(code)-1] == "" {
+		code = code[:len(code)-1]
+	}
+	flushPara()
+	flushCode()
+}
+
+// joinLines joins the lines of a paragraph. Lines are separated by a space,
+// except between two Chinese characters and next to Chinese punctuation.
+//
+// joinLines 连接一个段落中的各行。各行之间以空格分隔，但两个汉字之间以及中文标点旁边
+// 不加空格。
+func joinLines(lines []string) string {
+	var b strings.Builder
+	for i, line := range lines {
+		if i > 0 {
+			last, _ := utf8.DecodeLastRuneInString(lines[i-1])
+			first, _ := utf8.DecodeRuneInString(line)
+			han := unicode.Is(unicode.Han, last) && unicode.Is(unicode.Han, first)
+			if !han && !isPunct(last) && !isPunct(first) {
+				b.WriteByte(' ')
+			}
+		}
+		b.WriteString(line)
+	}
+	return b.String()
+}
+
+// isPunct reports whether r is a CJK or full-width punctuation mark.
+//
+// isPunct 报告 r 是否是中日韩或全角标点。
+func isPunct(r rune) bool {
+	return 0x3000 <= r && r <= 0x303f || 0xff00 <= r && r <= 0xffef
+}
@@ -14,6 +14,23 @@
 // highest-priority item from the queue. The Examples include such an
 // implementation; the file example_pq_test.go has the complete source.
 //
+// Package heap 为任何实现了 heap.Interface 的类型提供了堆操作。堆是一棵树，它的每个节点
+// 都是其子树中值最小的节点。
+//
+// 树中最小的元素是根，位于索引 0 处。
+//
+// 堆是实现优先级队列的一种常用方法。要构建一个优先级队列，在实现 Heap 接口时将（负的）优先级
+// 作为 Less 方法的排序依据，这样 Push 添加元素，而 Pop 从队列中移除优先级最高的元素。
+// 示例中包含了这样的实现，完整的源码在文件 example_pq_test.go 中。
+//
+// IMP: 堆用切片表示一棵完全二叉树，不需要额外的指针
+//
+//	         0
+//	   1           2
+//	3     4     5     6
+//
+// 索引为 i 的节点的父节点是 (i-1)/2，左子节点是 2*i+1，右子节点是 2*i+2。
+// 所有操作都只通过 Interface 的方法访问元素，所以 heap 包本身不知道元素的类型。
 package heap
 
 import "sort"
@@ -29,18 +46,37 @@ import "sort"
 // Note that Push and Pop in this interface are for package heap's
 // implementation to call. To add and remove things from the heap,
 // use heap.Push and heap.Pop.
+//
+// Interface 类型描述了使用此包中的例程的类型所需满足的要求。任何实现了它的类型都可以用作
+// 满足以下不变式的最小堆（在调用 Init 之后，或者数据为空或已排序时成立）：
+//
+//	!h.Less(j, i) for 0 <= i < h.Len() and 2*i+1 <= j <= 2*i+2 and j < h.Len()
+//
+// 注意，此接口中的 Push 和 Pop 是供 heap 包的实现调用的。要向堆中添加或者从堆中移除元素，
+// 请使用 heap.Push 和 heap.Pop。
 type Interface interface {
 	sort.Interface
+	// 添加 x 作为第 Len() 个元素
 	Push(x interface{}) // add x as element Len()
-	Pop() interface{}   // remove and return element Len() - 1.
+	// 移除并返回第 Len() - 1 个元素。
+	Pop() interface{} // remove and return element Len() - 1.
 }
 
 // Init establishes the heap invariants required by the other routines in this package.
 // Init is idempotent with respect to the heap invariants
 // and may be called whenever the heap invariants may have been invalidated.
 // Its complexity is O(n) where n = h.Len().
+//
+// Init 建立此包中其他例程所需要的堆不变式。
+// 对于堆不变式来说 Init 是幂等的，每当堆不变式可能被破坏时都可以调用它。
+// 它的复杂度是 O(n)，其中 n = h.Len()。
+//
+// IMP: 从最后一个非叶子节点 n/2-1 开始自底向上地下沉。虽然每次下沉是 O(log(n))，但大部分
+// 节点都在树的底层，下沉的距离很短，所以总的复杂度是 O(n) 而不是 O(n*log(n))。
 func Init(h Interface) {
 	// heapify
+	//
+	// 堆化
 	n := h.Len()
 	for i := n/2 - 1; i >= 0; i-- {
 		down(h, i, n)
@@ -50,6 +86,9 @@ func Init(h Interface) {
 // Push pushes the element x onto the heap. The complexity is
 // O(log(n)) where n = h.Len().
 //
+// Push 将元素 x 压入堆中。复杂度是 O(log(n))，其中 n = h.Len()。
+//
+// IMP: 先把 x 追加到末尾，再将它上浮到合适的位置。
 func Push(h Interface, x interface{}) {
 	h.Push(x)
 	up(h, h.Len()-1)
@@ -59,6 +98,11 @@ func Push(h Interface, x interface{}) {
 // and returns it. The complexity is O(log(n)) where n = h.Len().
 // It is equivalent to Remove(h, 0).
 //
+// Pop 从堆中移除并返回最小的元素（根据 Less）。复杂度是 O(log(n))，其中 n = h.Len()。
+// 它等价于 Remove(h, 0)。
+//
+// IMP: 将根与最后一个元素交换，在除最后一个元素之外的部分中下沉新的根，最后由 h.Pop 移除
+// 位于末尾的原来的根。
 func Pop(h Interface) interface{} {
 	n := h.Len() - 1
 	h.Swap(0, n)
@@ -69,6 +113,11 @@ func Pop(h Interface) interface{} {
 // Remove removes the element at index i from the heap.
 // The complexity is O(log(n)) where n = h.Len().
 //
+// Remove 从堆中移除索引为 i 的元素。
+// 复杂度是 O(log(n))，其中 n = h.Len()。
+//
+// IMP: 换到位置 i 的末尾元素可能比 i 的子节点大，也可能比 i 的父节点小，所以先尝试下沉，
+// 没有下沉时再尝试上浮。
 func Remove(h Interface, i int) interface{} {
 	n := h.Len() - 1
 	if n != i {
@@ -84,15 +133,26 @@ func Remove(h Interface, i int) interface{} {
 // Changing the value of the element at index i and then calling Fix is equivalent to,
 // but less expensive than, calling Remove(h, i) followed by a Push of the new value.
 // The complexity is O(log(n)) where n = h.Len().
+//
+// Fix 在索引为 i 的元素的值改变之后重新建立堆的顺序。
+// 改变索引为 i 的元素的值然后调用 Fix，等价于先调用 Remove(h, i) 再 Push 新值，但开销
+// 更小。
+// 复杂度是 O(log(n))，其中 n = h.Len()。
 func Fix(h Interface, i int) {
 	if !down(h, i, h.Len()) {
 		up(h, i)
 	}
 }
 
+// up moves the element at index j towards the root until it is not less
+// than its parent.
+//
+// up 将索引为 j 的元素向根的方向移动，直到它不小于它的父节点。
 func up(h Interface, j int) {
 	for {
+		// 父节点
 		i := (j - 1) / 2 // parent
+		// IMP: j 为 0 时 (0-1)/2 在 Go 中向零取整等于 0，所以 i == j 表示已经到达根。
 		if i == j || !h.Less(j, i) {
 			break
 		}
@@ -101,15 +161,25 @@ func up(h Interface, j int) {
 	}
 }
 
+// down moves the element at index i0 away from the root, considering only
+// the first n elements, until it is not greater than its children. It
+// reports whether the element moved.
+//
+// down 将索引为 i0 的元素向远离根的方向移动（只考虑前 n 个元素），直到它不大于它的子节点。
+// 它报告该元素是否移动了。
 func down(h Interface, i0, n int) bool {
 	i := i0
 	for {
 		j1 := 2*i + 1
+		// int 溢出之后 j1 < 0
 		if j1 >= n || j1 < 0 { // j1 < 0 after int overflow
 			break
 		}
+		// 左子节点
 		j := j1 // left child
+		// IMP: 和两个子节点中较小的那个比较，这样交换之后新的父节点一定不大于另一个子节点。
 		if j2 := j1 + 1; j2 < n && h.Less(j2, j1) {
+			// 右子节点
 			j = j2 // = 2*i + 2  // right child
 		}
 		if !h.Less(j, i) {
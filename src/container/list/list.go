@@ -5,30 +5,60 @@
 // Package list implements a doubly linked list.
 //
 // To iterate over a list (where l is a *List):
+//
 //	for e := l.Front(); e != nil; e = e.Next() {
 //		// do something with e.Value
 //	}
 //
+// Package list 实现了一个双向链表。
+//
+// 遍历一个链表（其中 l 是 *List）：
+//
+//	for e := l.Front(); e != nil; e = e.Next() {
+//		// 使用 e.Value 做一些事情
+//	}
+//
+// IMP: 链表内部是一个带哨兵节点 root 的环
+//
+//	root <-> e1 <-> e2 <-> ... <-> en <-> root
+//
+// root.next 是第一个元素，root.prev 是最后一个元素。有了哨兵节点，插入和删除时就不需要
+// 特殊处理头尾和空链表的情况。
 package list
 
 // Element is an element of a linked list.
+//
+// Element 是链表中的一个元素。
 type Element struct {
 	// Next and previous pointers in the doubly-linked list of elements.
 	// To simplify the implementation, internally a list l is implemented
 	// as a ring, such that &l.root is both the next element of the last
 	// list element (l.Back()) and the previous element of the first list
 	// element (l.Front()).
+	//
+	// 元素双向链表中的后继指针和前驱指针。
+	// 为了简化实现，链表 l 在内部被实现为一个环，这样 &l.root 既是链表最后一个元素（l.Back()）
+	// 的后继元素，也是链表第一个元素（l.Front()）的前驱元素。
 	next, prev *Element
 
 	// The list to which this element belongs.
+	//
+	// 该元素所属的链表。
+	// IMP: 通过 list 判断元素是否属于某个链表，防止把其他链表的元素传给 Remove、MoveToFront
+	// 等方法而破坏链表结构。
 	list *List
 
 	// The value stored with this element.
+	//
+	// 该元素中储存的值。
 	Value interface{}
 }
 
 // Next returns the next list element or nil.
+//
+// Next 返回链表中的后一个元素或者 nil。
 func (e *Element) Next() *Element {
+	// IMP: 后继是哨兵节点说明 e 是最后一个元素；e.list 为 nil 说明 e 已经被移除了。
 	if p := e.next; e.list != nil && p != &e.list.root {
 		return p
 	}
@@ -36,6 +66,8 @@ func (e *Element) Next() *Element {
 }
 
 // Prev returns the previous list element or nil.
+//
+// Prev 返回链表中的前一个元素或者 nil。
 func (e *Element) Prev() *Element {
 	if p := e.prev; e.list != nil && p != &e.list.root {
 		return p
@@ -45,12 +77,21 @@ func (e *Element) Prev() *Element {
 
 // List represents a doubly linked list.
 // The zero value for List is an empty list ready to use.
+//
+// List 代表一个双向链表。
+// List 的零值是一个可以直接使用的空链表。
 type List struct {
+	// 哨兵元素，只使用 &root、root.prev 和 root.next
 	root Element // sentinel list element, only &root, root.prev, and root.next are used
-	len  int     // current list length excluding (this) sentinel element
+	// 当前链表的长度，不包括（这个）哨兵元素
+	len int // current list length excluding (this) sentinel element
 }
 
 // Init initializes or clears list l.
+//
+// Init 初始化或者清空链表 l。
+//
+// IMP: 清空时只是让 root 指向自己，原来的元素的 list 字段不会被修改。
 func (l *List) Init() *List {
 	l.root.next = &l.root
 	l.root.prev = &l.root
@@ -59,13 +100,20 @@ func (l *List) Init() *List {
 }
 
 // New returns an initialized list.
+//
+// New 返回一个初始化过的链表。
 func New() *List { return new(List).Init() }
 
 // Len returns the number of elements of list l.
 // The complexity is O(1).
+//
+// Len 返回链表 l 中元素的数量。
+// 复杂度是 O(1)。
 func (l *List) Len() int { return l.len }
 
 // Front returns the first element of list l or nil if the list is empty.
+//
+// Front 返回链表 l 的第一个元素，如果链表为空则返回 nil。
 func (l *List) Front() *Element {
 	if l.len == 0 {
 		return nil
@@ -74,6 +122,8 @@ func (l *List) Front() *Element {
 }
 
 // Back returns the last element of list l or nil if the list is empty.
+//
+// Back 返回链表 l 的最后一个元素，如果链表为空则返回 nil。
 func (l *List) Back() *Element {
 	if l.len == 0 {
 		return nil
@@ -82,6 +132,9 @@ func (l *List) Back() *Element {
 }
 
 // lazyInit lazily initializes a zero List value.
+//
+// lazyInit 延迟初始化一个 List 零值。
+// IMP: 零值 List 的 root.next 为 nil，这也是零值 List 可以直接使用的原因。
 func (l *List) lazyInit() {
 	if l.root.next == nil {
 		l.Init()
@@ -89,6 +142,8 @@ func (l *List) lazyInit() {
 }
 
 // insert inserts e after at, increments l.len, and returns e.
+//
+// insert 将 e 插入到 at 之后，增加 l.len，并返回 e。
 func (l *List) insert(e, at *Element) *Element {
 	n := at.next
 	at.next = e
@@ -101,15 +156,21 @@ func (l *List) insert(e, at *Element) *Element {
 }
 
 // insertValue is a convenience wrapper for insert(&Element{Value: v}, at).
+//
+// insertValue 是 insert(&Element{Value: v}, at) 的便捷包装。
 func (l *List) insertValue(v interface{}, at *Element) *Element {
 	return l.insert(&Element{Value: v}, at)
 }
 
 // remove removes e from its list, decrements l.len, and returns e.
+//
+// remove 将 e 从它所在的链表中移除，减少 l.len，并返回 e。
 func (l *List) remove(e *Element) *Element {
 	e.prev.next = e.next
 	e.next.prev = e.prev
+	// 避免内存泄漏
 	e.next = nil // avoid memory leaks
+	// 避免内存泄漏
 	e.prev = nil // avoid memory leaks
 	e.list = nil
 	l.len--
@@ -119,22 +180,33 @@ func (l *List) remove(e *Element) *Element {
 // Remove removes e from l if e is an element of list l.
 // It returns the element value e.Value.
 // The element must not be nil.
+//
+// 如果 e 是链表 l 中的元素，Remove 将 e 从 l 中移除。
+// 它返回元素的值 e.Value。
+// 该元素不能为 nil。
 func (l *List) Remove(e *Element) interface{} {
 	if e.list == l {
 		// if e.list == l, l must have been initialized when e was inserted
 		// in l or l == nil (e is a zero Element) and l.remove will crash
+		//
+		// 如果 e.list == l，那么在 e 被插入到 l 中时 l 一定已经被初始化了，或者 l == nil
+		// （e 是一个零值 Element），此时 l.remove 会崩溃
 		l.remove(e)
 	}
 	return e.Value
 }
 
 // PushFront inserts a new element e with value v at the front of list l and returns e.
+//
+// PushFront 在链表 l 的头部插入一个值为 v 的新元素 e，并返回 e。
 func (l *List) PushFront(v interface{}) *Element {
 	l.lazyInit()
 	return l.insertValue(v, &l.root)
 }
 
 // PushBack inserts a new element e with value v at the back of list l and returns e.
+//
+// PushBack 在链表 l 的尾部插入一个值为 v 的新元素 e，并返回 e。
 func (l *List) PushBack(v interface{}) *Element {
 	l.lazyInit()
 	return l.insertValue(v, l.root.prev)
@@ -143,50 +215,78 @@ func (l *List) PushBack(v interface{}) *Element {
 // InsertBefore inserts a new element e with value v immediately before mark and returns e.
 // If mark is not an element of l, the list is not modified.
 // The mark must not be nil.
+//
+// InsertBefore 在 mark 之前插入一个值为 v 的新元素 e，并返回 e。
+// 如果 mark 不是 l 中的元素，链表不会被修改。
+// mark 不能为 nil。
 func (l *List) InsertBefore(v interface{}, mark *Element) *Element {
 	if mark.list != l {
 		return nil
 	}
 	// see comment in List.Remove about initialization of l
+	//
+	// 关于 l 的初始化，请参阅 List.Remove 中的注释
 	return l.insertValue(v, mark.prev)
 }
 
 // InsertAfter inserts a new element e with value v immediately after mark and returns e.
 // If mark is not an element of l, the list is not modified.
 // The mark must not be nil.
+//
+// InsertAfter 在 mark 之后插入一个值为 v 的新元素 e，并返回 e。
+// 如果 mark 不是 l 中的元素，链表不会被修改。
+// mark 不能为 nil。
 func (l *List) InsertAfter(v interface{}, mark *Element) *Element {
 	if mark.list != l {
 		return nil
 	}
 	// see comment in List.Remove about initialization of l
+	//
+	// 关于 l 的初始化，请参阅 List.Remove 中的注释
 	return l.insertValue(v, mark)
 }
 
 // MoveToFront moves element e to the front of list l.
 // If e is not an element of l, the list is not modified.
 // The element must not be nil.
+//
+// MoveToFront 将元素 e 移动到链表 l 的头部。
+// 如果 e 不是 l 中的元素，链表不会被修改。
+// 该元素不能为 nil。
 func (l *List) MoveToFront(e *Element) {
 	if e.list != l || l.root.next == e {
 		return
 	}
 	// see comment in List.Remove about initialization of l
+	//
+	// 关于 l 的初始化，请参阅 List.Remove 中的注释
 	l.insert(l.remove(e), &l.root)
 }
 
 // MoveToBack moves element e to the back of list l.
 // If e is not an element of l, the list is not modified.
 // The element must not be nil.
+//
+// MoveToBack 将元素 e 移动到链表 l 的尾部。
+// 如果 e 不是 l 中的元素，链表不会被修改。
+// 该元素不能为 nil。
 func (l *List) MoveToBack(e *Element) {
 	if e.list != l || l.root.prev == e {
 		return
 	}
 	// see comment in List.Remove about initialization of l
+	//
+	// 关于 l 的初始化，请参阅 List.Remove 中的注释
 	l.insert(l.remove(e), l.root.prev)
 }
 
 // MoveBefore moves element e to its new position before mark.
 // If e or mark is not an element of l, or e == mark, the list is not modified.
 // The element and mark must not be nil.
+//
+// MoveBefore 将元素 e 移动到 mark 之前的新位置。
+// 如果 e 或 mark 不是 l 中的元素，或者 e == mark，链表不会被修改。
+// 该元素和 mark 都不能为 nil。
 func (l *List) MoveBefore(e, mark *Element) {
 	if e.list != l || e == mark || mark.list != l {
 		return
@@ -197,6 +297,10 @@ func (l *List) MoveBefore(e, mark *Element) {
 // MoveAfter moves element e to its new position after mark.
 // If e or mark is not an element of l, or e == mark, the list is not modified.
 // The element and mark must not be nil.
+//
+// MoveAfter 将元素 e 移动到 mark 之后的新位置。
+// 如果 e 或 mark 不是 l 中的元素，或者 e == mark，链表不会被修改。
+// 该元素和 mark 都不能为 nil。
 func (l *List) MoveAfter(e, mark *Element) {
 	if e.list != l || e == mark || mark.list != l {
 		return
@@ -206,6 +310,12 @@ func (l *List) MoveAfter(e, mark *Element) {
 
 // PushBackList inserts a copy of an other list at the back of list l.
 // The lists l and other may be the same. They must not be nil.
+//
+// PushBackList 在链表 l 的尾部插入另一个链表的副本。
+// 链表 l 和 other 可以是同一个链表。它们都不能为 nil。
+//
+// IMP: 循环次数取自 other.Len() 的初始值，所以即使 l 和 other 是同一个链表，新插入的元素
+// 也不会被再次遍历，循环一定会终止。
 func (l *List) PushBackList(other *List) {
 	l.lazyInit()
 	for i, e := other.Len(), other.Front(); i > 0; i, e = i-1, e.Next() {
@@ -215,6 +325,9 @@ func (l *List) PushBackList(other *List) {
 
 // PushFrontList inserts a copy of an other list at the front of list l.
 // The lists l and other may be the same. They must not be nil.
+//
+// PushFrontList 在链表 l 的头部插入另一个链表的副本。
+// 链表 l 和 other 可以是同一个链表。它们都不能为 nil。
 func (l *List) PushFrontList(other *List) {
 	l.lazyInit()
 	for i, e := other.Len(), other.Back(); i > 0; i, e = i-1, e.Prev() {
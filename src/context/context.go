@@ -45,6 +45,52 @@
 //
 // See https://blog.golang.org/context for example code for a server that uses
 // Contexts.
+//
+// Package context 定义了 Context 类型，它在 API 边界之间以及进程之间传递截止时间、取消
+// 信号以及其他请求范围内的值。
+//
+// 服务器接收的请求应该创建一个 Context，而对服务器的调用应该接受一个 Context。它们之间的
+// 函数调用链必须传递 Context，也可以将其替换为使用 WithCancel、WithDeadline、WithTimeout
+// 或 WithValue 创建的派生 Context。当一个 Context 被取消时，所有从它派生的 Context 也会
+// 被取消。
+//
+// WithCancel、WithDeadline 和 WithTimeout 函数接受一个 Context（父节点）并返回一个派生
+// 的 Context（子节点）以及一个 CancelFunc。调用 CancelFunc 会取消该子节点及其所有子节点，
+// 删除父节点对该子节点的引用，并停止所有关联的计时器。不调用 CancelFunc 会导致子节点及其
+// 所有子节点泄漏，直到父节点被取消或者计时器触发。go vet 工具会检查所有控制流路径上是否
+// 都使用了 CancelFunc。
+//
+// 使用 Context 的程序应该遵守下面这些规则，以保持包之间接口的一致性，并使静态分析工具能够
+// 检查 context 的传递：
+//
+// 不要将 Context 储存在结构体类型中，而是将 Context 显式地传给每个需要它的函数。Context
+// 应该是第一个参数，通常命名为 ctx：
+//
+// 	func DoSomething(ctx context.Context, arg Arg) error {
+// 		// ... use ctx ...
+// 	}
+//
+// 不要传递 nil Context，即使函数允许这样做。如果你不确定使用哪个 Context，请传递
+// context.TODO。
+//
+// context 的 Value 只应该用于在进程和 API 之间传递的请求范围内的数据，而不是用于向函数
+// 传递可选参数。
+//
+// 同一个 Context 可以传给运行在不同 goroutine 中的函数，多个 goroutine 同时使用 Context
+// 是安全的。
+//
+// 有关使用 Context 的服务器的示例代码，请看 https://blog.golang.org/context。
+//
+// IMP: context 的派生关系构成了一棵树
+//
+//	           Background
+//	            /      \
+//	     WithCancel   WithValue
+//	      /     \          \
+//	WithTimeout WithValue  WithCancel
+//
+// 取消一个节点会沿着 children 向下传递，取消它的整棵子树，但不会影响它的父节点。
+// WithValue 创建的 valueCtx 不能被取消，它只是将取消关系透传给更上层的 cancelCtx。
 package context
 
 import (
@@ -59,10 +105,17 @@ import (
 // API boundaries.
 //
 // Context's methods may be called by multiple goroutines simultaneously.
+//
+// Context 在 API 边界之间传递截止时间、取消信号以及其他值。
+//
+// Context 的方法可以被多个 goroutine 同时调用。
 type Context interface {
 	// Deadline returns the time when work done on behalf of this context
 	// should be canceled. Deadline returns ok==false when no deadline is
 	// set. Successive calls to Deadline return the same results.
+	//
+	// Deadline 返回代表此 context 完成的工作应该被取消的时间。当没有设置截止时间时，
+	// Deadline 返回 ok==false。连续调用 Deadline 会返回相同的结果。
 	Deadline() (deadline time.Time, ok bool)
 
 	// Done returns a channel that's closed when work done on behalf of this
@@ -94,6 +147,18 @@ type Context interface {
 	//
 	// See https://blog.golang.org/pipelines for more examples of how to use
 	// a Done channel for cancelation.
+	//
+	// Done 返回一个通道，当代表此 context 完成的工作应该被取消时，该通道会被关闭。如果此
+	// context 永远不会被取消，Done 可能返回 nil。连续调用 Done 会返回相同的值。
+	//
+	// WithCancel 使 Done 在调用 cancel 时被关闭；WithDeadline 使 Done 在截止时间到达时
+	// 被关闭；WithTimeout 使 Done 在超时时被关闭。
+	//
+	// Done 用于 select 语句中，示例见上。
+	//
+	// 更多关于如何使用 Done 通道进行取消的示例，请看 https://blog.golang.org/pipelines。
+	// IMP: 关闭的通道可以被无数次接收，所以关闭通道是一种广播，所有等待的 goroutine 都会
+	// 收到通知。
 	Done() <-chan struct{}
 
 	// If Done is not yet closed, Err returns nil.
@@ -101,6 +166,11 @@ type Context interface {
 	// Canceled if the context was canceled
 	// or DeadlineExceeded if the context's deadline passed.
 	// After Err returns a non-nil error, successive calls to Err return the same error.
+	//
+	// 如果 Done 还没有被关闭，Err 返回 nil。
+	// 如果 Done 已经被关闭，Err 返回一个非 nil 的错误来解释原因：
+	// 如果 context 被取消，返回 Canceled；如果 context 的截止时间已过，返回 DeadlineExceeded。
+	// 在 Err 返回一个非 nil 的错误后，连续调用 Err 会返回相同的错误。
 	Err() error
 
 	// Value returns the value associated with this context for key, or nil
@@ -148,14 +218,32 @@ type Context interface {
 	// 		u, ok := ctx.Value(userKey).(*User)
 	// 		return u, ok
 	// 	}
+	//
+	// Value 返回与此 context 中 key 关联的值，如果没有与 key 关联的值则返回 nil。使用相同
+	// 的 key 连续调用 Value 会返回相同的结果。
+	//
+	// context 的值只应该用于在进程和 API 边界之间传递的请求范围内的数据，而不是用于向函数
+	// 传递可选参数。
+	//
+	// key 标识了 Context 中一个特定的值。希望在 Context 中储存值的函数通常会在全局变量中
+	// 分配一个 key，然后将该 key 作为 context.WithValue 和 Context.Value 的参数。key 可以
+	// 是任何支持相等比较的类型，包应该将 key 定义为未导出的类型以避免冲突。
+	//
+	// 定义了 Context key 的包应该为使用该 key 储存的值提供类型安全的访问器，示例见上。
 	Value(key interface{}) interface{}
 }
 
 // Canceled is the error returned by Context.Err when the context is canceled.
+//
+// Canceled 是 context 被取消时 Context.Err 返回的错误。
 var Canceled = errors.New("context canceled")
 
 // DeadlineExceeded is the error returned by Context.Err when the context's
 // deadline passes.
+//
+// DeadlineExceeded 是 context 的截止时间已过时 Context.Err 返回的错误。
+// IMP: 它实现了 net.Error 接口的 Timeout 和 Temporary 方法，所以网络相关的代码可以将其
+// 当作超时错误处理。
 var DeadlineExceeded error = deadlineExceededError{}
 
 type deadlineExceededError struct{}
@@ -166,6 +254,10 @@ func (deadlineExceededError) Temporary() bool { return true }
 
 // An emptyCtx is never canceled, has no values, and has no deadline. It is not
 // struct{}, since vars of this type must have distinct addresses.
+//
+// emptyCtx 永远不会被取消，没有值，也没有截止时间。它不是 struct{}，因为此类型的变量必须
+// 有不同的地址。
+// IMP: 零大小的变量（如 struct{}）可能共享同一个地址，这样 background 和 todo 就无法区分了。
 type emptyCtx int
 
 func (*emptyCtx) Deadline() (deadline time.Time, ok bool) {
@@ -203,6 +295,9 @@ var (
 // values, and has no deadline. It is typically used by the main function,
 // initialization, and tests, and as the top-level Context for incoming
 // requests.
+//
+// Background 返回一个非 nil 的空 Context。它永远不会被取消，没有值，也没有截止时间。它通常
+// 被 main 函数、初始化和测试使用，并作为接收到的请求的顶层 Context。
 func Background() Context {
 	return background
 }
@@ -212,6 +307,10 @@ func Background() Context {
 // surrounding function has not yet been extended to accept a Context
 // parameter). TODO is recognized by static analysis tools that determine
 // whether Contexts are propagated correctly in a program.
+//
+// TODO 返回一个非 nil 的空 Context。当不清楚使用哪个 Context 或者还没有可用的 Context 时
+// （因为外层函数还没有被扩展为接受 Context 参数），代码应该使用 context.TODO。静态分析工具
+// 可以识别 TODO，用于判断程序中的 Context 是否被正确地传递。
 func TODO() Context {
 	return todo
 }
@@ -219,6 +318,10 @@ func TODO() Context {
 // A CancelFunc tells an operation to abandon its work.
 // A CancelFunc does not wait for the work to stop.
 // After the first call, subsequent calls to a CancelFunc do nothing.
+//
+// CancelFunc 通知一个操作放弃它的工作。
+// CancelFunc 不会等待工作停止。
+// 在第一次调用之后，后续对 CancelFunc 的调用不会做任何事情。
 type CancelFunc func()
 
 // WithCancel returns a copy of parent with a new Done channel. The returned
@@ -227,6 +330,12 @@ type CancelFunc func()
 //
 // Canceling this context releases resources associated with it, so code should
 // call cancel as soon as the operations running in this Context complete.
+//
+// WithCancel 返回一个带有新 Done 通道的 parent 副本。当返回的 cancel 函数被调用或者父
+// context 的 Done 通道被关闭时（以先发生者为准），返回的 context 的 Done 通道会被关闭。
+//
+// 取消此 context 会释放与它关联的资源，所以在此 Context 中运行的操作完成后，代码应该立即
+// 调用 cancel。
 func WithCancel(parent Context) (ctx Context, cancel CancelFunc) {
 	c := newCancelCtx(parent)
 	propagateCancel(parent, &c)
@@ -234,19 +343,29 @@ func WithCancel(parent Context) (ctx Context, cancel CancelFunc) {
 }
 
 // newCancelCtx returns an initialized cancelCtx.
+//
+// newCancelCtx 返回一个初始化的 cancelCtx。
 func newCancelCtx(parent Context) cancelCtx {
 	return cancelCtx{Context: parent}
 }
 
 // propagateCancel arranges for child to be canceled when parent is.
+//
+// propagateCancel 使 child 在 parent 被取消时也被取消。
 func propagateCancel(parent Context, child canceler) {
 	if parent.Done() == nil {
+		// 父节点永远不会被取消
 		return // parent is never canceled
 	}
+	// IMP: 如果父节点是本包中的 cancelCtx（或者包装了 cancelCtx），直接将 child 挂到它的
+	// children 上，由父节点在取消时同步地取消 child。否则只能启动一个 goroutine 来监听
+	// 父节点的 Done 通道。
 	if p, ok := parentCancelCtx(parent); ok {
 		p.mu.Lock()
 		if p.err != nil {
 			// parent has already been canceled
+			//
+			// 父节点已经被取消
 			child.cancel(false, p.err)
 		} else {
 			if p.children == nil {
@@ -269,6 +388,10 @@ func propagateCancel(parent Context, child canceler) {
 // parentCancelCtx follows a chain of parent references until it finds a
 // *cancelCtx. This function understands how each of the concrete types in this
 // package represents its parent.
+//
+// parentCancelCtx 沿着父节点的引用链查找，直到找到一个 *cancelCtx。此函数了解本包中每个
+// 具体类型是如何表示其父节点的。
+// IMP: valueCtx 会被跳过，所以 WithValue 不会打断取消的传递。
 func parentCancelCtx(parent Context) (*cancelCtx, bool) {
 	for {
 		switch c := parent.(type) {
@@ -285,6 +408,8 @@ func parentCancelCtx(parent Context) (*cancelCtx, bool) {
 }
 
 // removeChild removes a context from its parent.
+//
+// removeChild 将一个 context 从它的父节点中删除。
 func removeChild(parent Context, child canceler) {
 	p, ok := parentCancelCtx(parent)
 	if !ok {
@@ -299,12 +424,16 @@ func removeChild(parent Context, child canceler) {
 
 // A canceler is a context type that can be canceled directly. The
 // implementations are *cancelCtx and *timerCtx.
+//
+// canceler 是可以被直接取消的 context 类型。它的实现有 *cancelCtx 和 *timerCtx。
 type canceler interface {
 	cancel(removeFromParent bool, err error)
 	Done() <-chan struct{}
 }
 
 // closedchan is a reusable closed channel.
+//
+// closedchan 是一个可重复使用的已关闭的通道。
 var closedchan = make(chan struct{})
 
 func init() {
@@ -313,13 +442,19 @@ func init() {
 
 // A cancelCtx can be canceled. When canceled, it also cancels any children
 // that implement canceler.
+//
+// cancelCtx 可以被取消。当它被取消时，它也会取消所有实现了 canceler 的子节点。
 type cancelCtx struct {
 	Context
 
-	mu       sync.Mutex            // protects following fields
-	done     chan struct{}         // created lazily, closed by first cancel call
+	// 保护下面的字段
+	mu sync.Mutex // protects following fields
+	// 延迟创建，第一次调用 cancel 时被关闭
+	done chan struct{} // created lazily, closed by first cancel call
+	// 第一次调用 cancel 时被设置为 nil
 	children map[canceler]struct{} // set to nil by the first cancel call
-	err      error                 // set to non-nil by the first cancel call
+	// 第一次调用 cancel 时被设置为非 nil
+	err error // set to non-nil by the first cancel call
 }
 
 func (c *cancelCtx) Done() <-chan struct{} {
@@ -345,6 +480,9 @@ func (c *cancelCtx) String() string {
 
 // cancel closes c.done, cancels each of c's children, and, if
 // removeFromParent is true, removes c from its parent's children.
+//
+// cancel 关闭 c.done，取消 c 的每个子节点，并且如果 removeFromParent 为 true，将 c 从其
+// 父节点的 children 中删除。
 func (c *cancelCtx) cancel(removeFromParent bool, err error) {
 	if err == nil {
 		panic("context: internal error: missing cancel error")
@@ -352,9 +490,11 @@ func (c *cancelCtx) cancel(removeFromParent bool, err error) {
 	c.mu.Lock()
 	if c.err != nil {
 		c.mu.Unlock()
+		// 已经被取消
 		return // already canceled
 	}
 	c.err = err
+	// IMP: 如果从未调用过 Done，则直接使用已关闭的 closedchan，省去一次通道的创建。
 	if c.done == nil {
 		c.done = closedchan
 	} else {
@@ -362,6 +502,10 @@ func (c *cancelCtx) cancel(removeFromParent bool, err error) {
 	}
 	for child := range c.children {
 		// NOTE: acquiring the child's lock while holding parent's lock.
+		//
+		// NOTE: 在持有父节点锁的同时获取子节点的锁。
+		// IMP: 子节点传入的 removeFromParent 为 false，因为下面会直接将 c.children 置为
+		// nil，而且此时持有 c.mu，子节点再去删除自己会导致死锁。
 		child.cancel(false, err)
 	}
 	c.children = nil
@@ -381,9 +525,19 @@ func (c *cancelCtx) cancel(removeFromParent bool, err error) {
 //
 // Canceling this context releases resources associated with it, so code should
 // call cancel as soon as the operations running in this Context complete.
+//
+// WithDeadline 返回一个父 context 的副本，其截止时间被调整为不晚于 d。如果父节点的截止时间
+// 已经早于 d，则 WithDeadline(parent, d) 在语义上等价于 parent。当截止时间到达、返回的
+// cancel 函数被调用或者父 context 的 Done 通道被关闭时（以先发生者为准），返回的 context
+// 的 Done 通道会被关闭。
+//
+// 取消此 context 会释放与它关联的资源，所以在此 Context 中运行的操作完成后，代码应该立即
+// 调用 cancel。
 func WithDeadline(parent Context, d time.Time) (Context, CancelFunc) {
 	if cur, ok := parent.Deadline(); ok && cur.Before(d) {
 		// The current deadline is already sooner than the new one.
+		//
+		// 当前的截止时间已经比新的截止时间更早。
 		return WithCancel(parent)
 	}
 	c := &timerCtx{
@@ -393,11 +547,13 @@ func WithDeadline(parent Context, d time.Time) (Context, CancelFunc) {
 	propagateCancel(parent, c)
 	dur := time.Until(d)
 	if dur <= 0 {
+		// 截止时间已过
 		c.cancel(true, DeadlineExceeded) // deadline has already passed
 		return c, func() { c.cancel(true, Canceled) }
 	}
 	c.mu.Lock()
 	defer c.mu.Unlock()
+	// IMP: propagateCancel 可能已经因为父节点被取消而取消了 c，此时不需要再创建计时器。
 	if c.err == nil {
 		c.timer = time.AfterFunc(dur, func() {
 			c.cancel(true, DeadlineExceeded)
@@ -409,8 +565,12 @@ func WithDeadline(parent Context, d time.Time) (Context, CancelFunc) {
 // A timerCtx carries a timer and a deadline. It embeds a cancelCtx to
 // implement Done and Err. It implements cancel by stopping its timer then
 // delegating to cancelCtx.cancel.
+//
+// timerCtx 带有一个计时器和一个截止时间。它嵌入了一个 cancelCtx 来实现 Done 和 Err。它通过
+// 停止计时器然后委托给 cancelCtx.cancel 来实现 cancel。
 type timerCtx struct {
 	cancelCtx
+	// 受 cancelCtx.mu 保护
 	timer *time.Timer // Under cancelCtx.mu.
 
 	deadline time.Time
@@ -428,6 +588,10 @@ func (c *timerCtx) cancel(removeFromParent bool, err error) {
 	c.cancelCtx.cancel(false, err)
 	if removeFromParent {
 		// Remove this timerCtx from its parent cancelCtx's children.
+		//
+		// 将此 timerCtx 从其父 cancelCtx 的 children 中删除。
+		// IMP: 父节点的 children 中储存的是 timerCtx 而不是嵌入的 cancelCtx，所以这里
+		// 不能由 cancelCtx.cancel 来删除。
 		removeChild(c.cancelCtx.Context, c)
 	}
 	c.mu.Lock()
@@ -448,6 +612,11 @@ func (c *timerCtx) cancel(removeFromParent bool, err error) {
 // 		defer cancel()  // releases resources if slowOperation completes before timeout elapses
 // 		return slowOperation(ctx)
 // 	}
+//
+// WithTimeout 返回 WithDeadline(parent, time.Now().Add(timeout))。
+//
+// 取消此 context 会释放与它关联的资源，所以在此 Context 中运行的操作完成后，代码应该立即
+// 调用 cancel，示例见上。
 func WithTimeout(parent Context, timeout time.Duration) (Context, CancelFunc) {
 	return WithDeadline(parent, time.Now().Add(timeout))
 }
@@ -465,6 +634,16 @@ func WithTimeout(parent Context, timeout time.Duration) (Context, CancelFunc) {
 // interface{}, context keys often have concrete type
 // struct{}. Alternatively, exported context key variables' static
 // type should be a pointer or interface.
+//
+// WithValue 返回一个 parent 的副本，其中与 key 关联的值为 val。
+//
+// context 的 Value 只应该用于在进程和 API 之间传递的请求范围内的数据，而不是用于向函数
+// 传递可选参数。
+//
+// 提供的 key 必须是可比较的，并且不应该是 string 或者任何其他内置类型，以避免使用 context
+// 的包之间发生冲突。WithValue 的使用者应该为 key 定义自己的类型。为了避免赋值给 interface{}
+// 时发生内存分配，context 的 key 通常使用具体类型 struct{}。或者，导出的 context key 变量
+// 的静态类型应该是指针或接口。
 func WithValue(parent Context, key, val interface{}) Context {
 	if key == nil {
 		panic("nil key")
@@ -477,6 +656,8 @@ func WithValue(parent Context, key, val interface{}) Context {
 
 // A valueCtx carries a key-value pair. It implements Value for that key and
 // delegates all other calls to the embedded Context.
+//
+// valueCtx 带有一个键值对。它为该 key 实现了 Value，并将所有其他调用委托给嵌入的 Context。
 type valueCtx struct {
 	Context
 	key, val interface{}
@@ -486,6 +667,8 @@ func (c *valueCtx) String() string {
 	return fmt.Sprintf("%v.WithValue(%#v, %#v)", c.Context, c.key, c.val)
 }
 
+// IMP: 查找是沿着父节点链表线性进行的，所以 Value 的时间复杂度为 O(n)，n 为链上 valueCtx
+// 的数量。这也是不应该用 context 传递大量值的原因之一。
 func (c *valueCtx) Value(key interface{}) interface{} {
 	if c.key == key {
 		return c.val
@@ -0,0 +1,168 @@
+// Copyright 2018 Li Zebang. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+// Package diag routes the diagnostics of flag, bytes and sync, such as
+// flag parse tracing, Buffer growth and Mutex contention, to a single
+// Logger installed by the application. Until SetLogger is called nothing
+// is logged, and a package that reports an event only pays for a load and
+// a nil check.
+//
+// diag is at the bottom of the import graph: it only imports sync/atomic,
+// so sync and bytes can import it.
+//
+// Package diag 将 flag、bytes 和 sync 的诊断信息，例如 flag 的解析跟踪、Buffer 的扩容和
+// Mutex 的争用，交给应用程序设置的唯一一个 Logger。在调用 SetLogger 之前不会记录任何
+// 信息，报告事件的包只需要付出一次读取和一次 nil 检查的代价。
+//
+// diag 位于导入关系图的最底层：它只导入了 sync/atomic，所以 sync 和 bytes 可以导入它。
+package diag
+
+import "sync/atomic"
+
+// A Level is the severity of a diagnostic.
+//
+// Level 是诊断信息的严重程度。
+type Level int
+
+const (
+	// Debug is for tracing, such as every flag that Parse sets.
+	//
+	// Debug 用于跟踪，例如 Parse 设置的每一个标志。
+	Debug Level = iota
+
+	// Info is for notable but expected events.
+	//
+	// Info 用于值得注意但在预期之中的事件。
+	Info
+
+	// Warn is for events that usually point to a problem, such as a Mutex
+	// entering starvation mode.
+	//
+	// Warn 用于通常意味着存在问题的事件，例如 Mutex 进入饥饿模式。
+	Warn
+
+	// Error is for failures.
+	//
+	// Error 用于失败。
+	Error
+)
+
+var levelNames = [...]string{
+	Debug: "DEBUG",
+	Info:  "INFO",
+	Warn:  "WARN",
+	Error: "ERROR",
+}
+
+// String returns the upper-case name of l, such as DEBUG.
+//
+// String 返回 l 的大写名称，例如 DEBUG。
+func (l Level) String() string {
+	if 0 <= l && int(l) < len(levelNames) {
+		return levelNames[l]
+	}
+	// strconv is not at the bottom of the import graph.
+	//
+	// strconv 不在导入关系图的最底层。
+	n := int(l)
+	neg := n < 0
+	if neg {
+		n = -n
+	}
+	var b [24]byte
+	i := len(b) - 1
+	b[i] = ')'
+	for {
+		i--
+		b[i] = byte('0' + n%10)
+		n /= 10
+		if n == 0 {
+			break
+		}
+	}
+	if neg {
+		i--
+		b[i] = '-'
+	}
+	return "Level(" + string(b[i:])
+}
+
+// A Logger receives the diagnostics of the packages. Its methods may be
+// called concurrently, and from inside sync primitives, so they must not
+// block on locks that the program may hold while it calls those packages.
+// Nor should they block on sync primitives of their own: a Mutex that Log
+// waits for reports its contention to Log. sync drops the reports that
+// start while another one is in progress, which keeps such a Logger from
+// recursing, but it still deadlocks if the program locks the same Mutex
+// outside of Log.
+//
+// pkg is the import path of the reporting package, such as "sync". msg is
+// a short lower-case description of the event, and keyvals are alternating
+// string keys and values that describe it.
+//
+// Logger 接收各个包的诊断信息。它的方法可能被并发地调用，也可能在同步原语的内部被调用，
+// 所以它们不能阻塞在程序调用这些包时可能持有的锁上。它们也不应该阻塞在自己的同步原语上：
+// Log 等待的 Mutex 会把自己的争用报告给 Log。sync 会丢弃在另一个报告进行的过程中开始的
+// 报告，这使这样的 Logger 不会递归，但如果程序在 Log 之外对同一个 Mutex 加锁，它仍然会
+// 死锁。
+//
+// pkg 是报告事件的包的导入路径，例如 "sync"。msg 是对事件的简短的小写描述，keyvals 是
+// 交替出现的描述它的字符串键和值。
+type Logger interface {
+	// Enabled reports whether events of pkg at level are wanted. Packages
+	// call it before they build the keyvals of an event.
+	//
+	// Enabled 报告是否需要 pkg 中 level 级别的事件。包在构造事件的 keyvals 之前会调用它。
+	Enabled(pkg string, level Level) bool
+
+	// Log records an event.
+	//
+	// Log 记录一个事件。
+	Log(pkg string, level Level, msg string, keyvals ...interface{})
+}
+
+// loggerBox lets a nil Logger be stored in an atomic.Value, and keeps the
+// stored type the same for every Logger.
+//
+// loggerBox 使 nil Logger 可以被存储在 atomic.Value 中，并且使每个 Logger 存储的类型都相同。
+type loggerBox struct {
+	l Logger
+}
+
+var logger atomic.Value // of loggerBox
+
+// SetLogger installs l as the Logger of every package. A nil l restores the
+// default, which discards everything.
+//
+// SetLogger 将 l 设置为所有包的 Logger。nil 会恢复默认值，即丢弃所有的信息。
+func SetLogger(l Logger) {
+	logger.Store(loggerBox{l})
+}
+
+// current returns the installed Logger, or nil.
+//
+// current 返回设置的 Logger，或者 nil。
+func current() Logger {
+	b, _ := logger.Load().(loggerBox)
+	return b.l
+}
+
+// Enabled reports whether a Logger is installed and wants events of pkg
+// at level.
+//
+// Enabled 报告是否设置了 Logger 并且它需要 pkg 中 level 级别的事件。
+func Enabled(pkg string, level Level) bool {
+	l := current()
+	return l != nil && l.Enabled(pkg, level)
+}
+
+// Log passes an event to the installed Logger if it is enabled for pkg at
+// level.
+//
+// 如果设置的 Logger 需要 pkg 中 level 级别的事件，Log 将事件交给它。
+func Log(pkg string, level Level, msg string, keyvals ...interface{}) {
+	if l := current(); l != nil && l.Enabled(pkg, level) {
+		l.Log(pkg, level, msg, keyvals...)
+	}
+}
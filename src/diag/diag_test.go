@@ -0,0 +1,73 @@
+// Copyright 2018 Li Zebang. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package diag
+
+import (
+	"fmt"
+	"testing"
+)
+
+// recorder records the events of the packages it is enabled for.
+type recorder struct {
+	min    Level
+	pkgs   map[string]bool
+	events []string
+}
+
+func (r *recorder) Enabled(pkg string, level Level) bool {
+	return r.pkgs[pkg] && level >= r.min
+}
+
+func (r *recorder) Log(pkg string, level Level, msg string, keyvals ...interface{}) {
+	r.events = append(r.events, fmt.Sprintf("%s %s %s %v", pkg, level, msg, keyvals))
+}
+
+func TestLogger(t *testing.T) {
+	defer SetLogger(nil)
+
+	if Enabled("flag", Error) {
+		t.Error("Enabled before SetLogger")
+	}
+	// Log without a Logger must not panic.
+	Log("flag", Error, "dropped")
+
+	r := &recorder{min: Info, pkgs: map[string]bool{"sync": true}}
+	SetLogger(r)
+	if !Enabled("sync", Warn) || Enabled("sync", Debug) || Enabled("flag", Warn) {
+		t.Error("Enabled does not follow the Logger")
+	}
+	Log("sync", Debug, "too low")
+	Log("flag", Warn, "other package")
+	Log("sync", Warn, "mutex contended", "waitns", 5)
+	want := []string{"sync WARN mutex contended [waitns 5]"}
+	if fmt.Sprint(r.events) != fmt.Sprint(want) {
+		t.Errorf("events = %q; want %q", r.events, want)
+	}
+
+	SetLogger(nil)
+	Log("sync", Warn, "after reset")
+	if len(r.events) != 1 {
+		t.Errorf("logged %q after SetLogger(nil)", r.events[1:])
+	}
+}
+
+func TestLevelString(t *testing.T) {
+	tests := []struct {
+		level Level
+		want  string
+	}{
+		{Debug, "DEBUG"},
+		{Info, "INFO"},
+		{Warn, "WARN"},
+		{Error, "ERROR"},
+		{10, "Level(10)"},
+		{-3, "Level(-3)"},
+	}
+	for _, tt := range tests {
+		if got := tt.level.String(); got != tt.want {
+			t.Errorf("Level(%d).String() = %q; want %q", int(tt.level), got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,3 @@
+module github.com/lizebang/annotate-go-sdk/src/diag
+
+go 1.11
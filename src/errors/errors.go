@@ -3,14 +3,60 @@
 // license that can be found in the LICENSE file.
 
 // Package errors implements functions to manipulate errors.
+//
+// The New function creates errors whose only content is a text message.
+//
+// An error e wraps another error if e's type has one of the methods
+//
+//	Unwrap() error
+//	Unwrap() []error
+//
+// If e.Unwrap() returns a non-nil error w or a slice containing w,
+// then we say that e wraps w. A nil error returned from e.Unwrap()
+// indicates that e does not wrap any error. It is invalid for an
+// Unwrap method to return an []error containing a nil error value.
+//
+// Is reports whether an error in the chain matches a target, and As
+// finds the first error in the chain that matches a target type.
+// Join returns an error wrapping a list of errors.
+//
+// Package errors 实现了操作错误的函数。
+//
+// New 函数创建的错误只包含一段文本信息。
+//
+// 如果错误 e 的类型有下面两个方法之一，则 e 包装了另一个错误
+//
+//	Unwrap() error
+//	Unwrap() []error
+//
+// 如果 e.Unwrap() 返回一个非 nil 的错误 w 或者一个包含 w 的切片，我们就说 e 包装了 w。
+// e.Unwrap() 返回 nil 表示 e 没有包装任何错误。Unwrap 方法返回的 []error 中包含 nil 错误
+// 值是无效的。
+//
+// Is 报告错误链中是否有错误与目标匹配，As 查找错误链中第一个与目标类型匹配的错误。
+// Join 返回一个包装了一组错误的错误。
+//
+// IMP: 错误链
+// 单个包装时错误链是一个链表，Join 之后错误链变成了一棵树
+//
+//	wrapped(a) -> a -> nil
+//	joined(a, wrapped(b)) -> a
+//	                      -> wrapped(b) -> b
+//
+// Is 和 As 都按照深度优先、先序遍历的顺序访问这棵树。
 package errors
 
 // New returns an error that formats as the given text.
+//
+// New 返回一个格式化为给定文本的错误。
+// IMP: 每次调用 New 都会返回一个不同的错误，即使文本相同，因为比较的是指针。
 func New(text string) error {
 	return &errorString{text}
 }
 
 // errorString is a trivial implementation of error.
+//
+// errorString 是 error 的一个简单实现。
 type errorString struct {
 	s string
 }
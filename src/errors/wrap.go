@@ -0,0 +1,213 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package errors
+
+// Unwrap returns the result of calling the Unwrap method on err, if err's
+// type contains an Unwrap method returning error.
+// Otherwise, Unwrap returns nil.
+//
+// Unwrap only calls a method of the form "Unwrap() error".
+// In particular Unwrap does not unwrap errors returned by Join.
+//
+// 如果 err 的类型包含返回 error 的 Unwrap 方法，Unwrap 返回对 err 调用 Unwrap 方法的
+// 结果。否则，Unwrap 返回 nil。
+//
+// Unwrap 只会调用 "Unwrap() error" 形式的方法。特别是 Unwrap 不会解包 Join 返回的错误。
+func Unwrap(err error) error {
+	u, ok := err.(interface {
+		Unwrap() error
+	})
+	if !ok {
+		return nil
+	}
+	return u.Unwrap()
+}
+
+// Is reports whether any error in err's chain matches target.
+//
+// The chain consists of err itself followed by the sequence of errors obtained by
+// repeatedly calling its Unwrap() error or Unwrap() []error method. When err wraps
+// multiple errors, Is examines err followed by a depth-first traversal of its
+// children.
+//
+// An error is considered to match a target if it is equal to that target or if
+// it implements a method Is(error) bool such that Is(target) returns true.
+//
+// An error type might provide an Is method so it can be treated as equivalent
+// to an existing error. For example, if MyError defines
+//
+//	func (m MyError) Is(target error) bool { return target == os.ErrExist }
+//
+// then Is(MyError{}, os.ErrExist) returns true. An Is method should only
+// shallowly compare err and the target and not call Unwrap on either.
+//
+// Is 报告 err 的错误链中是否有任何错误与 target 匹配。
+//
+// 错误链由 err 本身以及反复调用其 Unwrap() error 或 Unwrap() []error 方法获得的错误序列
+// 组成。当 err 包装了多个错误时，Is 先检查 err，然后对它的子节点进行深度优先遍历。
+//
+// 如果一个错误等于 target，或者它实现了 Is(error) bool 方法并且 Is(target) 返回 true，
+// 则认为该错误与 target 匹配。
+//
+// 错误类型可以提供 Is 方法，使它可以被视为等价于一个已有的错误。例如，如果 MyError 定义了
+// 上面的方法，则 Is(MyError{}, os.ErrExist) 返回 true。Is 方法只应该浅层地比较 err 和
+// target，而不应该对它们中的任何一个调用 Unwrap。
+func Is(err, target error) bool {
+	if err == nil || target == nil {
+		return err == target
+	}
+
+	// IMP: 只有可比较的 target 才能使用 == 比较，否则会 panic（例如 target 的动态类型
+	// 是切片）。
+	isComparable := isComparable(target)
+	return is(err, target, isComparable)
+}
+
+func is(err, target error, targetComparable bool) bool {
+	for {
+		if targetComparable && err == target {
+			return true
+		}
+		if x, ok := err.(interface{ Is(error) bool }); ok && x.Is(target) {
+			return true
+		}
+		switch x := err.(type) {
+		case interface{ Unwrap() error }:
+			err = x.Unwrap()
+			if err == nil {
+				return false
+			}
+		case interface{ Unwrap() []error }:
+			for _, err := range x.Unwrap() {
+				if is(err, target, targetComparable) {
+					return true
+				}
+			}
+			return false
+		default:
+			return false
+		}
+	}
+}
+
+// As finds the first error in err's chain that matches target, and if one is found, sets
+// target to that error value and returns true. Otherwise, it returns false.
+//
+// The chain consists of err itself followed by the sequence of errors obtained by
+// repeatedly calling its Unwrap() error or Unwrap() []error method. When err wraps
+// multiple errors, As examines err followed by a depth-first traversal of its
+// children.
+//
+// An error matches target if the error's concrete value is assignable to the value
+// pointed to by target, or if the error has a method As(interface{}) bool such that
+// As(target) returns true. In the latter case, the As method is responsible for
+// setting target.
+//
+// An error type might provide an As method so it can be treated as if it were a
+// different error type.
+//
+// As panics if target is not a non-nil pointer to either a type that implements
+// error, or to any interface type.
+//
+// As 查找 err 的错误链中第一个与 target 匹配的错误，如果找到了，则将 target 设置为该错误
+// 值并返回 true。否则，它返回 false。
+//
+// 错误链由 err 本身以及反复调用其 Unwrap() error 或 Unwrap() []error 方法获得的错误序列
+// 组成。当 err 包装了多个错误时，As 先检查 err，然后对它的子节点进行深度优先遍历。
+//
+// 如果错误的具体值可以赋值给 target 指向的值，或者错误有 As(interface{}) bool 方法并且
+// As(target) 返回 true，则该错误与 target 匹配。在后一种情况下，As 方法负责设置 target。
+//
+// 错误类型可以提供 As 方法，使它可以被当作另一种错误类型来处理。
+//
+// 如果 target 不是指向实现了 error 的类型或任何接口类型的非 nil 指针，As 会 panic。
+//
+// IMP: As 需要通过反射检查类型并设置 target，但 errors 不能导入 reflect（reflect 通过
+// strconv 依赖了 errors，会形成循环导入），所以这部分工作由 reflect 包在初始化时通过
+// setAsHook 注册的 asHook 完成。只有 *error 类型的 target 不需要反射。
+func As(err error, target interface{}) bool {
+	if err == nil {
+		return false
+	}
+	if target == nil {
+		panic("errors: target cannot be nil")
+	}
+	if p, ok := target.(*error); ok {
+		if p == nil {
+			panic("errors: target must be a non-nil pointer")
+		}
+		*p = err
+		return true
+	}
+	if asHook == nil {
+		panic("errors: As requires package reflect to be linked into the program")
+	}
+	return as(err, target)
+}
+
+func as(err error, target interface{}) bool {
+	for {
+		if asHook(err, target) {
+			return true
+		}
+		if x, ok := err.(interface{ As(interface{}) bool }); ok && x.As(target) {
+			return true
+		}
+		switch x := err.(type) {
+		case interface{ Unwrap() error }:
+			err = x.Unwrap()
+			if err == nil {
+				return false
+			}
+		case interface{ Unwrap() []error }:
+			for _, err := range x.Unwrap() {
+				if err == nil {
+					continue
+				}
+				if as(err, target) {
+					return true
+				}
+			}
+			return false
+		default:
+			return false
+		}
+	}
+}
+
+// asHook reports whether err is assignable to the value pointed to by
+// target and, if so, stores it there. It panics if target is not a
+// non-nil pointer to an interface type or to a type implementing error.
+// It is installed by package reflect.
+//
+// asHook 报告 err 是否可以赋值给 target 指向的值，如果可以，则将 err 储存到该值中。如果
+// target 不是指向接口类型或实现了 error 的类型的非 nil 指针，它会 panic。
+// 它由 reflect 包注册。
+var asHook func(err error, target interface{}) bool
+
+// setAsHook is called by package reflect during initialization
+// (via go:linkname), since errors cannot import reflect.
+//
+// setAsHook 在 reflect 包初始化时被调用（通过 go:linkname），因为 errors 不能导入 reflect。
+func setAsHook(fn func(err error, target interface{}) bool) {
+	asHook = fn
+}
+
+// isComparable reports whether target may be compared with ==.
+// Only the dynamic types that panic on comparison need to be excluded,
+// and a recover is the cheapest way to find out without reflect.
+//
+// isComparable 报告 target 是否可以使用 == 比较。
+// 只需要排除比较时会 panic 的动态类型，而在不使用 reflect 的情况下，recover 是最简单的
+// 检测方法。
+func isComparable(target error) (ok bool) {
+	defer func() {
+		if recover() != nil {
+			ok = false
+		}
+	}()
+	_ = target == target
+	return true
+}
@@ -0,0 +1,177 @@
+// Copyright 2018 Li Zebang. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package flag
+
+import "fmt"
+
+// A ConflictPolicy tells AddFlagSet what to do with a flag whose name is
+// already taken in the set it is added to. The resolver of
+// SetConflictResolver returns one for a single flag.
+//
+// ConflictPolicy 告诉 AddFlagSet 如何处理名称在被添加到的标志集中已经被占用的标志。
+// SetConflictResolver 的解决函数为单个标志返回一个 ConflictPolicy。
+type ConflictPolicy int
+
+const (
+	// ConflictError makes AddFlagSet fail without adding any flag.
+	//
+	// ConflictError 使 AddFlagSet 失败，并且不添加任何标志。
+	ConflictError ConflictPolicy = iota
+
+	// ConflictSkip leaves the flag out.
+	//
+	// ConflictSkip 不添加该标志。
+	ConflictSkip
+
+	// ConflictPrefix adds the flag under the name of the other set, a dot
+	// and its own name, so -host from a set named db becomes -db.host.
+	//
+	// ConflictPrefix 以另一个标志集的名称、一个点和标志自己的名称来添加该标志，所以名为 db
+	// 的标志集中的 -host 会变成 -db.host。
+	ConflictPrefix
+)
+
+// AddFlagSet adds the flags defined in other to f, so that a library can
+// define its flags in a set of its own and an application can parse them
+// together with its own. The added flags share their Values with other,
+// but not whether they are set. They stay experimental with the same gate
+// if MarkExperimental marked them in other, and keep the watchers added to
+// them by Watch so far. A flag whose name is taken in f is handled
+// according to policy. A taken shorthand is dropped, unless policy is
+// ConflictError, which makes AddFlagSet fail for it too.
+//
+// AddFlagSet 将 other 中定义的标志添加到 f 中，这样库可以在自己的标志集中定义它的标志，
+// 而应用程序可以将它们和自己的标志一起解析。添加的标志和 other 共享它们的 Value，但不共享
+// 它们是否被设置。如果它们在 other 中被 MarkExperimental 标记过，它们仍然是使用同样开关的
+// 实验性标志，并且保留到目前为止 Watch 为它们添加的监视函数。名称在 f 中被占用的标志按照
+// policy 处理。被占用的缩写会被丢弃，除非 policy 是 ConflictError，它会使 AddFlagSet 同样
+// 因此失败。
+//
+// IMP: 所有冲突都在添加之前检查，所以返回错误时 f 不会被修改。
+func (f *FlagSet) AddFlagSet(other *FlagSet, policy ConflictPolicy) error {
+	// 正在添加的标志占用的名称、缩写和别名，以 foldKey 为键
+	added := make(map[string]bool) // names, shorthands and aliases taken by the flags being added, by foldKey
+	taken := func(name string) bool {
+		return f.formal[name] != nil || f.shorthands[name] != nil || f.aliases[name] != nil || f.lookupFolded(name) != nil || added[f.foldKey(name)]
+	}
+	var flags []*Flag
+	// flags 中每个标志在 other 中对应的标志
+	var origins []*Flag // the flag of other that each of flags copies
+	for _, flag := range other.order {
+		if flag.Name == enableExperimental && other.experimental != nil && f.formal[enableExperimental] != nil {
+			// f 自己的 -enable-experimental 同样会开启添加的实验性标志
+			continue // the -enable-experimental of f turns the added experimental flags on too
+		}
+		nf := *flag
+		if taken(nf.Name) {
+			switch policy {
+			case ConflictSkip:
+				continue
+			case ConflictPrefix:
+				if other.name == "" {
+					return f.conflictError("cannot prefix flag %s: flag set has no name", nf.Name)
+				}
+				nf.Name = other.name + "." + nf.Name
+				if taken(nf.Name) {
+					return f.conflictError("flag redefined: %s", nf.Name)
+				}
+			default:
+				return f.conflictError("flag redefined: %s", nf.Name)
+			}
+		}
+		if nf.Shorthand != "" && taken(nf.Shorthand) {
+			if policy == ConflictError {
+				return f.conflictError("flag shorthand redefined: %s", nf.Shorthand)
+			}
+			nf.Shorthand = ""
+		}
+		nf.Aliases = nil
+		for _, alias := range flag.Aliases {
+			if taken(alias) {
+				if policy == ConflictError {
+					return f.conflictError("flag alias redefined: %s", alias)
+				}
+				continue
+			}
+			nf.Aliases = append(nf.Aliases, alias)
+			added[f.foldKey(alias)] = true
+		}
+		added[f.foldKey(nf.Name)] = true
+		if nf.Shorthand != "" {
+			added[nf.Shorthand] = true
+		}
+		flags = append(flags, &nf)
+		origins = append(origins, flag)
+	}
+	f.addFlags(flags)
+	f.carryOver(other, flags, origins)
+	return nil
+}
+
+// carryOver gives flags, which copy the flags origins of other, the
+// experimental gates and watchers of origins.
+//
+// carryOver 将 origins 的实验性开关和监视函数交给 flags，flags 是 other 中的标志 origins
+// 的副本。
+//
+// IMP: experimental 和 watchers 以 *Flag 为键，所以要换成复制出的标志。
+func (f *FlagSet) carryOver(other *FlagSet, flags, origins []*Flag) {
+	for i, flag := range flags {
+		if gate, ok := other.experimental[origins[i]]; ok {
+			f.markExperimental(flag, gate)
+		}
+		if watchers := other.watchers[origins[i]]; len(watchers) > 0 {
+			if f.watchers == nil {
+				f.watchers = make(map[*Flag][]func(old, new string))
+			}
+			f.watchers[flag] = append([]func(old, new string){}, watchers...)
+		}
+	}
+}
+
+// addFlags registers flags, whose names, shorthands and aliases have been
+// checked to be free in f.
+//
+// addFlags 注册 flags，它们的名称、缩写和别名已经检查过在 f 中没有被占用。
+func (f *FlagSet) addFlags(flags []*Flag) {
+	f.sorted = nil
+	for _, flag := range flags {
+		if f.formal == nil {
+			f.formal = make(map[string]*Flag, len(flags))
+		}
+		f.formal[flag.Name] = flag
+		f.order = append(f.order, flag)
+		if flag.Shorthand != "" {
+			if f.shorthands == nil {
+				f.shorthands = make(map[string]*Flag)
+			}
+			f.shorthands[flag.Shorthand] = flag
+		}
+		for _, alias := range flag.Aliases {
+			if f.aliases == nil {
+				f.aliases = make(map[string]*Flag)
+			}
+			f.aliases[alias] = flag
+		}
+	}
+}
+
+// conflictError returns an error of AddFlagSet, named after f like the
+// panics of Var.
+//
+// conflictError 返回 AddFlagSet 的一个错误，它和 Var 的 panic 一样以 f 的名称开头。
+func (f *FlagSet) conflictError(format string, a ...interface{}) error {
+	if f.name != "" {
+		format = f.name + " " + format
+	}
+	return fmt.Errorf(format, a...)
+}
+
+// AddFlagSet adds the flags defined in other to the command-line flags.
+//
+// AddFlagSet 将 other 中定义的标志添加到命令行标志中。
+func AddFlagSet(other *FlagSet, policy ConflictPolicy) error {
+	return CommandLine.AddFlagSet(other, policy)
+}
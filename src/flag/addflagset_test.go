@@ -0,0 +1,202 @@
+// Copyright 2018 Li Zebang. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package flag_test
+
+import (
+	"bytes"
+	. "flag"
+	"os"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func newLibFlagSet(name string) (*FlagSet, *string, *int) {
+	lib := NewFlagSet(name, ContinueOnError)
+	host := lib.StringP("host", "H", "localhost", "database host")
+	port := lib.IntP("port", "p", 5432, "database port")
+	return lib, host, port
+}
+
+func flagNames(fs *FlagSet) string {
+	var names []string
+	fs.VisitAll(func(f *Flag) {
+		if f.Shorthand != "" {
+			names = append(names, f.Name+"/"+f.Shorthand)
+		} else {
+			names = append(names, f.Name)
+		}
+	})
+	sort.Strings(names)
+	return strings.Join(names, " ")
+}
+
+func TestAddFlagSet(t *testing.T) {
+	app := NewFlagSet("app", ContinueOnError)
+	v := app.Bool("v", false, "verbose")
+	lib, host, port := newLibFlagSet("db")
+	if err := app.AddFlagSet(lib, ConflictError); err != nil {
+		t.Fatal(err)
+	}
+	if got := flagNames(app); got != "host/H port/p v" {
+		t.Errorf("flags = %s", got)
+	}
+	if err := app.Parse([]string{"-v", "-H", "db.internal", "--port=6432"}); err != nil {
+		t.Fatal(err)
+	}
+	if !*v || *host != "db.internal" || *port != 6432 {
+		t.Errorf("v, host, port = %v, %q, %d", *v, *host, *port)
+	}
+	if lib.NFlag() != 0 || app.NFlag() != 3 {
+		t.Errorf("NFlag: lib %d, app %d", lib.NFlag(), app.NFlag())
+	}
+}
+
+func TestAddFlagSetConflicts(t *testing.T) {
+	tests := []struct {
+		policy ConflictPolicy
+		err    string
+		names  string
+	}{
+		{ConflictError, "app flag redefined: port", "port verbose"},
+		{ConflictSkip, "", "host/H port verbose"},
+		{ConflictPrefix, "", "db.port/p host/H port verbose"},
+	}
+	for _, tt := range tests {
+		app := NewFlagSet("app", ContinueOnError)
+		app.Int("port", 80, "app port")
+		app.Bool("verbose", false, "verbose")
+		lib, _, _ := newLibFlagSet("db")
+		err := app.AddFlagSet(lib, tt.policy)
+		switch {
+		case tt.err == "" && err != nil:
+			t.Errorf("policy %d: %v", tt.policy, err)
+		case tt.err != "" && (err == nil || err.Error() != tt.err):
+			t.Errorf("policy %d: error = %v; want %q", tt.policy, err, tt.err)
+		}
+		if got := flagNames(app); got != tt.names {
+			t.Errorf("policy %d: flags = %s; want %s", tt.policy, got, tt.names)
+		}
+	}
+}
+
+func TestAddFlagSetCaseInsensitive(t *testing.T) {
+	tests := []struct {
+		policy ConflictPolicy
+		err    string
+		names  string
+	}{
+		{ConflictError, "app flag redefined: logfile", ""},
+		{ConflictSkip, "", "LogFile"},
+		{ConflictPrefix, "", "LogFile lib.logfile"},
+	}
+	for _, tt := range tests {
+		app := NewFlagSet("app", ContinueOnError)
+		app.SetCaseInsensitive(true)
+		lib := NewFlagSet("lib", ContinueOnError)
+		lib.String("LogFile", "", "log file")
+		lib.String("logfile", "", "the same, regardless of case")
+		err := app.AddFlagSet(lib, tt.policy)
+		switch {
+		case tt.err == "" && err != nil:
+			t.Errorf("policy %d: %v", tt.policy, err)
+		case tt.err != "" && (err == nil || err.Error() != tt.err):
+			t.Errorf("policy %d: error = %v; want %q", tt.policy, err, tt.err)
+		}
+		if got := flagNames(app); got != tt.names {
+			t.Errorf("policy %d: flags = %s; want %s", tt.policy, got, tt.names)
+		}
+	}
+}
+
+func TestAddFlagSetShorthandConflict(t *testing.T) {
+	app := NewFlagSet("app", ContinueOnError)
+	app.BoolP("help-all", "H", false, "help")
+	lib, _, _ := newLibFlagSet("db")
+	if err := app.AddFlagSet(lib, ConflictError); err == nil || err.Error() != "app flag shorthand redefined: H" {
+		t.Errorf("ConflictError: %v", err)
+	}
+	if app.Lookup("port") != nil {
+		t.Error("failed AddFlagSet added port")
+	}
+	if err := app.AddFlagSet(lib, ConflictSkip); err != nil {
+		t.Fatal(err)
+	}
+	if f := app.Lookup("host"); f == nil || f.Shorthand != "" {
+		t.Errorf("host = %+v; want it without its shorthand", f)
+	}
+}
+
+func TestAddFlagSetPrefixUnnamed(t *testing.T) {
+	app := NewFlagSet("", ContinueOnError)
+	app.Int("port", 80, "port")
+	lib, _, _ := newLibFlagSet("")
+	err := app.AddFlagSet(lib, ConflictPrefix)
+	if err == nil || err.Error() != "cannot prefix flag port: flag set has no name" {
+		t.Errorf("error = %v", err)
+	}
+}
+
+func TestAddFlagSetExperimental(t *testing.T) {
+	defer setenv(t, "FLAG_TEST_TURBO", "")()
+	os.Unsetenv("FLAG_TEST_TURBO")
+	tests := []struct {
+		args  []string
+		turbo bool
+		err   string
+	}{
+		{[]string{"--turbo"}, false, "flag provided but not defined: -turbo"},
+		{[]string{"--turbo", "-enable-experimental"}, true, ""},
+	}
+	// An app with experimental flags of its own already has
+	// -enable-experimental, and it turns on the added ones too.
+	for _, own := range []bool{false, true} {
+		for _, tt := range tests {
+			app := NewFlagSet("app", ContinueOnError)
+			app.SetOutput(new(bytes.Buffer))
+			if own {
+				app.Bool("fast", false, "go fast")
+				app.MarkExperimental("fast", "")
+			}
+			lib, _ := newExperimentalFlagSet(new(bytes.Buffer))
+			if err := app.AddFlagSet(lib, ConflictError); err != nil {
+				t.Fatalf("own experimental flags %v: %v", own, err)
+			}
+			turbo := app.Lookup("turbo")
+			err := app.Parse(tt.args)
+			got := ""
+			if err != nil {
+				got = err.Error()
+			}
+			if got != tt.err {
+				t.Errorf("own experimental flags %v: Parse(%q) = %q; want %q", own, tt.args, got, tt.err)
+			}
+			if set := turbo.Value.String() == "true"; set != tt.turbo {
+				t.Errorf("own experimental flags %v: Parse(%q): turbo = %v; want %v", own, tt.args, set, tt.turbo)
+			}
+		}
+	}
+}
+
+func TestAddFlagSetWatch(t *testing.T) {
+	lib, _, _ := newLibFlagSet("db")
+	var changes []string
+	lib.Watch("port", func(old, new string) {
+		changes = append(changes, old+" -> "+new)
+	})
+	app := NewFlagSet("app", ContinueOnError)
+	if err := app.AddFlagSet(lib, ConflictError); err != nil {
+		t.Fatal(err)
+	}
+	if err := app.Parse([]string{"-p", "6432"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := app.Set("port", "7432"); err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.Join(changes, ", "); got != "6432 -> 7432" {
+		t.Errorf("changes = %q; want %q", got, "6432 -> 7432")
+	}
+}
@@ -0,0 +1,68 @@
+// Copyright 2018 Li Zebang. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package flag
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Alias registers alias as another name of the flag named name, so that
+// -alias sets the same Value as -name, for instance to keep scripts
+// working after a flag is renamed. Lookup and Set accept the alias too,
+// and so do config files. Usage messages list the alias after the name of
+// the flag. Alias panics if the flag is not defined or alias is already a
+// flag name, shorthand or alias.
+//
+// Alias 将 alias 注册为名为 name 的标志的另一个名称，这样 -alias 设置的是和 -name 相同的
+// Value，例如在标志被重命名之后让脚本继续工作。Lookup 和 Set 也接受这个别名，配置文件也
+// 一样。用法信息在标志名称的后面列出别名。如果标志没有定义，或者 alias 已经是一个标志名称、
+// 缩写或者别名，Alias 会 panic。
+func (f *FlagSet) Alias(alias, name string) {
+	flag, ok := f.formal[name]
+	var msg string
+	switch {
+	case !ok:
+		msg = fmt.Sprintf("flag provided to Alias but not defined: -%s", name)
+	case alias == "" || alias[0] == '-' || strings.Contains(alias, "="):
+		msg = fmt.Sprintf("invalid alias %q for flag -%s", alias, name)
+	case f.formal[alias] != nil || f.shorthands[alias] != nil || f.aliases[alias] != nil || f.lookupFolded(alias) != nil:
+		msg = fmt.Sprintf("flag alias redefined: %s", alias)
+	}
+	if msg != "" {
+		if f.name != "" {
+			msg = f.name + " " + msg
+		}
+		fmt.Fprintln(f.Output(), msg)
+		panic(msg)
+	}
+	if f.aliases == nil {
+		f.aliases = make(map[string]*Flag)
+	}
+	f.aliases[alias] = flag
+	flag.Aliases = append(flag.Aliases, alias)
+}
+
+// Alias registers alias as another name of the command-line flag named
+// name.
+//
+// Alias 将 alias 注册为名为 name 的命令行标志的另一个名称。
+func Alias(alias, name string) {
+	CommandLine.Alias(alias, name)
+}
+
+// lookupName returns the flag with the given name or alias, or nil. See
+// SetCaseInsensitive for how case is matched.
+//
+// lookupName 返回名称或别名为 name 的标志，或者 nil。大小写的匹配方式参见 SetCaseInsensitive。
+func (f *FlagSet) lookupName(name string) *Flag {
+	if flag, ok := f.formal[name]; ok {
+		return flag
+	}
+	if flag, ok := f.aliases[name]; ok {
+		return flag
+	}
+	return f.lookupFolded(name)
+}
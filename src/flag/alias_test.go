@@ -0,0 +1,150 @@
+// Copyright 2018 Li Zebang. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package flag_test
+
+import (
+	"bytes"
+	. "flag"
+	"strings"
+	"testing"
+)
+
+func TestAlias(t *testing.T) {
+	fs := NewFlagSet("alias", ContinueOnError)
+	color := fs.String("color", "auto", "when to colorize")
+	fs.Alias("colour", "color")
+	fs.Alias("c", "color")
+	for _, args := range [][]string{{"-colour", "never"}, {"--c=never"}, {"-color", "never"}} {
+		*color = "auto"
+		if err := fs.Parse(args); err != nil {
+			t.Fatalf("Parse(%q): %v", args, err)
+		}
+		if *color != "never" {
+			t.Errorf("Parse(%q): color = %q", args, *color)
+		}
+	}
+	if fs.Lookup("colour") != fs.Lookup("color") {
+		t.Error("Lookup(colour) is not the color flag")
+	}
+	if err := fs.Set("colour", "always"); err != nil || *color != "always" {
+		t.Errorf("Set(colour): color = %q, err = %v", *color, err)
+	}
+	var names []string
+	fs.Visit(func(f *Flag) { names = append(names, f.Name) })
+	if strings.Join(names, " ") != "color" {
+		t.Errorf("visited %q; want the canonical name only", names)
+	}
+}
+
+func TestAliasConfigAndNegatable(t *testing.T) {
+	fs := NewFlagSet("alias", ContinueOnError)
+	colour := fs.NegatableBool("color", true, "colorize")
+	level := fs.Int("log-level", 0, "level")
+	fs.Alias("colour", "color")
+	fs.Alias("loglevel", "log-level")
+	if err := fs.ParseConfigINI(strings.NewReader("loglevel = 3")); err != nil {
+		t.Fatal(err)
+	}
+	if err := fs.Parse([]string{"-no-colour"}); err != nil {
+		t.Fatal(err)
+	}
+	if *colour || *level != 3 {
+		t.Errorf("color, log-level = %v, %d", *colour, *level)
+	}
+}
+
+func TestAliasPanics(t *testing.T) {
+	tests := []struct {
+		alias, name string
+		want        string
+	}{
+		{"x", "missing", "alias flag provided to Alias but not defined: -missing"},
+		{"v", "verbose", "alias flag alias redefined: v"},
+		{"q", "verbose", "alias flag alias redefined: q"},
+		{"verbose", "verbose", "alias flag alias redefined: verbose"},
+		{"-v2", "verbose", `alias invalid alias "-v2" for flag -verbose`},
+	}
+	for _, tt := range tests {
+		fs := NewFlagSet("alias", ContinueOnError)
+		fs.SetOutput(new(bytes.Buffer))
+		fs.BoolP("verbose", "v", false, "verbose")
+		fs.Bool("quiet", false, "quiet")
+		fs.Alias("q", "quiet")
+		func() {
+			defer func() {
+				if r := recover(); r != tt.want {
+					t.Errorf("Alias(%q, %q): panic = %v; want %q", tt.alias, tt.name, r, tt.want)
+				}
+			}()
+			fs.Alias(tt.alias, tt.name)
+		}()
+	}
+}
+
+func TestAliasBlocksRedefinition(t *testing.T) {
+	fs := NewFlagSet("alias", ContinueOnError)
+	fs.SetOutput(new(bytes.Buffer))
+	fs.Bool("color", false, "colorize")
+	fs.Alias("colour", "color")
+	defer func() {
+		if r := recover(); r != "alias flag redefined: colour" {
+			t.Errorf("panic = %v", r)
+		}
+	}()
+	fs.Bool("colour", false, "again")
+}
+
+func TestAliasUndefineAndAddFlagSet(t *testing.T) {
+	fs := NewFlagSet("alias", ContinueOnError)
+	fs.SetOutput(new(bytes.Buffer))
+	fs.Bool("color", false, "colorize")
+	fs.Alias("colour", "color")
+	if err := fs.Undefine("color"); err != nil {
+		t.Fatal(err)
+	}
+	if fs.Lookup("colour") != nil {
+		t.Error("alias survived Undefine")
+	}
+
+	app := NewFlagSet("app", ContinueOnError)
+	app.Bool("colour", false, "app flag")
+	lib := NewFlagSet("lib", ContinueOnError)
+	lib.Bool("color", false, "colorize")
+	lib.Alias("colour", "color")
+	lib.Alias("tint", "color")
+	if err := app.AddFlagSet(lib, ConflictError); err == nil || err.Error() != "app flag alias redefined: colour" {
+		t.Errorf("ConflictError: %v", err)
+	}
+	if err := app.AddFlagSet(lib, ConflictSkip); err != nil {
+		t.Fatal(err)
+	}
+	if f := app.Lookup("tint"); f == nil || f.Name != "color" || strings.Join(f.Aliases, ",") != "tint" {
+		t.Errorf("Lookup(tint) = %+v", f)
+	}
+	if f := app.Lookup("colour"); f == nil || f.Name != "colour" {
+		t.Errorf("Lookup(colour) = %+v; want the app flag", f)
+	}
+}
+
+func TestAliasPrintDefaults(t *testing.T) {
+	fs := NewFlagSet("alias", ContinueOnError)
+	var buf bytes.Buffer
+	fs.SetOutput(&buf)
+	fs.StringP("color", "c", "auto", "when to colorize")
+	fs.Alias("colour", "color")
+	fs.Int("n", 0, "number")
+	fs.Alias("num", "n")
+	fs.PrintDefaults()
+	want := strings.Join([]string{
+		"  -c, --color, --colour string",
+		"    \twhen to colorize (default \"auto\")",
+		"  -n, -num int",
+		"    \tnumber",
+		"",
+	}, "\n")
+	if buf.String() != want {
+		t.Errorf("PrintDefaults:\n%q\nwant:\n%q", buf.String(), want)
+	}
+}
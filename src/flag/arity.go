@@ -0,0 +1,66 @@
+// Copyright 2018 Li Zebang. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package flag
+
+import "fmt"
+
+// arity is the number of arguments accepted after the flags; see
+// ExpectArgs.
+//
+// arity 是标志之后接受的参数数量，参见 ExpectArgs。
+type arity struct {
+	min, max int
+}
+
+// ExpectArgs makes Parse fail, and print the usage message, unless the
+// number of arguments left after the flags is between min and max,
+// inclusive. A negative max means there is no upper limit, so
+// ExpectArgs(1, -1) asks for at least one argument and ExpectArgs(2, 2)
+// for exactly two. ExpectArgs panics if min is negative or max is less
+// than min.
+//
+// ExpectArgs 使 Parse 在标志之后剩余的参数数量不在 min 和 max 之间（包括两者）时失败并打印
+// 用法信息。负数的 max 意味着没有上限，所以 ExpectArgs(1, -1) 要求至少一个参数，
+// ExpectArgs(2, 2) 要求正好两个参数。如果 min 为负数或者 max 小于 min，ExpectArgs 会 panic。
+func (f *FlagSet) ExpectArgs(min, max int) {
+	if min < 0 || max >= 0 && max < min {
+		msg := fmt.Sprintf("invalid range provided to ExpectArgs: %d to %d", min, max)
+		if f.name != "" {
+			msg = f.name + " " + msg
+		}
+		fmt.Fprintln(f.Output(), msg)
+		panic(msg)
+	}
+	f.arity = &arity{min, max}
+}
+
+// ExpectArgs makes Parse fail unless the number of arguments left after
+// the command-line flags is between min and max, inclusive.
+//
+// ExpectArgs 使 Parse 在命令行标志之后剩余的参数数量不在 min 和 max 之间（包括两者）时
+// 失败。
+func ExpectArgs(min, max int) {
+	CommandLine.ExpectArgs(min, max)
+}
+
+// checkArity reports a number of arguments outside the range given to
+// ExpectArgs.
+//
+// checkArity 报告不在 ExpectArgs 给出的范围之内的参数数量。
+func (f *FlagSet) checkArity() error {
+	a := f.arity
+	n := len(f.args)
+	switch {
+	case a == nil || n >= a.min && (a.max < 0 || n <= a.max):
+		return nil
+	case a.min == a.max:
+		return f.failf("expected %d argument(s), got %d", a.min, n)
+	case a.max < 0:
+		return f.failf("expected at least %d argument(s), got %d", a.min, n)
+	case a.min == 0:
+		return f.failf("expected at most %d argument(s), got %d", a.max, n)
+	}
+	return f.failf("expected %d to %d arguments, got %d", a.min, a.max, n)
+}
@@ -0,0 +1,63 @@
+// Copyright 2018 Li Zebang. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package flag_test
+
+import (
+	"bytes"
+	. "flag"
+	"strings"
+	"testing"
+)
+
+func TestExpectArgs(t *testing.T) {
+	tests := []struct {
+		min, max int
+		args     []string
+		err      string
+	}{
+		{0, 0, nil, ""},
+		{0, 0, []string{"a"}, "expected 0 argument(s), got 1"},
+		{2, 2, []string{"-v", "a", "b"}, ""},
+		{2, 2, []string{"-v", "a"}, "expected 2 argument(s), got 1"},
+		{1, -1, []string{"a", "b", "c"}, ""},
+		{1, -1, []string{"-v"}, "expected at least 1 argument(s), got 0"},
+		{0, 1, []string{"a", "b"}, "expected at most 1 argument(s), got 2"},
+		{1, 3, []string{"a", "b", "c", "d"}, "expected 1 to 3 arguments, got 4"},
+		{1, 3, []string{"--", "-a"}, ""},
+	}
+	for _, tt := range tests {
+		fs := NewFlagSet("arity", ContinueOnError)
+		var buf bytes.Buffer
+		fs.SetOutput(&buf)
+		fs.Bool("v", false, "verbose")
+		fs.ExpectArgs(tt.min, tt.max)
+		err := fs.Parse(tt.args)
+		got := ""
+		if err != nil {
+			got = err.Error()
+		}
+		if got != tt.err {
+			t.Errorf("ExpectArgs(%d, %d): Parse(%q) = %q; want %q", tt.min, tt.max, tt.args, got, tt.err)
+		}
+		if tt.err != "" && !strings.HasPrefix(buf.String(), tt.err+"\nUsage of arity:\n") {
+			t.Errorf("ExpectArgs(%d, %d): output %q", tt.min, tt.max, buf.String())
+		}
+	}
+}
+
+func TestExpectArgsPanics(t *testing.T) {
+	for _, r := range [][2]int{{-1, 2}, {3, 2}} {
+		fs := NewFlagSet("arity", ContinueOnError)
+		fs.SetOutput(new(bytes.Buffer))
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("ExpectArgs(%d, %d) did not panic", r[0], r[1])
+				}
+			}()
+			fs.ExpectArgs(r[0], r[1])
+		}()
+	}
+}
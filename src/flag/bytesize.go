@@ -0,0 +1,156 @@
+// Copyright 2018 Li Zebang. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package flag
+
+import (
+	"errors"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// byteUnits maps the lower-case unit suffixes of a size to their number
+// of bytes. Bare letters and the IEC names are powers of 1024, and the SI
+// names ending in b are powers of 1000, so 4k is 4096 and 4kB is 4000.
+//
+// byteUnits 将大小的小写单位后缀映射为它们的字节数。单独的字母和 IEC 名称是 1024 的幂，
+// 以 b 结尾的 SI 名称是 1000 的幂，所以 4k 是 4096，而 4kB 是 4000。
+var byteUnits = map[string]float64{
+	"":  1,
+	"b": 1,
+
+	"k": 1 << 10, "kib": 1 << 10, "kb": 1e3,
+	"m": 1 << 20, "mib": 1 << 20, "mb": 1e6,
+	"g": 1 << 30, "gib": 1 << 30, "gb": 1e9,
+	"t": 1 << 40, "tib": 1 << 40, "tb": 1e12,
+	"p": 1 << 50, "pib": 1 << 50, "pb": 1e15,
+	"e": 1 << 60, "eib": 1 << 60, "eb": 1e18,
+}
+
+// binaryUnits are the units String formats sizes with, largest first.
+//
+// binaryUnits 是 String 格式化大小时使用的单位，从大到小排列。
+var binaryUnits = [...]struct {
+	name string
+	size int64
+}{
+	{"EiB", 1 << 60},
+	{"PiB", 1 << 50},
+	{"TiB", 1 << 40},
+	{"GiB", 1 << 30},
+	{"MiB", 1 << 20},
+	{"KiB", 1 << 10},
+}
+
+// parseByteSize parses a size such as 512, 4k, 512MB or 1.5GiB.
+//
+// parseByteSize 解析一个大小，例如 512、4k、512MB 或者 1.5GiB。
+func parseByteSize(s string) (int64, error) {
+	i := strings.IndexFunc(s, func(r rune) bool { return (r < '0' || r > '9') && r != '.' })
+	if i < 0 {
+		i = len(s)
+	}
+	num, unit := s[:i], strings.ToLower(strings.TrimSpace(s[i:]))
+	mult, ok := byteUnits[unit]
+	if num == "" || !ok {
+		return 0, errors.New("invalid size " + strconv.Quote(s))
+	}
+	// 没有小数部分的大小按整数计算，以免丢失精度。
+	if !strings.Contains(num, ".") { // Whole sizes are computed exactly.
+		n, err := strconv.ParseInt(num, 10, 64)
+		if err != nil || n > math.MaxInt64/int64(mult) {
+			return 0, errors.New("size " + strconv.Quote(s) + " out of range")
+		}
+		return n * int64(mult), nil
+	}
+	x, err := strconv.ParseFloat(num, 64)
+	if err != nil {
+		return 0, errors.New("invalid size " + strconv.Quote(s))
+	}
+	x *= mult
+	if x >= math.MaxInt64 {
+		return 0, errors.New("size " + strconv.Quote(s) + " out of range")
+	}
+	if x != math.Trunc(x) {
+		return 0, errors.New("size " + strconv.Quote(s) + " is not a whole number of bytes")
+	}
+	return int64(x), nil
+}
+
+// formatByteSize formats n with the largest binary unit that divides it.
+//
+// formatByteSize 使用能整除 n 的最大的二进制单位格式化 n。
+func formatByteSize(n int64) string {
+	if n != 0 {
+		for _, u := range binaryUnits {
+			if n%u.size == 0 {
+				return strconv.FormatInt(n/u.size, 10) + u.name
+			}
+		}
+	}
+	return strconv.FormatInt(n, 10)
+}
+
+// -- byte size Value
+type bytesValue int64
+
+func newBytesValue(val int64, p *int64) *bytesValue {
+	*p = val
+	return (*bytesValue)(p)
+}
+
+func (b *bytesValue) Set(s string) error {
+	v, err := parseByteSize(s)
+	if err != nil {
+		return err
+	}
+	*b = bytesValue(v)
+	return nil
+}
+
+func (b *bytesValue) Get() interface{} { return int64(*b) }
+
+func (b *bytesValue) String() string { return formatByteSize(int64(*b)) }
+
+// BytesVar defines a byte size flag with specified name, default value,
+// and usage string. The argument p points to an int64 variable in which to
+// store the number of bytes. The flag accepts a number followed by an
+// optional unit, such as 4k, 512MB or 1.5GiB: k, KiB, M, MiB and so on are
+// powers of 1024, while kB, MB and so on are powers of 1000.
+//
+// BytesVar 定义一个具有指定名称、默认值和用法信息的字节大小标志。参数 p 指向一个用来存储
+// 字节数的 int64 变量。该标志接受一个数字和一个可选的单位，例如 4k、512MB 或者 1.5GiB：
+// k、KiB、M、MiB 等是 1024 的幂，而 kB、MB 等是 1000 的幂。
+func (f *FlagSet) BytesVar(p *int64, name string, value int64, usage string) {
+	f.Var(newBytesValue(value, p), name, usage)
+}
+
+// BytesVar defines a byte size flag with specified name, default value,
+// and usage string.
+//
+// BytesVar 定义一个具有指定名称、默认值和用法信息的字节大小标志。
+func BytesVar(p *int64, name string, value int64, usage string) {
+	CommandLine.Var(newBytesValue(value, p), name, usage)
+}
+
+// Bytes defines a byte size flag with specified name, default value, and
+// usage string. The return value is the address of an int64 variable that
+// stores the number of bytes.
+//
+// Bytes 定义一个具有指定名称、默认值和用法信息的字节大小标志。返回值是存储字节数的 int64
+// 变量的地址。
+func (f *FlagSet) Bytes(name string, value int64, usage string) *int64 {
+	p := new(int64)
+	f.BytesVar(p, name, value, usage)
+	return p
+}
+
+// Bytes defines a byte size flag with specified name, default value, and
+// usage string.
+//
+// Bytes 定义一个具有指定名称、默认值和用法信息的字节大小标志。
+func Bytes(name string, value int64, usage string) *int64 {
+	return CommandLine.Bytes(name, value, usage)
+}
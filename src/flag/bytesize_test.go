@@ -0,0 +1,100 @@
+// Copyright 2018 Li Zebang. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package flag_test
+
+import (
+	"bytes"
+	. "flag"
+	"strings"
+	"testing"
+)
+
+func TestBytes(t *testing.T) {
+	tests := []struct {
+		in   string
+		want int64
+		err  string
+	}{
+		{"0", 0, ""},
+		{"512", 512, ""},
+		{"512b", 512, ""},
+		{"4k", 4096, ""},
+		{"4K", 4096, ""},
+		{"4KiB", 4096, ""},
+		{"4kB", 4000, ""},
+		{"512MB", 512e6, ""},
+		{"512MiB", 512 << 20, ""},
+		{"1.5GiB", 3 << 29, ""},
+		{"1.5 g", 3 << 29, ""},
+		{"0.5k", 512, ""},
+		{"7EiB", 7 << 60, ""},
+		{"8EiB", 0, "out of range"},
+		{"99999999999999999999", 0, "out of range"},
+		{"1.1k", 0, "not a whole number of bytes"},
+		{"", 0, "invalid size"},
+		{"k", 0, "invalid size"},
+		{"-1k", 0, "invalid size"},
+		{"4kk", 0, "invalid size"},
+		{"1.2.3", 0, "invalid size"},
+	}
+	for _, tt := range tests {
+		fs := NewFlagSet("bytes", ContinueOnError)
+		fs.SetOutput(new(bytes.Buffer))
+		size := fs.Bytes("size", 0, "size")
+		err := fs.Parse([]string{"-size", tt.in})
+		switch {
+		case tt.err == "" && err != nil:
+			t.Errorf("Parse(%q): %v", tt.in, err)
+		case tt.err == "" && *size != tt.want:
+			t.Errorf("Parse(%q): size = %d; want %d", tt.in, *size, tt.want)
+		case tt.err != "" && (err == nil || !strings.Contains(err.Error(), tt.err)):
+			t.Errorf("Parse(%q) error = %v; want %q", tt.in, err, tt.err)
+		}
+	}
+}
+
+func TestBytesString(t *testing.T) {
+	fs := NewFlagSet("bytes", ContinueOnError)
+	var n int64
+	fs.BytesVar(&n, "n", 0, "n")
+	for _, tt := range []struct {
+		in   string
+		want string
+	}{
+		{"512MiB", "512MiB"},
+		{"1.5GiB", "1536MiB"},
+		{"4kB", "4000"},
+		{"2048", "2KiB"},
+	} {
+		if err := fs.Set("n", tt.in); err != nil {
+			t.Fatal(err)
+		}
+		if got := fs.Lookup("n").Value.String(); got != tt.want {
+			t.Errorf("Set(%q): String() = %q; want %q", tt.in, got, tt.want)
+		}
+	}
+	if got := fs.Lookup("n").Value.(Getter).Get(); got != int64(2048) {
+		t.Errorf("Get() = %v", got)
+	}
+}
+
+func TestBytesPrintDefaults(t *testing.T) {
+	fs := NewFlagSet("bytes", ContinueOnError)
+	var buf bytes.Buffer
+	fs.SetOutput(&buf)
+	fs.Bytes("cache", 64<<20, "cache size")
+	fs.Bytes("limit", 0, "limit")
+	fs.PrintDefaults()
+	want := strings.Join([]string{
+		"  -cache bytes",
+		"    \tcache size (default 64MiB)",
+		"  -limit bytes",
+		"    \tlimit",
+		"",
+	}, "\n")
+	if buf.String() != want {
+		t.Errorf("PrintDefaults:\n%q\nwant:\n%q", buf.String(), want)
+	}
+}
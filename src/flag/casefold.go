@@ -0,0 +1,91 @@
+// Copyright 2018 Li Zebang. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package flag
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SetCaseInsensitive sets whether the names and aliases of flags match
+// regardless of case, so that -Verbose, -VERBOSE and -verbose all set the
+// flag named verbose. It applies to Parse, config files, Lookup and Set.
+// One-letter names are always matched exactly, so -v and -V can still be
+// different flags or shorthands. While matching is case-insensitive,
+// defining a flag or alias whose name differs from another only in case
+// panics, and so does turning it on when such flags are already defined.
+//
+// SetCaseInsensitive 设置标志的名称和别名是否不区分大小写匹配，这样 -Verbose、-VERBOSE 和
+// -verbose 设置的都是名为 verbose 的标志。它适用于 Parse、配置文件、Lookup 和 Set。单字母的
+// 名称总是精确匹配，所以 -v 和 -V 仍然可以是不同的标志或缩写。在不区分大小写匹配时，定义一个
+// 名称与另一个只有大小写不同的标志或别名会 panic，在已经定义了这样的标志时开启它也会 panic。
+func (f *FlagSet) SetCaseInsensitive(on bool) {
+	if on {
+		seen := make(map[string]string)
+		for _, flag := range f.order {
+			names := append([]string{flag.Name}, flag.Aliases...)
+			for _, name := range names {
+				if len(name) < 2 {
+					continue
+				}
+				key := strings.ToLower(name)
+				if other, ok := seen[key]; ok {
+					msg := fmt.Sprintf("flag redefined: %s (same as %s regardless of case)", name, other)
+					if f.name != "" {
+						msg = f.name + " " + msg
+					}
+					fmt.Fprintln(f.Output(), msg)
+					panic(msg)
+				}
+				seen[key] = name
+			}
+		}
+	}
+	f.foldCase = on
+}
+
+// SetCaseInsensitive sets whether the names and aliases of the command-line
+// flags match regardless of case.
+//
+// SetCaseInsensitive 设置命令行标志的名称和别名是否不区分大小写匹配。
+func SetCaseInsensitive(on bool) {
+	CommandLine.SetCaseInsensitive(on)
+}
+
+// lookupFolded returns the flag whose name or alias equals name regardless
+// of case, if matching is case-insensitive and name is longer than one
+// letter, or nil.
+//
+// lookupFolded 在不区分大小写匹配并且 name 长于一个字母时，返回名称或别名在不区分大小写时
+// 等于 name 的标志，否则返回 nil。
+func (f *FlagSet) lookupFolded(name string) *Flag {
+	if !f.foldCase || len(name) < 2 {
+		return nil
+	}
+	for _, flag := range f.order {
+		if strings.EqualFold(flag.Name, name) {
+			return flag
+		}
+	}
+	for alias, flag := range f.aliases {
+		if strings.EqualFold(alias, name) {
+			return flag
+		}
+	}
+	return nil
+}
+
+// foldKey returns the key under which name is told apart from other names
+// and aliases: name lower-cased if matching is case-insensitive and name
+// is longer than one letter, as in SetCaseInsensitive, or name itself.
+//
+// foldKey 返回区分 name 与其他名称和别名时使用的键：如果不区分大小写匹配并且 name 长于一个
+// 字母，则和 SetCaseInsensitive 中一样是小写的 name，否则是 name 本身。
+func (f *FlagSet) foldKey(name string) string {
+	if f.foldCase && len(name) >= 2 {
+		return strings.ToLower(name)
+	}
+	return name
+}
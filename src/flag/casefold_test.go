@@ -0,0 +1,80 @@
+// Copyright 2018 Li Zebang. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package flag_test
+
+import (
+	. "flag"
+	"io/ioutil"
+	"strings"
+	"testing"
+)
+
+func newCaseFlagSet() (*FlagSet, *bool, *bool, *string) {
+	fs := NewFlagSet("case", ContinueOnError)
+	fs.SetOutput(ioutil.Discard)
+	verbose := fs.Bool("verbose", false, "verbose")
+	v := fs.Bool("v", false, "v")
+	fs.Bool("V", false, "version")
+	name := fs.String("log-file", "", "log file")
+	fs.Alias("logfile", "log-file")
+	return fs, verbose, v, name
+}
+
+func TestSetCaseInsensitive(t *testing.T) {
+	fs, verbose, v, name := newCaseFlagSet()
+	if err := fs.Parse([]string{"-Verbose"}); err == nil {
+		t.Error("-Verbose accepted before SetCaseInsensitive")
+	}
+
+	fs, verbose, v, name = newCaseFlagSet()
+	fs.SetCaseInsensitive(true)
+	if err := fs.Parse([]string{"-VERBOSE", "--Log-File", "a", "-v", "x"}); err != nil {
+		t.Fatal(err)
+	}
+	if !*verbose || !*v || *name != "a" {
+		t.Errorf("verbose = %v, v = %v, log-file = %q", *verbose, *v, *name)
+	}
+	if fs.Lookup("V").Value.String() != "false" {
+		t.Error("-v set -V")
+	}
+	if flag := fs.Lookup("LOGFILE"); flag == nil || flag.Name != "log-file" {
+		t.Errorf("Lookup(LOGFILE) = %v", flag)
+	}
+	if err := fs.Set("Verbose", "false"); err != nil || *verbose {
+		t.Errorf("Set(Verbose): %v, verbose = %v", err, *verbose)
+	}
+	err := fs.ParseConfigINI(strings.NewReader("LogFile = b"))
+	if err != nil || *name != "a" {
+		t.Errorf("ParseConfigINI: %v, log-file = %q", err, *name)
+	}
+}
+
+func TestSetCaseInsensitiveRedefined(t *testing.T) {
+	tests := []struct {
+		desc string
+		fn   func(fs *FlagSet)
+	}{
+		{"Var", func(fs *FlagSet) { fs.Bool("Verbose", false, "") }},
+		{"Alias", func(fs *FlagSet) { fs.Alias("LogFile", "verbose") }},
+		{"SetCaseInsensitive", nil},
+	}
+	for _, tt := range tests {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("%s did not panic on a case-only duplicate", tt.desc)
+				}
+			}()
+			fs, _, _, _ := newCaseFlagSet()
+			if tt.fn == nil {
+				fs.Bool("VERBOSE", false, "")
+				fs.SetCaseInsensitive(true)
+				return
+			}
+			fs.SetCaseInsensitive(true)
+			tt.fn(fs)
+		}()
+	}
+}
@@ -0,0 +1,403 @@
+// Copyright 2018 Li Zebang. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"os"
+	"path"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const defaultImport = "github.com/lizebang/annotate-go-sdk/src/flag"
+
+// A field is a struct field with a flag tag.
+//
+// field 是一个带有 flag 标签的结构体字段。
+type field struct {
+	// Go 中的字段名
+	Name string // field name in Go
+	// 字段的类型，例如 int 或 []time.Duration
+	Type string // type of the field, such as int or []time.Duration
+	// 标志名称和缩写
+	Flag, Shorthand string // flag name and shorthand
+	// 其余的标签，未给出时为空
+	Usage, Default, Env, Min, Max, Enum string // the other tags, or empty
+	// 是否必须设置该标志
+	Required bool // whether the flag must be set
+}
+
+// A kind describes how flaggen handles a field type.
+//
+// kind 描述了 flaggen 如何处理一种字段类型。
+type kind struct {
+	// 定义标志的 XxxVar 方法的 Xxx
+	method string // Xxx of the XxxVar method that defines the flag
+	// 是否有 XxxVarP 方法
+	short bool // whether there is an XxxVarP method
+	// 切片的元素类型，不是切片时为空
+	elem string // element type of a slice, or empty
+}
+
+var kinds = map[string]kind{
+	"string":          {"String", true, ""},
+	"bool":            {"Bool", true, ""},
+	"int":             {"Int", true, ""},
+	"int8":            {"Int8", false, ""},
+	"int16":           {"Int16", false, ""},
+	"int32":           {"Int32", false, ""},
+	"int64":           {"Int64", true, ""},
+	"uint":            {"Uint", true, ""},
+	"uint8":           {"Uint8", false, ""},
+	"uint16":          {"Uint16", false, ""},
+	"uint32":          {"Uint32", false, ""},
+	"uint64":          {"Uint64", true, ""},
+	"float32":         {"Float32", false, ""},
+	"float64":         {"Float64", true, ""},
+	"time.Duration":   {"Duration", true, ""},
+	"[]string":        {"StringSlice", true, "string"},
+	"[]int":           {"IntSlice", true, "int"},
+	"[]int64":         {"Int64Slice", true, "int64"},
+	"[]float64":       {"Float64Slice", true, "float64"},
+	"[]time.Duration": {"DurationSlice", true, "time.Duration"},
+}
+
+// parseStruct returns the name of the package in dir and the tagged
+// fields of its struct type typeName.
+//
+// parseStruct 返回 dir 中的包的名称，以及它的结构体类型 typeName 中带有标签的字段。
+func parseStruct(dir, typeName string) (string, []field, error) {
+	fset := token.NewFileSet()
+	pkgs, err := parser.ParseDir(fset, dir, func(fi os.FileInfo) bool {
+		return !strings.HasSuffix(fi.Name(), "_test.go")
+	}, 0)
+	if err != nil {
+		return "", nil, err
+	}
+	for name, pkg := range pkgs {
+		for _, file := range pkg.Files {
+			obj := file.Scope.Lookup(typeName)
+			if obj == nil || obj.Kind != ast.Typ {
+				continue
+			}
+			st, ok := obj.Decl.(*ast.TypeSpec).Type.(*ast.StructType)
+			if !ok {
+				return "", nil, fmt.Errorf("%s is not a struct type", typeName)
+			}
+			fields, err := structFields(st)
+			return name, fields, err
+		}
+	}
+	return "", nil, fmt.Errorf("type %s not found in %s", typeName, dir)
+}
+
+// structFields returns the tagged fields of st.
+//
+// structFields 返回 st 中带有标签的字段。
+func structFields(st *ast.StructType) ([]field, error) {
+	var fields []field
+	for _, f := range st.Fields.List {
+		if f.Tag == nil {
+			continue
+		}
+		tagText, err := strconv.Unquote(f.Tag.Value)
+		if err != nil {
+			return nil, err
+		}
+		tag := reflect.StructTag(tagText)
+		name, ok := tag.Lookup("flag")
+		if !ok || name == "-" {
+			continue
+		}
+		if len(f.Names) != 1 {
+			return nil, fmt.Errorf("flag %q: tag must be on a single named field", name)
+		}
+		fd := field{
+			Name:     f.Names[0].Name,
+			Type:     typeString(f.Type),
+			Flag:     name,
+			Usage:    tag.Get("usage"),
+			Default:  tag.Get("default"),
+			Env:      tag.Get("env"),
+			Min:      tag.Get("min"),
+			Max:      tag.Get("max"),
+			Enum:     tag.Get("enum"),
+			Required: tag.Get("required") == "true",
+		}
+		if i := strings.IndexByte(name, ','); i >= 0 {
+			fd.Flag, fd.Shorthand = name[:i], name[i+1:]
+		}
+		if err := check(&fd); err != nil {
+			return nil, fmt.Errorf("field %s: %v", fd.Name, err)
+		}
+		fields = append(fields, fd)
+	}
+	return fields, nil
+}
+
+// typeString returns the source text of a field type, or "" if flaggen
+// cannot spell it.
+//
+// typeString 返回字段类型的源码文本，如果 flaggen 无法写出它则返回 ""。
+func typeString(x ast.Expr) string {
+	switch x := x.(type) {
+	case *ast.Ident:
+		return x.Name
+	case *ast.SelectorExpr:
+		if pkg, ok := x.X.(*ast.Ident); ok {
+			return pkg.Name + "." + x.Sel.Name
+		}
+	case *ast.ArrayType:
+		if x.Len == nil {
+			if elem := typeString(x.Elt); elem != "" {
+				return "[]" + elem
+			}
+		}
+	}
+	return ""
+}
+
+// check reports whether the tags of fd make sense for its type, so that
+// mistakes show up when the code is generated rather than when it runs.
+//
+// check 报告 fd 的标签对它的类型是否合理，这样错误会在生成代码时而不是运行时暴露出来。
+func check(fd *field) error {
+	k, ok := kinds[fd.Type]
+	switch {
+	case !ok:
+		return fmt.Errorf("unsupported type %s", fd.Type)
+	case fd.Flag == "":
+		return fmt.Errorf("empty flag name")
+	case fd.Shorthand != "" && !k.short:
+		return fmt.Errorf("type %s does not support a shorthand", fd.Type)
+	case fd.Enum != "" && fd.Type != "string":
+		return fmt.Errorf("enum needs a string field")
+	case (fd.Min != "" || fd.Max != "") && !ordered(fd.Type):
+		return fmt.Errorf("min and max need a numeric or duration field")
+	}
+	for _, s := range []string{fd.Default, fd.Min, fd.Max} {
+		if s == "" {
+			continue
+		}
+		if _, err := literal(fd.Type, s); err != nil {
+			return err
+		}
+	}
+	if fd.Enum != "" && fd.Default != "" && !contains(strings.Split(fd.Enum, ","), fd.Default) {
+		return fmt.Errorf("default %q is not one of %s", fd.Default, fd.Enum)
+	}
+	return nil
+}
+
+// ordered reports whether values of typ can be compared with < and >.
+//
+// ordered 报告 typ 的值能否用 < 和 > 比较。
+func ordered(typ string) bool {
+	return typ != "string" && typ != "bool" && kinds[typ].elem == ""
+}
+
+func contains(list []string, s string) bool {
+	for _, x := range list {
+		if x == s {
+			return true
+		}
+	}
+	return false
+}
+
+// literal returns the Go literal of the value s of type typ, parsed the
+// way the flag of that type would parse it.
+//
+// literal 返回类型为 typ 的值 s 的 Go 字面量，解析方式与该类型的标志相同。
+func literal(typ, s string) (string, error) {
+	if elem := kinds[typ].elem; elem != "" {
+		if s == "" {
+			return "nil", nil
+		}
+		var lits []string
+		for _, e := range strings.Split(s, ",") {
+			lit, err := literal(elem, e)
+			if err != nil {
+				return "", err
+			}
+			lits = append(lits, lit)
+		}
+		return typ + "{" + strings.Join(lits, ", ") + "}", nil
+	}
+	var err error
+	switch typ {
+	case "string":
+		return strconv.Quote(s), nil
+	case "bool":
+		var v bool
+		if v, err = strconv.ParseBool(s); err == nil {
+			return strconv.FormatBool(v), nil
+		}
+	case "int", "int8", "int16", "int32", "int64":
+		var v int64
+		if v, err = strconv.ParseInt(s, 0, bitSize(typ)); err == nil {
+			return strconv.FormatInt(v, 10), nil
+		}
+	case "uint", "uint8", "uint16", "uint32", "uint64":
+		var v uint64
+		if v, err = strconv.ParseUint(s, 0, bitSize(typ)); err == nil {
+			return strconv.FormatUint(v, 10), nil
+		}
+	case "float32", "float64":
+		var v float64
+		if v, err = strconv.ParseFloat(s, bitSize(typ)); err == nil {
+			return strconv.FormatFloat(v, 'g', -1, bitSize(typ)), nil
+		}
+	case "time.Duration":
+		var d time.Duration
+		if d, err = time.ParseDuration(s); err == nil {
+			return durationLiteral(d), nil
+		}
+	}
+	return "", fmt.Errorf("invalid %s value %q: %v", typ, s, err)
+}
+
+// bitSize returns the size of the numeric type typ, with 64 for int and
+// uint, since the flags parse them as 64-bit values.
+//
+// bitSize 返回数值类型 typ 的位数，int 和 uint 为 64，因为标志将它们作为 64 位的值解析。
+func bitSize(typ string) int {
+	typ = strings.TrimLeft(typ, "abcdefghijklmnopqrstuvwxyz")
+	if typ == "" {
+		return 64
+	}
+	n, _ := strconv.Atoi(typ)
+	return n
+}
+
+var durationUnits = []struct {
+	d    time.Duration
+	name string
+}{
+	{time.Hour, "time.Hour"},
+	{time.Minute, "time.Minute"},
+	{time.Second, "time.Second"},
+	{time.Millisecond, "time.Millisecond"},
+	{time.Microsecond, "time.Microsecond"},
+}
+
+// durationLiteral returns d as a multiple of the largest unit that
+// divides it, such as 90 * time.Second.
+//
+// durationLiteral 将 d 写为能整除它的最大单位的倍数，例如 90 * time.Second。
+func durationLiteral(d time.Duration) string {
+	if d == 0 {
+		return "0"
+	}
+	for _, u := range durationUnits {
+		if d%u.d == 0 {
+			return fmt.Sprintf("%d * %s", d/u.d, u.name)
+		}
+	}
+	return strconv.FormatInt(int64(d), 10)
+}
+
+// generate returns the formatted source of the RegisterFlags and
+// ValidateFlags methods of typeName in package pkg.
+//
+// generate 返回包 pkg 中 typeName 的 RegisterFlags 和 ValidateFlags 方法的格式化源码。
+func generate(pkg, typeName, importPath string, fields []field) ([]byte, error) {
+	var reg, val bytes.Buffer
+	imports := map[string]bool{}
+	required := false
+	for _, fd := range fields {
+		k := kinds[fd.Type]
+		def := zeroLiteral(fd.Type)
+		if fd.Default != "" {
+			def, _ = literal(fd.Type, fd.Default)
+		}
+		if strings.Contains(def, "time.") {
+			imports["time"] = true
+		}
+		if fd.Shorthand != "" {
+			fmt.Fprintf(&reg, "\tfs.%sVarP(&c.%s, %q, %q, %s, %q)\n", k.method, fd.Name, fd.Flag, fd.Shorthand, def, fd.Usage)
+		} else {
+			fmt.Fprintf(&reg, "\tfs.%sVar(&c.%s, %q, %s, %q)\n", k.method, fd.Name, fd.Flag, def, fd.Usage)
+		}
+		if fd.Env != "" {
+			fmt.Fprintf(&reg, "\tfs.BindEnv(%q, %q)\n", fd.Flag, fd.Env)
+		}
+
+		if fd.Required {
+			required = true
+			imports["errors"] = true
+			fmt.Fprintf(&val, "\tif !set[%q] {\n\t\treturn errors.New(%q)\n\t}\n", fd.Flag, "flag -"+fd.Flag+" is required")
+		}
+		for _, b := range []struct{ tag, op, word string }{{fd.Min, "<", "least"}, {fd.Max, ">", "most"}} {
+			if b.tag == "" {
+				continue
+			}
+			lit, _ := literal(fd.Type, b.tag)
+			if strings.Contains(lit, "time.") {
+				imports["time"] = true
+			}
+			imports["fmt"] = true
+			fmt.Fprintf(&val, "\tif c.%s %s %s {\n\t\treturn fmt.Errorf(%q, c.%s)\n\t}\n",
+				fd.Name, b.op, lit, "flag -"+fd.Flag+" must be at "+b.word+" "+b.tag+", got %v", fd.Name)
+		}
+		if fd.Enum != "" {
+			values := strings.Split(fd.Enum, ",")
+			var lits []string
+			for _, v := range values {
+				lits = append(lits, strconv.Quote(v))
+			}
+			imports["fmt"] = true
+			fmt.Fprintf(&val, "\tswitch c.%s {\n\tcase %s:\n\tdefault:\n\t\treturn fmt.Errorf(%q, c.%s)\n\t}\n",
+				fd.Name, strings.Join(lits, ", "), "flag -"+fd.Flag+" must be one of "+strings.Join(values, ", ")+", got %q", fd.Name)
+		}
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "// Code generated by flaggen -type %s; DO NOT EDIT.\n\n", typeName)
+	fmt.Fprintf(&buf, "package %s\n\nimport (\n", pkg)
+	for _, imp := range []string{"errors", "fmt", "time"} {
+		if imports[imp] {
+			fmt.Fprintf(&buf, "\t%q\n", imp)
+		}
+	}
+	if path.Base(importPath) == "flag" {
+		fmt.Fprintf(&buf, "\n\t%q\n)\n\n", importPath)
+	} else {
+		fmt.Fprintf(&buf, "\n\tflag %q\n)\n\n", importPath)
+	}
+	fmt.Fprintf(&buf, "// RegisterFlags defines the flags of the fields of c on fs.\n")
+	fmt.Fprintf(&buf, "func (c *%s) RegisterFlags(fs *flag.FlagSet) {\n%s}\n\n", typeName, reg.Bytes())
+	fmt.Fprintf(&buf, "// ValidateFlags returns an error for the first field of c that breaks\n")
+	fmt.Fprintf(&buf, "// the rules of its tags. It should be called after fs.Parse.\n")
+	fmt.Fprintf(&buf, "func (c *%s) ValidateFlags(fs *flag.FlagSet) error {\n", typeName)
+	if required {
+		fmt.Fprintf(&buf, "\tset := make(map[string]bool)\n\tfs.Visit(func(f *flag.Flag) { set[f.Name] = true })\n")
+	}
+	fmt.Fprintf(&buf, "%s\treturn nil\n}\n", val.Bytes())
+	return format.Source(buf.Bytes())
+}
+
+// zeroLiteral returns the zero value of typ as a Go literal.
+//
+// zeroLiteral 以 Go 字面量的形式返回 typ 的零值。
+func zeroLiteral(typ string) string {
+	switch {
+	case typ == "string":
+		return `""`
+	case typ == "bool":
+		return "false"
+	case kinds[typ].elem != "":
+		return "nil"
+	}
+	return "0"
+}
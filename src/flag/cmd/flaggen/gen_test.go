@@ -0,0 +1,84 @@
+// Copyright 2018 Li Zebang. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"testing"
+)
+
+func TestGenerate(t *testing.T) {
+	pkg, fields, err := parseStruct("testdata/config", "Config")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(fields) != 8 {
+		t.Errorf("got %d fields; want 8", len(fields))
+	}
+	got, err := generate(pkg, "Config", defaultImport, fields)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want, err := ioutil.ReadFile("testdata/config/config_flags.golden")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("generated:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestParseStructErrors(t *testing.T) {
+	if _, _, err := parseStruct("testdata/config", "Missing"); err == nil {
+		t.Error("parseStruct found a missing type")
+	}
+}
+
+func TestCheck(t *testing.T) {
+	tests := []struct {
+		fd  field
+		err string
+	}{
+		{field{Type: "complex128", Flag: "c"}, "unsupported type complex128"},
+		{field{Type: "int8", Flag: "n", Shorthand: "n"}, "type int8 does not support a shorthand"},
+		{field{Type: "int", Flag: "n", Enum: "1,2"}, "enum needs a string field"},
+		{field{Type: "string", Flag: "s", Min: "a"}, "min and max need a numeric or duration field"},
+		{field{Type: "int8", Flag: "n", Default: "300"}, `invalid int8 value "300": strconv.ParseInt: parsing "300": value out of range`},
+		{field{Type: "time.Duration", Flag: "d", Max: "soon"}, `invalid time.Duration value "soon": time: invalid duration "soon"`},
+		{field{Type: "string", Flag: "m", Enum: "a,b", Default: "c"}, `default "c" is not one of a,b`},
+		{field{Type: "[]int", Flag: "n", Default: "1,x"}, `invalid int value "x": strconv.ParseInt: parsing "x": invalid syntax`},
+		{field{Type: "[]time.Duration", Flag: "d", Default: "1s,2m"}, ""},
+	}
+	for _, tt := range tests {
+		err := check(&tt.fd)
+		got := ""
+		if err != nil {
+			got = err.Error()
+		}
+		if got != tt.err {
+			t.Errorf("check(%+v) = %q; want %q", tt.fd, got, tt.err)
+		}
+	}
+}
+
+func TestDurationLiteral(t *testing.T) {
+	tests := []struct {
+		s, want string
+	}{
+		{"0", "0"},
+		{"90s", "90 * time.Second"},
+		{"2h", "2 * time.Hour"},
+		{"1.5ms", "1500 * time.Microsecond"},
+		{"3ns", "3"},
+		{"-1m", "-1 * time.Minute"},
+	}
+	for _, tt := range tests {
+		got, err := literal("time.Duration", tt.s)
+		if err != nil || got != tt.want {
+			t.Errorf("literal(time.Duration, %q) = %q, %v; want %q", tt.s, got, err, tt.want)
+		}
+	}
+}
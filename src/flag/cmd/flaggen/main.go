@@ -0,0 +1,124 @@
+// Copyright 2018 Li Zebang. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+// Flaggen generates flag definitions and validation code for a struct
+// whose fields are annotated with struct tags, so that programs get
+// struct-driven flags without reflection at run time. It is meant to be
+// run by go generate:
+//
+//	//go:generate flaggen -type Config
+//	type Config struct {
+//		Addr    string        `flag:"addr" default:":8080" usage:"listen address"`
+//		Workers int           `flag:"workers,w" default:"4" min:"1" max:"64"`
+//		Timeout time.Duration `flag:"timeout" default:"30s" env:"APP_TIMEOUT"`
+//		Mode    string        `flag:"mode" default:"fast" enum:"fast,safe"`
+//		Token   string        `flag:"token" required:"true"`
+//	}
+//
+// Usage:
+//
+//	flaggen -type name [-o file] [-import path] [dir]
+//
+// For the struct type name in the package in dir, by default ".", flaggen
+// writes name_flags.go, in lower case, with two methods:
+//
+//	func (c *Config) RegisterFlags(fs *flag.FlagSet)
+//	func (c *Config) ValidateFlags(fs *flag.FlagSet) error
+//
+// RegisterFlags defines a flag for each tagged field with the XxxVar or
+// XxxVarP method of its type, and ValidateFlags, to be called after Parse,
+// returns an error for the first field that breaks its rules.
+//
+// The tags are:
+//
+//	flag      the flag name, optionally followed by a comma and a shorthand;
+//	          fields without it, or with "-", are skipped
+//	usage     the usage message
+//	default   the default value, checked by flaggen
+//	env       an environment variable bound with BindEnv
+//	min, max  bounds of a numeric or duration value
+//	enum      comma-separated values a string may take
+//	required  "true" if the flag must be set
+//
+// Fields may be string, bool, int, int8, int16, int32, int64, uint,
+// uint8, uint16, uint32, uint64, float32, float64, time.Duration, or a
+// slice of string, int, int64, float64 or time.Duration. The flag package
+// is imported from github.com/lizebang/annotate-go-sdk/src/flag unless
+// -import names another.
+//
+// Flaggen 为用结构体标签注释了字段的结构体生成标志的定义和校验代码，这样程序可以获得由结构体
+// 驱动的标志，而不需要在运行时使用反射。它应该由 go generate 运行，示例见上。
+//
+// 用法：
+//
+//	flaggen -type name [-o file] [-import path] [dir]
+//
+// 对于 dir（默认为 "."）中的包里的结构体类型 name，flaggen 默认输出小写的 name_flags.go，
+// 其中包含 RegisterFlags 和 ValidateFlags 两个方法。RegisterFlags 用每个带有标签的字段的
+// 类型的 XxxVar 或 XxxVarP 方法为它定义一个标志；ValidateFlags 应该在 Parse 之后调用，它为
+// 第一个违反规则的字段返回一个错误。
+//
+// 标签有：flag 是标志名称，后面可以跟一个逗号和缩写，没有该标签或者值为 "-" 的字段会被跳过；
+// usage 是用法信息；default 是默认值，flaggen 会检查它；env 是用 BindEnv 绑定的环境变量；
+// min 和 max 是数值或时间段的边界；enum 是字符串可以取的以逗号分隔的值；required 为 "true"
+// 时标志必须被设置。
+//
+// 字段可以是 string、bool、int、int8、int16、int32、int64、uint、uint8、uint16、uint32、
+// uint64、float32、float64、time.Duration，或者 string、int、int64、float64、
+// time.Duration 的切片。除非 -import 给出了其他路径，标志包从
+// github.com/lizebang/annotate-go-sdk/src/flag 导入。
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/lizebang/annotate-go-sdk/src/flag"
+)
+
+var (
+	typeName   = flag.String("type", "", "struct type `name`")
+	outFile    = flag.String("o", "", "write the code to `file` instead of name_flags.go")
+	importPath = flag.String("import", defaultImport, "import `path` of the flag package")
+)
+
+func usage() {
+	fmt.Fprintf(os.Stderr, "usage: flaggen -type name [-o file] [-import path] [dir]\n")
+	flag.PrintDefaults()
+	os.Exit(2)
+}
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("flaggen: ")
+	flag.Usage = usage
+	flag.Parse()
+	if *typeName == "" || flag.NArg() > 1 {
+		usage()
+	}
+	dir := "."
+	if flag.NArg() == 1 {
+		dir = flag.Arg(0)
+	}
+
+	pkg, fields, err := parseStruct(dir, *typeName)
+	if err != nil {
+		log.Fatal(err)
+	}
+	src, err := generate(pkg, *typeName, *importPath, fields)
+	if err != nil {
+		log.Fatal(err)
+	}
+	out := *outFile
+	if out == "" {
+		out = filepath.Join(dir, strings.ToLower(*typeName)+"_flags.go")
+	}
+	if err := ioutil.WriteFile(out, src, 0666); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,17 @@
+package config
+
+import "time"
+
+//go:generate flaggen -type Config
+type Config struct {
+	Addr    string        `flag:"addr" default:":8080" usage:"listen address"`
+	Workers int           `flag:"workers,w" default:"4" min:"1" max:"64" usage:"number of workers"`
+	Timeout time.Duration `flag:"timeout" default:"90s" max:"1h" env:"APP_TIMEOUT"`
+	Mode    string        `flag:"mode" default:"fast" enum:"fast,safe"`
+	Token   string        `flag:"token" required:"true" usage:"API token"`
+	Verbose bool          `flag:"verbose,v"`
+	Tags    []string      `flag:"tag" default:"a,b"`
+	Ratio   float32       `flag:"ratio" default:"0.5" min:"0" max:"1"`
+	Cache   string        // no tag
+	Skip    int           `flag:"-"`
+}
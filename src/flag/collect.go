@@ -0,0 +1,98 @@
+// Copyright 2018 Li Zebang. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package flag
+
+import "strings"
+
+// ParseErrors is the error returned by Parse in CollectAllErrors mode. It
+// lists every problem found in the arguments, the environment and the
+// value sources, in the order they were found.
+//
+// ParseErrors 是 Parse 在 CollectAllErrors 模式下返回的错误。它按照发现的顺序列出了在
+// 参数、环境变量和值来源中发现的所有问题。
+type ParseErrors []error
+
+// Error returns the messages of the errors, one per line.
+//
+// Error 返回各个错误的信息，每行一个。
+func (e ParseErrors) Error() string {
+	msgs := make([]string, len(e))
+	for i, err := range e {
+		msgs[i] = err.Error()
+	}
+	return strings.Join(msgs, "\n")
+}
+
+// Unwrap returns the errors, so that errors.Is and errors.As look at each
+// of them.
+//
+// Unwrap 返回这些错误，这样 errors.Is 和 errors.As 会检查其中的每一个。
+func (e ParseErrors) Unwrap() []error { return e }
+
+// parseAll is Parse in CollectAllErrors and WarnOnError modes. An argument
+// that fails is skipped, and parsing goes on with the next one; a flag that
+// is not defined cannot tell whether it has a value, so a value that
+// follows it as a separate argument ends the flags like any other
+// non-flag argument. The usage message is printed once, after the errors,
+// except in WarnOnError mode, which returns nil instead of the errors.
+//
+// parseAll 是 CollectAllErrors 和 WarnOnError 模式下的 Parse。出错的参数会被跳过，解析
+// 从下一个参数继续；没有定义的标志无法知道它是否有值，所以作为单独的参数跟在它后面的值和
+// 其他非标志参数一样会结束标志。用法信息在这些错误之后只打印一次，但 WarnOnError 模式下
+// 不打印，并且返回 nil 而不是这些错误。
+func (f *FlagSet) parseAll() error {
+	var errs ParseErrors
+	for {
+		n := len(f.args)
+		seen, err := f.parseOne()
+		if err == ErrHelp {
+			return err
+		}
+		if err != nil {
+			errs = append(errs, err)
+			// 没有被消耗的参数（例如语法错误的参数）需要被跳过
+			if len(f.args) == n { // arguments not consumed, such as bad syntax, are skipped
+				f.args = f.args[1:]
+			}
+			continue
+		}
+		if !seen {
+			break
+		}
+	}
+	f.restorePositional()
+	if err := f.parseSources(); err != nil {
+		errs = append(errs, err.(ParseErrors)...)
+	}
+	if err := f.checkRequiredTogether(); err != nil {
+		errs = append(errs, err)
+	}
+	if err := f.checkPositionals(); err != nil {
+		errs = append(errs, err)
+	}
+	if err := f.checkArity(); err != nil {
+		errs = append(errs, err)
+	}
+	// 钩子只在没有其他错误时运行，因为它们检查的是已经设置好的标志
+	if len(errs) == 0 { // the hooks run only without other errors, as they check flags that are all set
+		if err := f.runPostParse(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) == 0 || f.errorHandling == WarnOnError {
+		return nil
+	}
+	f.usage()
+	return errs
+}
+
+// collecting reports whether Parse goes on past errors, as it does in
+// CollectAllErrors and WarnOnError modes.
+//
+// collecting 报告 Parse 是否越过错误继续解析，在 CollectAllErrors 和 WarnOnError 模式下
+// 就是这样。
+func (f *FlagSet) collecting() bool {
+	return f.errorHandling == CollectAllErrors || f.errorHandling == WarnOnError
+}
@@ -0,0 +1,128 @@
+// Copyright 2018 Li Zebang. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package flag_test
+
+import (
+	"bytes"
+	"errors"
+	. "flag"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestCollectAllErrors(t *testing.T) {
+	fs := NewFlagSet("collect", CollectAllErrors)
+	var buf bytes.Buffer
+	fs.SetOutput(&buf)
+	n := fs.Int("n", 0, "number")
+	s := fs.String("s", "", "string")
+	b := fs.Bool("b", false, "bool")
+	d := fs.Duration("d", 0, "duration")
+	fs.AddSource(MapSource{"d": "soon"}, 1)
+	args := []string{"-n", "x", "---bad", "-undefined", "-s", "ok", "-b=maybe", "-b", "rest", "-n", "3"}
+	err := fs.Parse(args)
+	errs, ok := err.(ParseErrors)
+	if !ok {
+		t.Fatalf("Parse = %#v; want ParseErrors", err)
+	}
+	want := []string{
+		`invalid value "x" for flag -n: strconv.ParseInt`,
+		"bad flag syntax: ---bad",
+		"flag provided but not defined: -undefined",
+		`invalid boolean value "maybe" for -b: strconv.ParseBool`,
+		`invalid value "soon" for value source flag.MapSource of flag -d: time: invalid duration`,
+	}
+	if len(errs) != len(want) {
+		t.Fatalf("got %d errors; want %d:\n%v", len(errs), len(want), err)
+	}
+	for i, e := range errs {
+		if !strings.HasPrefix(e.Error(), want[i]) {
+			t.Errorf("error %d = %q; want prefix %q", i, e, want[i])
+		}
+	}
+	if *s != "ok" || !*b || *n != 0 || *d != 0 {
+		t.Errorf("n, s, b, d = %d, %q, %v, %v", *n, *s, *b, *d)
+	}
+	if got := fmt.Sprintf("%q", fs.Args()); got != `["rest" "-n" "3"]` {
+		t.Errorf("Args = %s", got)
+	}
+	if got := strings.Count(buf.String(), "Usage of collect:"); got != 1 {
+		t.Errorf("usage printed %d times:\n%s", got, buf.String())
+	}
+	var undefined *ErrUndefinedFlag
+	if !errors.As(err, &undefined) || undefined.Name != "undefined" {
+		t.Errorf("errors.As(*ErrUndefinedFlag) = %v", undefined)
+	}
+}
+
+func TestCollectAllErrorsSuccessAndHelp(t *testing.T) {
+	fs := NewFlagSet("collect", CollectAllErrors)
+	var buf bytes.Buffer
+	fs.SetOutput(&buf)
+	fs.Int("n", 0, "number")
+	if err := fs.Parse([]string{"-n", "1", "arg"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := fs.Parse([]string{"-n", "x", "-h", "-n", "y"}); err != ErrHelp {
+		t.Errorf("Parse with -h = %v; want ErrHelp", err)
+	}
+	if buf.Len() == 0 {
+		t.Error("no output for -h")
+	}
+}
+
+func TestCollectAllErrorsTogether(t *testing.T) {
+	fs := NewFlagSet("collect", CollectAllErrors)
+	fs.SetOutput(new(bytes.Buffer))
+	fs.String("user", "", "user")
+	fs.String("password", "", "password")
+	fs.Int("n", 0, "number")
+	fs.MarkRequiredTogether("user", "password")
+	err := fs.Parse([]string{"-user", "u", "-n", "x"})
+	errs, ok := err.(ParseErrors)
+	if !ok || len(errs) != 2 {
+		t.Fatalf("Parse = %v; want two errors", err)
+	}
+	if !strings.Contains(errs[1].Error(), "must be set together") {
+		t.Errorf("second error = %v", errs[1])
+	}
+	if got := err.Error(); !strings.Contains(got, "\n") {
+		t.Errorf("Error() = %q; want one line per error", got)
+	}
+}
+
+func TestWarnOnError(t *testing.T) {
+	fs := NewFlagSet("warn", WarnOnError)
+	var buf bytes.Buffer
+	fs.SetOutput(&buf)
+	n := fs.Int("n", 0, "number")
+	s := fs.String("s", "", "string")
+	fs.AddSource(MapSource{"n": "many"}, 1)
+	if err := fs.Parse([]string{"-stale", "-n", "x", "-s", "ok", "rest"}); err != nil {
+		t.Fatalf("Parse = %v; want nil", err)
+	}
+	if *n != 0 || *s != "ok" {
+		t.Errorf("n, s = %d, %q", *n, *s)
+	}
+	if got := fmt.Sprintf("%q", fs.Args()); got != `["rest"]` {
+		t.Errorf("Args = %s", got)
+	}
+	want := "flag provided but not defined: -stale\n" +
+		"invalid value \"x\" for flag -n: strconv.ParseInt: parsing \"x\": invalid syntax\n" +
+		"invalid value \"many\" for value source flag.MapSource of flag -n: strconv.ParseInt: parsing \"many\": invalid syntax\n"
+	if buf.String() != want {
+		t.Errorf("output:\n%s\nwant:\n%s", buf.String(), want)
+	}
+	if err := fs.ParseConfigINI(strings.NewReader("gone = 1")); err != nil {
+		t.Errorf("ParseConfigINI = %v; want nil", err)
+	}
+	if err := fs.ParseString(`-s "unterminated`); err != nil {
+		t.Errorf("ParseString = %v; want nil", err)
+	}
+	if err := fs.Parse([]string{"-h"}); err != ErrHelp {
+		t.Errorf("Parse with -h = %v; want ErrHelp", err)
+	}
+}
@@ -0,0 +1,104 @@
+// Copyright 2018 Li Zebang. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package flag
+
+import (
+	"io"
+	"os"
+)
+
+// A ColorMode tells PrintDefaults whether to color its output.
+//
+// ColorMode 告诉 PrintDefaults 是否为它的输出添加颜色。
+type ColorMode int
+
+const (
+	// ColorNever prints plain text. It is the default.
+	//
+	// ColorNever 打印纯文本。它是默认值。
+	ColorNever ColorMode = iota
+
+	// ColorAuto colors the output when it is a terminal, unless the
+	// NO_COLOR environment variable is set or TERM is dumb.
+	//
+	// ColorAuto 在输出是终端时添加颜色，除非设置了 NO_COLOR 环境变量或者 TERM 为 dumb。
+	ColorAuto
+
+	// ColorAlways colors the output wherever it goes.
+	//
+	// ColorAlways 总是为输出添加颜色。
+	ColorAlways
+)
+
+// ANSI escape sequences used by PrintDefaults.
+//
+// PrintDefaults 使用的 ANSI 转义序列。
+const (
+	colorBold  = "\x1b[1m"
+	colorDim   = "\x1b[2m"
+	colorType  = "\x1b[36m"
+	colorReset = "\x1b[0m"
+)
+
+// SetColor sets whether PrintDefaults colors its output: flag names bold,
+// argument types in cyan and defaults dimmed.
+//
+// SetColor 设置 PrintDefaults 是否为它的输出添加颜色：标志名称加粗，参数类型为青色，默认值
+// 变暗。
+func (f *FlagSet) SetColor(mode ColorMode) {
+	f.color = mode
+}
+
+// SetColor sets whether PrintDefaults colors the usage message of the
+// command-line flags.
+//
+// SetColor 设置 PrintDefaults 是否为命令行标志的用法信息添加颜色。
+func SetColor(mode ColorMode) {
+	CommandLine.SetColor(mode)
+}
+
+// colorizer returns a function that wraps text in an escape sequence and a
+// reset when f's output is to be colored, and returns it as is otherwise.
+//
+// colorizer 返回一个函数，当 f 的输出需要添加颜色时，它用转义序列和重置序列包围文本，
+// 否则原样返回文本。
+func (f *FlagSet) colorizer() func(code, s string) string {
+	if !colorable(f.color, f.Output()) {
+		return func(code, s string) string { return s }
+	}
+	return func(code, s string) string { return code + s + colorReset }
+}
+
+// colorable reports whether output written to w should be colored in the
+// given mode.
+//
+// colorable 报告在给定模式下写入 w 的输出是否应该添加颜色。
+func colorable(mode ColorMode, w io.Writer) bool {
+	switch mode {
+	case ColorAlways:
+		return true
+	case ColorAuto:
+		if os.Getenv("NO_COLOR") != "" || os.Getenv("TERM") == "dumb" {
+			return false
+		}
+		return isTerminal(w)
+	}
+	return false
+}
+
+// isTerminal reports whether w is a terminal, that is, a character device.
+//
+// isTerminal 报告 w 是否是终端，即字符设备。
+//
+// NOTE: 字符设备不一定是终端（例如 /dev/null），但这足以决定是否添加颜色，并且不需要依赖
+// 系统调用。
+func isTerminal(w io.Writer) bool {
+	file, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+	fi, err := file.Stat()
+	return err == nil && fi.Mode()&os.ModeCharDevice != 0
+}
@@ -0,0 +1,76 @@
+// Copyright 2018 Li Zebang. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package flag_test
+
+import (
+	"bytes"
+	. "flag"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestSetColor(t *testing.T) {
+	fs := NewFlagSet("color", ContinueOnError)
+	var buf bytes.Buffer
+	fs.SetOutput(&buf)
+	fs.Bool("v", false, "verbose")
+	fs.Group("Server").IntP("port", "p", 80, "port to listen on")
+	fs.SetColor(ColorAlways)
+	fs.PrintDefaults()
+	want := strings.Join([]string{
+		"  \x1b[1m-v\x1b[0m\tverbose",
+		"",
+		"\x1b[1mServer:\x1b[0m",
+		"  \x1b[1m-p, --port\x1b[0m \x1b[36mint\x1b[0m",
+		"    \tport to listen on \x1b[2m(default 80)\x1b[0m",
+		"",
+	}, "\n")
+	if buf.String() != want {
+		t.Errorf("PrintDefaults:\n%q\nwant:\n%q", buf.String(), want)
+	}
+
+	buf.Reset()
+	fs.SetColor(ColorAuto)
+	fs.PrintDefaults()
+	if strings.Contains(buf.String(), "\x1b[") {
+		t.Errorf("ColorAuto colored a buffer:\n%q", buf.String())
+	}
+}
+
+func TestColorable(t *testing.T) {
+	tty, err := os.OpenFile(os.DevNull, os.O_WRONLY, 0)
+	if err != nil {
+		t.Skip(err)
+	}
+	defer tty.Close()
+	if fi, err := tty.Stat(); err != nil || fi.Mode()&os.ModeCharDevice == 0 {
+		t.Skipf("%s is not a character device", os.DevNull)
+	}
+	defer setenv(t, "TERM", "xterm")()
+	defer setenv(t, "NO_COLOR", "")()
+	tests := []struct {
+		mode    ColorMode
+		noColor string
+		term    string
+		want    bool
+	}{
+		{ColorNever, "", "xterm", false},
+		{ColorAlways, "1", "dumb", true},
+		{ColorAuto, "", "xterm", true},
+		{ColorAuto, "1", "xterm", false},
+		{ColorAuto, "", "dumb", false},
+	}
+	for _, tt := range tests {
+		os.Setenv("NO_COLOR", tt.noColor)
+		os.Setenv("TERM", tt.term)
+		if got := Colorable(tt.mode, tty); got != tt.want {
+			t.Errorf("Colorable(%d) with NO_COLOR=%q TERM=%q = %v; want %v", tt.mode, tt.noColor, tt.term, got, tt.want)
+		}
+	}
+	if Colorable(ColorAuto, new(bytes.Buffer)) {
+		t.Error("Colorable(ColorAuto) of a buffer = true")
+	}
+}
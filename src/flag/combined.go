@@ -0,0 +1,188 @@
+// Copyright 2018 Li Zebang. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package flag
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// A ParseMode is a set of options that change how FlagSet.Parse reads the
+// arguments. The zero value parses them like the standard flag package.
+//
+// ParseMode 是一组改变 FlagSet.Parse 读取参数的方式的选项。它的零值与标准库的 flag 包的
+// 解析方式相同。
+type ParseMode uint
+
+const (
+	// CombinedShorts makes Parse accept POSIX-style bundled short flags. A
+	// single-dash argument that is not a defined flag name is read as a
+	// run of one-letter flags or shorthands: -abc is -a -b -c when all of
+	// them are boolean, and the first non-boolean flag takes the rest of the
+	// argument as its value, so -ofile is -o file and -vn3 is -v -n 3.
+	//
+	// CombinedShorts 使 Parse 接受 POSIX 风格的组合短标志。不是已定义的标志名称的单横线
+	// 参数会被当作一串单字母的标志或缩写来读取：当它们都是布尔标志时 -abc 就是 -a -b -c，
+	// 第一个非布尔标志会将参数的剩余部分作为它的值，所以 -ofile 就是 -o file，-vn3 就是
+	// -v -n 3。
+	CombinedShorts ParseMode = 1 << iota
+
+	// Interspersed makes Parse go on past arguments that are not flags, so
+	// flags may follow them, as in build ./pkg -v. Such arguments are left
+	// in Args in their order, followed by everything after a "--", which
+	// still ends the flags.
+	//
+	// Interspersed 使 Parse 越过不是标志的参数继续解析，所以标志可以出现在它们的后面，例如
+	// build ./pkg -v。这些参数按照它们的顺序留在 Args 中，后面是 "--" 之后的所有参数，
+	// "--" 仍然会结束标志。
+	Interspersed
+
+	// ResponseFiles makes Parse expand an argument @file into the arguments
+	// read from file, one per line, so that long command lines can be kept
+	// in a file. See expandResponseFile for the format of the file.
+	//
+	// ResponseFiles 使 Parse 将参数 @file 展开为从 file 中读取的参数，每行一个，这样
+	// 很长的命令行就可以保存在文件中。文件的格式参见 expandResponseFile。
+	ResponseFiles
+
+	// ExpandEnv makes Parse expand environment variables in the values of
+	// non-boolean flags on the command line before they are set, so
+	// -log-dir '${HOME}/logs' works even if the shell did not expand it.
+	// See expandEnv for the syntax.
+	//
+	// ExpandEnv 使 Parse 在设置命令行上非布尔标志的值之前展开其中的环境变量，这样即使 shell
+	// 没有展开，-log-dir '${HOME}/logs' 也能正常工作。语法参见 expandEnv。
+	ExpandEnv
+
+	// PassthroughArgs makes Parse store the arguments after the "--" that ends
+	// the flags apart from Args, to be returned by FlagSet.Passthrough, as
+	// wrappers such as run -- docker ps -a pass them on untouched. A "--" is
+	// then never taken as the value of a flag, so run -o -- ls reports that
+	// -o is missing its argument.
+	//
+	// PassthroughArgs 使 Parse 将结束标志的 "--" 之后的参数与 Args 分开保存，由
+	// FlagSet.Passthrough 返回，这样 run -- docker ps -a 这样的包装命令可以原封不动地将它们
+	// 传递下去。此时 "--" 永远不会被当作标志的值，所以 run -o -- ls 会报告 -o 缺少参数。
+	PassthroughArgs
+)
+
+// IMP: 已定义的标志名称优先，所以同时定义了 -abc 和 -a、-b、-c 时，-abc 仍然是那个长标志。
+// 双横线参数从不拆分，--abc 只能是长标志。
+
+// ParseMode returns the parse mode of the flag set.
+//
+// ParseMode 返回标志集的解析模式。
+func (f *FlagSet) ParseMode() ParseMode {
+	return f.mode
+}
+
+// SetParseMode sets the parse mode of the flag set.
+// It must be called before Parse.
+//
+// SetParseMode 设置标志集的解析模式。必须在 Parse 之前调用它。
+func (f *FlagSet) SetParseMode(mode ParseMode) {
+	f.mode = mode
+}
+
+// setAsidePositional moves the positional argument at the front of f.args
+// aside in Interspersed mode, and reports whether it did.
+//
+// setAsidePositional 在 Interspersed 模式下将 f.args 开头的位置参数暂存起来，并报告是否
+// 这样做了。
+func (f *FlagSet) setAsidePositional() bool {
+	if f.mode&Interspersed == 0 {
+		return false
+	}
+	f.positional = append(f.positional, f.args[0])
+	f.args = f.args[1:]
+	return true
+}
+
+// restorePositional puts the positional arguments set aside back in front
+// of the remaining arguments.
+//
+// restorePositional 将暂存的位置参数放回剩余参数的前面。
+func (f *FlagSet) restorePositional() {
+	if len(f.positional) > 0 {
+		f.args = append(f.positional, f.args...)
+		f.positional = nil
+	}
+}
+
+// lookupShort returns the flag named by the one-letter name, or whose
+// shorthand it is.
+//
+// lookupShort 返回名称为单字母 name 的标志，或者以它为缩写的标志。
+func (f *FlagSet) lookupShort(name string) *Flag {
+	if flag, ok := f.formal[name]; ok {
+		return flag
+	}
+	return f.shorthands[name]
+}
+
+// parseCombined parses shorts, an argument without its dash, as a run of
+// one-letter flags. It reports whether a flag was seen.
+//
+// parseCombined 将 shorts（去掉了横线的参数）当作一串单字母标志来解析。它还返回是否
+// 找到标志。
+func (f *FlagSet) parseCombined(shorts string) (bool, error) {
+	for i := 0; i < len(shorts); i++ {
+		// A flag name may be a multi-byte character.
+		//
+		// 标志的名字可能是一个多字节字符。
+		_, size := utf8.DecodeRuneInString(shorts[i:])
+		name := shorts[i : i+size]
+		i += size - 1
+		flag := f.lookupShort(name)
+		if flag == nil || f.gatedOut(flag) {
+			if name == "h" {
+				f.usage()
+				return false, ErrHelp
+			}
+			return false, f.fail(&ErrUndefinedFlag{Name: name})
+		}
+		rest := shorts[i+1:]
+		var value string
+		if fv, ok := flag.Value.(boolFlag); ok && fv.IsBoolFlag() {
+			value = "true"
+			// -ab=false sets the last flag of the run.
+			//
+			// -ab=false 设置这一串中的最后一个标志。
+			if strings.HasPrefix(rest, "=") {
+				value = rest[1:]
+				i = len(shorts)
+			}
+			if err := fv.Set(value); err != nil {
+				return false, f.fail(&ErrInvalidValue{Name: name, Value: value, Err: err,
+					msg: fmt.Sprintf(f.messages.get("invalid boolean value %q for -%s: %v"), value, name, err)})
+			}
+		} else {
+			// The rest of the argument is the value, or else the next one.
+			//
+			// 参数的剩余部分是值，否则下一个参数是值。
+			value = strings.TrimPrefix(rest, "=")
+			if rest == "" && flag.NoOptDefVal != "" {
+				value = flag.NoOptDefVal
+			} else if rest == "" {
+				if !f.hasValueArg() {
+					return false, f.fail(&ErrMissingArgument{Name: name})
+				}
+				value, f.args = f.args[0], f.args[1:]
+			}
+			if err := f.setValue(flag, value); err != nil {
+				return false, f.fail(&ErrInvalidValue{Name: name, Value: value, Err: err})
+			}
+			i = len(shorts)
+		}
+		if f.actual == nil {
+			f.actual = make(map[string]*Flag, len(f.formal))
+		}
+		f.actual[flag.Name] = flag
+		f.trace(flag, "command line")
+		f.report(flag, value)
+	}
+	return true, nil
+}
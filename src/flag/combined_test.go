@@ -0,0 +1,122 @@
+// Copyright 2018 Li Zebang. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package flag_test
+
+import (
+	"bytes"
+	. "flag"
+	"strings"
+	"testing"
+)
+
+func newCombinedFlagSet() (*FlagSet, *bool, *bool, *bool, *string, *int) {
+	fs := NewFlagSet("combined", ContinueOnError)
+	fs.SetOutput(new(bytes.Buffer))
+	fs.SetParseMode(CombinedShorts)
+	a := fs.Bool("a", false, "a")
+	b := fs.Bool("b", false, "b")
+	v := fs.BoolP("verbose", "v", false, "verbose")
+	o := fs.StringP("output", "o", "", "output")
+	n := fs.Int("n", 0, "n")
+	fs.Bool("é", false, "a non-ASCII name")
+	return fs, a, b, v, o, n
+}
+
+func TestCombinedShorts(t *testing.T) {
+	tests := []struct {
+		args    []string
+		a, b, v bool
+		o       string
+		n       int
+		rest    []string
+	}{
+		{[]string{"-ab"}, true, true, false, "", 0, nil},
+		{[]string{"-abv", "x"}, true, true, true, "", 0, []string{"x"}},
+		{[]string{"-ofile"}, false, false, false, "file", 0, nil},
+		{[]string{"-o=file"}, false, false, false, "file", 0, nil},
+		{[]string{"-o", "file"}, false, false, false, "file", 0, nil},
+		{[]string{"-ao", "file", "x"}, true, false, false, "file", 0, []string{"x"}},
+		{[]string{"-vn3"}, false, false, true, "", 3, nil},
+		{[]string{"-aé"}, true, false, false, "", 0, nil},
+		{[]string{"-avo-b"}, true, false, true, "-b", 0, nil},
+		{[]string{"-ab=false", "-b"}, true, true, false, "", 0, nil},
+		{[]string{"-verbose", "-output=out"}, false, false, true, "out", 0, nil},
+		{[]string{"--", "-ab"}, false, false, false, "", 0, []string{"-ab"}},
+	}
+	for _, tt := range tests {
+		fs, a, b, v, o, n := newCombinedFlagSet()
+		if err := fs.Parse(tt.args); err != nil {
+			t.Errorf("Parse(%q): %v", tt.args, err)
+			continue
+		}
+		if *a != tt.a || *b != tt.b || *v != tt.v || *o != tt.o || *n != tt.n {
+			t.Errorf("Parse(%q): got a=%v b=%v v=%v o=%q n=%d; want a=%v b=%v v=%v o=%q n=%d",
+				tt.args, *a, *b, *v, *o, *n, tt.a, tt.b, tt.v, tt.o, tt.n)
+		}
+		if strings.Join(fs.Args(), " ") != strings.Join(tt.rest, " ") {
+			t.Errorf("Parse(%q): Args() = %q; want %q", tt.args, fs.Args(), tt.rest)
+		}
+	}
+}
+
+func TestCombinedShortsNFlag(t *testing.T) {
+	fs, _, _, _, _, _ := newCombinedFlagSet()
+	if err := fs.Parse([]string{"-abv", "-v", "--verbose"}); err != nil {
+		t.Fatal(err)
+	}
+	if fs.NFlag() != 3 {
+		t.Errorf("NFlag() = %d; want 3", fs.NFlag())
+	}
+}
+
+func TestCombinedShortsLongNameWins(t *testing.T) {
+	fs, a, b, _, _, _ := newCombinedFlagSet()
+	ab := fs.Bool("ab", false, "ab")
+	if err := fs.Parse([]string{"-ab"}); err != nil {
+		t.Fatal(err)
+	}
+	if !*ab || *a || *b {
+		t.Errorf("got ab=%v a=%v b=%v; want only ab set", *ab, *a, *b)
+	}
+}
+
+func TestCombinedShortsErrors(t *testing.T) {
+	tests := []struct {
+		args []string
+		err  string
+	}{
+		{[]string{"-abx"}, "flag provided but not defined: -x"},
+		{[]string{"-aë"}, "flag provided but not defined: -ë"},
+		{[]string{"-ao"}, "flag needs an argument: -o"},
+		{[]string{"-nx"}, `invalid value "x" for flag -n`},
+		{[]string{"-a=maybe"}, `invalid boolean value "maybe" for -a`},
+		{[]string{"--ab"}, "flag provided but not defined: -ab"},
+	}
+	for _, tt := range tests {
+		fs, _, _, _, _, _ := newCombinedFlagSet()
+		err := fs.Parse(tt.args)
+		if err == nil || !strings.Contains(err.Error(), tt.err) {
+			t.Errorf("Parse(%q) = %v; want error containing %q", tt.args, err, tt.err)
+		}
+	}
+}
+
+func TestCombinedShortsHelp(t *testing.T) {
+	fs, _, _, _, _, _ := newCombinedFlagSet()
+	if err := fs.Parse([]string{"-ah"}); err != ErrHelp {
+		t.Errorf("Parse(-ah) = %v; want ErrHelp", err)
+	}
+}
+
+func TestCombinedShortsOff(t *testing.T) {
+	fs, _, _, _, _, _ := newCombinedFlagSet()
+	fs.SetParseMode(0)
+	if fs.ParseMode() != 0 {
+		t.Errorf("ParseMode() = %d; want 0", fs.ParseMode())
+	}
+	if err := fs.Parse([]string{"-ab"}); err == nil {
+		t.Error("Parse(-ab) succeeded without CombinedShorts")
+	}
+}
@@ -0,0 +1,143 @@
+// Copyright 2018 Li Zebang. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package flag
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// A Completion tells shell completion which values a flag takes. All of
+// its fields are optional, and the candidates of each are offered together.
+//
+// Completion 告诉 shell 补全一个标志接受哪些值。它的所有字段都是可选的，每个字段给出的候选值
+// 会一起提供。
+type Completion struct {
+	// Choices are the values the flag takes.
+	//
+	// Choices 是标志接受的值。
+	Choices []string
+
+	// Glob is a pattern, as in filepath.Match, of the names of the files
+	// the flag takes, such as "*.json". Directories are offered too, so
+	// the user can descend into them.
+	//
+	// Glob 是标志接受的文件名称的模式，和 filepath.Match 中的一样，例如 "*.json"。目录
+	// 也会被提供，这样用户可以进入其中。
+	Glob string
+
+	// Func returns the values starting with prefix, for values only known
+	// at run time.
+	//
+	// Func 返回以 prefix 开头的值，用于只有运行时才知道的值。
+	Func func(prefix string) []string
+}
+
+// SetCompletion attaches completion hints to the named flag, which shell
+// completion can read from its Completion field or get applied by
+// CompleteValue. SetCompletion panics if the flag is not defined.
+//
+// SetCompletion 为名为 name 的标志附加补全提示，shell 补全可以从它的 Completion 字段读取
+// 这些提示，或者通过 CompleteValue 应用它们。如果标志没有定义，SetCompletion 会 panic。
+func (f *FlagSet) SetCompletion(name string, c Completion) {
+	flag := f.lookupName(name)
+	if flag == nil {
+		msg := fmt.Sprintf("flag provided to SetCompletion but not defined: -%s", name)
+		if f.name != "" {
+			msg = f.name + " " + msg
+		}
+		fmt.Fprintln(f.Output(), msg)
+		panic(msg)
+	}
+	flag.Completion = &c
+}
+
+// SetCompletion attaches completion hints to the named command-line flag.
+//
+// SetCompletion 为名为 name 的命令行标志附加补全提示。
+func SetCompletion(name string, c Completion) {
+	CommandLine.SetCompletion(name, c)
+}
+
+// CompleteValue returns the candidate values of the named flag that start
+// with prefix: the matching Choices, then what Func returns, then the
+// files and directories matching Glob, in that order. It returns nil if
+// the flag is not defined or has no completion hints.
+//
+// CompleteValue 返回名为 name 的标志以 prefix 开头的候选值：依次是匹配的 Choices、Func
+// 返回的值以及匹配 Glob 的文件和目录。如果标志没有定义或者没有补全提示，则返回 nil。
+func (f *FlagSet) CompleteValue(name, prefix string) []string {
+	flag := f.lookupName(name)
+	if flag == nil || flag.Completion == nil {
+		return nil
+	}
+	c := flag.Completion
+	var values []string
+	for _, choice := range c.Choices {
+		if strings.HasPrefix(choice, prefix) {
+			values = append(values, choice)
+		}
+	}
+	if c.Func != nil {
+		values = append(values, c.Func(prefix)...)
+	}
+	if c.Glob != "" {
+		values = append(values, globFiles(prefix, c.Glob)...)
+	}
+	return values
+}
+
+// CompleteValue returns the candidate values of the named command-line flag
+// that start with prefix.
+//
+// CompleteValue 返回名为 name 的命令行标志以 prefix 开头的候选值。
+func CompleteValue(name, prefix string) []string {
+	return CommandLine.CompleteValue(name, prefix)
+}
+
+// globFiles returns the directories and the files matching pattern whose
+// paths start with prefix. Directories end with a separator.
+//
+// globFiles 返回路径以 prefix 开头的目录和匹配 pattern 的文件。目录以分隔符结尾。
+func globFiles(prefix, pattern string) []string {
+	// prefix 中的通配符需要转义，Glob 才会将它们当作普通字符
+	matches, _ := filepath.Glob(escapeGlob(prefix) + "*") // escape prefix so Glob reads it literally
+	var files []string
+	for _, m := range matches {
+		fi, err := os.Stat(m)
+		if err != nil {
+			continue
+		}
+		if fi.IsDir() {
+			files = append(files, m+string(filepath.Separator))
+		} else if ok, _ := filepath.Match(pattern, filepath.Base(m)); ok {
+			files = append(files, m)
+		}
+	}
+	return files
+}
+
+// escapeGlob escapes the characters of s that filepath.Match treats
+// specially.
+//
+// escapeGlob 转义 s 中被 filepath.Match 特殊对待的字符。
+//
+// NOTE: Windows 上反斜杠是路径分隔符，filepath.Match 不支持转义，所以只能原样返回。
+func escapeGlob(s string) string {
+	if filepath.Separator == '\\' {
+		return s
+	}
+	var b strings.Builder
+	for _, r := range s {
+		switch r {
+		case '*', '?', '[', '\\':
+			b.WriteByte('\\')
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
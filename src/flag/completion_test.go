@@ -0,0 +1,82 @@
+// Copyright 2018 Li Zebang. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package flag_test
+
+import (
+	"bytes"
+	. "flag"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestCompleteValue(t *testing.T) {
+	dir, err := ioutil.TempDir("", "flagcomplete")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	for _, name := range []string{"a.json", "b.json", "c.txt", "sub/d.json"} {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0777); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(path, nil, 0666); err != nil {
+			t.Fatal(err)
+		}
+	}
+	sep := string(filepath.Separator)
+
+	fs := NewFlagSet("complete", ContinueOnError)
+	fs.String("format", "text", "output format")
+	fs.String("config", "", "config file")
+	fs.String("region", "", "region")
+	fs.String("plain", "", "no hints")
+	fs.Alias("fmt", "format")
+	fs.SetCompletion("fmt", Completion{Choices: []string{"text", "json", "table"}})
+	fs.SetCompletion("config", Completion{Glob: "*.json"})
+	fs.SetCompletion("region", Completion{
+		Choices: []string{"local"},
+		Func: func(prefix string) []string {
+			return []string{prefix + "-east-1", prefix + "-west-2"}
+		},
+	})
+	tests := []struct {
+		name, prefix string
+		want         []string
+	}{
+		{"format", "t", []string{"text", "table"}},
+		{"format", "", []string{"text", "json", "table"}},
+		{"format", "x", nil},
+		{"config", dir + sep, []string{dir + sep + "a.json", dir + sep + "b.json", dir + sep + "sub" + sep}},
+		{"config", dir + sep + "s", []string{dir + sep + "sub" + sep}},
+		{"region", "us", []string{"us-east-1", "us-west-2"}},
+		{"region", "l", []string{"local", "l-east-1", "l-west-2"}},
+		{"plain", "", nil},
+		{"missing", "", nil},
+	}
+	for _, tt := range tests {
+		got := fs.CompleteValue(tt.name, tt.prefix)
+		if strings.Join(got, "|") != strings.Join(tt.want, "|") {
+			t.Errorf("CompleteValue(%q, %q) = %q; want %q", tt.name, tt.prefix, got, tt.want)
+		}
+	}
+	if c := fs.Lookup("format").Completion; c == nil || len(c.Choices) != 3 {
+		t.Errorf("Completion = %+v", c)
+	}
+}
+
+func TestSetCompletionPanics(t *testing.T) {
+	fs := NewFlagSet("complete", ContinueOnError)
+	fs.SetOutput(new(bytes.Buffer))
+	defer func() {
+		if r := recover(); r != "complete flag provided to SetCompletion but not defined: -x" {
+			t.Errorf("panic = %v", r)
+		}
+	}()
+	fs.SetCompletion("x", Completion{})
+}
@@ -0,0 +1,124 @@
+// Copyright 2018 Li Zebang. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package flag
+
+import (
+	"io"
+	"os"
+	"sort"
+	"strconv"
+)
+
+// A ConfigDecoder reads a config file into the values it gives to flags,
+// keyed by flag name. The Set method of a flag is called once for each of
+// its values, in order. Package flag/flagjson provides one for JSON.
+//
+// ConfigDecoder 将一个配置文件读取为它给出的标志的值，以标志名称为键。每个标志的 Set 方法会
+// 按照顺序对它的每一个值调用一次。flag/flagjson 包为 JSON 提供了一个 ConfigDecoder。
+type ConfigDecoder func(r io.Reader) (map[string][]string, error)
+
+// ParseConfig sets flags from a config file that decode reads from r.
+// format names the kind of file in errors, as in "invalid JSON config" or
+// "flag provided in JSON config but not defined".
+//
+// Flags that are already set are skipped, so called after Parse the command
+// line and the environment take precedence over the file. Called before
+// Parse, the command line still overrides the file, which in turn takes
+// precedence over the environment. A key that names no defined flag is an
+// error. Errors are handled according to the error handling of f, as in
+// Parse.
+//
+// ParseConfig 根据 decode 从 r 中读取的配置文件设置标志。format 在错误信息中指代文件的类型，
+// 例如 "invalid JSON config" 或者 "flag provided in JSON config but not defined"。
+//
+// 已经被设置的标志会被跳过，所以在 Parse 之后调用时，命令行和环境变量优先于文件。在 Parse
+// 之前调用时，命令行仍然会覆盖文件，而文件优先于环境变量。不对应任何已定义标志的键是一个
+// 错误。和 Parse 一样，错误按照 f 的错误处理方式处理。
+//
+// IMP: testing 导入了 flag，所以 flag 自己不解析 JSON，encoding/json 留给 flag/flagjson。
+func (f *FlagSet) ParseConfig(r io.Reader, format string, decode ConfigDecoder) error {
+	values, err := decode(r)
+	if err != nil {
+		return f.handleError(f.failw(err, "invalid %s config: %v", format, err))
+	}
+	return f.handleError(f.setConfig(values, format+" config"))
+}
+
+// ParseConfig sets the command-line flags from a config file that decode
+// reads from r.
+//
+// ParseConfig 根据 decode 从 r 中读取的配置文件设置命令行标志。
+func ParseConfig(r io.Reader, format string, decode ConfigDecoder) error {
+	return CommandLine.ParseConfig(r, format, decode)
+}
+
+// A lineError is a syntax error on a line of a config file.
+//
+// lineError 是配置文件中某一行的语法错误。
+type lineError struct {
+	line int
+	msg  string
+}
+
+func (e *lineError) Error() string {
+	return "line " + strconv.Itoa(e.line) + ": " + e.msg
+}
+
+// setConfig sets the flags named in values that are not set yet, in the
+// order of their names, calling Set once for each of their values.
+// source names the config in errors and traces.
+//
+// setConfig 按照名称的顺序设置 values 中还没有被设置的标志，对它们的每一个值调用一次 Set。
+// source 在错误和跟踪信息中用来指代配置。
+func (f *FlagSet) setConfig(values map[string][]string, source string) error {
+	names := make([]string, 0, len(values))
+	for name := range values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		flag := f.lookupName(name)
+		if flag == nil || f.gatedOut(flag) {
+			return f.fail(&ErrUndefinedFlag{Name: name, Source: source})
+		}
+		if f.actual[flag.Name] != nil {
+			continue
+		}
+		for _, value := range values[name] {
+			if err := flag.Value.Set(value); err != nil {
+				return f.fail(&ErrInvalidValue{Name: name, Value: value, Source: source, Err: err})
+			}
+		}
+		// 在所有值都设置成功之后才报告
+		for _, value := range values[name] { // report only once all the values are set
+			f.report(flag, value)
+		}
+		if f.actual == nil {
+			f.actual = make(map[string]*Flag, len(f.formal))
+		}
+		f.actual[flag.Name] = flag
+		f.trace(flag, source)
+	}
+	return nil
+}
+
+// handleError applies the error handling of f to err, as Parse does.
+//
+// handleError 像 Parse 一样对 err 应用 f 的错误处理方式。
+func (f *FlagSet) handleError(err error) error {
+	if err == nil {
+		return nil
+	}
+	switch f.errorHandling {
+	case ExitOnError:
+		os.Exit(2)
+	case PanicOnError:
+		panic(err)
+	case WarnOnError:
+		// 错误已经被打印
+		return nil // the error has been printed
+	}
+	return err
+}
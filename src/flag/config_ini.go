@@ -0,0 +1,129 @@
+// Copyright 2018 Li Zebang. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package flag
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strconv"
+	"strings"
+)
+
+// ParseConfigINI sets flags from an INI file, like ParseConfig. Each
+// line is a key and a value separated by = or :, and a [section] header
+// names the flags that follow it with the section and a dot, so port = 80
+// under [server] sets -server.port. A key that appears more than once
+// calls Set once for each value, like a flag repeated on the command line.
+//
+// Lines starting with ; or # are comments, and so is the rest of a line
+// after a ; or # that follows a space in an unquoted value. A value may be
+// quoted with double quotes, which interpret Go escape sequences, or with
+// single quotes, which do not.
+//
+// ParseConfigINI 和 ParseConfig 一样，根据一个 INI 文件设置标志。每一行是由 = 或 :
+// 分隔的键和值，[section] 节头用节名和一个点来命名它后面的标志，所以 [server] 下面的
+// port = 80 设置的是 -server.port。出现多次的键会对每个值调用一次 Set，就像在命令行中
+// 重复给出的标志一样。
+//
+// 以 ; 或 # 开头的行是注释，没有被引号括起来的值中跟在空格后面的 ; 或 # 之后的部分也是注释。
+// 值可以用双引号括起来，其中的 Go 转义序列会被解释，也可以用单引号括起来，其中的内容保持原样。
+func (f *FlagSet) ParseConfigINI(r io.Reader) error {
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return f.handleError(f.failw(err, "invalid INI config: %v", err))
+	}
+	values, err := parseINI(string(data))
+	if err != nil {
+		return f.handleError(f.failw(err, "invalid INI config: %v", err))
+	}
+	return f.handleError(f.setConfig(values, "INI config"))
+}
+
+// ParseConfigINI sets the command-line flags from an INI file.
+//
+// ParseConfigINI 根据一个 INI 文件设置命令行标志。
+func ParseConfigINI(r io.Reader) error {
+	return CommandLine.ParseConfigINI(r)
+}
+
+// parseINI returns the values of an INI file keyed by flag name.
+//
+// parseINI 返回 INI 文件中以标志名称为键的值。
+func parseINI(doc string) (map[string][]string, error) {
+	values := make(map[string][]string)
+	section := ""
+	for i, line := range strings.Split(doc, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || line[0] == ';' || line[0] == '#' {
+			continue
+		}
+		if line[0] == '[' {
+			if !strings.HasSuffix(line, "]") {
+				return nil, &lineError{i + 1, "unterminated section header"}
+			}
+			section = strings.TrimSpace(line[1 : len(line)-1])
+			if section == "" {
+				return nil, &lineError{i + 1, "empty section name"}
+			}
+			continue
+		}
+		sep := strings.IndexAny(line, "=:")
+		if sep < 0 {
+			return nil, &lineError{i + 1, "expected a key and a value separated by = or :"}
+		}
+		key := strings.TrimSpace(line[:sep])
+		if key == "" {
+			return nil, &lineError{i + 1, "empty key"}
+		}
+		value, err := iniValue(strings.TrimSpace(line[sep+1:]))
+		if err != nil {
+			return nil, &lineError{i + 1, err.Error()}
+		}
+		if section != "" {
+			key = section + "." + key
+		}
+		values[key] = append(values[key], value)
+	}
+	return values, nil
+}
+
+// iniValue returns the value in text, without its quotes or comment.
+//
+// iniValue 返回 text 中的值，不包括它的引号或注释。
+func iniValue(text string) (string, error) {
+	if text == "" {
+		return "", nil
+	}
+	if q := text[0]; q == '"' || q == '\'' {
+		end := 1
+		for end < len(text) && text[end] != q {
+			if q == '"' && text[end] == '\\' {
+				end++
+			}
+			end++
+		}
+		if end >= len(text) {
+			return "", fmt.Errorf("unterminated quoted value %s", text)
+		}
+		if rest := strings.TrimSpace(text[end+1:]); rest != "" && rest[0] != ';' && rest[0] != '#' {
+			return "", fmt.Errorf("unexpected text after quoted value %s", text)
+		}
+		if q == '\'' {
+			return text[1:end], nil
+		}
+		s, err := strconv.Unquote(text[:end+1])
+		if err != nil {
+			return "", fmt.Errorf("invalid double-quoted value %s", text[:end+1])
+		}
+		return s, nil
+	}
+	for i := 1; i < len(text); i++ {
+		if (text[i] == ';' || text[i] == '#') && (text[i-1] == ' ' || text[i-1] == '\t') {
+			return strings.TrimSpace(text[:i]), nil
+		}
+	}
+	return text, nil
+}
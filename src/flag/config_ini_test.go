@@ -0,0 +1,81 @@
+// Copyright 2018 Li Zebang. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package flag_test
+
+import (
+	. "flag"
+	"fmt"
+	"io/ioutil"
+	"strings"
+	"testing"
+)
+
+func TestParseConfigINI(t *testing.T) {
+	fs := NewFlagSet("config", ContinueOnError)
+	v := fs.Bool("v", false, "verbose")
+	name := fs.String("name", "", "name")
+	port := fs.Int("server.port", 0, "port")
+	host := fs.String("server.host", "", "host")
+	quoted := fs.String("server.quoted", "", "quoted")
+	raw := fs.String("server.raw", "", "raw")
+	var tags listValue
+	fs.Var(&tags, "server.tag", "tags")
+
+	const doc = `; top-level flags
+v = true
+name: from-config
+
+[server]
+port = 8080 ; the port
+host = example.com#not-a-comment
+quoted = "a ; b\t"
+raw = '\t' # a comment
+tag = a
+tag = b
+`
+	if err := fs.Parse([]string{"-name", "from-flag"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := fs.ParseConfigINI(strings.NewReader(doc)); err != nil {
+		t.Fatal(err)
+	}
+	if !*v || *port != 8080 || *host != "example.com#not-a-comment" {
+		t.Errorf("v=%v server.port=%d server.host=%q", *v, *port, *host)
+	}
+	if *name != "from-flag" {
+		t.Errorf("name = %q; the command line should win", *name)
+	}
+	if *quoted != "a ; b\t" || *raw != `\t` {
+		t.Errorf("server.quoted = %q, server.raw = %q", *quoted, *raw)
+	}
+	if fmt.Sprint(tags) != "[a b]" {
+		t.Errorf("server.tag = %v; want [a b]", tags)
+	}
+}
+
+func TestParseConfigINIErrors(t *testing.T) {
+	tests := []struct {
+		doc  string
+		want string
+	}{
+		{"n = x\n", `invalid value "x" in INI config for flag -n`},
+		{"[s]\nn = 1\n", `flag provided in INI config but not defined: -s.n`},
+		{"[s\n", `invalid INI config: line 1: unterminated section header`},
+		{"\n[ ]\n", `invalid INI config: line 2: empty section name`},
+		{"n\n", `invalid INI config: line 1: expected a key and a value separated by = or :`},
+		{"= 1\n", `invalid INI config: line 1: empty key`},
+		{"n = \"1\n", `invalid INI config: line 1: unterminated quoted value "1`},
+		{"n = '1' 2\n", `invalid INI config: line 1: unexpected text after quoted value '1' 2`},
+	}
+	for _, tt := range tests {
+		fs := NewFlagSet("config", ContinueOnError)
+		fs.SetOutput(ioutil.Discard)
+		fs.Int("n", 0, "count")
+		err := fs.ParseConfigINI(strings.NewReader(tt.doc))
+		if err == nil || !strings.HasPrefix(err.Error(), tt.want) {
+			t.Errorf("ParseConfigINI(%q) = %v; want prefix %q", tt.doc, err, tt.want)
+		}
+	}
+}
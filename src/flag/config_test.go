@@ -0,0 +1,116 @@
+// Copyright 2018 Li Zebang. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package flag_test
+
+import (
+	"errors"
+	. "flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// listValue collects every value it is set to.
+type listValue []string
+
+func (l *listValue) String() string     { return fmt.Sprint(*l) }
+func (l *listValue) Set(s string) error { *l = append(*l, s); return nil }
+
+// decodeMap returns a ConfigDecoder that ignores its input and returns
+// values, or err if it is not nil.
+func decodeMap(values map[string][]string, err error) ConfigDecoder {
+	return func(io.Reader) (map[string][]string, error) { return values, err }
+}
+
+func TestParseConfig(t *testing.T) {
+	fs := NewFlagSet("config", ContinueOnError)
+	v := fs.Bool("v", false, "verbose")
+	n := fs.Int("n", 0, "count")
+	name := fs.String("name", "", "name")
+	var tags listValue
+	fs.Var(&tags, "tag", "tags")
+
+	if err := fs.Parse([]string{"-name", "from-flag"}); err != nil {
+		t.Fatal(err)
+	}
+	values := map[string][]string{
+		"v":    {"true"},
+		"n":    {"7"},
+		"name": {"from-config"},
+		"tag":  {"a", "b"},
+	}
+	if err := fs.ParseConfig(nil, "test", decodeMap(values, nil)); err != nil {
+		t.Fatal(err)
+	}
+	if !*v || *n != 7 {
+		t.Errorf("got v=%v n=%d", *v, *n)
+	}
+	if *name != "from-flag" {
+		t.Errorf("name = %q; the command line should win", *name)
+	}
+	if fmt.Sprint(tags) != "[a b]" {
+		t.Errorf("tag = %v; want [a b]", tags)
+	}
+}
+
+func TestParseConfigBeforeParse(t *testing.T) {
+	fs := NewFlagSet("config", ContinueOnError)
+	name := fs.String("name", "", "name")
+	n := fs.Int("n", 0, "count")
+	values := map[string][]string{"name": {"from-config"}, "n": {"1"}}
+	if err := fs.ParseConfig(nil, "test", decodeMap(values, nil)); err != nil {
+		t.Fatal(err)
+	}
+	if err := fs.Parse([]string{"-n", "2"}); err != nil {
+		t.Fatal(err)
+	}
+	if *name != "from-config" || *n != 2 {
+		t.Errorf("name = %q, n = %d; want from-config, 2", *name, *n)
+	}
+}
+
+func TestParseConfigErrors(t *testing.T) {
+	tests := []struct {
+		values map[string][]string
+		err    error
+		want   string
+	}{
+		{map[string][]string{"n": {"x"}}, nil, `invalid value "x" in test config for flag -n: strconv.ParseInt`},
+		{map[string][]string{"missing": {"1"}}, nil, `flag provided in test config but not defined: -missing`},
+		{nil, errors.New("bad line"), `invalid test config: bad line`},
+	}
+	for _, tt := range tests {
+		fs := NewFlagSet("config", ContinueOnError)
+		fs.SetOutput(ioutil.Discard)
+		fs.Int("n", 0, "count")
+		err := fs.ParseConfig(nil, "test", decodeMap(tt.values, tt.err))
+		if err == nil || !strings.HasPrefix(err.Error(), tt.want) {
+			t.Errorf("ParseConfig(%v, %v) = %v; want prefix %q", tt.values, tt.err, err, tt.want)
+		}
+	}
+
+	fs := NewFlagSet("config", ContinueOnError)
+	fs.SetOutput(ioutil.Discard)
+	fs.Int("n", 0, "count")
+	err := fs.ParseConfig(nil, "test", decodeMap(map[string][]string{"n": {"x"}}, nil))
+	var numErr *strconv.NumError
+	if !errors.As(err, &numErr) {
+		t.Errorf("ParseConfig error %v does not wrap the *strconv.NumError from Set", err)
+	}
+}
+
+func TestParseConfigPanicOnError(t *testing.T) {
+	fs := NewFlagSet("config", PanicOnError)
+	fs.SetOutput(ioutil.Discard)
+	defer func() {
+		if recover() == nil {
+			t.Error("ParseConfig did not panic with PanicOnError")
+		}
+	}()
+	fs.ParseConfig(nil, "test", decodeMap(map[string][]string{"missing": {"1"}}, nil))
+}
@@ -0,0 +1,347 @@
+// Copyright 2018 Li Zebang. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package flag
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strconv"
+	"strings"
+)
+
+// ParseConfigTOML sets flags from a TOML document, like ParseConfig.
+// Tables and dotted keys name flags with their keys joined by dots, so
+// port = 80 under [server] and server.port = 80 both set -server.port.
+// Strings are passed to Set without their quotes, other values as they are
+// written, with the underscores of numbers removed. An array calls Set once
+// for each of its items.
+//
+// Only the part of TOML that describes flags is supported: arrays of
+// tables, inline tables, nested arrays and multi-line strings are rejected
+// with an error.
+//
+// ParseConfigTOML 和 ParseConfig 一样，根据一个 TOML 文档设置标志。表和带点的键用
+// 以点连接起来的键来命名标志，所以 [server] 下面的 port = 80 和 server.port = 80 设置的
+// 都是 -server.port。字符串去掉引号之后传给 Set，其他的值按照写出来的样子传给 Set，但数字中
+// 的下划线会被去掉。数组会对它的每一项调用一次 Set。
+//
+// 只支持 TOML 中用来描述标志的部分：表数组、内联表、嵌套的数组和多行字符串都会被当作错误
+// 拒绝。
+func (f *FlagSet) ParseConfigTOML(r io.Reader) error {
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return f.handleError(f.failw(err, "invalid TOML config: %v", err))
+	}
+	values, err := parseTOML(string(data))
+	if err != nil {
+		return f.handleError(f.failw(err, "invalid TOML config: %v", err))
+	}
+	return f.handleError(f.setConfig(values, "TOML config"))
+}
+
+// ParseConfigTOML sets the command-line flags from a TOML document.
+//
+// ParseConfigTOML 根据一个 TOML 文档设置命令行标志。
+func ParseConfigTOML(r io.Reader) error {
+	return CommandLine.ParseConfigTOML(r)
+}
+
+// tomlScanner reads the keys and values of a TOML document.
+//
+// tomlScanner 读取 TOML 文档中的键和值。
+type tomlScanner struct {
+	s    string
+	i    int
+	line int
+}
+
+func (sc *tomlScanner) errorf(format string, a ...interface{}) error {
+	return &lineError{sc.line, fmt.Sprintf(format, a...)}
+}
+
+func (sc *tomlScanner) eof() bool { return sc.i >= len(sc.s) }
+
+func (sc *tomlScanner) peek() byte { return sc.s[sc.i] }
+
+// skipSpace skips spaces and tabs.
+//
+// skipSpace 跳过空格和制表符。
+func (sc *tomlScanner) skipSpace() {
+	for !sc.eof() && (sc.peek() == ' ' || sc.peek() == '\t') {
+		sc.i++
+	}
+}
+
+// skipBlank skips spaces, tabs, newlines and comments.
+//
+// skipBlank 跳过空格、制表符、换行符和注释。
+func (sc *tomlScanner) skipBlank() {
+	for !sc.eof() {
+		switch sc.peek() {
+		case ' ', '\t', '\r':
+			sc.i++
+		case '\n':
+			sc.i++
+			sc.line++
+		case '#':
+			for !sc.eof() && sc.peek() != '\n' {
+				sc.i++
+			}
+		default:
+			return
+		}
+	}
+}
+
+// endLine checks that only a comment follows on the current line, and
+// moves to the next one.
+//
+// endLine 检查当前行的剩余部分只有注释，然后移到下一行。
+func (sc *tomlScanner) endLine() error {
+	sc.skipSpace()
+	if !sc.eof() && sc.peek() == '#' {
+		for !sc.eof() && sc.peek() != '\n' {
+			sc.i++
+		}
+	}
+	if !sc.eof() && sc.peek() == '\r' {
+		sc.i++
+	}
+	if sc.eof() {
+		return nil
+	}
+	if sc.peek() != '\n' {
+		return sc.errorf("unexpected text after value")
+	}
+	sc.i++
+	sc.line++
+	return nil
+}
+
+// isBareKeyChar reports whether c may appear in a bare key.
+//
+// isBareKeyChar 报告 c 是否可以出现在不带引号的键中。
+func isBareKeyChar(c byte) bool {
+	return 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || '0' <= c && c <= '9' || c == '_' || c == '-'
+}
+
+// key reads a dotted key and returns it joined by dots.
+//
+// key 读取一个带点的键，返回用点连接起来的键。
+func (sc *tomlScanner) key() (string, error) {
+	var parts []string
+	for {
+		sc.skipSpace()
+		if sc.eof() {
+			return "", sc.errorf("expected a key")
+		}
+		var part string
+		switch c := sc.peek(); {
+		case c == '"' || c == '\'':
+			s, err := sc.str()
+			if err != nil {
+				return "", err
+			}
+			part = s
+		case isBareKeyChar(c):
+			start := sc.i
+			for !sc.eof() && isBareKeyChar(sc.peek()) {
+				sc.i++
+			}
+			part = sc.s[start:sc.i]
+		default:
+			return "", sc.errorf("invalid character %q in key", c)
+		}
+		parts = append(parts, part)
+		sc.skipSpace()
+		if sc.eof() || sc.peek() != '.' {
+			return strings.Join(parts, "."), nil
+		}
+		sc.i++
+	}
+}
+
+// str reads a basic or literal string.
+//
+// str 读取一个基本字符串或者字面量字符串。
+func (sc *tomlScanner) str() (string, error) {
+	q := sc.peek()
+	if strings.HasPrefix(sc.s[sc.i:], strings.Repeat(string(q), 3)) {
+		return "", sc.errorf("multi-line strings are not supported")
+	}
+	start := sc.i
+	sc.i++
+	for !sc.eof() && sc.peek() != q {
+		if sc.peek() == '\n' {
+			return "", sc.errorf("unterminated string")
+		}
+		if q == '"' && sc.peek() == '\\' {
+			sc.i++
+		}
+		sc.i++
+	}
+	if sc.eof() {
+		return "", sc.errorf("unterminated string")
+	}
+	sc.i++
+	lit := sc.s[start:sc.i]
+	if q == '\'' {
+		return lit[1 : len(lit)-1], nil
+	}
+	s, err := strconv.Unquote(lit)
+	if err != nil {
+		return "", sc.errorf("invalid string %s", lit)
+	}
+	return s, nil
+}
+
+// scalar reads a string, number, boolean or date-time.
+//
+// scalar 读取一个字符串、数字、布尔值或者日期时间。
+func (sc *tomlScanner) scalar() (string, error) {
+	if sc.eof() {
+		return "", sc.errorf("expected a value")
+	}
+	switch sc.peek() {
+	case '"', '\'':
+		return sc.str()
+	case '[':
+		return "", sc.errorf("nested arrays are not supported")
+	case '{':
+		return "", sc.errorf("inline tables are not supported")
+	}
+	start := sc.i
+	for !sc.eof() && strings.IndexByte(" \t\r\n,]#", sc.peek()) < 0 {
+		sc.i++
+	}
+	// A date and a time may be separated by a space.
+	//
+	// 日期和时间之间可以用空格分隔。
+	if v := sc.s[start:sc.i]; len(v) == 10 && v[4] == '-' && v[7] == '-' &&
+		sc.i+1 < len(sc.s) && sc.s[sc.i] == ' ' && '0' <= sc.s[sc.i+1] && sc.s[sc.i+1] <= '9' {
+		sc.i++
+		for !sc.eof() && strings.IndexByte(" \t\r\n,]#", sc.peek()) < 0 {
+			sc.i++
+		}
+	}
+	v := sc.s[start:sc.i]
+	switch {
+	case v == "":
+		return "", sc.errorf("expected a value")
+	case v == "true" || v == "false" || v == "inf" || v == "+inf" || v == "-inf" ||
+		v == "nan" || v == "+nan" || v == "-nan":
+		return v, nil
+	case strings.IndexByte("0123456789+-", v[0]) < 0:
+		return "", sc.errorf("invalid value %q; strings must be quoted", v)
+	case strings.Contains(v, "_") && !strings.ContainsAny(v, ":T"):
+		return strings.Replace(v, "_", "", -1), nil
+	}
+	return v, nil
+}
+
+// value reads the value of a key, which is a scalar or an array of
+// scalars that may span several lines.
+//
+// value 读取一个键的值，它是一个标量或者由标量组成的数组，数组可以跨越多行。
+func (sc *tomlScanner) value() ([]string, error) {
+	sc.skipSpace()
+	if sc.eof() || sc.peek() != '[' {
+		s, err := sc.scalar()
+		if err != nil {
+			return nil, err
+		}
+		return []string{s}, nil
+	}
+	sc.i++
+	var items []string
+	for {
+		sc.skipBlank()
+		if sc.eof() {
+			return nil, sc.errorf("unterminated array")
+		}
+		if sc.peek() == ']' {
+			sc.i++
+			return items, nil
+		}
+		s, err := sc.scalar()
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, s)
+		sc.skipBlank()
+		if sc.eof() {
+			return nil, sc.errorf("unterminated array")
+		}
+		switch sc.peek() {
+		case ',':
+			sc.i++
+		case ']':
+		default:
+			return nil, sc.errorf("expected a comma or ] in array")
+		}
+	}
+}
+
+// parseTOML returns the values of a TOML document keyed by flag name.
+//
+// parseTOML 返回 TOML 文档中以标志名称为键的值。
+func parseTOML(doc string) (map[string][]string, error) {
+	values := make(map[string][]string)
+	seen := make(map[string]bool)
+	sc := &tomlScanner{s: doc, line: 1}
+	table := ""
+	for {
+		sc.skipBlank()
+		if sc.eof() {
+			return values, nil
+		}
+		if sc.peek() == '[' {
+			sc.i++
+			if !sc.eof() && sc.peek() == '[' {
+				return nil, sc.errorf("arrays of tables are not supported")
+			}
+			name, err := sc.key()
+			if err != nil {
+				return nil, err
+			}
+			if sc.eof() || sc.peek() != ']' {
+				return nil, sc.errorf("expected ] after table name")
+			}
+			sc.i++
+			if err := sc.endLine(); err != nil {
+				return nil, err
+			}
+			table = name
+			continue
+		}
+		line := sc.line
+		name, err := sc.key()
+		if err != nil {
+			return nil, err
+		}
+		if sc.eof() || sc.peek() != '=' {
+			return nil, sc.errorf("expected = after key")
+		}
+		sc.i++
+		if table != "" {
+			name = table + "." + name
+		}
+		if seen[name] {
+			return nil, &lineError{line, fmt.Sprintf("duplicate key %q", name)}
+		}
+		seen[name] = true
+		items, err := sc.value()
+		if err != nil {
+			return nil, err
+		}
+		if len(items) > 0 {
+			values[name] = items
+		}
+		if err := sc.endLine(); err != nil {
+			return nil, err
+		}
+	}
+}
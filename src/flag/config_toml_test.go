@@ -0,0 +1,91 @@
+// Copyright 2018 Li Zebang. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package flag_test
+
+import (
+	. "flag"
+	"fmt"
+	"io/ioutil"
+	"strings"
+	"testing"
+)
+
+func TestParseConfigTOML(t *testing.T) {
+	fs := NewFlagSet("config", ContinueOnError)
+	v := fs.Bool("v", false, "verbose")
+	name := fs.String("name", "", "name")
+	n := fs.Int("n", 0, "count")
+	port := fs.Int("server.port", 0, "port")
+	host := fs.String("server.host", "", "host")
+	level := fs.String("server.log.level", "", "level")
+	when := fs.String("when", "", "date-time")
+	raw := fs.String("raw", "", "literal string")
+	var tags listValue
+	fs.Var(&tags, "server.tags", "tags")
+
+	const doc = `# top-level flags
+v = true
+name = "from-config"
+n = 1_000
+when = 1979-05-27 07:32:00Z
+raw = 'C:\path'
+
+[server]
+port = 8080 # the port
+"host" = "example.com"
+log.level = "debug"
+tags = [
+	"a", # first
+	"b",
+]
+`
+	if err := fs.Parse([]string{"-name", "from-flag"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := fs.ParseConfigTOML(strings.NewReader(doc)); err != nil {
+		t.Fatal(err)
+	}
+	if !*v || *n != 1000 || *port != 8080 || *host != "example.com" || *level != "debug" {
+		t.Errorf("v=%v n=%d server.port=%d server.host=%q server.log.level=%q", *v, *n, *port, *host, *level)
+	}
+	if *name != "from-flag" {
+		t.Errorf("name = %q; the command line should win", *name)
+	}
+	if *when != "1979-05-27 07:32:00Z" || *raw != `C:\path` {
+		t.Errorf("when = %q, raw = %q", *when, *raw)
+	}
+	if fmt.Sprint(tags) != "[a b]" {
+		t.Errorf("server.tags = %v; want [a b]", tags)
+	}
+}
+
+func TestParseConfigTOMLErrors(t *testing.T) {
+	tests := []struct {
+		doc  string
+		want string
+	}{
+		{"n = \"x\"\n", `invalid value "x" in TOML config for flag -n`},
+		{"[s]\nn = 1\n", `flag provided in TOML config but not defined: -s.n`},
+		{"n = 1\nn = 2\n", `invalid TOML config: line 2: duplicate key "n"`},
+		{"n = x\n", `invalid TOML config: line 1: invalid value "x"; strings must be quoted`},
+		{"[[n]]\n", `invalid TOML config: line 1: arrays of tables are not supported`},
+		{"n = {a = 1}\n", `invalid TOML config: line 1: inline tables are not supported`},
+		{"n = [[1]]\n", `invalid TOML config: line 1: nested arrays are not supported`},
+		{"n = \"\"\"1\"\"\"\n", `invalid TOML config: line 1: multi-line strings are not supported`},
+		{"n = [1,\n2\n", `invalid TOML config: line 3: unterminated array`},
+		{"n = 1 2\n", `invalid TOML config: line 1: unexpected text after value`},
+		{"\nn 1\n", `invalid TOML config: line 2: expected = after key`},
+		{"[n\n", `invalid TOML config: line 1: expected ] after table name`},
+	}
+	for _, tt := range tests {
+		fs := NewFlagSet("config", ContinueOnError)
+		fs.SetOutput(ioutil.Discard)
+		fs.Int("n", 0, "count")
+		err := fs.ParseConfigTOML(strings.NewReader(tt.doc))
+		if err == nil || !strings.HasPrefix(err.Error(), tt.want) {
+			t.Errorf("ParseConfigTOML(%q) = %v; want prefix %q", tt.doc, err, tt.want)
+		}
+	}
+}
@@ -0,0 +1,410 @@
+// Copyright 2018 Li Zebang. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package flag
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strconv"
+	"strings"
+)
+
+// ParseConfigYAML sets flags from a YAML document, like ParseConfig.
+// Nested mappings name the flags under them with their keys joined by dots,
+// so
+//
+//	server:
+//	  port: 8080
+//
+// sets -server.port to 8080. A sequence, in block or flow style, calls Set
+// once for each of its items. A null (~, null or no value) leaves the flag
+// alone.
+//
+// Only the part of YAML that describes flags is supported: block mappings,
+// sequences of scalars, and plain, single-quoted or double-quoted scalars.
+// Block scalars (| and >), flow mappings, anchors, aliases, tags and
+// multiple documents are rejected with an error.
+//
+// ParseConfigYAML 和 ParseConfig 一样，根据一个 YAML 文档设置标志。嵌套的映射用以点
+// 连接起来的键来命名其中的标志，所以上面的例子将 -server.port 设置为 8080。序列，无论是
+// 块风格还是流风格，都会对它的每一项调用一次 Set。null（~、null 或者没有值）不会改变标志。
+//
+// 只支持 YAML 中用来描述标志的部分：块映射、由标量组成的序列，以及普通的、单引号或双引号
+// 括起来的标量。块标量（| 和 >）、流映射、锚点、别名、标签以及多个文档都会被当作错误拒绝。
+func (f *FlagSet) ParseConfigYAML(r io.Reader) error {
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return f.handleError(f.failw(err, "invalid YAML config: %v", err))
+	}
+	values, err := parseYAML(string(data))
+	if err != nil {
+		return f.handleError(f.failw(err, "invalid YAML config: %v", err))
+	}
+	return f.handleError(f.setConfig(values, "YAML config"))
+}
+
+// ParseConfigYAML sets the command-line flags from a YAML document.
+//
+// ParseConfigYAML 根据一个 YAML 文档设置命令行标志。
+func ParseConfigYAML(r io.Reader) error {
+	return CommandLine.ParseConfigYAML(r)
+}
+
+// A yamlLine is a line of a YAML document without its indentation and
+// comment.
+//
+// yamlLine 是 YAML 文档中去掉了缩进和注释的一行。
+type yamlLine struct {
+	num    int
+	indent int
+	text   string
+}
+
+// yamlParser parses the block structure of a YAML document line by line.
+//
+// yamlParser 逐行解析 YAML 文档的块结构。
+type yamlParser struct {
+	lines  []yamlLine
+	i      int
+	values map[string][]string
+	seen   map[string]bool
+}
+
+// parseYAML returns the values of a YAML document keyed by flag name.
+//
+// parseYAML 返回 YAML 文档中以标志名称为键的值。
+func parseYAML(doc string) (map[string][]string, error) {
+	p := &yamlParser{
+		values: make(map[string][]string),
+		seen:   make(map[string]bool),
+	}
+	for i, line := range strings.Split(doc, "\n") {
+		line = strings.TrimRight(stripYAMLComment(line), " \t\r")
+		text := strings.TrimLeft(line, " ")
+		if text == "" {
+			continue
+		}
+		if text[0] == '\t' {
+			return nil, &lineError{i + 1, "tabs are not allowed in indentation"}
+		}
+		if text == "---" || strings.HasPrefix(text, "--- ") || text == "..." {
+			// Directives end and document end markers.
+			//
+			// 指令结束和文档结束标记。
+			if len(p.lines) > 0 || text == "..." {
+				return nil, &lineError{i + 1, "multiple documents are not supported"}
+			}
+			if text = strings.TrimSpace(text[3:]); text == "" {
+				continue
+			}
+		}
+		if strings.HasPrefix(text, "%") {
+			return nil, &lineError{i + 1, "directives are not supported"}
+		}
+		p.lines = append(p.lines, yamlLine{num: i + 1, indent: len(line) - len(text), text: text})
+	}
+	if len(p.lines) == 0 {
+		return p.values, nil
+	}
+	if err := p.mapping(p.lines[0].indent, ""); err != nil {
+		return nil, err
+	}
+	if p.i < len(p.lines) {
+		l := p.lines[p.i]
+		return nil, &lineError{l.num, "unexpected indentation"}
+	}
+	return p.values, nil
+}
+
+// mapping parses the entries of a block mapping at indent.
+//
+// mapping 解析缩进为 indent 的块映射中的各项。
+func (p *yamlParser) mapping(indent int, prefix string) error {
+	for p.i < len(p.lines) {
+		l := p.lines[p.i]
+		if l.indent < indent {
+			return nil
+		}
+		if l.indent > indent {
+			return &lineError{l.num, "unexpected indentation"}
+		}
+		if isYAMLItem(l.text) {
+			return &lineError{l.num, "sequence item where a mapping key was expected"}
+		}
+		key, rest, err := splitYAMLKey(l.text)
+		if err != nil {
+			return &lineError{l.num, err.Error()}
+		}
+		name := key
+		if prefix != "" {
+			name = prefix + "." + key
+		}
+		if p.seen[name] {
+			return &lineError{l.num, fmt.Sprintf("duplicate key %q", name)}
+		}
+		p.seen[name] = true
+		p.i++
+		if rest != "" {
+			if err := p.value(name, rest); err != nil {
+				return &lineError{l.num, err.Error()}
+			}
+			continue
+		}
+		if p.i == len(p.lines) {
+			return nil
+		}
+		next := p.lines[p.i]
+		switch {
+		case isYAMLItem(next.text) && next.indent >= indent:
+			// A sequence may be indented as much as the key it belongs to.
+			//
+			// 序列的缩进可以和它所属的键相同。
+			if err := p.sequence(next.indent, name); err != nil {
+				return err
+			}
+		case next.indent > indent:
+			if err := p.mapping(next.indent, name); err != nil {
+				return err
+			}
+		}
+		// Otherwise the value is null.
+		//
+		// 否则值为 null。
+	}
+	return nil
+}
+
+// sequence parses the items of a block sequence of scalars at indent.
+//
+// sequence 解析缩进为 indent 的由标量组成的块序列中的各项。
+func (p *yamlParser) sequence(indent int, name string) error {
+	for p.i < len(p.lines) {
+		l := p.lines[p.i]
+		if l.indent < indent || l.indent == indent && !isYAMLItem(l.text) {
+			return nil
+		}
+		if l.indent > indent {
+			return &lineError{l.num, "unexpected indentation"}
+		}
+		p.i++
+		item := strings.TrimSpace(l.text[1:])
+		if item == "" || isYAMLItem(item) || item[0] == '[' || item[0] == '{' {
+			return &lineError{l.num, "sequence items must be scalars"}
+		}
+		if _, _, err := splitYAMLKey(item); err == nil && item[0] != '"' && item[0] != '\'' {
+			return &lineError{l.num, "sequence items must be scalars"}
+		}
+		s, null, err := yamlScalar(item)
+		if err != nil {
+			return &lineError{l.num, err.Error()}
+		}
+		if !null {
+			p.values[name] = append(p.values[name], s)
+		}
+	}
+	return nil
+}
+
+// value records the value that follows the key of a mapping entry.
+//
+// value 记录跟在映射项的键后面的值。
+func (p *yamlParser) value(name, text string) error {
+	switch text[0] {
+	case '[':
+		items, err := yamlFlowSequence(text)
+		if err != nil {
+			return err
+		}
+		if len(items) > 0 {
+			p.values[name] = items
+		}
+		return nil
+	case '{':
+		return fmt.Errorf("flow mappings are not supported")
+	case '|', '>':
+		return fmt.Errorf("block scalars are not supported")
+	}
+	s, null, err := yamlScalar(text)
+	if err != nil {
+		return err
+	}
+	if !null {
+		p.values[name] = []string{s}
+	}
+	return nil
+}
+
+// isYAMLItem reports whether text starts a block sequence item.
+//
+// isYAMLItem 报告 text 是否是一个块序列项的开头。
+func isYAMLItem(text string) bool {
+	return text == "-" || strings.HasPrefix(text, "- ")
+}
+
+// splitYAMLKey splits a mapping entry into its key and the text of its
+// value. The key ends at the first colon followed by a space or the end of
+// the line.
+//
+// splitYAMLKey 将一个映射项分成键和值的文本。键在第一个后面跟着空格或者位于行尾的冒号处结束。
+func splitYAMLKey(text string) (key, rest string, err error) {
+	if text[0] == '"' || text[0] == '\'' {
+		end := yamlQuoteEnd(text)
+		if end < 0 {
+			return "", "", fmt.Errorf("unterminated quoted key")
+		}
+		key, _, err = yamlScalar(text[:end])
+		if err != nil {
+			return "", "", err
+		}
+		text = strings.TrimLeft(text[end:], " ")
+		if !strings.HasPrefix(text, ":") || len(text) > 1 && text[1] != ' ' {
+			return "", "", fmt.Errorf("expected a colon after the key")
+		}
+		return key, strings.TrimSpace(text[1:]), nil
+	}
+	for i := 0; i < len(text); i++ {
+		if text[i] == ':' && (i+1 == len(text) || text[i+1] == ' ') {
+			key = strings.TrimSpace(text[:i])
+			if key == "" {
+				break
+			}
+			if strings.ContainsAny(key[:1], "&*!") {
+				return "", "", fmt.Errorf("anchors, aliases and tags are not supported")
+			}
+			return key, strings.TrimSpace(text[i+1:]), nil
+		}
+	}
+	return "", "", fmt.Errorf("expected a key followed by a colon")
+}
+
+// yamlScalar returns the value of a plain or quoted scalar, and whether it
+// is null.
+//
+// yamlScalar 返回一个普通的或者被引号括起来的标量的值，以及它是否为 null。
+func yamlScalar(text string) (s string, null bool, err error) {
+	switch text[0] {
+	case '"':
+		if yamlQuoteEnd(text) != len(text) {
+			return "", false, fmt.Errorf("invalid double-quoted scalar %s", text)
+		}
+		s, err := strconv.Unquote(text)
+		if err != nil {
+			return "", false, fmt.Errorf("invalid double-quoted scalar %s", text)
+		}
+		return s, false, nil
+	case '\'':
+		if yamlQuoteEnd(text) != len(text) {
+			return "", false, fmt.Errorf("invalid single-quoted scalar %s", text)
+		}
+		return strings.Replace(text[1:len(text)-1], "''", "'", -1), false, nil
+	case '&', '*', '!':
+		return "", false, fmt.Errorf("anchors, aliases and tags are not supported")
+	}
+	switch text {
+	case "~", "null", "Null", "NULL":
+		return "", true, nil
+	}
+	return text, false, nil
+}
+
+// yamlQuoteEnd returns the index just after the closing quote of the
+// quoted scalar at the start of text, or -1.
+//
+// yamlQuoteEnd 返回 text 开头被引号括起来的标量的结束引号之后的下标，或者 -1。
+func yamlQuoteEnd(text string) int {
+	q := text[0]
+	for i := 1; i < len(text); i++ {
+		switch {
+		case q == '"' && text[i] == '\\':
+			i++
+		case text[i] == q && q == '\'' && i+1 < len(text) && text[i+1] == '\'':
+			// '' is an escaped quote.
+			//
+			// '' 是转义的引号。
+			i++
+		case text[i] == q:
+			return i + 1
+		}
+	}
+	return -1
+}
+
+// yamlFlowSequence returns the items of a flow sequence of scalars.
+//
+// yamlFlowSequence 返回由标量组成的流序列中的各项。
+func yamlFlowSequence(text string) ([]string, error) {
+	if !strings.HasSuffix(text, "]") {
+		return nil, fmt.Errorf("unterminated flow sequence")
+	}
+	text = strings.TrimSpace(text[1 : len(text)-1])
+	var items []string
+	for text != "" {
+		var item string
+		if text[0] == '"' || text[0] == '\'' {
+			end := yamlQuoteEnd(text)
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated quoted scalar in flow sequence")
+			}
+			item, text = text[:end], strings.TrimLeft(text[end:], " ")
+			if text != "" && text[0] != ',' {
+				return nil, fmt.Errorf("expected a comma in flow sequence")
+			}
+		} else {
+			i := strings.IndexByte(text, ',')
+			if i < 0 {
+				i = len(text)
+			}
+			item, text = strings.TrimSpace(text[:i]), text[i:]
+		}
+		if item == "" {
+			return nil, fmt.Errorf("empty item in flow sequence")
+		}
+		if strings.ContainsAny(item[:1], "[{") {
+			return nil, fmt.Errorf("flow sequence items must be scalars")
+		}
+		s, null, err := yamlScalar(item)
+		if err != nil {
+			return nil, err
+		}
+		if !null {
+			items = append(items, s)
+		}
+		text = strings.TrimSpace(strings.TrimPrefix(text, ","))
+	}
+	return items, nil
+}
+
+// stripYAMLComment removes a comment from line. A comment starts with a #
+// at the start of the line or after a space, outside of quotes.
+//
+// stripYAMLComment 去掉 line 中的注释。注释以位于行首或者空格之后、并且不在引号中的 #
+// 开始。
+func stripYAMLComment(line string) string {
+	var quote byte
+	for i := 0; i < len(line); i++ {
+		c := line[i]
+		switch {
+		case quote == '"' && c == '\\':
+			i++
+		case quote != 0:
+			if c == quote && quote == '\'' && i+1 < len(line) && line[i+1] == '\'' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			// A quote only opens a scalar at the start of one.
+			//
+			// 引号只有位于标量的开头时才会开始一个被引号括起来的标量。
+			if i == 0 || strings.IndexByte(" \t[,:-", line[i-1]) >= 0 {
+				quote = c
+			}
+		case c == '#' && (i == 0 || line[i-1] == ' ' || line[i-1] == '\t'):
+			return line[:i]
+		}
+	}
+	return line
+}
@@ -0,0 +1,110 @@
+// Copyright 2018 Li Zebang. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package flag_test
+
+import (
+	. "flag"
+	"fmt"
+	"io/ioutil"
+	"strings"
+	"testing"
+)
+
+func TestParseConfigYAML(t *testing.T) {
+	fs := NewFlagSet("config", ContinueOnError)
+	port := fs.Int("server.port", 0, "port")
+	host := fs.String("server.host", "", "host")
+	tlsOn := fs.Bool("server.tls.enabled", false, "tls")
+	name := fs.String("name", "", "name")
+	quoted := fs.String("quoted", "", "quoted")
+	single := fs.String("single", "", "single")
+	keep := fs.String("keep", "default", "left alone")
+	var tags, hosts listValue
+	fs.Var(&tags, "tags", "tags")
+	fs.Var(&hosts, "hosts", "hosts")
+
+	const doc = `---
+# flags of the server
+server:
+  port: 8080   # the port
+  host: example.com
+  tls:
+    enabled: true
+name: from-config
+quoted: "a # not a comment\tb"
+single: 'it''s'
+keep: ~
+tags: [a, "b, c", 'd']
+hosts:
+- one
+- "two"
+`
+	if err := fs.Parse([]string{"-name", "from-flag"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := fs.ParseConfigYAML(strings.NewReader(doc)); err != nil {
+		t.Fatal(err)
+	}
+	if *port != 8080 || *host != "example.com" || !*tlsOn {
+		t.Errorf("server: port=%d host=%q tls.enabled=%v", *port, *host, *tlsOn)
+	}
+	if *name != "from-flag" {
+		t.Errorf("name = %q; the command line should win", *name)
+	}
+	if *quoted != "a # not a comment\tb" || *single != "it's" {
+		t.Errorf("quoted = %q, single = %q", *quoted, *single)
+	}
+	if *keep != "default" {
+		t.Errorf("keep = %q; ~ should leave the flag alone", *keep)
+	}
+	if fmt.Sprintf("%q %q", []string(tags), []string(hosts)) != `["a" "b, c" "d"] ["one" "two"]` {
+		t.Errorf("tags = %q, hosts = %q", []string(tags), []string(hosts))
+	}
+}
+
+func TestParseConfigYAMLIndentedSequence(t *testing.T) {
+	fs := NewFlagSet("config", ContinueOnError)
+	var hosts listValue
+	fs.Var(&hosts, "db.hosts", "hosts")
+	n := fs.Int("db.n", 0, "n")
+	const doc = "db:\n  hosts:\n    - a\n    - b\n  n: 2\n"
+	if err := fs.ParseConfigYAML(strings.NewReader(doc)); err != nil {
+		t.Fatal(err)
+	}
+	if fmt.Sprint(hosts) != "[a b]" || *n != 2 {
+		t.Errorf("db.hosts = %v, db.n = %d", hosts, *n)
+	}
+}
+
+func TestParseConfigYAMLErrors(t *testing.T) {
+	tests := []struct {
+		doc  string
+		want string
+	}{
+		{"n: x\n", `invalid value "x" in YAML config for flag -n`},
+		{"missing: 1\n", `flag provided in YAML config but not defined: -missing`},
+		{"n: 1\n  m: 2\n", `invalid YAML config: line 2: unexpected indentation`},
+		{"n: 1\nn: 2\n", `invalid YAML config: line 2: duplicate key "n"`},
+		{"n: |\n  1\n", `invalid YAML config: line 1: block scalars are not supported`},
+		{"n: {a: 1}\n", `invalid YAML config: line 1: flow mappings are not supported`},
+		{"n: &a 1\n", `invalid YAML config: line 1: anchors, aliases and tags are not supported`},
+		{"n: 1\n---\nn: 2\n", `invalid YAML config: line 2: multiple documents are not supported`},
+		{"n\n", `invalid YAML config: line 1: expected a key followed by a colon`},
+		{"n:\n\t- 1\n", `invalid YAML config: line 2: tabs are not allowed in indentation`},
+		{"n:\n- a: 1\n", `invalid YAML config: line 2: sequence items must be scalars`},
+		{"n: [1, [2]]\n", `invalid YAML config: line 1: flow sequence items must be scalars`},
+		{"n: \"1\n", `invalid YAML config: line 1: invalid double-quoted scalar "1`},
+		{"- 1\n", `invalid YAML config: line 1: sequence item where a mapping key was expected`},
+	}
+	for _, tt := range tests {
+		fs := NewFlagSet("config", ContinueOnError)
+		fs.SetOutput(ioutil.Discard)
+		fs.Int("n", 0, "count")
+		err := fs.ParseConfigYAML(strings.NewReader(tt.doc))
+		if err == nil || !strings.HasPrefix(err.Error(), tt.want) {
+			t.Errorf("ParseConfigYAML(%q) = %v; want prefix %q", tt.doc, err, tt.want)
+		}
+	}
+}
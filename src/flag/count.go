@@ -0,0 +1,111 @@
+// Copyright 2018 Li Zebang. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package flag
+
+import "strconv"
+
+// -- count Value
+//
+// A count flag is a boolean flag that counts how often it is given, so
+// -v -v -v gives 3, and so does -vvv in the CombinedShorts mode. An
+// explicit value, as in -v=2, sets the count instead.
+//
+// 计数标志是一个计算它被给出了多少次的布尔标志，所以 -v -v -v 得到的是 3，在 CombinedShorts
+// 模式下 -vvv 也是如此。明确给出的值，例如 -v=2，会直接设置计数。
+//
+// IMP: 没有值的布尔标志会用 "true" 调用 Set，所以 "true" 表示加一。
+type countValue int
+
+func newCountValue(val int, p *int) *countValue {
+	*p = val
+	return (*countValue)(p)
+}
+
+func (c *countValue) Set(s string) error {
+	if s == "true" {
+		*c++
+		return nil
+	}
+	v, err := strconv.ParseInt(s, 0, strconv.IntSize)
+	if err != nil {
+		return err
+	}
+	*c = countValue(v)
+	return nil
+}
+
+func (c *countValue) Get() interface{} { return int(*c) }
+
+func (c *countValue) String() string { return strconv.Itoa(int(*c)) }
+
+func (c *countValue) IsBoolFlag() bool { return true }
+
+// CountVar defines a count flag with specified name, default value, and
+// usage string. The argument p points to an int variable in which to store
+// the number of times the flag is given, added to the default value.
+//
+// CountVar 定义一个具有指定名称、默认值和用法信息的计数标志。参数 p 指向一个用来存储标志
+// 被给出的次数的 int 变量，次数会被加到默认值上。
+func (f *FlagSet) CountVar(p *int, name string, value int, usage string) {
+	f.Var(newCountValue(value, p), name, usage)
+}
+
+// CountVar defines a count flag with specified name, default value, and
+// usage string.
+//
+// CountVar 定义一个具有指定名称、默认值和用法信息的计数标志。
+func CountVar(p *int, name string, value int, usage string) {
+	CommandLine.Var(newCountValue(value, p), name, usage)
+}
+
+// Count defines a count flag with specified name, default value, and usage
+// string. The return value is the address of an int variable that stores
+// the number of times the flag is given.
+//
+// Count 定义一个具有指定名称、默认值和用法信息的计数标志。返回值是存储标志被给出的次数的
+// int 变量的地址。
+func (f *FlagSet) Count(name string, value int, usage string) *int {
+	p := new(int)
+	f.CountVar(p, name, value, usage)
+	return p
+}
+
+// Count defines a count flag with specified name, default value, and usage
+// string.
+//
+// Count 定义一个具有指定名称、默认值和用法信息的计数标志。
+func Count(name string, value int, usage string) *int {
+	return CommandLine.Count(name, value, usage)
+}
+
+// CountVarP is like CountVar, but accepts a shorthand for the flag.
+//
+// CountVarP 类似于 CountVar，但接受该标志的缩写。
+func (f *FlagSet) CountVarP(p *int, name, shorthand string, value int, usage string) {
+	f.VarP(newCountValue(value, p), name, shorthand, usage)
+}
+
+// CountVarP is like CountVar, but accepts a shorthand for the flag.
+//
+// CountVarP 类似于 CountVar，但接受该标志的缩写。
+func CountVarP(p *int, name, shorthand string, value int, usage string) {
+	CommandLine.VarP(newCountValue(value, p), name, shorthand, usage)
+}
+
+// CountP is like Count, but accepts a shorthand for the flag.
+//
+// CountP 类似于 Count，但接受该标志的缩写。
+func (f *FlagSet) CountP(name, shorthand string, value int, usage string) *int {
+	p := new(int)
+	f.CountVarP(p, name, shorthand, value, usage)
+	return p
+}
+
+// CountP is like Count, but accepts a shorthand for the flag.
+//
+// CountP 类似于 Count，但接受该标志的缩写。
+func CountP(name, shorthand string, value int, usage string) *int {
+	return CommandLine.CountP(name, shorthand, value, usage)
+}
@@ -0,0 +1,80 @@
+// Copyright 2018 Li Zebang. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package flag_test
+
+import (
+	"bytes"
+	. "flag"
+	"strings"
+	"testing"
+)
+
+func TestCount(t *testing.T) {
+	tests := []struct {
+		args []string
+		want int
+	}{
+		{nil, 0},
+		{[]string{"-v"}, 1},
+		{[]string{"-v", "-v", "-v"}, 3},
+		{[]string{"-vvv"}, 3},
+		{[]string{"-vv", "--verbose"}, 3},
+		{[]string{"-v", "-verbose=5", "-v"}, 6},
+		{[]string{"-vq", "-v"}, 2},
+	}
+	for _, tt := range tests {
+		fs := NewFlagSet("count", ContinueOnError)
+		fs.SetParseMode(CombinedShorts)
+		v := fs.CountP("verbose", "v", 0, "verbosity")
+		fs.BoolP("quiet", "q", false, "quiet")
+		if err := fs.Parse(tt.args); err != nil {
+			t.Fatalf("Parse(%q): %v", tt.args, err)
+		}
+		if *v != tt.want {
+			t.Errorf("Parse(%q): verbose = %d; want %d", tt.args, *v, tt.want)
+		}
+	}
+}
+
+func TestCountDefault(t *testing.T) {
+	fs := NewFlagSet("count", ContinueOnError)
+	var v int
+	fs.CountVar(&v, "v", 2, "verbosity")
+	if err := fs.Parse([]string{"-v"}); err != nil {
+		t.Fatal(err)
+	}
+	if v != 3 {
+		t.Errorf("v = %d; want 3", v)
+	}
+	if got := fs.Lookup("v").Value.(Getter).Get(); got != 3 {
+		t.Errorf("Get() = %v; want 3", got)
+	}
+}
+
+func TestCountInvalid(t *testing.T) {
+	fs := NewFlagSet("count", ContinueOnError)
+	fs.SetOutput(new(bytes.Buffer))
+	fs.Count("v", 0, "verbosity")
+	if err := fs.Parse([]string{"-v=lots"}); err == nil {
+		t.Error("expected error for non-numeric count")
+	}
+}
+
+func TestCountPrintDefaults(t *testing.T) {
+	fs := NewFlagSet("count", ContinueOnError)
+	var buf bytes.Buffer
+	fs.SetOutput(&buf)
+	fs.Count("v", 0, "verbosity; repeat for more")
+	fs.Count("w", 1, "warnings")
+	fs.PrintDefaults()
+	want := strings.Join([]string{
+		"  -v\tverbosity; repeat for more",
+		"  -w\twarnings (default 1)",
+		"",
+	}, "\n")
+	if buf.String() != want {
+		t.Errorf("PrintDefaults:\n%q\nwant:\n%q", buf.String(), want)
+	}
+}
@@ -0,0 +1,94 @@
+// Copyright 2018 Li Zebang. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package flag
+
+// ArgsLenAtDash returns the number of arguments remaining after the flags
+// that came before the "--" that ended them, or -1 if the flags were not
+// ended by "--". Arguments from i = ArgsLenAtDash() on were given after the
+// "--", so run -- ls -l can tell ls -l, to be passed on, from arguments of
+// its own. Without Interspersed the result is 0 or -1, since any other
+// argument already ends the flags.
+//
+// ArgsLenAtDash 返回标志之后剩余的参数中出现在结束标志的 "--" 之前的参数个数，如果标志不是
+// 由 "--" 结束的，则返回 -1。从 i = ArgsLenAtDash() 开始的参数是在 "--" 之后给出的，所以
+// run -- ls -l 可以将需要传递下去的 ls -l 与它自己的参数区分开。没有 Interspersed 时结果为
+// 0 或者 -1，因为其他任何参数都已经结束了标志。
+func (f *FlagSet) ArgsLenAtDash() int {
+	return f.argsLenAtDash
+}
+
+// ArgsLenAtDash returns the number of remaining command-line arguments
+// that came before the "--" that ended the flags, or -1.
+//
+// ArgsLenAtDash 返回剩余的命令行参数中出现在结束标志的 "--" 之前的参数个数，或者 -1。
+func ArgsLenAtDash() int {
+	return CommandLine.ArgsLenAtDash()
+}
+
+// ArgsBeforeDash returns the arguments remaining after the flags that came
+// before the "--" that ended them, or all of them if there was no "--".
+//
+// ArgsBeforeDash 返回标志之后剩余的参数中出现在结束标志的 "--" 之前的参数，如果没有
+// "--"，则返回全部参数。
+func (f *FlagSet) ArgsBeforeDash() []string {
+	if f.argsLenAtDash < 0 {
+		return f.args
+	}
+	return f.args[:f.argsLenAtDash]
+}
+
+// ArgsBeforeDash returns the remaining command-line arguments that came
+// before the "--" that ended the flags.
+//
+// ArgsBeforeDash 返回剩余的命令行参数中出现在结束标志的 "--" 之前的参数。
+func ArgsBeforeDash() []string {
+	return CommandLine.ArgsBeforeDash()
+}
+
+// ArgsAfterDash returns the arguments that came after the "--" that ended
+// the flags, or nil if there was no "--".
+//
+// ArgsAfterDash 返回在结束标志的 "--" 之后给出的参数，如果没有 "--"，则返回 nil。
+func (f *FlagSet) ArgsAfterDash() []string {
+	if f.argsLenAtDash < 0 {
+		return nil
+	}
+	return f.args[f.argsLenAtDash:]
+}
+
+// ArgsAfterDash returns the command-line arguments that came after the
+// "--" that ended the flags.
+//
+// ArgsAfterDash 返回在结束标志的 "--" 之后给出的命令行参数。
+func ArgsAfterDash() []string {
+	return CommandLine.ArgsAfterDash()
+}
+
+// Passthrough returns the arguments that came after the "--" that ended
+// the flags in PassthroughArgs mode, which are not included in Args, or
+// nil if there was no "--". ArgsAfterDash is then empty.
+//
+// Passthrough 返回 PassthroughArgs 模式下在结束标志的 "--" 之后给出的参数，它们不包含在
+// Args 中，如果没有 "--"，则返回 nil。此时 ArgsAfterDash 为空。
+func (f *FlagSet) Passthrough() []string {
+	return f.passthrough
+}
+
+// Passthrough returns the command-line arguments that came after the "--"
+// that ended the flags in PassthroughArgs mode.
+//
+// Passthrough 返回 PassthroughArgs 模式下在结束标志的 "--" 之后给出的命令行参数。
+func Passthrough() []string {
+	return CommandLine.Passthrough()
+}
+
+// hasValueArg reports whether the next argument can be the value of a
+// flag: there is one, and it is not a "--" in PassthroughArgs mode.
+//
+// hasValueArg 报告下一个参数能否作为标志的值：参数存在，并且不是 PassthroughArgs 模式下
+// 的 "--"。
+func (f *FlagSet) hasValueArg() bool {
+	return len(f.args) > 0 && (f.mode&PassthroughArgs == 0 || f.args[0] != "--")
+}
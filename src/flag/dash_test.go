@@ -0,0 +1,128 @@
+// Copyright 2018 Li Zebang. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package flag_test
+
+import (
+	"errors"
+	. "flag"
+	"io/ioutil"
+	"reflect"
+	"testing"
+)
+
+func TestArgsLenAtDash(t *testing.T) {
+	tests := []struct {
+		mode          ParseMode
+		args          []string
+		at            int
+		before, after []string
+	}{
+		{0, []string{"-v", "a", "b"}, -1, []string{"a", "b"}, nil},
+		{0, []string{"-v", "--", "ls", "-l"}, 0, []string{}, []string{"ls", "-l"}},
+		{0, []string{"a", "--", "b"}, -1, []string{"a", "--", "b"}, nil},
+		{0, []string{"--"}, 0, []string{}, []string{}},
+		{Interspersed, []string{"a", "-v", "b", "--", "ls", "-v"}, 2, []string{"a", "b"}, []string{"ls", "-v"}},
+		{Interspersed, []string{"a", "-v", "b"}, -1, []string{"a", "b"}, nil},
+	}
+	for _, tt := range tests {
+		fs := NewFlagSet("dash", ContinueOnError)
+		fs.SetParseMode(tt.mode)
+		fs.Bool("v", false, "verbose")
+		if err := fs.Parse(tt.args); err != nil {
+			t.Fatal(err)
+		}
+		if at := fs.ArgsLenAtDash(); at != tt.at {
+			t.Errorf("Parse(%q): ArgsLenAtDash() = %d; want %d", tt.args, at, tt.at)
+		}
+		if before := fs.ArgsBeforeDash(); !reflect.DeepEqual(before, tt.before) {
+			t.Errorf("Parse(%q): ArgsBeforeDash() = %q; want %q", tt.args, before, tt.before)
+		}
+		if after := fs.ArgsAfterDash(); !reflect.DeepEqual(after, tt.after) {
+			t.Errorf("Parse(%q): ArgsAfterDash() = %q; want %q", tt.args, after, tt.after)
+		}
+	}
+}
+
+func TestArgsLenAtDashBeforeParse(t *testing.T) {
+	var initialized FlagSet
+	initialized.Init("dash", ContinueOnError)
+	for _, fs := range []*FlagSet{NewFlagSet("dash", ContinueOnError), &initialized} {
+		if at := fs.ArgsLenAtDash(); at != -1 {
+			t.Errorf("ArgsLenAtDash() before Parse = %d; want -1", at)
+		}
+		if after := fs.ArgsAfterDash(); after != nil {
+			t.Errorf("ArgsAfterDash() before Parse = %q; want nil", after)
+		}
+	}
+}
+
+func TestPassthrough(t *testing.T) {
+	tests := []struct {
+		mode              ParseMode
+		args              []string
+		out               string
+		rest, passthrough []string
+	}{
+		{PassthroughArgs, []string{"-o", "x", "run", "-v"}, "x", []string{"run", "-v"}, nil},
+		{PassthroughArgs, []string{"-o", "x", "--", "docker", "ps", "--", "-a"}, "x", []string{}, []string{"docker", "ps", "--", "-a"}},
+		{PassthroughArgs, []string{"--"}, "", []string{}, []string{}},
+		{PassthroughArgs | Interspersed, []string{"a", "-o", "x", "b", "--", "-o"}, "x", []string{"a", "b"}, []string{"-o"}},
+		{0, []string{"-o", "x", "--", "ls"}, "x", []string{"ls"}, nil},
+	}
+	for _, tt := range tests {
+		fs := NewFlagSet("pass", ContinueOnError)
+		fs.SetParseMode(tt.mode)
+		out := fs.String("o", "", "output")
+		if err := fs.Parse(tt.args); err != nil {
+			t.Fatal(err)
+		}
+		if *out != tt.out {
+			t.Errorf("Parse(%q): -o = %q; want %q", tt.args, *out, tt.out)
+		}
+		if rest := fs.Args(); !reflect.DeepEqual(rest, tt.rest) {
+			t.Errorf("Parse(%q): Args() = %q; want %q", tt.args, rest, tt.rest)
+		}
+		if p := fs.Passthrough(); !reflect.DeepEqual(p, tt.passthrough) {
+			t.Errorf("Parse(%q): Passthrough() = %q; want %q", tt.args, p, tt.passthrough)
+		}
+	}
+}
+
+func TestPassthroughNotValue(t *testing.T) {
+	fs := NewFlagSet("pass", ContinueOnError)
+	fs.SetOutput(ioutil.Discard)
+	fs.SetParseMode(PassthroughArgs | CombinedShorts)
+	fs.String("o", "", "output")
+	fs.Bool("v", false, "verbose")
+	for _, args := range [][]string{{"-o", "--", "ls"}, {"-vo", "--", "ls"}} {
+		var missing *ErrMissingArgument
+		if err := fs.Parse(args); !errors.As(err, &missing) || missing.Name != "o" {
+			t.Errorf("Parse(%q) = %v; want a missing argument for -o", args, err)
+		}
+	}
+
+	// 没有 PassthroughArgs 时 "--" 可以是值
+	fs = NewFlagSet("pass", ContinueOnError) // without PassthroughArgs a "--" can be a value
+	out := fs.String("o", "", "output")
+	if err := fs.Parse([]string{"-o", "--", "ls"}); err != nil || *out != "--" {
+		t.Errorf("Parse = %v, -o = %q; want nil, \"--\"", err, *out)
+	}
+}
+
+func TestPassthroughToArgs(t *testing.T) {
+	fs := NewFlagSet("pass", ContinueOnError)
+	fs.SetParseMode(PassthroughArgs)
+	fs.String("o", "", "output")
+	if err := fs.Parse([]string{"-o", "x", "--", "ls", "-l"}); err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"-o=x", "--", "ls", "-l"}
+	if args := fs.ToArgs(); !reflect.DeepEqual(args, want) {
+		t.Errorf("ToArgs() = %q; want %q", args, want)
+	}
+	if err := fs.ResetParsed(); err != nil || fs.Passthrough() != nil {
+		t.Errorf("ResetParsed = %v, Passthrough() = %q", err, fs.Passthrough())
+	}
+}
@@ -0,0 +1,123 @@
+// Copyright 2018 Li Zebang. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package flag
+
+// A FlagInfo describes a defined flag for tools that inspect a program's
+// flags, such as UIs and config generators. Its fields carry JSON tags, so
+// json.Marshal(f.Describe()) encodes the flags of f as a JSON array.
+//
+// FlagInfo 为检查程序的标志的工具（例如用户界面和配置生成器）描述一个已定义的标志。它的字段
+// 带有 JSON 标签，所以 json.Marshal(f.Describe()) 会将 f 的标志编码为一个 JSON 数组。
+//
+// IMP: testing 导入了 flag，所以 flag 自己不导入 encoding/json。
+type FlagInfo struct {
+	// Name 是标志的名称
+	Name string `json:"name"` // name of the flag
+	// Shorthand 是单个字母的缩写，没有则为空
+	Shorthand string `json:"shorthand,omitempty"` // one-letter abbreviation, or empty
+	// Type 是值的类型，例如 int 或 strings，不能识别的 Value 类型为 value
+	Type string `json:"type"` // type of the value, such as int or strings; value if unknown
+	// Default 是默认值的文本
+	Default string `json:"default"` // default value as text
+	// Usage 是去除了反引号的帮助信息
+	Usage string `json:"usage"` // help message, with back quotes removed
+	// Env 是该标志读取的环境变量，没有则为空
+	Env string `json:"env,omitempty"` // environment variable read by the flag, or empty
+	// Hidden 表示该标志是否在帮助信息中隐藏
+	Hidden bool `json:"hidden,omitempty"` // whether the flag is omitted from usage messages
+	// RequiredTogether 是必须和该标志同时被设置的其他标志
+	RequiredTogether []string `json:"requiredTogether,omitempty"` // other flags that must be set with this one
+	// Group 是用法信息中列出该标志的分组，没有则为空
+	Group string `json:"group,omitempty"` // group the flag is listed under in usage messages, or empty
+	// NoOptDefVal 是标志出现时没有值所使用的值，没有则为空
+	NoOptDefVal string `json:"noOptDefVal,omitempty"` // value used when the flag is given without one, or empty
+	// Secret 表示该标志的值是否被隐去，此时 Default 为 ***
+	Secret bool `json:"secret,omitempty"` // whether the value is kept out of output, with Default ***
+}
+
+// Describe returns a description of every defined flag, hidden ones
+// included, in the order PrintDefaults uses.
+//
+// Describe 按照 PrintDefaults 使用的顺序返回每个已定义标志（包括隐藏的标志）的描述。
+func (f *FlagSet) Describe() []FlagInfo {
+	flags := f.sortedFormal()
+	if f.unsorted {
+		flags = f.order
+	}
+	infos := make([]FlagInfo, 0, len(flags))
+	for _, flag := range flags {
+		infos = append(infos, f.describe(flag))
+	}
+	return infos
+}
+
+// describe returns the description of flag.
+//
+// describe 返回 flag 的描述。
+func (f *FlagSet) describe(flag *Flag) FlagInfo {
+	_, usage := UnquoteUsage(flag)
+	return FlagInfo{
+		Name:             flag.Name,
+		Shorthand:        flag.Shorthand,
+		Type:             flagType(flag),
+		Default:          shownValue(flag, flag.DefValue),
+		Usage:            usage,
+		Env:              f.envKey(flag),
+		Hidden:           flag.Hidden,
+		RequiredTogether: f.requiredWith(flag.Name),
+		Group:            flag.Group,
+		NoOptDefVal:      flag.NoOptDefVal,
+		Secret:           flag.Secret,
+	}
+}
+
+// Describe returns a description of every defined command-line flag.
+//
+// Describe 返回每个已定义的命令行标志的描述。
+func Describe() []FlagInfo {
+	return CommandLine.Describe()
+}
+
+// flagType returns the name of the type of the value of flag, which is
+// the name UnquoteUsage guesses when the usage message names none.
+//
+// flagType 返回 flag 的值的类型名称，即用法信息中没有给出名称时 UnquoteUsage 猜测的名称。
+func flagType(flag *Flag) string {
+	switch flag.Value.(type) {
+	case *countValue:
+		return "count"
+	case boolFlag:
+		return "bool"
+	}
+	probe := *flag
+	probe.Usage = ""
+	name, _ := UnquoteUsage(&probe)
+	return name
+}
+
+// requiredWith returns the flags that MarkRequiredTogether groups with
+// name, in the order they were given, without repeats.
+//
+// requiredWith 按照给出的顺序返回 MarkRequiredTogether 中与 name 同组的标志，不包含重复项。
+func (f *FlagSet) requiredWith(name string) []string {
+	var names []string
+	seen := map[string]bool{name: true}
+	for _, group := range f.together {
+		in := false
+		for _, n := range group {
+			in = in || n == name
+		}
+		if !in {
+			continue
+		}
+		for _, n := range group {
+			if !seen[n] {
+				seen[n] = true
+				names = append(names, n)
+			}
+		}
+	}
+	return names
+}
@@ -0,0 +1,61 @@
+// Copyright 2018 Li Zebang. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package flag_test
+
+import (
+	"encoding/json"
+	. "flag"
+	"reflect"
+	"testing"
+)
+
+func TestDescribe(t *testing.T) {
+	fs := NewFlagSet("describe", ContinueOnError)
+	fs.IntP("port", "p", 8080, "`port` to listen on")
+	fs.Bool("debug", false, "debug")
+	fs.Count("v", 0, "verbosity")
+	fs.StringSlice("tag", []string{"a", "b"}, "tags")
+	fs.String("user", "", "user")
+	fs.String("password", "", "password")
+	fs.MarkHidden("debug")
+	fs.BindEnv("password", "APP_PASSWORD")
+	fs.MarkRequiredTogether("user", "password")
+	want := []FlagInfo{
+		{Name: "debug", Type: "bool", Default: "false", Usage: "debug", Hidden: true},
+		{Name: "password", Type: "string", Default: "", Usage: "password", Env: "APP_PASSWORD", RequiredTogether: []string{"user"}},
+		{Name: "port", Shorthand: "p", Type: "int", Default: "8080", Usage: "port to listen on"},
+		{Name: "tag", Type: "strings", Default: "a,b", Usage: "tags"},
+		{Name: "user", Type: "string", Default: "", Usage: "user", RequiredTogether: []string{"password"}},
+		{Name: "v", Type: "count", Default: "0", Usage: "verbosity"},
+	}
+	if got := fs.Describe(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Describe:\n%+v\nwant:\n%+v", got, want)
+	}
+
+	fs.SetSortFlags(false)
+	var names []string
+	for _, info := range fs.Describe() {
+		names = append(names, info.Name)
+	}
+	if want := []string{"port", "debug", "v", "tag", "user", "password"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("unsorted names = %q; want %q", names, want)
+	}
+}
+
+func TestDescribeJSON(t *testing.T) {
+	fs := NewFlagSet("describe", ContinueOnError)
+	fs.IntP("port", "p", 8080, "port")
+	fs.Bool("debug", false, "debug")
+	fs.MarkHidden("debug")
+	data, err := json.Marshal(fs.Describe())
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `[{"name":"debug","type":"bool","default":"false","usage":"debug","hidden":true},` +
+		`{"name":"port","shorthand":"p","type":"int","default":"8080","usage":"port"}]`
+	if string(data) != want {
+		t.Errorf("json.Marshal:\n%s\nwant:\n%s", data, want)
+	}
+}
@@ -0,0 +1,50 @@
+// Copyright 2018 Li Zebang. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package flag_test
+
+import (
+	"diag"
+	. "flag"
+	"fmt"
+	"testing"
+)
+
+type traceLogger struct {
+	events []string
+}
+
+func (l *traceLogger) Enabled(pkg string, level diag.Level) bool {
+	return pkg == "flag"
+}
+
+func (l *traceLogger) Log(pkg string, level diag.Level, msg string, keyvals ...interface{}) {
+	l.events = append(l.events, fmt.Sprint(level, " ", msg, " ", keyvals))
+}
+
+func TestParseTrace(t *testing.T) {
+	defer setenv(t, "TRACE_NAME", "env")()
+	l := new(traceLogger)
+	diag.SetLogger(l)
+	defer diag.SetLogger(nil)
+
+	fs := NewFlagSet("trace", ContinueOnError)
+	fs.Bool("v", false, "verbose")
+	fs.String("name", "", "name")
+	fs.Int("n", 0, "count")
+	fs.BindEnv("name", "TRACE_NAME")
+	if err := fs.Parse([]string{"-v", "-n", "3"}); err != nil {
+		t.Fatal(err)
+	}
+	fs.Set("n", "4")
+	want := []string{
+		"DEBUG flag set [set trace flag v value true source command line]",
+		"DEBUG flag set [set trace flag n value 3 source command line]",
+		"DEBUG flag set [set trace flag name value env source $TRACE_NAME]",
+		"DEBUG flag set [set trace flag n value 4 source Set]",
+	}
+	if fmt.Sprint(l.events) != fmt.Sprint(want) {
+		t.Errorf("events:\n%q\nwant:\n%q", l.events, want)
+	}
+}
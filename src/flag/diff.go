@@ -0,0 +1,98 @@
+// Copyright 2018 Li Zebang. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package flag
+
+import (
+	"fmt"
+	"sort"
+)
+
+// A DeltaKind says how a flag differs between two flag sets.
+//
+// DeltaKind 说明一个标志在两个标志集之间有什么不同。
+type DeltaKind int
+
+const (
+	// FlagRemoved means the flag is defined in a but not in b.
+	//
+	// FlagRemoved 表示标志在 a 中定义了，但在 b 中没有定义。
+	FlagRemoved DeltaKind = iota
+
+	// FlagAdded means the flag is defined in b but not in a.
+	//
+	// FlagAdded 表示标志在 b 中定义了，但在 a 中没有定义。
+	FlagAdded
+
+	// DefaultChanged means the flag is defined in both with different
+	// default values.
+	//
+	// DefaultChanged 表示标志在两者中都有定义，但默认值不同。
+	DefaultChanged
+)
+
+func (k DeltaKind) String() string {
+	switch k {
+	case FlagRemoved:
+		return "removed"
+	case FlagAdded:
+		return "added"
+	case DefaultChanged:
+		return "default changed"
+	}
+	return fmt.Sprintf("DeltaKind(%d)", int(k))
+}
+
+// A FlagDelta describes a flag that differs between two flag sets.
+//
+// FlagDelta 描述了一个在两个标志集之间有所不同的标志。
+type FlagDelta struct {
+	// Name 是标志的名称
+	Name string // name of the flag
+	// Kind 说明标志有什么不同
+	Kind DeltaKind // how the flag differs
+	// A 是标志在 a 中的默认值，FlagAdded 时为空
+	A string // default value of the flag in a, or empty for FlagAdded
+	// B 是标志在 b 中的默认值，FlagRemoved 时为空
+	B string // default value of the flag in b, or empty for FlagRemoved
+}
+
+func (d FlagDelta) String() string {
+	if d.Kind == DefaultChanged {
+		return fmt.Sprintf("-%s: default changed from %q to %q", d.Name, d.A, d.B)
+	}
+	return fmt.Sprintf("-%s: %v", d.Name, d.Kind)
+}
+
+// Diff compares the flags defined in a and b, such as those of two
+// versions of a program, and returns the flags that are defined in only
+// one of them and those whose default values differ, in lexicographical
+// order. Flags are matched by name; shorthands, aliases and usage messages
+// are not compared. Diff returns nil if the flag sets define the same
+// flags with the same defaults.
+//
+// Diff 比较 a 和 b（例如一个程序的两个版本）中定义的标志，按照字典序返回只在其中之一定义的
+// 标志和默认值不同的标志。标志按照名称匹配；缩写、别名和用法信息不参与比较。如果两个标志集
+// 以相同的默认值定义了相同的标志，Diff 返回 nil。
+func Diff(a, b *FlagSet) []FlagDelta {
+	var deltas []FlagDelta
+	for _, flag := range a.sortedFormal() {
+		other, ok := b.formal[flag.Name]
+		switch {
+		case !ok:
+			deltas = append(deltas, FlagDelta{Name: flag.Name, Kind: FlagRemoved, A: shownValue(flag, flag.DefValue)})
+		case other.DefValue != flag.DefValue:
+			deltas = append(deltas, FlagDelta{Name: flag.Name, Kind: DefaultChanged,
+				A: shownValue(flag, flag.DefValue), B: shownValue(other, other.DefValue)})
+		}
+	}
+	for _, flag := range b.sortedFormal() {
+		if _, ok := a.formal[flag.Name]; !ok {
+			deltas = append(deltas, FlagDelta{Name: flag.Name, Kind: FlagAdded, B: shownValue(flag, flag.DefValue)})
+		}
+	}
+	// 两次遍历各自有序，合并后重新排序
+	sort.Slice(deltas, func(i, j int) bool { return deltas[i].Name < deltas[j].Name }) // each pass is sorted; sort the merged list
+	return deltas
+}
@@ -0,0 +1,45 @@
+// Copyright 2018 Li Zebang. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package flag_test
+
+import (
+	. "flag"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestDiff(t *testing.T) {
+	a := NewFlagSet("v1", ContinueOnError)
+	a.Bool("v", false, "verbose")
+	a.Int("workers", 4, "workers")
+	a.String("log", "", "log file")
+	a.Duration("timeout", time.Second, "timeout")
+
+	b := NewFlagSet("v2", ContinueOnError)
+	b.BoolP("v", "V", false, "be verbose")
+	b.Int("workers", 8, "workers")
+	b.Duration("timeout", time.Second, "timeout")
+	b.String("addr", ":80", "address")
+
+	want := []FlagDelta{
+		{Name: "addr", Kind: FlagAdded, B: ":80"},
+		{Name: "log", Kind: FlagRemoved},
+		{Name: "workers", Kind: DefaultChanged, A: "4", B: "8"},
+	}
+	got := Diff(a, b)
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("Diff = %v; want %v", got, want)
+	}
+	strs := []string{`-addr: added`, `-log: removed`, `-workers: default changed from "4" to "8"`}
+	for i, d := range got {
+		if d.String() != strs[i] {
+			t.Errorf("String() = %q; want %q", d.String(), strs[i])
+		}
+	}
+	if d := Diff(a, a); d != nil {
+		t.Errorf("Diff(a, a) = %v; want nil", d)
+	}
+}
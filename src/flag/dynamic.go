@@ -0,0 +1,113 @@
+// Copyright 2018 Li Zebang. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package flag
+
+import (
+	"fmt"
+	"strings"
+)
+
+// dynamicFlag is a family of flags registered with Dynamic.
+//
+// dynamicFlag 是用 Dynamic 注册的一族标志。
+type dynamicFlag struct {
+	prefix string
+	usage  string
+	fn     func(key, value string) error
+}
+
+// Dynamic registers a family of flags whose names start with prefix, such
+// as the -Dkey=value of compilers or the -X name=value of linkers. Each of
+// them calls fn with the key and the value, in any of these forms:
+//	-Dkey=value  -Dkey     -D key=value  -D=key=value
+// where the key is empty if there is none, and so is the value if there is
+// no equals sign after the key. Defined flags take precedence, and when
+// several prefixes match, the longest one wins. An error returned by fn is
+// reported like an invalid value. Dynamic panics if prefix is empty, is the
+// name of a flag, or is already registered.
+//
+// Dynamic 注册一族名称以 prefix 开头的标志，例如编译器的 -Dkey=value 或者链接器的
+// -X name=value。它们中的每一个都以键和值调用 fn，可以使用以下任何一种形式：
+//	-Dkey=value  -Dkey     -D key=value  -D=key=value
+// 没有键时键为空，键之后没有等号时值为空。已定义的标志优先，有多个前缀匹配时，最长的那个
+// 生效。fn 返回的错误和无效的值一样被报告。如果 prefix 为空、是某个标志的名称或者已经注册
+// 过，Dynamic 会 panic。
+func (f *FlagSet) Dynamic(prefix, usage string, fn func(key, value string) error) {
+	var msg string
+	switch {
+	case prefix == "" || prefix[0] == '-' || strings.Contains(prefix, "="):
+		msg = fmt.Sprintf("invalid prefix %q provided to Dynamic", prefix)
+	case f.lookupName(prefix) != nil || f.shorthands[prefix] != nil:
+		msg = fmt.Sprintf("prefix provided to Dynamic is a flag: -%s", prefix)
+	case f.lookupDynamic(prefix) != nil && f.lookupDynamic(prefix).prefix == prefix:
+		msg = fmt.Sprintf("dynamic flag redefined: %s", prefix)
+	}
+	if msg != "" {
+		if f.name != "" {
+			msg = f.name + " " + msg
+		}
+		fmt.Fprintln(f.Output(), msg)
+		panic(msg)
+	}
+	f.dynamics = append(f.dynamics, &dynamicFlag{prefix, usage, fn})
+}
+
+// Dynamic registers a family of command-line flags whose names start with
+// prefix.
+//
+// Dynamic 注册一族名称以 prefix 开头的命令行标志。
+func Dynamic(prefix, usage string, fn func(key, value string) error) {
+	CommandLine.Dynamic(prefix, usage, fn)
+}
+
+// lookupDynamic returns the family of flags with the longest prefix of
+// name, or nil.
+//
+// lookupDynamic 返回前缀是 name 的最长前缀的那族标志，或者 nil。
+func (f *FlagSet) lookupDynamic(name string) *dynamicFlag {
+	var found *dynamicFlag
+	for _, d := range f.dynamics {
+		if strings.HasPrefix(name, d.prefix) && (found == nil || len(d.prefix) > len(found.prefix)) {
+			found = d
+		}
+	}
+	return found
+}
+
+// parseDynamic parses the flag name of the family d, with the value given
+// after an equals sign, if any.
+//
+// parseDynamic 解析 d 这族标志中的标志 name，如果在等号之后给出了值，value 就是它。
+func (f *FlagSet) parseDynamic(d *dynamicFlag, name, value string, hasValue bool) (bool, error) {
+	key := name[len(d.prefix):]
+	if key == "" {
+		// -D key=value 或者 -D=key=value：键和值在同一个参数中
+		if !hasValue { // -D key=value or -D=key=value: the key comes with the value
+			if !f.hasValueArg() {
+				return false, f.fail(&ErrMissingArgument{Name: name})
+			}
+			value, f.args = f.args[0], f.args[1:]
+		}
+		key, value = value, ""
+		if i := strings.IndexByte(key, '='); i >= 0 {
+			key, value = key[:i], key[i+1:]
+		}
+	}
+	if err := d.fn(key, value); err != nil {
+		return false, f.fail(&ErrInvalidValue{Name: d.prefix + key, Value: value, Err: err})
+	}
+	return true, nil
+}
+
+// printDynamics prints the families of flags registered with Dynamic for
+// PrintDefaults.
+//
+// printDynamics 为 PrintDefaults 打印用 Dynamic 注册的各族标志。
+func (f *FlagSet) printDynamics(c func(code, s string) string) {
+	for _, d := range f.dynamics {
+		fmt.Fprintf(f.Output(), "  %s\n    \t%s\n", c(colorBold, "-"+d.prefix+"<key>=<value>"),
+			strings.Replace(d.usage, "\n", "\n    \t", -1))
+	}
+}
@@ -0,0 +1,117 @@
+// Copyright 2018 Li Zebang. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package flag_test
+
+import (
+	"bytes"
+	"errors"
+	. "flag"
+	"strings"
+	"testing"
+)
+
+func TestDynamic(t *testing.T) {
+	fs := NewFlagSet("cc", ContinueOnError)
+	fs.SetOutput(new(bytes.Buffer))
+	fs.SetParseMode(CombinedShorts)
+	debug := fs.Bool("Debug", false, "defined flags take precedence")
+	fs.Bool("v", false, "verbose")
+	var got []string
+	fs.Dynamic("D", "define a macro", func(key, value string) error {
+		got = append(got, "D:"+key+"="+value)
+		return nil
+	})
+	fs.Dynamic("X", "set a string", func(key, value string) error {
+		if key == "bad" {
+			return errors.New("not allowed")
+		}
+		got = append(got, "X:"+key+"="+value)
+		return nil
+	})
+	fs.Dynamic("Xlinker", "linker option", func(key, value string) error {
+		got = append(got, "Xlinker:"+key+"="+value)
+		return nil
+	})
+	args := []string{"-DFOO=1", "-DBAR", "-D", "BAZ=2", "-D=QUX", "--DX=y=z", "-Debug",
+		"-X", "main.version=1.0", "-Xlinker=-s", "rest"}
+	if err := fs.Parse(args); err != nil {
+		t.Fatal(err)
+	}
+	want := "D:FOO=1 D:BAR= D:BAZ=2 D:QUX= D:X=y=z X:main.version=1.0 Xlinker:-s="
+	if strings.Join(got, " ") != want {
+		t.Errorf("handled:\n%s\nwant:\n%s", strings.Join(got, " "), want)
+	}
+	if !*debug {
+		t.Error("-Debug was handled as a dynamic flag")
+	}
+	if strings.Join(fs.Args(), " ") != "rest" {
+		t.Errorf("Args = %q", fs.Args())
+	}
+	if fs.NFlag() != 1 {
+		t.Errorf("NFlag = %d; want 1", fs.NFlag())
+	}
+
+	for _, tt := range []struct {
+		args []string
+		err  string
+	}{
+		{[]string{"-X", "bad=1"}, `invalid value "1" for flag -Xbad: not allowed`},
+		{[]string{"-D"}, "flag needs an argument: -D"},
+	} {
+		err := fs.Parse(tt.args)
+		if err == nil || err.Error() != tt.err {
+			t.Errorf("Parse(%q): %v; want %s", tt.args, err, tt.err)
+		}
+	}
+}
+
+func TestDynamicPrintDefaults(t *testing.T) {
+	fs := NewFlagSet("cc", ContinueOnError)
+	var buf bytes.Buffer
+	fs.SetOutput(&buf)
+	fs.Bool("v", false, "verbose")
+	fs.Group("Output").String("o", "", "output `file`")
+	fs.Dynamic("D", "define a macro", func(key, value string) error { return nil })
+	fs.PrintDefaults()
+	want := strings.Join([]string{
+		"  -v\tverbose",
+		"  -D<key>=<value>",
+		"    \tdefine a macro",
+		"",
+		"Output:",
+		"  -o file",
+		"    \toutput file",
+		"",
+	}, "\n")
+	if buf.String() != want {
+		t.Errorf("PrintDefaults:\n%s\nwant:\n%s", buf.String(), want)
+	}
+}
+
+func TestDynamicPanics(t *testing.T) {
+	tests := []struct {
+		prefix string
+		want   string
+	}{
+		{"", `cc invalid prefix "" provided to Dynamic`},
+		{"-D", `cc invalid prefix "-D" provided to Dynamic`},
+		{"v", "cc prefix provided to Dynamic is a flag: -v"},
+		{"D", "cc dynamic flag redefined: D"},
+	}
+	for _, tt := range tests {
+		fs := NewFlagSet("cc", ContinueOnError)
+		fs.SetOutput(new(bytes.Buffer))
+		fs.Bool("v", false, "verbose")
+		fs.Dynamic("D", "", func(key, value string) error { return nil })
+		func() {
+			defer func() {
+				if r := recover(); r != tt.want {
+					t.Errorf("Dynamic(%q): panic = %v; want %q", tt.prefix, r, tt.want)
+				}
+			}()
+			fs.Dynamic(tt.prefix, "", func(key, value string) error { return nil })
+		}()
+	}
+}
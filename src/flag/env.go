@@ -0,0 +1,142 @@
+// Copyright 2018 Li Zebang. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package flag
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// BindEnv makes the flag named name read the environment variable key when
+// it is not set on the command line, so that a value given as a flag takes
+// precedence over the environment, which takes precedence over the default.
+// An empty variable counts as unset. A flag set from the environment is
+// reported by Visit and NFlag like one set on the command line.
+// BindEnv panics if the flag is not defined.
+//
+// BindEnv 使名为 name 的标志在命令行中没有被设置时读取环境变量 key，这样以标志的形式给出
+// 的值优先于环境变量，环境变量又优先于默认值。空的环境变量被当作没有设置。从环境变量中设置
+// 的标志和在命令行中设置的一样会被 Visit 和 NFlag 统计。如果标志没有定义，BindEnv 会
+// panic。
+func (f *FlagSet) BindEnv(name, key string) {
+	flag, ok := f.formal[name]
+	if !ok {
+		msg := fmt.Sprintf("flag provided to BindEnv but not defined: -%s", name)
+		if f.name != "" {
+			msg = f.name + " " + msg
+		}
+		fmt.Fprintln(f.Output(), msg)
+		panic(msg)
+	}
+	flag.Env = key
+}
+
+// BindEnv makes the command-line flag named name read the environment
+// variable key when it is not set on the command line.
+//
+// BindEnv 使名为 name 的命令行标志在命令行中没有被设置时读取环境变量 key。
+func BindEnv(name, key string) {
+	CommandLine.BindEnv(name, key)
+}
+
+// SetEnvPrefix makes every flag that is not bound to a variable with
+// BindEnv read the variable named by prefix, an underscore and the flag
+// name in upper case with dashes and dots replaced by underscores: with the
+// prefix MYAPP, -flag-name reads MYAPP_FLAG_NAME. An empty prefix turns
+// this off.
+//
+// SetEnvPrefix 使每个没有用 BindEnv 绑定环境变量的标志读取名为 prefix、下划线以及大写的
+// 标志名称（其中的横线和点被替换成下划线）的环境变量：前缀为 MYAPP 时，-flag-name 读取
+// MYAPP_FLAG_NAME。空的前缀会关闭此功能。
+func (f *FlagSet) SetEnvPrefix(prefix string) {
+	f.envPrefix = prefix
+}
+
+// SetEnvPrefix sets the environment variable prefix of the command-line
+// flags.
+//
+// SetEnvPrefix 设置命令行标志的环境变量前缀。
+func SetEnvPrefix(prefix string) {
+	CommandLine.SetEnvPrefix(prefix)
+}
+
+// envReplacer maps a flag name to the rest of its environment variable.
+//
+// envReplacer 将标志名称映射为其环境变量名的剩余部分。
+var envReplacer = strings.NewReplacer("-", "_", ".", "_")
+
+// envKey returns the environment variable read by flag, or "" if there is
+// none. A variable bound with BindEnv takes precedence over the prefix.
+//
+// envKey 返回 flag 读取的环境变量，没有则返回 ""。用 BindEnv 绑定的变量优先于前缀。
+func (f *FlagSet) envKey(flag *Flag) string {
+	if flag.Env != "" || f.envPrefix == "" {
+		return flag.Env
+	}
+	return f.envPrefix + "_" + strings.ToUpper(envReplacer.Replace(flag.Name))
+}
+
+// ParseEnv sets the flags from environ, a list of KEY=VALUE pairs in the
+// form of os.Environ, instead of from the command line, for daemons that
+// are configured through their environment alone. Each flag reads the
+// variable given by BindEnv or SetEnvPrefix, as in Parse; the environment
+// of the process is not consulted, and when a key appears more than once
+// the last pair wins. Otherwise ParseEnv behaves like Parse with no
+// arguments: value sources, required flags and the hooks of OnPostParse
+// are handled the same way, and so are errors.
+//
+// ParseEnv 根据 environ（os.Environ 形式的 KEY=VALUE 列表）而不是命令行设置标志，用于只
+// 通过环境变量配置的守护进程。和 Parse 一样，每个标志读取 BindEnv 或 SetEnvPrefix 指定的
+// 变量；进程的环境变量不会被查询，同一个键出现多次时最后一对生效。除此之外 ParseEnv 的行为
+// 和不带参数的 Parse 一样：值来源、必需的标志和 OnPostParse 的钩子以同样的方式处理，错误
+// 也是如此。
+func (f *FlagSet) ParseEnv(environ []string) error {
+	f.environ = make(map[string]string, len(environ))
+	for _, kv := range environ {
+		// 没有等号或者键为空的项不是变量
+		if i := strings.IndexByte(kv, '='); i > 0 { // entries without an equals sign or a key are no variables
+			f.environ[kv[:i]] = kv[i+1:]
+		}
+	}
+	defer func() { f.environ = nil }()
+	return f.Parse(nil)
+}
+
+// ParseEnv sets the command-line flags from environ instead of from the
+// command line.
+//
+// ParseEnv 根据 environ 而不是命令行设置命令行标志。
+func ParseEnv(environ []string) error {
+	return CommandLine.ParseEnv(environ)
+}
+
+// getenv returns the value of the environment variable key, taken from
+// the pairs given to ParseEnv while it runs.
+//
+// getenv 返回环境变量 key 的值，在 ParseEnv 运行期间从传给它的变量中获取。
+func (f *FlagSet) getenv(key string) string {
+	if f.environ != nil {
+		return f.environ[key]
+	}
+	return os.Getenv(key)
+}
+
+// lookupEnv returns the value of the environment variable of flag, a
+// description of the variable for errors and a label for traces. It
+// reports false if flag reads no variable or the variable is empty.
+//
+// lookupEnv 返回 flag 的环境变量的值、在错误信息中描述这个变量的文字以及跟踪信息中使用的
+// 标签。如果 flag 不读取环境变量或者变量为空，它返回 false。
+func (f *FlagSet) lookupEnv(flag *Flag) (value, desc, label string, ok bool) {
+	key := f.envKey(flag)
+	if key == "" {
+		return "", "", "", false
+	}
+	if value = f.getenv(key); value == "" {
+		return "", "", "", false
+	}
+	return value, "environment variable " + key, "$" + key, true
+}
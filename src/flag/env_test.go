@@ -0,0 +1,210 @@
+// Copyright 2018 Li Zebang. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package flag_test
+
+import (
+	"bytes"
+	. "flag"
+	"os"
+	"strings"
+	"testing"
+)
+
+// setenv sets the environment variable key and returns a function that
+// restores it.
+func setenv(t *testing.T, key, value string) func() {
+	old, ok := os.LookupEnv(key)
+	if err := os.Setenv(key, value); err != nil {
+		t.Fatal(err)
+	}
+	return func() {
+		if ok {
+			os.Setenv(key, old)
+		} else {
+			os.Unsetenv(key)
+		}
+	}
+}
+
+func TestBindEnv(t *testing.T) {
+	defer setenv(t, "FLAG_TEST_ADDR", ":8080")()
+	defer setenv(t, "FLAG_TEST_N", "7")()
+	defer setenv(t, "FLAG_TEST_EMPTY", "")()
+
+	fs := NewFlagSet("env", ContinueOnError)
+	addr := fs.String("addr", ":80", "address")
+	n := fs.Int("n", 1, "n")
+	v := fs.Bool("v", false, "v")
+	e := fs.String("e", "default", "e")
+	d := fs.String("d", "default", "d")
+	fs.BindEnv("addr", "FLAG_TEST_ADDR")
+	fs.BindEnv("n", "FLAG_TEST_N")
+	fs.BindEnv("e", "FLAG_TEST_EMPTY")
+	fs.BindEnv("d", "FLAG_TEST_UNSET")
+
+	if err := fs.Parse([]string{"-n", "3"}); err != nil {
+		t.Fatal(err)
+	}
+	if *addr != ":8080" {
+		t.Errorf("addr = %q; want :8080 from the environment", *addr)
+	}
+	if *n != 3 {
+		t.Errorf("n = %d; want 3 from the command line", *n)
+	}
+	if *v || *e != "default" || *d != "default" {
+		t.Errorf("v = %v, e = %q, d = %q; want defaults", *v, *e, *d)
+	}
+	if fs.NFlag() != 2 {
+		t.Errorf("NFlag() = %d; want 2", fs.NFlag())
+	}
+	if f := fs.Lookup("addr"); f.Env != "FLAG_TEST_ADDR" {
+		t.Errorf("Env = %q; want FLAG_TEST_ADDR", f.Env)
+	}
+}
+
+func TestBindEnvInvalid(t *testing.T) {
+	defer setenv(t, "FLAG_TEST_N", "seven")()
+	fs := NewFlagSet("env", ContinueOnError)
+	var buf bytes.Buffer
+	fs.SetOutput(&buf)
+	fs.Int("n", 1, "n")
+	fs.BindEnv("n", "FLAG_TEST_N")
+	err := fs.Parse(nil)
+	const want = `invalid value "seven" for environment variable FLAG_TEST_N of flag -n`
+	if err == nil || !strings.Contains(err.Error(), want) {
+		t.Errorf("Parse = %v; want error containing %q", err, want)
+	}
+	if !strings.Contains(buf.String(), want) {
+		t.Errorf("output %q does not contain %q", buf.String(), want)
+	}
+}
+
+func TestBindEnvUndefined(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("BindEnv of an undefined flag did not panic")
+		}
+	}()
+	fs := NewFlagSet("env", ContinueOnError)
+	fs.SetOutput(new(bytes.Buffer))
+	fs.BindEnv("x", "X")
+}
+
+func TestBindEnvPrintDefaults(t *testing.T) {
+	fs := NewFlagSet("env", ContinueOnError)
+	var buf bytes.Buffer
+	fs.SetOutput(&buf)
+	fs.String("addr", ":80", "listen `address`")
+	fs.BindEnv("addr", "MY_ADDR")
+	fs.PrintDefaults()
+	const want = "  -addr address\n    \tlisten address (default \":80\") (env $MY_ADDR)\n"
+	if buf.String() != want {
+		t.Errorf("got %q want %q", buf.String(), want)
+	}
+}
+
+func TestSetEnvPrefix(t *testing.T) {
+	defer setenv(t, "MYAPP_FLAG_NAME", "from-prefix")()
+	defer setenv(t, "MYAPP_LOG_LEVEL", "3")()
+	defer setenv(t, "MYAPP_ADDR", ":1")()
+	defer setenv(t, "OTHER_ADDR", ":2")()
+	defer setenv(t, "MYAPP_SET", "from-env")()
+
+	fs := NewFlagSet("env", ContinueOnError)
+	name := fs.String("flag-name", "", "name")
+	level := fs.Int("log.level", 0, "level")
+	addr := fs.String("addr", "", "address")
+	set := fs.String("set", "default", "set on the command line")
+	fs.BindEnv("addr", "OTHER_ADDR")
+	fs.SetEnvPrefix("MYAPP")
+	if err := fs.Parse([]string{"-set", "from-flag"}); err != nil {
+		t.Fatal(err)
+	}
+	if *name != "from-prefix" || *level != 3 {
+		t.Errorf("flag-name = %q, log.level = %d; want values from MYAPP_*", *name, *level)
+	}
+	if *addr != ":2" {
+		t.Errorf("addr = %q; want :2 from the bound variable", *addr)
+	}
+	if *set != "from-flag" {
+		t.Errorf("set = %q; want from-flag", *set)
+	}
+
+	fs = NewFlagSet("env", ContinueOnError)
+	name = fs.String("flag-name", "", "name")
+	fs.SetEnvPrefix("MYAPP")
+	fs.SetEnvPrefix("")
+	if err := fs.Parse(nil); err != nil {
+		t.Fatal(err)
+	}
+	if *name != "" {
+		t.Errorf("flag-name = %q after clearing the prefix; want empty", *name)
+	}
+}
+
+func TestSetEnvPrefixPrintDefaults(t *testing.T) {
+	fs := NewFlagSet("env", ContinueOnError)
+	var buf bytes.Buffer
+	fs.SetOutput(&buf)
+	fs.String("flag-name", "", "name")
+	fs.String("addr", "", "address")
+	fs.BindEnv("addr", "ADDR")
+	fs.SetEnvPrefix("MYAPP")
+	fs.PrintDefaults()
+	const want = "  -addr string\n    \taddress (env $ADDR)\n" +
+		"  -flag-name string\n    \tname (env $MYAPP_FLAG_NAME)\n"
+	if buf.String() != want {
+		t.Errorf("got %q want %q", buf.String(), want)
+	}
+}
+
+func TestParseEnv(t *testing.T) {
+	defer setenv(t, "APP_LEVEL", "9")()
+
+	fs := NewFlagSet("env", ContinueOnError)
+	fs.SetEnvPrefix("APP")
+	addr := fs.String("listen-addr", ":80", "address")
+	level := fs.Int("level", 1, "level")
+	n := fs.Int("n", 0, "n")
+	fs.BindEnv("n", "WORKERS")
+	err := fs.ParseEnv([]string{"APP_LISTEN_ADDR=:81", "WORKERS=2", "noequals", "=x", "APP_LISTEN_ADDR=:8080"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 进程的环境变量不会被读取
+	if *addr != ":8080" || *level != 1 || *n != 2 { // the process environment is not read
+		t.Errorf("addr = %q, level = %d, n = %d; want \":8080\", 1, 2", *addr, *level, *n)
+	}
+	if !fs.Parsed() || fs.NArg() != 0 || fs.NFlag() != 2 {
+		t.Errorf("Parsed = %v, NArg = %d, NFlag = %d", fs.Parsed(), fs.NArg(), fs.NFlag())
+	}
+
+	// ParseEnv 之后 Parse 重新读取进程的环境变量
+	fs = NewFlagSet("env", ContinueOnError) // Parse reads the process environment again after ParseEnv
+	fs.SetEnvPrefix("APP")
+	level = fs.Int("level", 1, "level")
+	if err := fs.ParseEnv(nil); err != nil || *level != 1 {
+		t.Errorf("ParseEnv(nil) = %v, level = %d", err, *level)
+	}
+	if err := fs.Parse(nil); err != nil || *level != 9 {
+		t.Errorf("Parse = %v, level = %d", err, *level)
+	}
+}
+
+func TestParseEnvInvalid(t *testing.T) {
+	fs := NewFlagSet("env", ContinueOnError)
+	var buf bytes.Buffer
+	fs.SetOutput(&buf)
+	fs.SetEnvPrefix("APP")
+	fs.Int("port", 0, "port")
+	err := fs.ParseEnv([]string{"APP_PORT=http"})
+	want := `invalid value "http" for environment variable APP_PORT of flag -port`
+	if err == nil || !strings.HasPrefix(err.Error(), want) {
+		t.Fatalf("ParseEnv = %v; want %s", err, want)
+	}
+	if !strings.Contains(buf.String(), "Usage of env:") {
+		t.Errorf("output = %q; want usage", buf.String())
+	}
+}
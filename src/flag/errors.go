@@ -0,0 +1,111 @@
+// Copyright 2018 Li Zebang. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package flag
+
+import "fmt"
+
+// ErrUndefinedFlag is the error returned when the arguments or a config
+// name a flag that is not defined.
+//
+// ErrUndefinedFlag 是参数或者配置中给出了没有定义的标志时返回的错误。
+type ErrUndefinedFlag struct {
+	// Name is the flag name as given, without dashes.
+	//
+	// Name 是给出的标志名称，不包括横线。
+	Name string
+
+	// Source names the config that gave the flag, such as "JSON config",
+	// or is empty for the command line.
+	//
+	// Source 指代给出该标志的配置，例如 "JSON config"，对于命令行则为空。
+	Source string
+
+	// messages translates the message; see SetLocale.
+	//
+	// messages 翻译错误信息，参见 SetLocale。
+	messages messages
+}
+
+func (e *ErrUndefinedFlag) Error() string {
+	if e.Source == "" {
+		return fmt.Sprintf(e.messages.get("flag provided but not defined: -%s"), e.Name)
+	}
+	return fmt.Sprintf(e.messages.get("flag provided in %s but not defined: -%s"), e.Source, e.Name)
+}
+
+// ErrInvalidValue is the error returned when the Set method of a flag
+// rejects a value. It wraps the error returned by Set, which can be
+// recovered with errors.Is, errors.As or errors.Unwrap.
+//
+// ErrInvalidValue 是标志的 Set 方法拒绝一个值时返回的错误。它包装了 Set 返回的错误，
+// 可以通过 errors.Is、errors.As 或 errors.Unwrap 取回这个错误。
+type ErrInvalidValue struct {
+	// Name is the name of the flag.
+	//
+	// Name 是标志的名称。
+	Name string
+
+	// Value is the value that was rejected.
+	//
+	// Value 是被拒绝的值。
+	Value string
+
+	// Source describes where the value came from, such as "JSON config"
+	// or "environment variable PORT", or is empty for the command line.
+	//
+	// Source 描述了值的来源，例如 "JSON config" 或者 "environment variable PORT"，
+	// 对于命令行则为空。
+	Source string
+
+	// Err is the error returned by Set.
+	//
+	// Err 是 Set 返回的错误。
+	Err error
+
+	// msg keeps the wording Parse has always used for some sources.
+	//
+	// msg 保留了 Parse 对某些来源一直使用的措辞。
+	msg string
+
+	// messages translates the message; see SetLocale.
+	//
+	// messages 翻译错误信息，参见 SetLocale。
+	messages messages
+}
+
+func (e *ErrInvalidValue) Error() string {
+	switch {
+	case e.msg != "":
+		return e.msg
+	case e.Source == "":
+		return fmt.Sprintf(e.messages.get("invalid value %q for flag -%s: %v"), e.Value, e.Name, e.Err)
+	}
+	return fmt.Sprintf(e.messages.get("invalid value %q in %s for flag -%s: %v"), e.Value, e.Source, e.Name, e.Err)
+}
+
+func (e *ErrInvalidValue) Unwrap() error { return e.Err }
+
+// ErrMissingArgument is the error returned when a flag that needs a value
+// is the last argument.
+//
+// ErrMissingArgument 是需要值的标志是最后一个参数时返回的错误。
+type ErrMissingArgument struct {
+	// Name is the flag name as given, without dashes.
+	//
+	// Name 是给出的标志名称，不包括横线。
+	Name string
+
+	// messages translates the message; see SetLocale.
+	//
+	// messages 翻译错误信息，参见 SetLocale。
+	messages messages
+}
+
+func (e *ErrMissingArgument) Error() string {
+	return fmt.Sprintf(e.messages.get("flag needs an argument: -%s"), e.Name)
+}
+
+// IMP: 这些错误类型都以指针的形式返回，所以应该使用 errors.As(err, &target) 并将 target
+// 声明为 *ErrUndefinedFlag 等类型。
@@ -0,0 +1,113 @@
+// Copyright 2018 Li Zebang. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package flag_test
+
+import (
+	"bytes"
+	"errors"
+	. "flag"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func newErrorsFlagSet(mode ParseMode) *FlagSet {
+	fs := NewFlagSet("errors", ContinueOnError)
+	fs.SetOutput(new(bytes.Buffer))
+	fs.SetParseMode(mode)
+	fs.Int("n", 0, "number")
+	fs.Bool("b", false, "bool")
+	fs.String("s", "", "string")
+	return fs
+}
+
+func TestErrUndefinedFlag(t *testing.T) {
+	for _, args := range [][]string{{"-x"}, {"--x=1"}, {"-bx"}} {
+		err := newErrorsFlagSet(CombinedShorts).Parse(args)
+		var e *ErrUndefinedFlag
+		if !errors.As(err, &e) || e.Name != "x" || e.Source != "" {
+			t.Errorf("Parse(%q) = %#v; want *ErrUndefinedFlag for x", args, err)
+		}
+	}
+	err := newErrorsFlagSet(0).ParseConfigINI(strings.NewReader("x = 1"))
+	var e *ErrUndefinedFlag
+	if !errors.As(err, &e) || e.Name != "x" || e.Source != "INI config" {
+		t.Errorf("ParseConfigINI = %#v; want *ErrUndefinedFlag for x in INI config", err)
+	}
+}
+
+func TestErrMissingArgument(t *testing.T) {
+	for _, args := range [][]string{{"-s"}, {"-bs"}} {
+		err := newErrorsFlagSet(CombinedShorts).Parse(args)
+		var e *ErrMissingArgument
+		if !errors.As(err, &e) || e.Name != "s" {
+			t.Errorf("Parse(%q) = %#v; want *ErrMissingArgument for s", args, err)
+		}
+	}
+}
+
+func TestErrInvalidValue(t *testing.T) {
+	tests := []struct {
+		args   []string
+		name   string
+		value  string
+		source string
+	}{
+		{[]string{"-n", "x"}, "n", "x", ""},
+		{[]string{"-n=x"}, "n", "x", ""},
+		{[]string{"-nx"}, "n", "x", ""},
+		{[]string{"-b=maybe"}, "b", "maybe", ""},
+		{[]string{"-bn", "y"}, "n", "y", ""},
+	}
+	for _, tt := range tests {
+		err := newErrorsFlagSet(CombinedShorts).Parse(tt.args)
+		var e *ErrInvalidValue
+		if !errors.As(err, &e) {
+			t.Errorf("Parse(%q) = %#v; want *ErrInvalidValue", tt.args, err)
+			continue
+		}
+		if e.Name != tt.name || e.Value != tt.value || e.Source != tt.source {
+			t.Errorf("Parse(%q): Name, Value, Source = %q, %q, %q", tt.args, e.Name, e.Value, e.Source)
+		}
+		if !errors.Is(err, strconv.ErrSyntax) {
+			t.Errorf("Parse(%q): errors.Is(err, strconv.ErrSyntax) = false", tt.args)
+		}
+	}
+}
+
+func TestErrInvalidValueSources(t *testing.T) {
+	fs := newErrorsFlagSet(0)
+	err := fs.ParseConfigINI(strings.NewReader("n = x"))
+	var e *ErrInvalidValue
+	if !errors.As(err, &e) || e.Source != "INI config" || e.Value != "x" {
+		t.Errorf("ParseConfigINI = %#v", err)
+	}
+
+	fs = newErrorsFlagSet(0)
+	fs.AddSource(MapSource{"n": "y"}, 1)
+	err = fs.Parse(nil)
+	if !errors.As(err, &e) || e.Source != "value source flag.MapSource" || e.Value != "y" {
+		t.Errorf("Parse with source = %#v", err)
+	}
+}
+
+func TestErrInvalidValueError(t *testing.T) {
+	err := errors.New("boom")
+	tests := []struct {
+		err  error
+		want string
+	}{
+		{&ErrInvalidValue{Name: "n", Value: "x", Err: err}, `invalid value "x" for flag -n: boom`},
+		{&ErrInvalidValue{Name: "n", Value: "x", Source: "INI config", Err: err}, `invalid value "x" in INI config for flag -n: boom`},
+		{&ErrUndefinedFlag{Name: "n"}, "flag provided but not defined: -n"},
+		{&ErrUndefinedFlag{Name: "n", Source: "INI config"}, "flag provided in INI config but not defined: -n"},
+		{&ErrMissingArgument{Name: "n"}, "flag needs an argument: -n"},
+	}
+	for _, tt := range tests {
+		if got := tt.err.Error(); got != tt.want {
+			t.Errorf("%#v.Error() = %q; want %q", tt.err, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,79 @@
+// Copyright 2018 Li Zebang. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package flag
+
+import (
+	"errors"
+	"os"
+	"strings"
+)
+
+// setValue sets the value of a non-boolean flag given on the command line,
+// expanding environment variables in it first in ExpandEnv mode.
+//
+// setValue 设置命令行上给出的非布尔标志的值，在 ExpandEnv 模式下首先展开其中的环境变量。
+func (f *FlagSet) setValue(flag *Flag, value string) error {
+	if f.mode&ExpandEnv != 0 {
+		var err error
+		if value, err = expandEnv(value); err != nil {
+			return err
+		}
+	}
+	return flag.Value.Set(value)
+}
+
+// expandEnv replaces ${NAME} and $NAME in s with the value of the
+// environment variable NAME, or nothing if it is not set. A NAME is made of
+// letters, digits and underscores. $$ stands for a single $, and a $ that
+// does not start a name is kept as is, so $5 and a trailing $ need no
+// escaping. An unterminated ${ is an error.
+//
+// expandEnv 将 s 中的 ${NAME} 和 $NAME 替换为环境变量 NAME 的值，如果没有设置则替换为空。
+// NAME 由字母、数字和下划线组成。$$ 表示单个 $，不是名称开头的 $ 会被保留，所以 $5 和末尾
+// 的 $ 不需要转义。未闭合的 ${ 是一个错误。
+//
+// NOTE: 和 os.Expand 不同，这里支持 $$ 转义，也不会展开 $5、$* 这样的 shell 特殊参数。
+func expandEnv(s string) (string, error) {
+	if strings.IndexByte(s, '$') < 0 {
+		return s, nil
+	}
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] != '$' || i+1 == len(s) {
+			b.WriteByte(s[i])
+			continue
+		}
+		switch c := s[i+1]; {
+		case c == '$':
+			b.WriteByte('$')
+			i++
+		case c == '{':
+			end := strings.IndexByte(s[i+2:], '}')
+			if end < 0 {
+				return "", errors.New("unterminated ${ in value")
+			}
+			b.WriteString(os.Getenv(s[i+2 : i+2+end]))
+			i += 2 + end
+		case isNameStart(c):
+			j := i + 2
+			for j < len(s) && (isNameStart(s[j]) || '0' <= s[j] && s[j] <= '9') {
+				j++
+			}
+			b.WriteString(os.Getenv(s[i+1 : j]))
+			i = j - 1
+		default:
+			b.WriteByte('$')
+		}
+	}
+	return b.String(), nil
+}
+
+// isNameStart reports whether c can start the name of an environment
+// variable in expandEnv.
+//
+// isNameStart 报告 c 是否可以作为 expandEnv 中环境变量名称的开头。
+func isNameStart(c byte) bool {
+	return c == '_' || 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z'
+}
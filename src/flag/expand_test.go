@@ -0,0 +1,57 @@
+// Copyright 2018 Li Zebang. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package flag_test
+
+import (
+	"bytes"
+	. "flag"
+	"os"
+	"testing"
+)
+
+func TestExpandEnv(t *testing.T) {
+	defer setenv(t, "FLAG_TEST_HOME", "/home/gopher")()
+	defer setenv(t, "FLAG_TEST_N", "3")()
+	os.Unsetenv("FLAG_TEST_UNSET")
+	tests := []struct {
+		mode ParseMode
+		args []string
+		dir  string
+		n    int
+		err  string
+	}{
+		{ExpandEnv, []string{"-dir", "${FLAG_TEST_HOME}/logs"}, "/home/gopher/logs", 0, ""},
+		{ExpandEnv, []string{"-dir=$FLAG_TEST_HOME/logs"}, "/home/gopher/logs", 0, ""},
+		{ExpandEnv, []string{"-dir", "$FLAG_TEST_HOME.d"}, "/home/gopher.d", 0, ""},
+		{ExpandEnv, []string{"-dir", "a$FLAG_TEST_UNSET.b"}, "a.b", 0, ""},
+		{ExpandEnv, []string{"-dir", "$$FLAG_TEST_HOME costs $5$"}, "$FLAG_TEST_HOME costs $5$", 0, ""},
+		{ExpandEnv, []string{"-n", "${FLAG_TEST_N}"}, "", 3, ""},
+		{ExpandEnv | CombinedShorts, []string{"-vd${FLAG_TEST_HOME}"}, "/home/gopher", 0, ""},
+		{ExpandEnv, []string{"-dir", "${FLAG_TEST_HOME"}, "", 0, `invalid value "${FLAG_TEST_HOME" for flag -dir: unterminated ${ in value`},
+		{0, []string{"-dir", "${FLAG_TEST_HOME}"}, "${FLAG_TEST_HOME}", 0, ""},
+	}
+	for _, tt := range tests {
+		fs := NewFlagSet("expand", ContinueOnError)
+		fs.SetOutput(new(bytes.Buffer))
+		fs.SetParseMode(tt.mode)
+		fs.Bool("v", false, "verbose")
+		dir := fs.StringP("dir", "d", "", "log directory")
+		n := fs.Int("n", 0, "count")
+		err := fs.Parse(tt.args)
+		if tt.err != "" {
+			if err == nil || err.Error() != tt.err {
+				t.Errorf("Parse(%q): %v; want %s", tt.args, err, tt.err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Parse(%q): %v", tt.args, err)
+			continue
+		}
+		if *dir != tt.dir || *n != tt.n {
+			t.Errorf("Parse(%q): dir, n = %q, %d; want %q, %d", tt.args, *dir, *n, tt.dir, tt.n)
+		}
+	}
+}
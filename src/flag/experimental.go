@@ -0,0 +1,139 @@
+// Copyright 2018 Li Zebang. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package flag
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// enableExperimental is the name of the flag that enables all the flags
+// marked with MarkExperimental.
+//
+// enableExperimental 是启用所有用 MarkExperimental 标记的标志的标志名称。
+const enableExperimental = "enable-experimental"
+
+// MarkExperimental ships the named flag dark: Parse reports it as not
+// defined, and usage messages leave it out, unless the environment
+// variable envGate is set to a non-empty value or the command line has
+// -enable-experimental, a hidden flag defined by the first call of
+// MarkExperimental. An empty envGate leaves only the flag as the gate. The
+// gate covers the command line and config files; the program itself can
+// still look up and set the flag. MarkExperimental panics if the flag is
+// not defined.
+//
+// MarkExperimental 使名为 name 的标志处于隐藏发布状态：Parse 将它报告为没有定义，用法信息
+// 也不会列出它，除非环境变量 envGate 被设置为非空值，或者命令行中有 -enable-experimental，
+// 这是第一次调用 MarkExperimental 时定义的一个隐藏标志。envGate 为空时只有这个标志可以开启
+// 它。这一限制作用于命令行和配置文件，程序本身仍然可以查找和设置该标志。如果标志没有定义，
+// MarkExperimental 会 panic。
+func (f *FlagSet) MarkExperimental(name, envGate string) {
+	flag, ok := f.formal[name]
+	if !ok {
+		msg := fmt.Sprintf("flag provided to MarkExperimental but not defined: -%s", name)
+		if f.name != "" {
+			msg = f.name + " " + msg
+		}
+		fmt.Fprintln(f.Output(), msg)
+		panic(msg)
+	}
+	f.markExperimental(flag, envGate)
+}
+
+// markExperimental gates flag behind envGate, and defines -enable-experimental
+// for the first experimental flag of f.
+//
+// markExperimental 使 flag 受 envGate 限制，并在 f 的第一个实验性标志被标记时定义
+// -enable-experimental。
+func (f *FlagSet) markExperimental(flag *Flag, envGate string) {
+	if f.experimental == nil {
+		f.experimental = make(map[*Flag]string)
+		if f.formal[enableExperimental] == nil {
+			f.Bool(enableExperimental, false, "enable experimental flags")
+			f.MarkHidden(enableExperimental)
+		}
+	}
+	f.experimental[flag] = envGate
+}
+
+// MarkExperimental ships the named command-line flag dark, unless the
+// environment variable envGate is set or -enable-experimental is given.
+//
+// MarkExperimental 使名为 name 的命令行标志处于隐藏发布状态，除非设置了环境变量 envGate
+// 或者给出了 -enable-experimental。
+func MarkExperimental(name, envGate string) {
+	CommandLine.MarkExperimental(name, envGate)
+}
+
+// gatedOut reports whether flag is experimental and its gate is closed.
+//
+// gatedOut 报告 flag 是否是实验性的并且它的开关是关闭的。
+func (f *FlagSet) gatedOut(flag *Flag) bool {
+	gate, ok := f.experimental[flag]
+	if !ok || f.experimentsOn {
+		return false
+	}
+	return gate == "" || os.Getenv(gate) == ""
+}
+
+// scanEnableExperimental reports whether the flags in arguments turn
+// -enable-experimental on. Parse looks for it ahead, so that it may come
+// after the flags it enables. The scan stops where Parse stops: at "--" and,
+// unless in Interspersed mode, at the first argument that is not a flag.
+// The value of a flag given as the next argument is skipped.
+//
+// scanEnableExperimental 报告 arguments 中的标志是否打开了 -enable-experimental。Parse
+// 会预先查找它，这样它可以出现在它启用的标志之后。查找在 Parse 停止的地方停止：在 "--" 处，
+// 以及除了 Interspersed 模式之外，在第一个不是标志的参数处。以下一个参数给出的标志的值会被
+// 跳过。
+//
+// IMP: 组合在一起的短标志（例如 -vl 3）不会被拆开，所以其中最后一个标志的值不会被跳过。
+func (f *FlagSet) scanEnableExperimental(arguments []string) bool {
+	on := false
+	for i := 0; i < len(arguments); i++ {
+		s := arguments[i]
+		if s == "--" {
+			break
+		}
+		if len(s) >= 2 && s[0] == '@' && f.mode&ResponseFiles != 0 {
+			continue
+		}
+		if len(s) < 2 || s[0] != '-' {
+			if f.mode&Interspersed != 0 {
+				continue
+			}
+			break
+		}
+		name := strings.TrimPrefix(s[1:], "-")
+		value, hasValue := "", false
+		if j := strings.IndexByte(name, '='); j > 0 {
+			name, value, hasValue = name[:j], name[j+1:], true
+		}
+		if name == enableExperimental {
+			on = true
+			if hasValue {
+				on, _ = strconv.ParseBool(value)
+			}
+			continue
+		}
+		flag := f.lookupName(name)
+		if flag == nil && len(name) == 1 {
+			flag = f.shorthands[name]
+		}
+		if flag == nil || hasValue || flag.NoOptDefVal != "" {
+			continue
+		}
+		if fv, ok := flag.Value.(boolFlag); ok && fv.IsBoolFlag() {
+			continue
+		}
+		// 和 hasValueArg 一样，PassthroughArgs 模式下的 "--" 不是值
+		if i+1 < len(arguments) && (f.mode&PassthroughArgs == 0 || arguments[i+1] != "--") { // as in hasValueArg, a "--" is not a value in PassthroughArgs mode
+			i++
+		}
+	}
+	return on
+}
@@ -0,0 +1,120 @@
+// Copyright 2018 Li Zebang. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package flag_test
+
+import (
+	"bytes"
+	. "flag"
+	"os"
+	"strings"
+	"testing"
+)
+
+func newExperimentalFlagSet(out *bytes.Buffer) (*FlagSet, *bool) {
+	fs := NewFlagSet("exp", ContinueOnError)
+	fs.SetOutput(out)
+	fs.SetParseMode(CombinedShorts)
+	fs.Bool("v", false, "verbose")
+	turbo := fs.BoolP("turbo", "t", false, "go faster")
+	fs.MarkExperimental("turbo", "FLAG_TEST_TURBO")
+	return fs, turbo
+}
+
+func TestMarkExperimental(t *testing.T) {
+	defer setenv(t, "FLAG_TEST_TURBO", "")()
+	os.Unsetenv("FLAG_TEST_TURBO")
+	tests := []struct {
+		env   string
+		args  []string
+		turbo bool
+		err   string
+	}{
+		{"", []string{"--turbo"}, false, "flag provided but not defined: -turbo"},
+		{"", []string{"-vt"}, false, "flag provided but not defined: -t"},
+		{"", []string{"-v"}, false, ""},
+		{"1", []string{"--turbo"}, true, ""},
+		{"", []string{"--turbo", "-enable-experimental"}, true, ""},
+		{"", []string{"--enable-experimental=true", "-vt"}, true, ""},
+		{"", []string{"-enable-experimental=false", "--turbo"}, false, "flag provided but not defined: -turbo"},
+		{"", []string{"--turbo", "--", "-enable-experimental"}, false, "flag provided but not defined: -turbo"},
+	}
+	for _, tt := range tests {
+		os.Setenv("FLAG_TEST_TURBO", tt.env)
+		fs, turbo := newExperimentalFlagSet(new(bytes.Buffer))
+		err := fs.Parse(tt.args)
+		got := ""
+		if err != nil {
+			got = err.Error()
+		}
+		if got != tt.err {
+			t.Errorf("FLAG_TEST_TURBO=%q Parse(%q) = %q; want %q", tt.env, tt.args, got, tt.err)
+		}
+		if *turbo != tt.turbo {
+			t.Errorf("FLAG_TEST_TURBO=%q Parse(%q): turbo = %v; want %v", tt.env, tt.args, *turbo, tt.turbo)
+		}
+	}
+}
+
+func TestMarkExperimentalScanStops(t *testing.T) {
+	defer setenv(t, "FLAG_TEST_TURBO", "")()
+	os.Unsetenv("FLAG_TEST_TURBO")
+	tests := []struct {
+		mode  ParseMode
+		args  []string
+		turbo bool
+		name  string
+		err   string
+	}{
+		{0, []string{"--turbo", "arg", "-enable-experimental"}, false, "", "flag provided but not defined: -turbo"},
+		{0, []string{"-name", "-enable-experimental", "--turbo"}, false, "-enable-experimental", "flag provided but not defined: -turbo"},
+		{0, []string{"-name=x", "-enable-experimental", "--turbo"}, true, "x", ""},
+		{Interspersed, []string{"arg", "-enable-experimental", "--turbo"}, true, "", ""},
+		{Interspersed, []string{"--turbo", "arg", "--", "-enable-experimental"}, false, "", "flag provided but not defined: -turbo"},
+	}
+	for _, tt := range tests {
+		fs := NewFlagSet("exp", ContinueOnError)
+		fs.SetOutput(new(bytes.Buffer))
+		fs.SetParseMode(tt.mode)
+		name := fs.String("name", "", "name")
+		turbo := fs.Bool("turbo", false, "go faster")
+		fs.MarkExperimental("turbo", "FLAG_TEST_TURBO")
+		err := fs.Parse(tt.args)
+		got := ""
+		if err != nil {
+			got = err.Error()
+		}
+		if got != tt.err || *turbo != tt.turbo || *name != tt.name {
+			t.Errorf("mode %v: Parse(%q) = %q, turbo %v, name %q; want %q, %v, %q", tt.mode, tt.args, got, *turbo, *name, tt.err, tt.turbo, tt.name)
+		}
+	}
+}
+
+func TestMarkExperimentalUsage(t *testing.T) {
+	defer setenv(t, "FLAG_TEST_TURBO", "")()
+	var buf bytes.Buffer
+	fs, _ := newExperimentalFlagSet(&buf)
+	fs.PrintDefaults()
+	if want := "  -v\tverbose\n"; buf.String() != want {
+		t.Errorf("PrintDefaults with the gate closed = %q; want %q", buf.String(), want)
+	}
+	buf.Reset()
+	os.Setenv("FLAG_TEST_TURBO", "on")
+	fs.PrintDefaults()
+	if !strings.Contains(buf.String(), "-t, --turbo") {
+		t.Errorf("PrintDefaults with the gate open = %q", buf.String())
+	}
+	if err := fs.Set("turbo", "true"); err != nil {
+		t.Errorf("Set: %v", err)
+	}
+}
+
+func TestMarkExperimentalConfig(t *testing.T) {
+	defer setenv(t, "FLAG_TEST_TURBO", "")()
+	fs, _ := newExperimentalFlagSet(new(bytes.Buffer))
+	err := fs.ParseConfigINI(strings.NewReader("turbo = true"))
+	if err == nil || err.Error() != "flag provided in INI config but not defined: -turbo" {
+		t.Errorf("ParseConfigINI: %v", err)
+	}
+}
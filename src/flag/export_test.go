@@ -10,6 +10,14 @@ import "os"
 
 var DefaultUsage = Usage
 
+var Colorable = colorable
+
+// IsExperimental reports whether f still gates flag; see MarkExperimental.
+func IsExperimental(f *FlagSet, flag *Flag) bool {
+	_, ok := f.experimental[flag]
+	return ok
+}
+
 // ResetForTesting clears all flag state and sets the usage function as directed.
 // After calling ResetForTesting, parse errors in flag handling will not
 // exit the program.
@@ -156,6 +156,8 @@ func (b *boolValue) Get() interface{} { return bool(*b) }
 
 func (b *boolValue) String() string { return strconv.FormatBool(bool(*b)) }
 
+func (b *boolValue) IsZero() bool { return !bool(*b) }
+
 func (b *boolValue) IsBoolFlag() bool { return true }
 
 // optional interface to indicate boolean flags that can be
@@ -185,6 +187,8 @@ func (i *intValue) Get() interface{} { return int(*i) }
 
 func (i *intValue) String() string { return strconv.Itoa(int(*i)) }
 
+func (i *intValue) IsZero() bool { return *i == 0 }
+
 // -- int64 Value
 type int64Value int64
 
@@ -203,6 +207,8 @@ func (i *int64Value) Get() interface{} { return int64(*i) }
 
 func (i *int64Value) String() string { return strconv.FormatInt(int64(*i), 10) }
 
+func (i *int64Value) IsZero() bool { return *i == 0 }
+
 // -- uint Value
 type uintValue uint
 
@@ -221,6 +227,8 @@ func (i *uintValue) Get() interface{} { return uint(*i) }
 
 func (i *uintValue) String() string { return strconv.FormatUint(uint64(*i), 10) }
 
+func (i *uintValue) IsZero() bool { return *i == 0 }
+
 // -- uint64 Value
 type uint64Value uint64
 
@@ -239,6 +247,8 @@ func (i *uint64Value) Get() interface{} { return uint64(*i) }
 
 func (i *uint64Value) String() string { return strconv.FormatUint(uint64(*i), 10) }
 
+func (i *uint64Value) IsZero() bool { return *i == 0 }
+
 // -- string Value
 type stringValue string
 
@@ -256,6 +266,8 @@ func (s *stringValue) Get() interface{} { return string(*s) }
 
 func (s *stringValue) String() string { return string(*s) }
 
+func (s *stringValue) IsZero() bool { return *s == "" }
+
 // -- float64 Value
 type float64Value float64
 
@@ -274,6 +286,8 @@ func (f *float64Value) Get() interface{} { return float64(*f) }
 
 func (f *float64Value) String() string { return strconv.FormatFloat(float64(*f), 'g', -1, 64) }
 
+func (f *float64Value) IsZero() bool { return *f == 0 }
+
 // -- time.Duration Value
 type durationValue time.Duration
 
@@ -292,6 +306,8 @@ func (d *durationValue) Get() interface{} { return time.Duration(*d) }
 
 func (d *durationValue) String() string { return (*time.Duration)(d).String() }
 
+func (d *durationValue) IsZero() bool { return *d == 0 }
+
 // Value is the interface to the dynamic value stored in a flag.
 // (The default value is represented as a string.)
 //
@@ -303,6 +319,10 @@ func (d *durationValue) String() string { return (*time.Duration)(d).String() }
 // The flag package may call the String method with a zero-valued receiver,
 // such as a nil pointer.
 //
+// If a Value has an IsZero() bool method, PrintDefaults uses it to decide
+// whether the default value is the zero value of its type, and so should
+// not be shown, instead of building a zero Value to compare with.
+//
 // Value 是操作存储在标志中动态值的接口。（默认值表示为字符串）
 //
 // 如果 Value 的 IsBoolFlag() bool 方法返回 true，命令行解析器将使 -name 等效于 -name=true，
@@ -310,6 +330,9 @@ func (d *durationValue) String() string { return (*time.Duration)(d).String() }
 //
 // 对于每个存在的标志，以命令行的顺序调用一次 Set。
 // flag 包可以使用零值接受者（例如空指针）调用 String 方法。
+//
+// 如果 Value 有 IsZero() bool 方法，PrintDefaults 会用它判断默认值是否是其类型的零值，
+// 从而是否不显示默认值，而不是构造一个零值的 Value 来比较。
 type Value interface {
 	String() string
 	Set(string) error
@@ -342,6 +365,10 @@ const (
 	ExitOnError // Call os.Exit(2).
 	// 使用描述性错误调用 panic。
 	PanicOnError // Call panic with a descriptive error.
+	// 越过错误继续解析，返回列出所有错误的 ParseErrors。
+	CollectAllErrors // Parse on past errors; return a ParseErrors listing them all.
+	// 越过错误继续解析，只将它们打印出来，返回 nil。
+	WarnOnError // Parse on past errors, only printing them; return nil.
 )
 
 // A FlagSet represents a set of defined flags. The zero value of a FlagSet
@@ -365,9 +392,69 @@ type FlagSet struct {
 	parsed bool
 	actual map[string]*Flag
 	formal map[string]*Flag
+	// 按名称排序的 formal，定义或者取消定义标志时置为 nil，参见 sortedFormal
+	sorted []*Flag // formal sorted by name, reset when a flag is defined or undefined; see sortedFormal
+	// 按照定义的顺序排列的 formal 中的标志，参见 VisitAllDeclared
+	order []*Flag // the flags of formal in definition order; see VisitAllDeclared
+	// PrintDefaults 是否按照定义的顺序列出标志，参见 SetSortFlags
+	unsorted bool // whether PrintDefaults and VisitAll list flags in definition order; see SetSortFlags
+	// 以缩写为键，值与 formal 中的相同
+	shorthands map[string]*Flag // keyed by shorthand, same values as formal
+	// 以别名为键，值与 formal 中的相同，参见 Alias
+	aliases map[string]*Flag // keyed by alias, same values as formal; see Alias
 	// 标志后的参数（更多请看 111 行注释）
 	args          []string // arguments after flags
 	errorHandling ErrorHandling
+	// 解析 f.args 的方式，参见 SetParseMode
+	mode ParseMode // how f.args are parsed; see SetParseMode
+	// Interspersed 模式下 Parse 时暂存的位置参数
+	positional []string // positional arguments set aside by Parse in Interspersed mode
+	// 当前 Parse 读取的响应文件数量
+	responseFiles int // response files read by the current Parse
+	// 没有绑定环境变量的标志使用的环境变量前缀，参见 SetEnvPrefix
+	envPrefix string // env prefix for flags without Env; see SetEnvPrefix
+	// ParseEnv 期间代替进程环境变量的环境变量
+	environ map[string]string // replaces the process environment during ParseEnv
+	// 按照优先级从高到低排列的值来源，参见 AddSource
+	sources []valueSource // value sources by decreasing priority; see AddSource
+	// 必须同时被设置的各组标志，参见 MarkRequiredTogether
+	together [][]string // groups of flags that must be set together; see MarkRequiredTogether
+	// 之后定义的标志所属的分组，参见 Group
+	group string // group of the flags defined next; see Group
+	// 替代默认用法信息的模板，参见 SetUsageTemplate
+	usageTemplate UsageTemplate // replaces the default usage message; see SetUsageTemplate
+	// 用法信息是否使用颜色，参见 SetColor
+	color ColorMode // whether usage messages use colors; see SetColor
+	// 错误和用法信息的翻译，参见 SetLocale
+	messages messages // translations of error and usage messages; see SetLocale
+	// 声明的位置参数，参见 Positional
+	positionals []positionalArg // declared positional arguments; see Positional
+	// 标志之后接受的参数数量，nil 表示不检查，参见 ExpectArgs
+	arity *arity // number of arguments accepted after the flags, or nil; see ExpectArgs
+	// 用 Dynamic 注册的各族标志
+	dynamics []*dynamicFlag // families of flags registered with Dynamic
+	// 实验性的标志以及开启它们的环境变量，参见 MarkExperimental
+	experimental map[*Flag]string // experimental flags and their env gates; see MarkExperimental
+	// 当前 Parse 的命令行是否给出了 -enable-experimental
+	experimentsOn bool // whether the command line of the current Parse has -enable-experimental
+	// 名称和别名是否不区分大小写匹配，参见 SetCaseInsensitive
+	foldCase bool // whether names and aliases match regardless of case; see SetCaseInsensitive
+	// 剩余参数中 "--" 之前的参数个数，没有 "--" 则为 -1，参见 ArgsLenAtDash
+	argsLenAtDash int // number of remaining arguments before "--", or -1; see ArgsLenAtDash
+	// PassthroughArgs 模式下 "--" 之后的参数，参见 Passthrough
+	passthrough []string // arguments after "--" in PassthroughArgs mode; see Passthrough
+	// 名称冲突时决定如何处理的函数，参见 SetConflictResolver
+	resolver func(existing, flag *Flag) ConflictPolicy // settles clashes of names; see SetConflictResolver
+	// 各个标志的监视函数，参见 Watch
+	watchers map[*Flag][]func(old, new string) // watchers of each flag; see Watch
+	// Execute 解析的参数，nil 表示 os.Args[1:]，参见 SetArgs
+	stagedArgs []string // arguments Execute parses, or nil for os.Args[1:]; see SetArgs
+	// Parse 之前运行的钩子，参见 OnPreParse
+	preParse []func([]string) []string // hooks run before Parse; see OnPreParse
+	// Parse 之后运行的钩子，参见 OnPostParse
+	postParse []func() error // hooks run after Parse; see OnPostParse
+	// 每设置一个标志就调用一次，参见 SetReporter
+	reporter func(*Flag, string) // called for each flag set; see SetReporter
 	// nil 意味着是 stderr，使用 out() 访问器
 	output io.Writer // nil means stderr; use out() accessor
 }
@@ -383,26 +470,53 @@ type Flag struct {
 	Value Value // value as set
 	// 默认值（为文本），提供给帮助信息使用
 	DefValue string // default value (as text); for usage message
+	// 单个字母的缩写，没有则为空，参见 VarP
+	Shorthand string // one-letter abbreviation, or empty; see VarP
+	// 命令行中没有设置该标志时读取的环境变量，参见 BindEnv
+	Env string // environment variable read if the flag is not set; see BindEnv
+	// 是否在帮助信息中隐藏该标志，参见 MarkHidden
+	Hidden bool // omitted from usage messages; see MarkHidden
+	// 该标志的其他名称，参见 Alias
+	Aliases []string // other names of the flag; see Alias
+	// 用法信息中列出该标志的分组，没有则为空，参见 Group
+	Group string // group the flag is listed under in usage messages, or empty; see Group
+	// 标志出现时没有值所使用的值，没有则为空，参见 SetNoOptDefVal
+	NoOptDefVal string // value used when the flag is given without one, or empty; see SetNoOptDefVal
+	// shell 补全的提示，没有则为 nil，参见 SetCompletion
+	Completion *Completion // hints for shell completion, or nil; see SetCompletion
+	// PrintDefaults 中代替内置格式的用法文本，没有则为 nil，参见 SetUsageFunc
+	UsageFunc func(*Flag) string // replaces the built-in layout in PrintDefaults, or nil; see SetUsageFunc
+	// 是否在输出中用 *** 代替该标志的值，参见 MarkSecret
+	Secret bool // whether the value is shown as *** in output; see MarkSecret
 }
 
 // sortFlags returns the flags as a slice in lexicographical sorted order.
 //
 // sortFlags 返回字典序排列的标志切片。
 func sortFlags(flags map[string]*Flag) []*Flag {
-	list := make(sort.StringSlice, len(flags))
-	i := 0
+	result := make([]*Flag, 0, len(flags))
 	for _, f := range flags {
-		list[i] = f.Name
-		i++
-	}
-	list.Sort()
-	result := make([]*Flag, len(list))
-	for i, name := range list {
-		result[i] = flags[name]
+		result = append(result, f)
 	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Name < result[j].Name })
 	return result
 }
 
+// sortedFormal returns the defined flags sorted by name. The slice is
+// cached until a flag is defined or undefined, so it must not be modified.
+//
+// sortedFormal 返回按名称排序的已定义标志。这个切片会被缓存，直到有标志被定义或者取消定义，
+// 所以不能修改它。
+//
+// IMP: 在 VisitAll 的回调中定义或取消定义标志只会丢弃缓存，正在遍历的旧切片不受影响，这和
+// 每次都重新排序时的行为相同。
+func (f *FlagSet) sortedFormal() []*Flag {
+	if f.sorted == nil && len(f.formal) > 0 {
+		f.sorted = sortFlags(f.formal)
+	}
+	return f.sorted
+}
+
 // Output returns the destination for usage and error messages. os.Stderr is returned if
 // output was not set or was set to nil.
 //
@@ -436,12 +550,18 @@ func (f *FlagSet) SetOutput(output io.Writer) {
 	f.output = output
 }
 
-// VisitAll visits the flags in lexicographical order, calling fn for each.
-// It visits all flags, even those not set.
+// VisitAll visits the flags in lexicographical order, or in the order they
+// were defined after SetSortFlags(false), calling fn for each. It visits
+// all flags, even those not set.
 //
-// VisitAll 以字典序访问标志，并为每个标志调用 fn。它会访问所有标志，即使用户未设置它。
+// VisitAll 以字典序访问标志，在 SetSortFlags(false) 之后则按照标志被定义的顺序访问，并为
+// 每个标志调用 fn。它会访问所有标志，即使用户未设置它。
 func (f *FlagSet) VisitAll(fn func(*Flag)) {
-	for _, flag := range sortFlags(f.formal) {
+	flags := f.sortedFormal()
+	if f.unsorted {
+		flags = f.order
+	}
+	for _, flag := range flags {
 		fn(flag)
 	}
 }
@@ -454,11 +574,21 @@ func VisitAll(fn func(*Flag)) {
 	CommandLine.VisitAll(fn)
 }
 
-// Visit visits the flags in lexicographical order, calling fn for each.
-// It visits only those flags that have been set.
+// Visit visits the flags in lexicographical order, or in the order they
+// were defined after SetSortFlags(false), calling fn for each. It visits
+// only those flags that have been set.
 //
-// Visit 以字典序访问标志，并为每个标志调用 fn。它只访问被用户设置过的标志。
+// Visit 以字典序访问标志，在 SetSortFlags(false) 之后则按照标志被定义的顺序访问，并为每个
+// 标志调用 fn。它只访问被用户设置过的标志。
 func (f *FlagSet) Visit(fn func(*Flag)) {
+	if f.unsorted {
+		for _, flag := range f.order {
+			if f.actual[flag.Name] == flag {
+				fn(flag)
+			}
+		}
+		return
+	}
 	for _, flag := range sortFlags(f.actual) {
 		fn(flag)
 	}
@@ -472,11 +602,49 @@ func Visit(fn func(*Flag)) {
 	CommandLine.Visit(fn)
 }
 
+// Flags returns the defined flags, in the order VisitAll visits them. The
+// slice is a copy, so the caller may keep or modify it, but the Flags it
+// points to are those of f.
+//
+// Flags 按照 VisitAll 访问的顺序返回已定义的标志。这个切片是一份拷贝，所以调用者可以保留
+// 或修改它，但其中的 Flag 就是 f 中的 Flag。
+func (f *FlagSet) Flags() []*Flag {
+	flags := make([]*Flag, 0, len(f.formal))
+	f.VisitAll(func(flag *Flag) { flags = append(flags, flag) })
+	return flags
+}
+
+// Flags returns the defined command-line flags, in the order VisitAll
+// visits them.
+//
+// Flags 按照 VisitAll 访问的顺序返回已定义的命令行标志。
+func Flags() []*Flag {
+	return CommandLine.Flags()
+}
+
+// SetFlags returns the flags that have been set, in the order Visit visits
+// them. Like Flags, it returns a copy of the slice.
+//
+// SetFlags 按照 Visit 访问的顺序返回已被设置的标志。和 Flags 一样，它返回切片的一份拷贝。
+func (f *FlagSet) SetFlags() []*Flag {
+	flags := make([]*Flag, 0, len(f.actual))
+	f.Visit(func(flag *Flag) { flags = append(flags, flag) })
+	return flags
+}
+
+// SetFlags returns the command-line flags that have been set, in the order
+// Visit visits them.
+//
+// SetFlags 按照 Visit 访问的顺序返回已被设置的命令行标志。
+func SetFlags() []*Flag {
+	return CommandLine.SetFlags()
+}
+
 // Lookup returns the Flag structure of the named flag, returning nil if none exists.
 //
 // Lookup 返回 name 标志对应到 Flag 结构体，如果不存在返回 nil。
 func (f *FlagSet) Lookup(name string) *Flag {
-	return f.formal[name]
+	return f.lookupName(name)
 }
 
 // Lookup returns the Flag structure of the named command-line flag,
@@ -484,25 +652,31 @@ func (f *FlagSet) Lookup(name string) *Flag {
 //
 // Lookup 返回命令行标志中 name 标志对应到 Flag 结构体，如果不存在返回 nil。
 func Lookup(name string) *Flag {
-	return CommandLine.formal[name]
+	return CommandLine.lookupName(name)
 }
 
 // Set sets the value of the named flag.
 //
 // Set 设置 name 标志的值。
 func (f *FlagSet) Set(name, value string) error {
-	flag, ok := f.formal[name]
-	if !ok {
+	flag := f.lookupName(name)
+	if flag == nil {
 		return fmt.Errorf("no such flag -%v", name)
 	}
+	var old string
+	if f.watchers[flag] != nil {
+		old = flag.Value.String()
+	}
 	err := flag.Value.Set(value)
 	if err != nil {
 		return err
 	}
 	if f.actual == nil {
-		f.actual = make(map[string]*Flag)
+		f.actual = make(map[string]*Flag, len(f.formal))
 	}
-	f.actual[name] = flag
+	f.actual[flag.Name] = flag
+	f.trace(flag, "Set")
+	f.notify(flag, old)
 	return nil
 }
 
@@ -525,7 +699,16 @@ func isZeroValue(flag *Flag, value string) bool {
 	// 构造一个标志 Value 类型的零值，并检验用其 String 方法得到的结果和传入的 value 是否相等。
 	// 除非 Value 类型本身是一个接口类型，否则此方法有效。
 	// TSK:// IMP:
-	typ := reflect.TypeOf(flag.Value)
+	v := flag.Value
+	// 适配器的零值不包含任何值，所以使用被包装的值的类型
+	if w, ok := v.(interface{ unwrap() Value }); ok { // the zero value of an adapter wraps nothing; use the wrapped type
+		v = w.unwrap()
+	}
+	// IsZero 报告的是当前值，所以只有当前值仍是 value 时才能使用它
+	if z, ok := v.(interface{ IsZero() bool }); ok && v.String() == value { // IsZero reports on the current value, which must still be value
+		return z.IsZero()
+	}
+	typ := reflect.TypeOf(v)
 	var z reflect.Value
 	if typ.Kind() == reflect.Ptr {
 		z = reflect.New(typ.Elem())
@@ -572,14 +755,35 @@ func UnquoteUsage(flag *Flag) (name string, usage string) {
 		name = ""
 	case *durationValue:
 		name = "duration"
-	case *float64Value:
+	case *timeValue:
+		name = "time"
+	case *bytesValue:
+		name = "bytes"
+	case *pathValue:
+		name = "file"
+		if flag.Value.(*pathValue).dir {
+			name = "dir"
+		}
+	case *float64Value, *float32Value:
 		name = "float"
-	case *intValue, *int64Value:
+	case *intValue, *int64Value, *int8Value, *int16Value, *int32Value:
 		name = "int"
 	case *stringValue:
 		name = "string"
-	case *uintValue, *uint64Value:
+	case *stringSliceValue:
+		name = "strings"
+	case *intSliceValue, *int64SliceValue:
+		name = "ints"
+	case *float64SliceValue:
+		name = "floats"
+	case *durationSliceValue:
+		name = "durations"
+	case *stringToStringValue, *stringToIntValue:
+		name = "key=value"
+	case *uintValue, *uint64Value, *uint8Value, *uint16Value, *uint32Value:
 		name = "uint"
+	case TypedValue:
+		name = pflagUsageName(flag.Value.(TypedValue).Type())
 	}
 	return
 }
@@ -591,20 +795,41 @@ func UnquoteUsage(flag *Flag) (name string, usage string) {
 // PrintDefaults 打印集合中所有已定义的命令行标志的默认值到标志错误输出，除非另有配置。
 // 更多信息请查看全局函数 PrintDefaults 的文档。
 func (f *FlagSet) PrintDefaults() {
-	f.VisitAll(func(flag *Flag) {
+	c := f.colorizer()
+	// 各族动态标志列在不属于任何分组的标志之后，这里记录它们是否已经打印
+	dynamics := false // dynamic flags follow the flags outside any group
+	f.visitGroups(func(group string) {
+		if !dynamics {
+			f.printDynamics(c)
+			dynamics = true
+		}
+		fmt.Fprintf(f.Output(), "\n%s\n", c(colorBold, group+":"))
+	}, func(flag *Flag) {
+		if flag.UsageFunc != nil {
+			s := flag.UsageFunc(flag)
+			if !strings.HasSuffix(s, "\n") {
+				s += "\n"
+			}
+			fmt.Fprint(f.Output(), s)
+			return
+		}
+		names := strings.Join(usageNames(flag), ", ")
 		// 前面有两个空格，看下面两条注释
-		s := fmt.Sprintf("  -%s", flag.Name) // Two spaces before -; see next two comments.
+		s := "  " + c(colorBold, names) // Two spaces before -; see next two comments.
 		name, usage := UnquoteUsage(flag)
 		if len(name) > 0 {
-			s += " " + name
+			s += " " + c(colorType, name)
+		}
+		if flag.NoOptDefVal != "" {
+			s += "[=" + flag.NoOptDefVal + "]"
 		}
 		// Boolean flags of one ASCII letter are so common we
 		// treat them specially, putting their usage on the same line.
 		//
 		// 单个 ASCII 码字母的 bool 型标志是如此常见。我们特殊对待此类标志，将它们的
 		// 用法信息在同一行输出。
-		// 看上一条注释可以知道格式为，空格、空格、'-'、字母。
-		if len(s) <= 4 { // space, space, '-', 'x'.
+		// 看上一条注释可以知道格式为，空格、空格、'-'、字母，不计颜色。
+		if len(names) <= 2 && len(name) == 0 { // space, space, '-', 'x', without colors.
 			s += "\t"
 		} else {
 			// Four spaces before the tab triggers good alignment
@@ -615,18 +840,17 @@ func (f *FlagSet) PrintDefaults() {
 		}
 		s += strings.Replace(usage, "\n", "\n    \t", -1)
 
-		if !isZeroValue(flag, flag.DefValue) {
-			if _, ok := flag.Value.(*stringValue); ok {
-				// put quotes on the value
-				//
-				// 值中存在引号
-				s += fmt.Sprintf(" (default %q)", flag.DefValue)
-			} else {
-				s += fmt.Sprintf(" (default %v)", flag.DefValue)
-			}
+		if def, ok := defaultText(flag); ok {
+			s += " " + c(colorDim, "(default "+def+")")
+		}
+		for _, note := range f.bindings(flag) {
+			s += " " + c(colorDim, note)
 		}
 		fmt.Fprint(f.Output(), s, "\n")
 	})
+	if !dynamics {
+		f.printDynamics(c)
+	}
 }
 
 // PrintDefaults prints, to standard error unless configured otherwise,
@@ -670,12 +894,17 @@ func PrintDefaults() {
 //
 // defaultUsage 是打印用法信息的默认方法。
 func (f *FlagSet) defaultUsage() {
+	if f.usageTemplate != nil {
+		f.executeUsageTemplate()
+		return
+	}
 	if f.name == "" {
-		fmt.Fprintf(f.Output(), "Usage:\n")
+		fmt.Fprint(f.Output(), f.messages.get("Usage:\n"))
 	} else {
-		fmt.Fprintf(f.Output(), "Usage of %s:\n", f.name)
+		fmt.Fprintf(f.Output(), f.messages.get("Usage of %s:\n"), f.name)
 	}
 	f.PrintDefaults()
+	f.printPositionals()
 }
 
 // NOTE: Usage is not just defaultUsage(CommandLine)
@@ -688,6 +917,8 @@ func (f *FlagSet) defaultUsage() {
 // The function is a variable that may be changed to point to a custom function.
 // By default it prints a simple header and calls PrintDefaults; for details about the
 // format of the output and how to control it, see the documentation for PrintDefaults.
+// The positional arguments declared with Positional are listed after the flags.
+// A template set by SetUsageTemplate replaces the default message.
 // Custom usage functions may choose to exit the program; by default exiting
 // happens anyway as the command line's error handling strategy is set to
 // ExitOnError.
@@ -698,10 +929,16 @@ func (f *FlagSet) defaultUsage() {
 // Usage 打印用法信息到 CommandLine.output（默认为 os.Stderr），它包含了所有命令行定义的标志。当解析
 // 标志出现错误时会调用此函数。此函数是一个变量，由此它可以被改变指向一个自定义的函数。默认情况下，它会打印
 // 一个简单的标题并调用 PrintDefaults。有关输出格式及其控制方法的详细信息，请看 PrintDefaults 的文档。
+// 用 Positional 声明的位置参数列在标志之后。SetUsageTemplate 设置的模板会代替默认的信息。
 // 自定义的 usage 函数可以选择退出程序。默认情况下会退出程序，因为命令行的错误处理策略为 ExitOnError。
 var Usage = func() {
-	fmt.Fprintf(CommandLine.Output(), "Usage of %s:\n", os.Args[0])
+	if CommandLine.usageTemplate != nil {
+		CommandLine.executeUsageTemplate()
+		return
+	}
+	fmt.Fprintf(CommandLine.Output(), CommandLine.messages.get("Usage of %s:\n"), os.Args[0])
 	PrintDefaults()
+	CommandLine.printPositionals()
 }
 
 // NFlag returns the number of flags that have been set.
@@ -962,26 +1199,43 @@ func Duration(name string, value time.Duration, usage string) *time.Duration {
 // 自定义的 Value 实现，类型为 Value。例如，调用者可以创建一个标志，通过给切片提供 Value 的方法，
 // 将逗号分隔的字符串转化成字符串切片。尤其是 Set 能将逗号分隔的字符串分解成切片。
 func (f *FlagSet) Var(value Value, name string, usage string) {
+	if _, err := f.define(value, name, usage); err != nil {
+		msg := err.Error()
+		fmt.Fprintln(f.Output(), msg)
+		// 仅在使用相同的名称声明标志时才会发生
+		panic(msg) // Happens only if flags are declared with identical names
+	}
+}
+
+// define defines the flag for Var and VarE, settling a clash of names with
+// the resolver of SetConflictResolver. It returns the new flag, or nil if
+// the existing one is kept.
+//
+// define 为 Var 和 VarE 定义标志，并使用 SetConflictResolver 的解决函数处理名称冲突。它返回
+// 新的标志，如果保留已有的标志则返回 nil。
+func (f *FlagSet) define(value Value, name string, usage string) (*Flag, error) {
 	// Remember the default value as a string; it won't change.
 	//
 	// 记住默认值是一个字符串，它不会改变。
-	flag := &Flag{name, usage, value, value.String()}
-	_, alreadythere := f.formal[name]
-	if alreadythere {
-		var msg string
-		if f.name == "" {
-			msg = fmt.Sprintf("flag redefined: %s", name)
-		} else {
-			msg = fmt.Sprintf("%s flag redefined: %s", f.name, name)
+	flag := &Flag{Name: name, Usage: usage, Value: value, DefValue: value.String(), Group: f.group}
+	if existing := f.conflicting(name); existing != nil {
+		policy := f.resolve(existing, flag)
+		if policy == ConflictSkip {
+			return nil, nil
 		}
-		fmt.Fprintln(f.Output(), msg)
-		// 仅在使用相同的名称声明标志时才会发生
-		panic(msg) // Happens only if flags are declared with identical names
+		prefixed := f.name + "." + name
+		if policy != ConflictPrefix || f.name == "" || f.conflicting(prefixed) != nil {
+			return nil, &ErrRedefined{Name: name, Existing: existing, set: f.name}
+		}
+		flag.Name = prefixed
 	}
 	if f.formal == nil {
 		f.formal = make(map[string]*Flag)
 	}
-	f.formal[name] = flag
+	f.formal[flag.Name] = flag
+	f.order = append(f.order, flag)
+	f.sorted = nil
+	return flag, nil
 }
 
 // Var defines a flag with the specified name and usage string. The type and
@@ -1003,12 +1257,63 @@ func Var(value Value, name string, usage string) {
 //
 // failf 打印格式化的错误和用法信息到输出，并返回错误。
 func (f *FlagSet) failf(format string, a ...interface{}) error {
-	err := fmt.Errorf(format, a...)
+	return f.fail(fmt.Errorf(f.messages.get(format), a...))
+}
+
+// fail prints to standard error err and a usage message and returns err.
+//
+// fail 打印 err 和用法信息到输出，并返回 err。
+func (f *FlagSet) fail(err error) error {
+	f.localize(err)
+	if e, ok := err.(*ErrInvalidValue); ok {
+		flag := f.lookupName(e.Name)
+		// -p hunter2 和 -phunter2 中的 e.Name 是缩写
+		if flag == nil { // e.Name is the shorthand in -p hunter2 and -phunter2
+			flag = f.lookupShort(e.Name)
+		}
+		if flag != nil && flag.Secret {
+			e.redact()
+		}
+	}
 	fmt.Fprintln(f.Output(), err)
-	f.usage()
+	// CollectAllErrors 模式下在所有错误之后只打印一次用法信息，WarnOnError 模式下不打印
+	if !f.collecting() { // CollectAllErrors prints usage once, after all errors, and WarnOnError never
+		f.usage()
+	}
 	return err
 }
 
+// failw is like failf, but the returned error also wraps err, so that
+// the error returned by a Value's Set method can be recovered with
+// errors.Is, errors.As or errors.Unwrap.
+//
+// failw 类似于 failf，但返回的错误还包装了 err，这样就可以通过 errors.Is、errors.As 或
+// errors.Unwrap 取回 Value 的 Set 方法返回的错误。
+func (f *FlagSet) failw(err error, format string, a ...interface{}) error {
+	return f.fail(&wrapError{msg: fmt.Sprintf(f.messages.get(format), a...), err: err})
+}
+
+// trace reports a flag that has been set, and where its value came from,
+// to the diag Logger.
+//
+// trace 将一个被设置的标志以及它的值的来源报告给 diag 的 Logger。
+func (f *FlagSet) trace(flag *Flag, source string) {
+	if diagEnabled(diagDebug) {
+		diagLog(diagDebug, "flag set", "set", f.name, "flag", flag.Name, "value", shownValue(flag, flag.Value.String()), "source", source)
+	}
+}
+
+// wrapError is an error with a formatted message that wraps another error.
+//
+// wrapError 是一个带有格式化信息并包装了另一个错误的错误。
+type wrapError struct {
+	msg string
+	err error
+}
+
+func (e *wrapError) Error() string { return e.msg }
+func (e *wrapError) Unwrap() error { return e.err }
+
 // usage calls the Usage method for the flag set if one is specified,
 // or the appropriate default usage function otherwise.
 //
@@ -1022,16 +1327,21 @@ func (f *FlagSet) usage() {
 	}
 }
 
-// parseOne parses one flag. It reports whether a flag was seen.
+// parseOne parses one flag. It reports whether a flag was seen, or, in
+// Interspersed mode, a positional argument was set aside.
 //
-// parseOne 解析一个标志。它还返回是否找到标志。
+// parseOne 解析一个标志。它还返回是否找到标志，或者在 Interspersed 模式下是否暂存了一个
+// 位置参数。
 func (f *FlagSet) parseOne() (bool, error) {
 	if len(f.args) == 0 {
 		return false, nil
 	}
 	s := f.args[0]
+	if len(s) >= 2 && s[0] == '@' && f.mode&ResponseFiles != 0 {
+		return f.expandResponseFile()
+	}
 	if len(s) < 2 || s[0] != '-' {
-		return false, nil
+		return f.setAsidePositional(), nil
 	}
 	numMinuses := 1
 	if s[1] == '-' {
@@ -1039,6 +1349,11 @@ func (f *FlagSet) parseOne() (bool, error) {
 		// "--" 终止标志
 		if len(s) == 2 { // "--" terminates the flags
 			f.args = f.args[1:]
+			if f.mode&PassthroughArgs != 0 {
+				f.passthrough, f.args = f.args, []string{}
+			}
+			// 暂存的位置参数会被放回 "--" 之后的参数前面
+			f.argsLenAtDash = len(f.positional) // the positional arguments set aside go back in front
 			return false, nil
 		}
 	}
@@ -1062,33 +1377,64 @@ func (f *FlagSet) parseOne() (bool, error) {
 			break
 		}
 	}
-	m := f.formal
-	flag, alreadythere := m[name] // BUG
+	flag := f.lookupName(name)
+	alreadythere := flag != nil
+	if !alreadythere && len(name) == 1 {
+		// 单个字母可能是某个标志的缩写
+		flag, alreadythere = f.shorthands[name] // one letter may be a shorthand
+	}
+	if !alreadythere {
+		if flag = f.lookupNegated(name); flag != nil {
+			if hasValue {
+				return false, f.failf("flag -%s does not take a value", name)
+			}
+			// -no-name 等价于 -name=false
+			hasValue, value, alreadythere = true, "false", true // -no-name is -name=false
+		}
+	}
+	if alreadythere && f.gatedOut(flag) {
+		flag, alreadythere = nil, false
+	}
 	if !alreadythere {
 		// 特殊情况：打印帮助信息
 		if name == "help" || name == "h" { // special case for nice help message.
 			f.usage()
 			return false, ErrHelp
 		}
-		return false, f.failf("flag provided but not defined: -%s", name)
+		if d := f.lookupDynamic(name); d != nil {
+			return f.parseDynamic(d, name, value, hasValue)
+		}
+		if f.mode&CombinedShorts != 0 && numMinuses == 1 && len(name) > 1 {
+			// 可能是组合在一起的多个短标志
+			return f.parseCombined(s[1:]) // may be several short flags run together
+		}
+		return false, f.fail(&ErrUndefinedFlag{Name: name})
 	}
 
 	// 特殊情况：不需要参数
 	if fv, ok := flag.Value.(boolFlag); ok && fv.IsBoolFlag() { // special case: doesn't need an arg
 		if hasValue {
 			if err := fv.Set(value); err != nil {
-				return false, f.failf("invalid boolean value %q for -%s: %v", value, name, err)
+				return false, f.fail(&ErrInvalidValue{Name: name, Value: value, Err: err,
+					msg: fmt.Sprintf(f.messages.get("invalid boolean value %q for -%s: %v"), value, name, err)})
 			}
 		} else {
-			if err := fv.Set("true"); err != nil {
-				return false, f.failf("invalid boolean flag %s: %v", name, err)
+			value = "true"
+			if err := fv.Set(value); err != nil {
+				return false, f.fail(&ErrInvalidValue{Name: name, Value: value, Err: err,
+					msg: fmt.Sprintf(f.messages.get("invalid boolean flag %s: %v"), name, err)})
 			}
 		}
 	} else {
 		// It must have a value, which might be the next argument.
 		//
 		// 它必须有一个值，值可能是下一个参数。
-		if !hasValue && len(f.args) > 0 {
+		if !hasValue && flag.NoOptDefVal != "" {
+			// 值是可选的，只能以 -flag=value 的形式给出
+			hasValue = true // the value is optional and only given as -flag=value
+			value = flag.NoOptDefVal
+		}
+		if !hasValue && f.hasValueArg() {
 			// value is the next arg
 			//
 			// 值是下一个参数
@@ -1096,16 +1442,18 @@ func (f *FlagSet) parseOne() (bool, error) {
 			value, f.args = f.args[0], f.args[1:]
 		}
 		if !hasValue {
-			return false, f.failf("flag needs an argument: -%s", name)
+			return false, f.fail(&ErrMissingArgument{Name: name})
 		}
-		if err := flag.Value.Set(value); err != nil {
-			return false, f.failf("invalid value %q for flag -%s: %v", value, name, err)
+		if err := f.setValue(flag, value); err != nil {
+			return false, f.fail(&ErrInvalidValue{Name: name, Value: value, Err: err})
 		}
 	}
 	if f.actual == nil {
-		f.actual = make(map[string]*Flag)
+		f.actual = make(map[string]*Flag, len(f.formal))
 	}
-	f.actual[name] = flag
+	f.actual[flag.Name] = flag
+	f.trace(flag, "command line")
+	f.report(flag, value)
 	return true, nil
 }
 
@@ -1119,12 +1467,38 @@ func (f *FlagSet) parseOne() (bool, error) {
 // 为 ErrHelp。
 func (f *FlagSet) Parse(arguments []string) error {
 	f.parsed = true
+	arguments = f.runPreParse(arguments)
 	f.args = arguments
+	f.positional = nil
+	f.argsLenAtDash = -1
+	f.passthrough = nil
+	f.responseFiles = 0
+	f.experimentsOn = f.experimental != nil && f.scanEnableExperimental(arguments)
+	if f.collecting() {
+		return f.parseAll()
+	}
 	for {
 		seen, err := f.parseOne()
 		if seen {
 			continue
 		}
+		f.restorePositional()
+		if err == nil {
+			// 命令行中没有设置的标志从环境变量和值来源中读取
+			err = f.parseSources() // flags not set on the command line are read from the environment and sources
+		}
+		if err == nil {
+			err = f.checkRequiredTogether()
+		}
+		if err == nil {
+			err = f.checkPositionals()
+		}
+		if err == nil {
+			err = f.checkArity()
+		}
+		if err == nil {
+			err = f.runPostParse()
+		}
 		if err == nil {
 			break
 		}
@@ -1197,6 +1571,7 @@ func NewFlagSet(name string, errorHandling ErrorHandling) *FlagSet {
 	f := &FlagSet{
 		name:          name,
 		errorHandling: errorHandling,
+		argsLenAtDash: -1,
 	}
 	f.Usage = f.defaultUsage
 	return f
@@ -1211,4 +1586,5 @@ func NewFlagSet(name string, errorHandling ErrorHandling) *FlagSet {
 func (f *FlagSet) Init(name string, errorHandling ErrorHandling) {
 	f.name = name
 	f.errorHandling = errorHandling
+	f.argsLenAtDash = -1
 }
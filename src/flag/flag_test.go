@@ -6,6 +6,7 @@ package flag_test
 
 import (
 	"bytes"
+	"errors"
 	. "flag"
 	"fmt"
 	"io"
@@ -429,6 +430,110 @@ func TestPrintDefaults(t *testing.T) {
 	}
 }
 
+// embeddedValue is a Value that only wraps another, so its zero value
+// cannot be built by reflection; IsZero lets PrintDefaults do without.
+type embeddedValue struct{ Value }
+
+func (v embeddedValue) IsZero() bool { return v.String() == "" || v.String() == "0" }
+
+func TestPrintDefaultsIsZero(t *testing.T) {
+	fs := NewFlagSet("is zero", ContinueOnError)
+	var buf bytes.Buffer
+	fs.SetOutput(&buf)
+	fs.Int("a-inner", 0, "")
+	fs.Int("b-inner", 7, "")
+	fs.Var(embeddedValue{fs.Lookup("a-inner").Value}, "a", "zero")
+	fs.Var(embeddedValue{fs.Lookup("b-inner").Value}, "b", "seven")
+	fs.PrintDefaults()
+	want := "  -a value\n    \tzero\n" +
+		"  -a-inner int\n    \t\n" +
+		"  -b value\n    \tseven (default 7)\n" +
+		"  -b-inner int\n    \t (default 7)\n"
+	if got := buf.String(); got != want {
+		t.Errorf("got %q want %q", got, want)
+	}
+}
+
+func TestVisitAllAfterChanges(t *testing.T) {
+	fs := NewFlagSet("visit", ContinueOnError)
+	fs.Int("b", 0, "")
+	fs.Int("c", 0, "")
+	names := func() string {
+		var s []string
+		fs.VisitAll(func(f *Flag) { s = append(s, f.Name) })
+		return strings.Join(s, " ")
+	}
+	if got := names(); got != "b c" {
+		t.Errorf("VisitAll = %q", got)
+	}
+	fs.Int("a", 0, "")
+	if got := names(); got != "a b c" {
+		t.Errorf("VisitAll after Int = %q", got)
+	}
+	fs.Undefine("b")
+	if got := names(); got != "a c" {
+		t.Errorf("VisitAll after Undefine = %q", got)
+	}
+	other := NewFlagSet("other", ContinueOnError)
+	other.Int("d", 0, "")
+	fs.AddFlagSet(other, ConflictError)
+	if got := names(); got != "a c d" {
+		t.Errorf("VisitAll after AddFlagSet = %q", got)
+	}
+}
+
+func TestFlagsAndSetFlags(t *testing.T) {
+	fs := NewFlagSet("flags", ContinueOnError)
+	fs.Int("b", 0, "")
+	fs.Int("a", 0, "")
+	fs.Int("c", 0, "")
+	if err := fs.Parse([]string{"-c", "1", "-b", "2"}); err != nil {
+		t.Fatal(err)
+	}
+	names := func(flags []*Flag) string {
+		var s []string
+		for _, f := range flags {
+			s = append(s, f.Name)
+		}
+		return strings.Join(s, " ")
+	}
+	all := fs.Flags()
+	if got := names(all); got != "a b c" {
+		t.Errorf("Flags() = %s", got)
+	}
+	if got := names(fs.SetFlags()); got != "b c" {
+		t.Errorf("SetFlags() = %s", got)
+	}
+	all[0] = nil
+	if got := names(fs.Flags()); got != "a b c" {
+		t.Errorf("Flags() after modifying a previous result = %s", got)
+	}
+	if all[1] != fs.Lookup("b") {
+		t.Error("Flags() does not point to the flags of the set")
+	}
+	fs.SetSortFlags(false)
+	if got := names(fs.SetFlags()); got != "b c" {
+		t.Errorf("unsorted SetFlags() = %s", got)
+	}
+	if got := names(fs.Flags()); got != "b a c" {
+		t.Errorf("unsorted Flags() = %s", got)
+	}
+	if flags := NewFlagSet("empty", ContinueOnError).SetFlags(); flags == nil || len(flags) != 0 {
+		t.Errorf("SetFlags() of an empty set = %#v", flags)
+	}
+}
+
+func BenchmarkVisitAll(b *testing.B) {
+	fs := NewFlagSet("bench", ContinueOnError)
+	for i := 0; i < 100; i++ {
+		fs.Int(fmt.Sprintf("flag%03d", i), 0, "")
+	}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		fs.VisitAll(func(*Flag) {})
+	}
+}
+
 // Issue 19230: validate range of Int and Uint flag values.
 func TestIntFlagOverflow(t *testing.T) {
 	if strconv.IntSize != 32 {
@@ -459,6 +564,43 @@ func TestUsageOutput(t *testing.T) {
 	}
 }
 
+var errBadValue = errors.New("bad value")
+
+type failingValue struct{ boolFlag bool }
+
+func (v *failingValue) String() string   { return "" }
+func (v *failingValue) Set(string) error { return errBadValue }
+func (v *failingValue) IsBoolFlag() bool { return v.boolFlag }
+
+func TestParseErrorWrapsSetError(t *testing.T) {
+	tests := []struct {
+		args     []string
+		boolFlag bool
+		want     string
+	}{
+		{[]string{"-v=x"}, false, `invalid value "x" for flag -v: bad value`},
+		{[]string{"-v", "x"}, false, `invalid value "x" for flag -v: bad value`},
+		{[]string{"-v=x"}, true, `invalid boolean value "x" for -v: bad value`},
+		{[]string{"-v"}, true, `invalid boolean flag v: bad value`},
+	}
+	for _, tt := range tests {
+		fs := NewFlagSet("test", ContinueOnError)
+		fs.SetOutput(new(bytes.Buffer))
+		fs.Var(&failingValue{tt.boolFlag}, "v", "")
+		err := fs.Parse(tt.args)
+		if err == nil {
+			t.Errorf("Parse(%q): expected error", tt.args)
+			continue
+		}
+		if err.Error() != tt.want {
+			t.Errorf("Parse(%q): error %q; want %q", tt.args, err, tt.want)
+		}
+		if !errors.Is(err, errBadValue) {
+			t.Errorf("Parse(%q): errors.Is(%v, errBadValue) = false", tt.args, err)
+		}
+	}
+}
+
 func TestGetters(t *testing.T) {
 	expectedName := "flag set"
 	expectedErrorHandling := ContinueOnError
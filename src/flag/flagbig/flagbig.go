@@ -0,0 +1,131 @@
+// Copyright 2018 Li Zebang. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+// Package flagbig provides flag.Values that hold a big.Int or a big.Float:
+//
+//	var amount big.Int
+//	fs.Var(flagbig.NewInt(&amount, big.NewInt(1000)), "amount", "amount")
+//
+// It is kept out of package flag, which testing imports, so that programs
+// using flag do not link math/big unless they ask for it.
+//
+// flagbig 包提供了保存 big.Int 或 big.Float 的 flag.Value：
+//
+//	var amount big.Int
+//	fs.Var(flagbig.NewInt(&amount, big.NewInt(1000)), "amount", "amount")
+//
+// 它没有放在 flag 包中，因为 testing 导入了 flag，这样使用 flag 的程序只有在需要时才会链接
+// math/big。
+package flagbig
+
+import (
+	"math/big"
+	"strconv"
+)
+
+// An Int is a flag.Value that stores a big.Int.
+//
+// Int 是一个存储 big.Int 的 flag.Value。
+type Int struct {
+	p *big.Int
+}
+
+// NewInt returns an Int that stores the value in p, which is first set to
+// value, or to 0 if value is nil. Values may have any number of digits and
+// a 0x or 0 prefix for hexadecimal or octal.
+//
+// NewInt 返回一个将值存储在 p 中的 Int，p 首先被设置为 value，value 为 nil 时为 0。值可以
+// 有任意多位数字，并可以带有表示十六进制或八进制的 0x 或 0 前缀。
+func NewInt(p *big.Int, value *big.Int) *Int {
+	if value != nil {
+		p.Set(value)
+	} else {
+		p.SetInt64(0)
+	}
+	return &Int{p}
+}
+
+// Set parses s as an integer.
+//
+// Set 将 s 解析为一个整数。
+func (b *Int) Set(s string) error {
+	// 和 strconv.ParseInt 一样，基数 0 接受 0x 和 0 前缀
+	v, ok := new(big.Int).SetString(s, 0) // base 0 accepts 0x and 0 prefixes, like strconv.ParseInt
+	if !ok {
+		return strconv.ErrSyntax
+	}
+	b.p.Set(v)
+	return nil
+}
+
+// Get returns the value as a *big.Int.
+//
+// Get 以 *big.Int 的形式返回值。
+func (b *Int) Get() interface{} { return b.p }
+
+func (b *Int) String() string {
+	if b.p == nil {
+		return "0"
+	}
+	return b.p.String()
+}
+
+// Type returns "bigInt", which usage messages show as int.
+//
+// Type 返回 "bigInt"，用法信息将它显示为 int。
+func (b *Int) Type() string { return "bigInt" }
+
+// A Float is a flag.Value that stores a big.Float.
+//
+// Float 是一个存储 big.Float 的 flag.Value。
+type Float struct {
+	p *big.Float
+}
+
+// NewFloat returns a Float that stores the value in p, which is first set
+// to value, or to 0 if value is nil. Values are parsed with the precision
+// of value, or with 64 bits if value is nil.
+//
+// NewFloat 返回一个将值存储在 p 中的 Float，p 首先被设置为 value，value 为 nil 时为 0。
+// 值以 value 的精度解析，value 为 nil 时以 64 位精度解析。
+func NewFloat(p *big.Float, value *big.Float) *Float {
+	if value != nil {
+		p.Set(value)
+	} else {
+		// 精度为 0 时 SetInt64 使用 64 位精度
+		p.SetInt64(0) // SetInt64 uses 64 bits of precision if there is none
+	}
+	return &Float{p}
+}
+
+// Set parses s as a floating-point number.
+//
+// Set 将 s 解析为一个浮点数。
+func (b *Float) Set(s string) error {
+	// 以变量的精度解析，精度为 0 时 SetString 使用 64 位精度
+	v, ok := new(big.Float).SetPrec(b.p.Prec()).SetString(s) // the variable's precision, or 64 bits
+	if !ok {
+		return strconv.ErrSyntax
+	}
+	b.p.Set(v)
+	return nil
+}
+
+// Get returns the value as a *big.Float.
+//
+// Get 以 *big.Float 的形式返回值。
+func (b *Float) Get() interface{} { return b.p }
+
+func (b *Float) String() string {
+	if b.p == nil {
+		return "0"
+	}
+	// 能够精确还原值的最短表示
+	return b.p.Text('g', -1) // the shortest text that gives back the value
+}
+
+// Type returns "bigFloat", which usage messages show as float.
+//
+// Type 返回 "bigFloat"，用法信息将它显示为 float。
+func (b *Float) Type() string { return "bigFloat" }
@@ -0,0 +1,92 @@
+// Copyright 2018 Li Zebang. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package flagbig_test
+
+import (
+	"bytes"
+	. "flag"
+	"flag/flagbig"
+	"math/big"
+	"strings"
+	"testing"
+)
+
+func TestBigInt(t *testing.T) {
+	tests := []struct {
+		arg  string
+		want string
+		err  bool
+	}{
+		{"123456789012345678901234567890", "123456789012345678901234567890", false},
+		{"-42", "-42", false},
+		{"0x10", "16", false},
+		{"010", "8", false},
+		{"1.5", "7", true},
+		{"", "7", true},
+	}
+	for _, tt := range tests {
+		fs := NewFlagSet("big", ContinueOnError)
+		fs.SetOutput(new(bytes.Buffer))
+		n := new(big.Int)
+		fs.Var(flagbig.NewInt(n, big.NewInt(7)), "n", "amount")
+		err := fs.Parse([]string{"-n", tt.arg})
+		if (err != nil) != tt.err {
+			t.Errorf("Parse(-n %q): err = %v", tt.arg, err)
+		}
+		if n.String() != tt.want {
+			t.Errorf("Parse(-n %q): n = %s; want %s", tt.arg, n, tt.want)
+		}
+	}
+}
+
+func TestBigFloat(t *testing.T) {
+	fs := NewFlagSet("big", ContinueOnError)
+	fs.SetOutput(new(bytes.Buffer))
+	def := new(big.Float).SetPrec(200).SetInt64(1)
+	var x, y big.Float
+	fs.Var(flagbig.NewFloat(&x, def), "x", "amount")
+	fs.Var(flagbig.NewFloat(&y, nil), "y", "amount")
+	if err := fs.Parse([]string{"-x", "0.1", "-y", "2.5e-3"}); err != nil {
+		t.Fatal(err)
+	}
+	want, _ := new(big.Float).SetPrec(200).SetString("0.1")
+	if x.Prec() != 200 || x.Cmp(want) != 0 {
+		t.Errorf("x = %s with precision %d", x.Text('g', 60), x.Prec())
+	}
+	if y.Prec() != 64 || fs.Lookup("y").Value.String() != "0.0025" {
+		t.Errorf("y = %s with precision %d", fs.Lookup("y").Value, y.Prec())
+	}
+	if err := fs.Set("y", "abc"); err == nil {
+		t.Error("Set(y, abc) succeeded")
+	}
+	if def.Cmp(big.NewFloat(1)) != 0 {
+		t.Errorf("default changed to %s", def)
+	}
+}
+
+func TestBigPrintDefaults(t *testing.T) {
+	fs := NewFlagSet("big", ContinueOnError)
+	var buf bytes.Buffer
+	fs.SetOutput(&buf)
+	fs.Var(flagbig.NewInt(new(big.Int), nil), "n", "amount")
+	fs.Var(flagbig.NewInt(new(big.Int), big.NewInt(1000)), "m", "amount")
+	fs.Var(flagbig.NewFloat(new(big.Float), big.NewFloat(0)), "x", "ratio")
+	fs.Var(flagbig.NewFloat(new(big.Float), big.NewFloat(0.5)), "y", "ratio")
+	fs.PrintDefaults()
+	want := strings.Join([]string{
+		"  -m int",
+		"    \tamount (default 1000)",
+		"  -n int",
+		"    \tamount",
+		"  -x float",
+		"    \tratio",
+		"  -y float",
+		"    \tratio (default 0.5)",
+		"",
+	}, "\n")
+	if buf.String() != want {
+		t.Errorf("PrintDefaults:\n%s\nwant:\n%s", buf.String(), want)
+	}
+}
@@ -0,0 +1,101 @@
+// Copyright 2018 Li Zebang. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+// Package flagbytes provides flag.Values that hold a []byte given in
+// hexadecimal or in base64:
+//
+//	var salt []byte
+//	fs.Var(flagbytes.NewHex(&salt, nil), "salt", "salt")
+//
+// It is kept out of package flag, which testing imports, so that programs
+// using flag do not link encoding/hex unless they ask for it.
+//
+// flagbytes 包提供了保存以十六进制或 base64 给出的 []byte 的 flag.Value：
+//
+//	var salt []byte
+//	fs.Var(flagbytes.NewHex(&salt, nil), "salt", "salt")
+//
+// 它没有放在 flag 包中，因为 testing 导入了 flag，这样使用 flag 的程序只有在需要时才会链接
+// encoding/hex。
+package flagbytes
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+)
+
+// A Value is a flag.Value that stores a []byte in an encoding.
+//
+// Value 是一个存储以某种编码给出的 []byte 的 flag.Value。
+type Value struct {
+	p   *[]byte
+	hex bool
+}
+
+// NewHex returns a Value that stores the bytes in p, which is first set to
+// a copy of value. The bytes are given in hexadecimal, such as 00ff10, and
+// usage messages show the default in hexadecimal too.
+//
+// NewHex 返回一个将字节存储在 p 中的 Value，p 首先被设置为 value 的副本。字节以十六进制
+// 给出，例如 00ff10，用法信息也以十六进制显示默认值。
+func NewHex(p *[]byte, value []byte) *Value {
+	*p = append([]byte(nil), value...)
+	return &Value{p: p, hex: true}
+}
+
+// NewBase64 returns a Value that stores the bytes in p, which is first set
+// to a copy of value. The bytes are given in standard, padded base64 as
+// defined in RFC 4648, and usage messages show the default in base64 too.
+//
+// NewBase64 返回一个将字节存储在 p 中的 Value，p 首先被设置为 value 的副本。字节以
+// RFC 4648 定义的带填充的标准 base64 给出，用法信息也以 base64 显示默认值。
+func NewBase64(p *[]byte, value []byte) *Value {
+	*p = append([]byte(nil), value...)
+	return &Value{p: p}
+}
+
+// Set decodes s.
+//
+// Set 解码 s。
+func (b *Value) Set(s string) error {
+	var v []byte
+	var err error
+	if b.hex {
+		v, err = hex.DecodeString(s)
+	} else {
+		v, err = base64.StdEncoding.DecodeString(s)
+	}
+	if err != nil {
+		return err
+	}
+	*b.p = v
+	return nil
+}
+
+// Get returns the bytes as a []byte.
+//
+// Get 以 []byte 的形式返回字节。
+func (b *Value) Get() interface{} { return *b.p }
+
+func (b *Value) String() string {
+	if b.p == nil {
+		return ""
+	}
+	if b.hex {
+		return hex.EncodeToString(*b.p)
+	}
+	return base64.StdEncoding.EncodeToString(*b.p)
+}
+
+// Type returns "bytesHex" or "bytesBase64", the names pflag uses, which
+// usage messages show as hex or base64.
+//
+// Type 返回 pflag 使用的名称 "bytesHex" 或 "bytesBase64"，用法信息将它们显示为 hex 或
+// base64。
+func (b *Value) Type() string {
+	if b.hex {
+		return "bytesHex"
+	}
+	return "bytesBase64"
+}
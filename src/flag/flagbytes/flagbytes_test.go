@@ -0,0 +1,74 @@
+// Copyright 2018 Li Zebang. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package flagbytes_test
+
+import (
+	"bytes"
+	. "flag"
+	"flag/flagbytes"
+	"strings"
+	"testing"
+)
+
+func TestBytesHexAndBase64(t *testing.T) {
+	tests := []struct {
+		hex  bool
+		arg  string
+		want string
+		err  bool
+	}{
+		{true, "00ff10", "\x00\xff\x10", false},
+		{true, "00FF10", "\x00\xff\x10", false},
+		{true, "", "", false},
+		{true, "0f0", "salt", true},
+		{true, "zz", "salt", true},
+		{false, "AP8Q", "\x00\xff\x10", false},
+		{false, "c2FsdA==", "salt", false},
+		{false, "c2FsdA", "salt", true},
+		{false, "!!!!", "salt", true},
+	}
+	for _, tt := range tests {
+		fs := NewFlagSet("bytes", ContinueOnError)
+		fs.SetOutput(new(bytes.Buffer))
+		def := []byte("salt")
+		p := new([]byte)
+		if tt.hex {
+			fs.Var(flagbytes.NewHex(p, def), "key", "key")
+		} else {
+			fs.Var(flagbytes.NewBase64(p, def), "key", "key")
+		}
+		def[0] = 'S'
+		err := fs.Parse([]string{"-key=" + tt.arg})
+		if (err != nil) != tt.err {
+			t.Errorf("Parse(-key=%s) with hex %v: err = %v", tt.arg, tt.hex, err)
+		}
+		if string(*p) != tt.want {
+			t.Errorf("Parse(-key=%s) with hex %v: key = %q; want %q", tt.arg, tt.hex, *p, tt.want)
+		}
+	}
+}
+
+func TestBytesHexAndBase64PrintDefaults(t *testing.T) {
+	fs := NewFlagSet("bytes", ContinueOnError)
+	var buf bytes.Buffer
+	fs.SetOutput(&buf)
+	var salt, token, key []byte
+	fs.Var(flagbytes.NewHex(&salt, []byte{0xca, 0xfe}), "salt", "salt")
+	fs.Var(flagbytes.NewBase64(&token, []byte("hi")), "token", "token")
+	fs.Var(flagbytes.NewHex(&key, nil), "key", "key")
+	fs.PrintDefaults()
+	want := strings.Join([]string{
+		"  -key hex",
+		"    \tkey",
+		"  -salt hex",
+		"    \tsalt (default cafe)",
+		"  -token base64",
+		"    \ttoken (default aGk=)",
+		"",
+	}, "\n")
+	if buf.String() != want {
+		t.Errorf("PrintDefaults:\n%s\nwant:\n%s", buf.String(), want)
+	}
+}
@@ -0,0 +1,103 @@
+// Copyright 2018 Li Zebang. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+// Package flagjson reads config files written as JSON for
+// flag.FlagSet.ParseConfig:
+//
+//	err := fs.ParseConfig(file, "JSON", flagjson.Decode)
+//
+// It is kept out of package flag, which testing imports, so that flag does
+// not depend on encoding/json.
+//
+// flagjson 包为 flag.FlagSet.ParseConfig 读取用 JSON 写成的配置文件。
+//
+// 它没有放在 flag 包中，因为 testing 导入了 flag，这样 flag 就不依赖 encoding/json。
+package flagjson
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// Decode reads a JSON object keyed by flag name from r. Values may be
+// strings, numbers or booleans, and are given to the flag's Set method as
+// they appear in the document. An array calls Set once for each of its
+// elements, and a nested object names the flags under it with its key and
+// a dot, so {"log": {"level": 2}} sets -log.level. A null or an empty
+// array leaves the flag alone.
+//
+// Decode 从 r 中读取一个以标志名称为键的 JSON 对象。值可以是字符串、数字或者布尔值，它们
+// 按照在文档中的样子传给标志的 Set 方法。数组会对它的每一个元素调用一次 Set，嵌套的对象
+// 则用它的键和一个点来命名其中的标志，所以 {"log": {"level": 2}} 设置的是 -log.level。
+// null 或者空数组不会改变标志。
+func Decode(r io.Reader) (map[string][]string, error) {
+	dec := json.NewDecoder(r)
+	dec.UseNumber()
+	var doc map[string]interface{}
+	if err := dec.Decode(&doc); err != nil {
+		return nil, err
+	}
+	values := make(map[string][]string)
+	if err := flatten("", doc, values); err != nil {
+		return nil, err
+	}
+	return values, nil
+}
+
+// flatten adds the values in v to values, keyed by flag name.
+//
+// flatten 将 v 中的值以标志名称为键加入 values。
+func flatten(name string, v interface{}, values map[string][]string) error {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for k, elem := range v {
+			if name != "" {
+				k = name + "." + k
+			}
+			if err := flatten(k, elem, values); err != nil {
+				return err
+			}
+		}
+		return nil
+	case []interface{}:
+		var list []string
+		for _, elem := range v {
+			s, ok := scalar(elem)
+			if !ok {
+				return fmt.Errorf("key %s: array elements must be strings, numbers or booleans", strconv.Quote(name))
+			}
+			list = append(list, s)
+		}
+		if len(list) > 0 {
+			values[name] = list
+		}
+		return nil
+	case nil:
+		return nil
+	}
+	s, ok := scalar(v)
+	if !ok {
+		return fmt.Errorf("key %s: unsupported value", strconv.Quote(name))
+	}
+	values[name] = []string{s}
+	return nil
+}
+
+// scalar returns the text of a string, number or boolean decoded with
+// UseNumber.
+//
+// scalar 返回用 UseNumber 解码得到的字符串、数字或布尔值的文本。
+func scalar(v interface{}) (string, bool) {
+	switch v := v.(type) {
+	case string:
+		return v, true
+	case json.Number:
+		return v.String(), true
+	case bool:
+		return strconv.FormatBool(v), true
+	}
+	return "", false
+}
@@ -0,0 +1,90 @@
+// Copyright 2018 Li Zebang. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package flagjson_test
+
+import (
+	"bytes"
+	. "flag"
+	"flag/flagjson"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+// listValue collects every value it is set to.
+type listValue []string
+
+func (l *listValue) String() string     { return fmt.Sprint(*l) }
+func (l *listValue) Set(s string) error { *l = append(*l, s); return nil }
+
+func TestDecode(t *testing.T) {
+	fs := NewFlagSet("config", ContinueOnError)
+	v := fs.Bool("v", false, "verbose")
+	n := fs.Int("n", 0, "count")
+	name := fs.String("name", "", "name")
+	rate := fs.Float64("rate", 0, "rate")
+	d := fs.Duration("timeout", 0, "timeout")
+	level := fs.Int("log.level", 0, "level")
+	keep := fs.String("keep", "default", "left alone")
+	var tags listValue
+	fs.Var(&tags, "tag", "tags")
+
+	const doc = `{
+		"v": true,
+		"n": 7,
+		"name": "from-config",
+		"rate": 1.5,
+		"timeout": "2s",
+		"log": {"level": 3},
+		"keep": null,
+		"tag": ["a", "b", 3]
+	}`
+	if err := fs.Parse([]string{"-name", "from-flag"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := fs.ParseConfig(strings.NewReader(doc), "JSON", flagjson.Decode); err != nil {
+		t.Fatal(err)
+	}
+	if !*v || *n != 7 || *rate != 1.5 || *d != 2*time.Second || *level != 3 {
+		t.Errorf("got v=%v n=%d rate=%v timeout=%v log.level=%d", *v, *n, *rate, *d, *level)
+	}
+	if *name != "from-flag" {
+		t.Errorf("name = %q; the command line should win", *name)
+	}
+	if *keep != "default" {
+		t.Errorf("keep = %q; null should leave the flag alone", *keep)
+	}
+	if fmt.Sprint(tags) != "[a b 3]" {
+		t.Errorf("tag = %v; want [a b 3]", tags)
+	}
+	if fs.NFlag() != 7 {
+		t.Errorf("NFlag() = %d; want 7", fs.NFlag())
+	}
+}
+
+func TestDecodeErrors(t *testing.T) {
+	tests := []struct {
+		doc  string
+		want string
+	}{
+		{`{"n": "x"}`, `invalid value "x" in JSON config for flag -n: strconv.ParseInt`},
+		{`{"missing": 1}`, `flag provided in JSON config but not defined: -missing`},
+		{`{"n": [[1]]}`, `invalid JSON config: key "n": array elements must be strings, numbers or booleans`},
+		{`{"n": {"a": [1, {}]}}`, `invalid JSON config: key "n.a": array elements must be strings, numbers or booleans`},
+		{`[1]`, `invalid JSON config: json: cannot unmarshal array`},
+		{`{"n": `, `invalid JSON config: unexpected EOF`},
+	}
+	for _, tt := range tests {
+		fs := NewFlagSet("config", ContinueOnError)
+		var out bytes.Buffer
+		fs.SetOutput(&out)
+		fs.Int("n", 0, "count")
+		err := fs.ParseConfig(strings.NewReader(tt.doc), "JSON", flagjson.Decode)
+		if err == nil || !strings.HasPrefix(err.Error(), tt.want) {
+			t.Errorf("ParseConfig(%q) = %v; want prefix %q", tt.doc, err, tt.want)
+		}
+	}
+}
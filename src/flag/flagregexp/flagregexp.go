@@ -0,0 +1,86 @@
+// Copyright 2018 Li Zebang. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+// Package flagregexp provides a string flag.Value that only accepts
+// values matching a regular expression:
+//
+//	var region string
+//	fs.Var(flagregexp.New(&region, `[a-z]+-[a-z]+-\d`, "us-west-1"), "region", "region")
+//
+// It is kept out of package flag, which testing imports, so that programs
+// using flag do not link regexp unless they ask for it.
+//
+// flagregexp 包提供了一个只接受匹配正则表达式的值的字符串 flag.Value：
+//
+//	var region string
+//	fs.Var(flagregexp.New(&region, `[a-z]+-[a-z]+-\d`, "us-west-1"), "region", "region")
+//
+// 它没有放在 flag 包中，因为 testing 导入了 flag，这样使用 flag 的程序只有在需要时才会链接
+// regexp。
+package flagregexp
+
+import (
+	"errors"
+	"regexp"
+	"strconv"
+)
+
+// A Value is a flag.Value that stores a string matching a regular
+// expression.
+//
+// Value 是一个存储匹配正则表达式的字符串的 flag.Value。
+type Value struct {
+	p       *string
+	re      *regexp.Regexp
+	pattern string
+}
+
+// New returns a Value that stores the string in p, which is first set to
+// value, like the values of StringVar, but whose Set rejects a value
+// unless the whole of it matches the regular expression pattern. The
+// default value is not checked. New panics if pattern does not compile.
+//
+// New 返回一个将字符串存储在 p 中的 Value，p 首先被设置为 value，它和 StringVar 的值
+// 一样，但 Set 会拒绝不能被正则表达式 pattern 完整匹配的值。默认值不会被检查。如果 pattern
+// 无法编译，New 会 panic。
+//
+// IMP: 模式只在创建 Value 时编译一次，并且被包在 ^(?:...)$ 中以匹配整个值。
+func New(p *string, pattern, value string) *Value {
+	re, err := regexp.Compile(`^(?:` + pattern + `)$`)
+	if err != nil {
+		panic("flagregexp: New(" + strconv.Quote(pattern) + "): " + err.Error())
+	}
+	*p = value
+	return &Value{p: p, re: re, pattern: pattern}
+}
+
+// Set sets the string to val if the whole of it matches the pattern.
+//
+// Set 在 val 被模式完整匹配时将字符串设置为 val。
+func (s *Value) Set(val string) error {
+	if !s.re.MatchString(val) {
+		return errors.New(strconv.Quote(val) + " does not match " + s.pattern)
+	}
+	*s.p = val
+	return nil
+}
+
+// Get returns the string.
+//
+// Get 返回字符串。
+func (s *Value) Get() interface{} { return *s.p }
+
+func (s *Value) String() string {
+	if s.p == nil {
+		return ""
+	}
+	return *s.p
+}
+
+// Type returns "string", so that usage messages show the argument of the
+// flag and quote its default value as they do for StringVar.
+//
+// Type 返回 "string"，这样用法信息会像 StringVar 一样显示标志的参数并为它的默认值加上
+// 引号。
+func (s *Value) Type() string { return "string" }
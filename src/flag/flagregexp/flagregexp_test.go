@@ -0,0 +1,78 @@
+// Copyright 2018 Li Zebang. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package flagregexp_test
+
+import (
+	"bytes"
+	. "flag"
+	"flag/flagregexp"
+	"strings"
+	"testing"
+)
+
+func TestStringRegexp(t *testing.T) {
+	tests := []struct {
+		arg string
+		ok  bool
+	}{
+		{"us-east-1", true},
+		{"eu-west-2", true},
+		{"1us-east-1", false},
+		{"us-east-1x", false},
+		{"", false},
+	}
+	for _, tt := range tests {
+		fs := NewFlagSet("regexp", ContinueOnError)
+		fs.SetOutput(new(bytes.Buffer))
+		region := new(string)
+		fs.Var(flagregexp.New(region, `[a-z]+-[a-z]+-\d`, "us-west-1"), "region", "region")
+		err := fs.Parse([]string{"-region", tt.arg})
+		switch {
+		case tt.ok && (err != nil || *region != tt.arg):
+			t.Errorf("Parse(%q): region = %q, err = %v", tt.arg, *region, err)
+		case !tt.ok && err == nil:
+			t.Errorf("Parse(%q) succeeded", tt.arg)
+		case !tt.ok && *region != "us-west-1":
+			t.Errorf("Parse(%q) changed region to %q", tt.arg, *region)
+		}
+	}
+}
+
+func TestStringRegexpError(t *testing.T) {
+	fs := NewFlagSet("regexp", ContinueOnError)
+	fs.SetOutput(new(bytes.Buffer))
+	var name string
+	fs.Var(flagregexp.New(&name, `[a-z]+|[0-9]+`, ""), "name", "name")
+	if err := fs.Parse([]string{"-name", "abc"}); err != nil || name != "abc" {
+		t.Errorf("Parse(abc): name = %q, err = %v", name, err)
+	}
+	err := fs.Parse([]string{"-name", "abc123"})
+	want := `invalid value "abc123" for flag -name: "abc123" does not match [a-z]+|[0-9]+`
+	if err == nil || err.Error() != want {
+		t.Errorf("Parse error = %v; want %s", err, want)
+	}
+}
+
+func TestStringRegexpInvalidPattern(t *testing.T) {
+	defer func() {
+		r, _ := recover().(string)
+		if !strings.HasPrefix(r, `flagregexp: New("("): error parsing regexp`) {
+			t.Errorf("panic = %q", r)
+		}
+	}()
+	flagregexp.New(new(string), "(", "")
+}
+
+func TestStringRegexpPrintDefaults(t *testing.T) {
+	fs := NewFlagSet("regexp", ContinueOnError)
+	var buf bytes.Buffer
+	fs.SetOutput(&buf)
+	fs.Var(flagregexp.New(new(string), `[a-z-]+`, "us-west"), "region", "region")
+	fs.PrintDefaults()
+	want := "  -region string\n    \tregion (default \"us-west\")\n"
+	if buf.String() != want {
+		t.Errorf("PrintDefaults:\n%q\nwant:\n%q", buf.String(), want)
+	}
+}
@@ -0,0 +1,95 @@
+// Copyright 2018 Li Zebang. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+// Package flagurl provides a flag.Value that holds a URL, optionally
+// restricted to some schemes:
+//
+//	var endpoint url.URL
+//	fs.Var(flagurl.New(&endpoint, nil, "http", "https"), "endpoint", "server `url`")
+//
+// It is kept out of package flag, which testing imports, so that programs
+// using flag do not link net/url unless they ask for it.
+//
+// flagurl 包提供了一个保存 URL 的 flag.Value，它可以只接受某些协议：
+//
+//	var endpoint url.URL
+//	fs.Var(flagurl.New(&endpoint, nil, "http", "https"), "endpoint", "server `url`")
+//
+// 它没有放在 flag 包中，因为 testing 导入了 flag，这样使用 flag 的程序只有在需要时才会链接
+// net/url。
+package flagurl
+
+import (
+	"errors"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// A Value is a flag.Value that stores a URL accepted by url.Parse.
+//
+// Value 是一个存储能被 url.Parse 接受的 URL 的 flag.Value。
+type Value struct {
+	p       *url.URL
+	schemes []string
+}
+
+// New returns a Value that stores the URL in p, which is first set to a
+// copy of value, or left empty if value is nil. If schemes are given, Set
+// only accepts URLs whose scheme is one of them, compared without regard
+// to case.
+//
+// New 返回一个将 URL 存储在 p 中的 Value，p 首先被设置为 value 的副本，value 为 nil 时
+// p 为空。如果给出了 schemes，Set 只接受协议是其中之一的 URL，比较时不区分大小写。
+func New(p *url.URL, value *url.URL, schemes ...string) *Value {
+	if value != nil {
+		*p = *value
+	} else {
+		*p = url.URL{}
+	}
+	return &Value{p: p, schemes: schemes}
+}
+
+// Set parses s with url.Parse and checks its scheme.
+//
+// Set 用 url.Parse 解析 s 并检查它的协议。
+func (u *Value) Set(s string) error {
+	v, err := url.Parse(s)
+	if err != nil {
+		return err
+	}
+	if len(u.schemes) > 0 {
+		ok := false
+		for _, scheme := range u.schemes {
+			// url.Parse 会将协议转换为小写。
+			if strings.ToLower(scheme) == v.Scheme { // url.Parse lower-cases the scheme.
+				ok = true
+				break
+			}
+		}
+		if !ok {
+			return errors.New("scheme " + strconv.Quote(v.Scheme) + " is not one of " + strings.Join(u.schemes, ", "))
+		}
+	}
+	*u.p = *v
+	return nil
+}
+
+// Get returns the URL as a url.URL.
+//
+// Get 以 url.URL 的形式返回 URL。
+func (u *Value) Get() interface{} { return *u.p }
+
+func (u *Value) String() string {
+	if u.p == nil {
+		return ""
+	}
+	return u.p.String()
+}
+
+// Type returns "url", which usage messages show as the name of the
+// argument of the flag.
+//
+// Type 返回 "url"，用法信息将它显示为标志的参数名称。
+func (u *Value) Type() string { return "url" }
@@ -0,0 +1,85 @@
+// Copyright 2018 Li Zebang. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package flagurl_test
+
+import (
+	"bytes"
+	. "flag"
+	"flag/flagurl"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestURL(t *testing.T) {
+	fs := NewFlagSet("url", ContinueOnError)
+	def, _ := url.Parse("http://localhost:8080")
+	var endpoint, proxy url.URL
+	fs.Var(flagurl.New(&endpoint, def), "endpoint", "endpoint")
+	fs.Var(flagurl.New(&proxy, nil), "proxy", "proxy")
+	if err := fs.Parse([]string{"-proxy", "socks5://10.0.0.1:1080/x?y=1"}); err != nil {
+		t.Fatal(err)
+	}
+	if endpoint.String() != "http://localhost:8080" {
+		t.Errorf("endpoint = %s", &endpoint)
+	}
+	if proxy.Scheme != "socks5" || proxy.Host != "10.0.0.1:1080" || proxy.RawQuery != "y=1" {
+		t.Errorf("proxy = %#v", proxy)
+	}
+	if got, ok := fs.Lookup("proxy").Value.(Getter).Get().(url.URL); !ok || got.Path != "/x" {
+		t.Errorf("proxy Get() = %#v", got)
+	}
+	// The default is copied, not shared.
+	endpoint.Host = "changed"
+	if def.Host != "localhost:8080" {
+		t.Errorf("setting the flag changed its default: %s", def)
+	}
+}
+
+func TestURLSchemes(t *testing.T) {
+	tests := []struct {
+		arg string
+		err string
+	}{
+		{"https://example.com", ""},
+		{"HTTP://example.com", ""},
+		{"ftp://example.com", `scheme "ftp" is not one of http, HTTPS`},
+		{"example.com", `scheme "" is not one of http, HTTPS`},
+		{"http://[::1", "missing ']' in host"},
+	}
+	for _, tt := range tests {
+		fs := NewFlagSet("url", ContinueOnError)
+		fs.SetOutput(new(bytes.Buffer))
+		var u url.URL
+		fs.Var(flagurl.New(&u, nil, "http", "HTTPS"), "u", "url")
+		err := fs.Parse([]string{"-u", tt.arg})
+		switch {
+		case tt.err == "" && err != nil:
+			t.Errorf("Parse(%q): %v", tt.arg, err)
+		case tt.err != "" && (err == nil || !strings.Contains(err.Error(), tt.err)):
+			t.Errorf("Parse(%q) error = %v; want %q", tt.arg, err, tt.err)
+		}
+	}
+}
+
+func TestURLPrintDefaults(t *testing.T) {
+	fs := NewFlagSet("url", ContinueOnError)
+	var buf bytes.Buffer
+	fs.SetOutput(&buf)
+	def, _ := url.Parse("http://localhost:8080")
+	fs.Var(flagurl.New(new(url.URL), def), "endpoint", "endpoint")
+	fs.Var(flagurl.New(new(url.URL), nil), "proxy", "proxy")
+	fs.PrintDefaults()
+	want := strings.Join([]string{
+		"  -endpoint url",
+		"    \tendpoint (default http://localhost:8080)",
+		"  -proxy url",
+		"    \tproxy",
+		"",
+	}, "\n")
+	if buf.String() != want {
+		t.Errorf("PrintDefaults:\n%q\nwant:\n%q", buf.String(), want)
+	}
+}
@@ -0,0 +1,33 @@
+// Copyright 2018 Li Zebang. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package flag
+
+// -- func Value
+type funcValue func(string) error
+
+func (f funcValue) Set(s string) error { return f(s) }
+
+func (f funcValue) String() string { return "" }
+
+// Func defines a flag with the specified name and usage string. Each time
+// the flag is seen, fn is called with the value of the flag. If fn returns
+// a non-nil error, it will be treated as a flag value parsing error.
+//
+// Func 定义一个具有指定名称和用法信息的标志。每次遇到该标志时，都会用标志的值调用 fn。如果
+// fn 返回一个非 nil 的错误，它会被当作标志的值的解析错误。
+//
+// IMP: funcValue 没有状态，它的 String 方法总是返回空字符串，所以 PrintDefaults 不会显示
+// 默认值。
+func (f *FlagSet) Func(name, usage string, fn func(string) error) {
+	f.Var(funcValue(fn), name, usage)
+}
+
+// Func defines a flag with the specified name and usage string. Each time
+// the flag is seen, fn is called with the value of the flag.
+//
+// Func 定义一个具有指定名称和用法信息的标志。每次遇到该标志时，都会用标志的值调用 fn。
+func Func(name, usage string, fn func(string) error) {
+	CommandLine.Func(name, usage, fn)
+}
@@ -0,0 +1,46 @@
+// Copyright 2018 Li Zebang. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package flag_test
+
+import (
+	"bytes"
+	"errors"
+	. "flag"
+	"strings"
+	"testing"
+)
+
+func TestFunc(t *testing.T) {
+	fs := NewFlagSet("func", ContinueOnError)
+	var buf bytes.Buffer
+	fs.SetOutput(&buf)
+	var seen []string
+	fs.Func("x", "`X` to add", func(s string) error {
+		if s == "bad" {
+			return errors.New("bad value")
+		}
+		seen = append(seen, s)
+		return nil
+	})
+	if err := fs.Parse([]string{"-x", "1", "-x=2", "rest"}); err != nil {
+		t.Fatal(err)
+	}
+	if strings.Join(seen, " ") != "1 2" {
+		t.Errorf("seen = %q", seen)
+	}
+	if fs.NArg() != 1 || fs.Arg(0) != "rest" {
+		t.Errorf("args = %q", fs.Args())
+	}
+	err := fs.Parse([]string{"-x", "bad"})
+	if err == nil || !strings.Contains(err.Error(), `invalid value "bad" for flag -x: bad value`) {
+		t.Errorf("Parse error = %v", err)
+	}
+
+	buf.Reset()
+	fs.PrintDefaults()
+	if want := "  -x X\n    \tX to add\n"; buf.String() != want {
+		t.Errorf("PrintDefaults:\n%q\nwant:\n%q", buf.String(), want)
+	}
+}
@@ -0,0 +1,340 @@
+// Copyright 2018 Li Zebang. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+// +build !gofuzz
+
+package flag
+
+import (
+	"fmt"
+	"io/ioutil"
+	"math/rand"
+	"reflect"
+	"strings"
+	"testing"
+	"testing/quick"
+	"time"
+)
+
+// The harness of the property tests below. fuzz_gofuzz.go keeps a copy
+// for the go-fuzz target, so that neither is built into the package, and a
+// gofuzz build leaves this file out. The two must be changed together.
+// A flag set is described by a string of comma-separated definitions
+// "<kind><name>" or "<kind><name>/<shorthand>", where kind is one of
+//
+//	b bool, i int, I int64, u uint, U uint64, s string, f float64, d duration
+//
+// so that random inputs can define random flags. Definitions that Var or
+// VarP would reject with their documented panics are skipped.
+//
+// 下面的性质测试使用的测试工具。fuzz_gofuzz.go 中为 go-fuzz 目标保留了一份副本，这样它们
+// 都不会被构建到包中，gofuzz 构建也不会包含本文件。两者必须一起修改。标志集由一个以逗号
+// 分隔的定义字符串描述，每个定义为 "<kind><name>" 或 "<kind><name>/<shorthand>"，kind
+// 是上面列出的字母之一，这样随机的输入就可以定义随机的标志。Var 或 VarP 会以文档中说明的
+// panic 拒绝的定义会被跳过。
+
+// fuzzFlagSet returns a flag set with the flags described by defs that
+// parses in mode and reports errors silently.
+//
+// fuzzFlagSet 返回一个定义了 defs 所描述的标志、以 mode 模式解析并且静默报告错误的标志集。
+func fuzzFlagSet(defs string, mode ParseMode) *FlagSet {
+	f := NewFlagSet("fuzz", ContinueOnError)
+	f.SetOutput(ioutil.Discard)
+	f.Usage = func() {}
+	f.SetParseMode(mode)
+	for _, def := range strings.Split(defs, ",") {
+		if len(def) < 2 {
+			continue
+		}
+		kind, name := def[0], def[1:]
+		shorthand := ""
+		if i := strings.Index(name, "/"); i >= 0 {
+			name, shorthand = name[:i], name[i+1:]
+		}
+		if !fuzzValidName(f, name) || shorthand != "" && !fuzzValidShorthand(f, shorthand) {
+			continue
+		}
+		var value Value
+		switch kind {
+		case 'b':
+			value = newBoolValue(false, new(bool))
+		case 'i':
+			value = newIntValue(0, new(int))
+		case 'I':
+			value = newInt64Value(0, new(int64))
+		case 'u':
+			value = newUintValue(0, new(uint))
+		case 'U':
+			value = newUint64Value(0, new(uint64))
+		case 's':
+			value = newStringValue("", new(string))
+		case 'f':
+			value = newFloat64Value(0, new(float64))
+		case 'd':
+			value = newDurationValue(0, new(time.Duration))
+		default:
+			continue
+		}
+		f.VarP(value, name, shorthand, "")
+	}
+	return f
+}
+
+// fuzzValidName reports whether name can be defined in f and given on a
+// command line.
+//
+// fuzzValidName 报告 name 是否可以在 f 中定义并且在命令行中给出。
+func fuzzValidName(f *FlagSet, name string) bool {
+	return name != "" && name[0] != '-' && !strings.ContainsAny(name, "=,") &&
+		f.formal[name] == nil && f.shorthands[name] == nil
+}
+
+// fuzzValidShorthand reports whether VarP accepts shorthand in f.
+//
+// fuzzValidShorthand 报告 VarP 是否会在 f 中接受 shorthand。
+func fuzzValidShorthand(f *FlagSet, shorthand string) bool {
+	return len(shorthand) == 1 && isLetter(shorthand[0]) &&
+		f.formal[shorthand] == nil && f.shorthands[shorthand] == nil
+}
+
+// fuzzCommandLine reconstructs a command line that sets the flags that
+// were set in f to their current values, followed by the remaining
+// arguments.
+//
+// fuzzCommandLine 重建一个命令行，它将 f 中被设置过的标志设置为它们当前的值，后面跟着
+// 剩余的参数。
+func fuzzCommandLine(f *FlagSet) []string {
+	var args []string
+	f.Visit(func(flag *Flag) {
+		args = append(args, "--"+flag.Name+"="+flag.Value.String())
+	})
+	args = append(args, "--")
+	return append(args, f.Args()...)
+}
+
+// fuzzParse parses args with the flags described by defs and checks the
+// invariants of Parse:
+//
+//   - Parse does not panic;
+//   - every flag that was set is a defined flag, and NFlag counts them;
+//   - the remaining arguments are a suffix of args;
+//   - after a successful parse, the reconstructed command line parses
+//     to the same values and remaining arguments.
+//
+// It reports whether args parsed without error, and returns an error
+// describing the first invariant that does not hold.
+//
+// fuzzParse 使用 defs 所描述的标志解析 args，并检查 Parse 的不变式：
+//
+//   - Parse 不会 panic；
+//   - 每个被设置的标志都是已定义的标志，并且 NFlag 统计的就是它们；
+//   - 剩余的参数是 args 的后缀；
+//   - 解析成功之后，重建的命令行会解析出同样的值和剩余的参数。
+//
+// 它返回 args 是否被成功解析，以及描述第一个不成立的不变式的错误。
+func fuzzParse(defs string, mode ParseMode, args []string) (ok bool, err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = fmt.Errorf("Parse(%q) with %q panicked: %v", args, defs, e)
+		}
+	}()
+	f := fuzzFlagSet(defs, mode)
+	if perr := f.Parse(args); perr != nil {
+		if err := fuzzCheckSet(f, args); err != nil {
+			return false, err
+		}
+		return false, nil
+	}
+	if err := fuzzCheckSet(f, args); err != nil {
+		return true, err
+	}
+
+	line := fuzzCommandLine(f)
+	g := fuzzFlagSet(defs, mode)
+	if err := g.Parse(line); err != nil {
+		return true, fmt.Errorf("reparsing %q, reconstructed from %q with %q: %v", line, args, defs, err)
+	}
+	for name, flag := range f.formal {
+		if got, want := g.formal[name].Value.String(), flag.Value.String(); got != want {
+			return true, fmt.Errorf("reparsing %q, reconstructed from %q with %q: -%s = %q; want %q", line, args, defs, name, got, want)
+		}
+	}
+	if got, want := strings.Join(g.Args(), "\x00"), strings.Join(f.Args(), "\x00"); got != want {
+		return true, fmt.Errorf("reparsing %q, reconstructed from %q with %q: Args() = %q; want %q", line, args, defs, g.Args(), f.Args())
+	}
+	return true, nil
+}
+
+// fuzzCheckSet checks the invariants that hold whether or not Parse failed.
+//
+// fuzzCheckSet 检查无论 Parse 是否失败都成立的不变式。
+func fuzzCheckSet(f *FlagSet, args []string) error {
+	n := 0
+	var err error
+	f.Visit(func(flag *Flag) {
+		n++
+		if err == nil && f.Lookup(flag.Name) != flag {
+			err = fmt.Errorf("Parse(%q): set flag -%s is not defined", args, flag.Name)
+		}
+	})
+	if err != nil {
+		return err
+	}
+	if n != f.NFlag() {
+		return fmt.Errorf("Parse(%q): Visit visited %d flags, NFlag() = %d", args, n, f.NFlag())
+	}
+	rest := f.Args()
+	if len(rest) > len(args) {
+		return fmt.Errorf("Parse(%q): Args() = %q is longer than the arguments", args, rest)
+	}
+	tail := args[len(args)-len(rest):]
+	for i := range rest {
+		if rest[i] != tail[i] {
+			return fmt.Errorf("Parse(%q): Args() = %q is not a suffix of the arguments", args, rest)
+		}
+	}
+	return nil
+}
+
+// parseInput is a random flag set and command line for fuzzParse.
+type parseInput struct {
+	defs string
+	mode ParseMode
+	args []string
+}
+
+var (
+	fuzzKinds      = "biIuUsfd"
+	fuzzNames      = []string{"a", "b", "c", "v", "h", "ab", "abc", "name", "x-y", "-", "a=b", ""}
+	fuzzShorthands = []string{"", "", "a", "b", "v", "x", "1", "xy"}
+	fuzzPieces     = []string{
+		"-", "--", "=", "-a", "-b", "-v", "-x", "-ab", "-abc", "-vx", "--name", "-name", "--x-y",
+		"-h", "-help", "true", "false", "1", "-1", "1.5", "1s", "x", "",
+	}
+)
+
+// Generate implements quick.Generator.
+func (parseInput) Generate(r *rand.Rand, size int) reflect.Value {
+	var in parseInput
+	var defs []string
+	for n := r.Intn(7); n > 0; n-- {
+		def := string(fuzzKinds[r.Intn(len(fuzzKinds))]) + fuzzNames[r.Intn(len(fuzzNames))]
+		if s := fuzzShorthands[r.Intn(len(fuzzShorthands))]; s != "" {
+			def += "/" + s
+		}
+		defs = append(defs, def)
+	}
+	in.defs = strings.Join(defs, ",")
+	if r.Intn(2) == 0 {
+		in.mode = CombinedShorts
+	}
+	for n := r.Intn(size%8 + 1); n > 0; n-- {
+		var arg string
+		for k := r.Intn(3) + 1; k > 0; k-- {
+			arg += fuzzPieces[r.Intn(len(fuzzPieces))]
+		}
+		in.args = append(in.args, arg)
+	}
+	return reflect.ValueOf(in)
+}
+
+func TestParseProperties(t *testing.T) {
+	count := 20000
+	if testing.Short() {
+		count = 2000
+	}
+	check := func(in parseInput) bool {
+		if _, err := fuzzParse(in.defs, in.mode, in.args); err != nil {
+			t.Error(err)
+			return false
+		}
+		return true
+	}
+	if err := quick.Check(check, &quick.Config{MaxCount: count}); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestParseEdgeCases(t *testing.T) {
+	const defs = "ba/v,bb,sname/n,ic,dd"
+	tests := []struct {
+		args []string
+		ok   bool
+	}{
+		{[]string{}, true},
+		{[]string{""}, true},
+		{[]string{"-"}, true},
+		{[]string{"--"}, true},
+		{[]string{"--", "--"}, true},
+		{[]string{"---"}, false},
+		{[]string{"---a"}, false},
+		{[]string{"-="}, false},
+		{[]string{"--="}, false},
+		{[]string{"-=a"}, false},
+		{[]string{"-a="}, false},
+		{[]string{"-name="}, true},
+		{[]string{"-name==x"}, true},
+		{[]string{"-name", "-a"}, true},
+		{[]string{"-name"}, false},
+		{[]string{"-c", ""}, false},
+		{[]string{"-d=1s", "-d", "2m"}, true},
+		{[]string{"-a", "x", "-b"}, true},
+		{[]string{"-h"}, false},
+		{[]string{"-x"}, false},
+		{[]string{"-vb"}, false},
+	}
+	for _, tt := range tests {
+		ok, err := fuzzParse(defs, 0, tt.args)
+		if err != nil {
+			t.Error(err)
+		}
+		if ok != tt.ok {
+			t.Errorf("Parse(%q) ok = %v; want %v", tt.args, ok, tt.ok)
+		}
+	}
+}
+
+func TestParseEdgeCasesCombined(t *testing.T) {
+	const defs = "ba/v,bb,sname/n,ic,dd"
+	tests := []struct {
+		args []string
+		ok   bool
+	}{
+		{[]string{"-vb"}, true},
+		{[]string{"-vb=false"}, true},
+		{[]string{"-vbn"}, false},
+		{[]string{"-vbnx"}, true},
+		{[]string{"-vbn="}, true},
+		{[]string{"-vbn", ""}, true},
+		{[]string{"-c12"}, true},
+		{[]string{"-c=12"}, true},
+		{[]string{"-cx"}, false},
+		{[]string{"-vh"}, false},
+		{[]string{"-v-"}, false},
+		{[]string{"-v="}, false},
+		{[]string{"--vb"}, false},
+	}
+	for _, tt := range tests {
+		ok, err := fuzzParse(defs, CombinedShorts, tt.args)
+		if err != nil {
+			t.Error(err)
+		}
+		if ok != tt.ok {
+			t.Errorf("Parse(%q) ok = %v; want %v", tt.args, ok, tt.ok)
+		}
+	}
+}
+
+func TestFuzzFlagSet(t *testing.T) {
+	// Invalid and duplicate definitions are skipped instead of panicking.
+	f := fuzzFlagSet("ba/v,sa,x,ib/v,iv,zq,s=,s-y,bc/1,bd/dd,,sname/n,bb", 0)
+	var got []string
+	f.VisitAll(func(flag *Flag) {
+		got = append(got, flag.Name+"/"+flag.Shorthand)
+	})
+	want := []string{"a/v", "b/", "name/n"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("defined %q; want %q", got, want)
+	}
+}
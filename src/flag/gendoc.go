@@ -0,0 +1,252 @@
+// Copyright 2018 Li Zebang. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package flag
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// visitUsage calls fn for each flag that usage messages list, in the order
+// they list them.
+//
+// visitUsage 按照用法信息列出标志的顺序，为它列出的每个标志调用 fn。
+func (f *FlagSet) visitUsage(fn func(*Flag)) {
+	f.VisitAll(func(flag *Flag) {
+		if !flag.Hidden && !f.gatedOut(flag) {
+			fn(flag)
+		}
+	})
+}
+
+// usageNames returns the forms of flag that usage messages list, with
+// their dashes, such as -v, --verbose and --no-verbose.
+//
+// usageNames 返回用法信息列出的 flag 的各种形式，包括它们的横线，例如 -v、--verbose 和
+// --no-verbose。
+func usageNames(flag *Flag) []string {
+	names := []string{"-" + flag.Name}
+	dashes := "-"
+	if flag.Shorthand != "" {
+		dashes = "--"
+		names = []string{"-" + flag.Shorthand, dashes + flag.Name}
+	}
+	if _, ok := flag.Value.(*negatableBoolValue); ok {
+		names = append(names, dashes+"no-"+flag.Name)
+	}
+	for _, alias := range flag.Aliases {
+		names = append(names, dashes+alias)
+	}
+	return names
+}
+
+// defaultText returns the default value of flag as usage messages show it,
+// and reports false if it is the zero value and is not shown.
+//
+// defaultText 返回用法信息中显示的 flag 的默认值，如果它是零值而不显示，则返回 false。
+func defaultText(flag *Flag) (string, bool) {
+	if isZeroValue(flag, flag.DefValue) {
+		return "", false
+	}
+	if flag.Secret {
+		return secretMask, true
+	}
+	switch v := flag.Value.(type) {
+	case *stringValue:
+		// put quotes on the value
+		//
+		// 值中存在引号
+		return fmt.Sprintf("%q", flag.DefValue), true
+	case TypedValue:
+		// 和 pflag 一样，为类型是 string 的值加上引号
+		if v.Type() == "string" { // quoted as pflag does for values of type string
+			return fmt.Sprintf("%q", flag.DefValue), true
+		}
+	}
+	return flag.DefValue, true
+}
+
+// docWriter remembers the first error of the writes to w, so the
+// generators can check it once at the end.
+//
+// docWriter 记住对 w 的写入中的第一个错误，这样生成函数只需要在最后检查一次。
+type docWriter struct {
+	w   io.Writer
+	err error
+}
+
+func (d *docWriter) print(a ...string) {
+	for _, s := range a {
+		if d.err == nil {
+			_, d.err = io.WriteString(d.w, s)
+		}
+	}
+}
+
+// markdownReplacer escapes the text of a Markdown table cell.
+//
+// markdownReplacer 转义 Markdown 表格单元格中的文本。
+var markdownReplacer = strings.NewReplacer("|", `\|`, "\n", "<br>", "`", "\\`", "*", `\*`, "_", `\_`, "<", "&lt;")
+
+// GenMarkdown writes to w a Markdown document of the flags that
+// PrintDefaults lists, in the same order: a heading with the name of f, if
+// it has one, and a table of the flags with their types, defaults and
+// usage messages. A column of environment variables is added when a flag
+// reads one. Each group of flags gets a heading and a table of its own.
+//
+// GenMarkdown 将 PrintDefaults 列出的标志按照相同的顺序写成 Markdown 文档输出到 w：如果 f
+// 有名称，首先是以它为标题的一行，然后是标志以及它们的类型、默认值和用法信息组成的表格。
+// 当有标志读取环境变量时，会增加一列环境变量。每个分组的标志有自己的标题和表格。
+func (f *FlagSet) GenMarkdown(w io.Writer) error {
+	env := false
+	f.visitUsage(func(flag *Flag) {
+		env = env || f.envKey(flag) != ""
+	})
+	d := &docWriter{w: w}
+	if f.name != "" {
+		d.print("# ", markdownReplacer.Replace(f.name), "\n\n")
+	}
+	header := func() {
+		d.print("| Flag | Type | Default |")
+		if env {
+			d.print(" Environment |")
+		}
+		d.print(" Description |\n|---|---|---|")
+		if env {
+			d.print("---|")
+		}
+		d.print("---|\n")
+	}
+	// 不属于任何分组的标志的表格只在有这样的标志时才需要
+	table := false // whether a table was started; flags outside any group may need none
+	f.visitGroups(func(group string) {
+		if table {
+			d.print("\n")
+		}
+		d.print("## ", markdownReplacer.Replace(group), "\n\n")
+		header()
+		table = true
+	}, func(flag *Flag) {
+		if !table {
+			header()
+			table = true
+		}
+		name, usage := UnquoteUsage(flag)
+		d.print("| ", markdownCode(usageNames(flag)...), " | ", markdownReplacer.Replace(name), " | ")
+		if def, ok := defaultText(flag); ok {
+			d.print(markdownCode(def))
+		}
+		d.print(" | ")
+		if env {
+			if key := f.envKey(flag); key != "" {
+				d.print(markdownCode("$"+key), " | ")
+			} else {
+				d.print(" | ")
+			}
+		}
+		d.print(markdownReplacer.Replace(usage), " |\n")
+	})
+	if !table {
+		header()
+	}
+	return d.err
+}
+
+// markdownCode formats each of a as a code span inside a table cell,
+// separated by commas.
+//
+// markdownCode 将 a 中的每一项格式化为表格单元格中的代码片段，以逗号分隔。
+func markdownCode(a ...string) string {
+	spans := make([]string, len(a))
+	for i, s := range a {
+		// 代码片段中不能转义反引号，所以用更长的反引号串来包围它
+		fence := "`" // a code span cannot escape a back quote, so it is fenced by a longer run
+		for strings.Contains(s, fence) {
+			fence += "`"
+		}
+		if strings.HasPrefix(s, "`") || strings.HasSuffix(s, "`") {
+			s = " " + s + " "
+		}
+		spans[i] = fence + strings.Replace(s, "|", `\|`, -1) + fence
+	}
+	return strings.Join(spans, ", ")
+}
+
+// manReplacer escapes text for troff: backslashes and dashes, which troff
+// would otherwise turn into hyphens.
+//
+// manReplacer 为 troff 转义文本：反斜杠和横线，否则 troff 会将横线转换为连字符。
+var manReplacer = strings.NewReplacer(`\`, `\e`, "-", `\-`)
+
+// manText escapes s for troff and protects its lines from being read as
+// requests.
+//
+// manText 为 troff 转义 s，并防止它的行被当作请求来读取。
+func manText(s string) string {
+	lines := strings.Split(manReplacer.Replace(s), "\n")
+	for i, line := range lines {
+		if strings.HasPrefix(line, ".") || strings.HasPrefix(line, "'") {
+			lines[i] = `\&` + line
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// GenManPage writes to w a man page in troff format of the flags that
+// PrintDefaults lists, in the same order, for the given section of the
+// manual, such as "1". The page is titled with the name of f, and lists
+// each flag with its type, usage message, default and environment
+// variable in an OPTIONS section, with a subsection for each group of
+// flags.
+//
+// GenManPage 将 PrintDefaults 列出的标志按照相同的顺序写成 troff 格式的手册页输出到 w，
+// section 是手册的章节，例如 "1"。手册页以 f 的名称为标题，并在 OPTIONS 一节中列出每个
+// 标志以及它的类型、用法信息、默认值和环境变量，每个分组的标志在一个小节中。
+func (f *FlagSet) GenManPage(w io.Writer, section string) error {
+	d := &docWriter{w: w}
+	title := manText(strings.ToUpper(f.name))
+	d.print(".TH \"", title, "\" \"", manText(section), "\"\n")
+	if f.name != "" {
+		d.print(".SH NAME\n", manText(f.name), "\n")
+	}
+	d.print(".SH OPTIONS\n")
+	f.visitGroups(func(group string) {
+		d.print(".SS \"", manText(group), "\"\n")
+	}, func(flag *Flag) {
+		name, usage := UnquoteUsage(flag)
+		names := usageNames(flag)
+		for i, n := range names {
+			names[i] = `\fB` + manText(n) + `\fR`
+		}
+		d.print(".TP\n", strings.Join(names, ", "))
+		if name != "" {
+			d.print(` \fI`, manText(name), `\fR`)
+		}
+		d.print("\n", manText(usage))
+		if def, ok := defaultText(flag); ok {
+			d.print(" (default ", manText(def), ")")
+		}
+		if key := f.envKey(flag); key != "" {
+			d.print(" (env $", manText(key), ")")
+		}
+		d.print("\n")
+	})
+	return d.err
+}
+
+// GenMarkdown writes to w a Markdown document of the command-line flags.
+//
+// GenMarkdown 将命令行标志写成 Markdown 文档输出到 w。
+func GenMarkdown(w io.Writer) error {
+	return CommandLine.GenMarkdown(w)
+}
+
+// GenManPage writes to w a man page of the command-line flags.
+//
+// GenManPage 将命令行标志写成手册页输出到 w。
+func GenManPage(w io.Writer, section string) error {
+	return CommandLine.GenManPage(w, section)
+}
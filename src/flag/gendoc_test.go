@@ -0,0 +1,111 @@
+// Copyright 2018 Li Zebang. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package flag_test
+
+import (
+	"bytes"
+	"errors"
+	. "flag"
+	"strings"
+	"testing"
+)
+
+func newDocFlagSet() *FlagSet {
+	fs := NewFlagSet("serve", ContinueOnError)
+	fs.IntP("port", "p", 8080, "`port` to listen on")
+	fs.String("root", ".", "directory to serve | files from")
+	fs.NegatableBool("cache", true, "cache responses")
+	fs.Bool("debug", false, "internal")
+	fs.MarkHidden("debug")
+	fs.String("motd", "", "message of the day\n.shown on login")
+	fs.BindEnv("root", "SERVE_ROOT")
+	return fs
+}
+
+func TestGenMarkdown(t *testing.T) {
+	var buf bytes.Buffer
+	if err := newDocFlagSet().GenMarkdown(&buf); err != nil {
+		t.Fatal(err)
+	}
+	want := strings.Join([]string{
+		"# serve",
+		"",
+		"| Flag | Type | Default | Environment | Description |",
+		"|---|---|---|---|---|",
+		"| `-cache`, `-no-cache` |  | `true` |  | cache responses |",
+		"| `-motd` | string |  |  | message of the day<br>.shown on login |",
+		"| `-p`, `--port` | port | `8080` |  | port to listen on |",
+		"| `-root` | string | `\".\"` | `$SERVE_ROOT` | directory to serve \\| files from |",
+		"",
+	}, "\n")
+	if buf.String() != want {
+		t.Errorf("GenMarkdown:\n%s\nwant:\n%s", buf.String(), want)
+	}
+}
+
+func TestGenMarkdownNoEnv(t *testing.T) {
+	fs := NewFlagSet("", ContinueOnError)
+	fs.String("tick", "`", "a back quote")
+	var buf bytes.Buffer
+	if err := fs.GenMarkdown(&buf); err != nil {
+		t.Fatal(err)
+	}
+	want := "| Flag | Type | Default | Description |\n" +
+		"|---|---|---|---|\n" +
+		"| `-tick` | string | ``\"`\"`` | a back quote |\n"
+	if buf.String() != want {
+		t.Errorf("GenMarkdown:\n%s\nwant:\n%s", buf.String(), want)
+	}
+}
+
+func TestGenManPage(t *testing.T) {
+	var buf bytes.Buffer
+	if err := newDocFlagSet().GenManPage(&buf, "1"); err != nil {
+		t.Fatal(err)
+	}
+	want := strings.Join([]string{
+		`.TH "SERVE" "1"`,
+		`.SH NAME`,
+		`serve`,
+		`.SH OPTIONS`,
+		`.TP`,
+		`\fB\-cache\fR, \fB\-no\-cache\fR`,
+		`cache responses (default true)`,
+		`.TP`,
+		`\fB\-motd\fR \fIstring\fR`,
+		`message of the day`,
+		`\&.shown on login`,
+		`.TP`,
+		`\fB\-p\fR, \fB\-\-port\fR \fIport\fR`,
+		`port to listen on (default 8080)`,
+		`.TP`,
+		`\fB\-root\fR \fIstring\fR`,
+		`directory to serve | files from (default ".") (env $SERVE_ROOT)`,
+		``,
+	}, "\n")
+	if buf.String() != want {
+		t.Errorf("GenManPage:\n%s\nwant:\n%s", buf.String(), want)
+	}
+}
+
+type failWriter struct{ n int }
+
+func (w *failWriter) Write(p []byte) (int, error) {
+	if w.n == 0 {
+		return 0, errors.New("disk full")
+	}
+	w.n--
+	return len(p), nil
+}
+
+func TestGenDocWriteError(t *testing.T) {
+	fs := newDocFlagSet()
+	if err := fs.GenMarkdown(&failWriter{n: 3}); err == nil || err.Error() != "disk full" {
+		t.Errorf("GenMarkdown error = %v", err)
+	}
+	if err := fs.GenManPage(&failWriter{n: 3}, "1"); err == nil || err.Error() != "disk full" {
+		t.Errorf("GenManPage error = %v", err)
+	}
+}
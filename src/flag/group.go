@@ -0,0 +1,65 @@
+// Copyright 2018 Li Zebang. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package flag
+
+// Group puts the flags defined after it, up to the next call of Group, in
+// the group with the given name and returns f, so a group of flags can be
+// defined as f.Group("Networking").StringVar(...). Usage messages list the
+// flags outside any group first, then each group under a heading, in the
+// order their first flags were defined. Group("") ends the group.
+//
+// Group 将在它之后、直到下一次调用 Group 之前定义的标志放入名为 name 的分组中，并返回 f，
+// 这样就可以用 f.Group("Networking").StringVar(...) 定义一组标志。用法信息首先列出不属于
+// 任何分组的标志，然后按照各个分组的第一个标志被定义的顺序，在标题下列出每个分组。
+// Group("") 结束分组。
+func (f *FlagSet) Group(name string) *FlagSet {
+	f.group = name
+	return f
+}
+
+// Group puts the command-line flags defined after it in the group with the
+// given name and returns the command-line flag set.
+//
+// Group 将在它之后定义的命令行标志放入名为 name 的分组中，并返回命令行标志集。
+func Group(name string) *FlagSet {
+	return CommandLine.Group(name)
+}
+
+// visitGroups calls fn for each flag that usage messages list, like
+// visitUsage, but the flags outside any group come first, and the flags
+// of each group follow a call of heading with its name.
+//
+// visitGroups 和 visitUsage 一样为用法信息列出的每个标志调用 fn，但不属于任何分组的标志
+// 在最前面，每个分组的标志之前会以分组名称调用 heading。
+//
+// NOTE: 分组的顺序由 f.order 决定，所以 AddFlagSet 和 Namespace 带来的分组也会被列出。
+func (f *FlagSet) visitGroups(heading func(group string), fn func(*Flag)) {
+	var groups []string
+	seen := make(map[string]bool)
+	for _, flag := range f.order {
+		if flag.Group != "" && !seen[flag.Group] {
+			seen[flag.Group] = true
+			groups = append(groups, flag.Group)
+		}
+	}
+	f.visitUsage(func(flag *Flag) {
+		if flag.Group == "" {
+			fn(flag)
+		}
+	})
+	for _, group := range groups {
+		first := true
+		f.visitUsage(func(flag *Flag) {
+			if flag.Group != group {
+				return
+			}
+			if first {
+				heading(group)
+				first = false
+			}
+			fn(flag)
+		})
+	}
+}
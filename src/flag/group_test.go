@@ -0,0 +1,109 @@
+// Copyright 2018 Li Zebang. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package flag_test
+
+import (
+	"bytes"
+	. "flag"
+	"strings"
+	"testing"
+)
+
+func newGroupFlagSet() *FlagSet {
+	fs := NewFlagSet("group", ContinueOnError)
+	fs.Bool("v", false, "verbose")
+	var port int
+	fs.Group("Networking").IntVar(&port, "port", 80, "port to listen on")
+	fs.String("host", "", "host to listen on")
+	fs.Group("Storage").String("root", ".", "directory to serve")
+	fs.Bool("secret", false, "hidden")
+	fs.MarkHidden("secret")
+	fs.Group("Empty").Group("")
+	fs.Bool("q", false, "quiet")
+	fs.Group("Networking").Bool("ipv6", false, "use IPv6")
+	return fs
+}
+
+func TestGroupPrintDefaults(t *testing.T) {
+	fs := newGroupFlagSet()
+	var buf bytes.Buffer
+	fs.SetOutput(&buf)
+	fs.PrintDefaults()
+	want := strings.Join([]string{
+		"  -q\tquiet",
+		"  -v\tverbose",
+		"",
+		"Networking:",
+		"  -host string",
+		"    \thost to listen on",
+		"  -ipv6",
+		"    \tuse IPv6",
+		"  -port int",
+		"    \tport to listen on (default 80)",
+		"",
+		"Storage:",
+		"  -root string",
+		"    \tdirectory to serve (default \".\")",
+		"",
+	}, "\n")
+	if buf.String() != want {
+		t.Errorf("PrintDefaults:\n%s\nwant:\n%s", buf.String(), want)
+	}
+	if got := fs.Lookup("ipv6").Group; got != "Networking" {
+		t.Errorf("Group of ipv6 = %q", got)
+	}
+}
+
+func TestGroupGenDocs(t *testing.T) {
+	fs := NewFlagSet("group", ContinueOnError)
+	fs.Group("Networking").Int("port", 80, "port")
+	fs.Group("Storage").String("root", "", "root")
+	var buf bytes.Buffer
+	if err := fs.GenMarkdown(&buf); err != nil {
+		t.Fatal(err)
+	}
+	want := strings.Join([]string{
+		"# group",
+		"",
+		"## Networking",
+		"",
+		"| Flag | Type | Default | Description |",
+		"|---|---|---|---|",
+		"| `-port` | int | `80` | port |",
+		"",
+		"## Storage",
+		"",
+		"| Flag | Type | Default | Description |",
+		"|---|---|---|---|",
+		"| `-root` | string |  | root |",
+		"",
+	}, "\n")
+	if buf.String() != want {
+		t.Errorf("GenMarkdown:\n%s\nwant:\n%s", buf.String(), want)
+	}
+	buf.Reset()
+	if err := fs.GenManPage(&buf, "1"); err != nil {
+		t.Fatal(err)
+	}
+	if got := buf.String(); !strings.Contains(got, ".SH OPTIONS\n.SS \"Networking\"\n.TP\n\\fB\\-port\\fR") {
+		t.Errorf("GenManPage:\n%s", got)
+	}
+}
+
+func TestGroupAcrossFlagSets(t *testing.T) {
+	lib := NewFlagSet("lib", ContinueOnError)
+	lib.Group("Database").String("host", "", "database host")
+	app := NewFlagSet("app", ContinueOnError)
+	app.Namespace("db", lib)
+	var buf bytes.Buffer
+	app.SetOutput(&buf)
+	app.PrintDefaults()
+	if want := "\nDatabase:\n  -db.host string\n"; !strings.HasPrefix(buf.String(), want) {
+		t.Errorf("PrintDefaults:\n%s\nwant prefix:\n%s", buf.String(), want)
+	}
+	if infos := app.Describe(); len(infos) != 1 || infos[0].Group != "Database" {
+		t.Errorf("Describe = %+v", infos)
+	}
+}
@@ -0,0 +1,33 @@
+// Copyright 2018 Li Zebang. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package flag
+
+import "fmt"
+
+// MarkHidden hides the named flag from PrintDefaults and so from the
+// default usage message. A hidden flag is parsed, visited and looked up
+// like any other. MarkHidden panics if the flag is not defined.
+//
+// MarkHidden 在 PrintDefaults 中隐藏名为 name 的标志，因此默认的帮助信息中也不会出现它。
+// 被隐藏的标志和其他标志一样会被解析、遍历和查找。如果标志没有定义，MarkHidden 会 panic。
+func (f *FlagSet) MarkHidden(name string) {
+	flag, ok := f.formal[name]
+	if !ok {
+		msg := fmt.Sprintf("flag provided to MarkHidden but not defined: -%s", name)
+		if f.name != "" {
+			msg = f.name + " " + msg
+		}
+		fmt.Fprintln(f.Output(), msg)
+		panic(msg)
+	}
+	flag.Hidden = true
+}
+
+// MarkHidden hides the named command-line flag from the usage message.
+//
+// MarkHidden 在帮助信息中隐藏名为 name 的命令行标志。
+func MarkHidden(name string) {
+	CommandLine.MarkHidden(name)
+}
@@ -0,0 +1,46 @@
+// Copyright 2018 Li Zebang. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package flag_test
+
+import (
+	"bytes"
+	. "flag"
+	"io/ioutil"
+	"testing"
+)
+
+func TestMarkHidden(t *testing.T) {
+	fs := NewFlagSet("hidden", ContinueOnError)
+	var buf bytes.Buffer
+	fs.SetOutput(&buf)
+	fs.Bool("v", false, "verbose")
+	debug := fs.Bool("debug-internals", false, "dump internal state")
+	fs.MarkHidden("debug-internals")
+
+	fs.PrintDefaults()
+	if want := "  -v\tverbose\n"; buf.String() != want {
+		t.Errorf("PrintDefaults:\n%q\nwant:\n%q", buf.String(), want)
+	}
+	if err := fs.Parse([]string{"-debug-internals"}); err != nil {
+		t.Fatal(err)
+	}
+	if !*debug {
+		t.Error("hidden flag was not set")
+	}
+	if fl := fs.Lookup("debug-internals"); fl == nil || !fl.Hidden {
+		t.Errorf("Lookup(debug-internals) = %+v; want a hidden flag", fl)
+	}
+}
+
+func TestMarkHiddenPanics(t *testing.T) {
+	fs := NewFlagSet("hidden", ContinueOnError)
+	fs.SetOutput(ioutil.Discard)
+	defer func() {
+		if recover() == nil {
+			t.Error("MarkHidden of an undefined flag did not panic")
+		}
+	}()
+	fs.MarkHidden("missing")
+}
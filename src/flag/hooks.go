@@ -0,0 +1,69 @@
+// Copyright 2018 Li Zebang. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package flag
+
+// OnPreParse registers fn to rewrite the arguments before Parse reads
+// them, for instance to translate flags that were renamed or removed.
+// fn is given the arguments passed to Parse, or those returned by the
+// previous hook, since the hooks run in the order they were registered,
+// and returns the arguments to parse.
+//
+// OnPreParse 注册 fn，在 Parse 读取参数之前改写它们，例如翻译被重命名或者移除的标志。
+// 钩子按照注册的顺序运行，fn 接收传给 Parse 的参数或者前一个钩子返回的参数，并返回要解析
+// 的参数。
+func (f *FlagSet) OnPreParse(fn func(args []string) []string) {
+	f.preParse = append(f.preParse, fn)
+}
+
+// OnPreParse registers fn to rewrite the command-line arguments before
+// Parse reads them.
+//
+// OnPreParse 注册 fn，在 Parse 读取命令行参数之前改写它们。
+func OnPreParse(fn func(args []string) []string) {
+	CommandLine.OnPreParse(fn)
+}
+
+// OnPostParse registers fn to be called, in the order of registration,
+// once Parse has set the flags and checked them without error, for
+// instance to check constraints between flags. The first error returned
+// by a hook is printed with the usage message and handled like any other
+// parse error, so the following hooks are not called.
+//
+// OnPostParse 注册 fn，在 Parse 设置了标志并且检查无误之后按照注册的顺序调用，例如用来检查
+// 标志之间的约束。钩子返回的第一个错误会和用法信息一起被打印，并像其他解析错误一样被处理，
+// 所以之后的钩子不会被调用。
+func (f *FlagSet) OnPostParse(fn func() error) {
+	f.postParse = append(f.postParse, fn)
+}
+
+// OnPostParse registers fn to be called once Parse has set the
+// command-line flags.
+//
+// OnPostParse 注册 fn，在 Parse 设置了命令行标志之后调用。
+func OnPostParse(fn func() error) {
+	CommandLine.OnPostParse(fn)
+}
+
+// runPreParse returns args as rewritten by the OnPreParse hooks.
+//
+// runPreParse 返回经过 OnPreParse 钩子改写的 args。
+func (f *FlagSet) runPreParse(args []string) []string {
+	for _, fn := range f.preParse {
+		args = fn(args)
+	}
+	return args
+}
+
+// runPostParse calls the OnPostParse hooks until one fails.
+//
+// runPostParse 调用 OnPostParse 钩子，直到其中一个失败。
+func (f *FlagSet) runPostParse() error {
+	for _, fn := range f.postParse {
+		if err := fn(); err != nil {
+			return f.fail(err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+// Copyright 2018 Li Zebang. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package flag_test
+
+import (
+	"bytes"
+	"errors"
+	. "flag"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestOnPreParse(t *testing.T) {
+	fs := NewFlagSet("hooks", ContinueOnError)
+	workers := fs.Int("workers", 1, "")
+	verbose := fs.Bool("verbose", false, "")
+	// -threads 是 -workers 的旧名称
+	fs.OnPreParse(func(args []string) []string { // -threads is the old name of -workers
+		out := make([]string, 0, len(args))
+		for _, arg := range args {
+			out = append(out, strings.Replace(arg, "-threads", "-workers", 1))
+		}
+		return out
+	})
+	fs.OnPreParse(func(args []string) []string { return append([]string{"-verbose"}, args...) })
+	if err := fs.Parse([]string{"-threads=4", "file"}); err != nil {
+		t.Fatal(err)
+	}
+	if *workers != 4 || !*verbose || !reflect.DeepEqual(fs.Args(), []string{"file"}) {
+		t.Errorf("workers = %d, verbose = %v, args = %q", *workers, *verbose, fs.Args())
+	}
+}
+
+func TestOnPostParse(t *testing.T) {
+	for _, handling := range []ErrorHandling{ContinueOnError, CollectAllErrors} {
+		fs := NewFlagSet("hooks", handling)
+		var buf bytes.Buffer
+		fs.SetOutput(&buf)
+		min := fs.Int("min", 0, "")
+		max := fs.Int("max", 10, "")
+		var calls []string
+		errRange := errors.New("-min must not exceed -max")
+		fs.OnPostParse(func() error {
+			calls = append(calls, "range")
+			if *min > *max {
+				return errRange
+			}
+			return nil
+		})
+		fs.OnPostParse(func() error { calls = append(calls, "second"); return nil })
+
+		if err := fs.Parse([]string{"-min", "3"}); err != nil {
+			t.Errorf("%v: Parse = %v", handling, err)
+		}
+		if want := []string{"range", "second"}; !reflect.DeepEqual(calls, want) {
+			t.Errorf("%v: hooks called %q; want %q", handling, calls, want)
+		}
+
+		calls = nil
+		err := fs.Parse([]string{"-min", "30"})
+		if !errors.Is(err, errRange) {
+			t.Errorf("%v: Parse = %v; want %v", handling, err, errRange)
+		}
+		if want := []string{"range"}; !reflect.DeepEqual(calls, want) {
+			t.Errorf("%v: hooks called %q; want %q", handling, calls, want)
+		}
+		if !strings.HasPrefix(buf.String(), "-min must not exceed -max\nUsage of hooks:") {
+			t.Errorf("%v: output = %q", handling, buf.String())
+		}
+
+		calls = nil
+		if err := fs.Parse([]string{"-min", "x"}); err == nil || len(calls) != 0 {
+			t.Errorf("%v: Parse = %v, hooks called %q after an invalid value", handling, err, calls)
+		}
+	}
+}
@@ -0,0 +1,69 @@
+// Copyright 2018 Li Zebang. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package flag_test
+
+import (
+	. "flag"
+	"fmt"
+	"testing"
+)
+
+func TestInterspersed(t *testing.T) {
+	tests := []struct {
+		args    []string
+		verbose bool
+		n       int
+		rest    string
+	}{
+		{[]string{"build", "./pkg", "-v"}, true, 0, `["build" "./pkg"]`},
+		{[]string{"-n", "3", "build", "-v", "./pkg"}, true, 3, `["build" "./pkg"]`},
+		{[]string{"a", "-", "b"}, false, 0, `["a" "-" "b"]`},
+		{[]string{"a", "--", "-v", "b"}, false, 0, `["a" "-v" "b"]`},
+		{[]string{"a", "-n=2", "--", "--"}, false, 2, `["a" "--"]`},
+		{nil, false, 0, `[]`},
+	}
+	for _, tt := range tests {
+		fs := NewFlagSet("inter", ContinueOnError)
+		fs.SetParseMode(Interspersed)
+		v := fs.Bool("v", false, "verbose")
+		n := fs.Int("n", 0, "count")
+		if err := fs.Parse(tt.args); err != nil {
+			t.Fatalf("Parse(%q): %v", tt.args, err)
+		}
+		if *v != tt.verbose || *n != tt.n {
+			t.Errorf("Parse(%q): v = %v, n = %d", tt.args, *v, *n)
+		}
+		if got := fmt.Sprintf("%q", fs.Args()); got != tt.rest {
+			t.Errorf("Parse(%q): Args = %s; want %s", tt.args, got, tt.rest)
+		}
+	}
+}
+
+func TestInterspersedOff(t *testing.T) {
+	fs := NewFlagSet("inter", ContinueOnError)
+	v := fs.Bool("v", false, "verbose")
+	if err := fs.Parse([]string{"build", "-v"}); err != nil {
+		t.Fatal(err)
+	}
+	if *v || fs.NArg() != 2 {
+		t.Errorf("v = %v, Args = %q", *v, fs.Args())
+	}
+}
+
+func TestInterspersedReparse(t *testing.T) {
+	fs := NewFlagSet("inter", ContinueOnError)
+	fs.SetParseMode(Interspersed | CombinedShorts)
+	fs.Bool("a", false, "a")
+	fs.Bool("b", false, "b")
+	if err := fs.Parse([]string{"x", "-ab", "y"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := fs.Parse([]string{"z"}); err != nil {
+		t.Fatal(err)
+	}
+	if got := fmt.Sprintf("%q", fs.Args()); got != `["z"]` {
+		t.Errorf("Args = %s", got)
+	}
+}
@@ -0,0 +1,139 @@
+// Copyright 2018 Li Zebang. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package flag
+
+import "strings"
+
+// messages maps the English formats of error and usage messages to their
+// translations. A nil messages translates nothing.
+//
+// messages 将错误和用法信息的英文格式映射为它们的翻译。nil 的 messages 不翻译任何信息。
+type messages map[string]string
+
+// get returns the translation of format, or format itself if there is
+// none.
+//
+// get 返回 format 的翻译，如果没有翻译则返回 format 本身。
+func (m messages) get(format string) string {
+	if s, ok := m[format]; ok {
+		return s
+	}
+	return format
+}
+
+// catalogs holds the messages of each locale, keyed by lower-case locale
+// names such as "zh-cn" and "zh".
+//
+// catalogs 保存每个语言环境的信息，以小写的语言环境名称为键，例如 "zh-cn" 和 "zh"。
+var catalogs = map[string]messages{
+	"zh": {
+		"flag provided but not defined: -%s":       "提供了未定义的标志：-%s",
+		"flag provided in %s but not defined: -%s": "%s 中提供了未定义的标志：-%s",
+		"flag needs an argument: -%s":              "标志需要一个参数：-%s",
+		"invalid value %q for flag -%s: %v":        "标志 -%[2]s 的值 %[1]q 无效：%[3]v",
+		"invalid value %q in %s for flag -%s: %v":  "%[2]s 中标志 -%[3]s 的值 %[1]q 无效：%[4]v",
+		"invalid value %q for %s of flag -%s: %v":  "标志 -%[3]s 的 %[2]s 的值 %[1]q 无效：%[4]v",
+		"invalid boolean value %q for -%s: %v":     "-%[2]s 的布尔值 %[1]q 无效：%[3]v",
+		"invalid boolean flag %s: %v":              "无效的布尔标志 %s：%v",
+		"bad flag syntax: %s":                      "错误的标志语法：%s",
+		"flag -%s does not take a value":           "标志 -%s 不接受值",
+		"Usage of %s:\n":                           "%s 的用法：\n",
+		"\nArguments:\n":                           "\n参数：\n",
+		"missing required argument: %s":            "缺少必需的参数：%s",
+		"expected %d argument(s), got %d":          "需要 %d 个参数，实际为 %d 个",
+		"expected at least %d argument(s), got %d": "需要至少 %d 个参数，实际为 %d 个",
+		"expected at most %d argument(s), got %d":  "需要至多 %d 个参数，实际为 %d 个",
+		"expected %d to %d arguments, got %d":      "需要 %d 到 %d 个参数，实际为 %d 个",
+		"Usage:\n":                                 "用法：\n",
+	},
+}
+
+// RegisterMessages adds translations of error and usage messages for
+// locale, such as "zh-CN" or "fr", to those already registered. The keys
+// of catalog are the English formats of the messages, such as
+// "flag needs an argument: -%s", and the values must take the same
+// arguments, which may be reordered with explicit indexes such as %[2]s.
+// RegisterMessages should be called before SetLocale, typically from an
+// init function.
+//
+// RegisterMessages 为语言环境 locale（例如 "zh-CN" 或 "fr"）添加错误和用法信息的翻译。
+// catalog 的键是信息的英文格式，例如 "flag needs an argument: -%s"，值必须接受相同的参数，
+// 可以使用 %[2]s 这样的显式索引调整参数的顺序。RegisterMessages 应该在 SetLocale 之前
+// 调用，通常在 init 函数中调用。
+func RegisterMessages(locale string, catalog map[string]string) {
+	key := normalizeLocale(locale)
+	m := catalogs[key]
+	if m == nil {
+		m = make(messages)
+		catalogs[key] = m
+	}
+	for k, v := range catalog {
+		m[k] = v
+	}
+}
+
+// SetLocale sets the locale of the error and usage messages of the flag
+// set, such as "zh-CN" or "zh_CN.UTF-8", as found in the LANG environment
+// variable. A message missing from the catalog of the locale is looked up
+// in the catalog of its language, and is printed in English if it is not
+// found there either, as it is for unknown locales and "". The errors
+// returned by Value's Set methods are not translated.
+//
+// SetLocale 设置标志集的错误和用法信息的语言环境，例如 LANG 环境变量中的 "zh-CN" 或者
+// "zh_CN.UTF-8"。语言环境的目录中缺少的信息会在它的语言的目录中查找，如果也没有找到，就以
+// 英文打印，未知的语言环境和 "" 也是如此。Value 的 Set 方法返回的错误不会被翻译。
+func (f *FlagSet) SetLocale(locale string) {
+	key := normalizeLocale(locale)
+	lang := key
+	if i := strings.IndexByte(key, '-'); i >= 0 {
+		lang = key[:i]
+	}
+	f.messages = nil
+	if catalogs[key] == nil && catalogs[lang] == nil {
+		return
+	}
+	f.messages = make(messages)
+	for k, v := range catalogs[lang] {
+		f.messages[k] = v
+	}
+	if key != lang {
+		for k, v := range catalogs[key] {
+			f.messages[k] = v
+		}
+	}
+}
+
+// SetLocale sets the locale of the error and usage messages of the
+// command-line flags.
+//
+// SetLocale 设置命令行标志的错误和用法信息的语言环境。
+func SetLocale(locale string) {
+	CommandLine.SetLocale(locale)
+}
+
+// normalizeLocale turns a locale such as zh_CN.UTF-8 into zh-cn.
+//
+// normalizeLocale 将 zh_CN.UTF-8 这样的语言环境转换为 zh-cn。
+func normalizeLocale(locale string) string {
+	if i := strings.IndexAny(locale, ".@"); i >= 0 {
+		locale = locale[:i]
+	}
+	return strings.ToLower(strings.Replace(locale, "_", "-", -1))
+}
+
+// localize makes the errors of this package that Parse returns use the
+// messages of f.
+//
+// localize 使 Parse 返回的本包中的错误使用 f 的信息。
+func (f *FlagSet) localize(err error) {
+	switch e := err.(type) {
+	case *ErrUndefinedFlag:
+		e.messages = f.messages
+	case *ErrInvalidValue:
+		e.messages = f.messages
+	case *ErrMissingArgument:
+		e.messages = f.messages
+	}
+}
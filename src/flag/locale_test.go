@@ -0,0 +1,67 @@
+// Copyright 2018 Li Zebang. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package flag_test
+
+import (
+	"bytes"
+	"errors"
+	. "flag"
+	"testing"
+)
+
+func TestSetLocale(t *testing.T) {
+	tests := []struct {
+		locale string
+		args   []string
+		err    string
+		usage  string
+	}{
+		{"", []string{"-x"}, "flag provided but not defined: -x", "Usage of locale:\n"},
+		{"zh-CN", []string{"-x"}, "提供了未定义的标志：-x", "locale 的用法：\n"},
+		{"zh_CN.UTF-8", []string{"-n"}, "标志需要一个参数：-n", "locale 的用法：\n"},
+		{"zh", []string{"-n", "x"}, `标志 -n 的值 "x" 无效：parse error`, "locale 的用法：\n"},
+		{"zh-TW", []string{"-v=x"}, `-v 的布尔值 "x" 无效：strconv.ParseBool: parsing "x": invalid syntax`, "locale 的用法：\n"},
+		{"fr", []string{"---x"}, "bad flag syntax: ---x", "Usage of locale:\n"},
+	}
+	for _, tt := range tests {
+		fs := NewFlagSet("locale", ContinueOnError)
+		var buf bytes.Buffer
+		fs.SetOutput(&buf)
+		fs.Bool("v", false, "verbose")
+		fs.Var(errorValue{}, "n", "number")
+		fs.SetLocale(tt.locale)
+		err := fs.Parse(tt.args)
+		if err == nil || err.Error() != tt.err {
+			t.Errorf("SetLocale(%q): Parse(%q) = %v; want %s", tt.locale, tt.args, err, tt.err)
+		}
+		if want := tt.err + "\n" + tt.usage; !bytes.HasPrefix(buf.Bytes(), []byte(want)) {
+			t.Errorf("SetLocale(%q): output %q; want prefix %q", tt.locale, buf.String(), want)
+		}
+	}
+}
+
+// errorValue is a Value whose Set always fails.
+type errorValue struct{}
+
+func (errorValue) String() string   { return "" }
+func (errorValue) Set(string) error { return errors.New("parse error") }
+
+func TestRegisterMessages(t *testing.T) {
+	RegisterMessages("en-x-pirate", map[string]string{
+		"flag provided but not defined: -%s": "no such flag aboard: -%s",
+	})
+	fs := NewFlagSet("", ContinueOnError)
+	var buf bytes.Buffer
+	fs.SetOutput(&buf)
+	fs.SetLocale("en-x-pirate")
+	err := fs.Parse([]string{"-x"})
+	var undefined *ErrUndefinedFlag
+	if !errors.As(err, &undefined) || err.Error() != "no such flag aboard: -x" {
+		t.Errorf("Parse = %v", err)
+	}
+	if want := "no such flag aboard: -x\nUsage:\n"; buf.String() != want {
+		t.Errorf("output = %q; want %q", buf.String(), want)
+	}
+}
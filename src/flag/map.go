@@ -0,0 +1,226 @@
+// Copyright 2018 Li Zebang. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package flag
+
+import (
+	"errors"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// splitPairs splits a value of a map flag into its key=value pairs, which
+// are separated by commas.
+//
+// splitPairs 将 map 标志的一个值分成以逗号分隔的 key=value 对。
+func splitPairs(val string) (keys, values []string, err error) {
+	for _, pair := range splitSlice(val, ",") {
+		i := strings.IndexByte(pair, '=')
+		if i < 0 {
+			return nil, nil, errors.New(strconv.Quote(pair) + " must be formatted as key=value")
+		}
+		keys = append(keys, pair[:i])
+		values = append(values, pair[i+1:])
+	}
+	return keys, values, nil
+}
+
+// joinPairs formats the pairs of a map flag in the order of their keys.
+//
+// joinPairs 按照键的顺序格式化 map 标志的键值对。
+func joinPairs(m map[string]string) string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for i, k := range keys {
+		keys[i] = k + "=" + m[k]
+	}
+	return strings.Join(keys, ",")
+}
+
+// -- map[string]string Value
+//
+// Like the slice values, the first Set replaces the default map and every
+// later one adds to it, so -label env=prod -label team=infra,tier=web gives
+// all three labels. A key given again takes its latest value.
+//
+// 和切片的值一样，第一次 Set 会替换默认的 map，之后的每一次都向其中添加，所以
+// -label env=prod -label team=infra,tier=web 得到全部三个标签。再次给出的键取最后的值。
+type stringToStringValue struct {
+	p       *map[string]string
+	changed bool
+}
+
+func newStringToStringValue(val map[string]string, p *map[string]string) *stringToStringValue {
+	m := make(map[string]string, len(val))
+	for k, v := range val {
+		m[k] = v
+	}
+	*p = m
+	return &stringToStringValue{p: p}
+}
+
+func (s *stringToStringValue) Set(val string) error {
+	keys, values, err := splitPairs(val)
+	if err != nil {
+		return err
+	}
+	if !s.changed {
+		*s.p = make(map[string]string, len(keys))
+		s.changed = true
+	}
+	for i, k := range keys {
+		(*s.p)[k] = values[i]
+	}
+	return nil
+}
+
+func (s *stringToStringValue) Get() interface{} { return *s.p }
+
+func (s *stringToStringValue) reset() { s.changed = false }
+
+func (s *stringToStringValue) String() string {
+	if s.p == nil {
+		return ""
+	}
+	return joinPairs(*s.p)
+}
+
+// -- map[string]int Value
+type stringToIntValue struct {
+	p       *map[string]int
+	changed bool
+}
+
+func newStringToIntValue(val map[string]int, p *map[string]int) *stringToIntValue {
+	m := make(map[string]int, len(val))
+	for k, v := range val {
+		m[k] = v
+	}
+	*p = m
+	return &stringToIntValue{p: p}
+}
+
+func (s *stringToIntValue) Set(val string) error {
+	keys, values, err := splitPairs(val)
+	if err != nil {
+		return err
+	}
+	ints := make([]int, len(values))
+	for i, v := range values {
+		n, err := strconv.ParseInt(v, 0, strconv.IntSize)
+		if err != nil {
+			return err
+		}
+		ints[i] = int(n)
+	}
+	if !s.changed {
+		*s.p = make(map[string]int, len(keys))
+		s.changed = true
+	}
+	for i, k := range keys {
+		(*s.p)[k] = ints[i]
+	}
+	return nil
+}
+
+func (s *stringToIntValue) Get() interface{} { return *s.p }
+
+func (s *stringToIntValue) reset() { s.changed = false }
+
+func (s *stringToIntValue) String() string {
+	if s.p == nil {
+		return ""
+	}
+	m := make(map[string]string, len(*s.p))
+	for k, v := range *s.p {
+		m[k] = strconv.Itoa(v)
+	}
+	return joinPairs(m)
+}
+
+// StringToStringVar defines a map flag with specified name, default value, and
+// usage string. The argument p points to a map[string]string variable in which
+// to store the key=value pairs of the flag. The flag may be repeated, and
+// each of its values may hold several pairs separated by commas. The first
+// value given replaces the default.
+//
+// StringToStringVar 定义一个具有指定名称、默认值和用法信息的 map 标志。参数 p 指向一个用来
+// 存储标志的 key=value 对的 map[string]string 变量。该标志可以重复出现，它的每个值都可以
+// 包含多个以逗号分隔的键值对。给出的第一个值会替换默认值。
+func (f *FlagSet) StringToStringVar(p *map[string]string, name string, value map[string]string, usage string) {
+	f.Var(newStringToStringValue(value, p), name, usage)
+}
+
+// StringToStringVar defines a map flag with specified name, default value, and
+// usage string.
+//
+// StringToStringVar 定义一个具有指定名称、默认值和用法信息的 map 标志。
+func StringToStringVar(p *map[string]string, name string, value map[string]string, usage string) {
+	CommandLine.Var(newStringToStringValue(value, p), name, usage)
+}
+
+// StringToString defines a map flag with specified name, default value, and
+// usage string. The return value is the address of a map[string]string
+// variable that stores the key=value pairs of the flag.
+//
+// StringToString 定义一个具有指定名称、默认值和用法信息的 map 标志。返回值是存储标志的
+// key=value 对的 map[string]string 变量的地址。
+func (f *FlagSet) StringToString(name string, value map[string]string, usage string) *map[string]string {
+	p := new(map[string]string)
+	f.StringToStringVar(p, name, value, usage)
+	return p
+}
+
+// StringToString defines a map flag with specified name, default value, and
+// usage string.
+//
+// StringToString 定义一个具有指定名称、默认值和用法信息的 map 标志。
+func StringToString(name string, value map[string]string, usage string) *map[string]string {
+	return CommandLine.StringToString(name, value, usage)
+}
+
+// StringToIntVar defines a map flag with specified name, default value, and
+// usage string. The argument p points to a map[string]int variable in which
+// to store the key=value pairs of the flag. The flag may be repeated, and
+// each of its values may hold several pairs separated by commas. The first
+// value given replaces the default.
+//
+// StringToIntVar 定义一个具有指定名称、默认值和用法信息的 map 标志。参数 p 指向一个用来
+// 存储标志的 key=value 对的 map[string]int 变量。该标志可以重复出现，它的每个值都可以
+// 包含多个以逗号分隔的键值对。给出的第一个值会替换默认值。
+func (f *FlagSet) StringToIntVar(p *map[string]int, name string, value map[string]int, usage string) {
+	f.Var(newStringToIntValue(value, p), name, usage)
+}
+
+// StringToIntVar defines a map flag with specified name, default value, and
+// usage string.
+//
+// StringToIntVar 定义一个具有指定名称、默认值和用法信息的 map 标志。
+func StringToIntVar(p *map[string]int, name string, value map[string]int, usage string) {
+	CommandLine.Var(newStringToIntValue(value, p), name, usage)
+}
+
+// StringToInt defines a map flag with specified name, default value, and
+// usage string. The return value is the address of a map[string]int
+// variable that stores the key=value pairs of the flag.
+//
+// StringToInt 定义一个具有指定名称、默认值和用法信息的 map 标志。返回值是存储标志的
+// key=value 对的 map[string]int 变量的地址。
+func (f *FlagSet) StringToInt(name string, value map[string]int, usage string) *map[string]int {
+	p := new(map[string]int)
+	f.StringToIntVar(p, name, value, usage)
+	return p
+}
+
+// StringToInt defines a map flag with specified name, default value, and
+// usage string.
+//
+// StringToInt 定义一个具有指定名称、默认值和用法信息的 map 标志。
+func StringToInt(name string, value map[string]int, usage string) *map[string]int {
+	return CommandLine.StringToInt(name, value, usage)
+}
@@ -0,0 +1,103 @@
+// Copyright 2018 Li Zebang. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package flag_test
+
+import (
+	"bytes"
+	. "flag"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestStringToString(t *testing.T) {
+	tests := []struct {
+		args []string
+		want string
+	}{
+		{nil, "map[env:dev]"},
+		{[]string{"-label", "env=prod", "-label", "team=infra"}, "map[env:prod team:infra]"},
+		{[]string{"-label", "team=infra,tier=web"}, "map[team:infra tier:web]"},
+		{[]string{"-label", "a=1", "-label", "a=2=3"}, "map[a:2=3]"},
+		{[]string{"-label", "empty="}, "map[empty:]"},
+		{[]string{"-label="}, "map[]"},
+	}
+	for _, tt := range tests {
+		fs := NewFlagSet("map", ContinueOnError)
+		labels := fs.StringToString("label", map[string]string{"env": "dev"}, "labels")
+		if err := fs.Parse(tt.args); err != nil {
+			t.Fatal(err)
+		}
+		if got := fmt.Sprint(*labels); got != tt.want {
+			t.Errorf("Parse(%q): label = %s; want %s", tt.args, got, tt.want)
+		}
+	}
+}
+
+func TestStringToInt(t *testing.T) {
+	fs := NewFlagSet("map", ContinueOnError)
+	fs.SetOutput(new(bytes.Buffer))
+	var limits map[string]int
+	fs.StringToIntVar(&limits, "limit", nil, "limits")
+	if err := fs.Parse([]string{"-limit", "cpu=2,mem=0x10", "-limit", "disk=-1"}); err != nil {
+		t.Fatal(err)
+	}
+	if got := fmt.Sprint(limits); got != "map[cpu:2 disk:-1 mem:16]" {
+		t.Errorf("limit = %s", got)
+	}
+	if got := fs.Lookup("limit").Value.String(); got != "cpu=2,disk=-1,mem=16" {
+		t.Errorf("limit String() = %q", got)
+	}
+	if got, ok := fs.Lookup("limit").Value.(Getter).Get().(map[string]int); !ok || got["cpu"] != 2 {
+		t.Errorf("limit Get() = %#v", got)
+	}
+	if err := fs.Parse([]string{"-limit", "cpu=x"}); err == nil {
+		t.Error("expected error for invalid int value")
+	}
+	if limits["cpu"] != 2 {
+		t.Errorf("invalid value changed cpu to %d", limits["cpu"])
+	}
+}
+
+func TestMapMissingEquals(t *testing.T) {
+	fs := NewFlagSet("map", ContinueOnError)
+	fs.SetOutput(new(bytes.Buffer))
+	fs.StringToString("label", nil, "labels")
+	err := fs.Parse([]string{"-label", "env"})
+	if err == nil || !strings.Contains(err.Error(), `"env" must be formatted as key=value`) {
+		t.Errorf("Parse error = %v", err)
+	}
+}
+
+func TestMapDefaultNotAliased(t *testing.T) {
+	def := map[string]string{"env": "dev"}
+	fs := NewFlagSet("map", ContinueOnError)
+	fs.StringToString("label", def, "labels")
+	if err := fs.Parse([]string{"-label", "env=prod"}); err != nil {
+		t.Fatal(err)
+	}
+	if def["env"] != "dev" {
+		t.Errorf("setting the flag changed its default: %v", def)
+	}
+}
+
+func TestMapPrintDefaults(t *testing.T) {
+	fs := NewFlagSet("map", ContinueOnError)
+	var buf bytes.Buffer
+	fs.SetOutput(&buf)
+	fs.StringToString("label", map[string]string{"team": "infra", "env": "dev"}, "labels")
+	fs.StringToInt("limit", nil, "limits")
+	fs.PrintDefaults()
+	want := strings.Join([]string{
+		"  -label key=value",
+		"    \tlabels (default env=dev,team=infra)",
+		"  -limit key=value",
+		"    \tlimits",
+		"",
+	}, "\n")
+	if buf.String() != want {
+		t.Errorf("PrintDefaults:\n%q\nwant:\n%q", buf.String(), want)
+	}
+}
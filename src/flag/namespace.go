@@ -0,0 +1,79 @@
+// Copyright 2018 Li Zebang. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package flag
+
+import "fmt"
+
+// Namespace registers every flag defined in other in f under prefix, a dot
+// and its own name, so a reusable component can define -host once and a
+// host binary can mount it as -db.host. Aliases are prefixed the same way,
+// shorthands are dropped because they cannot be prefixed, and the groups
+// of MarkRequiredTogether are carried over with the prefixed names. Like
+// AddFlagSet, the flags share their Values with other but not whether they
+// are set, stay experimental with the same gate, and keep the watchers
+// added to them by Watch so far. They are turned on by the
+// -enable-experimental of f, which is not prefixed. Namespace panics if a
+// prefixed name is already taken in f.
+//
+// Namespace 将 other 中定义的每个标志以 prefix、一个点和标志自己的名称注册到 f 中，这样
+// 可复用的组件只需定义一次 -host，宿主程序就可以把它挂载为 -db.host。别名以同样的方式加上
+// 前缀，缩写由于无法加上前缀而被丢弃，MarkRequiredTogether 的各个组以加上前缀后的名称保留。
+// 和 AddFlagSet 一样，这些标志和 other 共享它们的 Value，但不共享它们是否被设置，它们仍然是
+// 使用同样开关的实验性标志，并且保留到目前为止 Watch 为它们添加的监视函数。开启它们的是 f 的
+// -enable-experimental，它不加前缀。如果加上前缀后的名称在 f 中已经被占用，Namespace 会
+// panic。
+func (f *FlagSet) Namespace(prefix string, other *FlagSet) {
+	// 正在添加的标志占用的名称和别名，以 foldKey 为键
+	added := make(map[string]bool) // names and aliases taken by the flags being added, by foldKey
+	taken := func(name string) bool {
+		return f.formal[name] != nil || f.shorthands[name] != nil || f.aliases[name] != nil || f.lookupFolded(name) != nil || added[f.foldKey(name)]
+	}
+	var flags []*Flag
+	// flags 中每个标志在 other 中对应的标志
+	var origins []*Flag // the flag of other that each of flags copies
+	for _, flag := range other.order {
+		if flag.Name == enableExperimental && other.experimental != nil {
+			// 加上前缀的 -enable-experimental 不会开启任何标志，由 f 自己的代替
+			continue // a prefixed -enable-experimental would turn nothing on, the one of f does
+		}
+		nf := *flag
+		nf.Name = prefix + "." + flag.Name
+		nf.Shorthand = ""
+		nf.Aliases = nil
+		for _, alias := range flag.Aliases {
+			nf.Aliases = append(nf.Aliases, prefix+"."+alias)
+		}
+		for _, name := range append([]string{nf.Name}, nf.Aliases...) {
+			if taken(name) {
+				msg := fmt.Sprintf("flag redefined: %s", name)
+				if f.name != "" {
+					msg = f.name + " " + msg
+				}
+				fmt.Fprintln(f.Output(), msg)
+				panic(msg)
+			}
+			added[f.foldKey(name)] = true
+		}
+		flags = append(flags, &nf)
+		origins = append(origins, flag)
+	}
+	f.addFlags(flags)
+	f.carryOver(other, flags, origins)
+	for _, group := range other.together {
+		names := make([]string, len(group))
+		for i, name := range group {
+			names[i] = prefix + "." + name
+		}
+		f.together = append(f.together, names)
+	}
+}
+
+// Namespace registers every flag defined in other in the command-line
+// flags under prefix.
+//
+// Namespace 将 other 中定义的每个标志以 prefix 为前缀注册到命令行标志中。
+func Namespace(prefix string, other *FlagSet) {
+	CommandLine.Namespace(prefix, other)
+}
@@ -0,0 +1,127 @@
+// Copyright 2018 Li Zebang. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package flag_test
+
+import (
+	"bytes"
+	. "flag"
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestNamespace(t *testing.T) {
+	app := NewFlagSet("app", ContinueOnError)
+	app.SetOutput(ioutil.Discard)
+	v := app.BoolP("verbose", "v", false, "verbose")
+	lib, host, port := newLibFlagSet("lib")
+	lib.Alias("server", "host")
+	lib.MarkRequiredTogether("host", "port")
+	app.Namespace("db", lib)
+	if got := flagNames(app); got != "db.host db.port verbose/v" {
+		t.Errorf("flags = %s", got)
+	}
+	if err := app.Parse([]string{"-v", "-db.server", "db.internal", "--db.port=6432"}); err != nil {
+		t.Fatal(err)
+	}
+	if !*v || *host != "db.internal" || *port != 6432 {
+		t.Errorf("verbose, host, port = %v, %q, %d", *v, *host, *port)
+	}
+	if lib.NFlag() != 0 || app.NFlag() != 3 {
+		t.Errorf("NFlag: lib %d, app %d", lib.NFlag(), app.NFlag())
+	}
+}
+
+func TestNamespaceRequiredTogether(t *testing.T) {
+	app := NewFlagSet("app", ContinueOnError)
+	app.SetOutput(ioutil.Discard)
+	lib, _, _ := newLibFlagSet("lib")
+	lib.MarkRequiredTogether("host", "port")
+	app.Namespace("db", lib)
+	err := app.Parse([]string{"-db.host", "x"})
+	want := "flags -db.host and -db.port must be set together: -db.host is set but -db.port is not"
+	if err == nil || err.Error() != want {
+		t.Errorf("Parse: %v; want %s", err, want)
+	}
+}
+
+func TestNamespaceRedefined(t *testing.T) {
+	app := NewFlagSet("app", ContinueOnError)
+	app.SetOutput(ioutil.Discard)
+	app.String("db.port", "", "taken")
+	lib, _, _ := newLibFlagSet("lib")
+	defer func() {
+		if r := recover(); r != "app flag redefined: db.port" {
+			t.Errorf("panic = %v", r)
+		}
+		if got := flagNames(app); got != "db.port" {
+			t.Errorf("flags after panic = %s", got)
+		}
+	}()
+	app.Namespace("db", lib)
+}
+
+func TestNamespaceExperimentalAndWatch(t *testing.T) {
+	defer setenv(t, "FLAG_TEST_TURBO", "")()
+	os.Unsetenv("FLAG_TEST_TURBO")
+	tests := []struct {
+		args    []string
+		turbo   bool
+		err     string
+		changes string
+	}{
+		{[]string{"--lib.turbo"}, false, "flag provided but not defined: -lib.turbo", ""},
+		{[]string{"--lib.turbo", "-enable-experimental"}, true, "", "true -> false"},
+	}
+	for _, tt := range tests {
+		app := NewFlagSet("app", ContinueOnError)
+		app.SetOutput(new(bytes.Buffer))
+		lib, _ := newExperimentalFlagSet(new(bytes.Buffer))
+		var changes []string
+		lib.Watch("turbo", func(old, new string) {
+			changes = append(changes, old+" -> "+new)
+		})
+		app.Namespace("lib", lib)
+		if app.Lookup("lib.enable-experimental") != nil {
+			t.Error("Namespace added -lib.enable-experimental")
+		}
+		err := app.Parse(tt.args)
+		got := ""
+		if err != nil {
+			got = err.Error()
+		}
+		if got != tt.err {
+			t.Errorf("Parse(%q) = %q; want %q", tt.args, got, tt.err)
+		}
+		if set := app.Lookup("lib.turbo").Value.String() == "true"; set != tt.turbo {
+			t.Errorf("Parse(%q): lib.turbo = %v; want %v", tt.args, set, tt.turbo)
+		}
+		if err := app.Set("lib.turbo", "false"); err != nil {
+			t.Fatal(err)
+		}
+		if got := strings.Join(changes, ", "); got != tt.changes {
+			t.Errorf("Parse(%q): changes = %q; want %q", tt.args, got, tt.changes)
+		}
+	}
+}
+
+func TestNamespaceCaseInsensitive(t *testing.T) {
+	app := NewFlagSet("app", ContinueOnError)
+	app.SetOutput(ioutil.Discard)
+	app.SetCaseInsensitive(true)
+	lib := NewFlagSet("lib", ContinueOnError)
+	lib.String("LogFile", "", "log file")
+	lib.String("logfile", "", "the same, regardless of case")
+	defer func() {
+		if r := recover(); r != "app flag redefined: db.logfile" {
+			t.Errorf("panic = %v", r)
+		}
+		if got := flagNames(app); got != "" {
+			t.Errorf("flags after panic = %s", got)
+		}
+	}()
+	app.Namespace("db", lib)
+}
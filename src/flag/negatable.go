@@ -0,0 +1,124 @@
+// Copyright 2018 Li Zebang. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package flag
+
+import "strings"
+
+// -- negatable bool Value
+//
+// A negatable bool flag named x also answers to -no-x, which sets it to
+// false, so a flag that defaults to true can be turned off without the
+// -x=false form.
+//
+// 名为 x 的可否定布尔标志也响应 -no-x，它将标志设置为 false，所以默认值为 true 的标志
+// 不需要使用 -x=false 的形式就可以关闭。
+type negatableBoolValue bool
+
+func newNegatableBoolValue(val bool, p *bool) *negatableBoolValue {
+	*p = val
+	return (*negatableBoolValue)(p)
+}
+
+func (b *negatableBoolValue) Set(s string) error { return (*boolValue)(b).Set(s) }
+
+func (b *negatableBoolValue) Get() interface{} { return bool(*b) }
+
+func (b *negatableBoolValue) String() string { return (*boolValue)(b).String() }
+
+func (b *negatableBoolValue) IsBoolFlag() bool { return true }
+
+// lookupNegated returns the negatable bool flag that name, such as no-x,
+// negates, or nil.
+//
+// lookupNegated 返回被 name（例如 no-x）否定的可否定布尔标志，或者 nil。
+func (f *FlagSet) lookupNegated(name string) *Flag {
+	if !strings.HasPrefix(name, "no-") {
+		return nil
+	}
+	flag := f.lookupName(name[len("no-"):])
+	if flag == nil {
+		return nil
+	}
+	if _, ok := flag.Value.(*negatableBoolValue); !ok {
+		return nil
+	}
+	return flag
+}
+
+// NegatableBoolVar defines a bool flag with specified name, default value,
+// and usage string, like BoolVar. The flag may also be given as -no-name,
+// which sets it to false and takes no value.
+//
+// NegatableBoolVar 和 BoolVar 一样，定义一个具有指定名称、默认值和用法信息的 bool 标志。
+// 该标志也可以以 -no-name 的形式给出，它将标志设置为 false，并且不接受值。
+//
+// IMP: 已定义的名为 no-name 的标志优先于否定形式。
+func (f *FlagSet) NegatableBoolVar(p *bool, name string, value bool, usage string) {
+	f.Var(newNegatableBoolValue(value, p), name, usage)
+}
+
+// NegatableBoolVar defines a bool flag with specified name, default value,
+// and usage string, which may also be given as -no-name.
+//
+// NegatableBoolVar 定义一个具有指定名称、默认值和用法信息的 bool 标志，它也可以以 -no-name
+// 的形式给出。
+func NegatableBoolVar(p *bool, name string, value bool, usage string) {
+	CommandLine.Var(newNegatableBoolValue(value, p), name, usage)
+}
+
+// NegatableBool defines a bool flag with specified name, default value,
+// and usage string, which may also be given as -no-name. The return value
+// is the address of a bool variable that stores the value of the flag.
+//
+// NegatableBool 定义一个具有指定名称、默认值和用法信息的 bool 标志，它也可以以 -no-name
+// 的形式给出。返回值是存储标志的值的 bool 变量的地址。
+func (f *FlagSet) NegatableBool(name string, value bool, usage string) *bool {
+	p := new(bool)
+	f.NegatableBoolVar(p, name, value, usage)
+	return p
+}
+
+// NegatableBool defines a bool flag with specified name, default value,
+// and usage string, which may also be given as -no-name.
+//
+// NegatableBool 定义一个具有指定名称、默认值和用法信息的 bool 标志，它也可以以 -no-name
+// 的形式给出。
+func NegatableBool(name string, value bool, usage string) *bool {
+	return CommandLine.NegatableBool(name, value, usage)
+}
+
+// NegatableBoolVarP is like NegatableBoolVar, but accepts a shorthand for
+// the flag. The shorthand has no negated form.
+//
+// NegatableBoolVarP 类似于 NegatableBoolVar，但接受该标志的缩写。缩写没有否定形式。
+func (f *FlagSet) NegatableBoolVarP(p *bool, name, shorthand string, value bool, usage string) {
+	f.VarP(newNegatableBoolValue(value, p), name, shorthand, usage)
+}
+
+// NegatableBoolVarP is like NegatableBoolVar, but accepts a shorthand for
+// the flag.
+//
+// NegatableBoolVarP 类似于 NegatableBoolVar，但接受该标志的缩写。
+func NegatableBoolVarP(p *bool, name, shorthand string, value bool, usage string) {
+	CommandLine.VarP(newNegatableBoolValue(value, p), name, shorthand, usage)
+}
+
+// NegatableBoolP is like NegatableBool, but accepts a shorthand for the
+// flag.
+//
+// NegatableBoolP 类似于 NegatableBool，但接受该标志的缩写。
+func (f *FlagSet) NegatableBoolP(name, shorthand string, value bool, usage string) *bool {
+	p := new(bool)
+	f.NegatableBoolVarP(p, name, shorthand, value, usage)
+	return p
+}
+
+// NegatableBoolP is like NegatableBool, but accepts a shorthand for the
+// flag.
+//
+// NegatableBoolP 类似于 NegatableBool，但接受该标志的缩写。
+func NegatableBoolP(name, shorthand string, value bool, usage string) *bool {
+	return CommandLine.NegatableBoolP(name, shorthand, value, usage)
+}
@@ -0,0 +1,86 @@
+// Copyright 2018 Li Zebang. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package flag_test
+
+import (
+	"bytes"
+	. "flag"
+	"strings"
+	"testing"
+)
+
+func TestNegatableBool(t *testing.T) {
+	tests := []struct {
+		args []string
+		want bool
+	}{
+		{nil, true},
+		{[]string{"-color"}, true},
+		{[]string{"-no-color"}, false},
+		{[]string{"--no-color"}, false},
+		{[]string{"-color=false"}, false},
+		{[]string{"-no-color", "-color"}, true},
+	}
+	for _, tt := range tests {
+		fs := NewFlagSet("neg", ContinueOnError)
+		color := fs.NegatableBool("color", true, "colorize output")
+		if err := fs.Parse(tt.args); err != nil {
+			t.Fatalf("Parse(%q): %v", tt.args, err)
+		}
+		if *color != tt.want {
+			t.Errorf("Parse(%q): color = %v; want %v", tt.args, *color, tt.want)
+		}
+		if len(tt.args) > 0 && fs.NFlag() != 1 {
+			t.Errorf("Parse(%q): NFlag = %d; want 1", tt.args, fs.NFlag())
+		}
+	}
+}
+
+func TestNegatableBoolErrors(t *testing.T) {
+	fs := NewFlagSet("neg", ContinueOnError)
+	fs.SetOutput(new(bytes.Buffer))
+	fs.NegatableBool("color", true, "colorize output")
+	fs.Bool("plain", true, "plain bool")
+	err := fs.Parse([]string{"-no-color=true"})
+	if err == nil || !strings.Contains(err.Error(), "flag -no-color does not take a value") {
+		t.Errorf("Parse error = %v", err)
+	}
+	err = fs.Parse([]string{"-no-plain"})
+	if err == nil || !strings.Contains(err.Error(), "flag provided but not defined: -no-plain") {
+		t.Errorf("Parse error = %v", err)
+	}
+}
+
+func TestNegatableBoolExplicitFlagWins(t *testing.T) {
+	fs := NewFlagSet("neg", ContinueOnError)
+	color := fs.NegatableBool("color", true, "colorize output")
+	noColor := fs.Bool("no-color", false, "separate flag")
+	if err := fs.Parse([]string{"-no-color"}); err != nil {
+		t.Fatal(err)
+	}
+	if !*color || !*noColor {
+		t.Errorf("color = %v, no-color = %v", *color, *noColor)
+	}
+}
+
+func TestNegatableBoolPrintDefaults(t *testing.T) {
+	fs := NewFlagSet("neg", ContinueOnError)
+	var buf bytes.Buffer
+	fs.SetOutput(&buf)
+	fs.NegatableBool("color", true, "colorize output")
+	var cache bool
+	fs.NegatableBoolVarP(&cache, "cache", "c", false, "use the cache")
+	fs.PrintDefaults()
+	want := strings.Join([]string{
+		"  -c, --cache, --no-cache",
+		"    \tuse the cache",
+		"  -color, -no-color",
+		"    \tcolorize output (default true)",
+		"",
+	}, "\n")
+	if buf.String() != want {
+		t.Errorf("PrintDefaults:\n%q\nwant:\n%q", buf.String(), want)
+	}
+}
@@ -0,0 +1,47 @@
+// Copyright 2018 Li Zebang. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package flag
+
+import "fmt"
+
+// SetNoOptDefVal makes the value of the named flag optional: given as
+// -name, the flag is set to value, while -name=x still sets it to x. The
+// value can then only be given after an equals sign, or directly after a
+// shorthand as in -pcpu, because -name x leaves x as an argument. Usage
+// messages show the value after the type of the flag, as in
+// -profile string[=cpu]. SetNoOptDefVal has no effect on boolean flags,
+// which never need a value, and panics if the flag is not defined or value
+// is empty.
+//
+// SetNoOptDefVal 使名为 name 的标志的值变为可选的：以 -name 给出时，标志被设置为 value，
+// 而 -name=x 仍然将它设置为 x。这样值只能在等号之后给出，或者像 -pcpu 那样直接跟在缩写
+// 后面，因为 -name x 会将 x 留作参数。用法信息在标志类型的后面显示这个值，例如
+// -profile string[=cpu]。SetNoOptDefVal 对从不需要值的布尔标志没有影响。如果标志没有定义
+// 或者 value 为空，SetNoOptDefVal 会 panic。
+func (f *FlagSet) SetNoOptDefVal(name, value string) {
+	flag, ok := f.formal[name]
+	var msg string
+	switch {
+	case !ok:
+		msg = fmt.Sprintf("flag provided to SetNoOptDefVal but not defined: -%s", name)
+	case value == "":
+		msg = fmt.Sprintf("empty value provided to SetNoOptDefVal for flag -%s", name)
+	}
+	if msg != "" {
+		if f.name != "" {
+			msg = f.name + " " + msg
+		}
+		fmt.Fprintln(f.Output(), msg)
+		panic(msg)
+	}
+	flag.NoOptDefVal = value
+}
+
+// SetNoOptDefVal makes the value of the named command-line flag optional.
+//
+// SetNoOptDefVal 使名为 name 的命令行标志的值变为可选的。
+func SetNoOptDefVal(name, value string) {
+	CommandLine.SetNoOptDefVal(name, value)
+}
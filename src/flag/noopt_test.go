@@ -0,0 +1,85 @@
+// Copyright 2018 Li Zebang. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package flag_test
+
+import (
+	"bytes"
+	. "flag"
+	"strings"
+	"testing"
+)
+
+func TestSetNoOptDefVal(t *testing.T) {
+	tests := []struct {
+		args    []string
+		profile string
+		rest    string
+	}{
+		{nil, "", ""},
+		{[]string{"-profile"}, "cpu", ""},
+		{[]string{"--profile", "x"}, "cpu", "x"},
+		{[]string{"-profile=mem"}, "mem", ""},
+		{[]string{"-profile="}, "", ""},
+		{[]string{"-p"}, "cpu", ""},
+		{[]string{"-vp", "x"}, "cpu", "x"},
+		{[]string{"-vpmem"}, "mem", ""},
+		{[]string{"-p=block"}, "block", ""},
+	}
+	for _, tt := range tests {
+		fs := NewFlagSet("noopt", ContinueOnError)
+		fs.SetParseMode(CombinedShorts)
+		fs.Bool("v", false, "verbose")
+		profile := fs.StringP("profile", "p", "", "write a `kind` of profile")
+		fs.SetNoOptDefVal("profile", "cpu")
+		if err := fs.Parse(tt.args); err != nil {
+			t.Errorf("Parse(%q): %v", tt.args, err)
+			continue
+		}
+		if *profile != tt.profile {
+			t.Errorf("Parse(%q): profile = %q; want %q", tt.args, *profile, tt.profile)
+		}
+		if rest := strings.Join(fs.Args(), " "); rest != tt.rest {
+			t.Errorf("Parse(%q): Args = %q; want %q", tt.args, rest, tt.rest)
+		}
+	}
+}
+
+func TestSetNoOptDefValUsage(t *testing.T) {
+	fs := NewFlagSet("noopt", ContinueOnError)
+	var buf bytes.Buffer
+	fs.SetOutput(&buf)
+	fs.String("profile", "", "write a `kind` of profile")
+	fs.SetNoOptDefVal("profile", "cpu")
+	fs.PrintDefaults()
+	if want := "  -profile kind[=cpu]\n    \twrite a kind of profile\n"; buf.String() != want {
+		t.Errorf("PrintDefaults = %q; want %q", buf.String(), want)
+	}
+	if infos := fs.Describe(); infos[0].NoOptDefVal != "cpu" {
+		t.Errorf("Describe = %+v", infos)
+	}
+}
+
+func TestSetNoOptDefValPanics(t *testing.T) {
+	tests := []struct {
+		name, value string
+		want        string
+	}{
+		{"missing", "x", "noopt flag provided to SetNoOptDefVal but not defined: -missing"},
+		{"profile", "", "noopt empty value provided to SetNoOptDefVal for flag -profile"},
+	}
+	for _, tt := range tests {
+		fs := NewFlagSet("noopt", ContinueOnError)
+		fs.SetOutput(new(bytes.Buffer))
+		fs.String("profile", "", "profile")
+		func() {
+			defer func() {
+				if r := recover(); r != tt.want {
+					t.Errorf("SetNoOptDefVal(%q, %q): panic = %v; want %q", tt.name, tt.value, r, tt.want)
+				}
+			}()
+			fs.SetNoOptDefVal(tt.name, tt.value)
+		}()
+	}
+}
@@ -0,0 +1,63 @@
+// Copyright 2018 Li Zebang. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package flag
+
+// VisitAllDeclared visits the flags in the order they were defined,
+// calling fn for each. It visits all flags, even those not set.
+//
+// VisitAllDeclared 按照标志被定义的顺序访问标志，并为每个标志调用 fn。它会访问所有标志，
+// 即使用户未设置它。
+func (f *FlagSet) VisitAllDeclared(fn func(*Flag)) {
+	for _, flag := range f.order {
+		fn(flag)
+	}
+}
+
+// VisitAllDeclared visits the command-line flags in the order they were
+// defined, calling fn for each.
+//
+// VisitAllDeclared 按照命令行标志被定义的顺序访问它们，并为每个标志调用 fn。
+func VisitAllDeclared(fn func(*Flag)) {
+	CommandLine.VisitAllDeclared(fn)
+}
+
+// SetSortFlags sets whether PrintDefaults, VisitAll and Visit present the
+// flags in lexicographical order, which is the default, or in the order
+// they were defined, which reads better when related flags are defined
+// together.
+//
+// SetSortFlags 设置 PrintDefaults、VisitAll 和 Visit 是以字典序（默认）还是按照标志被定义
+// 的顺序呈现标志，相关的标志被定义在一起时，后者更易于阅读。
+func (f *FlagSet) SetSortFlags(sort bool) {
+	f.unsorted = !sort
+}
+
+// SortFlags reports whether the flags are presented in lexicographical
+// order; see SetSortFlags.
+//
+// SortFlags 报告标志是否以字典序呈现，参见 SetSortFlags。
+func (f *FlagSet) SortFlags() bool {
+	return !f.unsorted
+}
+
+// SetSortFlags sets whether PrintDefaults, VisitAll and Visit present the
+// command-line flags in lexicographical order.
+//
+// SetSortFlags 设置 PrintDefaults、VisitAll 和 Visit 是否以字典序呈现命令行标志。
+func SetSortFlags(sort bool) {
+	CommandLine.SetSortFlags(sort)
+}
+
+// removeDeclared drops flag from the definition order.
+//
+// removeDeclared 从定义顺序中移除 flag。
+func (f *FlagSet) removeDeclared(flag *Flag) {
+	for i, fl := range f.order {
+		if fl == flag {
+			f.order = append(f.order[:i], f.order[i+1:]...)
+			return
+		}
+	}
+}
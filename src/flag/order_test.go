@@ -0,0 +1,93 @@
+// Copyright 2018 Li Zebang. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package flag_test
+
+import (
+	"bytes"
+	. "flag"
+	"strings"
+	"testing"
+)
+
+func declaredNames(fs *FlagSet) string {
+	var names []string
+	fs.VisitAllDeclared(func(f *Flag) { names = append(names, f.Name) })
+	return strings.Join(names, " ")
+}
+
+func TestVisitAllDeclared(t *testing.T) {
+	fs := NewFlagSet("order", ContinueOnError)
+	fs.String("zeta", "", "z")
+	fs.Int("alpha", 0, "a")
+	fs.Bool("mid", false, "m")
+	fs.Bool("beta", false, "b")
+	if got := declaredNames(fs); got != "zeta alpha mid beta" {
+		t.Errorf("declared = %s", got)
+	}
+	if err := fs.Undefine("mid"); err != nil {
+		t.Fatal(err)
+	}
+	fs.Bool("mid", false, "m")
+	if got := declaredNames(fs); got != "zeta alpha beta mid" {
+		t.Errorf("declared after redefinition = %s", got)
+	}
+
+	lib := NewFlagSet("lib", ContinueOnError)
+	lib.String("y", "", "y")
+	lib.String("x", "", "x")
+	if err := fs.AddFlagSet(lib, ConflictError); err != nil {
+		t.Fatal(err)
+	}
+	if got := declaredNames(fs); got != "zeta alpha beta mid y x" {
+		t.Errorf("declared after AddFlagSet = %s", got)
+	}
+}
+
+func TestPrintDefaultsUnsorted(t *testing.T) {
+	fs := NewFlagSet("order", ContinueOnError)
+	var buf bytes.Buffer
+	fs.SetOutput(&buf)
+	fs.String("name", "", "name")
+	fs.Bool("a", false, "a")
+	fs.SetSortFlags(false)
+	fs.PrintDefaults()
+	want := "  -name string\n    \tname\n  -a\ta\n"
+	if buf.String() != want {
+		t.Errorf("PrintDefaults:\n%q\nwant:\n%q", buf.String(), want)
+	}
+	buf.Reset()
+	fs.SetSortFlags(true)
+	fs.PrintDefaults()
+	want = "  -a\ta\n  -name string\n    \tname\n"
+	if buf.String() != want {
+		t.Errorf("sorted PrintDefaults:\n%q\nwant:\n%q", buf.String(), want)
+	}
+}
+
+func TestVisitUnsorted(t *testing.T) {
+	fs := NewFlagSet("order", ContinueOnError)
+	fs.Bool("zeta", false, "")
+	fs.Bool("alpha", false, "")
+	fs.Bool("mid", false, "")
+	if !fs.SortFlags() {
+		t.Error("SortFlags() = false by default")
+	}
+	fs.SetSortFlags(false)
+	if fs.SortFlags() {
+		t.Error("SortFlags() = true after SetSortFlags(false)")
+	}
+	if err := fs.Parse([]string{"-mid", "-zeta"}); err != nil {
+		t.Fatal(err)
+	}
+	var all, set []string
+	fs.VisitAll(func(f *Flag) { all = append(all, f.Name) })
+	fs.Visit(func(f *Flag) { set = append(set, f.Name) })
+	if got := strings.Join(all, " "); got != "zeta alpha mid" {
+		t.Errorf("VisitAll = %s", got)
+	}
+	if got := strings.Join(set, " "); got != "zeta mid" {
+		t.Errorf("Visit = %s", got)
+	}
+}
@@ -0,0 +1,116 @@
+// Copyright 2018 Li Zebang. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package flag
+
+import (
+	"errors"
+	"os"
+	"strings"
+)
+
+// ParseString splits s into arguments the way a POSIX shell splits words
+// and parses them with Parse, so flags can be read from a single string
+// such as an environment variable in the style of GOFLAGS. Arguments are
+// separated by spaces, tabs and newlines. Single quotes keep everything up
+// to the next single quote; double quotes keep everything up to the next
+// double quote, except that a backslash escapes a double quote or a
+// backslash; elsewhere a backslash escapes any character. No variables or
+// globs are expanded. An unterminated quote or a trailing backslash is an
+// error, handled according to f's ErrorHandling.
+//
+// ParseString 以 POSIX shell 拆分单词的方式将 s 拆分为参数并使用 Parse 解析它们，这样就
+// 可以从单个字符串中读取标志，例如 GOFLAGS 风格的环境变量。参数由空格、制表符和换行符分隔。
+// 单引号保留直到下一个单引号的所有内容；双引号保留直到下一个双引号的所有内容，但反斜杠可以
+// 转义双引号或反斜杠；在其他地方反斜杠可以转义任何字符。变量和通配符都不会被展开。未闭合的
+// 引号或者末尾的反斜杠是一个错误，按照 f 的 ErrorHandling 处理。
+func (f *FlagSet) ParseString(s string) error {
+	args, err := splitArgs(s)
+	if err == nil {
+		return f.Parse(args)
+	}
+	f.parsed = true
+	err = f.failf("cannot split %q: %v", s, err)
+	switch f.errorHandling {
+	case CollectAllErrors:
+		f.usage()
+		return ParseErrors{err}
+	case ExitOnError:
+		os.Exit(2)
+	case PanicOnError:
+		panic(err)
+	case WarnOnError:
+		return nil
+	}
+	return err
+}
+
+// ParseString splits s into arguments and parses them as the command-line
+// flags.
+//
+// ParseString 将 s 拆分为参数并将它们作为命令行标志解析。
+func ParseString(s string) error {
+	return CommandLine.ParseString(s)
+}
+
+// splitArgs splits s into arguments for ParseString.
+//
+// splitArgs 为 ParseString 将 s 拆分为参数。
+func splitArgs(s string) ([]string, error) {
+	var (
+		args []string
+		arg  strings.Builder
+		// 当前参数是否已经开始，这样 '' 也是一个参数
+		inArg bool // whether an argument has started, so that '' is one too
+		// 当前所在的引号，没有则为 0
+		quote byte // the quote we are in, or 0
+	)
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case quote == '\'':
+			if c == '\'' {
+				quote = 0
+			} else {
+				arg.WriteByte(c)
+			}
+		case quote == '"':
+			switch {
+			case c == '"':
+				quote = 0
+			case c == '\\' && i+1 < len(s) && (s[i+1] == '"' || s[i+1] == '\\'):
+				i++
+				arg.WriteByte(s[i])
+			default:
+				arg.WriteByte(c)
+			}
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			if inArg {
+				args = append(args, arg.String())
+				arg.Reset()
+				inArg = false
+			}
+		case c == '\'' || c == '"':
+			quote = c
+			inArg = true
+		case c == '\\':
+			if i+1 == len(s) {
+				return nil, errors.New("trailing backslash")
+			}
+			i++
+			arg.WriteByte(s[i])
+			inArg = true
+		default:
+			arg.WriteByte(c)
+			inArg = true
+		}
+	}
+	if quote != 0 {
+		return nil, errors.New("unterminated " + string(quote) + " quote")
+	}
+	if inArg {
+		args = append(args, arg.String())
+	}
+	return args, nil
+}
@@ -0,0 +1,73 @@
+// Copyright 2018 Li Zebang. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package flag_test
+
+import (
+	. "flag"
+	"io/ioutil"
+	"strings"
+	"testing"
+)
+
+func TestParseString(t *testing.T) {
+	tests := []struct {
+		in   string
+		args []string
+		err  string
+	}{
+		{"", nil, ""},
+		{"  \t\n ", nil, ""},
+		{`a b  c`, []string{"a", "b", "c"}, ""},
+		{`"a b" 'c d'`, []string{"a b", "c d"}, ""},
+		{`x"a b"y`, []string{"xa by"}, ""},
+		{`'' ""`, []string{"", ""}, ""},
+		{`'a\b' "a\b" a\b`, []string{`a\b`, `a\b`, "ab"}, ""},
+		{`"say \"hi\" \\ \$"`, []string{`say "hi" \ \$`}, ""},
+		{`'it'\''s'`, []string{"it's"}, ""},
+		{`a\ b`, []string{"a b"}, ""},
+		{`"a b`, nil, `cannot split "\"a b": unterminated " quote`},
+		{`'a`, nil, `cannot split "'a": unterminated ' quote`},
+		{`a\`, nil, `cannot split "a\\": trailing backslash`},
+	}
+	for _, tt := range tests {
+		fs := NewFlagSet("parsestring", ContinueOnError)
+		fs.SetOutput(ioutil.Discard)
+		err := fs.ParseString(tt.in)
+		if tt.err != "" {
+			if err == nil || err.Error() != tt.err {
+				t.Errorf("ParseString(%q): %v; want %s", tt.in, err, tt.err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("ParseString(%q): %v", tt.in, err)
+			continue
+		}
+		if got := fs.Args(); strings.Join(got, "|") != strings.Join(tt.args, "|") || len(got) != len(tt.args) {
+			t.Errorf("ParseString(%q): Args = %q; want %q", tt.in, got, tt.args)
+		}
+	}
+}
+
+func TestParseStringFlags(t *testing.T) {
+	fs := NewFlagSet("parsestring", ContinueOnError)
+	name := fs.String("name", "", "name")
+	n := fs.Int("n", 0, "count")
+	if err := fs.ParseString(`--name "a b" -n 3 rest`); err != nil {
+		t.Fatal(err)
+	}
+	if *name != "a b" || *n != 3 || fs.NArg() != 1 || fs.Arg(0) != "rest" {
+		t.Errorf("name, n, args = %q, %d, %q", *name, *n, fs.Args())
+	}
+}
+
+func TestParseStringCollectAllErrors(t *testing.T) {
+	fs := NewFlagSet("parsestring", CollectAllErrors)
+	fs.SetOutput(ioutil.Discard)
+	err := fs.ParseString(`-x 'a`)
+	if errs, ok := err.(ParseErrors); !ok || len(errs) != 1 {
+		t.Errorf("ParseString: %#v; want one ParseErrors", err)
+	}
+}
@@ -0,0 +1,198 @@
+// Copyright 2018 Li Zebang. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package flag
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+// A PathOption is a set of checks and transformations that a file or
+// directory flag applies to its value.
+//
+// PathOption 是一组文件或目录标志对它的值进行的检查和转换。
+type PathOption uint
+
+const (
+	// ExpandTilde replaces a leading ~ with the home directory of the
+	// user, so ~/.config becomes $HOME/.config. It applies to the default
+	// value too.
+	//
+	// ExpandTilde 将开头的 ~ 替换为用户的主目录，所以 ~/.config 会变成 $HOME/.config。
+	// 它也作用于默认值。
+	ExpandTilde PathOption = 1 << iota
+
+	// MustExist requires the path to exist, and to be a regular file or a
+	// directory as the flag asks.
+	//
+	// MustExist 要求路径存在，并且按照标志的要求是一个普通文件或者目录。
+	MustExist
+
+	// MustBeReadable requires that the path can be opened for reading, which
+	// implies MustExist.
+	//
+	// MustBeReadable 要求路径可以被打开用于读取，它隐含了 MustExist。
+	MustBeReadable
+)
+
+// IMP: 检查只在 Set 时进行，默认值不会被检查，因为它通常指向一个可选的文件。
+
+// -- path Value
+type pathValue struct {
+	p    *string
+	dir  bool
+	opts PathOption
+}
+
+func newPathValue(val string, p *string, dir bool, opts PathOption) *pathValue {
+	if opts&ExpandTilde != 0 {
+		if v, err := expandTilde(val); err == nil {
+			val = v
+		}
+	}
+	*p = val
+	return &pathValue{p: p, dir: dir, opts: opts}
+}
+
+func (v *pathValue) Set(s string) error {
+	path := s
+	if v.opts&ExpandTilde != 0 {
+		var err error
+		if path, err = expandTilde(path); err != nil {
+			return err
+		}
+	}
+	if v.opts&(MustExist|MustBeReadable) != 0 {
+		fi, err := os.Stat(path)
+		if err != nil {
+			return err
+		}
+		switch {
+		case v.dir && !fi.IsDir():
+			return errors.New(path + " is not a directory")
+		case !v.dir && fi.IsDir():
+			return errors.New(path + " is a directory")
+		}
+	}
+	if v.opts&MustBeReadable != 0 {
+		file, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		file.Close()
+	}
+	*v.p = path
+	return nil
+}
+
+func (v *pathValue) Get() interface{} { return *v.p }
+
+func (v *pathValue) String() string {
+	if v.p == nil {
+		return ""
+	}
+	return *v.p
+}
+
+// expandTilde replaces the ~ that starts path with the home directory.
+// Paths starting with ~user are left alone.
+//
+// expandTilde 将 path 开头的 ~ 替换为主目录。以 ~user 开头的路径保持不变。
+func expandTilde(path string) (string, error) {
+	if path != "~" && !strings.HasPrefix(path, "~/") && !strings.HasPrefix(path, "~"+string(filepath.Separator)) {
+		return path, nil
+	}
+	home := os.Getenv("HOME")
+	if home == "" && runtime.GOOS == "windows" {
+		home = os.Getenv("USERPROFILE")
+	}
+	if home == "" {
+		return "", errors.New("cannot expand ~ in " + path + ": home directory is unknown")
+	}
+	return home + path[1:], nil
+}
+
+// FileVar defines a file path flag with specified name, default value,
+// options, and usage string. The argument p points to a string variable in
+// which to store the path. With MustExist or MustBeReadable, Parse fails
+// if the path is a directory.
+//
+// FileVar 定义一个具有指定名称、默认值、选项和用法信息的文件路径标志。参数 p 指向一个用来
+// 存储路径的 string 变量。使用 MustExist 或 MustBeReadable 时，如果路径是一个目录，
+// Parse 会失败。
+func (f *FlagSet) FileVar(p *string, name string, value string, opts PathOption, usage string) {
+	f.Var(newPathValue(value, p, false, opts), name, usage)
+}
+
+// FileVar defines a file path flag with specified name, default value,
+// options, and usage string.
+//
+// FileVar 定义一个具有指定名称、默认值、选项和用法信息的文件路径标志。
+func FileVar(p *string, name string, value string, opts PathOption, usage string) {
+	CommandLine.Var(newPathValue(value, p, false, opts), name, usage)
+}
+
+// File defines a file path flag with specified name, default value,
+// options, and usage string. The return value is the address of a string
+// variable that stores the path.
+//
+// File 定义一个具有指定名称、默认值、选项和用法信息的文件路径标志。返回值是存储路径的
+// string 变量的地址。
+func (f *FlagSet) File(name string, value string, opts PathOption, usage string) *string {
+	p := new(string)
+	f.FileVar(p, name, value, opts, usage)
+	return p
+}
+
+// File defines a file path flag with specified name, default value,
+// options, and usage string.
+//
+// File 定义一个具有指定名称、默认值、选项和用法信息的文件路径标志。
+func File(name string, value string, opts PathOption, usage string) *string {
+	return CommandLine.File(name, value, opts, usage)
+}
+
+// DirVar defines a directory path flag with specified name, default value,
+// options, and usage string. The argument p points to a string variable in
+// which to store the path. With MustExist or MustBeReadable, Parse fails
+// if the path is not a directory.
+//
+// DirVar 定义一个具有指定名称、默认值、选项和用法信息的目录路径标志。参数 p 指向一个用来
+// 存储路径的 string 变量。使用 MustExist 或 MustBeReadable 时，如果路径不是一个目录，
+// Parse 会失败。
+func (f *FlagSet) DirVar(p *string, name string, value string, opts PathOption, usage string) {
+	f.Var(newPathValue(value, p, true, opts), name, usage)
+}
+
+// DirVar defines a directory path flag with specified name, default value,
+// options, and usage string.
+//
+// DirVar 定义一个具有指定名称、默认值、选项和用法信息的目录路径标志。
+func DirVar(p *string, name string, value string, opts PathOption, usage string) {
+	CommandLine.Var(newPathValue(value, p, true, opts), name, usage)
+}
+
+// Dir defines a directory path flag with specified name, default value,
+// options, and usage string. The return value is the address of a string
+// variable that stores the path.
+//
+// Dir 定义一个具有指定名称、默认值、选项和用法信息的目录路径标志。返回值是存储路径的
+// string 变量的地址。
+func (f *FlagSet) Dir(name string, value string, opts PathOption, usage string) *string {
+	p := new(string)
+	f.DirVar(p, name, value, opts, usage)
+	return p
+}
+
+// Dir defines a directory path flag with specified name, default value,
+// options, and usage string.
+//
+// Dir 定义一个具有指定名称、默认值、选项和用法信息的目录路径标志。
+func Dir(name string, value string, opts PathOption, usage string) *string {
+	return CommandLine.Dir(name, value, opts, usage)
+}
@@ -0,0 +1,103 @@
+// Copyright 2018 Li Zebang. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package flag_test
+
+import (
+	"bytes"
+	. "flag"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestPathFlags(t *testing.T) {
+	dir, err := ioutil.TempDir("", "flagpath")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	file := filepath.Join(dir, "config")
+	if err := ioutil.WriteFile(file, []byte("x"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	missing := filepath.Join(dir, "missing")
+
+	tests := []struct {
+		dir  bool
+		opts PathOption
+		arg  string
+		err  string
+	}{
+		{false, 0, missing, ""},
+		{false, MustExist, file, ""},
+		{false, MustExist, missing, "no such file or directory"},
+		{false, MustExist, dir, dir + " is a directory"},
+		{false, MustBeReadable, file, ""},
+		{false, MustBeReadable, missing, "no such file or directory"},
+		{true, MustExist, dir, ""},
+		{true, MustExist, file, file + " is not a directory"},
+		{true, MustBeReadable, dir, ""},
+	}
+	for _, tt := range tests {
+		fs := NewFlagSet("path", ContinueOnError)
+		fs.SetOutput(new(bytes.Buffer))
+		var p string
+		if tt.dir {
+			fs.DirVar(&p, "p", "", tt.opts, "path")
+		} else {
+			fs.FileVar(&p, "p", "", tt.opts, "path")
+		}
+		err := fs.Parse([]string{"-p", tt.arg})
+		switch {
+		case tt.err == "" && err != nil:
+			t.Errorf("dir=%v opts=%d Parse(%q): %v", tt.dir, tt.opts, tt.arg, err)
+		case tt.err == "" && p != tt.arg:
+			t.Errorf("dir=%v opts=%d Parse(%q): p = %q", tt.dir, tt.opts, tt.arg, p)
+		case tt.err != "" && (err == nil || !strings.Contains(err.Error(), tt.err)):
+			t.Errorf("dir=%v opts=%d Parse(%q) error = %v; want %q", tt.dir, tt.opts, tt.arg, err, tt.err)
+		}
+	}
+}
+
+func TestPathExpandTilde(t *testing.T) {
+	defer setenv(t, "HOME", "/home/gopher")()
+	fs := NewFlagSet("path", ContinueOnError)
+	conf := fs.File("conf", "~/.config/app", ExpandTilde, "config file")
+	data := fs.Dir("data", "", ExpandTilde, "data dir")
+	other := fs.File("other", "", ExpandTilde, "other")
+	if *conf != "/home/gopher/.config/app" {
+		t.Errorf("default conf = %q", *conf)
+	}
+	if err := fs.Parse([]string{"-data", "~", "-other", "~gopher/x"}); err != nil {
+		t.Fatal(err)
+	}
+	if *data != "/home/gopher" {
+		t.Errorf("data = %q", *data)
+	}
+	if *other != "~gopher/x" {
+		t.Errorf("other = %q", *other)
+	}
+}
+
+func TestPathPrintDefaults(t *testing.T) {
+	fs := NewFlagSet("path", ContinueOnError)
+	var buf bytes.Buffer
+	fs.SetOutput(&buf)
+	fs.File("conf", "app.conf", 0, "config")
+	fs.Dir("data", "", MustExist, "data")
+	fs.PrintDefaults()
+	want := strings.Join([]string{
+		"  -conf file",
+		"    \tconfig (default app.conf)",
+		"  -data dir",
+		"    \tdata",
+		"",
+	}, "\n")
+	if buf.String() != want {
+		t.Errorf("PrintDefaults:\n%q\nwant:\n%q", buf.String(), want)
+	}
+}
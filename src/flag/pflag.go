@@ -0,0 +1,195 @@
+// Copyright 2018 Li Zebang. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package flag
+
+// TypedValue is a Value that names its type, like the Value interface of
+// github.com/spf13/pflag. Any pflag.Value is a TypedValue and so can be
+// passed to Var directly, or through FromPflag when it is a boolean; the
+// usage message then shows the name returned by Type.
+//
+// TypedValue 是一个给出自己的类型名称的 Value，就像 github.com/spf13/pflag 的 Value
+// 接口一样。所有的 pflag.Value 都是 TypedValue，所以可以直接传给 Var，布尔值则需要通过
+// FromPflag 传入；用法信息会显示 Type 返回的名称。
+type TypedValue interface {
+	Value
+	Type() string
+}
+
+// IMP: pflag 的布尔标志在没有值时使用 NoOptDefVal，而本包根据 IsBoolFlag 判断，所以
+// FromPflag 为类型为 "bool" 的值补上 IsBoolFlag。
+
+// fromPflagValue adapts a pflag.Value to this package, and fromPflagBool
+// a boolean one.
+//
+// fromPflagValue 将 pflag.Value 适配到本包，fromPflagBool 适配布尔值。
+type (
+	fromPflagValue struct{ TypedValue }
+	fromPflagBool  struct{ fromPflagValue }
+)
+
+func (v fromPflagBool) IsBoolFlag() bool { return true }
+
+func (v fromPflagValue) unwrap() Value { return v.TypedValue }
+
+func (v fromPflagValue) Get() interface{} {
+	if g, ok := v.TypedValue.(Getter); ok {
+		return g.Get()
+	}
+	return v.String()
+}
+
+// FromPflag returns a Value for v, a pflag.Value, that can be passed to
+// Var. A value whose Type is "bool" is a boolean flag, so it may be given
+// without an argument as in pflag.
+//
+// FromPflag 返回 v（一个 pflag.Value）的 Value，它可以被传给 Var。Type 为 "bool" 的值是
+// 布尔标志，所以它可以像在 pflag 中一样不带参数给出。
+func FromPflag(v TypedValue) Value {
+	switch p := v.(type) {
+	case toPflagValue:
+		return p.Value
+	case toPflagBool:
+		return p.Value
+	case boolFlag:
+		if p.IsBoolFlag() {
+			return fromPflagBool{fromPflagValue{v}}
+		}
+	}
+	if v.Type() == "bool" {
+		return fromPflagBool{fromPflagValue{v}}
+	}
+	return fromPflagValue{v}
+}
+
+// toPflagValue adapts a Value of this package to pflag, and toPflagBool a
+// boolean one.
+//
+// toPflagValue 将本包的 Value 适配到 pflag，toPflagBool 适配布尔值。
+type (
+	toPflagValue struct{ Value }
+	toPflagBool  struct{ toPflagValue }
+)
+
+func (v toPflagValue) Type() string { return pflagType(v.Value) }
+
+func (v toPflagValue) unwrap() Value { return v.Value }
+
+func (v toPflagBool) IsBoolFlag() bool { return true }
+
+// ToPflag returns a TypedValue for v that can be passed to the Var method
+// of a pflag.FlagSet. Its Type is the name pflag uses for the same kind of
+// value, such as "int64" or "stringSlice", so pflag's typed getters such
+// as GetInt64 work, and "value" for types pflag does not have. A pflag
+// boolean flag still needs its NoOptDefVal set to "true".
+//
+// ToPflag 返回 v 的 TypedValue，它可以被传给 pflag.FlagSet 的 Var 方法。它的 Type 是
+// pflag 对同类值使用的名称，例如 "int64" 或 "stringSlice"，所以 pflag 的 GetInt64 等
+// 类型化的获取方法可以使用，pflag 中没有的类型则是 "value"。pflag 的布尔标志仍然需要将它的
+// NoOptDefVal 设为 "true"。
+func ToPflag(v Value) TypedValue {
+	switch p := v.(type) {
+	case fromPflagValue:
+		return p.TypedValue
+	case fromPflagBool:
+		return p.TypedValue
+	case TypedValue:
+		return p
+	case boolFlag:
+		if p.IsBoolFlag() {
+			return toPflagBool{toPflagValue{v}}
+		}
+	}
+	return toPflagValue{v}
+}
+
+// pflagType returns the name pflag uses for the type of v.
+//
+// pflagType 返回 pflag 对 v 的类型使用的名称。
+func pflagType(v Value) string {
+	switch v.(type) {
+	case *boolValue, *negatableBoolValue:
+		return "bool"
+	case *countValue:
+		return "count"
+	case *intValue:
+		return "int"
+	case *int8Value:
+		return "int8"
+	case *int16Value:
+		return "int16"
+	case *int32Value:
+		return "int32"
+	case *int64Value:
+		return "int64"
+	case *uintValue:
+		return "uint"
+	case *uint8Value:
+		return "uint8"
+	case *uint16Value:
+		return "uint16"
+	case *uint32Value:
+		return "uint32"
+	case *uint64Value:
+		return "uint64"
+	case *float32Value:
+		return "float32"
+	case *float64Value:
+		return "float64"
+	case *stringValue:
+		return "string"
+	case *durationValue:
+		return "duration"
+	case *stringSliceValue:
+		return "stringSlice"
+	case *intSliceValue:
+		return "intSlice"
+	case *int64SliceValue:
+		return "int64Slice"
+	case *float64SliceValue:
+		return "float64Slice"
+	case *durationSliceValue:
+		return "durationSlice"
+	case *stringToStringValue:
+		return "stringToString"
+	case *stringToIntValue:
+		return "stringToInt"
+	}
+	return "value"
+}
+
+// pflagUsageName returns the name UnquoteUsage shows for a value whose
+// Type is typ, shortened as pflag does.
+//
+// pflagUsageName 返回 UnquoteUsage 为 Type 为 typ 的值显示的名称，像 pflag 一样缩短。
+func pflagUsageName(typ string) string {
+	switch typ {
+	case "bool":
+		return ""
+	case "float64":
+		return "float"
+	case "int64":
+		return "int"
+	case "uint64":
+		return "uint"
+	case "stringSlice":
+		return "strings"
+	case "intSlice":
+		return "ints"
+	case "uintSlice":
+		return "uints"
+	case "boolSlice":
+		return "bools"
+	case "bytesHex":
+		return "hex"
+	case "bytesBase64":
+		return "base64"
+	// flag/flagbig 的类型
+	case "bigInt": // types of flag/flagbig
+		return "int"
+	case "bigFloat":
+		return "float"
+	}
+	return typ
+}
@@ -0,0 +1,101 @@
+// Copyright 2018 Li Zebang. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package flag_test
+
+import (
+	"bytes"
+	. "flag"
+	"net"
+	"strconv"
+	"testing"
+)
+
+// pflagBool and pflagIP imitate values of github.com/spf13/pflag.
+//
+// pflagBool 和 pflagIP 模仿 github.com/spf13/pflag 的值。
+type pflagBool bool
+
+func (b *pflagBool) Set(s string) error {
+	v, err := strconv.ParseBool(s)
+	*b = pflagBool(v)
+	return err
+}
+func (b *pflagBool) String() string { return strconv.FormatBool(bool(*b)) }
+func (b *pflagBool) Type() string   { return "bool" }
+
+type pflagIP net.IP
+
+func (ip *pflagIP) Set(s string) error {
+	*ip = pflagIP(net.ParseIP(s))
+	return nil
+}
+func (ip *pflagIP) String() string { return net.IP(*ip).String() }
+func (ip *pflagIP) Type() string   { return "ip" }
+
+func TestFromPflag(t *testing.T) {
+	var buf bytes.Buffer
+	fs := NewFlagSet("pflag", ContinueOnError)
+	fs.SetOutput(&buf)
+	b := new(pflagBool)
+	ip := &pflagIP{}
+	fs.Var(FromPflag(b), "dry-run", "dry run")
+	fs.Var(FromPflag(ip), "bind", "bind `address`")
+	fs.Var(ip, "addr", "address")
+	if err := fs.Parse([]string{"-dry-run", "-addr", "10.0.0.1"}); err != nil {
+		t.Fatal(err)
+	}
+	if !*b || ip.String() != "10.0.0.1" {
+		t.Errorf("dry-run = %v, addr = %v", *b, ip)
+	}
+	fs.PrintDefaults()
+	want := "  -addr ip\n    \taddress\n  -bind address\n    \tbind address\n  -dry-run\n    \tdry run\n"
+	if buf.String() != want {
+		t.Errorf("PrintDefaults:\n%s\nwant:\n%s", buf.String(), want)
+	}
+	if got := fs.Lookup("dry-run").Value.(Getter).Get(); got != "true" {
+		t.Errorf("Get() = %v; want %q", got, "true")
+	}
+}
+
+func TestToPflag(t *testing.T) {
+	fs := NewFlagSet("pflag", ContinueOnError)
+	fs.Int64("n", 0, "n")
+	fs.StringSlice("tag", nil, "tags")
+	fs.Bool("v", false, "verbose")
+	fs.Var(new(pflagIP), "ip", "ip")
+	tests := []struct {
+		name, typ string
+		isBool    bool
+		wrapped   bool
+	}{
+		{"n", "int64", false, true},
+		{"tag", "stringSlice", false, true},
+		{"v", "bool", true, true},
+		{"ip", "ip", false, false},
+	}
+	for _, tt := range tests {
+		v := fs.Lookup(tt.name).Value
+		p := ToPflag(v)
+		if p.Type() != tt.typ {
+			t.Errorf("ToPflag(-%s).Type() = %q; want %q", tt.name, p.Type(), tt.typ)
+		}
+		_, isBool := p.(interface{ IsBoolFlag() bool })
+		if isBool != tt.isBool {
+			t.Errorf("ToPflag(-%s) is a boolean flag: %v; want %v", tt.name, isBool, tt.isBool)
+		}
+		if !tt.wrapped {
+			if p != v {
+				t.Errorf("ToPflag(-%s) wrapped a pflag.Value", tt.name)
+			}
+			continue
+		}
+		if back := FromPflag(p); back != v {
+			t.Errorf("FromPflag(ToPflag(-%s)) = %#v; want the original value", tt.name, back)
+		}
+	}
+	if err := ToPflag(fs.Lookup("n").Value).Set("42"); err != nil || fs.Lookup("n").Value.String() != "42" {
+		t.Errorf("Set through the adapter: %v, value %s", err, fs.Lookup("n").Value)
+	}
+}
@@ -0,0 +1,172 @@
+// Copyright 2018 Li Zebang. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package flag
+
+import (
+	"fmt"
+	"strings"
+)
+
+// A PositionalOption is a set of options of a positional argument declared
+// with Positional.
+//
+// PositionalOption 是用 Positional 声明的位置参数的一组选项。
+type PositionalOption uint
+
+const (
+	// Required makes Parse fail when the argument is missing.
+	//
+	// Required 使 Parse 在缺少该参数时失败。
+	Required PositionalOption = 1 << iota
+
+	// Variadic makes the argument take all the remaining arguments. It can
+	// only be given to the last positional argument.
+	//
+	// Variadic 使该参数获取剩余的所有参数。只有最后一个位置参数可以使用它。
+	Variadic
+)
+
+// positionalArg is a positional argument declared with Positional.
+//
+// positionalArg 是用 Positional 声明的位置参数。
+type positionalArg struct {
+	name  string
+	usage string
+	opts  PositionalOption
+}
+
+// Positional declares the next positional argument, the one left in Args
+// after the flags, so that the default usage message lists it under
+// Arguments, Parse checks that it is present if it is Required, and
+// ArgByName returns it. Required arguments must come before optional ones,
+// and nothing can follow a Variadic one. Positional panics if name is
+// empty or already declared, or if the order is broken.
+//
+// Positional 声明下一个位置参数，即标志之后留在 Args 中的参数，这样默认的用法信息会在
+// Arguments 下列出它，如果它是 Required 的，Parse 会检查它是否存在，并且 ArgByName 会
+// 返回它。必需的参数必须在可选的参数之前，Variadic 参数之后不能再有参数。如果 name 为空或者
+// 已经声明过，或者顺序不正确，Positional 会 panic。
+func (f *FlagSet) Positional(name, usage string, opts PositionalOption) {
+	var msg string
+	switch {
+	case name == "":
+		msg = "empty name provided to Positional"
+	case f.positionalIndex(name) >= 0:
+		msg = fmt.Sprintf("positional argument redefined: %s", name)
+	case len(f.positionals) > 0:
+		last := f.positionals[len(f.positionals)-1]
+		if last.opts&Variadic != 0 {
+			msg = fmt.Sprintf("positional argument %s follows variadic argument %s", name, last.name)
+		} else if opts&Required != 0 && last.opts&Required == 0 {
+			msg = fmt.Sprintf("required positional argument %s follows optional argument %s", name, last.name)
+		}
+	}
+	if msg != "" {
+		if f.name != "" {
+			msg = f.name + " " + msg
+		}
+		fmt.Fprintln(f.Output(), msg)
+		panic(msg)
+	}
+	f.positionals = append(f.positionals, positionalArg{name, usage, opts})
+}
+
+// Positional declares the next positional argument of the command line.
+//
+// Positional 声明命令行的下一个位置参数。
+func Positional(name, usage string, opts PositionalOption) {
+	CommandLine.Positional(name, usage, opts)
+}
+
+// ArgByName returns the positional argument declared as name, or, for a
+// Variadic one, the first of its arguments. It returns an empty string if
+// the argument is missing or not declared.
+//
+// ArgByName 返回声明为 name 的位置参数，对于 Variadic 参数则返回它的第一个参数。如果
+// 参数不存在或者没有声明，则返回空字符串。
+func (f *FlagSet) ArgByName(name string) string {
+	if args := f.ArgsByName(name); len(args) > 0 {
+		return args[0]
+	}
+	return ""
+}
+
+// ArgByName returns the positional argument of the command line declared
+// as name.
+//
+// ArgByName 返回命令行中声明为 name 的位置参数。
+func ArgByName(name string) string {
+	return CommandLine.ArgByName(name)
+}
+
+// ArgsByName returns the arguments of the Variadic positional argument
+// declared as name, or a slice holding the positional argument declared as
+// name. It returns nil if the argument is missing or not declared.
+//
+// ArgsByName 返回声明为 name 的 Variadic 位置参数的所有参数，或者包含声明为 name 的位置
+// 参数的切片。如果参数不存在或者没有声明，则返回 nil。
+func (f *FlagSet) ArgsByName(name string) []string {
+	i := f.positionalIndex(name)
+	if i < 0 || i >= len(f.args) {
+		return nil
+	}
+	if f.positionals[i].opts&Variadic != 0 {
+		return f.args[i:]
+	}
+	return f.args[i : i+1]
+}
+
+// ArgsByName returns the arguments of the command line declared as name.
+//
+// ArgsByName 返回命令行中声明为 name 的参数。
+func ArgsByName(name string) []string {
+	return CommandLine.ArgsByName(name)
+}
+
+// positionalIndex returns the index of the positional argument declared as
+// name, or -1.
+//
+// positionalIndex 返回声明为 name 的位置参数的索引，或者 -1。
+func (f *FlagSet) positionalIndex(name string) int {
+	for i, arg := range f.positionals {
+		if arg.name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// checkPositionals reports the first Required positional argument that is
+// missing.
+//
+// checkPositionals 报告第一个缺少的 Required 位置参数。
+func (f *FlagSet) checkPositionals() error {
+	// 必需的参数在最前面，所以第一个缺少的参数就是 f.args 之后的那个
+	if n := len(f.args); n < len(f.positionals) && f.positionals[n].opts&Required != 0 { // required arguments come first
+		return f.failf("missing required argument: %s", f.positionals[n].name)
+	}
+	return nil
+}
+
+// printPositionals prints the positional arguments declared with
+// Positional for the default usage message.
+//
+// printPositionals 为默认的用法信息打印用 Positional 声明的位置参数。
+func (f *FlagSet) printPositionals() {
+	if len(f.positionals) == 0 {
+		return
+	}
+	fmt.Fprint(f.Output(), f.messages.get("\nArguments:\n"))
+	for _, arg := range f.positionals {
+		name := arg.name
+		if arg.opts&Variadic != 0 {
+			name += "..."
+		}
+		if arg.opts&Required == 0 {
+			name = "[" + name + "]"
+		}
+		fmt.Fprintf(f.Output(), "  %s\n    \t%s\n", name, strings.Replace(arg.usage, "\n", "\n    \t", -1))
+	}
+}
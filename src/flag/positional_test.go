@@ -0,0 +1,143 @@
+// Copyright 2018 Li Zebang. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package flag_test
+
+import (
+	"bytes"
+	. "flag"
+	"os"
+	"strings"
+	"testing"
+)
+
+func newPositionalFlagSet(out *bytes.Buffer) *FlagSet {
+	fs := NewFlagSet("cp", ContinueOnError)
+	fs.SetOutput(out)
+	fs.Bool("v", false, "verbose")
+	fs.Positional("SRC", "file to copy", Required)
+	fs.Positional("DST", "where to copy it", Required)
+	fs.Positional("MORE", "more files\nto copy", Variadic)
+	return fs
+}
+
+func TestPositional(t *testing.T) {
+	tests := []struct {
+		args     []string
+		src, dst string
+		more     string
+		err      string
+	}{
+		{[]string{"a", "b"}, "a", "b", "", ""},
+		{[]string{"-v", "a", "b", "c", "d"}, "a", "b", "c d", ""},
+		{[]string{"-v", "a"}, "a", "", "", "missing required argument: DST"},
+		{[]string{"-v"}, "", "", "", "missing required argument: SRC"},
+	}
+	for _, tt := range tests {
+		var buf bytes.Buffer
+		fs := newPositionalFlagSet(&buf)
+		err := fs.Parse(tt.args)
+		if tt.err != "" {
+			if err == nil || err.Error() != tt.err {
+				t.Errorf("Parse(%q): %v; want %s", tt.args, err, tt.err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Parse(%q): %v", tt.args, err)
+			continue
+		}
+		more := strings.Join(fs.ArgsByName("MORE"), " ")
+		if fs.ArgByName("SRC") != tt.src || fs.ArgByName("DST") != tt.dst || more != tt.more {
+			t.Errorf("Parse(%q): SRC, DST, MORE = %q, %q, %q", tt.args, fs.ArgByName("SRC"), fs.ArgByName("DST"), more)
+		}
+	}
+}
+
+func TestPositionalUsage(t *testing.T) {
+	var buf bytes.Buffer
+	fs := newPositionalFlagSet(&buf)
+	fs.Parse([]string{"-h"})
+	want := strings.Join([]string{
+		"Usage of cp:",
+		"  -v\tverbose",
+		"",
+		"Arguments:",
+		"  SRC",
+		"    \tfile to copy",
+		"  DST",
+		"    \twhere to copy it",
+		"  [MORE...]",
+		"    \tmore files",
+		"    \tto copy",
+		"",
+	}, "\n")
+	if buf.String() != want {
+		t.Errorf("usage:\n%s\nwant:\n%s", buf.String(), want)
+	}
+	if fs.ArgByName("NOPE") != "" || fs.ArgsByName("NOPE") != nil {
+		t.Error("undeclared argument found")
+	}
+}
+
+func TestPositionalUsageCommandLine(t *testing.T) {
+	ResetForTesting(DefaultUsage)
+	defer ResetForTesting(nil)
+	var buf bytes.Buffer
+	CommandLine.SetOutput(&buf)
+	Bool("v", false, "verbose")
+	Positional("SRC", "file to copy", Required)
+	defer func(old []string) { os.Args = old }(os.Args)
+	os.Args = []string{"cp", "-h"}
+	Parse()
+	want := strings.Join([]string{
+		"Usage of cp:",
+		"  -v\tverbose",
+		"",
+		"Arguments:",
+		"  SRC",
+		"    \tfile to copy",
+		"",
+	}, "\n")
+	if buf.String() != want {
+		t.Errorf("usage:\n%s\nwant:\n%s", buf.String(), want)
+	}
+}
+
+func TestPositionalCollectAllErrors(t *testing.T) {
+	fs := NewFlagSet("cp", CollectAllErrors)
+	fs.SetOutput(new(bytes.Buffer))
+	fs.Positional("SRC", "file to copy", Required)
+	err := fs.Parse([]string{"-x"})
+	errs, ok := err.(ParseErrors)
+	if !ok || len(errs) != 2 || errs[1].Error() != "missing required argument: SRC" {
+		t.Errorf("Parse: %v", err)
+	}
+}
+
+func TestPositionalPanics(t *testing.T) {
+	tests := []struct {
+		define func(fs *FlagSet)
+		want   string
+	}{
+		{func(fs *FlagSet) { fs.Positional("", "", 0) }, "cp empty name provided to Positional"},
+		{func(fs *FlagSet) { fs.Positional("SRC", "", 0) }, "cp positional argument redefined: SRC"},
+		{func(fs *FlagSet) { fs.Positional("DST", "", Required) }, "cp required positional argument DST follows optional argument OPT"},
+		{func(fs *FlagSet) { fs.Positional("REST", "", Variadic); fs.Positional("X", "", 0) }, "cp positional argument X follows variadic argument REST"},
+	}
+	for i, tt := range tests {
+		fs := NewFlagSet("cp", ContinueOnError)
+		fs.SetOutput(new(bytes.Buffer))
+		fs.Positional("SRC", "", Required)
+		fs.Positional("OPT", "", 0)
+		func() {
+			defer func() {
+				if r := recover(); r != tt.want {
+					t.Errorf("%d: panic = %v; want %q", i, r, tt.want)
+				}
+			}()
+			tt.define(fs)
+		}()
+	}
+}
@@ -0,0 +1,113 @@
+// Copyright 2018 Li Zebang. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package flag
+
+// ErrRedefined is the error returned by VarE when the name of the flag is
+// already taken.
+//
+// ErrRedefined 是标志的名称已经被占用时 VarE 返回的错误。
+type ErrRedefined struct {
+	// Name is the name of the flag being defined.
+	//
+	// Name 是正在定义的标志的名称。
+	Name string
+
+	// Existing is the flag that already has the name, as its own name, its
+	// shorthand, one of its aliases or, with SetCaseInsensitive, its name
+	// in another case.
+	//
+	// Existing 是已经拥有这个名称的标志，这个名称可能是它自己的名称、缩写、某个别名，或者在
+	// SetCaseInsensitive 下大小写不同的名称。
+	Existing *Flag
+
+	// set is the name of the flag set, for the message.
+	//
+	// set 是标志集的名称，用于错误信息。
+	set string
+}
+
+func (e *ErrRedefined) Error() string {
+	if e.set == "" {
+		return "flag redefined: " + e.Name
+	}
+	return e.set + " flag redefined: " + e.Name
+}
+
+// SetConflictResolver makes Var, VarP, VarE and the functions built on
+// them call fn when the name of a new flag is already taken, instead of
+// failing at once, so that a host can survive plugins that define the same
+// flags. fn receives the existing flag and the new one, which is not
+// defined yet, and returns a policy as AddFlagSet takes: ConflictSkip keeps
+// the existing flag and drops the new one, so a pointer returned for it is
+// never set by Parse; ConflictPrefix defines the new flag under the name of
+// f, a dot and its own name, which must be free; and ConflictError fails as
+// without fn. A nil fn restores the default.
+//
+// SetConflictResolver 使 Var、VarP、VarE 以及基于它们的函数在新标志的名称已经被占用时调用
+// fn，而不是立即失败，这样宿主程序可以在定义了相同标志的插件下继续运行。fn 接收已有的标志
+// 和还没有被定义的新标志，并返回一个 AddFlagSet 所接受的策略：ConflictSkip 保留已有的标志
+// 并丢弃新的标志，所以为新标志返回的指针永远不会被 Parse 设置；ConflictPrefix 以 f 的名称、
+// 一个点和新标志自己的名称来定义它，这个名称必须没有被占用；ConflictError 和没有 fn 时一样
+// 失败。fn 为 nil 时恢复默认行为。
+//
+// NOTE: VarP 的缩写冲突以及 Alias 的冲突不经过 fn，仍然会 panic。
+func (f *FlagSet) SetConflictResolver(fn func(existing, flag *Flag) ConflictPolicy) {
+	f.resolver = fn
+}
+
+// SetConflictResolver makes the definitions of command-line flags whose
+// name is already taken call fn.
+//
+// SetConflictResolver 使名称已经被占用的命令行标志的定义调用 fn。
+func SetConflictResolver(fn func(existing, flag *Flag) ConflictPolicy) {
+	CommandLine.SetConflictResolver(fn)
+}
+
+// VarE is like Var, but returns an *ErrRedefined instead of panicking when
+// the name is already taken and the conflict resolver, if any, does not
+// settle the clash. Nothing is printed.
+//
+// VarE 类似于 Var，但当名称已经被占用并且冲突解决函数（如果有）没有解决冲突时，它返回一个
+// *ErrRedefined 而不是 panic。它不打印任何内容。
+func (f *FlagSet) VarE(value Value, name string, usage string) error {
+	_, err := f.define(value, name, usage)
+	return err
+}
+
+// VarE is like Var, but returns an error instead of panicking when the
+// name is already taken.
+//
+// VarE 类似于 Var，但当名称已经被占用时返回一个错误而不是 panic。
+func VarE(value Value, name string, usage string) error {
+	return CommandLine.VarE(value, name, usage)
+}
+
+// conflicting returns the flag that already takes name, or nil.
+//
+// conflicting 返回已经占用 name 的标志，或者 nil。
+//
+// IMP: 与已有缩写相同的单字母名称也算重复定义，否则 -x 的含义会有歧义。
+func (f *FlagSet) conflicting(name string) *Flag {
+	if flag := f.formal[name]; flag != nil {
+		return flag
+	}
+	if flag := f.shorthands[name]; flag != nil {
+		return flag
+	}
+	if flag := f.aliases[name]; flag != nil {
+		return flag
+	}
+	return f.lookupFolded(name)
+}
+
+// resolve returns the policy for the clash of flag with existing.
+//
+// resolve 返回 flag 与 existing 冲突时的处理策略。
+func (f *FlagSet) resolve(existing, flag *Flag) ConflictPolicy {
+	if f.resolver == nil {
+		return ConflictError
+	}
+	return f.resolver(existing, flag)
+}
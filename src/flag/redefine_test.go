@@ -0,0 +1,76 @@
+// Copyright 2018 Li Zebang. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package flag_test
+
+import (
+	"bytes"
+	"errors"
+	. "flag"
+	"strings"
+	"testing"
+)
+
+func TestVarE(t *testing.T) {
+	fs := NewFlagSet("host", ContinueOnError)
+	var buf bytes.Buffer
+	fs.SetOutput(&buf)
+	fs.IntP("verbose", "v", 0, "")
+	for _, name := range []string{"verbose", "v"} {
+		err := fs.VarE(new(listValue), name, "")
+		var redefined *ErrRedefined
+		if !errors.As(err, &redefined) || redefined.Name != name || redefined.Existing != fs.Lookup("verbose") {
+			t.Errorf("VarE(%q) = %v; want an *ErrRedefined for -verbose", name, err)
+		}
+		if err.Error() != "host flag redefined: "+name {
+			t.Errorf("VarE(%q) = %q", name, err)
+		}
+	}
+	if buf.Len() != 0 {
+		t.Errorf("VarE printed %q", buf.String())
+	}
+	if err := fs.VarE(new(listValue), "name", ""); err != nil || fs.Lookup("name") == nil {
+		t.Errorf("VarE(name) = %v", err)
+	}
+}
+
+func TestSetConflictResolver(t *testing.T) {
+	fs := NewFlagSet("host", ContinueOnError)
+	fs.SetOutput(new(bytes.Buffer))
+	port := fs.Int("port", 80, "")
+	var calls []string
+	policy := ConflictSkip
+	fs.SetConflictResolver(func(existing, flag *Flag) ConflictPolicy {
+		calls = append(calls, existing.Name+"/"+flag.DefValue)
+		return policy
+	})
+
+	dropped := fs.Int("port", 8080, "")
+	policy = ConflictPrefix
+	prefixed := fs.IntP("port", "p", 9090, "")
+	if err := fs.Parse([]string{"-port", "1", "-host.port", "2"}); err != nil {
+		t.Fatal(err)
+	}
+	if *port != 1 || *dropped != 8080 || *prefixed != 2 {
+		t.Errorf("port = %d, dropped = %d, prefixed = %d; want 1, 8080, 2", *port, *dropped, *prefixed)
+	}
+	if flag := fs.Lookup("host.port"); flag == nil || flag.Shorthand != "p" {
+		t.Errorf("Lookup(host.port) = %+v", flag)
+	}
+	if want := "port/8080 port/9090"; strings.Join(calls, " ") != want {
+		t.Errorf("resolver called for %q; want %q", strings.Join(calls, " "), want)
+	}
+
+	// 加了前缀的名称也被占用时仍然是错误
+	if err := fs.VarE(new(listValue), "port", ""); err == nil { // still an error when the prefixed name is taken too
+		t.Error("VarE(port) = nil with -host.port taken")
+	}
+	fs.SetConflictResolver(nil)
+	defer func() {
+		if msg := recover(); msg != "host flag redefined: port" {
+			t.Errorf("panic = %v", msg)
+		}
+	}()
+	fs.Int("port", 0, "")
+}
@@ -0,0 +1,168 @@
+// Copyright 2018 Li Zebang. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+// Package remote refreshes flags from a remote config, such as an HTTP
+// endpoint or a key-value store, while the program runs:
+//
+//	src, err := remote.NewSource(ctx, "config server", remotehttp.Fetcher(nil, url))
+//	...
+//	fs.AddSource(src, 0)
+//	fs.Parse(os.Args[1:])
+//	go src.Poll(ctx, flag.NewSafeFlagSet(fs), time.Minute, nil, "log.level")
+//
+// It is kept out of package flag, which testing imports, so that programs
+// using flag do not link context unless they ask for it.
+//
+// remote 包在程序运行时根据远程配置（例如 HTTP 接口或者键值存储）刷新标志：
+//
+//	src, err := remote.NewSource(ctx, "config server", remotehttp.Fetcher(nil, url))
+//	...
+//	fs.AddSource(src, 0)
+//	fs.Parse(os.Args[1:])
+//	go src.Poll(ctx, flag.NewSafeFlagSet(fs), time.Minute, nil, "log.level")
+//
+// 它没有放在 flag 包中，因为 testing 导入了 flag，这样使用 flag 的程序只有在需要时才会链接
+// context。
+package remote
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Flags is the flag set Sync and Poll change, such as a *flag.SafeFlagSet.
+// Update sets the flags named in values all at once, and returns the first
+// error.
+//
+// Flags 是 Sync 和 Poll 修改的标志集，例如 *flag.SafeFlagSet。Update 一次设置 values
+// 中指定的所有标志，并返回第一个错误。
+type Flags interface {
+	Update(values map[string]string) error
+}
+
+// A Source is a flag.ValueSource that keeps a copy of the values of a
+// remote config and can refresh it while the program runs. Added with
+// AddSource, it supplies the values Parse starts from; Sync and Poll then
+// apply later changes to the flags through Set, so that the watchers of
+// Watch see them. A Source is safe for use by multiple goroutines.
+//
+// A Source does not know how the values are transported: it reads them
+// with a fetch function, which should give up when its context is done.
+// Package flag/remotehttp provides one for HTTP endpoints.
+//
+// Source 是一个保存远程配置的值的副本，并且可以在程序运行时刷新它的 flag.ValueSource。用
+// AddSource 加入之后，它提供 Parse 开始时使用的值；之后 Sync 和 Poll 通过 Set 将变化应用到
+// 标志上，这样 Watch 的监视函数就能看到它们。Source 可以被多个 goroutine 安全地使用。
+//
+// Source 不关心值是如何传输的：它用一个 fetch 函数读取它们，fetch 应该在它的 context 结束时
+// 放弃。flag/remotehttp 包为 HTTP 接口提供了一个这样的函数。
+type Source struct {
+	name  string
+	fetch func(ctx context.Context) (map[string]string, error)
+
+	mu     sync.RWMutex
+	values map[string]string
+}
+
+// NewSource returns a Source named name in errors and diagnostics, which
+// reads the values keyed by flag name with fetch. fetch is first called by
+// NewSource with ctx, so that the source is ready for Parse, and its error
+// is returned.
+//
+// NewSource 返回一个在错误信息和诊断信息中名为 name 的 Source，它用 fetch 读取以标志名称为
+// 键的值。NewSource 会首先以 ctx 调用一次 fetch，这样来源就可以用于 Parse，fetch 的错误会
+// 被返回。
+func NewSource(ctx context.Context, name string, fetch func(ctx context.Context) (map[string]string, error)) (*Source, error) {
+	s := &Source{name: name, fetch: fetch}
+	values, err := fetch(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %v", name, err)
+	}
+	s.values = values
+	return s, nil
+}
+
+// Lookup returns the value of the flag named name in the latest copy.
+//
+// Lookup 返回最新的副本中名为 name 的标志的值。
+func (s *Source) Lookup(name string) (string, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	value, ok := s.values[name]
+	return value, ok
+}
+
+func (s *Source) String() string { return s.name }
+
+// Sync fetches the values again with ctx and updates the named flags of fs
+// whose value has changed since the last fetch, or has appeared, with one
+// call to Update, so that readers of a SafeFlagSet never see a partial
+// update. A value that disappears leaves its flag alone. Sync returns the
+// error of Update; if fetch fails, nothing is set and the copy is kept.
+//
+// Sync 以 ctx 重新获取值，并用一次 Update 调用更新 fs 中自上次获取以来值发生了变化或者新出现
+// 的指定标志，这样 SafeFlagSet 的读取者永远不会看到只完成了一部分的更新。消失的值不会改变它的
+// 标志。Sync 返回 Update 的错误；如果 fetch 失败，则不设置任何标志并保留原来的副本。
+func (s *Source) Sync(ctx context.Context, fs Flags, names ...string) error {
+	values, err := s.fetch(ctx)
+	if err != nil {
+		return fmt.Errorf("%s: %v", s.name, err)
+	}
+	s.mu.Lock()
+	old := s.values
+	s.values = values
+	s.mu.Unlock()
+
+	changed := make(map[string]string)
+	for _, name := range names {
+		value, ok := values[name]
+		if prev, had := old[name]; !ok || had && prev == value {
+			continue
+		}
+		changed[name] = value
+	}
+	if len(changed) == 0 {
+		return nil
+	}
+	if err := fs.Update(changed); err != nil {
+		return fmt.Errorf("%s: %v", s.name, err)
+	}
+	return nil
+}
+
+// Poll calls Sync with ctx every interval until ctx is done, passing its
+// errors to onError if it is not nil. An interval of 0 calls Sync again as
+// soon as it returns, for a fetch function that blocks until the remote
+// config changes, as the watches of key-value stores do. Such a fetch is
+// cancelled through ctx too, so Poll returns ctx.Err() once fetch gives up,
+// without passing the error of the cancelled Sync to onError.
+//
+// Poll 每隔 interval 以 ctx 调用一次 Sync，直到 ctx 结束，如果 onError 不为 nil，就将 Sync
+// 的错误传给它。interval 为 0 时 Sync 一返回就再次调用它，用于那些像键值存储的 watch 一样
+// 阻塞到远程配置发生变化的 fetch 函数。这样的 fetch 同样通过 ctx 取消，所以 fetch 放弃之后
+// Poll 就返回 ctx.Err()，而不会把被取消的 Sync 的错误传给 onError。
+func (s *Source) Poll(ctx context.Context, fs Flags, interval time.Duration, onError func(error), names ...string) error {
+	for {
+		if interval > 0 {
+			t := time.NewTimer(interval)
+			select {
+			case <-ctx.Done():
+				t.Stop()
+				return ctx.Err()
+			case <-t.C:
+			}
+		} else if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		err := s.Sync(ctx, fs, names...)
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if err != nil && onError != nil {
+			onError(err)
+		}
+	}
+}
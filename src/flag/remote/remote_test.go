@@ -0,0 +1,213 @@
+// Copyright 2018 Li Zebang. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package remote_test
+
+import (
+	"context"
+	"errors"
+	. "flag"
+	"flag/remote"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// listValue collects every value it is set to.
+type listValue []string
+
+func (l *listValue) String() string     { return fmt.Sprint(*l) }
+func (l *listValue) Set(s string) error { *l = append(*l, s); return nil }
+
+// remoteConfig holds values that tests can change, and fetches them as a
+// remote config would.
+type remoteConfig struct {
+	mu     sync.Mutex
+	values map[string]string
+}
+
+func (c *remoteConfig) set(values map[string]string) {
+	c.mu.Lock()
+	c.values = values
+	c.mu.Unlock()
+}
+
+func (c *remoteConfig) fetch(ctx context.Context) (map[string]string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.values == nil {
+		return nil, errors.New("unavailable")
+	}
+	m := make(map[string]string)
+	for k, v := range c.values {
+		m[k] = v
+	}
+	return m, nil
+}
+
+func TestSource(t *testing.T) {
+	config := &remoteConfig{values: map[string]string{"log.level": "info", "rate": "10", "tags": "a,b"}}
+	src, err := remote.NewSource(context.Background(), "config server", config.fetch)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fs := NewFlagSet("remote", ContinueOnError)
+	fs.SetOutput(new(strings.Builder))
+	fs.String("log.level", "warn", "")
+	fs.Int("rate", 1, "")
+	fs.Var(new(listValue), "tags", "")
+	fs.AddSource(src, 0)
+	if err := fs.Parse([]string{"-rate", "5"}); err != nil {
+		t.Fatal(err)
+	}
+	var changes []string
+	watch := func(name string) {
+		fs.Watch(name, func(old, new string) { changes = append(changes, name+":"+old+"->"+new) })
+	}
+	watch("log.level")
+	watch("rate")
+	safe := NewSafeFlagSet(fs)
+	if v, _ := safe.Value("log.level"); v != "info" {
+		t.Errorf("log.level = %q after Parse; want info", v)
+	}
+
+	// 只有指定的并且发生了变化的标志会被设置
+	config.set(map[string]string{"log.level": "debug", "rate": "10", "tags": "c"}) // only designated flags that changed are set
+	if err := src.Sync(context.Background(), safe, "log.level", "rate"); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := strings.Join(changes, " "), "log.level:info->debug"; got != want {
+		t.Errorf("changes = %q; want %q", got, want)
+	}
+	if v, _ := safe.Value("rate"); v != "5" {
+		t.Errorf("rate = %q; want the command-line 5", v)
+	}
+
+	config.set(map[string]string{"rate": "x"})
+	err = src.Sync(context.Background(), safe, "log.level", "rate")
+	if err == nil || err.Error() != `config server: cannot set flag -rate to "x": strconv.ParseInt: parsing "x": invalid syntax` {
+		t.Errorf("Sync = %v", err)
+	}
+	if v, _ := safe.Value("log.level"); v != "debug" {
+		t.Errorf("log.level = %q after its key disappeared; want debug", v)
+	}
+
+	config.set(nil)
+	if err := src.Sync(context.Background(), safe, "rate"); err == nil || err.Error() != "config server: unavailable" {
+		t.Errorf("Sync = %v; want the fetch error", err)
+	}
+	if v, ok := src.Lookup("rate"); !ok || v != "x" {
+		t.Errorf("Lookup(rate) = %q, %v after a failed fetch; want the last copy", v, ok)
+	}
+}
+
+func TestSourcePoll(t *testing.T) {
+	var mu sync.Mutex
+	values := map[string]string{"rate": "1"}
+	fetch := func(ctx context.Context) (map[string]string, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		if values == nil {
+			return nil, errors.New("down")
+		}
+		m := make(map[string]string)
+		for k, v := range values {
+			m[k] = v
+		}
+		return m, nil
+	}
+	src, err := remote.NewSource(context.Background(), "kv", fetch)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fs := NewFlagSet("remote", ContinueOnError)
+	fs.Int("rate", 0, "")
+	fs.AddSource(src, 0)
+	if err := fs.Parse(nil); err != nil {
+		t.Fatal(err)
+	}
+	changed := make(chan string, 1)
+	fs.Watch("rate", func(old, new string) { changed <- new })
+	safe := NewSafeFlagSet(fs)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	errs := make(chan error, 10)
+	go func() {
+		done <- src.Poll(ctx, safe, time.Millisecond, func(err error) {
+			select {
+			case errs <- err:
+			default:
+			}
+		}, "rate")
+	}()
+	mu.Lock()
+	values["rate"] = "2"
+	mu.Unlock()
+	select {
+	case v := <-changed:
+		if v != "2" {
+			t.Errorf("rate changed to %q; want 2", v)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Poll did not apply the change")
+	}
+	mu.Lock()
+	values = nil
+	mu.Unlock()
+	select {
+	case err := <-errs:
+		if err.Error() != "kv: down" {
+			t.Errorf("onError(%v); want kv: down", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Poll did not report the error")
+	}
+	cancel()
+	if err := <-done; err != context.Canceled {
+		t.Errorf("Poll = %v; want %v", err, context.Canceled)
+	}
+}
+
+func TestSourcePollCancel(t *testing.T) {
+	first := true
+	blocked := make(chan bool)
+	// fetch blocks until the remote config changes, as a watch does, and
+	// gives up when ctx is done.
+	fetch := func(ctx context.Context) (map[string]string, error) {
+		if first {
+			first = false
+			return map[string]string{"rate": "1"}, nil
+		}
+		blocked <- true
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+	src, err := remote.NewSource(context.Background(), "kv", fetch)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fs := NewFlagSet("remote", ContinueOnError)
+	fs.Int("rate", 0, "")
+	safe := NewSafeFlagSet(fs)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		done <- src.Poll(ctx, safe, 0, func(err error) { t.Errorf("onError(%v) for a cancelled fetch", err) }, "rate")
+	}()
+	<-blocked
+	cancel()
+	select {
+	case err := <-done:
+		if err != context.Canceled {
+			t.Errorf("Poll = %v; want %v", err, context.Canceled)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Poll did not return after its context was cancelled during a fetch")
+	}
+}
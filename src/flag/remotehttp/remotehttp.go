@@ -0,0 +1,122 @@
+// Copyright 2018 Li Zebang. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+// Package remotehttp reads the values of a remote.Source from an HTTP
+// endpoint that serves a JSON object keyed by flag name.
+//
+// It is kept out of package flag, which testing imports, so that programs
+// using flag do not link net/http unless they ask for it.
+//
+// remotehttp 包从一个提供以标志名称为键的 JSON 对象的 HTTP 接口读取 remote.Source 的值。
+//
+// 它没有放在 flag 包中，因为 testing 导入了 flag，这样使用 flag 的程序只有在需要时才会链接
+// net/http。
+package remotehttp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// Fetcher returns a fetch function for remote.NewSource that gets url
+// with client, or http.DefaultClient if client is nil, and reads the body
+// as a JSON object the way flag/flagjson does: nested objects name
+// flags with a dot, and the elements of an array are joined with commas.
+// The request is made with the context passed to fetch, so cancelling it
+// aborts a fetch in progress. A status other than 200 OK is an error.
+//
+// Fetcher 返回一个用于 remote.NewSource 的 fetch 函数，它用 client（为 nil 时使用
+// http.DefaultClient）获取 url，并像 flag/flagjson 一样将响应体作为 JSON 对象读取：
+// 嵌套的对象用一个点来命名标志，数组的元素用逗号连接。请求使用传给 fetch 的 context，所以
+// 取消它会中止正在进行的获取。200 OK 以外的状态是一个错误。
+func Fetcher(client *http.Client, url string) func(ctx context.Context) (map[string]string, error) {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return func(ctx context.Context) (map[string]string, error) {
+		req, err := http.NewRequest("GET", url, nil)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := client.Do(req.WithContext(ctx))
+		if err != nil {
+			return nil, err
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("GET %s: %s", url, resp.Status)
+		}
+		dec := json.NewDecoder(resp.Body)
+		dec.UseNumber()
+		var doc map[string]interface{}
+		if err := dec.Decode(&doc); err != nil {
+			return nil, fmt.Errorf("GET %s: invalid JSON: %v", url, err)
+		}
+		values := make(map[string]string)
+		if err := flatten("", doc, values); err != nil {
+			return nil, fmt.Errorf("GET %s: invalid JSON: %v", url, err)
+		}
+		return values, nil
+	}
+}
+
+// flatten adds the values of v to values, naming those of nested objects
+// with their keys joined by dots after name.
+//
+// IMP: 与 flag/flagjson 的规则相同，但数组的元素用逗号连接。flagjson 的导入路径在 GOROOT
+// 和模块两种构建中不同，所以在这里重复。
+func flatten(name string, v interface{}, values map[string]string) error {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for k, elem := range v {
+			if name != "" {
+				k = name + "." + k
+			}
+			if err := flatten(k, elem, values); err != nil {
+				return err
+			}
+		}
+		return nil
+	case []interface{}:
+		var list []string
+		for _, elem := range v {
+			s, ok := scalar(elem)
+			if !ok {
+				return fmt.Errorf("key %s: array elements must be strings, numbers or booleans", strconv.Quote(name))
+			}
+			list = append(list, s)
+		}
+		if len(list) > 0 {
+			values[name] = strings.Join(list, ",")
+		}
+		return nil
+	case nil:
+		return nil
+	}
+	s, ok := scalar(v)
+	if !ok {
+		return fmt.Errorf("key %s: unsupported value", strconv.Quote(name))
+	}
+	values[name] = s
+	return nil
+}
+
+// scalar returns the text of a JSON string, number or boolean.
+//
+// scalar 返回 JSON 字符串、数字或者布尔值的文本。
+func scalar(v interface{}) (string, bool) {
+	switch v := v.(type) {
+	case string:
+		return v, true
+	case json.Number:
+		return v.String(), true
+	case bool:
+		return strconv.FormatBool(v), true
+	}
+	return "", false
+}
@@ -0,0 +1,82 @@
+// Copyright 2018 Li Zebang. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package remotehttp
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFetcher(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, `{"log": {"level": "info"}, "rate": 10, "tags": ["a", "b"], "debug": true, "off": null}`)
+	}))
+	defer srv.Close()
+	values, err := Fetcher(nil, srv.URL)(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]string{"log.level": "info", "rate": "10", "tags": "a,b", "debug": "true"}
+	if !reflect.DeepEqual(values, want) {
+		t.Errorf("values = %v; want %v", values, want)
+	}
+}
+
+func TestFetcherErrors(t *testing.T) {
+	var tests = []struct {
+		status int
+		body   string
+		err    string
+	}{
+		{http.StatusServiceUnavailable, "unavailable", "503 Service Unavailable"},
+		{http.StatusOK, `{"rate": `, "invalid JSON: unexpected EOF"},
+		{http.StatusOK, `{"tags": [{}]}`, `invalid JSON: key "tags": array elements must be strings, numbers or booleans`},
+	}
+	for _, tt := range tests {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(tt.status)
+			io.WriteString(w, tt.body)
+		}))
+		_, err := Fetcher(nil, srv.URL)(context.Background())
+		srv.Close()
+		if err == nil || !strings.HasSuffix(err.Error(), tt.err) {
+			t.Errorf("fetch of %d %q = %v; want %s", tt.status, tt.body, err, tt.err)
+		}
+	}
+}
+
+func TestFetcherCancel(t *testing.T) {
+	blocked := make(chan bool)
+	release := make(chan bool)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		blocked <- true
+		<-release
+	}))
+	defer srv.Close()
+	defer close(release)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		_, err := Fetcher(nil, srv.URL)(ctx)
+		done <- err
+	}()
+	<-blocked
+	cancel()
+	select {
+	case err := <-done:
+		if err == nil || !strings.Contains(err.Error(), context.Canceled.Error()) {
+			t.Errorf("fetch = %v; want %v", err, context.Canceled)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("fetch did not return after its context was cancelled")
+	}
+}
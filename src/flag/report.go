@@ -0,0 +1,41 @@
+// Copyright 2018 Li Zebang. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package flag
+
+// SetReporter makes Parse call fn for each flag it sets successfully, with
+// the value as it was given, before any ExpandEnv expansion, whether it
+// came from the command line, the environment, a value source or, for the
+// ParseConfig methods, a config file. A boolean flag given without a value
+// is reported with "true". Applications can use it to record which flags
+// are in use; fn should anonymize the values before they leave the
+// program. The value of a secret flag is reported as ***. A nil fn turns
+// reporting off. Flags set by the Set method and by Dynamic are not
+// reported.
+//
+// SetReporter 使 Parse 在每次成功设置一个标志时调用 fn，参数是给出的值（在 ExpandEnv
+// 展开之前），无论这个值来自命令行、环境变量、值来源，还是 ParseConfig 系列方法的配置文件。没有给出值
+// 的布尔标志以 "true" 报告。应用程序可以用它记录哪些标志被使用了；fn 应该在值离开程序之前
+// 将其匿名化。秘密标志的值以 *** 报告。nil 的 fn 会关闭报告。Set 方法和 Dynamic 设置的标志
+// 不会被报告。
+func (f *FlagSet) SetReporter(fn func(flag *Flag, value string)) {
+	f.reporter = fn
+}
+
+// SetReporter makes Parse call fn for each command-line flag it sets.
+//
+// SetReporter 使 Parse 在每次设置一个命令行标志时调用 fn。
+func SetReporter(fn func(flag *Flag, value string)) {
+	CommandLine.SetReporter(fn)
+}
+
+// report passes flag and the value it was set to, masked if flag is
+// secret, to the reporter, if any.
+//
+// report 将 flag 和它被设置的值（如果 flag 是秘密的则被遮盖）传给报告函数（如果有的话）。
+func (f *FlagSet) report(flag *Flag, value string) {
+	if f.reporter != nil {
+		f.reporter(flag, shownValue(flag, value))
+	}
+}
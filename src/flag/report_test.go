@@ -0,0 +1,93 @@
+// Copyright 2018 Li Zebang. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package flag_test
+
+import (
+	. "flag"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestSetReporter(t *testing.T) {
+	fs := NewFlagSet("report", ContinueOnError)
+	fs.SetParseMode(CombinedShorts)
+	fs.BoolP("verbose", "v", false, "verbose")
+	fs.IntP("level", "l", 0, "level")
+	fs.String("name", "", "name")
+	fs.String("dir", "", "dir")
+	fs.Bool("quiet", false, "quiet")
+	fs.AddSource(MapSource{"dir": "/tmp"}, 1)
+	var got []string
+	fs.SetReporter(func(flag *Flag, value string) {
+		got = append(got, fmt.Sprintf("%s=%s", flag.Name, value))
+	})
+	if err := fs.Parse([]string{"-vl3", "-name", "x", "-quiet=false", "arg"}); err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"verbose=true", "level=3", "name=x", "quiet=false", "dir=/tmp"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("reported %q; want %q", got, want)
+	}
+
+	got = nil
+	if err := fs.Parse([]string{"-level", "x"}); err == nil {
+		t.Fatal("Parse accepted an invalid value")
+	}
+	if len(got) != 0 {
+		t.Errorf("reported %q for an invalid value", got)
+	}
+}
+
+func TestSetReporterConfig(t *testing.T) {
+	fs := NewFlagSet("report", ContinueOnError)
+	fs.Int("level", 0, "level")
+	var got []string
+	fs.SetReporter(func(flag *Flag, value string) {
+		got = append(got, flag.Name+"="+value)
+	})
+	if err := fs.ParseConfigINI(strings.NewReader("level = 2")); err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"level=2"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("reported %q; want %q", got, want)
+	}
+	fs.SetReporter(nil)
+	if err := fs.Parse([]string{"-level", "3"}); err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 1 {
+		t.Errorf("reported %q after SetReporter(nil)", got)
+	}
+}
+
+func TestSetReporterSecret(t *testing.T) {
+	fs := NewFlagSet("report", ContinueOnError)
+	fs.SetParseMode(CombinedShorts)
+	fs.StringP("password", "p", "", "password")
+	fs.String("user", "", "user")
+	fs.MarkSecret("password")
+	fs.AddSource(MapSource{"password": "s3cret"}, 1)
+	var got []string
+	fs.SetReporter(func(flag *Flag, value string) {
+		got = append(got, flag.Name+"="+value)
+	})
+	if err := fs.Parse([]string{"-phunter2", "-user", "root"}); err != nil {
+		t.Fatal(err)
+	}
+	fs.ResetParsed()
+	if err := fs.ParseConfigINI(strings.NewReader("password = letmein")); err != nil {
+		t.Fatal(err)
+	}
+	fs.ResetParsed()
+	if err := fs.Parse(nil); err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"password=***", "user=root", "password=***", "password=***"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("reported %q; want %q", got, want)
+	}
+}
@@ -0,0 +1,66 @@
+// Copyright 2018 Li Zebang. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package flag
+
+import "fmt"
+
+// resetter is implemented by Values whose first Set replaces the default
+// and later ones add to it, such as slices; reset makes the next Set
+// replace the value again.
+//
+// resetter 由第一次 Set 替换默认值、之后的 Set 在其基础上追加的 Value（例如切片）实现；
+// reset 使下一次 Set 再次替换值。
+type resetter interface {
+	reset()
+}
+
+// ResetParsed undoes Parse, so that the flag set can parse again as if it
+// were new, for instance to reload the configuration on SIGHUP. It sets
+// every flag that has been set back to its default value with Set, forgets
+// which flags were set, the remaining arguments and where the "--" among
+// them was, and makes Parsed report false. Flags defined by Func are left alone, since their
+// functions have no value to restore. ResetParsed returns the first error
+// returned by Set, after trying every flag.
+//
+// ResetParsed 撤销 Parse，这样标志集可以像新的一样再次解析，例如在收到 SIGHUP 时重新加载
+// 配置。它用 Set 将每个被设置过的标志恢复为默认值，忘记哪些标志被设置过、剩余的参数以及
+// "--" 在其中的位置，并使 Parsed 报告 false。Func 定义的标志不会被处理，因为它们的函数没有可以恢复的值。
+// ResetParsed 在尝试了每个标志之后，返回 Set 返回的第一个错误。
+//
+// IMP: 只有被设置过的标志会被恢复，直接修改变量的值不会被撤销。
+func (f *FlagSet) ResetParsed() error {
+	var first error
+	for _, flag := range sortFlags(f.actual) {
+		if _, ok := flag.Value.(funcValue); ok {
+			continue
+		}
+		r, ok := flag.Value.(resetter)
+		if ok {
+			r.reset()
+		}
+		if err := flag.Value.Set(flag.DefValue); err != nil && first == nil {
+			first = fmt.Errorf("cannot reset flag -%s to %q: %v", flag.Name, shownValue(flag, flag.DefValue), err)
+		}
+		if ok {
+			r.reset()
+		}
+	}
+	f.actual = nil
+	f.args = nil
+	f.positional = nil
+	f.argsLenAtDash = -1
+	f.passthrough = nil
+	f.responseFiles = 0
+	f.experimentsOn = false
+	f.parsed = false
+	return first
+}
+
+// ResetParsed undoes the parsing of the command-line flags.
+//
+// ResetParsed 撤销命令行标志的解析。
+func ResetParsed() error {
+	return CommandLine.ResetParsed()
+}
@@ -0,0 +1,89 @@
+// Copyright 2018 Li Zebang. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package flag_test
+
+import (
+	"errors"
+	. "flag"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestResetParsed(t *testing.T) {
+	fs := NewFlagSet("reset", ContinueOnError)
+	n := fs.Int("n", 5, "")
+	d := fs.Duration("d", time.Second, "")
+	tags := fs.StringSlice("tag", []string{"x"}, "")
+	labels := fs.StringToString("label", map[string]string{"a": "1"}, "")
+	v := fs.Count("v", 0, "")
+	calls := 0
+	fs.Func("f", "", func(string) error { calls++; return nil })
+	fs.SetParseMode(Interspersed)
+	fs.Positional("FILE", "", 0)
+
+	args := []string{"-n", "7", "-d", "1m", "-tag", "y", "-label", "b=2", "-v", "rest", "-v", "-f", "z", "--", "more"}
+	if err := fs.Parse(args); err != nil {
+		t.Fatal(err)
+	}
+	if fs.ArgsLenAtDash() != 1 || fs.ArgByName("FILE") != "rest" {
+		t.Fatalf("Parse(%q): ArgsLenAtDash() = %d, ArgByName(FILE) = %q", args, fs.ArgsLenAtDash(), fs.ArgByName("FILE"))
+	}
+	if err := fs.ResetParsed(); err != nil {
+		t.Fatal(err)
+	}
+	if *n != 5 || *d != time.Second || !reflect.DeepEqual(*tags, []string{"x"}) ||
+		!reflect.DeepEqual(*labels, map[string]string{"a": "1"}) || *v != 0 {
+		t.Errorf("after ResetParsed: n=%d d=%v tag=%q label=%v v=%d", *n, *d, *tags, *labels, *v)
+	}
+	if fs.Parsed() || fs.NArg() != 0 || fs.Lookup("n") == nil || len(fs.SetFlags()) != 0 {
+		t.Errorf("Parsed() = %v, NArg() = %d, SetFlags() = %v", fs.Parsed(), fs.NArg(), fs.SetFlags())
+	}
+	if calls != 1 {
+		t.Errorf("Func flag called %d times; want 1", calls)
+	}
+
+	if fs.ArgsLenAtDash() != -1 || fs.ArgsAfterDash() != nil || fs.ArgByName("FILE") != "" {
+		t.Errorf("ArgsLenAtDash() = %d, ArgsAfterDash() = %q, ArgByName(FILE) = %q after ResetParsed",
+			fs.ArgsLenAtDash(), fs.ArgsAfterDash(), fs.ArgByName("FILE"))
+	}
+
+	// 第二次解析必须和在新的标志集上解析得到相同的结果
+	if err := fs.Parse([]string{"-tag", "z"}); err != nil { // a second parse must act like one on a new set
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(*tags, []string{"z"}) || *n != 5 {
+		t.Errorf("second Parse: tag=%q n=%d", *tags, *n)
+	}
+}
+
+// onceValue accepts only its first Set.
+type onceValue struct{ set bool }
+
+func (v *onceValue) String() string { return "" }
+
+func (v *onceValue) Set(string) error {
+	if v.set {
+		return errors.New("already set")
+	}
+	v.set = true
+	return nil
+}
+
+func TestResetParsedError(t *testing.T) {
+	fs := NewFlagSet("reset", ContinueOnError)
+	fs.Var(&onceValue{}, "bad", "")
+	fs.Int("n", 1, "")
+	if err := fs.Parse([]string{"-bad", "x", "-n", "2"}); err != nil {
+		t.Fatal(err)
+	}
+	err := fs.ResetParsed()
+	if err == nil || err.Error() != `cannot reset flag -bad to "": already set` {
+		t.Errorf("ResetParsed() = %v", err)
+	}
+	if fs.Lookup("n").Value.String() != "1" {
+		t.Error("ResetParsed stopped at the first error")
+	}
+}
@@ -0,0 +1,51 @@
+// Copyright 2018 Li Zebang. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package flag
+
+import (
+	"io/ioutil"
+	"strings"
+)
+
+// maxResponseFiles bounds the response files read by one Parse, so that a
+// file naming itself is not expanded forever.
+//
+// maxResponseFiles 限制一次 Parse 读取的响应文件数量，这样引用自身的文件不会被无限展开。
+const maxResponseFiles = 64
+
+// expandResponseFile replaces the argument @file at the front of f.args
+// with the arguments read from file in ResponseFiles mode. Each line of the
+// file is one argument, with surrounding spaces removed, so a value may
+// contain spaces, as in -name=hello world. Empty lines and lines starting
+// with # are skipped. The expanded arguments are parsed like any others, so
+// they may name further response files.
+//
+// expandResponseFile 在 ResponseFiles 模式下将 f.args 开头的参数 @file 替换为从 file
+// 中读取的参数。文件的每一行是一个参数，并去掉两端的空白，所以值可以包含空格，例如
+// -name=hello world。空行和以 # 开头的行会被跳过。展开后的参数和其他参数一样被解析，所以
+// 它们还可以引用其他响应文件。
+//
+// NOTE: 只有出现在标志位置的 @file 会被展开，作为标志的值或者出现在 "--" 之后的不会。
+func (f *FlagSet) expandResponseFile() (bool, error) {
+	name := f.args[0][1:]
+	f.responseFiles++
+	if f.responseFiles > maxResponseFiles {
+		return false, f.failf("too many response files: @%s", name)
+	}
+	data, err := ioutil.ReadFile(name)
+	if err != nil {
+		return false, f.failw(err, "cannot read response file: %v", err)
+	}
+	var args []string
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || line[0] == '#' {
+			continue
+		}
+		args = append(args, line)
+	}
+	f.args = append(args, f.args[1:]...)
+	return true, nil
+}
@@ -0,0 +1,79 @@
+// Copyright 2018 Li Zebang. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package flag_test
+
+import (
+	. "flag"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestResponseFiles(t *testing.T) {
+	dir, err := ioutil.TempDir("", "flagresponse")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	common := filepath.Join(dir, "common.txt")
+	args := filepath.Join(dir, "args.txt")
+	loop := filepath.Join(dir, "loop.txt")
+	files := map[string]string{
+		common: "# shared flags\n-v\n\n  -name=hello world  \r\n",
+		args:   "@" + common + "\n-n\n3\nfile1\n",
+		loop:   "@" + loop + "\n",
+	}
+	for name, data := range files {
+		if err := ioutil.WriteFile(name, []byte(data), 0666); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		mode ParseMode
+		args []string
+		want string
+		rest string
+		err  string
+	}{
+		{ResponseFiles, []string{"@" + args, "file2"}, "true hello world 3", "file1 file2", ""},
+		{ResponseFiles, []string{"-n", "1", "@" + common}, "true hello world 1", "", ""},
+		{ResponseFiles, []string{"--", "@" + args}, "false  0", "@" + args, ""},
+		{ResponseFiles, []string{"-name", "@" + args}, "false @" + args + " 0", "", ""},
+		{ResponseFiles, []string{"@"}, "false  0", "@", ""},
+		{0, []string{"@" + args}, "false  0", "@" + args, ""},
+		{ResponseFiles | Interspersed, []string{"x", "@" + args}, "true hello world 3", "x file1", ""},
+		{ResponseFiles, []string{"@" + loop}, "", "", "too many response files: @" + loop},
+		{ResponseFiles, []string{"@" + filepath.Join(dir, "missing")}, "", "", "cannot read response file: open " + filepath.Join(dir, "missing")},
+	}
+	for _, tt := range tests {
+		fs := NewFlagSet("response", ContinueOnError)
+		fs.SetOutput(ioutil.Discard)
+		fs.SetParseMode(tt.mode)
+		fs.Bool("v", false, "verbose")
+		name := fs.String("name", "", "name")
+		fs.Int("n", 0, "count")
+		err := fs.Parse(tt.args)
+		if tt.err != "" {
+			if err == nil || !strings.HasPrefix(err.Error(), tt.err) {
+				t.Errorf("Parse(%q): %v; want %s", tt.args, err, tt.err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Parse(%q): %v", tt.args, err)
+			continue
+		}
+		got := strings.Join([]string{fs.Lookup("v").Value.String(), *name, fs.Lookup("n").Value.String()}, " ")
+		if got != tt.want {
+			t.Errorf("Parse(%q): v, name, n = %s; want %s", tt.args, got, tt.want)
+		}
+		if rest := strings.Join(fs.Args(), " "); rest != tt.rest {
+			t.Errorf("Parse(%q): Args = %q; want %q", tt.args, rest, tt.rest)
+		}
+	}
+}
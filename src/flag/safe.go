@@ -0,0 +1,167 @@
+// Copyright 2018 Li Zebang. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package flag
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// A SafeFlagSet guards a FlagSet with a read-write mutex, so that flags can
+// be read and changed from several goroutines after Parse, for instance by
+// an admin endpoint that tweaks them at run time. Set and Parse take the
+// write lock, the other methods the read lock.
+//
+// Only access through the SafeFlagSet is synchronized: the variables that
+// hold the values of the flags must be read with Get or Value, not
+// directly, once other goroutines may call Set.
+//
+// SafeFlagSet 用一个读写锁保护 FlagSet，这样在 Parse 之后可以从多个 goroutine 中读取和
+// 修改标志，例如通过一个在运行时调整标志的管理接口。Set 和 Parse 获取写锁，其他方法获取读锁。
+//
+// 只有通过 SafeFlagSet 的访问是同步的：一旦其他 goroutine 可能调用 Set，存储标志的值的
+// 变量就必须通过 Get 或 Value 读取，而不能直接读取。
+type SafeFlagSet struct {
+	mu sync.RWMutex
+	fs *FlagSet
+}
+
+// NewSafeFlagSet returns a SafeFlagSet that guards fs. fs must not be used
+// directly afterwards, except through Do.
+//
+// NewSafeFlagSet 返回一个保护 fs 的 SafeFlagSet。之后除了通过 Do，不能再直接使用 fs。
+func NewSafeFlagSet(fs *FlagSet) *SafeFlagSet {
+	// VisitAll 只持有读锁，所以排序后的标志必须预先缓存好
+	fs.sortedFormal() // VisitAll holds only the read lock, so the sorted flags must be cached beforehand
+	return &SafeFlagSet{fs: fs}
+}
+
+// Parse calls Parse on the guarded flag set.
+//
+// Parse 对被保护的标志集调用 Parse。
+func (s *SafeFlagSet) Parse(arguments []string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.fs.Parse(arguments)
+}
+
+// Set sets the value of the named flag.
+//
+// Set 设置名为 name 的标志的值。
+func (s *SafeFlagSet) Set(name, value string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.fs.Set(name, value)
+}
+
+// Update sets the flags named in values, in the order of their names, all
+// under the write lock so that readers never see a partial update. It goes
+// on past flags it cannot set and returns the first such error, which
+// shows *** for the value of a secret flag.
+//
+// Update 按照名称的顺序设置 values 中指定的标志，所有设置都在写锁下完成，这样读取者永远不会
+// 看到只完成了一部分的更新。它会越过无法设置的标志继续，并返回第一个这样的错误，其中秘密标志的
+// 值显示为 ***。
+func (s *SafeFlagSet) Update(values map[string]string) error {
+	names := make([]string, 0, len(values))
+	for name := range values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var first error
+	for _, name := range names {
+		value := values[name]
+		if err := s.fs.Set(name, value); err != nil && first == nil {
+			if flag := s.fs.lookupName(name); flag != nil && flag.Secret {
+				e := &ErrInvalidValue{Name: name, Value: value, Err: err}
+				e.redact()
+				value, err = e.Value, e.Err
+			}
+			first = fmt.Errorf("cannot set flag -%s to %q: %v", name, value, err)
+		}
+	}
+	return first
+}
+
+// Lookup returns the Flag structure of the named flag, returning nil if
+// none exists. Its Value must not be used without the lock; see Get and
+// Value.
+//
+// Lookup 返回名为 name 的标志的 Flag 结构，如果不存在则返回 nil。没有锁时不能使用它的
+// Value，参见 Get 和 Value。
+func (s *SafeFlagSet) Lookup(name string) *Flag {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.fs.Lookup(name)
+}
+
+// Value returns the String form of the value of the named flag, and
+// reports whether the flag exists.
+//
+// Value 返回名为 name 的标志的值的 String 形式，并报告该标志是否存在。
+func (s *SafeFlagSet) Value(name string) (string, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	flag := s.fs.Lookup(name)
+	if flag == nil {
+		return "", false
+	}
+	return flag.Value.String(), true
+}
+
+// Get returns the result of the Get method of the value of the named flag,
+// and reports whether the flag exists and its Value is a Getter.
+//
+// Get 返回名为 name 的标志的值的 Get 方法的结果，并报告该标志是否存在以及它的 Value 是否
+// 是一个 Getter。
+func (s *SafeFlagSet) Get(name string) (interface{}, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	flag := s.fs.Lookup(name)
+	if flag == nil {
+		return nil, false
+	}
+	g, ok := flag.Value.(Getter)
+	if !ok {
+		return nil, false
+	}
+	return g.Get(), true
+}
+
+// Visit calls Visit on the guarded flag set while holding the read lock,
+// so fn must not call methods of s that take the write lock.
+//
+// Visit 在持有读锁时对被保护的标志集调用 Visit，所以 fn 不能调用 s 中获取写锁的方法。
+func (s *SafeFlagSet) Visit(fn func(*Flag)) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	s.fs.Visit(fn)
+}
+
+// VisitAll calls VisitAll on the guarded flag set while holding the read
+// lock, so fn must not call methods of s that take the write lock.
+//
+// VisitAll 在持有读锁时对被保护的标志集调用 VisitAll，所以 fn 不能调用 s 中获取写锁的方法。
+func (s *SafeFlagSet) VisitAll(fn func(*Flag)) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	s.fs.VisitAll(fn)
+}
+
+// Do calls fn with the guarded flag set while holding the write lock, for
+// the operations that SafeFlagSet does not wrap, such as defining flags.
+// fn must not call methods of s.
+//
+// Do 在持有写锁时用被保护的标志集调用 fn，用于 SafeFlagSet 没有包装的操作，例如定义标志。
+// fn 不能调用 s 的方法。
+func (s *SafeFlagSet) Do(fn func(*FlagSet)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	fn(s.fs)
+	s.fs.sortedFormal()
+}
@@ -0,0 +1,120 @@
+// Copyright 2018 Li Zebang. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package flag_test
+
+import (
+	. "flag"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestSafeFlagSet(t *testing.T) {
+	fs := NewFlagSet("safe", ContinueOnError)
+	fs.Int("level", 1, "level")
+	s := NewSafeFlagSet(fs)
+	if err := s.Parse([]string{"-level", "2"}); err != nil {
+		t.Fatal(err)
+	}
+	if v, ok := s.Value("level"); !ok || v != "2" {
+		t.Errorf("Value(level) = %q, %v", v, ok)
+	}
+	if v, ok := s.Get("level"); !ok || v != 2 {
+		t.Errorf("Get(level) = %v, %v", v, ok)
+	}
+	if _, ok := s.Value("missing"); ok {
+		t.Error("Value(missing) reported ok")
+	}
+	if s.Lookup("level") == nil || s.Lookup("missing") != nil {
+		t.Error("Lookup mismatch")
+	}
+	s.Do(func(fs *FlagSet) { fs.String("name", "x", "name") })
+	n := 0
+	s.VisitAll(func(*Flag) { n++ })
+	if n != 2 {
+		t.Errorf("VisitAll visited %d flags; want 2", n)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(2)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				if err := s.Set("level", strconv.Itoa(i*100+j)); err != nil {
+					t.Error(err)
+					return
+				}
+			}
+		}(i)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				s.Get("level")
+				s.Visit(func(f *Flag) { _ = f.Value.String() })
+			}
+		}()
+	}
+	wg.Wait()
+	if err := s.Set("level", "x"); err == nil {
+		t.Error("Set(level, x) succeeded")
+	}
+}
+
+func TestSafeFlagSetVisitAllDo(t *testing.T) {
+	fs := NewFlagSet("safe", ContinueOnError)
+	fs.Int("a", 0, "")
+	s := NewSafeFlagSet(fs)
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				s.VisitAll(func(*Flag) {})
+			}
+		}()
+	}
+	for i := 0; i < 10; i++ {
+		name := fmt.Sprintf("f%d", i)
+		s.Do(func(fs *FlagSet) { fs.Int(name, 0, "") })
+	}
+	wg.Wait()
+	n := 0
+	s.VisitAll(func(*Flag) { n++ })
+	if n != 11 {
+		t.Errorf("VisitAll visited %d flags; want 11", n)
+	}
+}
+
+func TestSafeFlagSetUpdate(t *testing.T) {
+	fs := NewFlagSet("safe", ContinueOnError)
+	a := fs.Int("a", 0, "")
+	b := fs.Int("b", 0, "")
+	fs.String("token", "", "")
+	fs.MarkSecret("token")
+	fs.Int("pin", 0, "")
+	fs.MarkSecret("pin")
+	if err := fs.Parse(nil); err != nil {
+		t.Fatal(err)
+	}
+	s := NewSafeFlagSet(fs)
+	var order []string
+	fs.Watch("a", func(old, new string) { order = append(order, "a") })
+	fs.Watch("b", func(old, new string) { order = append(order, "b") })
+	err := s.Update(map[string]string{"b": "2", "a": "1", "c": "3"})
+	if err == nil || err.Error() != `cannot set flag -c to "3": no such flag -c` {
+		t.Errorf("Update = %v", err)
+	}
+	if *a != 1 || *b != 2 || fmt.Sprint(order) != "[a b]" {
+		t.Errorf("a, b = %d, %d set in order %v; want 1, 2 in order [a b]", *a, *b, order)
+	}
+	err = s.Update(map[string]string{"pin": "hunter2"})
+	if err == nil || !strings.HasPrefix(err.Error(), `cannot set flag -pin to "***": `) || strings.Contains(err.Error(), "hunter2") {
+		t.Errorf("Update = %v; want the secret value masked", err)
+	}
+}
@@ -0,0 +1,80 @@
+// Copyright 2018 Li Zebang. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package flag
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// secretMask replaces the values of secret flags in output.
+//
+// secretMask 在输出中代替秘密标志的值。
+const secretMask = "***"
+
+// MarkSecret makes the named flag, such as a password or a token, keep its
+// value out of the output of the flag set: usage messages show a default
+// that is not the zero value as ***, and so do error messages about an
+// invalid value, ToArgs, Describe, Diff and the diag trace. The program
+// itself still reads the real value. MarkSecret panics if the flag is not
+// defined.
+//
+// MarkSecret 使名为 name 的标志（例如密码或者令牌）的值不出现在标志集的输出中：用法信息将
+// 不是零值的默认值显示为 ***，关于无效值的错误信息、ToArgs、Describe、Diff 以及 diag 的跟踪
+// 信息也是如此。程序本身仍然读取真实的值。如果标志没有定义，MarkSecret 会 panic。
+func (f *FlagSet) MarkSecret(name string) {
+	flag, ok := f.formal[name]
+	if !ok {
+		msg := fmt.Sprintf("flag provided to MarkSecret but not defined: -%s", name)
+		if f.name != "" {
+			msg = f.name + " " + msg
+		}
+		fmt.Fprintln(f.Output(), msg)
+		panic(msg)
+	}
+	flag.Secret = true
+}
+
+// MarkSecret keeps the value of the named command-line flag out of the
+// output.
+//
+// MarkSecret 使名为 name 的命令行标志的值不出现在输出中。
+func MarkSecret(name string) {
+	CommandLine.MarkSecret(name)
+}
+
+// shownValue returns value as it may be shown for flag: *** if the flag is
+// secret.
+//
+// shownValue 返回 flag 的值 value 可以被显示的样子：如果标志是秘密的，则为 ***。
+func shownValue(flag *Flag, value string) string {
+	if flag.Secret {
+		return secretMask
+	}
+	return value
+}
+
+// redact replaces the value in e with ***, in its message and in the
+// message of the error returned by Set, which may quote the value as
+// strconv does. Err still unwraps to that error.
+//
+// redact 将 e 中的值替换为 ***，包括它的错误信息以及 Set 返回的错误的信息，后者可能像
+// strconv 那样引用这个值。Err 仍然可以解包为那个错误。
+func (e *ErrInvalidValue) redact() {
+	if e.Value == secretMask {
+		return
+	}
+	if e.Value != "" {
+		quoted := strconv.Quote(e.Value)
+		// 标志名称可能包含较短的值，所以错误信息中只替换带引号的值
+		e.msg = strings.Replace(e.msg, quoted, strconv.Quote(secretMask), -1) // only the quoted value, as the flag name may contain it
+		if e.Err != nil {
+			r := strings.NewReplacer(quoted, strconv.Quote(secretMask), e.Value, secretMask)
+			e.Err = &wrapError{msg: r.Replace(e.Err.Error()), err: e.Err}
+		}
+	}
+	e.Value = secretMask
+}
@@ -0,0 +1,113 @@
+// Copyright 2018 Li Zebang. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package flag_test
+
+import (
+	"bytes"
+	"errors"
+	. "flag"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestMarkSecret(t *testing.T) {
+	fs := NewFlagSet("secret", ContinueOnError)
+	var buf bytes.Buffer
+	fs.SetOutput(&buf)
+	password := fs.String("password", "hunter2", "`password` of the database")
+	fs.Int("pin", 1234, "pin")
+	fs.String("user", "admin", "user")
+	fs.MarkSecret("password")
+	fs.MarkSecret("pin")
+
+	fs.PrintDefaults()
+	if out := buf.String(); strings.Contains(out, "hunter2") || strings.Contains(out, "1234") ||
+		!strings.Contains(out, "(default ***)") || !strings.Contains(out, `(default "admin")`) {
+		t.Errorf("PrintDefaults:\n%s", out)
+	}
+
+	if err := fs.Parse([]string{"-password", "s3cret", "-user", "root"}); err != nil {
+		t.Fatal(err)
+	}
+	if *password != "s3cret" {
+		t.Errorf("password = %q; want the real value", *password)
+	}
+	if args, want := fs.ToArgs(), []string{"-password=***", "-user=root"}; !reflect.DeepEqual(args, want) {
+		t.Errorf("ToArgs() = %q; want %q", args, want)
+	}
+	for _, info := range fs.Describe() {
+		if info.Name == "password" && (info.Default != "***" || !info.Secret) {
+			t.Errorf("Describe: %+v", info)
+		}
+	}
+	other := NewFlagSet("other", ContinueOnError)
+	other.String("password", "letmein", "")
+	for _, d := range Diff(fs, other) {
+		if d.Name == "password" && (d.A != "***" || d.B != "letmein") {
+			t.Errorf("Diff: %+v", d)
+		}
+	}
+}
+
+func TestMarkSecretInvalidValue(t *testing.T) {
+	fs := NewFlagSet("secret", ContinueOnError)
+	var buf bytes.Buffer
+	fs.SetOutput(&buf)
+	fs.Int("pin", 0, "pin")
+	fs.MarkSecret("pin")
+	err := fs.Parse([]string{"-pin", "12x4"})
+	want := `invalid value "***" for flag -pin: strconv.ParseInt: parsing "***": invalid syntax`
+	if err == nil || err.Error() != want {
+		t.Fatalf("Parse = %v; want %s", err, want)
+	}
+	if strings.Contains(buf.String(), "12x4") {
+		t.Errorf("output shows the secret:\n%s", buf.String())
+	}
+	// 原来的错误仍然可以取回
+	if !errors.Is(err, strconv.ErrSyntax) { // the original error can still be recovered
+		t.Errorf("errors.Is(%v, strconv.ErrSyntax) = false", err)
+	}
+}
+
+func TestMarkSecretShorthand(t *testing.T) {
+	for _, tt := range []struct {
+		mode ParseMode
+		args []string
+	}{
+		{0, []string{"-p", "12x4"}},
+		{0, []string{"-p=12x4"}},
+		{CombinedShorts, []string{"-p12x4"}},
+		{CombinedShorts, []string{"-vp", "12x4"}},
+	} {
+		fs := NewFlagSet("secret", ContinueOnError)
+		var buf bytes.Buffer
+		fs.SetOutput(&buf)
+		fs.SetParseMode(tt.mode)
+		fs.BoolP("verbose", "v", false, "verbose")
+		fs.IntP("pin", "p", 0, "pin")
+		fs.MarkSecret("pin")
+		err := fs.Parse(tt.args)
+		var e *ErrInvalidValue
+		if !errors.As(err, &e) || e.Value != "***" {
+			t.Errorf("Parse(%q) = %v; want the value masked", tt.args, err)
+		}
+		if strings.Contains(buf.String(), "12x4") {
+			t.Errorf("Parse(%q) output shows the secret:\n%s", tt.args, buf.String())
+		}
+	}
+}
+
+func TestMarkSecretUndefined(t *testing.T) {
+	fs := NewFlagSet("secret", ContinueOnError)
+	fs.SetOutput(new(bytes.Buffer))
+	defer func() {
+		if msg := recover(); msg != "secret flag provided to MarkSecret but not defined: -nope" {
+			t.Errorf("panic = %v", msg)
+		}
+	}()
+	fs.MarkSecret("nope")
+}
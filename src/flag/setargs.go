@@ -0,0 +1,56 @@
+// Copyright 2018 Li Zebang. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package flag
+
+import "os"
+
+// SetArgs stages args, which should not include the command name, as the
+// arguments that Execute parses in place of os.Args[1:]. A test can then
+// define the flags once and stage different arguments before each
+// Execute. SetArgs(nil) goes back to os.Args[1:], while an empty non-nil
+// slice stages no arguments at all. Flags keep the values of earlier runs
+// unless they are set again; call ResetParsed between runs to start afresh.
+//
+// SetArgs 暂存 args（不应包含命令名称），作为 Execute 代替 os.Args[1:] 解析的参数。这样测试
+// 可以只定义一次标志，然后在每次 Execute 之前暂存不同的参数。SetArgs(nil) 恢复为使用
+// os.Args[1:]，而空的非 nil 切片表示没有任何参数。除非被再次设置，标志会保留之前运行的值；
+// 在两次运行之间调用 ResetParsed 可以重新开始。
+func (f *FlagSet) SetArgs(args []string) {
+	if args == nil {
+		f.stagedArgs = nil
+		return
+	}
+	f.stagedArgs = append([]string{}, args...)
+}
+
+// SetArgs stages args as the arguments that Execute parses for the
+// command-line flags.
+//
+// SetArgs 暂存 args，作为 Execute 为命令行标志解析的参数。
+func SetArgs(args []string) {
+	CommandLine.SetArgs(args)
+}
+
+// Execute parses the arguments staged with SetArgs, or os.Args[1:] if none
+// are staged, and returns the result of Parse. It may be called again to
+// parse the same arguments or, after another SetArgs, different ones.
+//
+// Execute 解析用 SetArgs 暂存的参数，如果没有暂存参数则解析 os.Args[1:]，并返回 Parse 的
+// 结果。它可以被再次调用，解析同样的参数，或者在再次调用 SetArgs 之后解析不同的参数。
+func (f *FlagSet) Execute() error {
+	args := f.stagedArgs
+	if args == nil {
+		args = os.Args[1:]
+	}
+	return f.Parse(args)
+}
+
+// Execute parses the arguments staged for the command-line flags, or
+// os.Args[1:].
+//
+// Execute 解析为命令行标志暂存的参数，或者 os.Args[1:]。
+func Execute() error {
+	return CommandLine.Execute()
+}
@@ -0,0 +1,43 @@
+// Copyright 2018 Li Zebang. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package flag_test
+
+import (
+	. "flag"
+	"os"
+	"reflect"
+	"testing"
+)
+
+func TestSetArgsExecute(t *testing.T) {
+	defer func(old []string) { os.Args = old }(os.Args)
+	os.Args = []string{"cmd", "-n", "1", "from-os"}
+
+	fs := NewFlagSet("exec", ContinueOnError)
+	n := fs.Int("n", 0, "")
+	if err := fs.Execute(); err != nil || *n != 1 || !reflect.DeepEqual(fs.Args(), []string{"from-os"}) {
+		t.Errorf("Execute() = %v with n = %d, args %q", err, *n, fs.Args())
+	}
+
+	args := []string{"-n", "2", "staged"}
+	fs.SetArgs(args)
+	args[1] = "3"
+	if err := fs.Execute(); err != nil || *n != 2 || !reflect.DeepEqual(fs.Args(), []string{"staged"}) {
+		t.Errorf("Execute() after SetArgs = %v with n = %d, args %q", err, *n, fs.Args())
+	}
+
+	fs.SetArgs([]string{})
+	if err := fs.Execute(); err != nil || len(fs.Args()) != 0 {
+		t.Errorf("Execute() with no staged arguments = %v, args %q", err, fs.Args())
+	}
+	if *n != 2 {
+		t.Errorf("n = %d after a run without -n; want the earlier 2", *n)
+	}
+
+	fs.SetArgs(nil)
+	if err := fs.Execute(); err != nil || !reflect.DeepEqual(fs.Args(), []string{"from-os"}) {
+		t.Errorf("Execute() after SetArgs(nil) = %v, args %q", err, fs.Args())
+	}
+}
@@ -0,0 +1,351 @@
+// Copyright 2018 Li Zebang. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package flag
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// IMP: 短名称只是 shorthands 中指向同一个 *Flag 的另一个键，formal 和 actual 中仍然只用
+// 长名称作为键，所以 Visit、VisitAll 和 NFlag 不会把同一个标志算两次。
+
+// VarP is like Var, but also registers shorthand, a single ASCII letter, as an
+// abbreviation of the flag: -v is then equivalent to -verbose. An empty
+// shorthand is the same as calling Var.
+//
+// VarP 类似于 Var，但还会将单个 ASCII 字母 shorthand 注册为该标志的缩写：这样 -v 就等价于
+// -verbose。shorthand 为空时和调用 Var 相同。
+func (f *FlagSet) VarP(value Value, name, shorthand, usage string) {
+	if shorthand == "" {
+		f.Var(value, name, usage)
+		return
+	}
+	var msg string
+	switch {
+	case len(shorthand) != 1 || !isLetter(shorthand[0]):
+		msg = fmt.Sprintf("flag shorthand %q for %s is not a single ASCII letter", shorthand, name)
+	case f.shorthands[shorthand] != nil:
+		msg = fmt.Sprintf("flag shorthand redefined: %s", shorthand)
+	case f.formal[shorthand] != nil || f.aliases[shorthand] != nil:
+		msg = fmt.Sprintf("flag shorthand %s for %s is already a flag name", shorthand, name)
+	}
+	if msg != "" {
+		if f.name != "" {
+			msg = f.name + " " + msg
+		}
+		fmt.Fprintln(f.Output(), msg)
+		panic(msg)
+	}
+	flag, err := f.define(value, name, usage)
+	if err != nil {
+		msg := err.Error()
+		fmt.Fprintln(f.Output(), msg)
+		panic(msg)
+	}
+	if flag == nil {
+		// 冲突解决函数保留了已有的标志
+		return // the conflict resolver kept the existing flag
+	}
+	flag.Shorthand = shorthand
+	if f.shorthands == nil {
+		f.shorthands = make(map[string]*Flag)
+	}
+	f.shorthands[shorthand] = flag
+}
+
+// VarP is like Var, but accepts a shorthand for the flag.
+//
+// VarP 类似于 Var，但接受该标志的缩写。
+func VarP(value Value, name, shorthand, usage string) {
+	CommandLine.VarP(value, name, shorthand, usage)
+}
+
+func isLetter(c byte) bool {
+	return 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z'
+}
+
+// BoolVarP is like BoolVar, but accepts a shorthand for the flag.
+//
+// BoolVarP 类似于 BoolVar，但接受该标志的缩写。
+func (f *FlagSet) BoolVarP(p *bool, name, shorthand string, value bool, usage string) {
+	f.VarP(newBoolValue(value, p), name, shorthand, usage)
+}
+
+// BoolVarP is like BoolVar, but accepts a shorthand for the flag.
+//
+// BoolVarP 类似于 BoolVar，但接受该标志的缩写。
+func BoolVarP(p *bool, name, shorthand string, value bool, usage string) {
+	CommandLine.VarP(newBoolValue(value, p), name, shorthand, usage)
+}
+
+// BoolP is like Bool, but accepts a shorthand for the flag.
+//
+// BoolP 类似于 Bool，但接受该标志的缩写。
+func (f *FlagSet) BoolP(name, shorthand string, value bool, usage string) *bool {
+	p := new(bool)
+	f.BoolVarP(p, name, shorthand, value, usage)
+	return p
+}
+
+// BoolP is like Bool, but accepts a shorthand for the flag.
+//
+// BoolP 类似于 Bool，但接受该标志的缩写。
+func BoolP(name, shorthand string, value bool, usage string) *bool {
+	return CommandLine.BoolP(name, shorthand, value, usage)
+}
+
+// IntVarP is like IntVar, but accepts a shorthand for the flag.
+//
+// IntVarP 类似于 IntVar，但接受该标志的缩写。
+func (f *FlagSet) IntVarP(p *int, name, shorthand string, value int, usage string) {
+	f.VarP(newIntValue(value, p), name, shorthand, usage)
+}
+
+// IntVarP is like IntVar, but accepts a shorthand for the flag.
+//
+// IntVarP 类似于 IntVar，但接受该标志的缩写。
+func IntVarP(p *int, name, shorthand string, value int, usage string) {
+	CommandLine.VarP(newIntValue(value, p), name, shorthand, usage)
+}
+
+// IntP is like Int, but accepts a shorthand for the flag.
+//
+// IntP 类似于 Int，但接受该标志的缩写。
+func (f *FlagSet) IntP(name, shorthand string, value int, usage string) *int {
+	p := new(int)
+	f.IntVarP(p, name, shorthand, value, usage)
+	return p
+}
+
+// IntP is like Int, but accepts a shorthand for the flag.
+//
+// IntP 类似于 Int，但接受该标志的缩写。
+func IntP(name, shorthand string, value int, usage string) *int {
+	return CommandLine.IntP(name, shorthand, value, usage)
+}
+
+// Int64VarP is like Int64Var, but accepts a shorthand for the flag.
+//
+// Int64VarP 类似于 Int64Var，但接受该标志的缩写。
+func (f *FlagSet) Int64VarP(p *int64, name, shorthand string, value int64, usage string) {
+	f.VarP(newInt64Value(value, p), name, shorthand, usage)
+}
+
+// Int64VarP is like Int64Var, but accepts a shorthand for the flag.
+//
+// Int64VarP 类似于 Int64Var，但接受该标志的缩写。
+func Int64VarP(p *int64, name, shorthand string, value int64, usage string) {
+	CommandLine.VarP(newInt64Value(value, p), name, shorthand, usage)
+}
+
+// Int64P is like Int64, but accepts a shorthand for the flag.
+//
+// Int64P 类似于 Int64，但接受该标志的缩写。
+func (f *FlagSet) Int64P(name, shorthand string, value int64, usage string) *int64 {
+	p := new(int64)
+	f.Int64VarP(p, name, shorthand, value, usage)
+	return p
+}
+
+// Int64P is like Int64, but accepts a shorthand for the flag.
+//
+// Int64P 类似于 Int64，但接受该标志的缩写。
+func Int64P(name, shorthand string, value int64, usage string) *int64 {
+	return CommandLine.Int64P(name, shorthand, value, usage)
+}
+
+// UintVarP is like UintVar, but accepts a shorthand for the flag.
+//
+// UintVarP 类似于 UintVar，但接受该标志的缩写。
+func (f *FlagSet) UintVarP(p *uint, name, shorthand string, value uint, usage string) {
+	f.VarP(newUintValue(value, p), name, shorthand, usage)
+}
+
+// UintVarP is like UintVar, but accepts a shorthand for the flag.
+//
+// UintVarP 类似于 UintVar，但接受该标志的缩写。
+func UintVarP(p *uint, name, shorthand string, value uint, usage string) {
+	CommandLine.VarP(newUintValue(value, p), name, shorthand, usage)
+}
+
+// UintP is like Uint, but accepts a shorthand for the flag.
+//
+// UintP 类似于 Uint，但接受该标志的缩写。
+func (f *FlagSet) UintP(name, shorthand string, value uint, usage string) *uint {
+	p := new(uint)
+	f.UintVarP(p, name, shorthand, value, usage)
+	return p
+}
+
+// UintP is like Uint, but accepts a shorthand for the flag.
+//
+// UintP 类似于 Uint，但接受该标志的缩写。
+func UintP(name, shorthand string, value uint, usage string) *uint {
+	return CommandLine.UintP(name, shorthand, value, usage)
+}
+
+// Uint64VarP is like Uint64Var, but accepts a shorthand for the flag.
+//
+// Uint64VarP 类似于 Uint64Var，但接受该标志的缩写。
+func (f *FlagSet) Uint64VarP(p *uint64, name, shorthand string, value uint64, usage string) {
+	f.VarP(newUint64Value(value, p), name, shorthand, usage)
+}
+
+// Uint64VarP is like Uint64Var, but accepts a shorthand for the flag.
+//
+// Uint64VarP 类似于 Uint64Var，但接受该标志的缩写。
+func Uint64VarP(p *uint64, name, shorthand string, value uint64, usage string) {
+	CommandLine.VarP(newUint64Value(value, p), name, shorthand, usage)
+}
+
+// Uint64P is like Uint64, but accepts a shorthand for the flag.
+//
+// Uint64P 类似于 Uint64，但接受该标志的缩写。
+func (f *FlagSet) Uint64P(name, shorthand string, value uint64, usage string) *uint64 {
+	p := new(uint64)
+	f.Uint64VarP(p, name, shorthand, value, usage)
+	return p
+}
+
+// Uint64P is like Uint64, but accepts a shorthand for the flag.
+//
+// Uint64P 类似于 Uint64，但接受该标志的缩写。
+func Uint64P(name, shorthand string, value uint64, usage string) *uint64 {
+	return CommandLine.Uint64P(name, shorthand, value, usage)
+}
+
+// StringVarP is like StringVar, but accepts a shorthand for the flag.
+//
+// StringVarP 类似于 StringVar，但接受该标志的缩写。
+func (f *FlagSet) StringVarP(p *string, name, shorthand string, value string, usage string) {
+	f.VarP(newStringValue(value, p), name, shorthand, usage)
+}
+
+// StringVarP is like StringVar, but accepts a shorthand for the flag.
+//
+// StringVarP 类似于 StringVar，但接受该标志的缩写。
+func StringVarP(p *string, name, shorthand string, value string, usage string) {
+	CommandLine.VarP(newStringValue(value, p), name, shorthand, usage)
+}
+
+// StringP is like String, but accepts a shorthand for the flag.
+//
+// StringP 类似于 String，但接受该标志的缩写。
+func (f *FlagSet) StringP(name, shorthand string, value string, usage string) *string {
+	p := new(string)
+	f.StringVarP(p, name, shorthand, value, usage)
+	return p
+}
+
+// StringP is like String, but accepts a shorthand for the flag.
+//
+// StringP 类似于 String，但接受该标志的缩写。
+func StringP(name, shorthand string, value string, usage string) *string {
+	return CommandLine.StringP(name, shorthand, value, usage)
+}
+
+// Float64VarP is like Float64Var, but accepts a shorthand for the flag.
+//
+// Float64VarP 类似于 Float64Var，但接受该标志的缩写。
+func (f *FlagSet) Float64VarP(p *float64, name, shorthand string, value float64, usage string) {
+	f.VarP(newFloat64Value(value, p), name, shorthand, usage)
+}
+
+// Float64VarP is like Float64Var, but accepts a shorthand for the flag.
+//
+// Float64VarP 类似于 Float64Var，但接受该标志的缩写。
+func Float64VarP(p *float64, name, shorthand string, value float64, usage string) {
+	CommandLine.VarP(newFloat64Value(value, p), name, shorthand, usage)
+}
+
+// Float64P is like Float64, but accepts a shorthand for the flag.
+//
+// Float64P 类似于 Float64，但接受该标志的缩写。
+func (f *FlagSet) Float64P(name, shorthand string, value float64, usage string) *float64 {
+	p := new(float64)
+	f.Float64VarP(p, name, shorthand, value, usage)
+	return p
+}
+
+// Float64P is like Float64, but accepts a shorthand for the flag.
+//
+// Float64P 类似于 Float64，但接受该标志的缩写。
+func Float64P(name, shorthand string, value float64, usage string) *float64 {
+	return CommandLine.Float64P(name, shorthand, value, usage)
+}
+
+// DurationVarP is like DurationVar, but accepts a shorthand for the flag.
+//
+// DurationVarP 类似于 DurationVar，但接受该标志的缩写。
+func (f *FlagSet) DurationVarP(p *time.Duration, name, shorthand string, value time.Duration, usage string) {
+	f.VarP(newDurationValue(value, p), name, shorthand, usage)
+}
+
+// DurationVarP is like DurationVar, but accepts a shorthand for the flag.
+//
+// DurationVarP 类似于 DurationVar，但接受该标志的缩写。
+func DurationVarP(p *time.Duration, name, shorthand string, value time.Duration, usage string) {
+	CommandLine.VarP(newDurationValue(value, p), name, shorthand, usage)
+}
+
+// DurationP is like Duration, but accepts a shorthand for the flag.
+//
+// DurationP 类似于 Duration，但接受该标志的缩写。
+func (f *FlagSet) DurationP(name, shorthand string, value time.Duration, usage string) *time.Duration {
+	p := new(time.Duration)
+	f.DurationVarP(p, name, shorthand, value, usage)
+	return p
+}
+
+// DurationP is like Duration, but accepts a shorthand for the flag.
+//
+// DurationP 类似于 Duration，但接受该标志的缩写。
+func DurationP(name, shorthand string, value time.Duration, usage string) *time.Duration {
+	return CommandLine.DurationP(name, shorthand, value, usage)
+}
+
+// ShorthandLookup returns the Flag structure of the flag whose shorthand is
+// the given letter, returning nil if none exists.
+//
+// ShorthandLookup 返回缩写为给定字母的标志的 Flag 结构，如果不存在则返回 nil。
+func (f *FlagSet) ShorthandLookup(shorthand string) *Flag {
+	return f.shorthands[shorthand]
+}
+
+// ShorthandLookup returns the Flag structure of the command-line flag whose
+// shorthand is the given letter, returning nil if none exists.
+//
+// ShorthandLookup 返回缩写为给定字母的命令行标志的 Flag 结构，如果不存在则返回 nil。
+func ShorthandLookup(shorthand string) *Flag {
+	return CommandLine.ShorthandLookup(shorthand)
+}
+
+// LookupAny returns the Flag structure of the flag that Parse would set for
+// the given spelling: a name, an alias, a shorthand or the -no- form of a
+// negatable flag, with or without its dashes. It returns nil if none
+// exists.
+//
+// LookupAny 返回 Parse 对给定的拼写会设置的标志的 Flag 结构：拼写可以是名称、别名、缩写
+// 或者可否定标志的 -no- 形式，可以带有横线也可以不带。如果不存在则返回 nil。
+func (f *FlagSet) LookupAny(spelling string) *Flag {
+	name := strings.TrimPrefix(strings.TrimPrefix(spelling, "-"), "-")
+	if flag := f.lookupName(name); flag != nil {
+		return flag
+	}
+	if flag := f.shorthands[name]; flag != nil {
+		return flag
+	}
+	return f.lookupNegated(name)
+}
+
+// LookupAny returns the Flag structure of the command-line flag that Parse
+// would set for the given spelling, returning nil if none exists.
+//
+// LookupAny 返回 Parse 对给定的拼写会设置的命令行标志的 Flag 结构，如果不存在则返回 nil。
+func LookupAny(spelling string) *Flag {
+	return CommandLine.LookupAny(spelling)
+}
@@ -0,0 +1,157 @@
+// Copyright 2018 Li Zebang. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package flag_test
+
+import (
+	"bytes"
+	. "flag"
+	"testing"
+	"time"
+)
+
+func TestShorthand(t *testing.T) {
+	fs := NewFlagSet("shorthand", ContinueOnError)
+	verbose := fs.BoolP("verbose", "v", false, "verbose output")
+	output := fs.StringP("output", "o", "", "output file")
+	n := fs.IntP("count", "n", 1, "count")
+	d := fs.DurationP("timeout", "t", time.Second, "timeout")
+	plain := fs.Bool("plain", false, "no shorthand")
+
+	args := []string{"-v", "-o", "out.txt", "--count=3", "-t=2s", "--plain", "rest"}
+	if err := fs.Parse(args); err != nil {
+		t.Fatal(err)
+	}
+	if !*verbose || *output != "out.txt" || *n != 3 || *d != 2*time.Second || !*plain {
+		t.Errorf("got verbose=%v output=%q count=%d timeout=%v plain=%v", *verbose, *output, *n, *d, *plain)
+	}
+	if fs.NFlag() != 5 {
+		t.Errorf("NFlag() = %d; want 5", fs.NFlag())
+	}
+	var visited []string
+	fs.Visit(func(f *Flag) { visited = append(visited, f.Name) })
+	want := []string{"count", "output", "plain", "timeout", "verbose"}
+	if len(visited) != len(want) {
+		t.Fatalf("Visit visited %q; want %q", visited, want)
+	}
+	for i := range want {
+		if visited[i] != want[i] {
+			t.Errorf("Visit visited %q; want %q", visited, want)
+			break
+		}
+	}
+	if f := fs.Lookup("verbose"); f == nil || f.Shorthand != "v" {
+		t.Errorf("Lookup(verbose) = %+v; want Shorthand v", f)
+	}
+	if fs.Arg(0) != "rest" {
+		t.Errorf("Arg(0) = %q; want rest", fs.Arg(0))
+	}
+}
+
+func TestShorthandCommandLine(t *testing.T) {
+	ResetForTesting(nil)
+	var s string
+	StringVarP(&s, "name", "N", "", "name")
+	if err := CommandLine.Parse([]string{"-N", "gopher"}); err != nil {
+		t.Fatal(err)
+	}
+	if s != "gopher" {
+		t.Errorf("s = %q; want gopher", s)
+	}
+}
+
+func TestShorthandRedefined(t *testing.T) {
+	tests := []struct {
+		name   string
+		define func(fs *FlagSet)
+	}{
+		{"shorthand twice", func(fs *FlagSet) {
+			fs.BoolP("a", "x", false, "")
+			fs.BoolP("b", "x", false, "")
+		}},
+		{"shorthand is a flag", func(fs *FlagSet) {
+			fs.Bool("x", false, "")
+			fs.BoolP("b", "x", false, "")
+		}},
+		{"flag is a shorthand", func(fs *FlagSet) {
+			fs.BoolP("b", "x", false, "")
+			fs.Bool("x", false, "")
+		}},
+		{"long shorthand", func(fs *FlagSet) {
+			fs.BoolP("b", "xy", false, "")
+		}},
+		{"not a letter", func(fs *FlagSet) {
+			fs.BoolP("b", "1", false, "")
+		}},
+	}
+	for _, tt := range tests {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("%s: no panic", tt.name)
+				}
+			}()
+			fs := NewFlagSet("test", ContinueOnError)
+			fs.SetOutput(new(bytes.Buffer))
+			tt.define(fs)
+		}()
+	}
+}
+
+const shorthandDefaults = `  -f	a flag
+  -o, --output file
+    	output file (default "a.out")
+  -v, --verbose
+    	verbose output
+`
+
+func TestShorthandPrintDefaults(t *testing.T) {
+	fs := NewFlagSet("print defaults test", ContinueOnError)
+	var buf bytes.Buffer
+	fs.SetOutput(&buf)
+	fs.Bool("f", false, "a flag")
+	fs.StringP("output", "o", "a.out", "output `file`")
+	fs.BoolP("verbose", "v", false, "verbose output")
+	fs.PrintDefaults()
+	if got := buf.String(); got != shorthandDefaults {
+		t.Errorf("got %q want %q", got, shorthandDefaults)
+	}
+}
+
+func TestShorthandLookupAndLookupAny(t *testing.T) {
+	fs := NewFlagSet("shorthand", ContinueOnError)
+	fs.BoolP("verbose", "v", false, "verbose output")
+	fs.NegatableBool("color", true, "colorize")
+	fs.Alias("colour", "color")
+	fs.Bool("x", false, "a one-letter name")
+	if f := fs.ShorthandLookup("v"); f == nil || f.Name != "verbose" {
+		t.Errorf("ShorthandLookup(v) = %+v", f)
+	}
+	if f := fs.ShorthandLookup("x"); f != nil {
+		t.Errorf("ShorthandLookup(x) = %+v; want nil for a flag name", f)
+	}
+	tests := []struct {
+		spelling, want string
+	}{
+		{"verbose", "verbose"},
+		{"--verbose", "verbose"},
+		{"-v", "verbose"},
+		{"colour", "color"},
+		{"-no-color", "color"},
+		{"--no-colour", "color"},
+		{"x", "x"},
+		{"no-verbose", ""},
+		{"---v", ""},
+		{"q", ""},
+	}
+	for _, tt := range tests {
+		got := ""
+		if f := fs.LookupAny(tt.spelling); f != nil {
+			got = f.Name
+		}
+		if got != tt.want {
+			t.Errorf("LookupAny(%q) = %q; want %q", tt.spelling, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,423 @@
+// Copyright 2018 Li Zebang. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package flag
+
+import "strconv"
+
+// IMP: 这些标志按照类型的位数解析值，超出范围的值会返回错误并且不会修改变量，因此不需要
+// 先定义为 int 再手动检查范围。
+
+// -- int8 Value
+type int8Value int8
+
+func newInt8Value(val int8, p *int8) *int8Value {
+	*p = val
+	return (*int8Value)(p)
+}
+
+func (i *int8Value) Set(s string) error {
+	v, err := strconv.ParseInt(s, 0, 8)
+	if err != nil {
+		return err
+	}
+	*i = int8Value(v)
+	return nil
+}
+
+func (i *int8Value) Get() interface{} { return int8(*i) }
+
+func (i *int8Value) String() string { return strconv.FormatInt(int64(*i), 10) }
+
+// Int8Var defines an int8 flag with specified name, default value, and usage string.
+// The argument p points to an int8 variable in which to store the value of the flag.
+//
+// Int8Var 定义一个具有指定名称、默认值和用法信息的 int8 标志。参数 p 指向一个用来存储标志的值的
+// int8 变量。
+func (f *FlagSet) Int8Var(p *int8, name string, value int8, usage string) {
+	f.Var(newInt8Value(value, p), name, usage)
+}
+
+// Int8Var defines an int8 flag with specified name, default value, and usage string.
+// The argument p points to an int8 variable in which to store the value of the flag.
+//
+// Int8Var 定义一个具有指定名称、默认值和用法信息的 int8 标志。参数 p 指向一个用来存储标志的值的
+// int8 变量。
+func Int8Var(p *int8, name string, value int8, usage string) {
+	CommandLine.Var(newInt8Value(value, p), name, usage)
+}
+
+// Int8 defines an int8 flag with specified name, default value, and usage string.
+// The return value is the address of an int8 variable that stores the value of the flag.
+//
+// Int8 定义一个具有指定名称、默认值和用法信息的 int8 标志。返回值是存储标志的值的 int8 变量的
+// 地址。
+func (f *FlagSet) Int8(name string, value int8, usage string) *int8 {
+	p := new(int8)
+	f.Int8Var(p, name, value, usage)
+	return p
+}
+
+// Int8 defines an int8 flag with specified name, default value, and usage string.
+// The return value is the address of an int8 variable that stores the value of the flag.
+//
+// Int8 定义一个具有指定名称、默认值和用法信息的 int8 标志。返回值是存储标志的值的 int8 变量的
+// 地址。
+func Int8(name string, value int8, usage string) *int8 {
+	return CommandLine.Int8(name, value, usage)
+}
+
+// -- int16 Value
+type int16Value int16
+
+func newInt16Value(val int16, p *int16) *int16Value {
+	*p = val
+	return (*int16Value)(p)
+}
+
+func (i *int16Value) Set(s string) error {
+	v, err := strconv.ParseInt(s, 0, 16)
+	if err != nil {
+		return err
+	}
+	*i = int16Value(v)
+	return nil
+}
+
+func (i *int16Value) Get() interface{} { return int16(*i) }
+
+func (i *int16Value) String() string { return strconv.FormatInt(int64(*i), 10) }
+
+// Int16Var defines an int16 flag with specified name, default value, and usage string.
+// The argument p points to an int16 variable in which to store the value of the flag.
+//
+// Int16Var 定义一个具有指定名称、默认值和用法信息的 int16 标志。参数 p 指向一个用来存储标志的值的
+// int16 变量。
+func (f *FlagSet) Int16Var(p *int16, name string, value int16, usage string) {
+	f.Var(newInt16Value(value, p), name, usage)
+}
+
+// Int16Var defines an int16 flag with specified name, default value, and usage string.
+// The argument p points to an int16 variable in which to store the value of the flag.
+//
+// Int16Var 定义一个具有指定名称、默认值和用法信息的 int16 标志。参数 p 指向一个用来存储标志的值的
+// int16 变量。
+func Int16Var(p *int16, name string, value int16, usage string) {
+	CommandLine.Var(newInt16Value(value, p), name, usage)
+}
+
+// Int16 defines an int16 flag with specified name, default value, and usage string.
+// The return value is the address of an int16 variable that stores the value of the flag.
+//
+// Int16 定义一个具有指定名称、默认值和用法信息的 int16 标志。返回值是存储标志的值的 int16 变量的
+// 地址。
+func (f *FlagSet) Int16(name string, value int16, usage string) *int16 {
+	p := new(int16)
+	f.Int16Var(p, name, value, usage)
+	return p
+}
+
+// Int16 defines an int16 flag with specified name, default value, and usage string.
+// The return value is the address of an int16 variable that stores the value of the flag.
+//
+// Int16 定义一个具有指定名称、默认值和用法信息的 int16 标志。返回值是存储标志的值的 int16 变量的
+// 地址。
+func Int16(name string, value int16, usage string) *int16 {
+	return CommandLine.Int16(name, value, usage)
+}
+
+// -- int32 Value
+type int32Value int32
+
+func newInt32Value(val int32, p *int32) *int32Value {
+	*p = val
+	return (*int32Value)(p)
+}
+
+func (i *int32Value) Set(s string) error {
+	v, err := strconv.ParseInt(s, 0, 32)
+	if err != nil {
+		return err
+	}
+	*i = int32Value(v)
+	return nil
+}
+
+func (i *int32Value) Get() interface{} { return int32(*i) }
+
+func (i *int32Value) String() string { return strconv.FormatInt(int64(*i), 10) }
+
+// Int32Var defines an int32 flag with specified name, default value, and usage string.
+// The argument p points to an int32 variable in which to store the value of the flag.
+//
+// Int32Var 定义一个具有指定名称、默认值和用法信息的 int32 标志。参数 p 指向一个用来存储标志的值的
+// int32 变量。
+func (f *FlagSet) Int32Var(p *int32, name string, value int32, usage string) {
+	f.Var(newInt32Value(value, p), name, usage)
+}
+
+// Int32Var defines an int32 flag with specified name, default value, and usage string.
+// The argument p points to an int32 variable in which to store the value of the flag.
+//
+// Int32Var 定义一个具有指定名称、默认值和用法信息的 int32 标志。参数 p 指向一个用来存储标志的值的
+// int32 变量。
+func Int32Var(p *int32, name string, value int32, usage string) {
+	CommandLine.Var(newInt32Value(value, p), name, usage)
+}
+
+// Int32 defines an int32 flag with specified name, default value, and usage string.
+// The return value is the address of an int32 variable that stores the value of the flag.
+//
+// Int32 定义一个具有指定名称、默认值和用法信息的 int32 标志。返回值是存储标志的值的 int32 变量的
+// 地址。
+func (f *FlagSet) Int32(name string, value int32, usage string) *int32 {
+	p := new(int32)
+	f.Int32Var(p, name, value, usage)
+	return p
+}
+
+// Int32 defines an int32 flag with specified name, default value, and usage string.
+// The return value is the address of an int32 variable that stores the value of the flag.
+//
+// Int32 定义一个具有指定名称、默认值和用法信息的 int32 标志。返回值是存储标志的值的 int32 变量的
+// 地址。
+func Int32(name string, value int32, usage string) *int32 {
+	return CommandLine.Int32(name, value, usage)
+}
+
+// -- uint8 Value
+type uint8Value uint8
+
+func newUint8Value(val uint8, p *uint8) *uint8Value {
+	*p = val
+	return (*uint8Value)(p)
+}
+
+func (i *uint8Value) Set(s string) error {
+	v, err := strconv.ParseUint(s, 0, 8)
+	if err != nil {
+		return err
+	}
+	*i = uint8Value(v)
+	return nil
+}
+
+func (i *uint8Value) Get() interface{} { return uint8(*i) }
+
+func (i *uint8Value) String() string { return strconv.FormatUint(uint64(*i), 10) }
+
+// Uint8Var defines a uint8 flag with specified name, default value, and usage string.
+// The argument p points to a uint8 variable in which to store the value of the flag.
+//
+// Uint8Var 定义一个具有指定名称、默认值和用法信息的 uint8 标志。参数 p 指向一个用来存储标志的值的
+// uint8 变量。
+func (f *FlagSet) Uint8Var(p *uint8, name string, value uint8, usage string) {
+	f.Var(newUint8Value(value, p), name, usage)
+}
+
+// Uint8Var defines a uint8 flag with specified name, default value, and usage string.
+// The argument p points to a uint8 variable in which to store the value of the flag.
+//
+// Uint8Var 定义一个具有指定名称、默认值和用法信息的 uint8 标志。参数 p 指向一个用来存储标志的值的
+// uint8 变量。
+func Uint8Var(p *uint8, name string, value uint8, usage string) {
+	CommandLine.Var(newUint8Value(value, p), name, usage)
+}
+
+// Uint8 defines a uint8 flag with specified name, default value, and usage string.
+// The return value is the address of a uint8 variable that stores the value of the flag.
+//
+// Uint8 定义一个具有指定名称、默认值和用法信息的 uint8 标志。返回值是存储标志的值的 uint8 变量的
+// 地址。
+func (f *FlagSet) Uint8(name string, value uint8, usage string) *uint8 {
+	p := new(uint8)
+	f.Uint8Var(p, name, value, usage)
+	return p
+}
+
+// Uint8 defines a uint8 flag with specified name, default value, and usage string.
+// The return value is the address of a uint8 variable that stores the value of the flag.
+//
+// Uint8 定义一个具有指定名称、默认值和用法信息的 uint8 标志。返回值是存储标志的值的 uint8 变量的
+// 地址。
+func Uint8(name string, value uint8, usage string) *uint8 {
+	return CommandLine.Uint8(name, value, usage)
+}
+
+// -- uint16 Value
+type uint16Value uint16
+
+func newUint16Value(val uint16, p *uint16) *uint16Value {
+	*p = val
+	return (*uint16Value)(p)
+}
+
+func (i *uint16Value) Set(s string) error {
+	v, err := strconv.ParseUint(s, 0, 16)
+	if err != nil {
+		return err
+	}
+	*i = uint16Value(v)
+	return nil
+}
+
+func (i *uint16Value) Get() interface{} { return uint16(*i) }
+
+func (i *uint16Value) String() string { return strconv.FormatUint(uint64(*i), 10) }
+
+// Uint16Var defines a uint16 flag with specified name, default value, and usage string.
+// The argument p points to a uint16 variable in which to store the value of the flag.
+//
+// Uint16Var 定义一个具有指定名称、默认值和用法信息的 uint16 标志。参数 p 指向一个用来存储标志的值的
+// uint16 变量。
+func (f *FlagSet) Uint16Var(p *uint16, name string, value uint16, usage string) {
+	f.Var(newUint16Value(value, p), name, usage)
+}
+
+// Uint16Var defines a uint16 flag with specified name, default value, and usage string.
+// The argument p points to a uint16 variable in which to store the value of the flag.
+//
+// Uint16Var 定义一个具有指定名称、默认值和用法信息的 uint16 标志。参数 p 指向一个用来存储标志的值的
+// uint16 变量。
+func Uint16Var(p *uint16, name string, value uint16, usage string) {
+	CommandLine.Var(newUint16Value(value, p), name, usage)
+}
+
+// Uint16 defines a uint16 flag with specified name, default value, and usage string.
+// The return value is the address of a uint16 variable that stores the value of the flag.
+//
+// Uint16 定义一个具有指定名称、默认值和用法信息的 uint16 标志。返回值是存储标志的值的 uint16 变量的
+// 地址。
+func (f *FlagSet) Uint16(name string, value uint16, usage string) *uint16 {
+	p := new(uint16)
+	f.Uint16Var(p, name, value, usage)
+	return p
+}
+
+// Uint16 defines a uint16 flag with specified name, default value, and usage string.
+// The return value is the address of a uint16 variable that stores the value of the flag.
+//
+// Uint16 定义一个具有指定名称、默认值和用法信息的 uint16 标志。返回值是存储标志的值的 uint16 变量的
+// 地址。
+func Uint16(name string, value uint16, usage string) *uint16 {
+	return CommandLine.Uint16(name, value, usage)
+}
+
+// -- uint32 Value
+type uint32Value uint32
+
+func newUint32Value(val uint32, p *uint32) *uint32Value {
+	*p = val
+	return (*uint32Value)(p)
+}
+
+func (i *uint32Value) Set(s string) error {
+	v, err := strconv.ParseUint(s, 0, 32)
+	if err != nil {
+		return err
+	}
+	*i = uint32Value(v)
+	return nil
+}
+
+func (i *uint32Value) Get() interface{} { return uint32(*i) }
+
+func (i *uint32Value) String() string { return strconv.FormatUint(uint64(*i), 10) }
+
+// Uint32Var defines a uint32 flag with specified name, default value, and usage string.
+// The argument p points to a uint32 variable in which to store the value of the flag.
+//
+// Uint32Var 定义一个具有指定名称、默认值和用法信息的 uint32 标志。参数 p 指向一个用来存储标志的值的
+// uint32 变量。
+func (f *FlagSet) Uint32Var(p *uint32, name string, value uint32, usage string) {
+	f.Var(newUint32Value(value, p), name, usage)
+}
+
+// Uint32Var defines a uint32 flag with specified name, default value, and usage string.
+// The argument p points to a uint32 variable in which to store the value of the flag.
+//
+// Uint32Var 定义一个具有指定名称、默认值和用法信息的 uint32 标志。参数 p 指向一个用来存储标志的值的
+// uint32 变量。
+func Uint32Var(p *uint32, name string, value uint32, usage string) {
+	CommandLine.Var(newUint32Value(value, p), name, usage)
+}
+
+// Uint32 defines a uint32 flag with specified name, default value, and usage string.
+// The return value is the address of a uint32 variable that stores the value of the flag.
+//
+// Uint32 定义一个具有指定名称、默认值和用法信息的 uint32 标志。返回值是存储标志的值的 uint32 变量的
+// 地址。
+func (f *FlagSet) Uint32(name string, value uint32, usage string) *uint32 {
+	p := new(uint32)
+	f.Uint32Var(p, name, value, usage)
+	return p
+}
+
+// Uint32 defines a uint32 flag with specified name, default value, and usage string.
+// The return value is the address of a uint32 variable that stores the value of the flag.
+//
+// Uint32 定义一个具有指定名称、默认值和用法信息的 uint32 标志。返回值是存储标志的值的 uint32 变量的
+// 地址。
+func Uint32(name string, value uint32, usage string) *uint32 {
+	return CommandLine.Uint32(name, value, usage)
+}
+
+// -- float32 Value
+type float32Value float32
+
+func newFloat32Value(val float32, p *float32) *float32Value {
+	*p = val
+	return (*float32Value)(p)
+}
+
+func (i *float32Value) Set(s string) error {
+	v, err := strconv.ParseFloat(s, 32)
+	if err != nil {
+		return err
+	}
+	*i = float32Value(v)
+	return nil
+}
+
+func (i *float32Value) Get() interface{} { return float32(*i) }
+
+func (i *float32Value) String() string { return strconv.FormatFloat(float64(*i), 'g', -1, 32) }
+
+// Float32Var defines a float32 flag with specified name, default value, and usage string.
+// The argument p points to a float32 variable in which to store the value of the flag.
+//
+// Float32Var 定义一个具有指定名称、默认值和用法信息的 float32 标志。参数 p 指向一个用来存储标志的值的
+// float32 变量。
+func (f *FlagSet) Float32Var(p *float32, name string, value float32, usage string) {
+	f.Var(newFloat32Value(value, p), name, usage)
+}
+
+// Float32Var defines a float32 flag with specified name, default value, and usage string.
+// The argument p points to a float32 variable in which to store the value of the flag.
+//
+// Float32Var 定义一个具有指定名称、默认值和用法信息的 float32 标志。参数 p 指向一个用来存储标志的值的
+// float32 变量。
+func Float32Var(p *float32, name string, value float32, usage string) {
+	CommandLine.Var(newFloat32Value(value, p), name, usage)
+}
+
+// Float32 defines a float32 flag with specified name, default value, and usage string.
+// The return value is the address of a float32 variable that stores the value of the flag.
+//
+// Float32 定义一个具有指定名称、默认值和用法信息的 float32 标志。返回值是存储标志的值的 float32 变量的
+// 地址。
+func (f *FlagSet) Float32(name string, value float32, usage string) *float32 {
+	p := new(float32)
+	f.Float32Var(p, name, value, usage)
+	return p
+}
+
+// Float32 defines a float32 flag with specified name, default value, and usage string.
+// The return value is the address of a float32 variable that stores the value of the flag.
+//
+// Float32 定义一个具有指定名称、默认值和用法信息的 float32 标志。返回值是存储标志的值的 float32 变量的
+// 地址。
+func Float32(name string, value float32, usage string) *float32 {
+	return CommandLine.Float32(name, value, usage)
+}
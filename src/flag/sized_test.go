@@ -0,0 +1,83 @@
+// Copyright 2018 Li Zebang. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package flag_test
+
+import (
+	"bytes"
+	. "flag"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestSizedNumbers(t *testing.T) {
+	fs := NewFlagSet("sized", ContinueOnError)
+	fs.SetOutput(new(bytes.Buffer))
+	i8 := fs.Int8("i8", -1, "")
+	i16 := fs.Int16("i16", 0, "")
+	i32 := fs.Int32("i32", 0, "")
+	u8 := fs.Uint8("u8", 1, "")
+	u16 := fs.Uint16("u16", 0, "")
+	u32 := fs.Uint32("u32", 0, "")
+	f32 := fs.Float32("f32", 0, "")
+	tests := []struct {
+		name, value string
+		want        string
+		err         string
+	}{
+		{"i8", "-128", "-128", ""},
+		{"i8", "128", "-128", "out of range"},
+		{"i16", "0x7fff", "32767", ""},
+		{"i16", "-32769", "32767", "out of range"},
+		{"i32", "2147483647", "2147483647", ""},
+		{"i32", "2147483648", "2147483647", "out of range"},
+		{"u8", "255", "255", ""},
+		{"u8", "256", "255", "out of range"},
+		{"u8", "-1", "255", "invalid syntax"},
+		{"u16", "65535", "65535", ""},
+		{"u32", "4294967296", "0", "out of range"},
+		{"f32", "1.5", "1.5", ""},
+		{"f32", "1e39", "1.5", "out of range"},
+		{"f32", "x", "1.5", "invalid syntax"},
+	}
+	for _, tt := range tests {
+		err := fs.Set(tt.name, tt.value)
+		if tt.err == "" && err != nil || tt.err != "" && (err == nil || !strings.Contains(err.Error(), tt.err)) {
+			t.Errorf("Set(%s, %s): %v; want %q", tt.name, tt.value, err, tt.err)
+		}
+		if got := fs.Lookup(tt.name).Value.String(); got != tt.want {
+			t.Errorf("after Set(%s, %s): value = %s; want %s", tt.name, tt.value, got, tt.want)
+		}
+	}
+	got := fmt.Sprint(*i8, *i16, *i32, *u8, *u16, *u32, *f32)
+	if want := "-128 32767 2147483647 255 65535 0 1.5"; got != want {
+		t.Errorf("values = %s; want %s", got, want)
+	}
+	if v := fs.Lookup("u16").Value.(Getter).Get(); v != uint16(65535) {
+		t.Errorf("Get = %#v", v)
+	}
+}
+
+func TestSizedNumbersPrintDefaults(t *testing.T) {
+	fs := NewFlagSet("sized", ContinueOnError)
+	var buf bytes.Buffer
+	fs.SetOutput(&buf)
+	fs.Int8("ttl", 64, "hop `limit`")
+	fs.Uint16("port", 0, "port")
+	fs.Float32("ratio", 0.25, "ratio")
+	fs.PrintDefaults()
+	want := strings.Join([]string{
+		"  -port uint",
+		"    \tport",
+		"  -ratio float",
+		"    \tratio (default 0.25)",
+		"  -ttl limit",
+		"    \thop limit (default 64)",
+		"",
+	}, "\n")
+	if buf.String() != want {
+		t.Errorf("PrintDefaults:\n%s\nwant:\n%s", buf.String(), want)
+	}
+}
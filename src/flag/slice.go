@@ -0,0 +1,623 @@
+// Copyright 2018 Li Zebang. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package flag
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// splitSlice splits a value of a slice flag at sep.
+//
+// splitSlice 在 sep 处分开切片标志的一个值。
+func splitSlice(val, sep string) []string {
+	switch {
+	case val == "":
+		return nil
+	case sep == "":
+		return []string{val}
+	}
+	return strings.Split(val, sep)
+}
+
+// -- []string Value
+//
+// The first Set replaces the default value, and every later one appends to
+// it, so -tag a -tag b,c gives [a b c]. Each value is split at sep, unless
+// sep is empty, and an empty value adds nothing.
+//
+// 第一次 Set 会替换默认值，之后的每一次都追加到它的后面，所以 -tag a -tag b,c 得到的是
+// [a b c]。除非 sep 为空，每个值都会在 sep 处被分开，空的值不会追加任何元素。
+//
+// IMP: 零值的 p 为 nil，isZeroValue 会调用零值的 String 方法，所以 String 需要处理这种情况。
+type stringSliceValue struct {
+	p       *[]string
+	sep     string
+	changed bool
+}
+
+func newStringSliceValue(val []string, p *[]string, sep string) *stringSliceValue {
+	*p = append([]string(nil), val...)
+	return &stringSliceValue{p: p, sep: sep}
+}
+
+func (s *stringSliceValue) Set(val string) error {
+	v := splitSlice(val, s.sep)
+	if !s.changed {
+		*s.p = v
+		s.changed = true
+	} else {
+		*s.p = append(*s.p, v...)
+	}
+	return nil
+}
+
+func (s *stringSliceValue) Get() interface{} { return *s.p }
+
+func (s *stringSliceValue) reset() { s.changed = false }
+
+func (s *stringSliceValue) String() string {
+	if s.p == nil {
+		return ""
+	}
+	sep := s.sep
+	if sep == "" {
+		sep = ","
+	}
+	return strings.Join(*s.p, sep)
+}
+
+// StringSliceVar defines a string slice flag with specified name, default
+// value, and usage string. The argument p points to a []string variable in
+// which to store the values of the flag. The flag may be repeated, and each
+// of its values is split at commas: -tag a -tag b,c stores [a b c]. The
+// first value given replaces the default.
+//
+// StringSliceVar 定义一个具有指定名称、默认值和用法信息的字符串切片标志。参数 p 指向一个
+// 用来存储标志的值的 []string 变量。该标志可以重复出现，它的每个值都会在逗号处被分开：
+// -tag a -tag b,c 存储的是 [a b c]。给出的第一个值会替换默认值。
+func (f *FlagSet) StringSliceVar(p *[]string, name string, value []string, usage string) {
+	f.Var(newStringSliceValue(value, p, ","), name, usage)
+}
+
+// StringSliceVar defines a string slice flag with specified name, default
+// value, and usage string.
+//
+// StringSliceVar 定义一个具有指定名称、默认值和用法信息的字符串切片标志。
+func StringSliceVar(p *[]string, name string, value []string, usage string) {
+	CommandLine.Var(newStringSliceValue(value, p, ","), name, usage)
+}
+
+// StringSlice defines a string slice flag with specified name, default
+// value, and usage string. The return value is the address of a []string
+// variable that stores the values of the flag.
+//
+// StringSlice 定义一个具有指定名称、默认值和用法信息的字符串切片标志。返回值是存储标志的值
+// 的 []string 变量的地址。
+func (f *FlagSet) StringSlice(name string, value []string, usage string) *[]string {
+	p := new([]string)
+	f.StringSliceVar(p, name, value, usage)
+	return p
+}
+
+// StringSlice defines a string slice flag with specified name, default
+// value, and usage string.
+//
+// StringSlice 定义一个具有指定名称、默认值和用法信息的字符串切片标志。
+func StringSlice(name string, value []string, usage string) *[]string {
+	return CommandLine.StringSlice(name, value, usage)
+}
+
+// StringSliceSepVar is like StringSliceVar, but splits each value at sep
+// instead of at commas. With an empty sep, each value is a single element.
+//
+// StringSliceSepVar 类似于 StringSliceVar，但在 sep 而不是逗号处分开每个值。sep 为空时，
+// 每个值都是一个单独的元素。
+func (f *FlagSet) StringSliceSepVar(p *[]string, name string, value []string, sep, usage string) {
+	f.Var(newStringSliceValue(value, p, sep), name, usage)
+}
+
+// StringSliceSepVar is like StringSliceVar, but splits each value at sep.
+//
+// StringSliceSepVar 类似于 StringSliceVar，但在 sep 处分开每个值。
+func StringSliceSepVar(p *[]string, name string, value []string, sep, usage string) {
+	CommandLine.Var(newStringSliceValue(value, p, sep), name, usage)
+}
+
+// StringSliceVarP is like StringSliceVar, but accepts a shorthand for the flag.
+//
+// StringSliceVarP 类似于 StringSliceVar，但接受该标志的缩写。
+func (f *FlagSet) StringSliceVarP(p *[]string, name, shorthand string, value []string, usage string) {
+	f.VarP(newStringSliceValue(value, p, ","), name, shorthand, usage)
+}
+
+// StringSliceVarP is like StringSliceVar, but accepts a shorthand for the flag.
+//
+// StringSliceVarP 类似于 StringSliceVar，但接受该标志的缩写。
+func StringSliceVarP(p *[]string, name, shorthand string, value []string, usage string) {
+	CommandLine.VarP(newStringSliceValue(value, p, ","), name, shorthand, usage)
+}
+
+// StringSliceP is like StringSlice, but accepts a shorthand for the flag.
+//
+// StringSliceP 类似于 StringSlice，但接受该标志的缩写。
+func (f *FlagSet) StringSliceP(name, shorthand string, value []string, usage string) *[]string {
+	p := new([]string)
+	f.StringSliceVarP(p, name, shorthand, value, usage)
+	return p
+}
+
+// StringSliceP is like StringSlice, but accepts a shorthand for the flag.
+//
+// StringSliceP 类似于 StringSlice，但接受该标志的缩写。
+func StringSliceP(name, shorthand string, value []string, usage string) *[]string {
+	return CommandLine.StringSliceP(name, shorthand, value, usage)
+}
+
+// -- []int Value
+//
+// Like stringSliceValue, but every element must parse as an int. A value
+// with an invalid element leaves the slice unchanged.
+//
+// 类似于 stringSliceValue，但每个元素都必须能被解析为 int。含有无效元素的值不会改变切片。
+type intSliceValue struct {
+	p       *[]int
+	changed bool
+}
+
+func newIntSliceValue(val []int, p *[]int) *intSliceValue {
+	*p = append([]int(nil), val...)
+	return &intSliceValue{p: p}
+}
+
+func (s *intSliceValue) Set(val string) error {
+	elems := splitSlice(val, ",")
+	v := make([]int, 0, len(elems))
+	for _, e := range elems {
+		n, err := strconv.ParseInt(e, 0, strconv.IntSize)
+		if err != nil {
+			return err
+		}
+		v = append(v, int(n))
+	}
+	if !s.changed {
+		*s.p = v
+		s.changed = true
+	} else {
+		*s.p = append(*s.p, v...)
+	}
+	return nil
+}
+
+func (s *intSliceValue) Get() interface{} { return *s.p }
+
+func (s *intSliceValue) reset() { s.changed = false }
+
+func (s *intSliceValue) String() string {
+	if s.p == nil {
+		return ""
+	}
+	elems := make([]string, len(*s.p))
+	for i, n := range *s.p {
+		elems[i] = strconv.Itoa(n)
+	}
+	return strings.Join(elems, ",")
+}
+
+// -- []int64 Value
+type int64SliceValue struct {
+	p       *[]int64
+	changed bool
+}
+
+func newInt64SliceValue(val []int64, p *[]int64) *int64SliceValue {
+	*p = append([]int64(nil), val...)
+	return &int64SliceValue{p: p}
+}
+
+func (s *int64SliceValue) Set(val string) error {
+	elems := splitSlice(val, ",")
+	v := make([]int64, 0, len(elems))
+	for _, e := range elems {
+		n, err := strconv.ParseInt(e, 0, 64)
+		if err != nil {
+			return err
+		}
+		v = append(v, n)
+	}
+	if !s.changed {
+		*s.p = v
+		s.changed = true
+	} else {
+		*s.p = append(*s.p, v...)
+	}
+	return nil
+}
+
+func (s *int64SliceValue) Get() interface{} { return *s.p }
+
+func (s *int64SliceValue) reset() { s.changed = false }
+
+func (s *int64SliceValue) String() string {
+	if s.p == nil {
+		return ""
+	}
+	elems := make([]string, len(*s.p))
+	for i, n := range *s.p {
+		elems[i] = strconv.FormatInt(n, 10)
+	}
+	return strings.Join(elems, ",")
+}
+
+// -- []float64 Value
+type float64SliceValue struct {
+	p       *[]float64
+	changed bool
+}
+
+func newFloat64SliceValue(val []float64, p *[]float64) *float64SliceValue {
+	*p = append([]float64(nil), val...)
+	return &float64SliceValue{p: p}
+}
+
+func (s *float64SliceValue) Set(val string) error {
+	elems := splitSlice(val, ",")
+	v := make([]float64, 0, len(elems))
+	for _, e := range elems {
+		x, err := strconv.ParseFloat(e, 64)
+		if err != nil {
+			return err
+		}
+		v = append(v, x)
+	}
+	if !s.changed {
+		*s.p = v
+		s.changed = true
+	} else {
+		*s.p = append(*s.p, v...)
+	}
+	return nil
+}
+
+func (s *float64SliceValue) Get() interface{} { return *s.p }
+
+func (s *float64SliceValue) reset() { s.changed = false }
+
+func (s *float64SliceValue) String() string {
+	if s.p == nil {
+		return ""
+	}
+	elems := make([]string, len(*s.p))
+	for i, x := range *s.p {
+		elems[i] = strconv.FormatFloat(x, 'g', -1, 64)
+	}
+	return strings.Join(elems, ",")
+}
+
+// -- []time.Duration Value
+type durationSliceValue struct {
+	p       *[]time.Duration
+	changed bool
+}
+
+func newDurationSliceValue(val []time.Duration, p *[]time.Duration) *durationSliceValue {
+	*p = append([]time.Duration(nil), val...)
+	return &durationSliceValue{p: p}
+}
+
+func (s *durationSliceValue) Set(val string) error {
+	elems := splitSlice(val, ",")
+	v := make([]time.Duration, 0, len(elems))
+	for _, e := range elems {
+		d, err := time.ParseDuration(e)
+		if err != nil {
+			return err
+		}
+		v = append(v, d)
+	}
+	if !s.changed {
+		*s.p = v
+		s.changed = true
+	} else {
+		*s.p = append(*s.p, v...)
+	}
+	return nil
+}
+
+func (s *durationSliceValue) Get() interface{} { return *s.p }
+
+func (s *durationSliceValue) reset() { s.changed = false }
+
+func (s *durationSliceValue) String() string {
+	if s.p == nil {
+		return ""
+	}
+	elems := make([]string, len(*s.p))
+	for i, d := range *s.p {
+		elems[i] = d.String()
+	}
+	return strings.Join(elems, ",")
+}
+
+// IntSliceVar defines an int slice flag with specified name, default
+// value, and usage string. The argument p points to a []int variable in
+// which to store the values of the flag. Like StringSliceVar, the flag may
+// be repeated and each of its values is split at commas.
+//
+// IntSliceVar 定义一个具有指定名称、默认值和用法信息的 int 切片标志。参数 p 指向一个用来
+// 存储标志的值的 []int 变量。和 StringSliceVar 一样，该标志可以重复出现，它的每个值都会
+// 在逗号处被分开。
+func (f *FlagSet) IntSliceVar(p *[]int, name string, value []int, usage string) {
+	f.Var(newIntSliceValue(value, p), name, usage)
+}
+
+// IntSliceVar defines an int slice flag with specified name, default
+// value, and usage string.
+//
+// IntSliceVar 定义一个具有指定名称、默认值和用法信息的 int 切片标志。
+func IntSliceVar(p *[]int, name string, value []int, usage string) {
+	CommandLine.Var(newIntSliceValue(value, p), name, usage)
+}
+
+// IntSlice defines an int slice flag with specified name, default
+// value, and usage string. The return value is the address of a []int
+// variable that stores the values of the flag.
+//
+// IntSlice 定义一个具有指定名称、默认值和用法信息的 int 切片标志。返回值是存储标志的值的
+// []int 变量的地址。
+func (f *FlagSet) IntSlice(name string, value []int, usage string) *[]int {
+	p := new([]int)
+	f.IntSliceVar(p, name, value, usage)
+	return p
+}
+
+// IntSlice defines an int slice flag with specified name, default
+// value, and usage string.
+//
+// IntSlice 定义一个具有指定名称、默认值和用法信息的 int 切片标志。
+func IntSlice(name string, value []int, usage string) *[]int {
+	return CommandLine.IntSlice(name, value, usage)
+}
+
+// IntSliceVarP is like IntSliceVar, but accepts a shorthand for the flag.
+//
+// IntSliceVarP 类似于 IntSliceVar，但接受该标志的缩写。
+func (f *FlagSet) IntSliceVarP(p *[]int, name, shorthand string, value []int, usage string) {
+	f.VarP(newIntSliceValue(value, p), name, shorthand, usage)
+}
+
+// IntSliceVarP is like IntSliceVar, but accepts a shorthand for the flag.
+//
+// IntSliceVarP 类似于 IntSliceVar，但接受该标志的缩写。
+func IntSliceVarP(p *[]int, name, shorthand string, value []int, usage string) {
+	CommandLine.VarP(newIntSliceValue(value, p), name, shorthand, usage)
+}
+
+// IntSliceP is like IntSlice, but accepts a shorthand for the flag.
+//
+// IntSliceP 类似于 IntSlice，但接受该标志的缩写。
+func (f *FlagSet) IntSliceP(name, shorthand string, value []int, usage string) *[]int {
+	p := new([]int)
+	f.IntSliceVarP(p, name, shorthand, value, usage)
+	return p
+}
+
+// IntSliceP is like IntSlice, but accepts a shorthand for the flag.
+//
+// IntSliceP 类似于 IntSlice，但接受该标志的缩写。
+func IntSliceP(name, shorthand string, value []int, usage string) *[]int {
+	return CommandLine.IntSliceP(name, shorthand, value, usage)
+}
+
+// Int64SliceVar defines an int64 slice flag with specified name, default
+// value, and usage string. The argument p points to a []int64 variable in
+// which to store the values of the flag. Like StringSliceVar, the flag may
+// be repeated and each of its values is split at commas.
+//
+// Int64SliceVar 定义一个具有指定名称、默认值和用法信息的 int64 切片标志。参数 p 指向一个用来
+// 存储标志的值的 []int64 变量。和 StringSliceVar 一样，该标志可以重复出现，它的每个值都会
+// 在逗号处被分开。
+func (f *FlagSet) Int64SliceVar(p *[]int64, name string, value []int64, usage string) {
+	f.Var(newInt64SliceValue(value, p), name, usage)
+}
+
+// Int64SliceVar defines an int64 slice flag with specified name, default
+// value, and usage string.
+//
+// Int64SliceVar 定义一个具有指定名称、默认值和用法信息的 int64 切片标志。
+func Int64SliceVar(p *[]int64, name string, value []int64, usage string) {
+	CommandLine.Var(newInt64SliceValue(value, p), name, usage)
+}
+
+// Int64Slice defines an int64 slice flag with specified name, default
+// value, and usage string. The return value is the address of a []int64
+// variable that stores the values of the flag.
+//
+// Int64Slice 定义一个具有指定名称、默认值和用法信息的 int64 切片标志。返回值是存储标志的值的
+// []int64 变量的地址。
+func (f *FlagSet) Int64Slice(name string, value []int64, usage string) *[]int64 {
+	p := new([]int64)
+	f.Int64SliceVar(p, name, value, usage)
+	return p
+}
+
+// Int64Slice defines an int64 slice flag with specified name, default
+// value, and usage string.
+//
+// Int64Slice 定义一个具有指定名称、默认值和用法信息的 int64 切片标志。
+func Int64Slice(name string, value []int64, usage string) *[]int64 {
+	return CommandLine.Int64Slice(name, value, usage)
+}
+
+// Int64SliceVarP is like Int64SliceVar, but accepts a shorthand for the flag.
+//
+// Int64SliceVarP 类似于 Int64SliceVar，但接受该标志的缩写。
+func (f *FlagSet) Int64SliceVarP(p *[]int64, name, shorthand string, value []int64, usage string) {
+	f.VarP(newInt64SliceValue(value, p), name, shorthand, usage)
+}
+
+// Int64SliceVarP is like Int64SliceVar, but accepts a shorthand for the flag.
+//
+// Int64SliceVarP 类似于 Int64SliceVar，但接受该标志的缩写。
+func Int64SliceVarP(p *[]int64, name, shorthand string, value []int64, usage string) {
+	CommandLine.VarP(newInt64SliceValue(value, p), name, shorthand, usage)
+}
+
+// Int64SliceP is like Int64Slice, but accepts a shorthand for the flag.
+//
+// Int64SliceP 类似于 Int64Slice，但接受该标志的缩写。
+func (f *FlagSet) Int64SliceP(name, shorthand string, value []int64, usage string) *[]int64 {
+	p := new([]int64)
+	f.Int64SliceVarP(p, name, shorthand, value, usage)
+	return p
+}
+
+// Int64SliceP is like Int64Slice, but accepts a shorthand for the flag.
+//
+// Int64SliceP 类似于 Int64Slice，但接受该标志的缩写。
+func Int64SliceP(name, shorthand string, value []int64, usage string) *[]int64 {
+	return CommandLine.Int64SliceP(name, shorthand, value, usage)
+}
+
+// Float64SliceVar defines a float64 slice flag with specified name, default
+// value, and usage string. The argument p points to a []float64 variable in
+// which to store the values of the flag. Like StringSliceVar, the flag may
+// be repeated and each of its values is split at commas.
+//
+// Float64SliceVar 定义一个具有指定名称、默认值和用法信息的 float64 切片标志。参数 p 指向一个用来
+// 存储标志的值的 []float64 变量。和 StringSliceVar 一样，该标志可以重复出现，它的每个值都会
+// 在逗号处被分开。
+func (f *FlagSet) Float64SliceVar(p *[]float64, name string, value []float64, usage string) {
+	f.Var(newFloat64SliceValue(value, p), name, usage)
+}
+
+// Float64SliceVar defines a float64 slice flag with specified name, default
+// value, and usage string.
+//
+// Float64SliceVar 定义一个具有指定名称、默认值和用法信息的 float64 切片标志。
+func Float64SliceVar(p *[]float64, name string, value []float64, usage string) {
+	CommandLine.Var(newFloat64SliceValue(value, p), name, usage)
+}
+
+// Float64Slice defines a float64 slice flag with specified name, default
+// value, and usage string. The return value is the address of a []float64
+// variable that stores the values of the flag.
+//
+// Float64Slice 定义一个具有指定名称、默认值和用法信息的 float64 切片标志。返回值是存储标志的值的
+// []float64 变量的地址。
+func (f *FlagSet) Float64Slice(name string, value []float64, usage string) *[]float64 {
+	p := new([]float64)
+	f.Float64SliceVar(p, name, value, usage)
+	return p
+}
+
+// Float64Slice defines a float64 slice flag with specified name, default
+// value, and usage string.
+//
+// Float64Slice 定义一个具有指定名称、默认值和用法信息的 float64 切片标志。
+func Float64Slice(name string, value []float64, usage string) *[]float64 {
+	return CommandLine.Float64Slice(name, value, usage)
+}
+
+// Float64SliceVarP is like Float64SliceVar, but accepts a shorthand for the flag.
+//
+// Float64SliceVarP 类似于 Float64SliceVar，但接受该标志的缩写。
+func (f *FlagSet) Float64SliceVarP(p *[]float64, name, shorthand string, value []float64, usage string) {
+	f.VarP(newFloat64SliceValue(value, p), name, shorthand, usage)
+}
+
+// Float64SliceVarP is like Float64SliceVar, but accepts a shorthand for the flag.
+//
+// Float64SliceVarP 类似于 Float64SliceVar，但接受该标志的缩写。
+func Float64SliceVarP(p *[]float64, name, shorthand string, value []float64, usage string) {
+	CommandLine.VarP(newFloat64SliceValue(value, p), name, shorthand, usage)
+}
+
+// Float64SliceP is like Float64Slice, but accepts a shorthand for the flag.
+//
+// Float64SliceP 类似于 Float64Slice，但接受该标志的缩写。
+func (f *FlagSet) Float64SliceP(name, shorthand string, value []float64, usage string) *[]float64 {
+	p := new([]float64)
+	f.Float64SliceVarP(p, name, shorthand, value, usage)
+	return p
+}
+
+// Float64SliceP is like Float64Slice, but accepts a shorthand for the flag.
+//
+// Float64SliceP 类似于 Float64Slice，但接受该标志的缩写。
+func Float64SliceP(name, shorthand string, value []float64, usage string) *[]float64 {
+	return CommandLine.Float64SliceP(name, shorthand, value, usage)
+}
+
+// DurationSliceVar defines a duration slice flag with specified name, default
+// value, and usage string. The argument p points to a []time.Duration variable in
+// which to store the values of the flag. Like StringSliceVar, the flag may
+// be repeated and each of its values is split at commas.
+//
+// DurationSliceVar 定义一个具有指定名称、默认值和用法信息的 time.Duration 切片标志。参数 p 指向一个用来
+// 存储标志的值的 []time.Duration 变量。和 StringSliceVar 一样，该标志可以重复出现，它的每个值都会
+// 在逗号处被分开。
+func (f *FlagSet) DurationSliceVar(p *[]time.Duration, name string, value []time.Duration, usage string) {
+	f.Var(newDurationSliceValue(value, p), name, usage)
+}
+
+// DurationSliceVar defines a duration slice flag with specified name, default
+// value, and usage string.
+//
+// DurationSliceVar 定义一个具有指定名称、默认值和用法信息的 time.Duration 切片标志。
+func DurationSliceVar(p *[]time.Duration, name string, value []time.Duration, usage string) {
+	CommandLine.Var(newDurationSliceValue(value, p), name, usage)
+}
+
+// DurationSlice defines a duration slice flag with specified name, default
+// value, and usage string. The return value is the address of a []time.Duration
+// variable that stores the values of the flag.
+//
+// DurationSlice 定义一个具有指定名称、默认值和用法信息的 time.Duration 切片标志。返回值是存储标志的值的
+// []time.Duration 变量的地址。
+func (f *FlagSet) DurationSlice(name string, value []time.Duration, usage string) *[]time.Duration {
+	p := new([]time.Duration)
+	f.DurationSliceVar(p, name, value, usage)
+	return p
+}
+
+// DurationSlice defines a duration slice flag with specified name, default
+// value, and usage string.
+//
+// DurationSlice 定义一个具有指定名称、默认值和用法信息的 time.Duration 切片标志。
+func DurationSlice(name string, value []time.Duration, usage string) *[]time.Duration {
+	return CommandLine.DurationSlice(name, value, usage)
+}
+
+// DurationSliceVarP is like DurationSliceVar, but accepts a shorthand for the flag.
+//
+// DurationSliceVarP 类似于 DurationSliceVar，但接受该标志的缩写。
+func (f *FlagSet) DurationSliceVarP(p *[]time.Duration, name, shorthand string, value []time.Duration, usage string) {
+	f.VarP(newDurationSliceValue(value, p), name, shorthand, usage)
+}
+
+// DurationSliceVarP is like DurationSliceVar, but accepts a shorthand for the flag.
+//
+// DurationSliceVarP 类似于 DurationSliceVar，但接受该标志的缩写。
+func DurationSliceVarP(p *[]time.Duration, name, shorthand string, value []time.Duration, usage string) {
+	CommandLine.VarP(newDurationSliceValue(value, p), name, shorthand, usage)
+}
+
+// DurationSliceP is like DurationSlice, but accepts a shorthand for the flag.
+//
+// DurationSliceP 类似于 DurationSlice，但接受该标志的缩写。
+func (f *FlagSet) DurationSliceP(name, shorthand string, value []time.Duration, usage string) *[]time.Duration {
+	p := new([]time.Duration)
+	f.DurationSliceVarP(p, name, shorthand, value, usage)
+	return p
+}
+
+// DurationSliceP is like DurationSlice, but accepts a shorthand for the flag.
+//
+// DurationSliceP 类似于 DurationSlice，但接受该标志的缩写。
+func DurationSliceP(name, shorthand string, value []time.Duration, usage string) *[]time.Duration {
+	return CommandLine.DurationSliceP(name, shorthand, value, usage)
+}
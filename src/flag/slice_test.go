@@ -0,0 +1,172 @@
+// Copyright 2018 Li Zebang. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package flag_test
+
+import (
+	"bytes"
+	. "flag"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestStringSlice(t *testing.T) {
+	tests := []struct {
+		args []string
+		want string
+	}{
+		{nil, `["x" "y"]`},
+		{[]string{"-tag", "a"}, `["a"]`},
+		{[]string{"-tag", "a", "-tag", "b"}, `["a" "b"]`},
+		{[]string{"-tag", "a,b", "-tag=c"}, `["a" "b" "c"]`},
+		{[]string{"-tag="}, `[]`},
+		{[]string{"-t", "a", "--tag", "b,,c"}, `["a" "b" "" "c"]`},
+	}
+	for _, tt := range tests {
+		fs := NewFlagSet("slice", ContinueOnError)
+		tags := fs.StringSliceP("tag", "t", []string{"x", "y"}, "tags")
+		if err := fs.Parse(tt.args); err != nil {
+			t.Fatal(err)
+		}
+		if got := fmt.Sprintf("%q", *tags); got != tt.want {
+			t.Errorf("Parse(%q): tag = %s; want %s", tt.args, got, tt.want)
+		}
+	}
+}
+
+func TestStringSliceSep(t *testing.T) {
+	fs := NewFlagSet("slice", ContinueOnError)
+	var paths, words []string
+	fs.StringSliceSepVar(&paths, "path", nil, ":", "search path")
+	fs.StringSliceSepVar(&words, "word", nil, "", "words")
+	if err := fs.Parse([]string{"-path", "/bin:/usr/bin", "-word", "a,b", "-word", "c:d"}); err != nil {
+		t.Fatal(err)
+	}
+	if got := fmt.Sprintf("%q %q", paths, words); got != `["/bin" "/usr/bin"] ["a,b" "c:d"]` {
+		t.Errorf("path, word = %s", got)
+	}
+	if got := fs.Lookup("path").Value.String(); got != "/bin:/usr/bin" {
+		t.Errorf("path String() = %q", got)
+	}
+	if got := fs.Lookup("word").Value.(Getter).Get(); fmt.Sprint(got) != "[a,b c:d]" {
+		t.Errorf("word Get() = %v", got)
+	}
+}
+
+func TestStringSliceDefaultNotAliased(t *testing.T) {
+	def := []string{"a", "b"}
+	fs := NewFlagSet("slice", ContinueOnError)
+	tags := fs.StringSlice("tag", def, "tags")
+	(*tags)[0] = "changed"
+	if def[0] != "a" {
+		t.Errorf("changing the flag changed its default: %q", def)
+	}
+}
+
+func TestStringSlicePrintDefaults(t *testing.T) {
+	fs := NewFlagSet("slice", ContinueOnError)
+	var buf bytes.Buffer
+	fs.SetOutput(&buf)
+	fs.StringSlice("tag", []string{"a", "b"}, "`tags` to add")
+	fs.StringSlice("none", nil, "no default")
+	fs.PrintDefaults()
+	want := strings.Join([]string{
+		"  -none strings",
+		"    \tno default",
+		"  -tag tags",
+		"    \ttags to add (default a,b)",
+		"",
+	}, "\n")
+	if buf.String() != want {
+		t.Errorf("PrintDefaults:\n%q\nwant:\n%q", buf.String(), want)
+	}
+}
+
+func TestNumericSlices(t *testing.T) {
+	fs := NewFlagSet("slice", ContinueOnError)
+	ints := fs.IntSliceP("int", "i", []int{1, 2}, "ints")
+	int64s := fs.Int64Slice("int64", nil, "int64s")
+	floats := fs.Float64Slice("float", []float64{0.5}, "floats")
+	args := []string{"-i", "3", "--int", "0x10,-4", "-int64", "9000000000", "-float", "1e3,2.25"}
+	if err := fs.Parse(args); err != nil {
+		t.Fatal(err)
+	}
+	if got := fmt.Sprint(*ints, *int64s, *floats); got != "[3 16 -4] [9000000000] [1000 2.25]" {
+		t.Errorf("values = %s", got)
+	}
+	if got := fs.Lookup("float").Value.String(); got != "1000,2.25" {
+		t.Errorf("float String() = %q", got)
+	}
+	if got, ok := fs.Lookup("int64").Value.(Getter).Get().([]int64); !ok || len(got) != 1 {
+		t.Errorf("int64 Get() = %#v", got)
+	}
+}
+
+func TestNumericSliceInvalid(t *testing.T) {
+	fs := NewFlagSet("slice", ContinueOnError)
+	fs.SetOutput(new(bytes.Buffer))
+	ints := fs.IntSlice("int", []int{1}, "ints")
+	err := fs.Parse([]string{"-int", "2", "-int", "3,x"})
+	if err == nil || !strings.Contains(err.Error(), `invalid value "3,x" for flag -int`) {
+		t.Errorf("Parse error = %v", err)
+	}
+	if fmt.Sprint(*ints) != "[2]" {
+		t.Errorf("int = %v; want [2]", *ints)
+	}
+}
+
+func TestNumericSlicePrintDefaults(t *testing.T) {
+	fs := NewFlagSet("slice", ContinueOnError)
+	var buf bytes.Buffer
+	fs.SetOutput(&buf)
+	fs.IntSlice("ports", []int{80, 443}, "ports to listen on")
+	fs.Int64Slice("ids", nil, "ids")
+	fs.Float64Slice("ratios", []float64{0.5, 1}, "ratios")
+	fs.PrintDefaults()
+	want := strings.Join([]string{
+		"  -ids ints",
+		"    \tids",
+		"  -ports ints",
+		"    \tports to listen on (default 80,443)",
+		"  -ratios floats",
+		"    \tratios (default 0.5,1)",
+		"",
+	}, "\n")
+	if buf.String() != want {
+		t.Errorf("PrintDefaults:\n%q\nwant:\n%q", buf.String(), want)
+	}
+}
+
+func TestDurationSlice(t *testing.T) {
+	fs := NewFlagSet("slice", ContinueOnError)
+	var buf bytes.Buffer
+	fs.SetOutput(&buf)
+	retry := fs.DurationSliceP("retry", "r", []time.Duration{time.Second}, "retry `schedule`")
+	fs.DurationSlice("timeouts", nil, "timeouts")
+	fs.PrintDefaults()
+	want := strings.Join([]string{
+		"  -r, --retry schedule",
+		"    \tretry schedule (default 1s)",
+		"  -timeouts durations",
+		"    \ttimeouts",
+		"",
+	}, "\n")
+	if buf.String() != want {
+		t.Errorf("PrintDefaults:\n%q\nwant:\n%q", buf.String(), want)
+	}
+	if err := fs.Parse([]string{"-r", "1s,2s", "--retry=1m30s"}); err != nil {
+		t.Fatal(err)
+	}
+	if got := fs.Lookup("retry").Value.String(); got != "1s,2s,1m30s" {
+		t.Errorf("retry = %s", got)
+	}
+	if err := fs.Parse([]string{"-r", "5s,soon"}); err == nil {
+		t.Error("Parse(-r 5s,soon) succeeded")
+	}
+	if len(*retry) != 3 {
+		t.Errorf("retry after invalid value = %v", *retry)
+	}
+}
@@ -0,0 +1,244 @@
+// Copyright 2018 Li Zebang. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package flag
+
+import (
+	"fmt"
+	"sort"
+)
+
+// A ValueSource supplies values for flags that are not set on the command
+// line, such as a config file or a remote key-value store. Lookup returns
+// the value of the flag named name, and whether the source has one. The
+// value is passed to the flag's Set method.
+//
+// A ValueSource that implements fmt.Stringer is named by its String method
+// in errors and diagnostics.
+//
+// ValueSource 为没有在命令行中设置的标志提供值，例如配置文件或者远程的键值存储。Lookup
+// 返回名为 name 的标志的值，以及这个来源中是否有它的值。这个值会被传给标志的 Set 方法。
+//
+// 实现了 fmt.Stringer 的 ValueSource 在错误信息和诊断信息中用它的 String 方法来命名。
+type ValueSource interface {
+	Lookup(name string) (value string, ok bool)
+}
+
+// MapSource is a ValueSource that holds its values in a map keyed by flag
+// name.
+//
+// MapSource 是一个将值保存在以标志名称为键的映射中的 ValueSource。
+type MapSource map[string]string
+
+// Lookup returns m[name].
+//
+// Lookup 返回 m[name]。
+func (m MapSource) Lookup(name string) (string, bool) {
+	value, ok := m[name]
+	return value, ok
+}
+
+// A KeyedSource is a ValueSource that can say where it reads the value of
+// a flag from, so that PrintDefaults can document it. SourceKey returns a
+// short description such as "config server.port" for the flag named name,
+// or "" if the source has no key for it.
+//
+// KeyedSource 是一个能够说明它从哪里读取标志的值的 ValueSource，这样 PrintDefaults 就可以
+// 在用法信息中记录它。SourceKey 为名为 name 的标志返回一段简短的描述，例如
+// "config server.port"，如果这个来源中没有它的键，则返回 ""。
+type KeyedSource interface {
+	ValueSource
+	SourceKey(name string) string
+}
+
+// keyMapSource is the KeyedSource returned by MapKeys.
+//
+// keyMapSource 是 MapKeys 返回的 KeyedSource。
+type keyMapSource struct {
+	src  ValueSource
+	kind string
+	keys map[string]string
+}
+
+// MapKeys returns a KeyedSource that reads the flag named name from src
+// under the key keys[name], and ignores the flags that keys does not map.
+// The usage message of such a flag notes "(kind key)", so with
+//
+//	MapKeys(src, "config", map[string]string{"port": "server.port"})
+//
+// the usage message of -port ends in "(config server.port)".
+//
+// MapKeys 返回一个 KeyedSource，它以 keys[name] 为键从 src 中读取名为 name 的标志，并忽略
+// keys 没有映射的标志。这样的标志的用法信息中会注明 "(kind key)"，例如
+// MapKeys(src, "config", map[string]string{"port": "server.port"}) 会注明
+// "(config server.port)"。
+func MapKeys(src ValueSource, kind string, keys map[string]string) KeyedSource {
+	return &keyMapSource{src: src, kind: kind, keys: keys}
+}
+
+func (s *keyMapSource) Lookup(name string) (string, bool) {
+	key, ok := s.keys[name]
+	if !ok {
+		return "", false
+	}
+	return s.src.Lookup(key)
+}
+
+func (s *keyMapSource) SourceKey(name string) string {
+	key, ok := s.keys[name]
+	if !ok {
+		return ""
+	}
+	return s.kind + " " + key
+}
+
+func (s *keyMapSource) String() string { return sourceName(s.src) }
+
+// valueSource is a ValueSource added to a FlagSet.
+//
+// valueSource 是一个被加入 FlagSet 的 ValueSource。
+type valueSource struct {
+	src      ValueSource
+	priority int
+}
+
+// AddSource adds src to the sources that Parse reads the flags that are
+// not set on the command line from. Each such flag takes its value from
+// the source with the highest priority that has one, so the order of
+// precedence is:
+//
+//	the command line
+//	sources with a priority above 0
+//	the environment variables of BindEnv and SetEnvPrefix, at priority 0
+//	sources with a priority of 0 or less
+//	the default value
+//
+// Sources with the same priority are consulted in the order they were
+// added.
+//
+// AddSource 将 src 加入 Parse 读取那些没有在命令行中设置的标志的来源中。每个这样的标志从
+// 含有它的值的、优先级最高的来源中获取值，所以优先顺序如上所示：命令行、优先级大于 0 的
+// 来源、BindEnv 和 SetEnvPrefix 的环境变量（优先级为 0）、优先级小于或等于 0 的来源，
+// 最后是默认值。
+//
+// 优先级相同的来源按照它们被加入的顺序查询。
+func (f *FlagSet) AddSource(src ValueSource, priority int) {
+	i := sort.Search(len(f.sources), func(i int) bool { return f.sources[i].priority < priority })
+	f.sources = append(f.sources, valueSource{})
+	copy(f.sources[i+1:], f.sources[i:])
+	f.sources[i] = valueSource{src: src, priority: priority}
+}
+
+// AddSource adds src to the value sources of the command-line flags.
+//
+// AddSource 将 src 加入命令行标志的值来源中。
+func AddSource(src ValueSource, priority int) {
+	CommandLine.AddSource(src, priority)
+}
+
+// sourceName returns the name of src for errors and traces.
+//
+// sourceName 返回在错误信息和跟踪信息中使用的 src 的名字。
+func sourceName(src ValueSource) string {
+	if s, ok := src.(fmt.Stringer); ok {
+		return s.String()
+	}
+	return fmt.Sprintf("%T", src)
+}
+
+// lookupSources returns the value of flag from the environment or the
+// source with the highest priority that has one, as lookupEnv does.
+//
+// lookupSources 像 lookupEnv 一样，从环境变量或者含有 flag 的值的、优先级最高的来源中
+// 返回它的值。
+func (f *FlagSet) lookupSources(flag *Flag) (value, desc, label string, ok bool) {
+	env := true
+	for _, s := range f.sources {
+		if env && s.priority <= 0 {
+			env = false
+			if value, desc, label, ok = f.lookupEnv(flag); ok {
+				return value, desc, label, true
+			}
+		}
+		if value, ok = s.src.Lookup(flag.Name); ok {
+			name := sourceName(s.src)
+			return value, "value source " + name, name, true
+		}
+	}
+	if env {
+		return f.lookupEnv(flag)
+	}
+	return "", "", "", false
+}
+
+// bindings returns the notes PrintDefaults adds for the environment
+// variable and the keyed sources that flag is read from, such as
+// "(env $PORT)", in order of precedence.
+//
+// bindings 按照优先顺序返回 PrintDefaults 为 flag 读取的环境变量和 KeyedSource 添加的注释，
+// 例如 "(env $PORT)"。
+func (f *FlagSet) bindings(flag *Flag) []string {
+	var notes []string
+	env := func() {
+		if key := f.envKey(flag); key != "" {
+			notes = append(notes, "(env $"+key+")")
+		}
+	}
+	done := false
+	for _, s := range f.sources {
+		if !done && s.priority <= 0 {
+			env()
+			done = true
+		}
+		if k, ok := s.src.(KeyedSource); ok {
+			if key := k.SourceKey(flag.Name); key != "" {
+				notes = append(notes, "("+key+")")
+			}
+		}
+	}
+	if !done {
+		env()
+	}
+	return notes
+}
+
+// parseSources sets the flags that were not set on the command line from
+// the environment and the value sources. In CollectAllErrors and
+// WarnOnError modes it goes on past invalid values and returns them all as
+// a ParseErrors.
+//
+// parseSources 使用环境变量和值来源设置在命令行中没有被设置的标志。在 CollectAllErrors
+// 和 WarnOnError 模式下它会越过无效的值继续设置，并将它们全部作为 ParseErrors 返回。
+func (f *FlagSet) parseSources() error {
+	var errs ParseErrors
+	// 排序使错误信息在多个值都无效时保持确定
+	for _, flag := range f.sortedFormal() { // sorting keeps the error deterministic
+		if f.actual[flag.Name] != nil {
+			continue
+		}
+		value, desc, label, ok := f.lookupSources(flag)
+		if !ok {
+			continue
+		}
+		if err := flag.Value.Set(value); err != nil {
+			err = f.fail(&ErrInvalidValue{Name: flag.Name, Value: value, Source: desc, Err: err,
+				msg: fmt.Sprintf(f.messages.get("invalid value %q for %s of flag -%s: %v"), value, desc, flag.Name, err)})
+			if !f.collecting() {
+				return err
+			}
+			errs = append(errs, err)
+			continue
+		}
+		if f.actual == nil {
+			f.actual = make(map[string]*Flag, len(f.formal))
+		}
+		f.actual[flag.Name] = flag
+		f.trace(flag, label)
+		f.report(flag, value)
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
@@ -0,0 +1,110 @@
+// Copyright 2018 Li Zebang. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package flag_test
+
+import (
+	. "flag"
+	"io/ioutil"
+	"strings"
+	"testing"
+)
+
+// namedSource is a MapSource with a name.
+type namedSource struct {
+	MapSource
+	name string
+}
+
+func (s namedSource) String() string { return s.name }
+
+func TestAddSource(t *testing.T) {
+	defer setenv(t, "SRC_A", "env")()
+	defer setenv(t, "SRC_B", "env")()
+	defer setenv(t, "SRC_C", "env")()
+
+	fs := NewFlagSet("source", ContinueOnError)
+	a := fs.String("a", "default", "")
+	b := fs.String("b", "default", "")
+	c := fs.String("c", "default", "")
+	d := fs.String("d", "default", "")
+	e := fs.String("e", "default", "")
+	cli := fs.String("cli", "default", "")
+	fs.SetEnvPrefix("SRC")
+
+	fs.AddSource(MapSource{"b": "low", "d": "low", "e": "low"}, -1)
+	fs.AddSource(MapSource{"a": "high", "cli": "high"}, 10)
+	fs.AddSource(MapSource{"c": "zero", "d": "zero"}, 0)
+	fs.AddSource(MapSource{"a": "high-later"}, 10)
+	if err := fs.Parse([]string{"-cli", "flag"}); err != nil {
+		t.Fatal(err)
+	}
+	for _, tt := range []struct {
+		name string
+		got  string
+		want string
+	}{
+		{"a", *a, "high"}, // the first of two sources with the same priority
+		{"b", *b, "env"},  // the environment outranks priority -1
+		{"c", *c, "env"},  // and priority 0
+		{"d", *d, "zero"}, // priority 0 outranks -1
+		{"e", *e, "low"},  // any source outranks the default
+		{"cli", *cli, "flag"},
+	} {
+		if tt.got != tt.want {
+			t.Errorf("-%s = %q; want %q", tt.name, tt.got, tt.want)
+		}
+	}
+	if fs.NFlag() != 6 {
+		t.Errorf("NFlag() = %d; want 6", fs.NFlag())
+	}
+}
+
+func TestAddSourceError(t *testing.T) {
+	fs := NewFlagSet("source", ContinueOnError)
+	fs.SetOutput(ioutil.Discard)
+	fs.Int("n", 0, "")
+	fs.AddSource(namedSource{MapSource{"n": "x"}, "consul"}, 1)
+	err := fs.Parse(nil)
+	const want = `invalid value "x" for value source consul of flag -n`
+	if err == nil || !strings.HasPrefix(err.Error(), want) {
+		t.Errorf("Parse error = %v; want prefix %q", err, want)
+	}
+
+	fs = NewFlagSet("source", ContinueOnError)
+	fs.SetOutput(ioutil.Discard)
+	fs.Int("n", 0, "")
+	fs.AddSource(MapSource{"n": "x"}, 1)
+	err = fs.Parse(nil)
+	const wantType = `invalid value "x" for value source flag.MapSource of flag -n`
+	if err == nil || !strings.HasPrefix(err.Error(), wantType) {
+		t.Errorf("Parse error = %v; want prefix %q", err, wantType)
+	}
+}
+
+func TestMapKeys(t *testing.T) {
+	defer setenv(t, "APP_PORT", "")()
+	fs := NewFlagSet("keys", ContinueOnError)
+	port := fs.Int("port", 80, "listen `port`")
+	host := fs.String("host", "", "host name")
+	fs.SetEnvPrefix("APP")
+	config := MapKeys(MapSource{"server.port": "8080", "host": "ignored"}, "config",
+		map[string]string{"port": "server.port"})
+	fs.AddSource(config, -1)
+	fs.AddSource(MapKeys(MapSource{}, "flagd", map[string]string{"port": "/app/port"}), 1)
+	if err := fs.Parse(nil); err != nil {
+		t.Fatal(err)
+	}
+	if *port != 8080 || *host != "" {
+		t.Errorf("port, host = %d, %q", *port, *host)
+	}
+	var buf strings.Builder
+	fs.SetOutput(&buf)
+	fs.PrintDefaults()
+	want := "  -host string\n    \thost name (env $APP_HOST)\n" +
+		"  -port port\n    \tlisten port (default 80) (flagd /app/port) (env $APP_PORT) (config server.port)\n"
+	if buf.String() != want {
+		t.Errorf("PrintDefaults:\n%s\nwant:\n%s", buf.String(), want)
+	}
+}
@@ -0,0 +1,121 @@
+// Copyright 2018 Li Zebang. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package flag
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// A UsageTemplate prints a usage message from a UsageData. A parsed
+// text/template.Template is one; package flag/usagetemplate parses
+// templates that may also call helper functions.
+//
+// UsageTemplate 根据一个 UsageData 打印用法信息。解析好的 text/template.Template 就是一个
+// UsageTemplate；flag/usagetemplate 包解析的模板还可以调用一些辅助函数。
+//
+// IMP: testing 导入了 flag，所以 flag 不能依赖 text/template，只依赖它的 Execute 方法。
+type UsageTemplate interface {
+	Execute(w io.Writer, data interface{}) error
+}
+
+// UsageData is the data a usage template set by SetUsageTemplate is
+// executed with.
+//
+// UsageData 是执行 SetUsageTemplate 设置的用法模板时使用的数据。
+type UsageData struct {
+	// Name 是标志集的名称
+	Name string // name of the flag set
+	// Flags 是 PrintDefaults 列出的标志，顺序与它相同
+	Flags []FlagInfo // flags PrintDefaults lists, in its order
+
+	f *FlagSet
+}
+
+// Defaults returns the text PrintDefaults prints.
+//
+// Defaults 返回 PrintDefaults 打印的文本。
+func (d UsageData) Defaults() string {
+	var buf bytes.Buffer
+	out := d.f.output
+	d.f.output = &buf
+	d.f.PrintDefaults()
+	d.f.output = out
+	return buf.String()
+}
+
+// Names returns the forms of the named flag, such as "-p, --port".
+//
+// Names 返回名为 name 的标志的各种形式，例如 "-p, --port"。
+func (d UsageData) Names(name string) string {
+	if flag := d.f.lookupName(name); flag != nil {
+		return strings.Join(usageNames(flag), ", ")
+	}
+	return ""
+}
+
+// Arg returns the name of the argument of the named flag, such as "int".
+//
+// Arg 返回名为 name 的标志的参数名称，例如 "int"。
+func (d UsageData) Arg(name string) string {
+	if flag := d.f.lookupName(name); flag != nil {
+		arg, _ := UnquoteUsage(flag)
+		return arg
+	}
+	return ""
+}
+
+// SetUsageTemplate sets a template that replaces the default usage
+// message, so programs can restyle it without writing a Usage function.
+// The template is executed with a UsageData, whose methods give the text
+// of PrintDefaults and the names and argument of a flag:
+//
+//	t := template.Must(template.New("usage").Parse(
+//		"usage: {{.Name}} [flags]\n{{range .Flags}}{{$.Names .Name}}\t{{.Usage}}\n{{end}}"))
+//	fs.SetUsageTemplate(t)
+//
+// An error executing the template is printed after the message. A nil t
+// restores the default usage message. The template is not used if f.Usage
+// is set.
+//
+// SetUsageTemplate 设置一个替代默认用法信息的模板，这样程序不需要编写 Usage 函数就可以改变
+// 它的样式。模板以 UsageData 执行，它的方法给出 PrintDefaults 的文本以及标志的名称和参数：
+//
+//	t := template.Must(template.New("usage").Parse(
+//		"usage: {{.Name}} [flags]\n{{range .Flags}}{{$.Names .Name}}\t{{.Usage}}\n{{end}}"))
+//	fs.SetUsageTemplate(t)
+//
+// 执行模板时出现的错误会在信息之后打印。t 为 nil 时恢复默认的用法信息。如果设置了 f.Usage，
+// 则不会使用该模板。
+func (f *FlagSet) SetUsageTemplate(t UsageTemplate) {
+	f.usageTemplate = t
+}
+
+// SetUsageTemplate sets a template that replaces the default usage
+// message of the command-line flags. It is used by Usage, unless Usage has
+// been changed.
+//
+// SetUsageTemplate 设置一个替代命令行标志默认用法信息的模板。除非 Usage 被改变了，否则
+// Usage 会使用它。
+func SetUsageTemplate(t UsageTemplate) {
+	CommandLine.SetUsageTemplate(t)
+}
+
+// executeUsageTemplate prints the usage message of the template set by
+// SetUsageTemplate. An error executing it is printed too.
+//
+// executeUsageTemplate 打印 SetUsageTemplate 设置的模板生成的用法信息。执行模板时出现的
+// 错误也会被打印。
+func (f *FlagSet) executeUsageTemplate() {
+	data := UsageData{Name: f.name, f: f}
+	f.visitGroups(func(string) {}, func(flag *Flag) {
+		data.Flags = append(data.Flags, f.describe(flag))
+	})
+	if err := f.usageTemplate.Execute(f.Output(), data); err != nil {
+		fmt.Fprintln(f.Output(), err)
+	}
+}
@@ -0,0 +1,69 @@
+// Copyright 2018 Li Zebang. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package flag_test
+
+import (
+	"bytes"
+	. "flag"
+	"os"
+	"strings"
+	"testing"
+	"text/template"
+)
+
+func TestSetUsageTemplate(t *testing.T) {
+	fs := NewFlagSet("serve", ContinueOnError)
+	var buf bytes.Buffer
+	fs.SetOutput(&buf)
+	fs.IntP("port", "p", 8080, "`port` to listen on")
+	fs.Bool("v", false, "verbose")
+	fs.SetUsageTemplate(template.Must(template.New("usage").Parse(
+		"usage: {{.Name}} [flags]\n{{range .Flags}}{{$.Names .Name}} {{$.Arg .Name}}\n{{end}}{{.Defaults}}")))
+	if err := fs.Parse([]string{"-h"}); err != ErrHelp {
+		t.Fatalf("Parse(-h): %v", err)
+	}
+	want := strings.Join([]string{
+		"usage: serve [flags]",
+		"-p, --port port",
+		"-v ",
+		"  -p, --port port",
+		"    \tport to listen on (default 8080)",
+		"  -v\tverbose",
+		"",
+	}, "\n")
+	if buf.String() != want {
+		t.Errorf("usage:\n%q\nwant:\n%q", buf.String(), want)
+	}
+
+	buf.Reset()
+	fs.SetUsageTemplate(nil)
+	fs.Parse([]string{"-h"})
+	if !strings.HasPrefix(buf.String(), "Usage of serve:\n") {
+		t.Errorf("usage after reset = %q", buf.String())
+	}
+
+	buf.Reset()
+	fs.SetUsageTemplate(template.Must(template.New("usage").Parse("{{.Missing}}")))
+	fs.Parse([]string{"-h"})
+	if !strings.Contains(buf.String(), "can't evaluate field Missing") {
+		t.Errorf("usage with bad field = %q", buf.String())
+	}
+}
+
+func TestSetUsageTemplateCommandLine(t *testing.T) {
+	ResetForTesting(DefaultUsage)
+	defer ResetForTesting(nil)
+	var buf bytes.Buffer
+	CommandLine.SetOutput(&buf)
+	Int("port", 8080, "`port` to listen on")
+	SetUsageTemplate(template.Must(template.New("usage").Parse(
+		"usage: app{{range .Flags}} [{{$.Names .Name}} {{$.Arg .Name}}]{{end}}\n")))
+	defer func(old []string) { os.Args = old }(os.Args)
+	os.Args = []string{"app", "-h"}
+	Parse()
+	if got, want := buf.String(), "usage: app [-port port]\n"; got != want {
+		t.Errorf("usage = %q; want %q", got, want)
+	}
+}
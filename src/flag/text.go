@@ -0,0 +1,88 @@
+// Copyright 2018 Li Zebang. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package flag
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// textUnmarshaler and textMarshaler have the method sets of
+// encoding.TextUnmarshaler and encoding.TextMarshaler.
+//
+// IMP: testing 导入了 flag，flag 在 go/build/deps_test.go 中只允许依赖 L4 和 OS，所以
+// 这里不导入 encoding，而是使用具有相同方法集的接口，任何 encoding.TextUnmarshaler 都满足它。
+type (
+	textUnmarshaler = interface{ UnmarshalText([]byte) error }
+	textMarshaler   = interface{ MarshalText() ([]byte, error) }
+)
+
+// -- encoding.TextUnmarshaler Value
+type textValue struct{ p textUnmarshaler }
+
+func (v textValue) Set(s string) error { return v.p.UnmarshalText([]byte(s)) }
+
+func (v textValue) Get() interface{} { return v.p }
+
+func (v textValue) String() string {
+	if m, ok := v.p.(textMarshaler); ok {
+		if b, err := m.MarshalText(); err == nil {
+			return string(b)
+		}
+	}
+	return ""
+}
+
+// TextVar defines a flag with a specified name, default value, and usage
+// string. The argument p must be a pointer to a variable that will hold the
+// value of the flag, and p must implement encoding.TextUnmarshaler. If the
+// flag is used, the flag value will be passed to p's UnmarshalText method.
+// The type of the default value must be the same as the type of p, or of
+// what p points to. TextVar panics if it is not.
+//
+// TextVar 定义一个具有指定名称、默认值和用法信息的标志。参数 p 必须是一个指向用来存储标志
+// 的值的变量的指针，并且 p 必须实现了 encoding.TextUnmarshaler。如果使用了该标志，标志的
+// 值会被传给 p 的 UnmarshalText 方法。默认值的类型必须和 p 或者 p 指向的变量的类型相同，
+// 否则 TextVar 会 panic。
+//
+// IMP: 默认值通过反射复制到 p 指向的变量中，所以 p 不能是一个非指针类型的 TextUnmarshaler。
+func (f *FlagSet) TextVar(p interface{ UnmarshalText([]byte) error }, name string, value interface{ MarshalText() ([]byte, error) }, usage string) {
+	ptrVal := reflect.ValueOf(p)
+	if ptrVal.Kind() != reflect.Ptr || ptrVal.IsNil() {
+		f.textVarPanic(name, "variable value type must be a non-nil pointer")
+	}
+	defVal := reflect.ValueOf(value)
+	if defVal.Kind() == reflect.Ptr {
+		defVal = defVal.Elem()
+	}
+	if !defVal.IsValid() || defVal.Type() != ptrVal.Type().Elem() {
+		f.textVarPanic(name, fmt.Sprintf("default type does not match variable type: %T != %v", value, ptrVal.Type().Elem()))
+	}
+	ptrVal.Elem().Set(defVal)
+	f.Var(textValue{p}, name, usage)
+}
+
+// textVarPanic reports a misuse of TextVar the way Var reports a flag
+// defined twice.
+//
+// textVarPanic 像 Var 报告重复定义的标志一样报告对 TextVar 的误用。
+func (f *FlagSet) textVarPanic(name, reason string) {
+	msg := fmt.Sprintf("invalid TextVar for flag -%s: %s", name, reason)
+	if f.name != "" {
+		msg = f.name + " " + msg
+	}
+	fmt.Fprintln(f.Output(), msg)
+	panic(msg)
+}
+
+// TextVar defines a flag with a specified name, default value, and usage
+// string. The argument p must be a pointer to a variable that will hold the
+// value of the flag, and p must implement encoding.TextUnmarshaler.
+//
+// TextVar 定义一个具有指定名称、默认值和用法信息的标志。参数 p 必须是一个指向用来存储标志
+// 的值的变量的指针，并且 p 必须实现了 encoding.TextUnmarshaler。
+func TextVar(p interface{ UnmarshalText([]byte) error }, name string, value interface{ MarshalText() ([]byte, error) }, usage string) {
+	CommandLine.TextVar(p, name, value, usage)
+}
@@ -0,0 +1,106 @@
+// Copyright 2018 Li Zebang. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package flag_test
+
+import (
+	"bytes"
+	"errors"
+	. "flag"
+	"net"
+	"strings"
+	"testing"
+)
+
+// level is a log level that implements the text interfaces.
+type level int
+
+var levelNames = []string{"debug", "info", "warn"}
+
+func (l level) MarshalText() ([]byte, error) { return []byte(levelNames[l]), nil }
+
+func (l *level) UnmarshalText(text []byte) error {
+	for i, name := range levelNames {
+		if name == string(text) {
+			*l = level(i)
+			return nil
+		}
+	}
+	return errors.New("unknown level " + string(text))
+}
+
+func TestTextVar(t *testing.T) {
+	fs := NewFlagSet("text", ContinueOnError)
+	var buf bytes.Buffer
+	fs.SetOutput(&buf)
+	var ip net.IP
+	var lvl level
+	fs.TextVar(&ip, "ip", net.IPv4(127, 0, 0, 1), "address")
+	fs.TextVar(&lvl, "level", level(1), "log level")
+	if !ip.Equal(net.IPv4(127, 0, 0, 1)) || lvl != 1 {
+		t.Fatalf("defaults: ip = %v, level = %v", ip, lvl)
+	}
+	if err := fs.Parse([]string{"-ip", "10.0.0.2", "-level", "warn"}); err != nil {
+		t.Fatal(err)
+	}
+	if !ip.Equal(net.IPv4(10, 0, 0, 2)) || lvl != 2 {
+		t.Errorf("ip = %v, level = %v", ip, lvl)
+	}
+	if got := fs.Lookup("level").Value.String(); got != "warn" {
+		t.Errorf("level String() = %q", got)
+	}
+	if got := fs.Lookup("level").Value.(Getter).Get(); got != &lvl {
+		t.Errorf("level Get() = %v; want %p", got, &lvl)
+	}
+	err := fs.Parse([]string{"-level", "loud"})
+	if err == nil || !strings.Contains(err.Error(), "unknown level loud") {
+		t.Errorf("Parse error = %v", err)
+	}
+}
+
+func TestTextVarPrintDefaults(t *testing.T) {
+	fs := NewFlagSet("text", ContinueOnError)
+	var buf bytes.Buffer
+	fs.SetOutput(&buf)
+	var lvl level
+	fs.TextVar(&lvl, "level", level(2), "log `level`")
+	fs.PrintDefaults()
+	want := "  -level level\n    \tlog level (default warn)\n"
+	if buf.String() != want {
+		t.Errorf("PrintDefaults:\n%q\nwant:\n%q", buf.String(), want)
+	}
+}
+
+func TestTextVarPanics(t *testing.T) {
+	tests := []struct {
+		name string
+		def  func(fs *FlagSet)
+		want string
+	}{
+		{"mismatch", func(fs *FlagSet) {
+			var lvl level
+			fs.TextVar(&lvl, "level", net.IPv4(1, 2, 3, 4), "")
+		}, "text invalid TextVar for flag -level: default type does not match variable type: net.IP != flag_test.level"},
+		{"nil", func(fs *FlagSet) {
+			var ip *net.IP
+			fs.TextVar(ip, "ip", net.IP{}, "")
+		}, "text invalid TextVar for flag -ip: variable value type must be a non-nil pointer"},
+	}
+	for _, tt := range tests {
+		fs := NewFlagSet("text", ContinueOnError)
+		var buf bytes.Buffer
+		fs.SetOutput(&buf)
+		func() {
+			defer func() {
+				if r := recover(); r != tt.want {
+					t.Errorf("%s: panic = %v; want %q", tt.name, r, tt.want)
+				}
+			}()
+			tt.def(fs)
+		}()
+		if got := strings.TrimSpace(buf.String()); got != tt.want {
+			t.Errorf("%s: output = %q", tt.name, got)
+		}
+	}
+}
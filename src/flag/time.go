@@ -0,0 +1,84 @@
+// Copyright 2018 Li Zebang. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package flag
+
+import "time"
+
+// -- time.Time Value
+//
+// The zero time formats as the empty string, so that a flag without a
+// default shows none in PrintDefaults.
+//
+// 零时间被格式化为空字符串，所以没有默认值的标志在 PrintDefaults 中不显示默认值。
+type timeValue struct {
+	p      *time.Time
+	layout string
+}
+
+func newTimeValue(val time.Time, p *time.Time, layout string) *timeValue {
+	if layout == "" {
+		layout = time.RFC3339
+	}
+	*p = val
+	return &timeValue{p: p, layout: layout}
+}
+
+func (t *timeValue) Set(s string) error {
+	v, err := time.Parse(t.layout, s)
+	if err != nil {
+		return err
+	}
+	*t.p = v
+	return nil
+}
+
+func (t *timeValue) Get() interface{} { return *t.p }
+
+func (t *timeValue) String() string {
+	if t.p == nil || t.p.IsZero() {
+		return ""
+	}
+	return t.p.Format(t.layout)
+}
+
+// TimeVar defines a time.Time flag with specified name, layout, default
+// value, and usage string. The argument p points to a time.Time variable in
+// which to store the value of the flag. The flag accepts a value parsed by
+// time.Parse with layout, or with time.RFC3339 if layout is empty.
+//
+// TimeVar 定义一个具有指定名称、格式、默认值和用法信息的 time.Time 标志。参数 p 指向一个
+// 用来存储标志的值的 time.Time 变量。该标志接受能被 time.Parse 用 layout 解析的值，如果
+// layout 为空，则使用 time.RFC3339。
+func (f *FlagSet) TimeVar(p *time.Time, name, layout string, value time.Time, usage string) {
+	f.Var(newTimeValue(value, p, layout), name, usage)
+}
+
+// TimeVar defines a time.Time flag with specified name, layout, default
+// value, and usage string.
+//
+// TimeVar 定义一个具有指定名称、格式、默认值和用法信息的 time.Time 标志。
+func TimeVar(p *time.Time, name, layout string, value time.Time, usage string) {
+	CommandLine.Var(newTimeValue(value, p, layout), name, usage)
+}
+
+// Time defines a time.Time flag with specified name, layout, default value,
+// and usage string. The return value is the address of a time.Time variable
+// that stores the value of the flag.
+//
+// Time 定义一个具有指定名称、格式、默认值和用法信息的 time.Time 标志。返回值是存储标志的值
+// 的 time.Time 变量的地址。
+func (f *FlagSet) Time(name, layout string, value time.Time, usage string) *time.Time {
+	p := new(time.Time)
+	f.TimeVar(p, name, layout, value, usage)
+	return p
+}
+
+// Time defines a time.Time flag with specified name, layout, default value,
+// and usage string.
+//
+// Time 定义一个具有指定名称、格式、默认值和用法信息的 time.Time 标志。
+func Time(name, layout string, value time.Time, usage string) *time.Time {
+	return CommandLine.Time(name, layout, value, usage)
+}
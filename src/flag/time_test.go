@@ -0,0 +1,69 @@
+// Copyright 2018 Li Zebang. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package flag_test
+
+import (
+	"bytes"
+	. "flag"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTime(t *testing.T) {
+	fs := NewFlagSet("time", ContinueOnError)
+	since := fs.Time("since", "", time.Time{}, "start time")
+	var day time.Time
+	fs.TimeVar(&day, "day", "2006-01-02", time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC), "day")
+	if err := fs.Parse([]string{"-since", "2018-06-01T12:30:00+08:00", "-day", "2018-07-04"}); err != nil {
+		t.Fatal(err)
+	}
+	want := time.Date(2018, 6, 1, 4, 30, 0, 0, time.UTC)
+	if !since.Equal(want) {
+		t.Errorf("since = %v; want %v", since, want)
+	}
+	if got := day.Format("2006-01-02"); got != "2018-07-04" {
+		t.Errorf("day = %s", got)
+	}
+	if got := fs.Lookup("day").Value.String(); got != "2018-07-04" {
+		t.Errorf("day String() = %q", got)
+	}
+	if got, ok := fs.Lookup("since").Value.(Getter).Get().(time.Time); !ok || !got.Equal(want) {
+		t.Errorf("since Get() = %v", got)
+	}
+}
+
+func TestTimeInvalid(t *testing.T) {
+	fs := NewFlagSet("time", ContinueOnError)
+	fs.SetOutput(new(bytes.Buffer))
+	def := time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC)
+	day := fs.Time("day", "2006-01-02", def, "day")
+	err := fs.Parse([]string{"-day", "2018-07-04T00:00:00Z"})
+	if err == nil || !strings.Contains(err.Error(), `invalid value "2018-07-04T00:00:00Z" for flag -day`) {
+		t.Errorf("Parse error = %v", err)
+	}
+	if !day.Equal(def) {
+		t.Errorf("invalid value changed day to %v", day)
+	}
+}
+
+func TestTimePrintDefaults(t *testing.T) {
+	fs := NewFlagSet("time", ContinueOnError)
+	var buf bytes.Buffer
+	fs.SetOutput(&buf)
+	fs.Time("day", "2006-01-02", time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC), "day")
+	fs.Time("since", "", time.Time{}, "start time")
+	fs.PrintDefaults()
+	want := strings.Join([]string{
+		"  -day time",
+		"    \tday (default 2018-01-01)",
+		"  -since time",
+		"    \tstart time",
+		"",
+	}, "\n")
+	if buf.String() != want {
+		t.Errorf("PrintDefaults:\n%q\nwant:\n%q", buf.String(), want)
+	}
+}
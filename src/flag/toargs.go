@@ -0,0 +1,59 @@
+// Copyright 2018 Li Zebang. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package flag
+
+import "strings"
+
+// ToArgs returns a command line that sets the flags that have been set to
+// their current values, followed by the remaining arguments, for instance
+// to re-exec the program or to start workers with the same configuration.
+// Flags are written in lexicographical order as -name=value, or -name for
+// a boolean flag that is true, and a "--" is put before the arguments if
+// any of them looks like a flag; the arguments of Passthrough follow a
+// "--" of their own. Flags set from the environment, value
+// sources or config files are written as well, so the command line does
+// not depend on them. Values are written with the String method of the
+// flag's Value, so flags whose Value cannot print what it was set to, such
+// as those defined by Func, and the flags of Dynamic are not reproduced.
+// Secret flags are written as -name=***; see MarkSecret.
+//
+// ToArgs 返回一个命令行，它将已被设置的标志设为它们的当前值，后面跟着剩余的参数，例如用来
+// 重新执行程序，或者以相同的配置启动工作进程。标志按照字典序写为 -name=value，值为 true 的
+// 布尔标志写为 -name，如果某个参数看起来像标志，参数之前会加上 "--"；Passthrough 的参数跟在
+// 它们自己的 "--" 之后。从环境变量、值来源或者
+// 配置文件设置的标志也会被写出，所以这个命令行不依赖它们。值由标志的 Value 的 String 方法
+// 写出，所以 Value 无法打印被设置的值的标志（例如 Func 定义的标志）和 Dynamic 的标志无法
+// 被重现。秘密标志被写为 -name=***，参见 MarkSecret。
+func (f *FlagSet) ToArgs() []string {
+	var args []string
+	for _, flag := range sortFlags(f.actual) {
+		value := shownValue(flag, flag.Value.String())
+		if fv, ok := flag.Value.(boolFlag); ok && fv.IsBoolFlag() && value == "true" {
+			args = append(args, "-"+flag.Name)
+			continue
+		}
+		args = append(args, "-"+flag.Name+"="+value)
+	}
+	for _, arg := range f.args {
+		// 单独的 "-" 通常表示标准输入，不是标志
+		if len(arg) > 1 && strings.HasPrefix(arg, "-") { // a lone "-" usually means stdin and is not a flag
+			args = append(args, "--")
+			break
+		}
+	}
+	args = append(args, f.args...)
+	if f.passthrough != nil {
+		args = append(append(args, "--"), f.passthrough...)
+	}
+	return args
+}
+
+// ToArgs returns a command line that sets the command-line flags that have
+// been set to their current values, followed by the remaining arguments.
+//
+// ToArgs 返回一个命令行，它将已被设置的命令行标志设为它们的当前值，后面跟着剩余的参数。
+func ToArgs() []string {
+	return CommandLine.ToArgs()
+}
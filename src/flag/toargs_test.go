@@ -0,0 +1,70 @@
+// Copyright 2018 Li Zebang. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package flag_test
+
+import (
+	. "flag"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func newToArgsFlagSet() *FlagSet {
+	fs := NewFlagSet("toargs", ContinueOnError)
+	fs.Bool("v", false, "verbose")
+	fs.Bool("cache", true, "cache")
+	fs.Count("d", 0, "debug level")
+	fs.String("name", "", "name")
+	fs.Duration("timeout", time.Second, "timeout")
+	fs.StringSlice("tag", nil, "tags")
+	fs.Int("unset", 7, "unset")
+	return fs
+}
+
+func TestToArgs(t *testing.T) {
+	tests := []struct {
+		args []string
+		want []string
+	}{
+		{nil, nil},
+		{[]string{"a", "b"}, []string{"a", "b"}},
+		{
+			[]string{"-v", "-cache=false", "-d", "-d", "-name", "-x y", "-timeout", "90s", "-tag", "a", "-tag", "b,c", "in", "-"},
+			[]string{"-cache=false", "-d=2", "-name=-x y", "-tag=a,b,c", "-timeout=1m30s", "-v", "in", "-"},
+		},
+		{[]string{"-name=", "--", "-out"}, []string{"-name=", "--", "-out"}},
+	}
+	for _, tt := range tests {
+		fs := newToArgsFlagSet()
+		if err := fs.Parse(tt.args); err != nil {
+			t.Fatal(err)
+		}
+		got := fs.ToArgs()
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Parse(%q).ToArgs() = %q; want %q", tt.args, got, tt.want)
+			continue
+		}
+		// 重新解析得到的命令行必须得到相同的结果
+		again := newToArgsFlagSet() // parsing the command line again must give the same result
+		if err := again.Parse(got); err != nil {
+			t.Errorf("Parse(%q): %v", got, err)
+			continue
+		}
+		if back := again.ToArgs(); !reflect.DeepEqual(back, got) {
+			t.Errorf("round trip of %q gave %q", got, back)
+		}
+	}
+}
+
+func TestToArgsSources(t *testing.T) {
+	fs := newToArgsFlagSet()
+	fs.AddSource(MapSource{"name": "from-source"}, 1)
+	if err := fs.Parse([]string{"-v"}); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := fs.ToArgs(), []string{"-name=from-source", "-v"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("ToArgs() = %q; want %q", got, want)
+	}
+}
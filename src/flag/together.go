@@ -0,0 +1,96 @@
+// Copyright 2018 Li Zebang. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package flag
+
+import (
+	"fmt"
+	"strings"
+)
+
+// MarkRequiredTogether declares that the named flags must be set together:
+// once Parse has read the command line, the environment and the value
+// sources, it fails if some of them are set and others are not. Setting
+// none of them is fine. MarkRequiredTogether panics if a flag is not
+// defined or fewer than two names are given.
+//
+// MarkRequiredTogether 声明给出名字的这些标志必须同时被设置：Parse 在读取了命令行、环境
+// 变量和值来源之后，如果它们中有一些被设置了而另一些没有，就会失败。全都不设置是可以的。
+// 如果某个标志没有定义或者给出的名字少于两个，MarkRequiredTogether 会 panic。
+func (f *FlagSet) MarkRequiredTogether(names ...string) {
+	var msg string
+	if len(names) < 2 {
+		msg = fmt.Sprintf("MarkRequiredTogether needs at least two flags, got %d", len(names))
+	}
+	for _, name := range names {
+		if msg != "" {
+			break
+		}
+		if _, ok := f.formal[name]; !ok {
+			msg = fmt.Sprintf("flag provided to MarkRequiredTogether but not defined: -%s", name)
+		}
+	}
+	if msg != "" {
+		if f.name != "" {
+			msg = f.name + " " + msg
+		}
+		fmt.Fprintln(f.Output(), msg)
+		panic(msg)
+	}
+	f.together = append(f.together, append([]string(nil), names...))
+}
+
+// MarkRequiredTogether declares that the named command-line flags must be
+// set together.
+//
+// MarkRequiredTogether 声明给出名字的这些命令行标志必须同时被设置。
+func MarkRequiredTogether(names ...string) {
+	CommandLine.MarkRequiredTogether(names...)
+}
+
+// checkRequiredTogether reports the first group of MarkRequiredTogether
+// that is only partly set.
+//
+// checkRequiredTogether 报告 MarkRequiredTogether 中第一个只有一部分被设置的组。
+func (f *FlagSet) checkRequiredTogether() error {
+	for _, group := range f.together {
+		var set, missing []string
+		for _, name := range group {
+			if f.actual[name] != nil {
+				set = append(set, name)
+			} else {
+				missing = append(missing, name)
+			}
+		}
+		if len(set) > 0 && len(missing) > 0 {
+			return f.failf("flags %s must be set together: %s %s set but %s %s not",
+				joinFlags(group), joinFlags(set), isAre(set), joinFlags(missing), isAre(missing))
+		}
+	}
+	return nil
+}
+
+// joinFlags returns names as a list of flags, such as "-a, -b and -c".
+//
+// joinFlags 将 names 返回为标志的列表，例如 "-a, -b and -c"。
+func joinFlags(names []string) string {
+	flags := make([]string, len(names))
+	for i, name := range names {
+		flags[i] = "-" + name
+	}
+	if len(flags) == 1 {
+		return flags[0]
+	}
+	return strings.Join(flags[:len(flags)-1], ", ") + " and " + flags[len(flags)-1]
+}
+
+// isAre returns the verb that agrees with the number of names.
+//
+// isAre 返回与 names 的个数一致的动词。
+func isAre(names []string) string {
+	if len(names) == 1 {
+		return "is"
+	}
+	return "are"
+}
@@ -0,0 +1,72 @@
+// Copyright 2018 Li Zebang. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package flag_test
+
+import (
+	. "flag"
+	"io/ioutil"
+	"testing"
+)
+
+func TestMarkRequiredTogether(t *testing.T) {
+	tests := []struct {
+		args []string
+		want string
+	}{
+		{nil, ""},
+		{[]string{"-tls-cert", "c", "-tls-key", "k"}, ""},
+		{[]string{"-tls-cert", "c"}, "flags -tls-cert and -tls-key must be set together: -tls-cert is set but -tls-key is not"},
+		{[]string{"-x", "-tls-cert", "c", "-tls-key", "k"}, "flags -x, -y and -z must be set together: -x is set but -y and -z are not"},
+		{[]string{"-x", "-z", "-tls-cert", "c", "-tls-key", "k"}, "flags -x, -y and -z must be set together: -x and -z are set but -y is not"},
+	}
+	for _, tt := range tests {
+		fs := NewFlagSet("together", ContinueOnError)
+		fs.SetOutput(ioutil.Discard)
+		fs.String("tls-cert", "", "")
+		fs.String("tls-key", "", "")
+		fs.Bool("x", false, "")
+		fs.Bool("y", false, "")
+		fs.Bool("z", false, "")
+		fs.MarkRequiredTogether("tls-cert", "tls-key")
+		fs.MarkRequiredTogether("x", "y", "z")
+		err := fs.Parse(tt.args)
+		got := ""
+		if err != nil {
+			got = err.Error()
+		}
+		if got != tt.want {
+			t.Errorf("Parse(%q) = %q; want %q", tt.args, got, tt.want)
+		}
+	}
+}
+
+func TestMarkRequiredTogetherSources(t *testing.T) {
+	defer setenv(t, "TOGETHER_KEY", "k")()
+	fs := NewFlagSet("together", ContinueOnError)
+	fs.SetOutput(ioutil.Discard)
+	fs.String("cert", "", "")
+	fs.String("key", "", "")
+	fs.BindEnv("key", "TOGETHER_KEY")
+	fs.MarkRequiredTogether("cert", "key")
+	if err := fs.Parse([]string{"-cert", "c"}); err != nil {
+		t.Errorf("Parse with -key from the environment: %v", err)
+	}
+}
+
+func TestMarkRequiredTogetherPanics(t *testing.T) {
+	for _, names := range [][]string{{"a"}, {"a", "missing"}} {
+		func() {
+			fs := NewFlagSet("together", ContinueOnError)
+			fs.SetOutput(ioutil.Discard)
+			fs.String("a", "", "")
+			defer func() {
+				if recover() == nil {
+					t.Errorf("MarkRequiredTogether(%q) did not panic", names)
+				}
+			}()
+			fs.MarkRequiredTogether(names...)
+		}()
+	}
+}
@@ -0,0 +1,58 @@
+// Copyright 2018 Li Zebang. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package flag
+
+import "fmt"
+
+// Undefine removes the flag named name from the flag set, together with
+// its shorthand, its aliases and its value if it was set, so the name can
+// be defined again. The flag is also dropped from the groups of
+// MarkRequiredTogether, and a group left with a single flag is dropped too.
+// Undefine returns an error if the flag is not defined.
+//
+// Undefine 从标志集中移除名为 name 的标志，以及它的缩写、别名和被设置的值，这样这个名称
+// 就可以被再次定义。该标志也会从 MarkRequiredTogether 的各个组中移除，只剩下一个标志的组
+// 也会被移除。如果标志没有定义，Undefine 返回一个错误。
+//
+// IMP: 变量中已经存储的值保持不变，Undefine 只是让标志集不再知道这个标志。
+func (f *FlagSet) Undefine(name string) error {
+	flag, ok := f.formal[name]
+	if !ok {
+		return fmt.Errorf("cannot undefine flag -%s: not defined", name)
+	}
+	delete(f.formal, name)
+	f.sorted = nil
+	f.removeDeclared(flag)
+	delete(f.actual, name)
+	delete(f.watchers, flag)
+	delete(f.experimental, flag)
+	if flag.Shorthand != "" {
+		delete(f.shorthands, flag.Shorthand)
+	}
+	for _, alias := range flag.Aliases {
+		delete(f.aliases, alias)
+	}
+	groups := f.together[:0]
+	for _, group := range f.together {
+		names := group[:0]
+		for _, n := range group {
+			if n != name {
+				names = append(names, n)
+			}
+		}
+		if len(names) >= 2 {
+			groups = append(groups, names)
+		}
+	}
+	f.together = groups
+	return nil
+}
+
+// Undefine removes the command-line flag named name.
+//
+// Undefine 移除名为 name 的命令行标志。
+func Undefine(name string) error {
+	return CommandLine.Undefine(name)
+}
@@ -0,0 +1,84 @@
+// Copyright 2018 Li Zebang. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package flag_test
+
+import (
+	"bytes"
+	"errors"
+	. "flag"
+	"testing"
+)
+
+func TestUndefine(t *testing.T) {
+	fs := NewFlagSet("undefine", ContinueOnError)
+	fs.SetOutput(new(bytes.Buffer))
+	fs.IntP("port", "p", 80, "port")
+	fs.Bool("v", false, "verbose")
+	if err := fs.Parse([]string{"-p", "8080", "-v"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := fs.Undefine("port"); err != nil {
+		t.Fatal(err)
+	}
+	if fs.Lookup("port") != nil {
+		t.Error("port still defined")
+	}
+	if fs.NFlag() != 1 {
+		t.Errorf("NFlag = %d; want 1", fs.NFlag())
+	}
+	for _, args := range [][]string{{"-port=1"}, {"-p", "1"}} {
+		var e *ErrUndefinedFlag
+		if err := fs.Parse(args); !errors.As(err, &e) {
+			t.Errorf("Parse(%q) = %v; want *ErrUndefinedFlag", args, err)
+		}
+	}
+
+	// The name and the shorthand may be defined again.
+	port := fs.StringP("port", "p", "http", "port name")
+	if err := fs.Parse([]string{"-p", "https"}); err != nil {
+		t.Fatal(err)
+	}
+	if *port != "https" {
+		t.Errorf("port = %q", *port)
+	}
+
+	if err := fs.Undefine("missing"); err == nil || err.Error() != "cannot undefine flag -missing: not defined" {
+		t.Errorf("Undefine(missing) = %v", err)
+	}
+}
+
+func TestUndefineRequiredTogether(t *testing.T) {
+	fs := NewFlagSet("undefine", ContinueOnError)
+	fs.SetOutput(new(bytes.Buffer))
+	fs.String("a", "", "a")
+	fs.String("b", "", "b")
+	fs.String("c", "", "c")
+	fs.MarkRequiredTogether("a", "b")
+	fs.MarkRequiredTogether("a", "b", "c")
+	if err := fs.Undefine("b"); err != nil {
+		t.Fatal(err)
+	}
+	// {a, b} is gone, and {a, c} is still checked.
+	if err := fs.Parse([]string{"-a", "1"}); err == nil {
+		t.Error("Parse(-a 1) succeeded; want -a and -c required together")
+	}
+	if err := fs.Parse([]string{"-a", "1", "-c", "2"}); err != nil {
+		t.Errorf("Parse(-a 1 -c 2) = %v", err)
+	}
+}
+
+func TestUndefineExperimental(t *testing.T) {
+	fs, _ := newExperimentalFlagSet(new(bytes.Buffer))
+	turbo := fs.Lookup("turbo")
+	if !IsExperimental(fs, turbo) {
+		t.Fatal("turbo is not experimental")
+	}
+	if err := fs.Undefine("turbo"); err != nil {
+		t.Fatal(err)
+	}
+	if IsExperimental(fs, turbo) {
+		t.Error("Undefine left turbo experimental")
+	}
+}
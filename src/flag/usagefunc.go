@@ -0,0 +1,37 @@
+// Copyright 2018 Li Zebang. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package flag
+
+import "fmt"
+
+// SetUsageFunc makes PrintDefaults print the text returned by fn for the
+// named flag instead of its built-in layout, for instance to document the
+// grammar of a complex value over several lines. The text should be
+// indented like the other entries, and a final newline is added if it is
+// missing. SetUsageFunc panics if the flag is not defined.
+//
+// SetUsageFunc 使 PrintDefaults 为名为 name 的标志打印 fn 返回的文本，而不是使用内置的
+// 格式，例如用多行文本说明复杂值的语法。文本应该和其他条目一样缩进，缺少末尾的换行符时会
+// 自动添加。如果标志没有定义，SetUsageFunc 会 panic。
+func (f *FlagSet) SetUsageFunc(name string, fn func(*Flag) string) {
+	flag := f.lookupName(name)
+	if flag == nil {
+		msg := fmt.Sprintf("flag provided to SetUsageFunc but not defined: -%s", name)
+		if f.name != "" {
+			msg = f.name + " " + msg
+		}
+		fmt.Fprintln(f.Output(), msg)
+		panic(msg)
+	}
+	flag.UsageFunc = fn
+}
+
+// SetUsageFunc makes PrintDefaults print the text returned by fn for the
+// named command-line flag.
+//
+// SetUsageFunc 使 PrintDefaults 为名为 name 的命令行标志打印 fn 返回的文本。
+func SetUsageFunc(name string, fn func(*Flag) string) {
+	CommandLine.SetUsageFunc(name, fn)
+}
@@ -0,0 +1,48 @@
+// Copyright 2018 Li Zebang. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package flag_test
+
+import (
+	"bytes"
+	. "flag"
+	"strings"
+	"testing"
+)
+
+func TestSetUsageFunc(t *testing.T) {
+	fs := NewFlagSet("usagefunc", ContinueOnError)
+	var buf bytes.Buffer
+	fs.SetOutput(&buf)
+	fs.Bool("a", false, "first")
+	fs.String("filter", "all", "event filter")
+	fs.Bool("z", false, "last")
+	fs.SetUsageFunc("filter", func(flag *Flag) string {
+		return "  -" + flag.Name + " expr\n    \t" + flag.Usage + ", where\n    \t  expr = term { \"|\" term }\n    \t(default " + flag.DefValue + ")"
+	})
+	fs.PrintDefaults()
+	want := strings.Join([]string{
+		"  -a\tfirst",
+		"  -filter expr",
+		"    \tevent filter, where",
+		"    \t  expr = term { \"|\" term }",
+		"    \t(default all)",
+		"  -z\tlast",
+		"",
+	}, "\n")
+	if buf.String() != want {
+		t.Errorf("PrintDefaults:\n%s\nwant:\n%s", buf.String(), want)
+	}
+}
+
+func TestSetUsageFuncPanics(t *testing.T) {
+	fs := NewFlagSet("usagefunc", ContinueOnError)
+	fs.SetOutput(new(bytes.Buffer))
+	defer func() {
+		if r := recover(); r != "usagefunc flag provided to SetUsageFunc but not defined: -x" {
+			t.Errorf("panic = %v", r)
+		}
+	}()
+	fs.SetUsageFunc("x", nil)
+}
@@ -0,0 +1,128 @@
+// Copyright 2018 Li Zebang. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+// Package usagetemplate parses text/templates for the usage messages of
+// flag.FlagSet.SetUsageTemplate. Besides the functions of text/template,
+// the templates may use these ones:
+//
+//	defaults    the text PrintDefaults prints
+//	names NAME  the forms of flag NAME, such as "-p, --port"
+//	arg NAME    the name of the argument of flag NAME, such as "int"
+//	join, upper, lower
+//	            strings.Join, strings.ToUpper and strings.ToLower
+//	pad N S     S padded with spaces to N bytes
+//
+// For instance:
+//
+//	fs.SetUsageTemplate(usagetemplate.Must(usagetemplate.New(
+//		"{{upper .Name}} OPTIONS\n{{range .Flags}}{{pad 18 (names .Name)}}{{.Usage}}\n{{end}}")))
+//
+// It is kept out of package flag, which testing imports, so that programs
+// using flag do not link text/template unless they ask for it.
+//
+// usagetemplate 包为 flag.FlagSet.SetUsageTemplate 的用法信息解析 text/template 模板。
+// 除了 text/template 的函数，模板还可以使用以下函数：
+//
+//	defaults    PrintDefaults 打印的文本
+//	names NAME  标志 NAME 的各种形式，例如 "-p, --port"
+//	arg NAME    标志 NAME 的参数名称，例如 "int"
+//	join, upper, lower
+//	            strings.Join、strings.ToUpper 和 strings.ToLower
+//	pad N S     用空格填充到 N 字节的 S
+//
+// 例如：
+//
+//	fs.SetUsageTemplate(usagetemplate.Must(usagetemplate.New(
+//		"{{upper .Name}} OPTIONS\n{{range .Flags}}{{pad 18 (names .Name)}}{{.Usage}}\n{{end}}")))
+//
+// 它没有放在 flag 包中，因为 testing 导入了 flag，这样使用 flag 的程序只有在需要时才会链接
+// text/template。
+package usagetemplate
+
+import (
+	"io"
+	"strings"
+	"text/template"
+)
+
+// funcs are the functions available to usage templates. defaults, names
+// and arg are only placeholders for parsing: Execute binds them to the
+// flag set the template is executed for.
+//
+// funcs 是用法模板中可以使用的函数。defaults、names 和 arg 只是解析时的占位函数：Execute 会
+// 将它们绑定到执行模板的标志集。
+var funcs = template.FuncMap{
+	"defaults": func() string { return "" },
+	"names":    func(name string) string { return "" },
+	"arg":      func(name string) string { return "" },
+	"join":     strings.Join,
+	"upper":    strings.ToUpper,
+	"lower":    strings.ToLower,
+	"pad": func(width int, s string) string {
+		if n := width - len(s); n > 0 {
+			return s + strings.Repeat(" ", n)
+		}
+		return s
+	},
+}
+
+// usageData is the part of flag.UsageData that the functions bound to the
+// flag set call.
+//
+// usageData 是 flag.UsageData 中被绑定到标志集的函数所调用的部分。
+type usageData interface {
+	Defaults() string
+	Names(name string) string
+	Arg(name string) string
+}
+
+// A Template is a parsed usage template.
+//
+// Template 是一个解析好的用法模板。
+type Template struct {
+	t *template.Template
+}
+
+// New parses text as a usage template.
+//
+// New 将 text 解析为一个用法模板。
+func New(text string) (*Template, error) {
+	t, err := template.New("usage").Funcs(funcs).Parse(text)
+	if err != nil {
+		return nil, err
+	}
+	return &Template{t}, nil
+}
+
+// Must returns t if err is nil and panics otherwise, like template.Must.
+//
+// Must 在 err 为 nil 时返回 t，否则 panic，和 template.Must 一样。
+func Must(t *Template, err error) *Template {
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+// Execute prints the usage message for data, which is a flag.UsageData
+// when flag calls it.
+//
+// Execute 为 data 打印用法信息，flag 调用它时 data 是一个 flag.UsageData。
+//
+// IMP: 每次执行都复制模板来绑定 data 的方法，这样同一个模板可以被多个标志集使用。
+func (t *Template) Execute(w io.Writer, data interface{}) error {
+	tmpl := t.t
+	if d, ok := data.(usageData); ok {
+		c, err := t.t.Clone()
+		if err != nil {
+			return err
+		}
+		tmpl = c.Funcs(template.FuncMap{
+			"defaults": d.Defaults,
+			"names":    d.Names,
+			"arg":      d.Arg,
+		})
+	}
+	return tmpl.Execute(w, data)
+}
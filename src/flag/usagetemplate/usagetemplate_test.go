@@ -0,0 +1,66 @@
+// Copyright 2018 Li Zebang. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package usagetemplate_test
+
+import (
+	"bytes"
+	. "flag"
+	"flag/usagetemplate"
+	"strings"
+	"testing"
+)
+
+func TestSetUsageTemplate(t *testing.T) {
+	fs := NewFlagSet("serve", ContinueOnError)
+	var buf bytes.Buffer
+	fs.SetOutput(&buf)
+	fs.IntP("port", "p", 8080, "`port` to listen on")
+	fs.Bool("debug", false, "internal")
+	fs.MarkHidden("debug")
+	fs.Group("Storage").String("root", ".", "directory to serve")
+	fs.SetUsageTemplate(usagetemplate.Must(usagetemplate.New(`{{upper .Name}} OPTIONS
+{{range .Flags}}{{pad 18 (printf "%s %s" (names .Name) (arg .Name))}}{{.Usage}}{{if .Group}} [{{.Group}}]{{end}}
+{{end}}`)))
+	if err := fs.Parse([]string{"-h"}); err != ErrHelp {
+		t.Fatalf("Parse(-h): %v", err)
+	}
+	want := strings.Join([]string{
+		"SERVE OPTIONS",
+		"-p, --port port   port to listen on",
+		"-root string      directory to serve [Storage]",
+		"",
+	}, "\n")
+	if buf.String() != want {
+		t.Errorf("usage:\n%q\nwant:\n%q", buf.String(), want)
+	}
+}
+
+func TestSetUsageTemplateDefaults(t *testing.T) {
+	tmpl := usagetemplate.Must(usagetemplate.New("usage: {{.Name}} [flags]\n{{defaults}}"))
+	// 同一个模板被两个标志集使用
+	for _, name := range []string{"serve", "build"} { // one template shared by two flag sets
+		fs := NewFlagSet(name, ContinueOnError)
+		var buf bytes.Buffer
+		fs.SetOutput(&buf)
+		fs.Bool(name[:1], false, "verbose")
+		fs.SetUsageTemplate(tmpl)
+		fs.Parse([]string{"-h"})
+		if want := "usage: " + name + " [flags]\n  -" + name[:1] + "\tverbose\n"; buf.String() != want {
+			t.Errorf("usage = %q; want %q", buf.String(), want)
+		}
+	}
+}
+
+func TestNewError(t *testing.T) {
+	if _, err := usagetemplate.New("{{.Name"); err == nil || !strings.HasPrefix(err.Error(), "template: usage:1: ") {
+		t.Errorf("New = %v", err)
+	}
+	defer func() {
+		if recover() == nil {
+			t.Error("Must did not panic")
+		}
+	}()
+	usagetemplate.Must(usagetemplate.New("{{.Name"))
+}
@@ -0,0 +1,61 @@
+// Copyright 2018 Li Zebang. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package flag
+
+import "fmt"
+
+// Watch makes the Set method call fn with the old and the new value of the
+// named flag, as its Value prints them, whenever Set changes the flag after
+// Parse, so that flags such as a log level or a rate limit can be tuned
+// while the program runs. Parse itself, and a Set that leaves the printed
+// value unchanged, call no watcher. Watchers of a flag are called in the
+// order they were added. Watch panics if the flag is not defined.
+//
+// Watch 使 Set 方法在 Parse 之后每次改变名为 name 的标志时，以该标志的旧值和新值（由它的
+// Value 打印）调用 fn，这样日志级别或者速率限制这样的标志就可以在程序运行时调整。Parse
+// 本身以及没有改变打印出的值的 Set 不会调用任何监视函数。同一个标志的监视函数按照它们被
+// 添加的顺序调用。如果标志没有定义，Watch 会 panic。
+//
+// NOTE: SafeFlagSet 的 Set 在持有锁时调用 fn，所以 fn 不能再调用这个 SafeFlagSet 的方法。
+func (f *FlagSet) Watch(name string, fn func(old, new string)) {
+	flag := f.lookupName(name)
+	if flag == nil {
+		msg := fmt.Sprintf("flag provided to Watch but not defined: -%s", name)
+		if f.name != "" {
+			msg = f.name + " " + msg
+		}
+		fmt.Fprintln(f.Output(), msg)
+		panic(msg)
+	}
+	if f.watchers == nil {
+		f.watchers = make(map[*Flag][]func(old, new string))
+	}
+	f.watchers[flag] = append(f.watchers[flag], fn)
+}
+
+// Watch makes Set call fn whenever it changes the named command-line flag
+// after Parse.
+//
+// Watch 使 Set 在 Parse 之后每次改变名为 name 的命令行标志时调用 fn。
+func Watch(name string, fn func(old, new string)) {
+	CommandLine.Watch(name, fn)
+}
+
+// notify calls the watchers of flag if its value has changed from old.
+//
+// notify 在 flag 的值与 old 不同时调用它的监视函数。
+func (f *FlagSet) notify(flag *Flag, old string) {
+	watchers := f.watchers[flag]
+	if len(watchers) == 0 || !f.parsed {
+		return
+	}
+	new := flag.Value.String()
+	if new == old {
+		return
+	}
+	for _, fn := range watchers {
+		fn(old, new)
+	}
+}
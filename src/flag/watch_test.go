@@ -0,0 +1,63 @@
+// Copyright 2018 Li Zebang. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package flag_test
+
+import (
+	"bytes"
+	. "flag"
+	"strings"
+	"testing"
+)
+
+func TestWatch(t *testing.T) {
+	fs := NewFlagSet("watch", ContinueOnError)
+	fs.SetOutput(new(bytes.Buffer))
+	fs.String("log-level", "info", "")
+	fs.Alias("v", "log-level")
+	fs.Int("rate", 10, "")
+	var changes []string
+	fs.Watch("log-level", func(old, new string) { changes = append(changes, "a:"+old+"->"+new) })
+	fs.Watch("v", func(old, new string) { changes = append(changes, "b:"+old+"->"+new) })
+
+	// Parse 之前和 Parse 本身都不会通知
+	if err := fs.Set("log-level", "warn"); err != nil { // neither Parse nor a Set before it notifies
+		t.Fatal(err)
+	}
+	if err := fs.Parse([]string{"-log-level", "debug"}); err != nil {
+		t.Fatal(err)
+	}
+	if len(changes) != 0 {
+		t.Errorf("changes before Set after Parse: %q", changes)
+	}
+
+	for _, tt := range []struct{ name, value string }{
+		{"log-level", "error"},
+		{"v", "info"},
+		{"log-level", "info"}, // unchanged
+		{"rate", "20"},        // not watched
+	} {
+		if err := fs.Set(tt.name, tt.value); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := fs.Set("rate", "x"); err == nil {
+		t.Error("Set(rate, x) = nil")
+	}
+	want := "a:debug->error b:debug->error a:error->info b:error->info"
+	if got := strings.Join(changes, " "); got != want {
+		t.Errorf("changes = %q; want %q", got, want)
+	}
+}
+
+func TestWatchUndefined(t *testing.T) {
+	fs := NewFlagSet("watch", ContinueOnError)
+	fs.SetOutput(new(bytes.Buffer))
+	defer func() {
+		if msg := recover(); msg != "watch flag provided to Watch but not defined: -nope" {
+			t.Errorf("panic = %v", msg)
+		}
+	}()
+	fs.Watch("nope", func(old, new string) {})
+}
@@ -40,13 +40,15 @@ var pkgDeps = map[string][]string{
 	"runtime/internal/sys":    {},
 	"runtime/internal/atomic": {"unsafe", "runtime/internal/sys"},
 	"internal/race":           {"runtime", "unsafe"},
-	"sync":                    {"internal/race", "runtime", "sync/atomic", "unsafe"},
+	"sync":                    {"diag", "internal/race", "runtime", "sync/atomic", "unsafe"},
+	"diag":                    {"sync/atomic"},
 	"sync/atomic":             {"unsafe"},
 	"unsafe":                  {},
 	"internal/cpu":            {},
 	"internal/bytealg":        {"unsafe", "internal/cpu"},
 
 	"L0": {
+		"diag",
 		"errors",
 		"io",
 		"runtime",
@@ -258,6 +260,13 @@ var pkgDeps = map[string][]string{
 	"encoding/pem":             {"L4"},
 	"encoding/xml":             {"L4", "encoding"},
 	"flag":                     {"L4", "OS"},
+	"flag/flagbig":             {"L4", "math/big"},
+	"flag/flagbytes":           {"L4", "encoding/hex"},
+	"flag/flagjson":            {"L4", "encoding/json"},
+	"flag/flagregexp":          {"L4", "regexp"},
+	"flag/flagurl":             {"L4", "net/url"},
+	"flag/remote":              {"L4", "context"},
+	"flag/usagetemplate":       {"L4", "text/template"},
 	"go/build":                 {"L4", "OS", "GOPARSER"},
 	"html":                     {"L4"},
 	"image/draw":               {"L4", "image/internal/imageutil"},
@@ -425,6 +434,7 @@ var pkgDeps = map[string][]string{
 	"net/http/cgi":       {"L4", "NET", "OS", "crypto/tls", "net/http", "regexp"},
 	"net/http/cookiejar": {"L4", "NET", "net/http"},
 	"net/http/fcgi":      {"L4", "NET", "OS", "context", "net/http", "net/http/cgi"},
+	"flag/remotehttp":    {"L4", "context", "encoding/json", "net/http"},
 	"net/http/httptest": {
 		"L4", "NET", "OS", "crypto/tls", "flag", "net/http", "net/http/internal", "crypto/x509",
 		"golang_org/x/net/http/httpguts",
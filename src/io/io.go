@@ -10,6 +10,13 @@
 // Because these interfaces and primitives wrap lower-level operations with
 // various implementations, unless otherwise informed clients should not
 // assume they are safe for parallel execution.
+//
+// Package io 提供了 I/O 原语的基础接口。
+// 它的主要工作是将这些原语已有的实现（如 os 包中的实现）包装成抽象了其功能的共享公共接口，
+// 以及一些其他相关的原语。
+//
+// 因为这些接口和原语包装了各种不同实现的低级操作，除非另有说明，否则调用者不应该假设它们
+// 可以安全地并行执行。
 package io
 
 import (
@@ -17,17 +24,26 @@ import (
 )
 
 // Seek whence values.
+//
+// Seek 的 whence 值。
 const (
-	SeekStart   = 0 // seek relative to the origin of the file
+	// 相对于文件起始位置
+	SeekStart = 0 // seek relative to the origin of the file
+	// 相对于当前偏移量
 	SeekCurrent = 1 // seek relative to the current offset
-	SeekEnd     = 2 // seek relative to the end
+	// 相对于文件末尾
+	SeekEnd = 2 // seek relative to the end
 )
 
 // ErrShortWrite means that a write accepted fewer bytes than requested
 // but failed to return an explicit error.
+//
+// ErrShortWrite 意味着写入接受的字节数少于请求的字节数，但未能返回一个明确的错误。
 var ErrShortWrite = errors.New("short write")
 
 // ErrShortBuffer means that a read required a longer buffer than was provided.
+//
+// ErrShortBuffer 意味着读取需要的缓冲区比提供的缓冲区更长。
 var ErrShortBuffer = errors.New("short buffer")
 
 // EOF is the error returned by Read when no more input is available.
@@ -35,15 +51,26 @@ var ErrShortBuffer = errors.New("short buffer")
 // If the EOF occurs unexpectedly in a structured data stream,
 // the appropriate error is either ErrUnexpectedEOF or some other error
 // giving more detail.
+//
+// EOF 是 Read 在没有更多输入可用时返回的错误。
+// 函数只应该在输入正常结束时返回 EOF。
+// 如果 EOF 意外地出现在结构化数据流中，合适的错误是 ErrUnexpectedEOF 或者其他给出更多
+// 细节的错误。
+// IMP: 调用者应该使用 err == io.EOF 来判断，所以 EOF 不能被包装。
 var EOF = errors.New("EOF")
 
 // ErrUnexpectedEOF means that EOF was encountered in the
 // middle of reading a fixed-size block or data structure.
+//
+// ErrUnexpectedEOF 意味着在读取固定大小的块或数据结构的过程中遇到了 EOF。
 var ErrUnexpectedEOF = errors.New("unexpected EOF")
 
 // ErrNoProgress is returned by some clients of an io.Reader when
 // many calls to Read have failed to return any data or error,
 // usually the sign of a broken io.Reader implementation.
+//
+// 当多次调用 Read 都没有返回任何数据或错误时，一些 io.Reader 的调用者会返回 ErrNoProgress，
+// 这通常是 io.Reader 实现有问题的标志。
 var ErrNoProgress = errors.New("multiple Read calls return no data or error")
 
 // Reader is the interface that wraps the basic Read method.
@@ -74,6 +101,29 @@ var ErrNoProgress = errors.New("multiple Read calls return no data or error")
 // nothing happened; in particular it does not indicate EOF.
 //
 // Implementations must not retain p.
+//
+// Reader 是包装了基础 Read 方法的接口。
+//
+// Read 最多读取 len(p) 个字节到 p 中。它返回读取的字节数（0 <= n <= len(p)）以及遇到的
+// 任何错误。即使 Read 返回的 n < len(p)，它也可能在调用期间将 p 全部用作暂存空间。
+// 如果有一些数据可用但不足 len(p) 个字节，按照惯例 Read 会返回可用的数据，而不是等待更多数据。
+//
+// 当 Read 在成功读取 n > 0 个字节后遇到错误或文件结束的情况，它会返回读取的字节数。它可能
+// 在同一次调用中返回（非 nil 的）错误，也可能在后续的调用中返回该错误（并且 n == 0）。
+// 这种常见情况的一个例子是，一个在输入流末尾返回非零字节数的 Reader 可能返回 err == EOF
+// 或 err == nil。下一次 Read 应该返回 0, EOF。
+//
+// 调用者应该总是先处理返回的 n > 0 个字节，然后再考虑错误 err。这样做可以正确地处理在读取
+// 一些字节后发生的 I/O 错误，同时也能正确地处理两种被允许的 EOF 行为。
+//
+// 不鼓励 Read 的实现在返回零字节数的同时返回 nil 错误，除非 len(p) == 0。
+// 调用者应该将返回 0 和 nil 视为什么都没有发生，特别是它并不表示 EOF。
+//
+// 实现不能保留 p。
+// IMP: 正确的调用方式如下所示
+// n, err := r.Read(p)
+// process(p[:n]) // 先处理数据
+// if err != nil { ... } // 再处理错误
 type Reader interface {
 	Read(p []byte) (n int, err error)
 }
@@ -87,6 +137,14 @@ type Reader interface {
 // Write must not modify the slice data, even temporarily.
 //
 // Implementations must not retain p.
+//
+// Writer 是包装了基础 Write 方法的接口。
+//
+// Write 将 p 中的 len(p) 个字节写入到底层数据流中。它返回从 p 中写入的字节数
+// （0 <= n <= len(p)）以及导致写入提前停止的任何错误。如果 Write 返回的 n < len(p)，
+// 它必须返回一个非 nil 的错误。Write 不能修改切片中的数据，即使是暂时的。
+//
+// 实现不能保留 p。
 type Writer interface {
 	Write(p []byte) (n int, err error)
 }
@@ -95,6 +153,10 @@ type Writer interface {
 //
 // The behavior of Close after the first call is undefined.
 // Specific implementations may document their own behavior.
+//
+// Closer 是包装了基础 Close 方法的接口。
+//
+// 第一次调用之后再调用 Close 的行为是未定义的。具体的实现可以在文档中说明它们自己的行为。
 type Closer interface {
 	Close() error
 }
@@ -112,29 +174,47 @@ type Closer interface {
 // Seeking to an offset before the start of the file is an error.
 // Seeking to any positive offset is legal, but the behavior of subsequent
 // I/O operations on the underlying object is implementation-dependent.
+//
+// Seeker 是包装了基础 Seek 方法的接口。
+//
+// Seek 将下一次 Read 或 Write 的偏移量设置为 offset，offset 根据 whence 来解释：
+// SeekStart 表示相对于文件的起始位置，SeekCurrent 表示相对于当前的偏移量，
+// SeekEnd 表示相对于文件的末尾。
+// Seek 返回相对于文件起始位置的新偏移量以及可能出现的错误。
+//
+// 移动到文件起始位置之前的偏移量是一个错误。移动到任何正的偏移量都是合法的，但是后续对底层
+// 对象的 I/O 操作的行为取决于具体实现。
 type Seeker interface {
 	Seek(offset int64, whence int) (int64, error)
 }
 
 // ReadWriter is the interface that groups the basic Read and Write methods.
+//
+// ReadWriter 是组合了基础 Read 和 Write 方法的接口。
 type ReadWriter interface {
 	Reader
 	Writer
 }
 
 // ReadCloser is the interface that groups the basic Read and Close methods.
+//
+// ReadCloser 是组合了基础 Read 和 Close 方法的接口。
 type ReadCloser interface {
 	Reader
 	Closer
 }
 
 // WriteCloser is the interface that groups the basic Write and Close methods.
+//
+// WriteCloser 是组合了基础 Write 和 Close 方法的接口。
 type WriteCloser interface {
 	Writer
 	Closer
 }
 
 // ReadWriteCloser is the interface that groups the basic Read, Write and Close methods.
+//
+// ReadWriteCloser 是组合了基础 Read、Write 和 Close 方法的接口。
 type ReadWriteCloser interface {
 	Reader
 	Writer
@@ -142,18 +222,24 @@ type ReadWriteCloser interface {
 }
 
 // ReadSeeker is the interface that groups the basic Read and Seek methods.
+//
+// ReadSeeker 是组合了基础 Read 和 Seek 方法的接口。
 type ReadSeeker interface {
 	Reader
 	Seeker
 }
 
 // WriteSeeker is the interface that groups the basic Write and Seek methods.
+//
+// WriteSeeker 是组合了基础 Write 和 Seek 方法的接口。
 type WriteSeeker interface {
 	Writer
 	Seeker
 }
 
 // ReadWriteSeeker is the interface that groups the basic Read, Write and Seek methods.
+//
+// ReadWriteSeeker 是组合了基础 Read、Write 和 Seek 方法的接口。
 type ReadWriteSeeker interface {
 	Reader
 	Writer
@@ -167,6 +253,13 @@ type ReadWriteSeeker interface {
 // Any error except io.EOF encountered during the read is also returned.
 //
 // The Copy function uses ReaderFrom if available.
+//
+// ReaderFrom 是包装了 ReadFrom 方法的接口。
+//
+// ReadFrom 从 r 中读取数据直到 EOF 或出现错误。
+// 返回值 n 是读取的字节数。读取期间遇到的除 io.EOF 以外的任何错误也会被返回。
+//
+// 如果可用，Copy 函数会使用 ReaderFrom。
 type ReaderFrom interface {
 	ReadFrom(r Reader) (n int64, err error)
 }
@@ -178,6 +271,13 @@ type ReaderFrom interface {
 // written. Any error encountered during the write is also returned.
 //
 // The Copy function uses WriterTo if available.
+//
+// WriterTo 是包装了 WriteTo 方法的接口。
+//
+// WriteTo 将数据写入 w，直到没有更多的数据可写或者出现错误。返回值 n 是写入的字节数。
+// 写入期间遇到的任何错误也会被返回。
+//
+// 如果可用，Copy 函数会使用 WriterTo。
 type WriterTo interface {
 	WriteTo(w Writer) (n int64, err error)
 }
@@ -208,6 +308,28 @@ type WriterTo interface {
 // same input source.
 //
 // Implementations must not retain p.
+//
+// ReaderAt 是包装了基础 ReadAt 方法的接口。
+//
+// ReadAt 从底层输入源的偏移量 off 处开始读取 len(p) 个字节到 p 中。它返回读取的字节数
+// （0 <= n <= len(p)）以及遇到的任何错误。
+//
+// 当 ReadAt 返回的 n < len(p) 时，它会返回一个非 nil 的错误来解释为什么没有返回更多的字节。
+// 在这一点上，ReadAt 比 Read 更严格。
+//
+// 即使 ReadAt 返回的 n < len(p)，它也可能在调用期间将 p 全部用作暂存空间。如果有一些数据
+// 可用但不足 len(p) 个字节，ReadAt 会阻塞直到所有数据都可用或者出现错误。
+// 在这一点上，ReadAt 与 Read 不同。
+//
+// 如果 ReadAt 返回的 n = len(p) 个字节位于输入源的末尾，ReadAt 可能返回 err == EOF 或
+// err == nil。
+//
+// 如果 ReadAt 从一个带有偏移量的输入源中读取，ReadAt 既不应该影响底层的偏移量，也不应该
+// 被它影响。
+//
+// ReadAt 的调用者可以在同一个输入源上并行地调用 ReadAt。
+//
+// 实现不能保留 p。
 type ReaderAt interface {
 	ReadAt(p []byte, off int64) (n int, err error)
 }
@@ -227,6 +349,18 @@ type ReaderAt interface {
 // destination if the ranges do not overlap.
 //
 // Implementations must not retain p.
+//
+// WriterAt 是包装了基础 WriteAt 方法的接口。
+//
+// WriteAt 将 p 中的 len(p) 个字节写入到底层数据流的偏移量 off 处。它返回从 p 中写入的
+// 字节数（0 <= n <= len(p)）以及导致写入提前停止的任何错误。如果 WriteAt 返回的
+// n < len(p)，它必须返回一个非 nil 的错误。
+//
+// 如果 WriteAt 写入一个带有偏移量的目标，WriteAt 既不应该影响底层的偏移量，也不应该被它影响。
+//
+// 如果写入的范围不重叠，WriteAt 的调用者可以在同一个目标上并行地调用 WriteAt。
+//
+// 实现不能保留 p。
 type WriterAt interface {
 	WriteAt(p []byte, off int64) (n int, err error)
 }
@@ -236,6 +370,11 @@ type WriterAt interface {
 // ReadByte reads and returns the next byte from the input or
 // any error encountered. If ReadByte returns an error, no input
 // byte was consumed, and the returned byte value is undefined.
+//
+// ByteReader 是包装了 ReadByte 方法的接口。
+//
+// ReadByte 读取并返回输入中的下一个字节或者遇到的任何错误。如果 ReadByte 返回一个错误，
+// 则没有输入字节被消耗，并且返回的字节值是未定义的。
 type ByteReader interface {
 	ReadByte() (byte, error)
 }
@@ -247,12 +386,19 @@ type ByteReader interface {
 // as the previous call to ReadByte.
 // It may be an error to call UnreadByte twice without an intervening
 // call to ReadByte.
+//
+// ByteScanner 是在基础 ReadByte 方法上添加了 UnreadByte 方法的接口。
+//
+// UnreadByte 使下一次调用 ReadByte 返回与上一次调用 ReadByte 相同的字节。
+// 在两次调用 UnreadByte 之间没有调用 ReadByte 可能是一个错误。
 type ByteScanner interface {
 	ByteReader
 	UnreadByte() error
 }
 
 // ByteWriter is the interface that wraps the WriteByte method.
+//
+// ByteWriter 是包装了 WriteByte 方法的接口。
 type ByteWriter interface {
 	WriteByte(c byte) error
 }
@@ -262,6 +408,11 @@ type ByteWriter interface {
 // ReadRune reads a single UTF-8 encoded Unicode character
 // and returns the rune and its size in bytes. If no character is
 // available, err will be set.
+//
+// RuneReader 是包装了 ReadRune 方法的接口。
+//
+// ReadRune 读取单个 UTF-8 编码的 Unicode 字符，并返回该文字及其字节大小。如果没有可用的
+// 字符，将设置 err。
 type RuneReader interface {
 	ReadRune() (r rune, size int, err error)
 }
@@ -273,12 +424,19 @@ type RuneReader interface {
 // as the previous call to ReadRune.
 // It may be an error to call UnreadRune twice without an intervening
 // call to ReadRune.
+//
+// RuneScanner 是在基础 ReadRune 方法上添加了 UnreadRune 方法的接口。
+//
+// UnreadRune 使下一次调用 ReadRune 返回与上一次调用 ReadRune 相同的文字。
+// 在两次调用 UnreadRune 之间没有调用 ReadRune 可能是一个错误。
 type RuneScanner interface {
 	RuneReader
 	UnreadRune() error
 }
 
 // stringWriter is the interface that wraps the WriteString method.
+//
+// stringWriter 是包装了 WriteString 方法的接口。
 type stringWriter interface {
 	WriteString(s string) (n int, err error)
 }
@@ -286,6 +444,10 @@ type stringWriter interface {
 // WriteString writes the contents of the string s to w, which accepts a slice of bytes.
 // If w implements a WriteString method, it is invoked directly.
 // Otherwise, w.Write is called exactly once.
+//
+// WriteString 将字符串 s 的内容写入接受字节切片的 w 中。
+// 如果 w 实现了 WriteString 方法，则直接调用它。否则，w.Write 只会被调用一次。
+// IMP: bytes.Buffer 实现了 WriteString 方法，此时可以避免 []byte(s) 的转换带来的内存分配。
 func WriteString(w Writer, s string) (n int, err error) {
 	if sw, ok := w.(stringWriter); ok {
 		return sw.WriteString(s)
@@ -301,6 +463,14 @@ func WriteString(w Writer, s string) (n int, err error) {
 // If min is greater than the length of buf, ReadAtLeast returns ErrShortBuffer.
 // On return, n >= min if and only if err == nil.
 // If r returns an error having read at least min bytes, the error is dropped.
+//
+// ReadAtLeast 从 r 中读取数据到 buf 中，直到至少读取了 min 个字节。
+// 它返回复制的字节数，如果读取的字节数较少，还会返回一个错误。
+// 只有在没有读取任何字节时，错误才是 EOF。
+// 如果在读取少于 min 个字节后遇到 EOF，ReadAtLeast 返回 ErrUnexpectedEOF。
+// 如果 min 大于 buf 的长度，ReadAtLeast 返回 ErrShortBuffer。
+// 返回时，当且仅当 err == nil 时，n >= min。
+// 如果 r 在读取了至少 min 个字节后返回错误，该错误会被丢弃。
 func ReadAtLeast(r Reader, buf []byte, min int) (n int, err error) {
 	if len(buf) < min {
 		return 0, ErrShortBuffer
@@ -325,6 +495,13 @@ func ReadAtLeast(r Reader, buf []byte, min int) (n int, err error) {
 // ReadFull returns ErrUnexpectedEOF.
 // On return, n == len(buf) if and only if err == nil.
 // If r returns an error having read at least len(buf) bytes, the error is dropped.
+//
+// ReadFull 从 r 中精确地读取 len(buf) 个字节到 buf 中。
+// 它返回复制的字节数，如果读取的字节数较少，还会返回一个错误。
+// 只有在没有读取任何字节时，错误才是 EOF。
+// 如果在读取了部分字节但不是全部字节后遇到 EOF，ReadFull 返回 ErrUnexpectedEOF。
+// 返回时，当且仅当 err == nil 时，n == len(buf)。
+// 如果 r 在读取了至少 len(buf) 个字节后返回错误，该错误会被丢弃。
 func ReadFull(r Reader, buf []byte) (n int, err error) {
 	return ReadAtLeast(r, buf, len(buf))
 }
@@ -336,6 +513,13 @@ func ReadFull(r Reader, buf []byte) (n int, err error) {
 //
 // If dst implements the ReaderFrom interface,
 // the copy is implemented using it.
+//
+// CopyN 从 src 复制 n 个字节（或者直到出现错误）到 dst 中。
+// 它返回复制的字节数以及复制期间遇到的最早的错误。
+// 返回时，当且仅当 err == nil 时，written == n。
+//
+// 如果 dst 实现了 ReaderFrom 接口，则使用它来实现复制。
+// IMP: CopyN 是通过 LimitReader 包装 src 再调用 Copy 实现的。
 func CopyN(dst Writer, src Reader, n int64) (written int64, err error) {
 	written, err = Copy(dst, LimitReader(src, n))
 	if written == n {
@@ -343,6 +527,8 @@ func CopyN(dst Writer, src Reader, n int64) (written int64, err error) {
 	}
 	if written < n && err == nil {
 		// src stopped early; must have been EOF.
+		//
+		// src 提前停止了，一定是遇到了 EOF。
 		err = EOF
 	}
 	return
@@ -360,6 +546,15 @@ func CopyN(dst Writer, src Reader, n int64) (written int64, err error) {
 // the copy is implemented by calling src.WriteTo(dst).
 // Otherwise, if dst implements the ReaderFrom interface,
 // the copy is implemented by calling dst.ReadFrom(src).
+//
+// Copy 从 src 复制数据到 dst，直到 src 到达 EOF 或者出现错误。它返回复制的字节数以及
+// 复制期间遇到的第一个错误（如果有的话）。
+//
+// 成功的 Copy 返回 err == nil，而不是 err == EOF。
+// 因为 Copy 被定义为从 src 读取直到 EOF，所以它不会将 Read 返回的 EOF 视为需要报告的错误。
+//
+// 如果 src 实现了 WriterTo 接口，则通过调用 src.WriteTo(dst) 实现复制。
+// 否则，如果 dst 实现了 ReaderFrom 接口，则通过调用 dst.ReadFrom(src) 实现复制。
 func Copy(dst Writer, src Reader) (written int64, err error) {
 	return copyBuffer(dst, src, nil)
 }
@@ -368,6 +563,11 @@ func Copy(dst Writer, src Reader) (written int64, err error) {
 // provided buffer (if one is required) rather than allocating a
 // temporary one. If buf is nil, one is allocated; otherwise if it has
 // zero length, CopyBuffer panics.
+//
+// CopyBuffer 与 Copy 相同，只是它使用提供的缓冲区（如果需要的话）进行中转，而不是分配
+// 一个临时缓冲区。如果 buf 为 nil，则会分配一个缓冲区；否则如果它的长度为零，CopyBuffer
+// 会 panic。
+// IMP: 如果 src 实现了 WriterTo 或者 dst 实现了 ReaderFrom，buf 将不会被使用。
 func CopyBuffer(dst Writer, src Reader, buf []byte) (written int64, err error) {
 	if buf != nil && len(buf) == 0 {
 		panic("empty buffer in io.CopyBuffer")
@@ -377,17 +577,26 @@ func CopyBuffer(dst Writer, src Reader, buf []byte) (written int64, err error) {
 
 // copyBuffer is the actual implementation of Copy and CopyBuffer.
 // if buf is nil, one is allocated.
+//
+// copyBuffer 是 Copy 和 CopyBuffer 的实际实现。如果 buf 为 nil，则会分配一个缓冲区。
 func copyBuffer(dst Writer, src Reader, buf []byte) (written int64, err error) {
 	// If the reader has a WriteTo method, use it to do the copy.
 	// Avoids an allocation and a copy.
+	//
+	// 如果 reader 有 WriteTo 方法，使用它来进行复制。
+	// 避免了一次内存分配和一次复制。
 	if wt, ok := src.(WriterTo); ok {
 		return wt.WriteTo(dst)
 	}
 	// Similarly, if the writer has a ReadFrom method, use it to do the copy.
+	//
+	// 类似地，如果 writer 有 ReadFrom 方法，使用它来进行复制。
 	if rt, ok := dst.(ReaderFrom); ok {
 		return rt.ReadFrom(src)
 	}
 	if buf == nil {
+		// IMP: 默认使用 32KB 的缓冲区，如果 src 是 LimitedReader 并且剩余的字节数更少，
+		// 则只分配剩余字节数大小的缓冲区。
 		size := 32 * 1024
 		if l, ok := src.(*LimitedReader); ok && int64(size) > l.N {
 			if l.N < 1 {
@@ -400,6 +609,7 @@ func copyBuffer(dst Writer, src Reader, buf []byte) (written int64, err error) {
 	}
 	for {
 		nr, er := src.Read(buf)
+		// IMP: 先处理读取到的数据，再处理读取时的错误，这正是 Reader 文档中要求的。
 		if nr > 0 {
 			nw, ew := dst.Write(buf[0:nr])
 			if nw > 0 {
@@ -427,15 +637,24 @@ func copyBuffer(dst Writer, src Reader, buf []byte) (written int64, err error) {
 // LimitReader returns a Reader that reads from r
 // but stops with EOF after n bytes.
 // The underlying implementation is a *LimitedReader.
+//
+// LimitReader 返回一个从 r 中读取数据的 Reader，但是在读取 n 个字节后以 EOF 停止。
+// 底层实现是一个 *LimitedReader。
 func LimitReader(r Reader, n int64) Reader { return &LimitedReader{r, n} }
 
 // A LimitedReader reads from R but limits the amount of
 // data returned to just N bytes. Each call to Read
 // updates N to reflect the new amount remaining.
 // Read returns EOF when N <= 0 or when the underlying R returns EOF.
+//
+// LimitedReader 从 R 中读取数据，但是将返回的数据量限制为 N 个字节。每次调用 Read 都会
+// 更新 N 以反映新的剩余数据量。
+// 当 N <= 0 或者底层的 R 返回 EOF 时，Read 返回 EOF。
 type LimitedReader struct {
+	// 底层 reader
 	R Reader // underlying reader
-	N int64  // max bytes remaining
+	// 剩余的最大字节数
+	N int64 // max bytes remaining
 }
 
 func (l *LimitedReader) Read(p []byte) (n int, err error) {
@@ -452,12 +671,18 @@ func (l *LimitedReader) Read(p []byte) (n int, err error) {
 
 // NewSectionReader returns a SectionReader that reads from r
 // starting at offset off and stops with EOF after n bytes.
+//
+// NewSectionReader 返回一个 SectionReader，它从 r 的偏移量 off 处开始读取，并在读取
+// n 个字节后以 EOF 停止。
 func NewSectionReader(r ReaderAt, off int64, n int64) *SectionReader {
 	return &SectionReader{r, off, off, off + n}
 }
 
 // SectionReader implements Read, Seek, and ReadAt on a section
 // of an underlying ReaderAt.
+//
+// SectionReader 在底层 ReaderAt 的一个区段上实现了 Read、Seek 和 ReadAt。
+// IMP: 区段为 [base, limit)，off 为当前读取的位置，它们都是相对于底层 ReaderAt 的偏移量。
 type SectionReader struct {
 	r     ReaderAt
 	base  int64
@@ -515,6 +740,8 @@ func (s *SectionReader) ReadAt(p []byte, off int64) (n int, err error) {
 }
 
 // Size returns the size of the section in bytes.
+//
+// Size 返回区段的字节大小。
 func (s *SectionReader) Size() int64 { return s.limit - s.base }
 
 // TeeReader returns a Reader that writes to w what it reads from r.
@@ -522,6 +749,11 @@ func (s *SectionReader) Size() int64 { return s.limit - s.base }
 // corresponding writes to w. There is no internal buffering -
 // the write must complete before the read completes.
 // Any error encountered while writing is reported as a read error.
+//
+// TeeReader 返回一个 Reader，它将从 r 中读取的数据写入 w 中。
+// 通过它对 r 执行的所有读取都与对 w 的相应写入相匹配。它没有内部缓冲区 -- 写入必须在读取
+// 完成之前完成。写入时遇到的任何错误都会作为读取错误报告。
+// IMP: 与 Unix 中的 tee 命令类似。
 func TeeReader(r Reader, w Writer) Reader {
 	return &teeReader{r, w}
 }
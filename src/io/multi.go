@@ -17,6 +17,10 @@ type multiReader struct {
 func (mr *multiReader) Read(p []byte) (n int, err error) {
 	for len(mr.readers) > 0 {
 		// Optimization to flatten nested multiReaders (Issue 13558).
+		//
+		// 展平嵌套的 multiReader 的优化（Issue 13558）。
+		// IMP: 只剩下一个 reader 并且它也是 multiReader 时，直接使用它的 readers，
+		// 避免了层层嵌套调用 Read。
 		if len(mr.readers) == 1 {
 			if r, ok := mr.readers[0].(*multiReader); ok {
 				mr.readers = r.readers
@@ -27,12 +31,17 @@ func (mr *multiReader) Read(p []byte) (n int, err error) {
 		if err == EOF {
 			// Use eofReader instead of nil to avoid nil panic
 			// after performing flatten (Issue 18232).
+			//
+			// 使用 eofReader 而不是 nil，以避免在展平后出现 nil panic（Issue 18232）。
+			// 允许更早地进行垃圾回收
 			mr.readers[0] = eofReader{} // permit earlier GC
 			mr.readers = mr.readers[1:]
 		}
 		if n > 0 || err != EOF {
 			if err == EOF && len(mr.readers) > 0 {
 				// Don't return EOF yet. More readers remain.
+				//
+				// 还不能返回 EOF，还剩下更多的 reader。
 				err = nil
 			}
 			return
@@ -45,7 +54,12 @@ func (mr *multiReader) Read(p []byte) (n int, err error) {
 // the provided input readers. They're read sequentially. Once all
 // inputs have returned EOF, Read will return EOF.  If any of the readers
 // return a non-nil, non-EOF error, Read will return that error.
+//
+// MultiReader 返回一个 Reader，它是提供的输入 reader 的逻辑串联。它们会被顺序地读取。
+// 一旦所有输入都返回了 EOF，Read 将返回 EOF。如果任何一个 reader 返回了非 nil、非 EOF
+// 的错误，Read 将返回该错误。
 func MultiReader(readers ...Reader) Reader {
+	// IMP: 复制一份 readers，避免调用者之后修改切片影响到 multiReader。
 	r := make([]Reader, len(readers))
 	copy(r, readers)
 	return &multiReader{r}
@@ -72,6 +86,7 @@ func (t *multiWriter) Write(p []byte) (n int, err error) {
 var _ stringWriter = (*multiWriter)(nil)
 
 func (t *multiWriter) WriteString(s string) (n int, err error) {
+	// 当需要时再延迟初始化
 	var p []byte // lazily initialized if/when needed
 	for _, w := range t.writers {
 		if sw, ok := w.(stringWriter); ok {
@@ -99,7 +114,14 @@ func (t *multiWriter) WriteString(s string) (n int, err error) {
 // Each write is written to each listed writer, one at a time.
 // If a listed writer returns an error, that overall write operation
 // stops and returns the error; it does not continue down the list.
+//
+// MultiWriter 创建一个 writer，它将写入的数据复制到所有提供的 writer 中，类似于 Unix
+// 中的 tee(1) 命令。
+//
+// 每次写入都会被依次写入到列出的每个 writer 中。如果列出的某个 writer 返回了错误，则整个
+// 写入操作停止并返回该错误，它不会继续写入列表中剩下的 writer。
 func MultiWriter(writers ...Writer) Writer {
+	// IMP: 与 multiReader 类似，展平嵌套的 multiWriter。
 	allWriters := make([]Writer, 0, len(writers))
 	for _, w := range writers {
 		if mw, ok := w.(*multiWriter); ok {
@@ -4,6 +4,8 @@
 
 // Pipe adapter to connect code expecting an io.Reader
 // with code expecting an io.Writer.
+//
+// Pipe 适配器，用于连接期望 io.Reader 的代码和期望 io.Writer 的代码。
 
 package io
 
@@ -15,6 +17,11 @@ import (
 
 // atomicError is a type-safe atomic value for errors.
 // We use a struct{ error } to ensure consistent use of a concrete type.
+//
+// atomicError 是一个类型安全的错误原子值。
+// 我们使用 struct{ error } 来确保始终使用同一个具体类型。
+// IMP: atomic.Value 要求每次 Store 的值具体类型相同，而不同的 error 实现具体类型不同，
+// 所以需要用 struct{ error } 包装一层。
 type atomicError struct{ v atomic.Value }
 
 func (a *atomicError) Store(err error) {
@@ -26,14 +33,21 @@ func (a *atomicError) Load() error {
 }
 
 // ErrClosedPipe is the error used for read or write operations on a closed pipe.
+//
+// ErrClosedPipe 是在已关闭的管道上进行读或写操作时使用的错误。
 var ErrClosedPipe = errors.New("io: read/write on closed pipe")
 
 // A pipe is the shared pipe structure underlying PipeReader and PipeWriter.
+//
+// pipe 是 PipeReader 和 PipeWriter 底层共享的管道结构。
 type pipe struct {
+	// 串行化 Write 操作
 	wrMu sync.Mutex // Serializes Write operations
+	// IMP: wrCh 传递写入的数据，rdCh 传回读取的字节数。
 	wrCh chan []byte
 	rdCh chan int
 
+	// 保护 done 的关闭
 	once sync.Once // Protects closing done
 	done chan struct{}
 	rerr atomicError
@@ -41,6 +55,7 @@ type pipe struct {
 }
 
 func (p *pipe) Read(b []byte) (n int, err error) {
+	// IMP: 先检查管道是否已经关闭，避免在管道关闭后 select 随机选中 wrCh。
 	select {
 	case <-p.done:
 		return 0, p.readCloseError()
@@ -83,6 +98,8 @@ func (p *pipe) Write(b []byte) (n int, err error) {
 		defer p.wrMu.Unlock()
 	}
 
+	// IMP: once 保证即使 len(b) == 0 也会进行一次传递，使空写入也与一次读取相匹配。
+	// 一次写入的数据可能需要多次读取才能被完全消耗。
 	for once := true; once || len(b) > 0; once = false {
 		select {
 		case p.wrCh <- b:
@@ -114,6 +131,8 @@ func (p *pipe) CloseWrite(err error) error {
 }
 
 // A PipeReader is the read half of a pipe.
+//
+// PipeReader 是管道的读取端。
 type PipeReader struct {
 	p *pipe
 }
@@ -123,23 +142,33 @@ type PipeReader struct {
 // arrives or the write end is closed.
 // If the write end is closed with an error, that error is
 // returned as err; otherwise err is EOF.
+//
+// Read 实现了标准的 Read 接口：
+// 它从管道中读取数据，阻塞直到有写入者到来或者写入端被关闭。
+// 如果写入端以一个错误关闭，该错误将作为 err 返回；否则 err 为 EOF。
 func (r *PipeReader) Read(data []byte) (n int, err error) {
 	return r.p.Read(data)
 }
 
 // Close closes the reader; subsequent writes to the
 // write half of the pipe will return the error ErrClosedPipe.
+//
+// Close 关闭读取端，之后对管道写入端的写入将返回错误 ErrClosedPipe。
 func (r *PipeReader) Close() error {
 	return r.CloseWithError(nil)
 }
 
 // CloseWithError closes the reader; subsequent writes
 // to the write half of the pipe will return the error err.
+//
+// CloseWithError 关闭读取端，之后对管道写入端的写入将返回错误 err。
 func (r *PipeReader) CloseWithError(err error) error {
 	return r.p.CloseRead(err)
 }
 
 // A PipeWriter is the write half of a pipe.
+//
+// PipeWriter 是管道的写入端。
 type PipeWriter struct {
 	p *pipe
 }
@@ -149,12 +178,18 @@ type PipeWriter struct {
 // have consumed all the data or the read end is closed.
 // If the read end is closed with an error, that err is
 // returned as err; otherwise err is ErrClosedPipe.
+//
+// Write 实现了标准的 Write 接口：
+// 它将数据写入管道，阻塞直到一个或多个读取者消耗了所有的数据或者读取端被关闭。
+// 如果读取端以一个错误关闭，该错误将作为 err 返回；否则 err 为 ErrClosedPipe。
 func (w *PipeWriter) Write(data []byte) (n int, err error) {
 	return w.p.Write(data)
 }
 
 // Close closes the writer; subsequent reads from the
 // read half of the pipe will return no bytes and EOF.
+//
+// Close 关闭写入端，之后从管道读取端的读取将不返回任何字节并返回 EOF。
 func (w *PipeWriter) Close() error {
 	return w.CloseWithError(nil)
 }
@@ -164,6 +199,11 @@ func (w *PipeWriter) Close() error {
 // or EOF if err is nil.
 //
 // CloseWithError always returns nil.
+//
+// CloseWithError 关闭写入端，之后从管道读取端的读取将不返回任何字节并返回错误 err，
+// 如果 err 为 nil 则返回 EOF。
+//
+// CloseWithError 总是返回 nil。
 func (w *PipeWriter) CloseWithError(err error) error {
 	return w.p.CloseWrite(err)
 }
@@ -183,6 +223,17 @@ func (w *PipeWriter) CloseWithError(err error) error {
 // It is safe to call Read and Write in parallel with each other or with Close.
 // Parallel calls to Read and parallel calls to Write are also safe:
 // the individual calls will be gated sequentially.
+//
+// Pipe 创建一个同步的内存管道。
+// 它可以用于连接期望 io.Reader 的代码和期望 io.Writer 的代码。
+//
+// 管道上的 Read 和 Write 是一一匹配的，除非需要多次 Read 才能消耗一次 Write。也就是说，
+// 每次对 PipeWriter 的 Write 都会阻塞，直到它满足了 PipeReader 的一次或多次 Read，
+// 并且这些 Read 完全消耗了写入的数据。
+// 数据直接从 Write 复制到相应的 Read（或多次 Read），它没有内部缓冲区。
+//
+// 并行地调用 Read 和 Write，或者与 Close 并行地调用它们都是安全的。并行地调用 Read 和
+// 并行地调用 Write 也是安全的：每个调用会被依次执行。
 func Pipe() (*PipeReader, *PipeWriter) {
 	p := &pipe{
 		wrCh: make(chan []byte),
@@ -0,0 +1,44 @@
+// Copyright 2018 Li Zebang. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package reflect
+
+import _ "unsafe" // for go:linkname
+
+// errors_setAsHook is implemented in package errors. Package errors
+// cannot import reflect (reflect depends on errors through strconv),
+// so reflect installs the type checks that errors.As needs instead.
+//
+// errors_setAsHook 在 errors 包中实现。errors 包不能导入 reflect（reflect 通过 strconv
+// 依赖了 errors），所以由 reflect 来注册 errors.As 所需要的类型检查。
+//
+//go:linkname errors_setAsHook errors.setAsHook
+func errors_setAsHook(fn func(err error, target interface{}) bool)
+
+func init() {
+	errors_setAsHook(errorsAs)
+}
+
+var errorType = TypeOf((*error)(nil)).Elem()
+
+// errorsAs reports whether err is assignable to the value pointed to by
+// target and, if so, stores it there.
+//
+// errorsAs 报告 err 是否可以赋值给 target 指向的值，如果可以，则将 err 储存到该值中。
+func errorsAs(err error, target interface{}) bool {
+	val := ValueOf(target)
+	typ := val.Type()
+	if typ.Kind() != Ptr || val.IsNil() {
+		panic("errors: target must be a non-nil pointer")
+	}
+	targetType := typ.Elem()
+	if targetType.Kind() != Interface && !targetType.Implements(errorType) {
+		panic("errors: *target must be interface or implement error")
+	}
+	if TypeOf(err).AssignableTo(targetType) {
+		val.Elem().Set(ValueOf(err))
+		return true
+	}
+	return false
+}
@@ -3,6 +3,8 @@
 // license that can be found in the LICENSE file.
 
 // This file implements binary search.
+//
+// 此文件实现了二分查找。
 
 package sort
 
@@ -56,30 +58,60 @@ package sort
 //		fmt.Printf("Your number is %d.\n", answer)
 //	}
 //
+// Search 使用二分查找找到并返回 [0, n) 中使 f(i) 为 true 的最小索引 i，假设在 [0, n) 范围内，
+// f(i) == true 意味着 f(i+1) == true。也就是说，Search 要求 f 在输入范围 [0, n) 的某个前缀
+// （可能为空）上为 false，然后在剩余部分（可能为空）上为 true。Search 返回第一个为 true 的
+// 索引。如果没有这样的索引，Search 返回 n。（注意“未找到”时的返回值不是 -1，这与
+// strings.Index 等函数不同。）
+// Search 只会对 [0, n) 范围内的 i 调用 f(i)。
+//
+// Search 的一个常见用法是在有序的、可索引的数据结构（如数组或切片）中查找值 x 的索引 i。
+// 在这种情况下，参数 f（通常是一个闭包）捕获了要查找的值，以及数据结构的索引和排序方式。
+//
+// 例如，给定一个按升序排列的切片 data，调用
+// Search(len(data), func(i int) bool { return data[i] >= 23 }) 返回满足 data[i] >= 23 的
+// 最小索引 i。如果调用者想知道 23 是否在切片中，它必须单独检测 data[i] == 23。
+//
+// 查找按降序排列的数据时，应该使用 <= 运算符而不是 >= 运算符。
+//
+// 上面两个示例分别展示了在升序的 int 切片中查找 x，以及一个猜数字的小游戏。
 func Search(n int, f func(int) bool) int {
 	// Define f(-1) == false and f(n) == true.
 	// Invariant: f(i-1) == false, f(j) == true.
+	//
+	// 定义 f(-1) == false 以及 f(n) == true。
+	// 不变量：f(i-1) == false，f(j) == true。
 	i, j := 0, n
 	for i < j {
+		// 计算 h 时避免溢出
 		h := int(uint(i+j) >> 1) // avoid overflow when computing h
 		// i ≤ h < j
 		if !f(h) {
+			// 保持 f(i-1) == false
 			i = h + 1 // preserves f(i-1) == false
 		} else {
+			// 保持 f(j) == true
 			j = h // preserves f(j) == true
 		}
 	}
 	// i == j, f(i-1) == false, and f(j) (= f(i)) == true  =>  answer is i.
+	//
+	// i == j，f(i-1) == false，并且 f(j) (= f(i)) == true  =>  答案为 i。
 	return i
 }
 
 // Convenience wrappers for common cases.
+//
+// 常见情况下的便捷包装器。
 
 // SearchInts searches for x in a sorted slice of ints and returns the index
 // as specified by Search. The return value is the index to insert x if x is
 // not present (it could be len(a)).
 // The slice must be sorted in ascending order.
 //
+// SearchInts 在有序的 int 切片中查找 x，并返回 Search 所规定的索引。如果 x 不存在，
+// 返回值是插入 x 的索引（它可能是 len(a)）。
+// 切片必须按升序排列。
 func SearchInts(a []int, x int) int {
 	return Search(len(a), func(i int) bool { return a[i] >= x })
 }
@@ -89,6 +121,9 @@ func SearchInts(a []int, x int) int {
 // present (it could be len(a)).
 // The slice must be sorted in ascending order.
 //
+// SearchFloat64s 在有序的 float64 切片中查找 x，并返回 Search 所规定的索引。如果 x 不存在，
+// 返回值是插入 x 的索引（它可能是 len(a)）。
+// 切片必须按升序排列。
 func SearchFloat64s(a []float64, x float64) int {
 	return Search(len(a), func(i int) bool { return a[i] >= x })
 }
@@ -98,15 +133,24 @@ func SearchFloat64s(a []float64, x float64) int {
 // present (it could be len(a)).
 // The slice must be sorted in ascending order.
 //
+// SearchStrings 在有序的 string 切片中查找 x，并返回 Search 所规定的索引。如果 x 不存在，
+// 返回值是插入 x 的索引（它可能是 len(a)）。
+// 切片必须按升序排列。
 func SearchStrings(a []string, x string) int {
 	return Search(len(a), func(i int) bool { return a[i] >= x })
 }
 
 // Search returns the result of applying SearchInts to the receiver and x.
+//
+// Search 返回对接收者和 x 调用 SearchInts 的结果。
 func (p IntSlice) Search(x int) int { return SearchInts(p, x) }
 
 // Search returns the result of applying SearchFloat64s to the receiver and x.
+//
+// Search 返回对接收者和 x 调用 SearchFloat64s 的结果。
 func (p Float64Slice) Search(x float64) int { return SearchFloat64s(p, x) }
 
 // Search returns the result of applying SearchStrings to the receiver and x.
+//
+// Search 返回对接收者和 x 调用 SearchStrings 的结果。
 func (p StringSlice) Search(x string) int { return SearchStrings(p, x) }
@@ -14,6 +14,14 @@ import "reflect"
 // SliceStable.
 //
 // The function panics if the provided interface is not a slice.
+//
+// Slice 使用提供的 less 函数对提供的切片进行排序。
+//
+// 此排序不保证是稳定的。如果需要稳定排序，请使用 SliceStable。
+//
+// 如果提供的接口不是切片，此函数会 panic。
+// IMP: reflect.Swapper 根据切片元素的类型返回一个专门的交换函数，再通过 lessSwap 调用
+// zfuncversion.go 中的 quickSort_func，避免了为每个切片类型实现 Interface。
 func Slice(slice interface{}, less func(i, j int) bool) {
 	rv := reflect.ValueOf(slice)
 	swap := reflect.Swapper(slice)
@@ -25,6 +33,10 @@ func Slice(slice interface{}, less func(i, j int) bool) {
 // function while keeping the original order of equal elements.
 //
 // The function panics if the provided interface is not a slice.
+//
+// SliceStable 使用提供的 less 函数对提供的切片进行排序，同时保持相等元素的原始顺序。
+//
+// 如果提供的接口不是切片，此函数会 panic。
 func SliceStable(slice interface{}, less func(i, j int) bool) {
 	rv := reflect.ValueOf(slice)
 	swap := reflect.Swapper(slice)
@@ -34,6 +46,10 @@ func SliceStable(slice interface{}, less func(i, j int) bool) {
 // SliceIsSorted tests whether a slice is sorted.
 //
 // The function panics if the provided interface is not a slice.
+//
+// SliceIsSorted 检测切片是否已经排好序。
+//
+// 如果提供的接口不是切片，此函数会 panic。
 func SliceIsSorted(slice interface{}, less func(i, j int) bool) bool {
 	rv := reflect.ValueOf(slice)
 	n := rv.Len()
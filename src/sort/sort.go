@@ -6,22 +6,42 @@
 
 // Package sort provides primitives for sorting slices and user-defined
 // collections.
+//
+// Package sort 提供了对切片和用户自定义集合进行排序的原语。
+//
+// IMP: Sort 使用的是内省排序（introsort）
+// 1. 元素个数大于 12 时使用快速排序，基准值（pivot）通过三数取中或者 Tukey 的九数取中选取。
+// 2. 递归深度超过 maxDepth 时切换为堆排序，保证最坏情况下的时间复杂度为 O(n*log(n))。
+// 3. 元素个数小于等于 12 时先做一次间隔为 6 的希尔排序，再使用插入排序。
+// Stable 使用的是分块插入排序加上 SymMerge 原地归并，只需要 O(log(n)) 的额外栈空间。
 package sort
 
 // A type, typically a collection, that satisfies sort.Interface can be
 // sorted by the routines in this package. The methods require that the
 // elements of the collection be enumerated by an integer index.
+//
+// 满足 sort.Interface 的类型（通常是集合）可以被本包中的例程排序。这些方法要求集合中的元素
+// 可以通过整数索引进行枚举。
 type Interface interface {
 	// Len is the number of elements in the collection.
+	//
+	// Len 是集合中元素的个数。
 	Len() int
 	// Less reports whether the element with
 	// index i should sort before the element with index j.
+	//
+	// Less 报告索引为 i 的元素是否应该排在索引为 j 的元素之前。
 	Less(i, j int) bool
 	// Swap swaps the elements with indexes i and j.
+	//
+	// Swap 交换索引为 i 和 j 的元素。
 	Swap(i, j int)
 }
 
 // Insertion sort
+//
+// 插入排序
+// IMP: 将 data[i] 不断与前一个元素比较并交换，直到它到达正确的位置。
 func insertionSort(data Interface, a, b int) {
 	for i := a + 1; i < b; i++ {
 		for j := i; j > a && data.Less(j, j-1); j-- {
@@ -32,6 +52,11 @@ func insertionSort(data Interface, a, b int) {
 
 // siftDown implements the heap property on data[lo, hi).
 // first is an offset into the array where the root of the heap lies.
+//
+// siftDown 在 data[lo, hi) 上实现堆的性质。
+// first 是堆的根节点在数组中的偏移量。
+// IMP: 这是一个大顶堆，节点 root 的子节点为 2*root+1 和 2*root+2，root 不断与较大的子节点
+// 交换，直到它不小于两个子节点。
 func siftDown(data Interface, lo, hi, first int) {
 	root := lo
 	for {
@@ -50,17 +75,22 @@ func siftDown(data Interface, lo, hi, first int) {
 	}
 }
 
+// IMP: 堆排序，在快速排序递归过深时使用。
 func heapSort(data Interface, a, b int) {
 	first := a
 	lo := 0
 	hi := b - a
 
 	// Build heap with greatest element at top.
+	//
+	// 构建最大元素在顶部的堆。
 	for i := (hi - 1) / 2; i >= 0; i-- {
 		siftDown(data, i, hi, first)
 	}
 
 	// Pop elements, largest first, into end of data.
+	//
+	// 将元素按从大到小的顺序弹出，放到 data 的末尾。
 	for i := hi - 1; i >= 0; i-- {
 		data.Swap(first, first+i)
 		siftDown(data, lo, i, first)
@@ -69,10 +99,18 @@ func heapSort(data Interface, a, b int) {
 
 // Quicksort, loosely following Bentley and McIlroy,
 // ``Engineering a Sort Function,'' SP&E November 1993.
+//
+// 快速排序，大致遵循 Bentley 和 McIlroy 的 ``Engineering a Sort Function,''
+// SP&E November 1993。
 
 // medianOfThree moves the median of the three values data[m0], data[m1], data[m2] into data[m1].
+//
+// medianOfThree 将 data[m0]、data[m1]、data[m2] 三个值的中位数移动到 data[m1]。
+// NOTE: 参数的顺序是 m1, m0, m2，调用时 m1 是中间位置。
 func medianOfThree(data Interface, m1, m0, m2 int) {
 	// sort 3 elements
+	//
+	// 对 3 个元素排序
 	if data.Less(m1, m0) {
 		data.Swap(m1, m0)
 	}
@@ -93,10 +131,16 @@ func swapRange(data Interface, a, b, n int) {
 	}
 }
 
+// IMP: doPivot 将 data[lo:hi] 划分为三部分，并返回中间部分的边界
+// data[lo:midlo] < pivot，data[midlo:midhi] = pivot，data[midhi:hi] > pivot。
+// 当存在大量重复元素时，中间部分可以直接跳过，避免了退化。
 func doPivot(data Interface, lo, hi int) (midlo, midhi int) {
+	// 写成这种形式是为了避免整数溢出。
 	m := int(uint(lo+hi) >> 1) // Written like this to avoid integer overflow.
 	if hi-lo > 40 {
 		// Tukey's ``Ninther,'' median of three medians of three.
+		//
+		// Tukey 的九数取中，即三组三数中位数的中位数。
 		s := (hi - lo) / 8
 		medianOfThree(data, lo, lo+s, lo+2*s)
 		medianOfThree(data, m, m-s, m+s)
@@ -111,6 +155,14 @@ func doPivot(data Interface, lo, hi int) (midlo, midhi int) {
 	//	data[b <= i < c] unexamined
 	//	data[c <= i < hi-1] > pivot
 	//	data[hi-1] >= pivot
+	//
+	// 不变量为：
+	//	data[lo] = pivot（由 ChoosePivot 设置）
+	//	data[lo < i < a] < pivot
+	//	data[a <= i < b] <= pivot
+	//	data[b <= i < c] 未检查
+	//	data[c <= i < hi-1] > pivot
+	//	data[hi-1] >= pivot
 	pivot := lo
 	a, c := lo+1, hi-1
 
@@ -132,9 +184,14 @@ func doPivot(data Interface, lo, hi int) (midlo, midhi int) {
 	}
 	// If hi-c<3 then there are duplicates (by property of median of nine).
 	// Let be a bit more conservative, and set border to 5.
+	//
+	// 如果 hi-c<3，则存在重复元素（由九数取中的性质可知）。
+	// 保守一点，将边界设置为 5。
 	protect := hi-c < 5
 	if !protect && hi-c < (hi-lo)/4 {
 		// Lets test some points for equality to pivot
+		//
+		// 检测一些点是否与 pivot 相等
 		dups := 0
 		if !data.Less(pivot, hi-1) { // data[hi-1] = pivot
 			data.Swap(c, hi-1)
@@ -154,6 +211,8 @@ func doPivot(data Interface, lo, hi int) (midlo, midhi int) {
 			dups++
 		}
 		// if at least 2 points are equal to pivot, assume skewed distribution
+		//
+		// 如果至少有 2 个点与 pivot 相等，则假设数据分布是倾斜的
 		protect = dups > 1
 	}
 	if protect {
@@ -161,6 +220,11 @@ func doPivot(data Interface, lo, hi int) (midlo, midhi int) {
 		// Add invariant:
 		//	data[a <= i < b] unexamined
 		//	data[b <= i < c] = pivot
+		//
+		// 防止出现大量重复元素
+		// 添加不变量：
+		//	data[a <= i < b] 未检查
+		//	data[b <= i < c] = pivot
 		for {
 			for ; a < b && !data.Less(b-1, pivot); b-- { // data[b] == pivot
 			}
@@ -176,12 +240,16 @@ func doPivot(data Interface, lo, hi int) (midlo, midhi int) {
 		}
 	}
 	// Swap pivot into middle
+	//
+	// 将 pivot 交换到中间
 	data.Swap(pivot, b-1)
 	return b - 1, c
 }
 
 func quickSort(data Interface, a, b, maxDepth int) {
+	// 元素个数小于等于 12 的切片使用希尔排序
 	for b-a > 12 { // Use ShellSort for slices <= 12 elements
+		// IMP: 递归深度用尽，说明快速排序可能已经退化，切换为堆排序。
 		if maxDepth == 0 {
 			heapSort(data, a, b)
 			return
@@ -190,6 +258,9 @@ func quickSort(data Interface, a, b, maxDepth int) {
 		mlo, mhi := doPivot(data, a, b)
 		// Avoiding recursion on the larger subproblem guarantees
 		// a stack depth of at most lg(b-a).
+		//
+		// 避免在较大的子问题上递归，保证栈的深度最多为 lg(b-a)。
+		// IMP: 较小的子问题递归处理，较大的子问题通过修改 a 或 b 在循环中处理（尾递归消除）。
 		if mlo-a < b-mhi {
 			quickSort(data, a, mlo, maxDepth)
 			a = mhi // i.e., quickSort(data, mhi, b)
@@ -201,6 +272,9 @@ func quickSort(data Interface, a, b, maxDepth int) {
 	if b-a > 1 {
 		// Do ShellSort pass with gap 6
 		// It could be written in this simplified form cause b-a <= 12
+		//
+		// 进行一次间隔为 6 的希尔排序
+		// 因为 b-a <= 12，所以可以写成这种简化的形式
 		for i := a + 6; i < b; i++ {
 			if data.Less(i, i-6) {
 				data.Swap(i, i-6)
@@ -213,6 +287,10 @@ func quickSort(data Interface, a, b, maxDepth int) {
 // Sort sorts data.
 // It makes one call to data.Len to determine n, and O(n*log(n)) calls to
 // data.Less and data.Swap. The sort is not guaranteed to be stable.
+//
+// Sort 对 data 进行排序。
+// 它调用一次 data.Len 来确定 n，并调用 O(n*log(n)) 次 data.Less 和 data.Swap。此排序不保证
+// 是稳定的。
 func Sort(data Interface) {
 	n := data.Len()
 	quickSort(data, 0, n, maxDepth(n))
@@ -220,6 +298,8 @@ func Sort(data Interface) {
 
 // maxDepth returns a threshold at which quicksort should switch
 // to heapsort. It returns 2*ceil(lg(n+1)).
+//
+// maxDepth 返回快速排序应该切换为堆排序的阈值。它返回 2*ceil(lg(n+1))。
 func maxDepth(n int) int {
 	var depth int
 	for i := n; i > 0; i >>= 1 {
@@ -231,6 +311,11 @@ func maxDepth(n int) int {
 // lessSwap is a pair of Less and Swap function for use with the
 // auto-generated func-optimized variant of sort.go in
 // zfuncversion.go.
+//
+// lessSwap 是一对 Less 和 Swap 函数，供 zfuncversion.go 中自动生成的、针对函数优化的
+// sort.go 变体使用。
+// IMP: zfuncversion.go 由 genzfunc.go 生成，它将本文件中的 data Interface 替换为
+// data lessSwap，从而避免了 Slice 中接口方法调用的开销。
 type lessSwap struct {
 	Less func(i, j int) bool
 	Swap func(i, j int)
@@ -239,20 +324,29 @@ type lessSwap struct {
 type reverse struct {
 	// This embedded Interface permits Reverse to use the methods of
 	// another Interface implementation.
+	//
+	// 嵌入的 Interface 使 Reverse 可以使用另一个 Interface 实现的方法。
 	Interface
 }
 
 // Less returns the opposite of the embedded implementation's Less method.
+//
+// Less 返回与嵌入实现的 Less 方法相反的结果。
+// IMP: 只需要交换参数 i 和 j 即可，Len 和 Swap 直接使用嵌入的 Interface。
 func (r reverse) Less(i, j int) bool {
 	return r.Interface.Less(j, i)
 }
 
 // Reverse returns the reverse order for data.
+//
+// Reverse 返回 data 的逆序。
 func Reverse(data Interface) Interface {
 	return &reverse{data}
 }
 
 // IsSorted reports whether data is sorted.
+//
+// IsSorted 报告 data 是否已经排好序。
 func IsSorted(data Interface) bool {
 	n := data.Len()
 	for i := n - 1; i > 0; i-- {
@@ -264,8 +358,12 @@ func IsSorted(data Interface) bool {
 }
 
 // Convenience types for common cases
+//
+// 常见情况下的便捷类型
 
 // IntSlice attaches the methods of Interface to []int, sorting in increasing order.
+//
+// IntSlice 为 []int 附加了 Interface 的方法，按升序排序。
 type IntSlice []int
 
 func (p IntSlice) Len() int           { return len(p) }
@@ -273,10 +371,15 @@ func (p IntSlice) Less(i, j int) bool { return p[i] < p[j] }
 func (p IntSlice) Swap(i, j int)      { p[i], p[j] = p[j], p[i] }
 
 // Sort is a convenience method.
+//
+// Sort 是一个便捷方法。
 func (p IntSlice) Sort() { Sort(p) }
 
 // Float64Slice attaches the methods of Interface to []float64, sorting in increasing order
 // (not-a-number values are treated as less than other values).
+//
+// Float64Slice 为 []float64 附加了 Interface 的方法，按升序排序（非数字值被视为小于其他值）。
+// IMP: NaN 与任何值比较都为 false，所以 Less 需要特殊处理 NaN，否则排序结果是不确定的。
 type Float64Slice []float64
 
 func (p Float64Slice) Len() int           { return len(p) }
@@ -284,14 +387,20 @@ func (p Float64Slice) Less(i, j int) bool { return p[i] < p[j] || isNaN(p[i]) &&
 func (p Float64Slice) Swap(i, j int)      { p[i], p[j] = p[j], p[i] }
 
 // isNaN is a copy of math.IsNaN to avoid a dependency on the math package.
+//
+// isNaN 是 math.IsNaN 的副本，以避免对 math 包的依赖。
 func isNaN(f float64) bool {
 	return f != f
 }
 
 // Sort is a convenience method.
+//
+// Sort 是一个便捷方法。
 func (p Float64Slice) Sort() { Sort(p) }
 
 // StringSlice attaches the methods of Interface to []string, sorting in increasing order.
+//
+// StringSlice 为 []string 附加了 Interface 的方法，按升序排序。
 type StringSlice []string
 
 func (p StringSlice) Len() int           { return len(p) }
@@ -299,28 +408,44 @@ func (p StringSlice) Less(i, j int) bool { return p[i] < p[j] }
 func (p StringSlice) Swap(i, j int)      { p[i], p[j] = p[j], p[i] }
 
 // Sort is a convenience method.
+//
+// Sort 是一个便捷方法。
 func (p StringSlice) Sort() { Sort(p) }
 
 // Convenience wrappers for common cases
+//
+// 常见情况下的便捷包装器
 
 // Ints sorts a slice of ints in increasing order.
+//
+// Ints 将 int 切片按升序排序。
 func Ints(a []int) { Sort(IntSlice(a)) }
 
 // Float64s sorts a slice of float64s in increasing order
 // (not-a-number values are treated as less than other values).
+//
+// Float64s 将 float64 切片按升序排序（非数字值被视为小于其他值）。
 func Float64s(a []float64) { Sort(Float64Slice(a)) }
 
 // Strings sorts a slice of strings in increasing order.
+//
+// Strings 将 string 切片按升序排序。
 func Strings(a []string) { Sort(StringSlice(a)) }
 
 // IntsAreSorted tests whether a slice of ints is sorted in increasing order.
+//
+// IntsAreSorted 检测 int 切片是否按升序排好序。
 func IntsAreSorted(a []int) bool { return IsSorted(IntSlice(a)) }
 
 // Float64sAreSorted tests whether a slice of float64s is sorted in increasing order
 // (not-a-number values are treated as less than other values).
+//
+// Float64sAreSorted 检测 float64 切片是否按升序排好序（非数字值被视为小于其他值）。
 func Float64sAreSorted(a []float64) bool { return IsSorted(Float64Slice(a)) }
 
 // StringsAreSorted tests whether a slice of strings is sorted in increasing order.
+//
+// StringsAreSorted 检测 string 切片是否按升序排好序。
 func StringsAreSorted(a []string) bool { return IsSorted(StringSlice(a)) }
 
 // Notes on stable sorting:
@@ -353,11 +478,19 @@ func StringsAreSorted(a []string) bool { return IsSorted(StringSlice(a)) }
 //
 // It makes one call to data.Len to determine n, O(n*log(n)) calls to
 // data.Less and O(n*log(n)*log(n)) calls to data.Swap.
+//
+// Stable 对 data 进行排序，同时保持相等元素的原始顺序。
+//
+// 它调用一次 data.Len 来确定 n，调用 O(n*log(n)) 次 data.Less 以及 O(n*log(n)*log(n)) 次
+// data.Swap。
 func Stable(data Interface) {
 	stable(data, data.Len())
 }
 
+// IMP: 先将 data 分成大小为 20 的块，对每块进行插入排序（插入排序是稳定的），然后两两归并
+// 相邻的块，每轮归并后块的大小翻倍，直到整个 data 有序。
 func stable(data Interface, n int) {
+	// 必须大于 0
 	blockSize := 20 // must be > 0
 	a, b := 0, blockSize
 	for b <= n {
@@ -400,14 +533,36 @@ func stable(data Interface, n int) {
 // symMerge assumes non-degenerate arguments: a < m && m < b.
 // Having the caller check this condition eliminates many leaf recursion calls,
 // which improves performance.
+//
+// SymMerge 使用 Pok-Son Kim 和 Arne Kutzner 的 SymMerge 算法合并两个已经排好序的子序列
+// data[a:m] 和 data[m:b]，论文信息见上。
+//
+// 令 M = m-a，N = b-n。不失一般性，设 M < N。
+// 递归深度的上界为 ceil(log(N+M))。
+// 此算法需要调用 O(M*log(N/M + 1)) 次 data.Less。
+// 此算法需要调用 O((M+N)*log(M)) 次 data.Swap。
+//
+// 论文中给出的赋值次数为 O((M+N)*log(M))，它假设使用的旋转算法需要 O(M+N+gcd(M+N)) 次
+// 赋值。论文中的论证对 Swap 操作同样成立，特别是块交换旋转只需要 O(M+N) 次 Swap。
+//
+// symMerge 假设参数不是退化的：a < m && m < b。
+// 由调用者检查此条件可以消除许多叶子递归调用，从而提高性能。
+// IMP: 核心思想是找到一个分割点 start，将 data[start:m] 和 data[m:end] 旋转交换，使得
+// 左半部分的元素都不大于右半部分的元素，然后对两边分别递归合并。
 func symMerge(data Interface, a, m, b int) {
 	// Avoid unnecessary recursions of symMerge
 	// by direct insertion of data[a] into data[m:b]
 	// if data[a:m] only contains one element.
+	//
+	// 如果 data[a:m] 只包含一个元素，则直接将 data[a] 插入到 data[m:b] 中，避免不必要的
+	// symMerge 递归。
 	if m-a == 1 {
 		// Use binary search to find the lowest index i
 		// such that data[i] >= data[a] for m <= i < b.
 		// Exit the search loop with i == b in case no such index exists.
+		//
+		// 使用二分查找找到满足 data[i] >= data[a] 的最小索引 i，其中 m <= i < b。
+		// 如果不存在这样的索引，则在 i == b 时退出查找循环。
 		i := m
 		j := b
 		for i < j {
@@ -419,6 +574,8 @@ func symMerge(data Interface, a, m, b int) {
 			}
 		}
 		// Swap values until data[a] reaches the position before i.
+		//
+		// 交换值，直到 data[a] 到达 i 之前的位置。
 		for k := a; k < i-1; k++ {
 			data.Swap(k, k+1)
 		}
@@ -428,10 +585,17 @@ func symMerge(data Interface, a, m, b int) {
 	// Avoid unnecessary recursions of symMerge
 	// by direct insertion of data[m] into data[a:m]
 	// if data[m:b] only contains one element.
+	//
+	// 如果 data[m:b] 只包含一个元素，则直接将 data[m] 插入到 data[a:m] 中，避免不必要的
+	// symMerge 递归。
 	if b-m == 1 {
 		// Use binary search to find the lowest index i
 		// such that data[i] > data[m] for a <= i < m.
 		// Exit the search loop with i == m in case no such index exists.
+		//
+		// 使用二分查找找到满足 data[i] > data[m] 的最小索引 i，其中 a <= i < m。
+		// 如果不存在这样的索引，则在 i == m 时退出查找循环。
+		// IMP: 这里使用 > 而不是 >=，这样相等的元素中 data[m] 会排在后面，保证了稳定性。
 		i := a
 		j := m
 		for i < j {
@@ -443,6 +607,8 @@ func symMerge(data Interface, a, m, b int) {
 			}
 		}
 		// Swap values until data[m] reaches the position i.
+		//
+		// 交换值，直到 data[m] 到达位置 i。
 		for k := m; k > i; k-- {
 			data.Swap(k, k-1)
 		}
@@ -486,6 +652,12 @@ func symMerge(data Interface, a, m, b int) {
 // Data of the form 'x u v y' is changed to 'x v u y'.
 // Rotate performs at most b-a many calls to data.Swap.
 // Rotate assumes non-degenerate arguments: a < m && m < b.
+//
+// 旋转 data 中两个连续的块 u = data[a:m] 和 v = data[m:b]：
+// 将 'x u v y' 形式的数据变为 'x v u y'。
+// Rotate 最多调用 b-a 次 data.Swap。
+// Rotate 假设参数不是退化的：a < m && m < b。
+// IMP: 类似于求最大公约数的辗转相减法，每次将较短的块交换到它的最终位置。
 func rotate(data Interface, a, m, b int) {
 	i := m - a
 	j := b - m
@@ -7,6 +7,10 @@ package strconv
 // ParseBool returns the boolean value represented by the string.
 // It accepts 1, t, T, TRUE, true, True, 0, f, F, FALSE, false, False.
 // Any other value returns an error.
+//
+// ParseBool 返回字符串所表示的布尔值。
+// 它接受 1、t、T、TRUE、true、True、0、f、F、FALSE、false、False。
+// 其他任何值都会返回错误。
 func ParseBool(str string) (bool, error) {
 	switch str {
 	case "1", "t", "T", "true", "TRUE", "True":
@@ -18,6 +22,8 @@ func ParseBool(str string) (bool, error) {
 }
 
 // FormatBool returns "true" or "false" according to the value of b.
+//
+// FormatBool 根据 b 的值返回 "true" 或 "false"。
 func FormatBool(b bool) string {
 	if b {
 		return "true"
@@ -27,6 +33,8 @@ func FormatBool(b bool) string {
 
 // AppendBool appends "true" or "false", according to the value of b,
 // to dst and returns the extended buffer.
+//
+// AppendBool 根据 b 的值将 "true" 或 "false" 追加到 dst 中，并返回扩展后的缓冲区。
 func AppendBool(dst []byte, b bool) []byte {
 	if b {
 		return append(dst, "true"...)
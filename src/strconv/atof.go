@@ -9,11 +9,28 @@ package strconv
 //   1) Store input in multiprecision decimal.
 //   2) Multiply/divide decimal by powers of two until in range [0.5, 1)
 //   3) Multiply by 2^precision and round to get mantissa.
+//
+// 十进制到二进制的浮点数转换。
+// 算法：
+//   1) 将输入储存在多精度十进制数中。
+//   2) 将十进制数乘以/除以 2 的幂，直到它在区间 [0.5, 1) 中
+//   3) 乘以 2^precision 并舍入得到尾数。
+//
+// IMP: 上面是最慢但总是正确的 decimal.floatBits 的算法。atof64 和 atof32 依次尝试三种方法：
+//
+//	1) atof64exact：尾数和 10 的幂都能被浮点数准确表示时，一次浮点乘除法就能得到正确舍入的结果；
+//	2) extFloat.AssignDecimal：使用 64 位尾数的扩展精度浮点数计算，误差足够小时结果是正确的；
+//	3) decimal.floatBits：多精度十进制计算，总是正确。
 
 import "math"
 
+// 测试时可以修改
 var optimize = true // can change for testing
 
+// equalIgnoreCase reports whether the ASCII strings s1 and s2 are equal
+// ignoring case.
+//
+// equalIgnoreCase 报告 ASCII 字符串 s1 和 s2 在忽略大小写时是否相等。
 func equalIgnoreCase(s1, s2 string) bool {
 	if len(s1) != len(s2) {
 		return false
@@ -34,6 +51,10 @@ func equalIgnoreCase(s1, s2 string) bool {
 	return true
 }
 
+// special parses the special values "inf", "infinity" (with an optional
+// sign) and "nan", ignoring case.
+//
+// special 解析特殊值 "inf"、"infinity"（带有可选的符号）和 "nan"，忽略大小写。
 func special(s string) (f float64, ok bool) {
 	if len(s) == 0 {
 		return
@@ -154,6 +175,12 @@ func (b *decimal) set(s string) (ok bool) {
 // readFloat reads a decimal mantissa and exponent from a float
 // string representation. It sets ok to false if the number could
 // not fit return types or is invalid.
+//
+// readFloat 从浮点数的字符串表示中读取十进制尾数和指数。如果这个数无法放进返回类型或者是
+// 无效的，它将 ok 设置为 false。
+//
+// IMP: 尾数最多保留 19 位十进制数字（uint64 能准确表示的位数），更多的非零数字会被丢弃并将
+// trunc 置为 true，此时只能使用较慢的算法。
 func readFloat(s string) (mantissa uint64, exp int, neg, trunc, ok bool) {
 	const uint64digits = 19
 	i := 0
@@ -253,6 +280,10 @@ func readFloat(s string) (mantissa uint64, exp int, neg, trunc, ok bool) {
 }
 
 // decimal power of ten to binary power of two.
+//
+// 十进制的 10 的幂到二进制的 2 的幂的映射。
+// IMP: powtab[n] 约等于 log2(10^n)，floatBits 根据小数点的位置 dp 查表，决定每次将十进制数
+// 移动多少个二进制位。
 var powtab = []int{1, 3, 6, 9, 13, 16, 19, 23, 26}
 
 func (d *decimal) floatBits(flt *floatInfo) (b uint64, overflow bool) {
@@ -354,6 +385,10 @@ out:
 }
 
 // Exact powers of 10.
+//
+// 准确的 10 的幂。
+// IMP: float64 有 52 位尾数，10^22 = 2^22 * 5^22，而 5^22 < 2^53，所以 10^22 是 float64 能
+// 准确表示的最大的 10 的幂；同理 10^10 是 float32 能准确表示的最大的 10 的幂。
 var float64pow10 = []float64{
 	1e0, 1e1, 1e2, 1e3, 1e4, 1e5, 1e6, 1e7, 1e8, 1e9,
 	1e10, 1e11, 1e12, 1e13, 1e14, 1e15, 1e16, 1e17, 1e18, 1e19,
@@ -368,6 +403,17 @@ var float32pow10 = []float32{1e0, 1e1, 1e2, 1e3, 1e4, 1e5, 1e6, 1e7, 1e8, 1e9, 1
 //	value is exact integer * exact power of ten
 //	value is exact integer / exact power of ten
 // These all produce potentially inexact but correctly rounded answers.
+//
+// 如果能够完全使用浮点运算将十进制表示准确地转换为 64 位浮点数 f，就这样做，以避免
+// decimalToFloatBits 的开销。
+// 三种常见的情况：
+//
+//	值是准确的整数
+//	值是准确的整数 * 准确的 10 的幂
+//	值是准确的整数 / 准确的 10 的幂
+//
+// 它们都会产生可能不准确但正确舍入的结果。
+// IMP: 两个准确表示的浮点数做一次乘法或除法，IEEE 754 保证结果是正确舍入的。
 func atof64exact(mantissa uint64, exp int, neg bool) (f float64, ok bool) {
 	if mantissa>>float64info.mantbits != 0 {
 		return
@@ -379,18 +425,27 @@ func atof64exact(mantissa uint64, exp int, neg bool) (f float64, ok bool) {
 	switch {
 	case exp == 0:
 		// an integer.
+		//
+		// 一个整数。
 		return f, true
 	// Exact integers are <= 10^15.
 	// Exact powers of ten are <= 10^22.
+	//
+	// 准确的整数 <= 10^15。
+	// 准确的 10 的幂 <= 10^22。
 	case exp > 0 && exp <= 15+22: // int * 10^k
 		// If exponent is big but number of digits is not,
 		// can move a few zeros into the integer part.
+		//
+		// 如果指数很大但位数不多，可以把一些零移到整数部分中。
 		if exp > 22 {
 			f *= float64pow10[exp-22]
 			exp = 22
 		}
 		if f > 1e15 || f < -1e15 {
 			// the exponent was really too large.
+			//
+			// 指数确实太大了。
 			return
 		}
 		return f * float64pow10[exp], true
@@ -402,6 +457,9 @@ func atof64exact(mantissa uint64, exp int, neg bool) (f float64, ok bool) {
 
 // If possible to compute mantissa*10^exp to 32-bit float f exactly,
 // entirely in floating-point math, do so, avoiding the machinery above.
+//
+// 如果能够完全使用浮点运算准确地计算出 32 位浮点数 f = mantissa*10^exp，就这样做，以避免
+// 上面的机制。
 func atof32exact(mantissa uint64, exp int, neg bool) (f float32, ok bool) {
 	if mantissa>>float32info.mantbits != 0 {
 		return
@@ -415,15 +473,22 @@ func atof32exact(mantissa uint64, exp int, neg bool) (f float32, ok bool) {
 		return f, true
 	// Exact integers are <= 10^7.
 	// Exact powers of ten are <= 10^10.
+	//
+	// 准确的整数 <= 10^7。
+	// 准确的 10 的幂 <= 10^10。
 	case exp > 0 && exp <= 7+10: // int * 10^k
 		// If exponent is big but number of digits is not,
 		// can move a few zeros into the integer part.
+		//
+		// 如果指数很大但位数不多，可以把一些零移到整数部分中。
 		if exp > 10 {
 			f *= float32pow10[exp-10]
 			exp = 10
 		}
 		if f > 1e7 || f < -1e7 {
 			// the exponent was really too large.
+			//
+			// 指数确实太大了。
 			return
 		}
 		return f * float32pow10[exp], true
@@ -442,15 +507,21 @@ func atof32(s string) (f float32, err error) {
 
 	if optimize {
 		// Parse mantissa and exponent.
+		//
+		// 解析尾数和指数。
 		mantissa, exp, neg, trunc, ok := readFloat(s)
 		if ok {
 			// Try pure floating-point arithmetic conversion.
+			//
+			// 尝试纯浮点运算的转换。
 			if !trunc {
 				if f, ok := atof32exact(mantissa, exp, neg); ok {
 					return f, nil
 				}
 			}
 			// Try another fast path.
+			//
+			// 尝试另一个快速路径。
 			ext := new(extFloat)
 			if ok := ext.AssignDecimal(mantissa, exp, neg, trunc, &float32info); ok {
 				b, ovf := ext.floatBits(&float32info)
@@ -481,15 +552,21 @@ func atof64(s string) (f float64, err error) {
 
 	if optimize {
 		// Parse mantissa and exponent.
+		//
+		// 解析尾数和指数。
 		mantissa, exp, neg, trunc, ok := readFloat(s)
 		if ok {
 			// Try pure floating-point arithmetic conversion.
+			//
+			// 尝试纯浮点运算的转换。
 			if !trunc {
 				if f, ok := atof64exact(mantissa, exp, neg); ok {
 					return f, nil
 				}
 			}
 			// Try another fast path.
+			//
+			// 尝试另一个快速路径。
 			ext := new(extFloat)
 			if ok := ext.AssignDecimal(mantissa, exp, neg, trunc, &float64info); ok {
 				b, ovf := ext.floatBits(&float64info)
@@ -530,6 +607,19 @@ func atof64(s string) (f float64, err error) {
 // If s is syntactically well-formed but is more than 1/2 ULP
 // away from the largest floating point number of the given size,
 // ParseFloat returns f = ±Inf, err.Err = ErrRange.
+//
+// ParseFloat 将字符串 s 转换为浮点数，精度由 bitSize 指定：32 表示 float32，64 表示 float64。
+// 当 bitSize=32 时，结果的类型仍然是 float64，但它可以转换为 float32 而不改变它的值。
+//
+// 如果 s 格式正确并且接近一个有效的浮点数，ParseFloat 返回使用 IEEE754 无偏舍入得到的最接近
+// 的浮点数。
+//
+// ParseFloat 返回的错误的具体类型为 *NumError，并且 err.Num = s。
+//
+// 如果 s 在语法上格式不正确，ParseFloat 返回 err.Err = ErrSyntax。
+//
+// 如果 s 在语法上格式正确，但与给定大小的最大浮点数相差超过 1/2 ULP，ParseFloat 返回
+// f = ±Inf，err.Err = ErrRange。
 func ParseFloat(s string, bitSize int) (float64, error) {
 	if bitSize == 32 {
 		f, err := atof32(s)
@@ -7,16 +7,25 @@ package strconv
 import "errors"
 
 // ErrRange indicates that a value is out of range for the target type.
+//
+// ErrRange 表示值超出了目标类型的范围。
 var ErrRange = errors.New("value out of range")
 
 // ErrSyntax indicates that a value does not have the right syntax for the target type.
+//
+// ErrSyntax 表示值不符合目标类型的语法。
 var ErrSyntax = errors.New("invalid syntax")
 
 // A NumError records a failed conversion.
+//
+// NumError 记录了一次失败的转换。
 type NumError struct {
+	// 失败的函数（ParseBool、ParseInt、ParseUint、ParseFloat）
 	Func string // the failing function (ParseBool, ParseInt, ParseUint, ParseFloat)
-	Num  string // the input
-	Err  error  // the reason the conversion failed (e.g. ErrRange, ErrSyntax, etc.)
+	// 输入
+	Num string // the input
+	// 转换失败的原因（例如 ErrRange、ErrSyntax 等）
+	Err error // the reason the conversion failed (e.g. ErrRange, ErrSyntax, etc.)
 }
 
 func (e *NumError) Error() string {
@@ -39,14 +48,20 @@ func bitSizeError(fn, str string, bitSize int) *NumError {
 	return &NumError{fn, str, errors.New("invalid bit size " + Itoa(bitSize))}
 }
 
+// IMP: 在 64 位平台上 ^uint(0) >> 63 为 1，intSize 为 64；在 32 位平台上右移 63 位得到 0，
+// intSize 为 32。这样在编译期就可以得到 int 的位数。
 const intSize = 32 << (^uint(0) >> 63)
 
 // IntSize is the size in bits of an int or uint value.
+//
+// IntSize 是 int 或 uint 值的位数。
 const IntSize = intSize
 
 const maxUint64 = (1<<64 - 1)
 
 // ParseUint is like ParseInt but for unsigned numbers.
+//
+// ParseUint 类似于 ParseInt，但是用于无符号数。
 func ParseUint(s string, base int, bitSize int) (uint64, error) {
 	const fnParseUint = "ParseUint"
 
@@ -58,9 +73,13 @@ func ParseUint(s string, base int, bitSize int) (uint64, error) {
 	switch {
 	case 2 <= base && base <= 36:
 		// valid base; nothing to do
+		//
+		// 有效的进制，什么也不用做
 
 	case base == 0:
 		// Look for octal, hex prefix.
+		//
+		// 查找八进制、十六进制前缀。
 		switch {
 		case s[0] == '0' && len(s) > 1 && (s[1] == 'x' || s[1] == 'X'):
 			if len(s) < 3 {
@@ -87,6 +106,11 @@ func ParseUint(s string, base int, bitSize int) (uint64, error) {
 
 	// Cutoff is the smallest number such that cutoff*base > maxUint64.
 	// Use compile-time constants for common cases.
+	//
+	// cutoff 是满足 cutoff*base > maxUint64 的最小的数。
+	// 对常见的情况使用编译期常量。
+	// IMP: 在乘法之前用 n >= cutoff 判断 n*base 是否会溢出，而不是在乘法之后检查，因为
+	// 溢出之后的结果已经回绕了，无法再判断。
 	var cutoff uint64
 	switch base {
 	case 10:
@@ -119,13 +143,18 @@ func ParseUint(s string, base int, bitSize int) (uint64, error) {
 
 		if n >= cutoff {
 			// n*base overflows
+			//
+			// n*base 溢出
 			return maxVal, rangeError(fnParseUint, s0)
 		}
 		n *= uint64(base)
 
 		n1 := n + uint64(d)
+		// IMP: n1 < n 表示 uint64 加法回绕了，n1 > maxVal 表示超出了 bitSize 的范围。
 		if n1 < n || n1 > maxVal {
 			// n+v overflows
+			//
+			// n+v 溢出
 			return maxVal, rangeError(fnParseUint, s0)
 		}
 		n = n1
@@ -153,15 +182,31 @@ func ParseUint(s string, base int, bitSize int) (uint64, error) {
 // signed integer of the given size, err.Err = ErrRange and the
 // returned value is the maximum magnitude integer of the
 // appropriate bitSize and sign.
+//
+// ParseInt 以给定的进制（0、2 到 36）和位数（0 到 64）解释字符串 s，并返回对应的值 i。
+//
+// 如果 base == 0，则进制由字符串的前缀决定："0x" 为十六进制，"0" 为八进制，其他为十进制。
+// 对于进制 1、小于 0 或者大于 36 的进制，返回一个错误。
+//
+// bitSize 参数指定了结果必须能够容纳进的整数类型。位数 0、8、16、32 和 64 分别对应 int、
+// int8、int16、int32 和 int64。对于小于 0 或者大于 64 的 bitSize，返回一个错误。
+//
+// ParseInt 返回的错误的具体类型为 *NumError，并且 err.Num = s。如果 s 为空或者包含无效的
+// 数字，则 err.Err = ErrSyntax 并且返回值为 0；如果 s 对应的值无法用给定位数的有符号整数
+// 表示，则 err.Err = ErrRange，并且返回值是对应 bitSize 和符号的绝对值最大的整数。
 func ParseInt(s string, base int, bitSize int) (i int64, err error) {
 	const fnParseInt = "ParseInt"
 
 	// Empty string bad.
+	//
+	// 空字符串是错误的。
 	if len(s) == 0 {
 		return 0, syntaxError(fnParseInt, s)
 	}
 
 	// Pick off leading sign.
+	//
+	// 去掉开头的符号。
 	s0 := s
 	neg := false
 	if s[0] == '+' {
@@ -172,6 +217,11 @@ func ParseInt(s string, base int, bitSize int) (i int64, err error) {
 	}
 
 	// Convert unsigned and check range.
+	//
+	// 按无符号数转换并检查范围。
+	// IMP: ErrRange 不能直接返回，因为负数的范围比正数多一个，例如 int8 的 -128 对应的无符号
+	// 值 128 对 ParseUint(s, base, 8) 来说是合法的，但 ParseUint 得到的是无符号数的范围，
+	// 有符号数的范围要在下面根据符号重新检查。
 	var un uint64
 	un, err = ParseUint(s, base, bitSize)
 	if err != nil && err.(*NumError).Err != ErrRange {
@@ -184,6 +234,7 @@ func ParseInt(s string, base int, bitSize int) (i int64, err error) {
 		bitSize = int(IntSize)
 	}
 
+	// IMP: 有符号数的范围是 [-cutoff, cutoff-1]。
 	cutoff := uint64(1 << uint(bitSize-1))
 	if !neg && un >= cutoff {
 		return int64(cutoff - 1), rangeError(fnParseInt, s0)
@@ -199,6 +250,8 @@ func ParseInt(s string, base int, bitSize int) (i int64, err error) {
 }
 
 // Atoi returns the result of ParseInt(s, 10, 0) converted to type int.
+//
+// Atoi 返回 ParseInt(s, 10, 0) 的结果并转换为 int 类型。
 func Atoi(s string) (int, error) {
 	const fnAtoi = "Atoi"
 
@@ -206,6 +259,10 @@ func Atoi(s string) (int, error) {
 	if intSize == 32 && (0 < sLen && sLen < 10) ||
 		intSize == 64 && (0 < sLen && sLen < 19) {
 		// Fast path for small integers that fit int type.
+		//
+		// 能放进 int 类型的小整数的快速路径。
+		// IMP: 32 位下不超过 9 位、64 位下不超过 18 位的十进制数一定不会溢出，所以不需要检查
+		// 溢出。
 		s0 := s
 		if s[0] == '-' || s[0] == '+' {
 			s = s[1:]
@@ -216,6 +273,8 @@ func Atoi(s string) (int, error) {
 
 		n := 0
 		for _, ch := range []byte(s) {
+			// IMP: ch 是 byte，小于 '0' 的字符减去 '0' 后会回绕成很大的数，所以一次比较就能
+			// 同时排除小于 '0' 和大于 '9' 的字符。
 			ch -= '0'
 			if ch > 9 {
 				return 0, &NumError{fnAtoi, s0, ErrSyntax}
@@ -229,6 +288,8 @@ func Atoi(s string) (int, error) {
 	}
 
 	// Slow path for invalid or big integers.
+	//
+	// 无效的或者大的整数的慢速路径。
 	i64, err := ParseInt(s, 10, 0)
 	if nerr, ok := err.(*NumError); ok {
 		nerr.Func = fnAtoi
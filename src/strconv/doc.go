@@ -54,4 +54,54 @@
 //
 // Unquote and UnquoteChar unquote Go string and rune literals.
 //
+// Package strconv 实现了基本数据类型与其字符串表示之间的相互转换。
+//
+// 数值转换
+//
+// 最常用的数值转换是 Atoi（字符串转 int）和 Itoa（int 转字符串）。
+//
+//	i, err := strconv.Atoi("-42")
+//	s := strconv.Itoa(-42)
+//
+// 它们假定使用十进制和 Go 的 int 类型。
+//
+// ParseBool、ParseFloat、ParseInt 和 ParseUint 将字符串转换为值：
+//
+//	b, err := strconv.ParseBool("true")
+//	f, err := strconv.ParseFloat("3.1415", 64)
+//	i, err := strconv.ParseInt("-42", 10, 64)
+//	u, err := strconv.ParseUint("42", 10, 64)
+//
+// 解析函数返回最宽的类型（float64、int64 和 uint64），但如果 size 参数指定了一个更窄的
+// 宽度，那么结果可以无损地转换为该更窄的类型：
+//
+//	s := "2147483647" // biggest int32
+//	i64, err := strconv.ParseInt(s, 10, 32)
+//	...
+//	i := int32(i64)
+//
+// FormatBool、FormatFloat、FormatInt 和 FormatUint 将值转换为字符串：
+//
+//	s := strconv.FormatBool(true)
+//	s := strconv.FormatFloat(3.1415, 'E', -1, 64)
+//	s := strconv.FormatInt(-42, 16)
+//	s := strconv.FormatUint(42, 16)
+//
+// AppendBool、AppendFloat、AppendInt 和 AppendUint 与之类似，但是它们将格式化后的值追加到
+// 目标切片中。
+//
+// 字符串转换
+//
+// Quote 和 QuoteToASCII 将字符串转换为带引号的 Go 字符串字面量。后者通过使用 \u 转义所有
+// 非 ASCII 的 Unicode 字符，保证结果是一个 ASCII 字符串：
+//
+//	q := Quote("Hello, 世界")
+//	q := QuoteToASCII("Hello, 世界")
+//
+// QuoteRune 和 QuoteRuneToASCII 与之类似，但是它们接受 rune 并返回带引号的 Go rune 字面量。
+//
+// Unquote 和 UnquoteChar 对 Go 字符串和 rune 字面量去除引号。
+//
+// IMP: FormatT 都是通过对应的 AppendT 或者共用的底层函数实现的，区别只在于结果是新分配的
+// 字符串还是追加到调用者提供的 []byte 中。后者可以复用缓冲区，避免额外的内存分配。
 package strconv
@@ -7,12 +7,25 @@
 //   1) store mantissa in multiprecision decimal
 //   2) shift decimal by exponent
 //   3) read digits out & format
+//
+// 二进制到十进制的浮点数转换。
+// 算法：
+//   1) 将尾数储存在多精度十进制数中
+//   2) 按指数移动十进制数
+//   3) 读出数字并格式化
+//
+// IMP: 上面是最慢但总是正确的 bigFtoa 的算法。genericFtoa 会先尝试基于 extFloat 的快速
+// 算法（最短表示时使用 Grisu3，固定位数时使用 FixedDecimal），只有在快速算法无法确定结果
+// 时才回退到 bigFtoa。
 
 package strconv
 
 import "math"
 
 // TODO: move elsewhere?
+//
+// TODO: 移到别处？
+// IMP: mantbits 是尾数的位数（不包括隐含的最高位），expbits 是指数的位数，bias 是指数的偏移量。
 type floatInfo struct {
 	mantbits uint
 	expbits  uint
@@ -42,16 +55,45 @@ var float64info = floatInfo{52, 11, -1023}
 // zeros are removed).
 // The special precision -1 uses the smallest number of digits
 // necessary such that ParseFloat will return f exactly.
+//
+// FormatFloat 根据格式 fmt 和精度 prec 将浮点数 f 转换为字符串。它假定原始值是从 bitSize 位
+// （float32 为 32，float64 为 64）的浮点值得到的，并据此对结果进行舍入。
+//
+// 格式 fmt 是以下之一：
+// 'b'（-ddddp±ddd，二进制指数），
+// 'e'（-d.dddde±dd，十进制指数），
+// 'E'（-d.ddddE±dd，十进制指数），
+// 'f'（-ddd.dddd，没有指数），
+// 'g'（指数较大时使用 'e'，否则使用 'f'），或者
+// 'G'（指数较大时使用 'E'，否则使用 'f'）。
+//
+// 精度 prec 控制 'e'、'E'、'f'、'g' 和 'G' 格式打印的数字的位数（不包括指数）。
+// 对于 'e'、'E' 和 'f'，它是小数点后的位数。
+// 对于 'g' 和 'G'，它是有效数字的最大位数（尾部的零会被移除）。
+// 特殊的精度 -1 使用能让 ParseFloat 准确地返回 f 的最少的位数。
 func FormatFloat(f float64, fmt byte, prec, bitSize int) string {
 	return string(genericFtoa(make([]byte, 0, max(prec+4, 24)), f, fmt, prec, bitSize))
 }
 
 // AppendFloat appends the string form of the floating-point number f,
 // as generated by FormatFloat, to dst and returns the extended buffer.
+//
+// AppendFloat 将浮点数 f 的字符串形式（由 FormatFloat 生成）追加到 dst 中，并返回扩展后的
+// 缓冲区。
 func AppendFloat(dst []byte, f float64, fmt byte, prec, bitSize int) []byte {
 	return genericFtoa(dst, f, fmt, prec, bitSize)
 }
 
+// genericFtoa splits val into sign, exponent and mantissa and picks the
+// conversion algorithm.
+//
+// genericFtoa 将 val 拆分为符号、指数和尾数，并选择转换算法。
+//
+// IMP: IEEE 754 浮点数的位布局（以 float64 为例）
+//
+//	| 1 位符号 | 11 位指数 | 52 位尾数 |
+//
+// 指数全为 1 表示 Inf 或 NaN，全为 0 表示非规格化数（没有隐含的最高位 1）。
 func genericFtoa(dst []byte, val float64, fmt byte, prec, bitSize int) []byte {
 	var bits uint64
 	var flt *floatInfo
@@ -73,6 +115,8 @@ func genericFtoa(dst []byte, val float64, fmt byte, prec, bitSize int) []byte {
 	switch exp {
 	case 1<<flt.expbits - 1:
 		// Inf, NaN
+		//
+		// 无穷大，非数
 		var s string
 		switch {
 		case mant != 0:
@@ -86,15 +130,22 @@ func genericFtoa(dst []byte, val float64, fmt byte, prec, bitSize int) []byte {
 
 	case 0:
 		// denormalized
+		//
+		// 非规格化数
+		// IMP: 非规格化数的实际指数与最小的规格化数相同，即 1 + bias 而不是 0 + bias。
 		exp++
 
 	default:
 		// add implicit top bit
+		//
+		// 添加隐含的最高位
 		mant |= uint64(1) << flt.mantbits
 	}
 	exp += flt.bias
 
 	// Pick off easy binary format.
+	//
+	// 先处理简单的二进制格式。
 	if fmt == 'b' {
 		return fmtB(dst, neg, mant, exp, flt)
 	}
@@ -106,9 +157,13 @@ func genericFtoa(dst []byte, val float64, fmt byte, prec, bitSize int) []byte {
 	var digs decimalSlice
 	ok := false
 	// Negative precision means "only as much as needed to be exact."
+	//
+	// 负的精度表示“只使用准确表示所需要的位数”。
 	shortest := prec < 0
 	if shortest {
 		// Try Grisu3 algorithm.
+		//
+		// 尝试 Grisu3 算法。
 		f := new(extFloat)
 		lower, upper := f.AssignComputeBounds(mant, exp, neg, flt)
 		var buf [32]byte
@@ -118,6 +173,8 @@ func genericFtoa(dst []byte, val float64, fmt byte, prec, bitSize int) []byte {
 			return bigFtoa(dst, prec, fmt, neg, mant, exp, flt)
 		}
 		// Precision for shortest representation mode.
+		//
+		// 最短表示模式的精度。
 		switch fmt {
 		case 'e', 'E':
 			prec = max(digs.nd-1, 0)
@@ -128,6 +185,8 @@ func genericFtoa(dst []byte, val float64, fmt byte, prec, bitSize int) []byte {
 		}
 	} else if fmt != 'f' {
 		// Fixed number of digits.
+		//
+		// 固定的位数。
 		digits := prec
 		switch fmt {
 		case 'e', 'E':
@@ -140,6 +199,10 @@ func genericFtoa(dst []byte, val float64, fmt byte, prec, bitSize int) []byte {
 		}
 		if digits <= 15 {
 			// try fast algorithm when the number of digits is reasonable.
+			//
+			// 位数合理时尝试快速算法。
+			// IMP: float64 最多只有 15 到 17 位有效的十进制数字，FixedDecimal 只在不超过 15 位时
+			// 保证正确。
 			var buf [24]byte
 			digs.d = buf[:]
 			f := extFloat{mant, exp - int(flt.mantbits), neg}
@@ -153,6 +216,8 @@ func genericFtoa(dst []byte, val float64, fmt byte, prec, bitSize int) []byte {
 }
 
 // bigFtoa uses multiprecision computations to format a float.
+//
+// bigFtoa 使用多精度计算来格式化浮点数。
 func bigFtoa(dst []byte, prec int, fmt byte, neg bool, mant uint64, exp int, flt *floatInfo) []byte {
 	d := new(decimal)
 	d.Assign(mant)
@@ -163,6 +228,8 @@ func bigFtoa(dst []byte, prec int, fmt byte, neg bool, mant uint64, exp int, flt
 		roundShortest(d, mant, exp, flt)
 		digs = decimalSlice{d: d.d[:], nd: d.nd, dp: d.dp}
 		// Precision for shortest representation mode.
+		//
+		// 最短表示模式的精度。
 		switch fmt {
 		case 'e', 'E':
 			prec = digs.nd - 1
@@ -173,6 +240,8 @@ func bigFtoa(dst []byte, prec int, fmt byte, neg bool, mant uint64, exp int, flt
 		}
 	} else {
 		// Round appropriately.
+		//
+		// 适当地舍入。
 		switch fmt {
 		case 'e', 'E':
 			d.Round(prec + 1)
@@ -189,6 +258,9 @@ func bigFtoa(dst []byte, prec int, fmt byte, neg bool, mant uint64, exp int, flt
 	return formatDigits(dst, shortest, neg, digs, prec, fmt)
 }
 
+// formatDigits formats the decimal digits digs according to fmt and prec.
+//
+// formatDigits 根据 fmt 和 prec 格式化十进制数字 digs。
 func formatDigits(dst []byte, shortest bool, neg bool, digs decimalSlice, prec int, fmt byte) []byte {
 	switch fmt {
 	case 'e', 'E':
@@ -197,6 +269,8 @@ func formatDigits(dst []byte, shortest bool, neg bool, digs decimalSlice, prec i
 		return fmtF(dst, neg, digs, prec)
 	case 'g', 'G':
 		// trailing fractional zeros in 'e' form will be trimmed.
+		//
+		// 'e' 形式中小数部分尾部的零会被去掉。
 		eprec := prec
 		if eprec > digs.nd && digs.nd >= digs.dp {
 			eprec = digs.nd
@@ -204,6 +278,9 @@ func formatDigits(dst []byte, shortest bool, neg bool, digs decimalSlice, prec i
 		// %e is used if the exponent from the conversion
 		// is less than -4 or greater than or equal to the precision.
 		// if precision was the shortest possible, use precision 6 for this decision.
+		//
+		// 如果转换得到的指数小于 -4 或者大于等于精度，则使用 %e。
+		// 如果精度是最短的，则使用精度 6 来做这个判断。
 		if shortest {
 			eprec = 6
 		}
@@ -221,13 +298,23 @@ func formatDigits(dst []byte, shortest bool, neg bool, digs decimalSlice, prec i
 	}
 
 	// unknown format
+	//
+	// 未知的格式
 	return append(dst, '%', fmt)
 }
 
 // roundShortest rounds d (= mant * 2^exp) to the shortest number of digits
 // that will let the original floating point value be precisely reconstructed.
+//
+// roundShortest 将 d（= mant * 2^exp）舍入到能够准确重建原始浮点值的最少的位数。
+//
+// IMP: 原始浮点数与它相邻的两个浮点数之间的中点构成了一个区间 [lower, upper]，区间内的任何
+// 十进制数解析时都会舍入回原始浮点数。从高位开始逐位比较 d、lower 和 upper，一旦 d 在某一位
+// 上可以向下或者向上舍入而仍然留在区间内，就在这一位截断。
 func roundShortest(d *decimal, mant uint64, exp int, flt *floatInfo) {
 	// If mantissa is zero, the number is zero; stop now.
+	//
+	// 如果尾数为零，这个数就是零，现在停止。
 	if mant == 0 {
 		d.nd = 0
 		return
@@ -236,6 +323,9 @@ func roundShortest(d *decimal, mant uint64, exp int, flt *floatInfo) {
 	// Compute upper and lower such that any decimal number
 	// between upper and lower (possibly inclusive)
 	// will round to the original floating point number.
+	//
+	// 计算 upper 和 lower，使得 upper 和 lower 之间（可能包含端点）的任何十进制数都会舍入为
+	// 原始的浮点数。
 
 	// We may see at once that the number is already shortest.
 	//
@@ -247,15 +337,32 @@ func roundShortest(d *decimal, mant uint64, exp int, flt *floatInfo) {
 	// So the number is already shortest if 10^(dp-nd) > 2^(exp-mantbits),
 	// or equivalently log2(10)*(dp-nd) > exp-mantbits.
 	// It is true if 332/100*(dp-nd) >= exp-mantbits (log2(10) > 3.32).
+	//
+	// 我们也许能立即看出这个数已经是最短的了。
+	//
+	// 假设 d 不是非规格化数，那么 2^exp <= d < 10^dp。
+	// 最接近的更短的数至少相距 10^(dp-nd)。
+	// 下面计算的 lower/upper 边界与 d 的距离最多为 2^(exp-mantbits)。
+	//
+	// 所以如果 10^(dp-nd) > 2^(exp-mantbits)，即 log2(10)*(dp-nd) > exp-mantbits，
+	// 这个数就已经是最短的了。
+	// 如果 332/100*(dp-nd) >= exp-mantbits（log2(10) > 3.32），上式成立。
+	// 最小的可能的指数
 	minexp := flt.bias + 1 // minimum possible exponent
 	if exp > minexp && 332*(d.dp-d.nd) >= 100*(exp-int(flt.mantbits)) {
 		// The number is already shortest.
+		//
+		// 这个数已经是最短的了。
 		return
 	}
 
 	// d = mant << (exp - mantbits)
 	// Next highest floating point number is mant+1 << exp-mantbits.
 	// Our upper bound is halfway between, mant*2+1 << exp-mantbits-1.
+	//
+	// d = mant << (exp - mantbits)
+	// 下一个更大的浮点数是 mant+1 << exp-mantbits。
+	// 我们的上界是两者的中点，即 mant*2+1 << exp-mantbits-1。
 	upper := new(decimal)
 	upper.Assign(mant*2 + 1)
 	upper.Shift(exp - int(flt.mantbits) - 1)
@@ -266,6 +373,12 @@ func roundShortest(d *decimal, mant uint64, exp int, flt *floatInfo) {
 	// in which case the next lowest is mant*2-1 << exp-mantbits-1.
 	// Either way, call it mantlo << explo-mantbits.
 	// Our lower bound is halfway between, mantlo*2+1 << explo-mantbits-1.
+	//
+	// d = mant << (exp - mantbits)
+	// 下一个更小的浮点数是 mant-1 << exp-mantbits，除非 mant-1 丢掉了最高有效位并且 exp 不是
+	// 最小的指数，此时下一个更小的浮点数是 mant*2-1 << exp-mantbits-1。
+	// 无论哪种情况，都记为 mantlo << explo-mantbits。
+	// 我们的下界是两者的中点，即 mantlo*2+1 << explo-mantbits-1。
 	var mantlo uint64
 	var explo int
 	if mant > 1<<flt.mantbits || exp == minexp {
@@ -282,16 +395,25 @@ func roundShortest(d *decimal, mant uint64, exp int, flt *floatInfo) {
 	// The upper and lower bounds are possible outputs only if
 	// the original mantissa is even, so that IEEE round-to-even
 	// would round to the original mantissa and not the neighbors.
+	//
+	// 只有在原始尾数是偶数时，上下界本身才是可能的输出，因为这样 IEEE 的向偶数舍入才会舍入到
+	// 原始的尾数而不是相邻的尾数。
 	inclusive := mant%2 == 0
 
 	// Now we can figure out the minimum number of digits required.
 	// Walk along until d has distinguished itself from upper and lower.
+	//
+	// 现在我们可以计算出所需要的最少的位数了。
+	// 一直向后遍历，直到 d 与 upper 和 lower 区分开来。
 	for i := 0; i < d.nd; i++ {
+		// 下界的数字
 		l := byte('0') // lower digit
 		if i < lower.nd {
 			l = lower.d[i]
 		}
-		m := d.d[i]    // middle digit
+		// 中间的数字
+		m := d.d[i] // middle digit
+		// 上界的数字
 		u := byte('0') // upper digit
 		if i < upper.nd {
 			u = upper.d[i]
@@ -300,14 +422,23 @@ func roundShortest(d *decimal, mant uint64, exp int, flt *floatInfo) {
 		// Okay to round down (truncate) if lower has a different digit
 		// or if lower is inclusive and is exactly the result of rounding
 		// down (i.e., and we have reached the final digit of lower).
+		//
+		// 如果 lower 在这一位上的数字不同，或者 lower 是包含在内的并且恰好是向下舍入的结果
+		// （即我们已经到达了 lower 的最后一位数字），则可以向下舍入（截断）。
 		okdown := l != m || inclusive && i+1 == lower.nd
 
 		// Okay to round up if upper has a different digit and either upper
 		// is inclusive or upper is bigger than the result of rounding up.
+		//
+		// 如果 upper 在这一位上的数字不同，并且 upper 是包含在内的或者 upper 大于向上舍入的结果，
+		// 则可以向上舍入。
 		okup := m != u && (inclusive || m+1 < u || i+1 < upper.nd)
 
 		// If it's okay to do either, then round to the nearest one.
 		// If it's okay to do only one, do it.
+		//
+		// 如果两种都可以，则舍入到最近的那个。
+		// 如果只有一种可以，就使用它。
 		switch {
 		case okdown && okup:
 			d.Round(i + 1)
@@ -6,11 +6,15 @@ package strconv
 
 import "math/bits"
 
+// 为小整数启用快速路径
 const fastSmalls = true // enable fast path for small integers
 
 // FormatUint returns the string representation of i in the given base,
 // for 2 <= base <= 36. The result uses the lower-case letters 'a' to 'z'
 // for digit values >= 10.
+//
+// FormatUint 返回 i 在给定进制下的字符串表示，其中 2 <= base <= 36。对于 >= 10 的数字值，
+// 结果使用小写字母 'a' 到 'z'。
 func FormatUint(i uint64, base int) string {
 	if fastSmalls && i < nSmalls && base == 10 {
 		return small(int(i))
@@ -22,6 +26,9 @@ func FormatUint(i uint64, base int) string {
 // FormatInt returns the string representation of i in the given base,
 // for 2 <= base <= 36. The result uses the lower-case letters 'a' to 'z'
 // for digit values >= 10.
+//
+// FormatInt 返回 i 在给定进制下的字符串表示，其中 2 <= base <= 36。对于 >= 10 的数字值，
+// 结果使用小写字母 'a' 到 'z'。
 func FormatInt(i int64, base int) string {
 	if fastSmalls && 0 <= i && i < nSmalls && base == 10 {
 		return small(int(i))
@@ -31,12 +38,16 @@ func FormatInt(i int64, base int) string {
 }
 
 // Itoa is shorthand for FormatInt(int64(i), 10).
+//
+// Itoa 是 FormatInt(int64(i), 10) 的简写。
 func Itoa(i int) string {
 	return FormatInt(int64(i), 10)
 }
 
 // AppendInt appends the string form of the integer i,
 // as generated by FormatInt, to dst and returns the extended buffer.
+//
+// AppendInt 将整数 i 的字符串形式（由 FormatInt 生成）追加到 dst 中，并返回扩展后的缓冲区。
 func AppendInt(dst []byte, i int64, base int) []byte {
 	if fastSmalls && 0 <= i && i < nSmalls && base == 10 {
 		return append(dst, small(int(i))...)
@@ -47,6 +58,9 @@ func AppendInt(dst []byte, i int64, base int) []byte {
 
 // AppendUint appends the string form of the unsigned integer i,
 // as generated by FormatUint, to dst and returns the extended buffer.
+//
+// AppendUint 将无符号整数 i 的字符串形式（由 FormatUint 生成）追加到 dst 中，并返回扩展后的
+// 缓冲区。
 func AppendUint(dst []byte, i uint64, base int) []byte {
 	if fastSmalls && i < nSmalls && base == 10 {
 		return append(dst, small(int(i))...)
@@ -56,6 +70,9 @@ func AppendUint(dst []byte, i uint64, base int) []byte {
 }
 
 // small returns the string for an i with 0 <= i < nSmalls.
+//
+// small 返回 0 <= i < nSmalls 的 i 对应的字符串。
+// IMP: 返回的是常量字符串的子串，不需要分配内存。
 func small(i int) string {
 	if i < 10 {
 		return digits[i : i+1]
@@ -65,6 +82,8 @@ func small(i int) string {
 
 const nSmalls = 100
 
+// IMP: smallsString 按顺序存放了 00 到 99 的两位数字，数 x（0 <= x < 100）对应的两个字符
+// 是 smallsString[x*2 : x*2+2]，这样每次可以转换两位数字，除法的次数减少了一半。
 const smallsString = "00010203040506070809" +
 	"10111213141516171819" +
 	"20212223242526272829" +
@@ -86,15 +105,22 @@ const digits = "0123456789abcdefghijklmnopqrstuvwxyz"
 // returned as the first result value; otherwise the string is returned
 // as the second result value.
 //
+// formatBits 计算 u 在给定进制下的字符串表示。如果设置了 neg，u 被当作负的 int64 值。如果
+// 设置了 append_，字符串被追加到 dst 中，结果字节切片作为第一个返回值返回；否则字符串作为
+// 第二个返回值返回。
+//
+// IMP: 数字从低位到高位依次写入 a 的末尾，i 指向已写入部分的开头，最后 a[i:] 就是结果。
 func formatBits(dst []byte, u uint64, base int, neg, append_ bool) (d []byte, s string) {
 	if base < 2 || base > len(digits) {
 		panic("strconv: illegal AppendInt/FormatInt base")
 	}
 	// 2 <= base && base <= len(digits)
 
+	// +1 是为二进制下 64 位值的符号预留的
 	var a [64 + 1]byte // +1 for sign of 64bit value in base 2
 	i := len(a)
 
+	// IMP: 对 uint64 取负等价于对补码取负，对于 math.MinInt64 也能得到正确的绝对值 1<<63。
 	if neg {
 		u = -u
 	}
@@ -102,17 +128,28 @@ func formatBits(dst []byte, u uint64, base int, neg, append_ bool) (d []byte, s
 	// convert bits
 	// We use uint values where we can because those will
 	// fit into a single register even on a 32bit machine.
+	//
+	// 转换位
+	// 我们尽可能使用 uint 值，因为即使在 32 位机器上它们也能放进一个寄存器。
 	if base == 10 {
 		// common case: use constants for / because
 		// the compiler can optimize it into a multiply+shift
+		//
+		// 常见情况：对 / 使用常量，因为编译器可以将它优化为乘法+移位
 
 		if host32bit {
 			// convert the lower digits using 32bit operations
+			//
+			// 使用 32 位操作转换低位数字
 			for u >= 1e9 {
 				// Avoid using r = a%b in addition to q = a/b
 				// since 64bit division and modulo operations
 				// are calculated by runtime functions on 32bit machines.
+				//
+				// 除了 q = a/b 之外避免再使用 r = a%b，因为在 32 位机器上 64 位除法和取模运算
+				// 是由运行时函数计算的。
 				q := u / 1e9
+				// u % 1e9 可以放进一个 uint
 				us := uint(u - q*1e9) // u % 1e9 fits into a uint
 				for j := 4; j > 0; j-- {
 					is := us % 100 * 2
@@ -124,6 +161,8 @@ func formatBits(dst []byte, u uint64, base int, neg, append_ bool) (d []byte, s
 
 				// us < 10, since it contains the last digit
 				// from the initial 9-digit us.
+				//
+				// us < 10，因为它包含的是最初 9 位数字的 us 的最后一位数字。
 				i--
 				a[i] = smallsString[us*2+1]
 
@@ -133,6 +172,8 @@ func formatBits(dst []byte, u uint64, base int, neg, append_ bool) (d []byte, s
 		}
 
 		// u guaranteed to fit into a uint
+		//
+		// u 一定能放进一个 uint
 		us := uint(u)
 		for us >= 100 {
 			is := us % 100 * 2
@@ -155,6 +196,9 @@ func formatBits(dst []byte, u uint64, base int, neg, append_ bool) (d []byte, s
 		// It is known that base is a power of two and
 		// 2 <= base <= len(digits).
 		// Use shifts and masks instead of / and %.
+		//
+		// 已知 base 是 2 的幂并且 2 <= base <= len(digits)。
+		// 使用移位和掩码代替 / 和 %。
 		shift := uint(bits.TrailingZeros(uint(base))) & 31
 		b := uint64(base)
 		m := uint(base) - 1 // == 1<<shift - 1
@@ -168,12 +212,17 @@ func formatBits(dst []byte, u uint64, base int, neg, append_ bool) (d []byte, s
 		a[i] = digits[uint(u)]
 	} else {
 		// general case
+		//
+		// 一般情况
 		b := uint64(base)
 		for u >= b {
 			i--
 			// Avoid using r = a%b in addition to q = a/b
 			// since 64bit division and modulo operations
 			// are calculated by runtime functions on 32bit machines.
+			//
+			// 除了 q = a/b 之外避免再使用 r = a%b，因为在 32 位机器上 64 位除法和取模运算
+			// 是由运行时函数计算的。
 			q := u / b
 			a[i] = digits[uint(u-q*b)]
 			u = q
@@ -184,6 +233,8 @@ func formatBits(dst []byte, u uint64, base int, neg, append_ bool) (d []byte, s
 	}
 
 	// add sign, if any
+	//
+	// 如果有符号，则添加符号
 	if neg {
 		i--
 		a[i] = '-'
@@ -197,6 +248,7 @@ func formatBits(dst []byte, u uint64, base int, neg, append_ bool) (d []byte, s
 	return
 }
 
+// IMP: x 是 2 的幂时只有一位为 1，x-1 将该位清零并把低位全部置为 1，两者相与为 0。
 func isPowerOfTwo(x int) bool {
 	return x&(x-1) == 0
 }
@@ -10,6 +10,11 @@ import "unicode/utf8"
 
 const lowerhex = "0123456789abcdef"
 
+// IMP: 所有 QuoteXxx 和 AppendQuoteXxx 都落到 appendQuotedWith 或 appendQuotedRuneWith，
+// 它们之间的区别只在于 quote（引号字符）、ASCIIonly（非 ASCII 字符是否转义）和 graphicOnly
+// （是否按照 IsGraphic 而不是 IsPrint 判断可打印字符）三个参数。
+//
+// quoteWith 预先分配 1.5 倍于 s 的容量，因为大部分字符串只有少量字符需要转义。
 func quoteWith(s string, quote byte, ASCIIonly, graphicOnly bool) string {
 	return string(appendQuotedWith(make([]byte, 0, 3*len(s)/2), s, quote, ASCIIonly, graphicOnly))
 }
@@ -26,6 +31,7 @@ func appendQuotedWith(buf []byte, s string, quote byte, ASCIIonly, graphicOnly b
 		if r >= utf8.RuneSelf {
 			r, width = utf8.DecodeRuneInString(s)
 		}
+		// IMP: 无效的 UTF-8 字节无法用 \u 表示，只能按字节以 \x 转义。
 		if width == 1 && r == utf8.RuneError {
 			buf = append(buf, `\x`...)
 			buf = append(buf, lowerhex[s[0]>>4])
@@ -50,6 +56,7 @@ func appendQuotedRuneWith(buf []byte, r rune, quote byte, ASCIIonly, graphicOnly
 
 func appendEscapedRune(buf []byte, r rune, quote byte, ASCIIonly, graphicOnly bool) []byte {
 	var runeTmp [utf8.UTFMax]byte
+	// 总是需要反斜杠转义
 	if r == rune(quote) || r == '\\' { // always backslashed
 		buf = append(buf, '\\')
 		buf = append(buf, byte(r))
@@ -108,12 +115,18 @@ func appendEscapedRune(buf []byte, r rune, quote byte, ASCIIonly, graphicOnly bo
 // returned string uses Go escape sequences (\t, \n, \xFF, \u0100) for
 // control characters and non-printable characters as defined by
 // IsPrint.
+//
+// Quote 返回一个表示 s 的双引号 Go 字符串字面量。对于控制字符和 IsPrint 定义的不可打印字符，
+// 返回的字符串使用 Go 转义序列（\t、\n、\xFF、\u0100）。
 func Quote(s string) string {
 	return quoteWith(s, '"', false, false)
 }
 
 // AppendQuote appends a double-quoted Go string literal representing s,
 // as generated by Quote, to dst and returns the extended buffer.
+//
+// AppendQuote 将表示 s 的双引号 Go 字符串字面量（由 Quote 生成）追加到 dst 中，并返回扩展
+// 后的缓冲区。
 func AppendQuote(dst []byte, s string) []byte {
 	return appendQuotedWith(dst, s, '"', false, false)
 }
@@ -121,12 +134,18 @@ func AppendQuote(dst []byte, s string) []byte {
 // QuoteToASCII returns a double-quoted Go string literal representing s.
 // The returned string uses Go escape sequences (\t, \n, \xFF, \u0100) for
 // non-ASCII characters and non-printable characters as defined by IsPrint.
+//
+// QuoteToASCII 返回一个表示 s 的双引号 Go 字符串字面量。对于非 ASCII 字符和 IsPrint 定义的
+// 不可打印字符，返回的字符串使用 Go 转义序列（\t、\n、\xFF、\u0100）。
 func QuoteToASCII(s string) string {
 	return quoteWith(s, '"', true, false)
 }
 
 // AppendQuoteToASCII appends a double-quoted Go string literal representing s,
 // as generated by QuoteToASCII, to dst and returns the extended buffer.
+//
+// AppendQuoteToASCII 将表示 s 的双引号 Go 字符串字面量（由 QuoteToASCII 生成）追加到 dst 中，
+// 并返回扩展后的缓冲区。
 func AppendQuoteToASCII(dst []byte, s string) []byte {
 	return appendQuotedWith(dst, s, '"', true, false)
 }
@@ -134,12 +153,18 @@ func AppendQuoteToASCII(dst []byte, s string) []byte {
 // QuoteToGraphic returns a double-quoted Go string literal representing s.
 // The returned string uses Go escape sequences (\t, \n, \xFF, \u0100) for
 // non-ASCII characters and non-printable characters as defined by IsGraphic.
+//
+// QuoteToGraphic 返回一个表示 s 的双引号 Go 字符串字面量。对于非 ASCII 字符和 IsGraphic
+// 定义的不可打印字符，返回的字符串使用 Go 转义序列（\t、\n、\xFF、\u0100）。
 func QuoteToGraphic(s string) string {
 	return quoteWith(s, '"', false, true)
 }
 
 // AppendQuoteToGraphic appends a double-quoted Go string literal representing s,
 // as generated by QuoteToGraphic, to dst and returns the extended buffer.
+//
+// AppendQuoteToGraphic 将表示 s 的双引号 Go 字符串字面量（由 QuoteToGraphic 生成）追加到
+// dst 中，并返回扩展后的缓冲区。
 func AppendQuoteToGraphic(dst []byte, s string) []byte {
 	return appendQuotedWith(dst, s, '"', false, true)
 }
@@ -147,12 +172,18 @@ func AppendQuoteToGraphic(dst []byte, s string) []byte {
 // QuoteRune returns a single-quoted Go character literal representing the
 // rune. The returned string uses Go escape sequences (\t, \n, \xFF, \u0100)
 // for control characters and non-printable characters as defined by IsPrint.
+//
+// QuoteRune 返回一个表示该 rune 的单引号 Go 字符字面量。对于控制字符和 IsPrint 定义的不可
+// 打印字符，返回的字符串使用 Go 转义序列（\t、\n、\xFF、\u0100）。
 func QuoteRune(r rune) string {
 	return quoteRuneWith(r, '\'', false, false)
 }
 
 // AppendQuoteRune appends a single-quoted Go character literal representing the rune,
 // as generated by QuoteRune, to dst and returns the extended buffer.
+//
+// AppendQuoteRune 将表示该 rune 的单引号 Go 字符字面量（由 QuoteRune 生成）追加到 dst 中，
+// 并返回扩展后的缓冲区。
 func AppendQuoteRune(dst []byte, r rune) []byte {
 	return appendQuotedRuneWith(dst, r, '\'', false, false)
 }
@@ -161,12 +192,18 @@ func AppendQuoteRune(dst []byte, r rune) []byte {
 // the rune. The returned string uses Go escape sequences (\t, \n, \xFF,
 // \u0100) for non-ASCII characters and non-printable characters as defined
 // by IsPrint.
+//
+// QuoteRuneToASCII 返回一个表示该 rune 的单引号 Go 字符字面量。对于非 ASCII 字符和 IsPrint
+// 定义的不可打印字符，返回的字符串使用 Go 转义序列（\t、\n、\xFF、\u0100）。
 func QuoteRuneToASCII(r rune) string {
 	return quoteRuneWith(r, '\'', true, false)
 }
 
 // AppendQuoteRuneToASCII appends a single-quoted Go character literal representing the rune,
 // as generated by QuoteRuneToASCII, to dst and returns the extended buffer.
+//
+// AppendQuoteRuneToASCII 将表示该 rune 的单引号 Go 字符字面量（由 QuoteRuneToASCII 生成）
+// 追加到 dst 中，并返回扩展后的缓冲区。
 func AppendQuoteRuneToASCII(dst []byte, r rune) []byte {
 	return appendQuotedRuneWith(dst, r, '\'', true, false)
 }
@@ -175,12 +212,18 @@ func AppendQuoteRuneToASCII(dst []byte, r rune) []byte {
 // the rune. The returned string uses Go escape sequences (\t, \n, \xFF,
 // \u0100) for non-ASCII characters and non-printable characters as defined
 // by IsGraphic.
+//
+// QuoteRuneToGraphic 返回一个表示该 rune 的单引号 Go 字符字面量。对于非 ASCII 字符和
+// IsGraphic 定义的不可打印字符，返回的字符串使用 Go 转义序列（\t、\n、\xFF、\u0100）。
 func QuoteRuneToGraphic(r rune) string {
 	return quoteRuneWith(r, '\'', false, true)
 }
 
 // AppendQuoteRuneToGraphic appends a single-quoted Go character literal representing the rune,
 // as generated by QuoteRuneToGraphic, to dst and returns the extended buffer.
+//
+// AppendQuoteRuneToGraphic 将表示该 rune 的单引号 Go 字符字面量（由 QuoteRuneToGraphic
+// 生成）追加到 dst 中，并返回扩展后的缓冲区。
 func AppendQuoteRuneToGraphic(dst []byte, r rune) []byte {
 	return appendQuotedRuneWith(dst, r, '\'', false, true)
 }
@@ -188,14 +231,19 @@ func AppendQuoteRuneToGraphic(dst []byte, r rune) []byte {
 // CanBackquote reports whether the string s can be represented
 // unchanged as a single-line backquoted string without control
 // characters other than tab.
+//
+// CanBackquote 报告字符串 s 是否可以不加修改地表示为一个单行的、除了制表符之外不含控制字符的
+// 反引号字符串。
 func CanBackquote(s string) bool {
 	for len(s) > 0 {
 		r, wid := utf8.DecodeRuneInString(s)
 		s = s[wid:]
 		if wid > 1 {
 			if r == '\ufeff' {
+				// BOM 是不可见的，不应该被引用。
 				return false // BOMs are invisible and should not be quoted.
 			}
+			// 其他所有多字节 rune 都是正确编码的，并被认为是可打印的。
 			continue // All other multibyte runes are correctly encoded and assumed printable.
 		}
 		if r == utf8.RuneError {
@@ -235,8 +283,23 @@ func unhex(b byte) (v rune, ok bool) {
 // If set to a single quote, it permits the sequence \' and disallows unescaped '.
 // If set to a double quote, it permits \" and disallows unescaped ".
 // If set to zero, it does not permit either escape and allows both quote characters to appear unescaped.
+//
+// UnquoteChar 解码字符串 s 所表示的转义字符串或字符字面量中的第一个字符或字节。
+// 它返回四个值：
+//
+//	1) value，解码后的 Unicode 码点或字节值；
+//	2) multibyte，一个布尔值，表示解码后的字符是否需要多字节的 UTF-8 表示；
+//	3) tail，该字符之后剩余的字符串；以及
+//	4) 一个错误，如果该字符在语法上是有效的，则为 nil。
+//
+// 第二个参数 quote 指定了正在解析的字面量的类型，从而决定了允许哪种转义的引号字符。
+// 如果设置为单引号，则允许序列 \' 并且不允许未转义的 '。
+// 如果设置为双引号，则允许 \" 并且不允许未转义的 "。
+// 如果设置为零，则两种转义都不允许，并且允许两种引号字符以未转义的形式出现。
 func UnquoteChar(s string, quote byte) (value rune, multibyte bool, tail string, err error) {
 	// easy cases
+	//
+	// 简单的情况
 	if len(s) == 0 {
 		err = ErrSyntax
 		return
@@ -253,6 +316,8 @@ func UnquoteChar(s string, quote byte) (value rune, multibyte bool, tail string,
 	}
 
 	// hard case: c is backslash
+	//
+	// 困难的情况：c 是反斜杠
 	if len(s) <= 1 {
 		err = ErrSyntax
 		return
@@ -301,6 +366,10 @@ func UnquoteChar(s string, quote byte) (value rune, multibyte bool, tail string,
 		s = s[n:]
 		if c == 'x' {
 			// single-byte string, possibly not UTF-8
+			//
+			// 单字节字符串，可能不是 UTF-8
+			// IMP: \x 转义表示的是一个字节而不是一个码点，所以 multibyte 保持为 false，
+			// Unquote 会把它原样写入一个字节。
 			value = v
 			break
 		}
@@ -316,6 +385,7 @@ func UnquoteChar(s string, quote byte) (value rune, multibyte bool, tail string,
 			err = ErrSyntax
 			return
 		}
+		// 已经有一位数字，再读两位
 		for j := 0; j < 2; j++ { // one digit already; two more
 			x := rune(s[j]) - '0'
 			if x < 0 || x > 7 {
@@ -351,6 +421,9 @@ func UnquoteChar(s string, quote byte) (value rune, multibyte bool, tail string,
 // that s quotes.  (If s is single-quoted, it would be a Go
 // character literal; Unquote returns the corresponding
 // one-character string.)
+//
+// Unquote 将 s 解释为单引号、双引号或反引号的 Go 字符串字面量，返回 s 所引用的字符串值。
+// （如果 s 是单引号的，它应该是一个 Go 字符字面量，Unquote 返回对应的单字符字符串。）
 func Unquote(s string) (string, error) {
 	n := len(s)
 	if n < 2 {
@@ -368,6 +441,9 @@ func Unquote(s string) (string, error) {
 		}
 		if contains(s, '\r') {
 			// -1 because we know there is at least one \r to remove.
+			//
+			// 减 1 是因为我们知道至少有一个 \r 需要移除。
+			// IMP: 原始字符串字面量中的回车符会被丢弃，这与 Go 规范一致。
 			buf := make([]byte, 0, len(s)-1)
 			for i := 0; i < len(s); i++ {
 				if s[i] != '\r' {
@@ -386,6 +462,9 @@ func Unquote(s string) (string, error) {
 	}
 
 	// Is it trivial? Avoid allocation.
+	//
+	// 是否是平凡的？避免内存分配。
+	// IMP: 没有转义也没有引号时，结果就是 s 本身，直接返回子串，不需要复制。
 	if !contains(s, '\\') && !contains(s, quote) {
 		switch quote {
 		case '"':
@@ -401,6 +480,7 @@ func Unquote(s string) (string, error) {
 	}
 
 	var runeTmp [utf8.UTFMax]byte
+	// 尽量避免更多的内存分配。
 	buf := make([]byte, 0, 3*len(s)/2) // Try to avoid more allocations.
 	for len(s) > 0 {
 		c, multibyte, ss, err := UnquoteChar(s, quote)
@@ -416,6 +496,8 @@ func Unquote(s string) (string, error) {
 		}
 		if quote == '\'' && len(s) != 0 {
 			// single-quoted must be single character
+			//
+			// 单引号中必须是单个字符
 			return "", ErrSyntax
 		}
 	}
@@ -423,6 +505,8 @@ func Unquote(s string) (string, error) {
 }
 
 // contains reports whether the string contains the byte c.
+//
+// contains 报告字符串是否包含字节 c。
 func contains(s string, c byte) bool {
 	for i := 0; i < len(s); i++ {
 		if s[i] == c {
@@ -434,6 +518,9 @@ func contains(s string, c byte) bool {
 
 // bsearch16 returns the smallest i such that a[i] >= x.
 // If there is no such i, bsearch16 returns len(a).
+//
+// bsearch16 返回满足 a[i] >= x 的最小的 i。
+// 如果没有这样的 i，bsearch16 返回 len(a)。
 func bsearch16(a []uint16, x uint16) int {
 	i, j := 0, len(a)
 	for i < j {
@@ -449,6 +536,9 @@ func bsearch16(a []uint16, x uint16) int {
 
 // bsearch32 returns the smallest i such that a[i] >= x.
 // If there is no such i, bsearch32 returns len(a).
+//
+// bsearch32 返回满足 a[i] >= x 的最小的 i。
+// 如果没有这样的 i，bsearch32 返回 len(a)。
 func bsearch32(a []uint32, x uint32) int {
 	i, j := 0, len(a)
 	for i < j {
@@ -467,19 +557,33 @@ func bsearch32(a []uint32, x uint32) int {
 // and therefore not pull in all the Unicode tables. If the linker were better
 // at tossing unused tables, we could get rid of this implementation.
 // That would be nice.
+//
+// TODO: IsPrint 是 unicode.IsPrint 的本地实现，测试验证了它们给出相同的结果。它使得这个包
+// 不需要依赖 unicode，因此不会引入所有的 Unicode 表。如果链接器能更好地丢弃未使用的表，我们
+// 就可以去掉这个实现了。那样会很好。
 
 // IsPrint reports whether the rune is defined as printable by Go, with
 // the same definition as unicode.IsPrint: letters, numbers, punctuation,
 // symbols and ASCII space.
+//
+// IsPrint 报告该 rune 是否被 Go 定义为可打印的，其定义与 unicode.IsPrint 相同：字母、数字、
+// 标点、符号和 ASCII 空格。
 func IsPrint(r rune) bool {
 	// Fast check for Latin-1
+	//
+	// Latin-1 的快速检查
 	if r <= 0xFF {
 		if 0x20 <= r && r <= 0x7E {
 			// All the ASCII is printable from space through DEL-1.
+			//
+			// 从空格到 DEL-1 的所有 ASCII 字符都是可打印的。
 			return true
 		}
 		if 0xA1 <= r && r <= 0xFF {
 			// Similarly for ¡ through ÿ...
+			//
+			// ¡ 到 ÿ 也类似……
+			// ……除了奇怪的软连字符。
 			return r != 0xAD // ...except for the bizarre soft hyphen.
 		}
 		return false
@@ -490,6 +594,14 @@ func IsPrint(r rune) bool {
 	// This is the index of either the start or end of a pair that might span x.
 	// The start is even (isPrint[i&^1]) and the end is odd (isPrint[i|1]).
 	// If we find x in a range, make sure x is not in isNotPrint list.
+	//
+	// 对 uint16 或 uint32 值使用相同的算法。
+	// 首先，找到第一个满足 isPrint[i] >= x 的 i。
+	// 它是某个可能覆盖 x 的区间的起点或终点的索引。
+	// 起点的索引是偶数（isPrint[i&^1]），终点的索引是奇数（isPrint[i|1]）。
+	// 如果我们发现 x 在某个区间中，还要确保 x 不在 isNotPrint 列表中。
+	// IMP: isPrint 表以 [起点, 终点] 对的形式存放可打印字符的区间，isNotPrint 存放这些区间
+	// 中零散的不可打印字符，这样比逐个列出可打印字符节省很多空间。
 
 	if 0 <= r && r < 1<<16 {
 		rr, isPrint, isNotPrint := uint16(r), isPrint16, isNotPrint16
@@ -506,6 +618,8 @@ func IsPrint(r rune) bool {
 	if i >= len(isPrint) || rr < isPrint[i&^1] || isPrint[i|1] < rr {
 		return false
 	}
+	// IMP: isNotPrint32 中的值都在 0x10000 到 0x1FFFF 之间，所以以 uint16 存放相对于
+	// 0x10000 的偏移量。
 	if r >= 0x20000 {
 		return true
 	}
@@ -517,6 +631,9 @@ func IsPrint(r rune) bool {
 // IsGraphic reports whether the rune is defined as a Graphic by Unicode. Such
 // characters include letters, marks, numbers, punctuation, symbols, and
 // spaces, from categories L, M, N, P, S, and Zs.
+//
+// IsGraphic 报告该 rune 是否被 Unicode 定义为图形字符。这些字符包括类别 L、M、N、P、S 和
+// Zs 中的字母、标记、数字、标点、符号和空格。
 func IsGraphic(r rune) bool {
 	if IsPrint(r) {
 		return true
@@ -527,8 +644,14 @@ func IsGraphic(r rune) bool {
 // isInGraphicList reports whether the rune is in the isGraphic list. This separation
 // from IsGraphic allows quoteWith to avoid two calls to IsPrint.
 // Should be called only if IsPrint fails.
+//
+// isInGraphicList 报告该 rune 是否在 isGraphic 列表中。将它从 IsGraphic 中分离出来，可以
+// 让 quoteWith 避免两次调用 IsPrint。
+// 只应该在 IsPrint 失败时调用。
 func isInGraphicList(r rune) bool {
 	// We know r must fit in 16 bits - see makeisprint.go.
+	//
+	// 我们知道 r 一定能放进 16 位——参见 makeisprint.go。
 	if r > 0xFFFF {
 		return false
 	}
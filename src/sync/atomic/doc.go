@@ -37,6 +37,45 @@
 // functions, are the atomic equivalents of "return *addr" and
 // "*addr = val".
 //
+// In addition to the functions, the Bool, Int32, Int64, Uint32, Uint64
+// and Uintptr types wrap a single value and expose the same operations
+// as methods, so that the value cannot be accessed non-atomically by
+// mistake.
+//
+// Package atomic 提供了底层的原子内存原语，它们可以用于实现同步算法。
+//
+// 想要正确地使用这些函数需要非常小心。除了特殊的底层应用，同步最好使用 channel 或者 sync 包
+// 提供的工具来完成。通过通信来共享内存，而不是通过共享内存来通信。
+//
+// 交换操作由 SwapT 函数实现，它的原子等价操作为：
+//
+//	old = *addr
+//	*addr = new
+//	return old
+//
+// 比较并交换操作由 CompareAndSwapT 函数实现，它的原子等价操作为：
+//
+//	if *addr == old {
+//		*addr = new
+//		return true
+//	}
+//	return false
+//
+// 加法操作由 AddT 函数实现，它的原子等价操作为：
+//
+//	*addr += delta
+//	return *addr
+//
+// 载入和储存操作由 LoadT 和 StoreT 函数实现，它们是 "return *addr" 和 "*addr = val" 的
+// 原子等价操作。
+//
+// 除了这些函数，Bool、Int32、Int64、Uint32、Uint64 和 Uintptr 类型包装了单个值，并以方法的
+// 形式提供了相同的操作，这样就不会因为失误而以非原子的方式访问该值。
+//
+// IMP: 这些函数只有声明没有函数体，它们由 asm.s 跳转到 runtime/internal/atomic 中的汇编
+// 实现（例如 amd64 上的 CompareAndSwapInt32 最终是一条 LOCK CMPXCHGL 指令）。
+// sync 包中的 Mutex、RWMutex、WaitGroup 和 Pool 都建立在这些原语之上，例如 Mutex.Lock
+// 的快速路径就是一次 CompareAndSwapInt32(&m.state, 0, mutexLocked)。
 package atomic
 
 import (
@@ -51,23 +90,42 @@ import (
 // alignment of 64-bit words accessed atomically. The first word in a
 // variable or in an allocated struct, array, or slice can be relied upon to be
 // 64-bit aligned.
+//
+// 在 x86-32 上，64 位函数使用了 Pentium MMX 之前不可用的指令。
+//
+// 在非 Linux 的 ARM 上，64 位函数使用了 ARMv6k 内核之前不可用的指令。
+//
+// 在 ARM 和 x86-32 上，调用者有责任保证原子访问的 64 位字是 64 位对齐的。变量或者分配的结构体、
+// 数组、切片中的第一个字可以被认为是 64 位对齐的。
 
 // SwapInt32 atomically stores new into *addr and returns the previous *addr value.
+//
+// SwapInt32 原子地将 new 储存到 *addr 中，并返回 *addr 之前的值。
 func SwapInt32(addr *int32, new int32) (old int32)
 
 // SwapInt64 atomically stores new into *addr and returns the previous *addr value.
+//
+// SwapInt64 原子地将 new 储存到 *addr 中，并返回 *addr 之前的值。
 func SwapInt64(addr *int64, new int64) (old int64)
 
 // SwapUint32 atomically stores new into *addr and returns the previous *addr value.
+//
+// SwapUint32 原子地将 new 储存到 *addr 中，并返回 *addr 之前的值。
 func SwapUint32(addr *uint32, new uint32) (old uint32)
 
 // SwapUint64 atomically stores new into *addr and returns the previous *addr value.
+//
+// SwapUint64 原子地将 new 储存到 *addr 中，并返回 *addr 之前的值。
 func SwapUint64(addr *uint64, new uint64) (old uint64)
 
 // SwapUintptr atomically stores new into *addr and returns the previous *addr value.
+//
+// SwapUintptr 原子地将 new 储存到 *addr 中，并返回 *addr 之前的值。
 func SwapUintptr(addr *uintptr, new uintptr) (old uintptr)
 
 // SwapPointer atomically stores new into *addr and returns the previous *addr value.
+//
+// SwapPointer 原子地将 new 储存到 *addr 中，并返回 *addr 之前的值。
 func SwapPointer(addr *unsafe.Pointer, new unsafe.Pointer) (old unsafe.Pointer)
 
 // CompareAndSwapInt32 executes the compare-and-swap operation for an int32 value.
@@ -76,76 +134,127 @@ func SwapPointer(addr *unsafe.Pointer, new unsafe.Pointer) (old unsafe.Pointer)
 func CompareAndSwapInt32(addr *int32, old, new int32) (swapped bool)
 
 // CompareAndSwapInt64 executes the compare-and-swap operation for an int64 value.
+//
+// CompareAndSwapInt64 执行比较并交换一个 int64 值的操作。
 func CompareAndSwapInt64(addr *int64, old, new int64) (swapped bool)
 
 // CompareAndSwapUint32 executes the compare-and-swap operation for a uint32 value.
+//
+// CompareAndSwapUint32 执行比较并交换一个 uint32 值的操作。
 func CompareAndSwapUint32(addr *uint32, old, new uint32) (swapped bool)
 
 // CompareAndSwapUint64 executes the compare-and-swap operation for a uint64 value.
+//
+// CompareAndSwapUint64 执行比较并交换一个 uint64 值的操作。
 func CompareAndSwapUint64(addr *uint64, old, new uint64) (swapped bool)
 
 // CompareAndSwapUintptr executes the compare-and-swap operation for a uintptr value.
+//
+// CompareAndSwapUintptr 执行比较并交换一个 uintptr 值的操作。
 func CompareAndSwapUintptr(addr *uintptr, old, new uintptr) (swapped bool)
 
 // CompareAndSwapPointer executes the compare-and-swap operation for a unsafe.Pointer value.
+//
+// CompareAndSwapPointer 执行比较并交换一个 unsafe.Pointer 值的操作。
 func CompareAndSwapPointer(addr *unsafe.Pointer, old, new unsafe.Pointer) (swapped bool)
 
 // AddInt32 atomically adds delta to *addr and returns the new value.
+//
+// AddInt32 原子地将 delta 加到 *addr 上，并返回新值。
 func AddInt32(addr *int32, delta int32) (new int32)
 
 // AddUint32 atomically adds delta to *addr and returns the new value.
 // To subtract a signed positive constant value c from x, do AddUint32(&x, ^uint32(c-1)).
 // In particular, to decrement x, do AddUint32(&x, ^uint32(0)).
+//
+// AddUint32 原子地将 delta 加到 *addr 上，并返回新值。
+// 要从 x 中减去一个有符号的正常量 c，执行 AddUint32(&x, ^uint32(c-1))。
+// 特别地，要将 x 减一，执行 AddUint32(&x, ^uint32(0))。
+// IMP: 在补码表示中 ^uint32(c-1) 等于 -c，所以加上它就相当于减去 c。
 func AddUint32(addr *uint32, delta uint32) (new uint32)
 
 // AddInt64 atomically adds delta to *addr and returns the new value.
+//
+// AddInt64 原子地将 delta 加到 *addr 上，并返回新值。
 func AddInt64(addr *int64, delta int64) (new int64)
 
 // AddUint64 atomically adds delta to *addr and returns the new value.
 // To subtract a signed positive constant value c from x, do AddUint64(&x, ^uint64(c-1)).
 // In particular, to decrement x, do AddUint64(&x, ^uint64(0)).
+//
+// AddUint64 原子地将 delta 加到 *addr 上，并返回新值。
+// 要从 x 中减去一个有符号的正常量 c，执行 AddUint64(&x, ^uint64(c-1))。
+// 特别地，要将 x 减一，执行 AddUint64(&x, ^uint64(0))。
 func AddUint64(addr *uint64, delta uint64) (new uint64)
 
 // AddUintptr atomically adds delta to *addr and returns the new value.
+//
+// AddUintptr 原子地将 delta 加到 *addr 上，并返回新值。
 func AddUintptr(addr *uintptr, delta uintptr) (new uintptr)
 
 // LoadInt32 atomically loads *addr.
+//
+// LoadInt32 原子地载入 *addr。
 func LoadInt32(addr *int32) (val int32)
 
 // LoadInt64 atomically loads *addr.
+//
+// LoadInt64 原子地载入 *addr。
 func LoadInt64(addr *int64) (val int64)
 
 // LoadUint32 atomically loads *addr.
+//
+// LoadUint32 原子地载入 *addr。
 func LoadUint32(addr *uint32) (val uint32)
 
 // LoadUint64 atomically loads *addr.
+//
+// LoadUint64 原子地载入 *addr。
 func LoadUint64(addr *uint64) (val uint64)
 
 // LoadUintptr atomically loads *addr.
+//
+// LoadUintptr 原子地载入 *addr。
 func LoadUintptr(addr *uintptr) (val uintptr)
 
 // LoadPointer atomically loads *addr.
+//
+// LoadPointer 原子地载入 *addr。
 func LoadPointer(addr *unsafe.Pointer) (val unsafe.Pointer)
 
 // StoreInt32 atomically stores val into *addr.
+//
+// StoreInt32 原子地将 val 储存到 *addr 中。
 func StoreInt32(addr *int32, val int32)
 
 // StoreInt64 atomically stores val into *addr.
+//
+// StoreInt64 原子地将 val 储存到 *addr 中。
 func StoreInt64(addr *int64, val int64)
 
 // StoreUint32 atomically stores val into *addr.
+//
+// StoreUint32 原子地将 val 储存到 *addr 中。
 func StoreUint32(addr *uint32, val uint32)
 
 // StoreUint64 atomically stores val into *addr.
+//
+// StoreUint64 原子地将 val 储存到 *addr 中。
 func StoreUint64(addr *uint64, val uint64)
 
 // StoreUintptr atomically stores val into *addr.
+//
+// StoreUintptr 原子地将 val 储存到 *addr 中。
 func StoreUintptr(addr *uintptr, val uintptr)
 
 // StorePointer atomically stores val into *addr.
+//
+// StorePointer 原子地将 val 储存到 *addr 中。
 func StorePointer(addr *unsafe.Pointer, val unsafe.Pointer)
 
 // Helper for ARM.  Linker will discard on other systems
+//
+// ARM 的辅助函数。在其他系统上链接器会丢弃它。
 func panic64() {
 	panic("sync/atomic: broken 64-bit atomic operations (buggy QEMU)")
 }
@@ -0,0 +1,264 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package atomic
+
+// IMP: 下面的类型只是在对应的函数外面包了一层，好处是值只能通过方法访问，不会被误写成
+// 普通的读写；同时它们包含 noCopy，go vet 的 copylocks 检查可以发现对它们的复制。
+
+// A Bool is an atomic boolean value.
+// The zero value is false.
+//
+// Bool 是一个原子的布尔值。
+// 零值为 false。
+type Bool struct {
+	_ noCopy
+	v uint32
+}
+
+// Load atomically loads and returns the value stored in x.
+//
+// Load 原子地载入并返回储存在 x 中的值。
+func (x *Bool) Load() bool { return LoadUint32(&x.v) != 0 }
+
+// Store atomically stores val into x.
+//
+// Store 原子地将 val 储存到 x 中。
+func (x *Bool) Store(val bool) { StoreUint32(&x.v, b32(val)) }
+
+// Swap atomically stores new into x and returns the previous value.
+//
+// Swap 原子地将 new 储存到 x 中，并返回之前的值。
+func (x *Bool) Swap(new bool) (old bool) { return SwapUint32(&x.v, b32(new)) != 0 }
+
+// CompareAndSwap executes the compare-and-swap operation for the boolean value x.
+//
+// CompareAndSwap 对布尔值 x 执行比较并交换操作。
+func (x *Bool) CompareAndSwap(old, new bool) (swapped bool) {
+	return CompareAndSwapUint32(&x.v, b32(old), b32(new))
+}
+
+// b32 returns a uint32 0 or 1 representing b.
+//
+// b32 返回表示 b 的 uint32 值 0 或 1。
+func b32(b bool) uint32 {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// An Int32 is an atomic int32.
+// The zero value is zero.
+//
+// Int32 是一个原子的 int32。
+// 零值为 0。
+type Int32 struct {
+	_ noCopy
+	v int32
+}
+
+// Load atomically loads and returns the value stored in x.
+//
+// Load 原子地载入并返回储存在 x 中的值。
+func (x *Int32) Load() int32 { return LoadInt32(&x.v) }
+
+// Store atomically stores val into x.
+//
+// Store 原子地将 val 储存到 x 中。
+func (x *Int32) Store(val int32) { StoreInt32(&x.v, val) }
+
+// Swap atomically stores new into x and returns the previous value.
+//
+// Swap 原子地将 new 储存到 x 中，并返回之前的值。
+func (x *Int32) Swap(new int32) (old int32) { return SwapInt32(&x.v, new) }
+
+// CompareAndSwap executes the compare-and-swap operation for x.
+//
+// CompareAndSwap 对 x 执行比较并交换操作。
+func (x *Int32) CompareAndSwap(old, new int32) (swapped bool) {
+	return CompareAndSwapInt32(&x.v, old, new)
+}
+
+// Add atomically adds delta to x and returns the new value.
+//
+// Add 原子地将 delta 加到 x 上，并返回新值。
+func (x *Int32) Add(delta int32) (new int32) { return AddInt32(&x.v, delta) }
+
+// An Int64 is an atomic int64.
+// The zero value is zero.
+//
+// On ARM and x86-32 the caller must arrange for a Int64 to be 64-bit
+// aligned, as for the Int64 functions; see the bugs section.
+//
+// Int64 是一个原子的 int64。
+// 零值为 0。
+//
+// 和 Int64 系列函数一样，在 ARM 和 x86-32 上调用者必须保证 Int64 是 64 位对齐的，参见 BUG
+// 部分。
+type Int64 struct {
+	_ noCopy
+	v int64
+}
+
+// Load atomically loads and returns the value stored in x.
+//
+// Load 原子地载入并返回储存在 x 中的值。
+func (x *Int64) Load() int64 { return LoadInt64(&x.v) }
+
+// Store atomically stores val into x.
+//
+// Store 原子地将 val 储存到 x 中。
+func (x *Int64) Store(val int64) { StoreInt64(&x.v, val) }
+
+// Swap atomically stores new into x and returns the previous value.
+//
+// Swap 原子地将 new 储存到 x 中，并返回之前的值。
+func (x *Int64) Swap(new int64) (old int64) { return SwapInt64(&x.v, new) }
+
+// CompareAndSwap executes the compare-and-swap operation for x.
+//
+// CompareAndSwap 对 x 执行比较并交换操作。
+func (x *Int64) CompareAndSwap(old, new int64) (swapped bool) {
+	return CompareAndSwapInt64(&x.v, old, new)
+}
+
+// Add atomically adds delta to x and returns the new value.
+//
+// Add 原子地将 delta 加到 x 上，并返回新值。
+func (x *Int64) Add(delta int64) (new int64) { return AddInt64(&x.v, delta) }
+
+// A Uint32 is an atomic uint32.
+// The zero value is zero.
+//
+// Uint32 是一个原子的 uint32。
+// 零值为 0。
+type Uint32 struct {
+	_ noCopy
+	v uint32
+}
+
+// Load atomically loads and returns the value stored in x.
+//
+// Load 原子地载入并返回储存在 x 中的值。
+func (x *Uint32) Load() uint32 { return LoadUint32(&x.v) }
+
+// Store atomically stores val into x.
+//
+// Store 原子地将 val 储存到 x 中。
+func (x *Uint32) Store(val uint32) { StoreUint32(&x.v, val) }
+
+// Swap atomically stores new into x and returns the previous value.
+//
+// Swap 原子地将 new 储存到 x 中，并返回之前的值。
+func (x *Uint32) Swap(new uint32) (old uint32) { return SwapUint32(&x.v, new) }
+
+// CompareAndSwap executes the compare-and-swap operation for x.
+//
+// CompareAndSwap 对 x 执行比较并交换操作。
+func (x *Uint32) CompareAndSwap(old, new uint32) (swapped bool) {
+	return CompareAndSwapUint32(&x.v, old, new)
+}
+
+// Add atomically adds delta to x and returns the new value.
+//
+// Add 原子地将 delta 加到 x 上，并返回新值。
+func (x *Uint32) Add(delta uint32) (new uint32) { return AddUint32(&x.v, delta) }
+
+// A Uint64 is an atomic uint64.
+// The zero value is zero.
+//
+// On ARM and x86-32 the caller must arrange for a Uint64 to be 64-bit
+// aligned, as for the Uint64 functions; see the bugs section.
+//
+// Uint64 是一个原子的 uint64。
+// 零值为 0。
+//
+// 和 Uint64 系列函数一样，在 ARM 和 x86-32 上调用者必须保证 Uint64 是 64 位对齐的，参见 BUG
+// 部分。
+type Uint64 struct {
+	_ noCopy
+	v uint64
+}
+
+// Load atomically loads and returns the value stored in x.
+//
+// Load 原子地载入并返回储存在 x 中的值。
+func (x *Uint64) Load() uint64 { return LoadUint64(&x.v) }
+
+// Store atomically stores val into x.
+//
+// Store 原子地将 val 储存到 x 中。
+func (x *Uint64) Store(val uint64) { StoreUint64(&x.v, val) }
+
+// Swap atomically stores new into x and returns the previous value.
+//
+// Swap 原子地将 new 储存到 x 中，并返回之前的值。
+func (x *Uint64) Swap(new uint64) (old uint64) { return SwapUint64(&x.v, new) }
+
+// CompareAndSwap executes the compare-and-swap operation for x.
+//
+// CompareAndSwap 对 x 执行比较并交换操作。
+func (x *Uint64) CompareAndSwap(old, new uint64) (swapped bool) {
+	return CompareAndSwapUint64(&x.v, old, new)
+}
+
+// Add atomically adds delta to x and returns the new value.
+//
+// Add 原子地将 delta 加到 x 上，并返回新值。
+func (x *Uint64) Add(delta uint64) (new uint64) { return AddUint64(&x.v, delta) }
+
+// A Uintptr is an atomic uintptr.
+// The zero value is zero.
+//
+// Uintptr 是一个原子的 uintptr。
+// 零值为 0。
+type Uintptr struct {
+	_ noCopy
+	v uintptr
+}
+
+// Load atomically loads and returns the value stored in x.
+//
+// Load 原子地载入并返回储存在 x 中的值。
+func (x *Uintptr) Load() uintptr { return LoadUintptr(&x.v) }
+
+// Store atomically stores val into x.
+//
+// Store 原子地将 val 储存到 x 中。
+func (x *Uintptr) Store(val uintptr) { StoreUintptr(&x.v, val) }
+
+// Swap atomically stores new into x and returns the previous value.
+//
+// Swap 原子地将 new 储存到 x 中，并返回之前的值。
+func (x *Uintptr) Swap(new uintptr) (old uintptr) { return SwapUintptr(&x.v, new) }
+
+// CompareAndSwap executes the compare-and-swap operation for x.
+//
+// CompareAndSwap 对 x 执行比较并交换操作。
+func (x *Uintptr) CompareAndSwap(old, new uintptr) (swapped bool) {
+	return CompareAndSwapUintptr(&x.v, old, new)
+}
+
+// Add atomically adds delta to x and returns the new value.
+//
+// Add 原子地将 delta 加到 x 上，并返回新值。
+func (x *Uintptr) Add(delta uintptr) (new uintptr) { return AddUintptr(&x.v, delta) }
+
+// noCopy may be added to structs which must not be copied
+// after the first use.
+//
+// See https://golang.org/issues/8005#issuecomment-190753527
+// for details.
+//
+// noCopy 可以添加到在第一次使用后不能被复制的结构体中。
+//
+// 详情请参阅 https://golang.org/issues/8005#issuecomment-190753527。
+type noCopy struct{}
+
+// Lock is a no-op used by -copylocks checker from `go vet`.
+//
+// Lock 是一个空操作，供 `go vet` 的 -copylocks 检查器使用。
+func (*noCopy) Lock()   {}
+func (*noCopy) Unlock() {}
@@ -0,0 +1,90 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package atomic_test
+
+import (
+	. "sync/atomic"
+	"testing"
+)
+
+func TestBool(t *testing.T) {
+	var x Bool
+	if x.Load() {
+		t.Fatal("zero value is true")
+	}
+	x.Store(true)
+	if !x.Load() {
+		t.Fatal("Store(true) did not store true")
+	}
+	if old := x.Swap(false); !old {
+		t.Fatal("Swap returned wrong old value")
+	}
+	if x.CompareAndSwap(true, true) {
+		t.Fatal("CompareAndSwap succeeded with wrong old value")
+	}
+	if !x.CompareAndSwap(false, true) || !x.Load() {
+		t.Fatal("CompareAndSwap failed")
+	}
+}
+
+func TestInt32Type(t *testing.T) {
+	var x Int32
+	if x.Add(-3) != -3 || x.Load() != -3 {
+		t.Fatal("Add(-3) failed")
+	}
+	x.Store(7)
+	if old := x.Swap(9); old != 7 || x.Load() != 9 {
+		t.Fatalf("Swap(9) = %d, Load = %d; want 7, 9", old, x.Load())
+	}
+	if x.CompareAndSwap(7, 1) || !x.CompareAndSwap(9, 1) || x.Load() != 1 {
+		t.Fatal("CompareAndSwap failed")
+	}
+}
+
+func TestInt64Type(t *testing.T) {
+	var x Int64
+	x.Store(1 << 40)
+	if x.Add(1) != 1<<40+1 {
+		t.Fatal("Add failed")
+	}
+	if old := x.Swap(-1); old != 1<<40+1 || x.Load() != -1 {
+		t.Fatal("Swap failed")
+	}
+	if !x.CompareAndSwap(-1, 0) || x.Load() != 0 {
+		t.Fatal("CompareAndSwap failed")
+	}
+}
+
+func TestUint32Type(t *testing.T) {
+	var x Uint32
+	x.Store(1)
+	if x.Add(^uint32(0)) != 0 {
+		t.Fatal("Add(^uint32(0)) did not decrement")
+	}
+	if old := x.Swap(5); old != 0 || !x.CompareAndSwap(5, 6) || x.Load() != 6 {
+		t.Fatal("Swap/CompareAndSwap failed")
+	}
+}
+
+func TestUint64Type(t *testing.T) {
+	var x Uint64
+	x.Store(1 << 63)
+	if x.Add(1) != 1<<63+1 {
+		t.Fatal("Add failed")
+	}
+	if old := x.Swap(2); old != 1<<63+1 || !x.CompareAndSwap(2, 3) || x.Load() != 3 {
+		t.Fatal("Swap/CompareAndSwap failed")
+	}
+}
+
+func TestUintptrType(t *testing.T) {
+	var x Uintptr
+	if x.Add(4) != 4 {
+		t.Fatal("Add failed")
+	}
+	if old := x.Swap(8); old != 4 || !x.CompareAndSwap(8, 16) || x.Load() != 16 {
+		t.Fatal("Swap/CompareAndSwap failed")
+	}
+}
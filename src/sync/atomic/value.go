@@ -13,11 +13,23 @@ import (
 // Once Store has been called, a Value must not be copied.
 //
 // A Value must not be copied after first use.
+//
+// Value 提供了对一个类型一致的值的原子载入和储存。
+// Value 的零值调用 Load 返回 nil。
+// 一旦调用了 Store，就不能再复制 Value。
+//
+// 在第一次使用后，一定不能复制 Value。
+//
+// IMP: interface{} 由类型指针和数据指针两个字组成，没有指令可以原子地同时更新两个字。
+// Value 的做法是：类型只在第一次 Store 时写入一次，之后只原子地替换数据指针，所以要求
+// 所有 Store 的值具体类型相同。
 type Value struct {
 	v interface{}
 }
 
 // ifaceWords is interface{} internal representation.
+//
+// ifaceWords 是 interface{} 的内部表示。
 type ifaceWords struct {
 	typ  unsafe.Pointer
 	data unsafe.Pointer
@@ -25,11 +37,17 @@ type ifaceWords struct {
 
 // Load returns the value set by the most recent Store.
 // It returns nil if there has been no call to Store for this Value.
+//
+// Load 返回最近一次 Store 设置的值。
+// 如果还没有对该 Value 调用过 Store，它返回 nil。
 func (v *Value) Load() (x interface{}) {
 	vp := (*ifaceWords)(unsafe.Pointer(v))
 	typ := LoadPointer(&vp.typ)
+	// IMP: typ 为 ^uintptr(0) 表示第一次 Store 正在进行中，此时 data 可能还没有写入。
 	if typ == nil || uintptr(typ) == ^uintptr(0) {
 		// First store not yet completed.
+		//
+		// 第一次储存尚未完成。
 		return nil
 	}
 	data := LoadPointer(&vp.data)
@@ -42,6 +60,10 @@ func (v *Value) Load() (x interface{}) {
 // Store sets the value of the Value to x.
 // All calls to Store for a given Value must use values of the same concrete type.
 // Store of an inconsistent type panics, as does Store(nil).
+//
+// Store 将 Value 的值设置为 x。
+// 对同一个 Value 的所有 Store 调用必须使用相同具体类型的值。
+// Store 一个不一致类型的值会 panic，Store(nil) 也会 panic。
 func (v *Value) Store(x interface{}) {
 	if x == nil {
 		panic("sync/atomic: store of nil value into Value")
@@ -55,12 +77,21 @@ func (v *Value) Store(x interface{}) {
 			// Disable preemption so that other goroutines can use
 			// active spin wait to wait for completion; and so that
 			// GC does not see the fake type accidentally.
+			//
+			// 尝试开始第一次储存。
+			// 禁止抢占，这样其他 goroutine 可以使用主动自旋来等待它完成，同时 GC 也不会意外地
+			// 看到伪造的类型。
 			runtime_procPin()
+			// IMP: 用 ^uintptr(0) 这个不可能是真实类型指针的值占位，CAS 成功的 goroutine
+			// 获得了完成第一次储存的权利。
 			if !CompareAndSwapPointer(&vp.typ, nil, unsafe.Pointer(^uintptr(0))) {
 				runtime_procUnpin()
 				continue
 			}
 			// Complete first store.
+			//
+			// 完成第一次储存。
+			// IMP: 先写 data 再写 typ，Load 看到真实的 typ 时 data 一定已经写好了。
 			StorePointer(&vp.data, xp.data)
 			StorePointer(&vp.typ, xp.typ)
 			runtime_procUnpin()
@@ -70,9 +101,14 @@ func (v *Value) Store(x interface{}) {
 			// First store in progress. Wait.
 			// Since we disable preemption around the first store,
 			// we can wait with active spinning.
+			//
+			// 第一次储存正在进行中，等待。
+			// 由于第一次储存时禁止了抢占，所以我们可以使用主动自旋来等待。
 			continue
 		}
 		// First store completed. Check type and overwrite data.
+		//
+		// 第一次储存已完成。检查类型并覆盖数据。
 		if typ != xp.typ {
 			panic("sync/atomic: store of inconsistently typed value into Value")
 		}
@@ -82,5 +118,7 @@ func (v *Value) Store(x interface{}) {
 }
 
 // Disable/enable preemption, implemented in runtime.
+//
+// 禁止/允许抢占，在运行时中实现。
 func runtime_procPin()
 func runtime_procUnpin()
@@ -0,0 +1,62 @@
+// Copyright 2018 Li Zebang. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package sync_test
+
+import (
+	"diag"
+	. "sync"
+	"testing"
+	"time"
+)
+
+// mutexLogger serializes its events with a Mutex, as log.Logger does.
+type mutexLogger struct {
+	mu     Mutex
+	events []string
+}
+
+func (l *mutexLogger) Enabled(pkg string, level diag.Level) bool {
+	return pkg == "sync"
+}
+
+func (l *mutexLogger) Log(pkg string, level diag.Level, msg string, keyvals ...interface{}) {
+	l.mu.Lock()
+	l.events = append(l.events, msg)
+	l.mu.Unlock()
+}
+
+func TestMutexContentionMutexLogger(t *testing.T) {
+	l := new(mutexLogger)
+	diag.SetLogger(l)
+	defer diag.SetLogger(nil)
+
+	var m Mutex
+	m.Lock()
+	l.mu.Lock()
+	done := make(chan bool)
+	go func() {
+		m.Lock()
+		m.Unlock()
+		done <- true
+	}()
+	time.Sleep(10 * time.Millisecond)
+	// The goroutine reports the contention of m, and Log waits for l.mu.
+	// Once it gets l.mu, the contention of l.mu must not be reported to
+	// Log again while it holds l.mu.
+	m.Unlock()
+	time.Sleep(10 * time.Millisecond)
+	l.mu.Unlock()
+	select {
+	case <-done:
+	case <-time.After(10 * time.Second):
+		t.Fatal("Lock deadlocked reporting its contention to a Logger that locks a Mutex")
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if len(l.events) != 1 || l.events[0] != "mutex contended" {
+		t.Errorf("events = %q; want one mutex contended", l.events)
+	}
+}
@@ -0,0 +1,17 @@
+// Copyright 2018 Li Zebang. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+// +build go1.12
+
+package sync
+
+// The portable Pool has a single local cache, so the tests cannot migrate
+// to another one between Put and Get and need not pin. Pinning here would
+// deadlock, since the pin of runtime_portable.go is not reentrant.
+//
+// 可移植的 Pool 只有一个本地缓存，所以测试在 Put 和 Get 之间不会迁移到另一个缓存上，不需要
+// 固定。在这里固定会导致死锁，因为 runtime_portable.go 中的固定是不可重入的。
+
+func Runtime_procPin() int { return 0 }
+func Runtime_procUnpin()   {}
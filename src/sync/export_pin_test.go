@@ -0,0 +1,11 @@
+// Copyright 2018 Li Zebang. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+// +build !go1.12
+
+package sync
+
+// Export for testing.
+var Runtime_procPin = runtime_procPin
+var Runtime_procUnpin = runtime_procUnpin
@@ -0,0 +1,7 @@
+module github.com/lizebang/annotate-go-sdk/src/sync
+
+go 1.11
+
+require github.com/lizebang/annotate-go-sdk/src/diag v0.0.0
+
+replace github.com/lizebang/annotate-go-sdk/src/diag => ../diag
@@ -0,0 +1,171 @@
+// Copyright 2018 Li Zebang. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+// +build go1.12
+
+// Package portabletest runs the portable runtime hooks of sync, which
+// runtime_portable.go provides when sync is built as a module. The upstream
+// tests of sync import it by its standard path and so cannot test them:
+//
+//	cd src/sync && go test ./internal/portabletest && go test -race ./internal/portabletest
+//
+// portabletest 包运行 sync 被当作模块构建时由 runtime_portable.go 提供的可移植运行时钩子。
+// sync 的上游测试按照标准库的路径导入它，所以无法测试这些钩子。
+package portabletest_test
+
+import (
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/lizebang/annotate-go-sdk/src/sync"
+)
+
+func TestMutex(t *testing.T) {
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	n := 0
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 1000; j++ {
+				mu.Lock()
+				n++
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	if n != 8000 {
+		t.Errorf("n = %d; want 8000", n)
+	}
+}
+
+func TestRWMutex(t *testing.T) {
+	var mu sync.RWMutex
+	var wg sync.WaitGroup
+	n := 0
+	for i := 0; i < 4; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 500; j++ {
+				mu.Lock()
+				n++
+				mu.Unlock()
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 500; j++ {
+				mu.RLock()
+				_ = n
+				mu.RUnlock()
+			}
+		}()
+	}
+	wg.Wait()
+	if n != 2000 {
+		t.Errorf("n = %d; want 2000", n)
+	}
+}
+
+func TestCond(t *testing.T) {
+	var mu sync.Mutex
+	c := sync.NewCond(&mu)
+	ready, woken := 0, 0
+	done := make(chan bool)
+	for i := 0; i < 4; i++ {
+		go func() {
+			mu.Lock()
+			ready++
+			c.Wait()
+			woken++
+			mu.Unlock()
+			done <- true
+		}()
+	}
+	for {
+		mu.Lock()
+		if ready == 4 {
+			break
+		}
+		mu.Unlock()
+		runtime.Gosched()
+	}
+	c.Signal()
+	mu.Unlock()
+	<-done
+	mu.Lock()
+	c.Broadcast()
+	mu.Unlock()
+	for i := 0; i < 3; i++ {
+		<-done
+	}
+	if woken != 4 {
+		t.Errorf("woken = %d; want 4", woken)
+	}
+}
+
+func TestPool(t *testing.T) {
+	var p sync.Pool
+	p.Put("a")
+	if g := p.Get(); g != nil && g != "a" {
+		t.Fatalf("got %#v; want a or nil", g)
+	}
+
+	// The cleanup runs in a finalizer after a collection, so it may take
+	// more than one.
+	//
+	// 清理在垃圾回收之后由终结器执行，所以可能需要不止一次垃圾回收。
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		p.Put("b")
+		runtime.GC()
+		time.Sleep(time.Millisecond)
+		if p.Get() == nil {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("Pool was not cleaned up after a collection")
+		}
+	}
+}
+
+// TestPoolCleanup uses pools while their cleanup runs, and under -race
+// checks that it is ordered with their first use.
+//
+// TestPoolCleanup 在清理执行的同时使用 Pool，并且在 -race 下检查清理与 Pool 的第一次使用之间
+// 是有序的。
+func TestPoolCleanup(t *testing.T) {
+	stop := make(chan bool)
+	gcDone := make(chan bool)
+	go func() {
+		defer close(gcDone)
+		for {
+			select {
+			case <-stop:
+				return
+			default:
+				runtime.GC()
+			}
+		}
+	}()
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 200; j++ {
+				p := &sync.Pool{New: func() interface{} { return new(int) }}
+				p.Put(p.Get())
+				p.Get()
+			}
+		}()
+	}
+	wg.Wait()
+	close(stop)
+	<-gcDone
+}
@@ -17,13 +17,10 @@
 package sync
 
 import (
-	"internal/race"
 	"sync/atomic"
 	"unsafe"
 )
 
-func throw(string) // provided by runtime // 由运行时提供
-
 // A Mutex is a mutual exclusion lock.
 // The zero value for a Mutex is an unlocked mutex.
 //
@@ -126,14 +123,17 @@ const (
 //
 // Lock 将 m 上锁。
 // 如果 lock 已经在使用，调用的 goroutine 将阻塞到 mutex 可用。
+//
+//go:norace
 func (m *Mutex) Lock() {
 	// Fast path: grab unlocked mutex.
 	//
 	// 快速途径：获取 unlocked 状态到 mutex。
 	// IMP: m.state 等于 0 时，将 m.state 置为 1（mutexLocked），完成上锁过程。
+	// 比较并交换操作的语义参见 sync/atomic/doc.go。
 	if atomic.CompareAndSwapInt32(&m.state, 0, mutexLocked) {
-		if race.Enabled {
-			race.Acquire(unsafe.Pointer(m))
+		if raceEnabled {
+			raceAcquire(unsafe.Pointer(m))
 		}
 		return
 	}
@@ -245,11 +245,42 @@ func (m *Mutex) Lock() {
 		}
 	}
 
-	if race.Enabled {
-		race.Acquire(unsafe.Pointer(m))
+	if waitStartTime != 0 {
+		// 等待过的 Lock 会报告争用
+		m.reportContention(waitStartTime, starving) // a Lock that had to wait reports the contention
+	}
+
+	if raceEnabled {
+		raceAcquire(unsafe.Pointer(m))
 	}
 }
 
+// reportingContention is 1 while a contention is being reported. A Logger
+// that locks a Mutex in Log could otherwise report the contention of that
+// Mutex from inside Log, and lock it again or recurse without bound.
+//
+// reportingContention 在报告争用的过程中为 1。否则在 Log 中对一个 Mutex 加锁的 Logger
+// 可能会在 Log 内部报告这个 Mutex 的争用，从而再次对它加锁或者无限地递归。
+var reportingContention uint32
+
+// reportContention reports to the diag Logger how long Lock waited for m,
+// as a warning if the wait made m starving. The contentions that happen
+// while another one is being reported are dropped.
+//
+// reportContention 将 Lock 等待 m 的时间报告给 diag 的 Logger，如果等待使 m 进入了饥饿
+// 状态则报告为警告。在报告另一个争用的过程中发生的争用会被丢弃。
+func (m *Mutex) reportContention(waitStartTime int64, starving bool) {
+	level := diagDebug
+	if starving {
+		level = diagWarn
+	}
+	if !diagEnabled(level) || !atomic.CompareAndSwapUint32(&reportingContention, 0, 1) {
+		return
+	}
+	defer atomic.StoreUint32(&reportingContention, 0)
+	diagLog(level, "mutex contended", "mutex", unsafe.Pointer(m), "waitns", runtime_nanotime()-waitStartTime, "starving", starving)
+}
+
 // Unlock unlocks m.
 // It is a run-time error if m is not locked on entry to Unlock.
 //
@@ -261,10 +292,12 @@ func (m *Mutex) Lock() {
 // 如果在解锁 m 前未被上锁，将会产生一个运行时错误。
 // 一个被上锁的 Mutex 没有和特定的 goroutine 关联起来。
 // 允许一个 goroutine 锁定 Mutex，然后安排另一个 goroutine 解锁。
+//
+//go:norace
 func (m *Mutex) Unlock() {
-	if race.Enabled {
+	if raceEnabled {
 		_ = m.state
-		race.Release(unsafe.Pointer(m))
+		raceRelease(unsafe.Pointer(m))
 	}
 
 	// Fast path: drop lock bit.
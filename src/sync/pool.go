@@ -5,7 +5,6 @@
 package sync
 
 import (
-	"internal/race"
 	"runtime"
 	"sync/atomic"
 	"unsafe"
@@ -72,9 +71,6 @@ type poolLocal struct {
 	pad [128 - unsafe.Sizeof(poolLocalInternal{})%128]byte
 }
 
-// from runtime
-func fastrand() uint32
-
 var poolRaceHash [128]uint64
 
 // poolRaceAddr returns an address to use as the synchronization point
@@ -89,17 +85,19 @@ func poolRaceAddr(x interface{}) unsafe.Pointer {
 }
 
 // Put adds x to the pool.
+//
+//go:norace
 func (p *Pool) Put(x interface{}) {
 	if x == nil {
 		return
 	}
-	if race.Enabled {
+	if raceEnabled {
 		if fastrand()%4 == 0 {
 			// Randomly drop x on floor.
 			return
 		}
-		race.ReleaseMerge(poolRaceAddr(x))
-		race.Disable()
+		raceReleaseMerge(poolRaceAddr(x))
+		raceDisable()
 	}
 	l := p.pin()
 	if l.private == nil {
@@ -112,8 +110,8 @@ func (p *Pool) Put(x interface{}) {
 		l.shared = append(l.shared, x)
 		l.Unlock()
 	}
-	if race.Enabled {
-		race.Enable()
+	if raceEnabled {
+		raceEnable()
 	}
 }
 
@@ -127,9 +125,11 @@ func (p *Pool) Put(x interface{}) {
 // the result of calling p.New.
 //
 // Get
+//
+//go:norace
 func (p *Pool) Get() interface{} {
-	if race.Enabled {
-		race.Disable()
+	if raceEnabled {
+		raceDisable()
 	}
 	l := p.pin()
 	x := l.private
@@ -147,10 +147,10 @@ func (p *Pool) Get() interface{} {
 			x = p.getSlow()
 		}
 	}
-	if race.Enabled {
-		race.Enable()
+	if raceEnabled {
+		raceEnable()
 		if x != nil {
-			race.Acquire(poolRaceAddr(x))
+			raceAcquire(poolRaceAddr(x))
 		}
 	}
 	if x == nil && p.New != nil {
@@ -159,6 +159,7 @@ func (p *Pool) Get() interface{} {
 	return x
 }
 
+//go:norace
 func (p *Pool) getSlow() (x interface{}) {
 	// See the comment in pin regarding ordering of the loads.
 	size := atomic.LoadUintptr(&p.localSize) // load-acquire
@@ -183,6 +184,8 @@ func (p *Pool) getSlow() (x interface{}) {
 
 // pin pins the current goroutine to P, disables preemption and returns poolLocal pool for the P.
 // Caller must call runtime_procUnpin() when done with the pool.
+//
+//go:norace
 func (p *Pool) pin() *poolLocal {
 	pid := runtime_procPin()
 	// In pinSlow we store to localSize and then to local, here we load in opposite order.
@@ -197,6 +200,7 @@ func (p *Pool) pin() *poolLocal {
 	return p.pinSlow()
 }
 
+//go:norace
 func (p *Pool) pinSlow() *poolLocal {
 	// Retry under the mutex.
 	// Can not lock the mutex while pinned.
@@ -221,6 +225,7 @@ func (p *Pool) pinSlow() *poolLocal {
 	return &local[pid]
 }
 
+//go:norace
 func poolCleanup() {
 	// This function is called with the world stopped, at the beginning of a garbage collection.
 	// It must not allocate and probably should not call any runtime functions.
@@ -257,8 +262,3 @@ func indexLocal(l unsafe.Pointer, i int) *poolLocal {
 	lp := unsafe.Pointer(uintptr(l) + uintptr(i)*unsafe.Sizeof(poolLocal{}))
 	return (*poolLocal)(lp)
 }
-
-// Implemented in runtime.
-func runtime_registerPoolCleanup(cleanup func())
-func runtime_procPin() int
-func runtime_procUnpin()
@@ -0,0 +1,34 @@
+// Copyright 2018 Li Zebang. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+// +build !go1.12
+
+package sync
+
+import (
+	"internal/race"
+	"unsafe"
+)
+
+// When this tree is built as a GOROOT, its own Go 1.11 toolchain does not
+// satisfy the go1.12 release tag and sync reports its synchronization
+// events to the race detector through internal/race. Any newer toolchain
+// builds sync as a module instead, where internal/race cannot be imported,
+// and gets race_portable_race.go with -race and the no-ops in
+// race_portable.go without it.
+//
+// 把本仓库当作 GOROOT 构建时，它自己的 Go 1.11 工具链不满足 go1.12 这个发布标签，sync
+// 会通过 internal/race 向竞态检测器报告同步事件。任何更新的工具链都会把 sync 当作一个模块
+// 来构建，此时无法导入 internal/race，使用 -race 时用的是 race_portable_race.go，否则是
+// race_portable.go 中的空操作。
+
+const raceEnabled = race.Enabled
+
+func raceAcquire(addr unsafe.Pointer)      { race.Acquire(addr) }
+func raceRelease(addr unsafe.Pointer)      { race.Release(addr) }
+func raceReleaseMerge(addr unsafe.Pointer) { race.ReleaseMerge(addr) }
+func raceDisable()                         { race.Disable() }
+func raceEnable()                          { race.Enable() }
+func raceRead(addr unsafe.Pointer)         { race.Read(addr) }
+func raceWrite(addr unsafe.Pointer)        { race.Write(addr) }
@@ -0,0 +1,26 @@
+// Copyright 2018 Li Zebang. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+// +build go1.12,!race
+
+package sync
+
+import "unsafe"
+
+// Portable versions of the functions that race.go takes from internal/race,
+// used when sync is built as a module without the race detector, where they
+// are all no-ops. race_portable_race.go reports to the race detector.
+//
+// race.go 从 internal/race 中获取的函数的可移植版本，在 sync 被当作模块构建并且没有开启
+// 竞态检测器时使用，它们都是空操作。race_portable_race.go 会向竞态检测器报告。
+
+const raceEnabled = false
+
+func raceAcquire(addr unsafe.Pointer)      {}
+func raceRelease(addr unsafe.Pointer)      {}
+func raceReleaseMerge(addr unsafe.Pointer) {}
+func raceDisable()                         {}
+func raceEnable()                          {}
+func raceRead(addr unsafe.Pointer)         {}
+func raceWrite(addr unsafe.Pointer)        {}
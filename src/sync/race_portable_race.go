@@ -0,0 +1,34 @@
+// Copyright 2018 Li Zebang. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+// +build go1.12,race
+
+package sync
+
+import (
+	"runtime"
+	"unsafe"
+)
+
+// Portable versions of the functions that race.go takes from internal/race,
+// used when sync is built as a module with -race. internal/race wraps the
+// same functions of package runtime, which a module can call directly.
+//
+// race.go 从 internal/race 中获取的函数的可移植版本，在 sync 被当作模块并且使用 -race
+// 构建时使用。internal/race 包装的也是 runtime 包中的这些函数，模块可以直接调用它们。
+//
+// IMP: 工具链从不对标准库 sync 中的内存访问做插桩，而 Mutex 和 WaitGroup 会不加原子操作
+// 地读取自己的状态，Pool 则在 raceDisable 之后才读写它的本地缓存和 allPools，这期间的同步
+// 竞态检测器都看不到。模块版本的 sync 会被完整地插桩，所以这些方法和 runtime_portable.go
+// 中的信号量、stopPools 都标记了 go:norace，它们只通过这里的函数向竞态检测器报告同步事件。
+
+const raceEnabled = true
+
+func raceAcquire(addr unsafe.Pointer)      { runtime.RaceAcquire(addr) }
+func raceRelease(addr unsafe.Pointer)      { runtime.RaceRelease(addr) }
+func raceReleaseMerge(addr unsafe.Pointer) { runtime.RaceReleaseMerge(addr) }
+func raceDisable()                         { runtime.RaceDisable() }
+func raceEnable()                          { runtime.RaceEnable() }
+func raceRead(addr unsafe.Pointer)         { runtime.RaceRead(addr) }
+func raceWrite(addr unsafe.Pointer)        { runtime.RaceWrite(addr) }
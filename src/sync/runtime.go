@@ -2,10 +2,15 @@
 // Use of this source code is governed by a BSD-style
 // license that can be found in the LICENSE file.
 
+// +build !go1.12
+
 package sync
 
 import "unsafe"
 
+// IMP: 这些函数都由运行时通过 go:linkname 提供，只有把本仓库当作 GOROOT 构建时才存在。
+// 用 Go 1.12 及以上版本把 sync 当作模块构建时，使用的是 runtime_portable.go 中的实现。
+
 // defined in package runtime
 
 // Semacquire waits until *s > 0 and then atomically decrements it.
@@ -70,3 +75,13 @@ func runtime_canSpin(i int) bool
 func runtime_doSpin()
 
 func runtime_nanotime() int64
+
+func throw(string) // provided by runtime // 由运行时提供
+
+// from runtime
+func fastrand() uint32
+
+// Implemented in runtime.
+func runtime_registerPoolCleanup(cleanup func())
+func runtime_procPin() int
+func runtime_procUnpin()
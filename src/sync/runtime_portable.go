@@ -0,0 +1,378 @@
+// Copyright 2018 Li Zebang. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+// +build go1.12
+
+package sync
+
+import (
+	"os"
+	"runtime"
+	"sync/atomic"
+	"time"
+	"unsafe"
+)
+
+// Portable versions of the functions that runtime.go declares and the
+// runtime provides, used when sync is built as a module. The runtime only
+// links its hooks into the package whose import path is "sync", so here
+// they are built on channels, the scheduler and package time.
+//
+// runtime.go 中声明并由运行时提供的函数的可移植版本，在 sync 被当作模块构建时使用。
+// 运行时只会把它的钩子链接到导入路径为 "sync" 的包中，所以这里基于 channel、调度器和
+// time 包来实现它们。
+//
+// IMP: 这里的实现只保证正确，不追求性能：信号量和 notifyList 的等待者都阻塞在 channel 上，
+// 不会自旋，也不会把信号量直接交给等待者（handoff 被忽略）；Pool 的所有 P 本地缓存退化成
+// 一个，由 runtime_procPin 串行化。
+
+// A spinLock is a lock whose zero value is unlocked. It yields the
+// processor while it waits and is only held for a few instructions.
+//
+// spinLock 是零值为未加锁状态的锁。它在等待时会让出处理器，并且只会被持有很短的时间。
+type spinLock uint32
+
+func (l *spinLock) lock() {
+	for !atomic.CompareAndSwapUint32((*uint32)(l), 0, 1) {
+		runtime.Gosched()
+	}
+}
+
+func (l *spinLock) unlock() {
+	atomic.StoreUint32((*uint32)(l), 0)
+}
+
+// semTabSize is the number of roots in semTable, as in runtime/sema.go.
+//
+// semTabSize 是 semTable 中根的数量，与 runtime/sema.go 中的相同。
+const semTabSize = 251
+
+// semTable holds the goroutines blocked in a semaphore. The waiters of a
+// semaphore are kept on the root its address hashes to.
+//
+// semTable 保存了阻塞在信号量上的 goroutine。一个信号量的等待者保存在它的地址散列到的
+// 那个根上。
+//
+// IMP: 和运行时一样使用固定大小的数组而不是 map。map 的读写由运行时报告给竞态检测器，
+// 而 go:norace 函数中对这些链表的普通读写不会被报告。
+var semTable [semTabSize]semaRoot
+
+// A semaRoot holds a list of waiters, in the order they are woken.
+//
+// semaRoot 保存了一个等待者的链表，按照唤醒的顺序排列。
+type semaRoot struct {
+	lock spinLock
+	head *semWaiter
+}
+
+type semWaiter struct {
+	s     *uint32
+	ready chan struct{}
+	next  *semWaiter
+}
+
+//go:norace
+func semroot(s *uint32) *semaRoot {
+	return &semTable[(uintptr(unsafe.Pointer(s))>>3)%semTabSize]
+}
+
+// cansemacquire decrements *s if it is positive and reports whether it did.
+//
+// cansemacquire 在 *s 为正数时将它减一，并报告是否这样做了。
+func cansemacquire(s *uint32) bool {
+	for {
+		v := atomic.LoadUint32(s)
+		if v == 0 {
+			return false
+		}
+		if atomic.CompareAndSwapUint32(s, v, v-1) {
+			return true
+		}
+	}
+}
+
+//go:norace
+func semacquire(s *uint32, lifo bool) {
+	if cansemacquire(s) {
+		return
+	}
+	root := semroot(s)
+	root.lock.lock()
+	// Semrelease increments *s before it looks for waiters, so checking
+	// again under the lock cannot miss a wakeup.
+	//
+	// Semrelease 在查找等待者之前就增加了 *s，所以在锁中再检查一次不会错过唤醒。
+	if cansemacquire(s) {
+		root.lock.unlock()
+		return
+	}
+	w := &semWaiter{s: s, ready: make(chan struct{})}
+	// A lifo waiter goes before the other waiters of s, any other one
+	// after them.
+	//
+	// lifo 的等待者排在 s 的其他等待者之前，其他的则排在它们之后。
+	pp := &root.head
+	if lifo {
+		for *pp != nil && (*pp).s != s {
+			pp = &(*pp).next
+		}
+	} else {
+		for *pp != nil {
+			pp = &(*pp).next
+		}
+	}
+	w.next = *pp
+	*pp = w
+	root.lock.unlock()
+	<-w.ready
+}
+
+func runtime_Semacquire(s *uint32) {
+	semacquire(s, false)
+}
+
+func runtime_SemacquireMutex(s *uint32, lifo bool) {
+	semacquire(s, lifo)
+}
+
+//go:norace
+func runtime_Semrelease(s *uint32, handoff bool) {
+	atomic.AddUint32(s, 1)
+	root := semroot(s)
+	root.lock.lock()
+	pp := &root.head
+	for *pp != nil && (*pp).s != s {
+		pp = &(*pp).next
+	}
+	// The count goes to the first waiter, unless a goroutine that has not
+	// blocked yet took it first.
+	//
+	// 计数会交给第一个等待者，除非一个还没有阻塞的 goroutine 先拿走了它。
+	if w := *pp; w != nil && cansemacquire(s) {
+		*pp = w.next
+		close(w.ready)
+	}
+	root.lock.unlock()
+}
+
+// notifyList is a ticket-based notification list, as in runtime/sema.go.
+// Its zero value is ready to use.
+//
+// notifyList 是一个基于票号的通知列表，与 runtime/sema.go 中的相同。它的零值可以直接使用。
+type notifyList struct {
+	// wait is the ticket number of the next waiter.
+	//
+	// wait 是下一个等待者的票号。
+	wait uint32
+
+	// notify is the ticket number of the next waiter to be notified.
+	//
+	// notify 是下一个要被通知的等待者的票号。
+	notify uint32
+
+	lock spinLock
+	head *notifyWaiter
+	tail *notifyWaiter
+}
+
+type notifyWaiter struct {
+	ticket uint32
+	ready  chan struct{}
+	next   *notifyWaiter
+}
+
+// less checks if a < b, considering a & b running counts that may overflow
+// the 32-bit range.
+//
+// less 检查 a 是否小于 b，a 和 b 是可能超出 32 位范围的计数。
+func less(a, b uint32) bool {
+	return int32(a-b) < 0
+}
+
+func runtime_notifyListAdd(l *notifyList) uint32 {
+	return atomic.AddUint32(&l.wait, 1) - 1
+}
+
+func runtime_notifyListWait(l *notifyList, t uint32) {
+	l.lock.lock()
+	// Return right away if this ticket has already been notified.
+	//
+	// 如果这个票号已经被通知过了，立即返回。
+	if less(t, l.notify) {
+		l.lock.unlock()
+		return
+	}
+	w := &notifyWaiter{ticket: t, ready: make(chan struct{})}
+	if l.tail == nil {
+		l.head = w
+	} else {
+		l.tail.next = w
+	}
+	l.tail = w
+	l.lock.unlock()
+	<-w.ready
+}
+
+func runtime_notifyListNotifyAll(l *notifyList) {
+	if atomic.LoadUint32(&l.wait) == atomic.LoadUint32(&l.notify) {
+		return
+	}
+	l.lock.lock()
+	w := l.head
+	l.head = nil
+	l.tail = nil
+	atomic.StoreUint32(&l.notify, atomic.LoadUint32(&l.wait))
+	l.lock.unlock()
+	for w != nil {
+		next := w.next
+		close(w.ready)
+		w = next
+	}
+}
+
+func runtime_notifyListNotifyOne(l *notifyList) {
+	if atomic.LoadUint32(&l.wait) == atomic.LoadUint32(&l.notify) {
+		return
+	}
+	l.lock.lock()
+	t := l.notify
+	if t == atomic.LoadUint32(&l.wait) {
+		l.lock.unlock()
+		return
+	}
+	atomic.StoreUint32(&l.notify, t+1)
+	// The waiter with ticket t may not be in the list yet. It will then
+	// see that it has been notified in runtime_notifyListWait.
+	//
+	// 票号为 t 的等待者可能还不在列表中，那么它会在 runtime_notifyListWait 中发现自己
+	// 已经被通知了。
+	var prev *notifyWaiter
+	for w := l.head; w != nil; prev, w = w, w.next {
+		if w.ticket != t {
+			continue
+		}
+		if prev == nil {
+			l.head = w.next
+		} else {
+			prev.next = w.next
+		}
+		if l.tail == w {
+			l.tail = prev
+		}
+		close(w.ready)
+		break
+	}
+	l.lock.unlock()
+}
+
+// runtime_canSpin always reports false, the portable Mutex never spins.
+//
+// runtime_canSpin 总是返回 false，可移植的 Mutex 从不自旋。
+func runtime_canSpin(i int) bool {
+	return false
+}
+
+func runtime_doSpin() {}
+
+var startTime = time.Now()
+
+// runtime_nanotime returns a monotonic time in nanoseconds.
+//
+// runtime_nanotime 返回以纳秒为单位的单调时间。
+func runtime_nanotime() int64 {
+	return int64(time.Since(startTime))
+}
+
+// throw reports a fatal error. Like the runtime's, it cannot be recovered.
+//
+// throw 报告一个致命错误。和运行时中的一样，它无法被 recover。
+func throw(s string) {
+	print("fatal error: ", s, "\n")
+	os.Exit(2)
+}
+
+var fastrandState uint32 = 1
+
+// fastrand is a xorshift generator, it is only used for race detection.
+//
+// fastrand 是一个 xorshift 生成器，只在竞态检测中使用。
+func fastrand() uint32 {
+	for {
+		old := atomic.LoadUint32(&fastrandState)
+		x := old
+		x ^= x << 13
+		x ^= x >> 17
+		x ^= x << 5
+		if atomic.CompareAndSwapUint32(&fastrandState, old, x) {
+			return x
+		}
+	}
+}
+
+// pinLock serializes the pinned sections of Pool, which stands in for
+// staying on one P.
+//
+// pinLock 串行化了 Pool 中被固定的代码段，用来代替停留在同一个 P 上。
+var pinLock spinLock
+
+// runtime_procPin always returns 0, so Pool keeps a single local cache.
+//
+// runtime_procPin 总是返回 0，所以 Pool 只有一个本地缓存。
+func runtime_procPin() int {
+	pinLock.lock()
+	return 0
+}
+
+func runtime_procUnpin() {
+	pinLock.unlock()
+}
+
+// A gcSentinel is garbage after every collection, its finalizer runs the
+// pool cleanup and allocates the next one.
+//
+// gcSentinel 在每次垃圾回收之后都是垃圾，它的终结器会执行 Pool 的清理并分配下一个。
+type gcSentinel struct {
+	// The pointer keeps it out of the tiny allocator, whose objects may
+	// never be finalized.
+	//
+	// 这个指针使它不会被分配到 tiny 分配器中，那里的对象可能永远不会被终结。
+	_ *int
+}
+
+func runtime_registerPoolCleanup(cleanup func()) {
+	runtime.SetFinalizer(new(gcSentinel), func(*gcSentinel) {
+		stopPools(cleanup)
+		runtime_registerPoolCleanup(cleanup)
+	})
+}
+
+// stopPools runs cleanup with every Pool stopped. The runtime calls it with
+// the world stopped, here it holds the locks that Pool takes instead.
+//
+// stopPools 在所有 Pool 都停止的情况下执行 cleanup。运行时在停止整个世界时调用它，
+// 这里改为持有 Pool 会获取的那些锁。
+//
+// IMP: 加锁顺序和 pinSlow 中的相同：先 allPoolsMu，再 pin，最后是每个 poolLocal。Pool 在
+// raceDisable 之后才获取这些锁，竞态检测器看不到这个顺序，所以这里和 Pool 的方法一样标记了
+// go:norace，见 race_portable_race.go。
+//
+//go:norace
+func stopPools(cleanup func()) {
+	allPoolsMu.Lock()
+	runtime_procPin()
+	var locked []*poolLocal
+	for _, p := range allPools {
+		for i := 0; i < int(p.localSize); i++ {
+			l := indexLocal(p.local, i)
+			l.Lock()
+			locked = append(locked, l)
+		}
+	}
+	cleanup()
+	for _, l := range locked {
+		l.Unlock()
+	}
+	runtime_procUnpin()
+	allPoolsMu.Unlock()
+}
@@ -5,7 +5,6 @@
 package sync
 
 import (
-	"internal/race"
 	"sync/atomic"
 	"unsafe"
 )
@@ -41,17 +40,17 @@ const rwmutexMaxReaders = 1 << 30
 // call excludes new readers from acquiring the lock. See the
 // documentation on the RWMutex type.
 func (rw *RWMutex) RLock() {
-	if race.Enabled {
+	if raceEnabled {
 		_ = rw.w.state
-		race.Disable()
+		raceDisable()
 	}
 	if atomic.AddInt32(&rw.readerCount, 1) < 0 {
 		// A writer is pending, wait for it.
 		runtime_SemacquireMutex(&rw.readerSem, false)
 	}
-	if race.Enabled {
-		race.Enable()
-		race.Acquire(unsafe.Pointer(&rw.readerSem))
+	if raceEnabled {
+		raceEnable()
+		raceAcquire(unsafe.Pointer(&rw.readerSem))
 	}
 }
 
@@ -60,14 +59,14 @@ func (rw *RWMutex) RLock() {
 // It is a run-time error if rw is not locked for reading
 // on entry to RUnlock.
 func (rw *RWMutex) RUnlock() {
-	if race.Enabled {
+	if raceEnabled {
 		_ = rw.w.state
-		race.ReleaseMerge(unsafe.Pointer(&rw.writerSem))
-		race.Disable()
+		raceReleaseMerge(unsafe.Pointer(&rw.writerSem))
+		raceDisable()
 	}
 	if r := atomic.AddInt32(&rw.readerCount, -1); r < 0 {
 		if r+1 == 0 || r+1 == -rwmutexMaxReaders {
-			race.Enable()
+			raceEnable()
 			throw("sync: RUnlock of unlocked RWMutex")
 		}
 		// A writer is pending.
@@ -76,8 +75,8 @@ func (rw *RWMutex) RUnlock() {
 			runtime_Semrelease(&rw.writerSem, false)
 		}
 	}
-	if race.Enabled {
-		race.Enable()
+	if raceEnabled {
+		raceEnable()
 	}
 }
 
@@ -85,9 +84,9 @@ func (rw *RWMutex) RUnlock() {
 // If the lock is already locked for reading or writing,
 // Lock blocks until the lock is available.
 func (rw *RWMutex) Lock() {
-	if race.Enabled {
+	if raceEnabled {
 		_ = rw.w.state
-		race.Disable()
+		raceDisable()
 	}
 	// First, resolve competition with other writers.
 	rw.w.Lock()
@@ -97,10 +96,10 @@ func (rw *RWMutex) Lock() {
 	if r != 0 && atomic.AddInt32(&rw.readerWait, r) != 0 {
 		runtime_SemacquireMutex(&rw.writerSem, false)
 	}
-	if race.Enabled {
-		race.Enable()
-		race.Acquire(unsafe.Pointer(&rw.readerSem))
-		race.Acquire(unsafe.Pointer(&rw.writerSem))
+	if raceEnabled {
+		raceEnable()
+		raceAcquire(unsafe.Pointer(&rw.readerSem))
+		raceAcquire(unsafe.Pointer(&rw.writerSem))
 	}
 }
 
@@ -111,16 +110,16 @@ func (rw *RWMutex) Lock() {
 // goroutine. One goroutine may RLock (Lock) a RWMutex and then
 // arrange for another goroutine to RUnlock (Unlock) it.
 func (rw *RWMutex) Unlock() {
-	if race.Enabled {
+	if raceEnabled {
 		_ = rw.w.state
-		race.Release(unsafe.Pointer(&rw.readerSem))
-		race.Disable()
+		raceRelease(unsafe.Pointer(&rw.readerSem))
+		raceDisable()
 	}
 
 	// Announce to readers there is no active writer.
 	r := atomic.AddInt32(&rw.readerCount, rwmutexMaxReaders)
 	if r >= rwmutexMaxReaders {
-		race.Enable()
+		raceEnable()
 		throw("sync: Unlock of unlocked RWMutex")
 	}
 	// Unblock blocked readers, if any.
@@ -129,8 +128,8 @@ func (rw *RWMutex) Unlock() {
 	}
 	// Allow other writers to proceed.
 	rw.w.Unlock()
-	if race.Enabled {
-		race.Enable()
+	if raceEnabled {
+		raceEnable()
 	}
 }
 
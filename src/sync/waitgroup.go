@@ -5,7 +5,6 @@
 package sync
 
 import (
-	"internal/race"
 	"sync/atomic"
 	"unsafe"
 )
@@ -50,25 +49,27 @@ func (wg *WaitGroup) state() (statep *uint64, semap *uint32) {
 // If a WaitGroup is reused to wait for several independent sets of events,
 // new Add calls must happen after all previous Wait calls have returned.
 // See the WaitGroup example.
+//
+//go:norace
 func (wg *WaitGroup) Add(delta int) {
 	statep, semap := wg.state()
-	if race.Enabled {
+	if raceEnabled {
 		_ = *statep // trigger nil deref early
 		if delta < 0 {
 			// Synchronize decrements with Wait.
-			race.ReleaseMerge(unsafe.Pointer(wg))
+			raceReleaseMerge(unsafe.Pointer(wg))
 		}
-		race.Disable()
-		defer race.Enable()
+		raceDisable()
+		defer raceEnable()
 	}
 	state := atomic.AddUint64(statep, uint64(delta)<<32)
 	v := int32(state >> 32)
 	w := uint32(state)
-	if race.Enabled && delta > 0 && v == int32(delta) {
+	if raceEnabled && delta > 0 && v == int32(delta) {
 		// The first increment must be synchronized with Wait.
 		// Need to model this as a read, because there can be
 		// several concurrent wg.counter transitions from 0.
-		race.Read(unsafe.Pointer(semap))
+		raceRead(unsafe.Pointer(semap))
 	}
 	if v < 0 {
 		panic("sync: negative WaitGroup counter")
@@ -100,11 +101,13 @@ func (wg *WaitGroup) Done() {
 }
 
 // Wait blocks until the WaitGroup counter is zero.
+//
+//go:norace
 func (wg *WaitGroup) Wait() {
 	statep, semap := wg.state()
-	if race.Enabled {
+	if raceEnabled {
 		_ = *statep // trigger nil deref early
-		race.Disable()
+		raceDisable()
 	}
 	for {
 		state := atomic.LoadUint64(statep)
@@ -112,28 +115,28 @@ func (wg *WaitGroup) Wait() {
 		w := uint32(state)
 		if v == 0 {
 			// Counter is 0, no need to wait.
-			if race.Enabled {
-				race.Enable()
-				race.Acquire(unsafe.Pointer(wg))
+			if raceEnabled {
+				raceEnable()
+				raceAcquire(unsafe.Pointer(wg))
 			}
 			return
 		}
 		// Increment waiters count.
 		if atomic.CompareAndSwapUint64(statep, state, state+1) {
-			if race.Enabled && w == 0 {
+			if raceEnabled && w == 0 {
 				// Wait must be synchronized with the first Add.
 				// Need to model this is as a write to race with the read in Add.
 				// As a consequence, can do the write only for the first waiter,
 				// otherwise concurrent Waits will race with each other.
-				race.Write(unsafe.Pointer(semap))
+				raceWrite(unsafe.Pointer(semap))
 			}
 			runtime_Semacquire(semap)
 			if *statep != 0 {
 				panic("sync: WaitGroup is reused before previous Wait has returned")
 			}
-			if race.Enabled {
-				race.Enable()
-				race.Acquire(unsafe.Pointer(wg))
+			if raceEnabled {
+				raceEnable()
+				raceAcquire(unsafe.Pointer(wg))
 			}
 			return
 		}
@@ -1205,9 +1205,12 @@ func parseNanoseconds(value string, nbytes int) (ns int, rangeErrString string,
 	return
 }
 
+// 永远不会被打印
 var errLeadingInt = errors.New("time: bad [0-9]*") // never printed
 
 // leadingInt consumes the leading [0-9]* from s.
+//
+// leadingInt 消耗 s 开头的 [0-9]*。
 func leadingInt(s string) (x int64, rem string, err error) {
 	i := 0
 	for ; i < len(s); i++ {
@@ -1217,11 +1220,15 @@ func leadingInt(s string) (x int64, rem string, err error) {
 		}
 		if x > (1<<63-1)/10 {
 			// overflow
+			//
+			// 溢出
 			return 0, "", errLeadingInt
 		}
 		x = x*10 + int64(c) - '0'
 		if x < 0 {
 			// overflow
+			//
+			// 溢出
 			return 0, "", errLeadingInt
 		}
 	}
@@ -1231,6 +1238,10 @@ func leadingInt(s string) (x int64, rem string, err error) {
 // leadingFraction consumes the leading [0-9]* from s.
 // It is used only for fractions, so does not return an error on overflow,
 // it just stops accumulating precision.
+//
+// leadingFraction 消耗 s 开头的 [0-9]*。
+// 它只用于小数部分，所以在溢出时不返回错误，只是停止累积精度。
+// IMP: 返回值的含义是小数部分等于 x/scale。
 func leadingFraction(s string) (x int64, scale float64, rem string) {
 	i := 0
 	scale = 1
@@ -1245,6 +1256,8 @@ func leadingFraction(s string) (x int64, scale float64, rem string) {
 		}
 		if x > (1<<63-1)/10 {
 			// It's possible for overflow to give a positive number, so take care.
+			//
+			// 溢出可能得到一个正数，所以要小心。
 			overflow = true
 			continue
 		}
@@ -1259,10 +1272,15 @@ func leadingFraction(s string) (x int64, scale float64, rem string) {
 	return x, scale, s[i:]
 }
 
+// unitMap maps the unit suffixes accepted by ParseDuration to nanoseconds.
+//
+// unitMap 将 ParseDuration 接受的单位后缀映射到纳秒数。
 var unitMap = map[string]int64{
 	"ns": int64(Nanosecond),
 	"us": int64(Microsecond),
+	// U+00B5 = 微符号
 	"µs": int64(Microsecond), // U+00B5 = micro symbol
+	// U+03BC = 希腊字母 mu
 	"μs": int64(Microsecond), // U+03BC = Greek letter mu
 	"ms": int64(Millisecond),
 	"s":  int64(Second),
@@ -1275,6 +1293,14 @@ var unitMap = map[string]int64{
 // decimal numbers, each with optional fraction and a unit suffix,
 // such as "300ms", "-1.5h" or "2h45m".
 // Valid time units are "ns", "us" (or "µs"), "ms", "s", "m", "h".
+//
+// ParseDuration 解析一个时长字符串。
+// 时长字符串是一个可能带有符号的十进制数序列，每个数都可以带有小数部分和单位后缀，例如
+// "300ms"、"-1.5h" 或 "2h45m"。
+// 有效的时间单位有 "ns"、"us"（或 "µs"）、"ms"、"s"、"m"、"h"。
+//
+// IMP: 它是 Duration.String 的逆操作，flag 包的 Duration 标志就是通过它解析的。注意没有
+// "d" 这样的天单位，原因与没有定义 Day 常量相同。
 func ParseDuration(s string) (Duration, error) {
 	// [-+]?([0-9]*(\.[0-9]*)?[a-z]+)+
 	orig := s
@@ -1282,6 +1308,8 @@ func ParseDuration(s string) (Duration, error) {
 	neg := false
 
 	// Consume [-+]?
+	//
+	// 消耗 [-+]?
 	if s != "" {
 		c := s[0]
 		if c == '-' || c == '+' {
@@ -1290,6 +1318,9 @@ func ParseDuration(s string) (Duration, error) {
 		}
 	}
 	// Special case: if all that is left is "0", this is zero.
+	//
+	// 特殊情况：如果剩下的只有 "0"，那么它就是零。
+	// IMP: 这是唯一允许省略单位的情况。
 	if s == "0" {
 		return 0, nil
 	}
@@ -1298,25 +1329,34 @@ func ParseDuration(s string) (Duration, error) {
 	}
 	for s != "" {
 		var (
-			v, f  int64       // integers before, after decimal point
+			// 小数点前、后的整数
+			v, f int64 // integers before, after decimal point
+			// 值 = v + f/scale
 			scale float64 = 1 // value = v + f/scale
 		)
 
 		var err error
 
 		// The next character must be [0-9.]
+		//
+		// 下一个字符必须是 [0-9.]
 		if !(s[0] == '.' || '0' <= s[0] && s[0] <= '9') {
 			return 0, errors.New("time: invalid duration " + orig)
 		}
 		// Consume [0-9]*
+		//
+		// 消耗 [0-9]*
 		pl := len(s)
 		v, s, err = leadingInt(s)
 		if err != nil {
 			return 0, errors.New("time: invalid duration " + orig)
 		}
+		// 是否在小数点之前消耗了任何内容
 		pre := pl != len(s) // whether we consumed anything before a period
 
 		// Consume (\.[0-9]*)?
+		//
+		// 消耗 (\.[0-9]*)?
 		post := false
 		if s != "" && s[0] == '.' {
 			s = s[1:]
@@ -1326,10 +1366,14 @@ func ParseDuration(s string) (Duration, error) {
 		}
 		if !pre && !post {
 			// no digits (e.g. ".s" or "-.s")
+			//
+			// 没有数字（例如 ".s" 或 "-.s"）
 			return 0, errors.New("time: invalid duration " + orig)
 		}
 
 		// Consume unit.
+		//
+		// 消耗单位。
 		i := 0
 		for ; i < len(s); i++ {
 			c := s[i]
@@ -1348,21 +1392,30 @@ func ParseDuration(s string) (Duration, error) {
 		}
 		if v > (1<<63-1)/unit {
 			// overflow
+			//
+			// 溢出
 			return 0, errors.New("time: invalid duration " + orig)
 		}
 		v *= unit
 		if f > 0 {
 			// float64 is needed to be nanosecond accurate for fractions of hours.
 			// v >= 0 && (f*unit/scale) <= 3.6e+12 (ns/h, h is the largest unit)
+			//
+			// 对于小时的小数部分，需要使用 float64 才能精确到纳秒。
+			// v >= 0 && (f*unit/scale) <= 3.6e+12（纳秒/小时，小时是最大的单位）
 			v += int64(float64(f) * (float64(unit) / scale))
 			if v < 0 {
 				// overflow
+				//
+				// 溢出
 				return 0, errors.New("time: invalid duration " + orig)
 			}
 		}
 		d += v
 		if d < 0 {
 			// overflow
+			//
+			// 溢出
 			return 0, errors.New("time: invalid duration " + orig)
 		}
 	}
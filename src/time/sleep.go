@@ -6,28 +6,47 @@ package time
 
 // Sleep pauses the current goroutine for at least the duration d.
 // A negative or zero duration causes Sleep to return immediately.
+//
+// Sleep 将当前 goroutine 暂停至少 d 的时长。
+// 负数或零的时长会使 Sleep 立即返回。
+// IMP: Sleep 由运行时实现（runtime.timeSleep），它不会阻塞线程，只是将 goroutine 挂起并注册一个
+// 计时器。
 func Sleep(d Duration)
 
 // runtimeNano returns the current value of the runtime clock in nanoseconds.
+//
+// runtimeNano 以纳秒为单位返回运行时时钟的当前值。
+// IMP: 运行时时钟是单调时钟，计时器的 when 字段都是以它为基准的。
 func runtimeNano() int64
 
 // Interface to timers implemented in package runtime.
 // Must be in sync with ../runtime/time.go:/^type timer
+//
+// 在 runtime 包中实现的计时器的接口。
+// 必须与 ../runtime/time.go:/^type timer 保持一致。
+//
+// IMP: runtimeTimer 与运行时的 timer 内存布局相同，time 包只负责填写字段，之后由运行时
+// 管理。when 是到期的运行时时钟时间，period 大于 0 时表示周期性的计时器（Ticker），
+// 到期时运行时调用 f(arg, seq)。
 type runtimeTimer struct {
 	tb uintptr
 	i  int
 
 	when   int64
 	period int64
-	f      func(interface{}, uintptr) // NOTE: must not be closure
-	arg    interface{}
-	seq    uintptr
+	// 注意：一定不能是闭包
+	f   func(interface{}, uintptr) // NOTE: must not be closure
+	arg interface{}
+	seq uintptr
 }
 
 // when is a helper function for setting the 'when' field of a runtimeTimer.
 // It returns what the time will be, in nanoseconds, Duration d in the future.
 // If d is negative, it is ignored. If the returned value would be less than
 // zero because of an overflow, MaxInt64 is returned.
+//
+// when 是设置 runtimeTimer 的 'when' 字段的辅助函数。它返回 d 时长之后的时间（以纳秒为
+// 单位）。如果 d 是负数，则忽略它。如果由于溢出导致返回值小于零，则返回 MaxInt64。
 func when(d Duration) int64 {
 	if d <= 0 {
 		return runtimeNano()
@@ -39,6 +58,9 @@ func when(d Duration) int64 {
 	return t
 }
 
+// startTimer and stopTimer are implemented in package runtime.
+//
+// startTimer 和 stopTimer 在 runtime 包中实现。
 func startTimer(*runtimeTimer)
 func stopTimer(*runtimeTimer) bool
 
@@ -46,6 +68,10 @@ func stopTimer(*runtimeTimer) bool
 // When the Timer expires, the current time will be sent on C,
 // unless the Timer was created by AfterFunc.
 // A Timer must be created with NewTimer or AfterFunc.
+//
+// Timer 类型代表单个事件。
+// 当 Timer 到期时，当前时间会被发送到 C 中，除非 Timer 是由 AfterFunc 创建的。
+// Timer 必须使用 NewTimer 或 AfterFunc 创建。
 type Timer struct {
 	C <-chan Time
 	r runtimeTimer
@@ -73,6 +99,23 @@ type Timer struct {
 // Stop does not wait for f to complete before returning.
 // If the caller needs to know whether f is completed, it must coordinate
 // with f explicitly.
+//
+// Stop 阻止 Timer 触发。
+// 如果调用停止了计时器，它返回 true；如果计时器已经到期或者已经被停止，它返回 false。
+// Stop 不会关闭通道，以防止从通道中读取错误地成功。
+//
+// 为了防止使用 NewTimer 创建的计时器在调用 Stop 之后触发，需要检查返回值并排空通道。
+// 例如，假设程序还没有从 t.C 中接收过：
+//
+// 	if !t.Stop() {
+// 		<-t.C
+// 	}
+//
+// 这不能与从 Timer 通道的其他接收操作并发进行。
+//
+// 对于使用 AfterFunc(d, f) 创建的计时器，如果 t.Stop 返回 false，则计时器已经到期，并且函数
+// f 已经在它自己的 goroutine 中启动了；Stop 不会等待 f 完成再返回。
+// 如果调用者需要知道 f 是否已经完成，它必须显式地与 f 进行协调。
 func (t *Timer) Stop() bool {
 	if t.r.f == nil {
 		panic("time: Stop called on uninitialized Timer")
@@ -82,6 +125,9 @@ func (t *Timer) Stop() bool {
 
 // NewTimer creates a new Timer that will send
 // the current time on its channel after at least duration d.
+//
+// NewTimer 创建一个新的 Timer，它会在至少 d 时长之后将当前时间发送到它的通道中。
+// IMP: 通道的缓冲区大小为 1，这样即使没有人接收，运行时的 sendTime 也不会阻塞。
 func NewTimer(d Duration) *Timer {
 	c := make(chan Time, 1)
 	t := &Timer{
@@ -120,6 +166,25 @@ func NewTimer(d Duration) *Timer {
 // is a race condition between draining the channel and the new timer expiring.
 // Reset should always be invoked on stopped or expired channels, as described above.
 // The return value exists to preserve compatibility with existing programs.
+//
+// Reset 将计时器改为在 d 时长之后到期。
+// 如果计时器之前处于活跃状态，它返回 true；如果计时器已经到期或者已经被停止，它返回 false。
+//
+// 重置计时器时必须注意不要与当前计时器到期时对 t.C 的发送产生竞争。
+// 如果程序已经从 t.C 中接收过一个值，则已知计时器已经到期，可以直接使用 t.Reset。
+// 然而，如果程序还没有从 t.C 中接收过值，则必须停止计时器，并且——如果 Stop 报告计时器在
+// 停止之前已经到期——显式地排空通道：
+//
+// 	if !t.Stop() {
+// 		<-t.C
+// 	}
+// 	t.Reset(d)
+//
+// 这不应该与从 Timer 通道的其他接收操作并发进行。
+//
+// 注意，不可能正确地使用 Reset 的返回值，因为在排空通道与新的计时器到期之间存在竞争条件。
+// 如上所述，Reset 应该总是在已停止或已到期的通道上调用。
+// 返回值的存在是为了保持与已有程序的兼容性。
 func (t *Timer) Reset(d Duration) bool {
 	if t.r.f == nil {
 		panic("time: Reset called on uninitialized Timer")
@@ -131,12 +196,20 @@ func (t *Timer) Reset(d Duration) bool {
 	return active
 }
 
+// sendTime is the runtimeTimer callback used by NewTimer and NewTicker.
+//
+// sendTime 是 NewTimer 和 NewTicker 使用的 runtimeTimer 回调函数。
 func sendTime(c interface{}, seq uintptr) {
 	// Non-blocking send of time on c.
 	// Used in NewTimer, it cannot block anyway (buffer).
 	// Used in NewTicker, dropping sends on the floor is
 	// the desired behavior when the reader gets behind,
 	// because the sends are periodic.
+	//
+	// 在 c 上非阻塞地发送时间。
+	// 用于 NewTimer 时，它无论如何也不会阻塞（有缓冲区）。
+	// 用于 NewTicker 时，当读取者落后时丢弃发送是期望的行为，因为发送是周期性的。
+	// IMP: 回调在运行时的计时器处理中执行，绝不能阻塞，否则会拖住其他所有计时器。
 	select {
 	case c.(chan Time) <- Now():
 	default:
@@ -149,6 +222,11 @@ func sendTime(c interface{}, seq uintptr) {
 // The underlying Timer is not recovered by the garbage collector
 // until the timer fires. If efficiency is a concern, use NewTimer
 // instead and call Timer.Stop if the timer is no longer needed.
+//
+// After 等待时长过去，然后将当前时间发送到返回的通道中。
+// 它等价于 NewTimer(d).C。
+// 在计时器触发之前，底层的 Timer 不会被垃圾回收器回收。如果关心效率，请使用 NewTimer 代替，
+// 并在不再需要计时器时调用 Timer.Stop。
 func After(d Duration) <-chan Time {
 	return NewTimer(d).C
 }
@@ -156,6 +234,10 @@ func After(d Duration) <-chan Time {
 // AfterFunc waits for the duration to elapse and then calls f
 // in its own goroutine. It returns a Timer that can
 // be used to cancel the call using its Stop method.
+//
+// AfterFunc 等待时长过去，然后在它自己的 goroutine 中调用 f。它返回一个 Timer，可以使用它的
+// Stop 方法取消调用。
+// IMP: 这样创建的 Timer 的 C 为 nil。
 func AfterFunc(d Duration, f func()) *Timer {
 	t := &Timer{
 		r: runtimeTimer{
@@ -168,6 +250,10 @@ func AfterFunc(d Duration, f func()) *Timer {
 	return t
 }
 
+// goFunc is the runtimeTimer callback used by AfterFunc.
+//
+// goFunc 是 AfterFunc 使用的 runtimeTimer 回调函数。
+// IMP: 在新的 goroutine 中执行 f，避免 f 阻塞运行时的计时器处理。
 func goFunc(arg interface{}, seq uintptr) {
 	go arg.(func())()
 }
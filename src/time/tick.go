@@ -8,7 +8,10 @@ import "errors"
 
 // A Ticker holds a channel that delivers `ticks' of a clock
 // at intervals.
+//
+// Ticker 持有一个通道，该通道按一定间隔传递时钟的“滴答”。
 type Ticker struct {
+	// 传递滴答的通道。
 	C <-chan Time // The channel on which the ticks are delivered.
 	r runtimeTimer
 }
@@ -18,6 +21,11 @@ type Ticker struct {
 // It adjusts the intervals or drops ticks to make up for slow receivers.
 // The duration d must be greater than zero; if not, NewTicker will panic.
 // Stop the ticker to release associated resources.
+//
+// NewTicker 返回一个新的 Ticker，它包含一个通道，该通道会以 d 参数指定的周期发送时间。
+// 它会调整间隔或者丢弃滴答来弥补较慢的接收者。
+// d 必须大于零，否则 NewTicker 会 panic。
+// 停止 ticker 以释放相关的资源。
 func NewTicker(d Duration) *Ticker {
 	if d <= 0 {
 		panic(errors.New("non-positive interval for NewTicker"))
@@ -25,6 +33,11 @@ func NewTicker(d Duration) *Ticker {
 	// Give the channel a 1-element time buffer.
 	// If the client falls behind while reading, we drop ticks
 	// on the floor until the client catches up.
+	//
+	// 给通道 1 个元素的时间缓冲区。
+	// 如果客户端读取时落后了，我们会丢弃滴答，直到客户端赶上来。
+	// IMP: period 不为 0，运行时在每次触发后会将 when 增加 period，而不是从触发时刻重新计时，
+	// 所以滴答不会因为回调的耗时而累积漂移。
 	c := make(chan Time, 1)
 	t := &Ticker{
 		C: c,
@@ -42,6 +55,9 @@ func NewTicker(d Duration) *Ticker {
 // Stop turns off a ticker. After Stop, no more ticks will be sent.
 // Stop does not close the channel, to prevent a concurrent goroutine
 // reading from the channel from seeing an erroneous "tick".
+//
+// Stop 关闭 ticker。在 Stop 之后，不会再发送任何滴答。
+// Stop 不会关闭通道，以防止并发地从通道中读取的 goroutine 看到错误的“滴答”。
 func (t *Ticker) Stop() {
 	stopTimer(&t.r)
 }
@@ -51,6 +67,10 @@ func (t *Ticker) Stop() {
 // the Ticker, be aware that without a way to shut it down the underlying
 // Ticker cannot be recovered by the garbage collector; it "leaks".
 // Unlike NewTicker, Tick will return nil if d <= 0.
+//
+// Tick 是 NewTicker 的便捷包装，只提供对滴答通道的访问。虽然 Tick 对于不需要关闭 Ticker 的
+// 客户端很有用，但是要注意，由于无法关闭它，底层的 Ticker 无法被垃圾回收器回收，它会“泄漏”。
+// 与 NewTicker 不同，如果 d <= 0，Tick 会返回 nil。
 func Tick(d Duration) <-chan Time {
 	if d <= 0 {
 		return nil
@@ -73,6 +73,55 @@
 // clock reading if present. If t != u because of different monotonic clock readings,
 // that difference will be visible when printing t.String() and u.String().
 //
+// Package time 提供了测量和显示时间的功能。
+//
+// 日历计算总是假定使用公历，并且没有闰秒。
+//
+// 单调时钟
+//
+// 操作系统同时提供“挂钟”和“单调时钟”，前者会因为时钟同步而发生变化，后者则不会。一般的规则是
+// 挂钟用于报告时间，单调时钟用于测量时间。这个包没有拆分 API，而是让 time.Now 返回的 Time
+// 同时包含挂钟读数和单调时钟读数；之后报告时间的操作使用挂钟读数，而之后测量时间的操作，
+// 特别是比较和相减，使用单调时钟读数。
+//
+// 例如，下面的代码总是计算出大约 20 毫秒的正的经过时间，即使在被计时的操作期间挂钟被修改了：
+//
+//	start := time.Now()
+//	... operation that takes 20 milliseconds ...
+//	t := time.Now()
+//	elapsed := t.Sub(start)
+//
+// 其他惯用法，例如 time.Since(start)、time.Until(deadline) 和
+// time.Now().Before(deadline)，同样不受挂钟重置的影响。
+//
+// 本节的其余部分给出了操作如何使用单调时钟的详细细节，但是使用这个包并不需要理解这些细节。
+//
+// time.Now 返回的 Time 包含单调时钟读数。
+// 如果 Time t 有单调时钟读数，t.Add 会将相同的时长同时加到挂钟读数和单调时钟读数上来计算结果。
+// 由于 t.AddDate(y, m, d)、t.Round(d) 和 t.Truncate(d) 是挂钟时间的计算，它们总是从结果中
+// 去掉单调时钟读数。
+// 由于 t.In、t.Local 和 t.UTC 是用来改变挂钟时间的解释方式的，它们也会从结果中去掉单调时钟
+// 读数。
+// 去掉单调时钟读数的规范方法是使用 t = t.Round(0)。
+//
+// 如果 Time t 和 u 都包含单调时钟读数，操作 t.After(u)、t.Before(u)、t.Equal(u) 和
+// t.Sub(u) 只使用单调时钟读数进行，忽略挂钟读数。如果 t 或 u 中任何一个不包含单调时钟读数，
+// 这些操作会回退到使用挂钟读数。
+//
+// 在某些系统上，如果计算机进入睡眠状态，单调时钟会停止。
+// 在这样的系统上，t.Sub(u) 可能无法准确地反映 t 和 u 之间实际经过的时间。
+//
+// 由于单调时钟读数在当前进程之外没有意义，t.GobEncode、t.MarshalBinary、t.MarshalJSON 和
+// t.MarshalText 生成的序列化形式会省略单调时钟读数，t.Format 也没有提供它的格式。同样地，
+// 构造函数 time.Date、time.Parse、time.ParseInLocation 和 time.Unix，以及反序列化方法
+// t.GobDecode、t.UnmarshalBinary、t.UnmarshalJSON 和 t.UnmarshalText 总是创建没有单调
+// 时钟读数的时间。
+//
+// 注意，Go 的 == 运算符不仅比较时间点，还比较 Location 和单调时钟读数。关于 Time 值的相等性
+// 测试的讨论，请参阅 Time 类型的文档。
+//
+// 为了便于调试，如果存在单调时钟读数，t.String 的结果会包含它。如果因为单调时钟读数不同导致
+// t != u，打印 t.String() 和 u.String() 时就可以看到这个差异。
 package time
 
 import "errors"
@@ -117,6 +166,35 @@ import "errors"
 // correctly handles the case when only one of its arguments has a monotonic
 // clock reading.
 //
+// Time 代表一个纳秒精度的时间点。
+//
+// 使用时间的程序通常应该以值而不是指针的形式储存和传递它们。也就是说，时间变量和结构体字段的
+// 类型应该是 time.Time，而不是 *time.Time。
+//
+// 一个 Time 值可以被多个 goroutine 同时使用，除了方法 GobDecode、UnmarshalBinary、
+// UnmarshalJSON 和 UnmarshalText 不是并发安全的。
+//
+// 时间点可以使用 Before、After 和 Equal 方法进行比较。
+// Sub 方法将两个时间点相减，得到一个 Duration。
+// Add 方法将一个 Time 和一个 Duration 相加，得到一个 Time。
+//
+// Time 类型的零值是 UTC 时间公元 1 年 1 月 1 日 00:00:00.000000000。
+// 由于这个时间在实践中不太可能出现，IsZero 方法提供了一种简单的方法来检测没有被显式初始化的
+// 时间。
+//
+// 每个 Time 都关联一个 Location，在计算时间的表示形式时会查询它，例如在 Format、Hour 和 Year
+// 方法中。方法 Local、UTC 和 In 返回一个具有特定位置的 Time。以这种方式改变位置只会改变表示
+// 形式，它不会改变所表示的时间点，因此不会影响前面几段中描述的计算。
+//
+// 除了必需的“挂钟”读数之外，Time 还可以包含一个可选的当前进程的单调时钟读数，为比较或相减
+// 提供额外的精度。
+// 详情请参阅包文档中的“单调时钟”一节。
+//
+// 注意，Go 的 == 运算符不仅比较时间点，还比较 Location 和单调时钟读数。因此，在没有首先保证
+// 所有值都设置了相同的 Location（可以通过使用 UTC 或 Local 方法实现），并且通过设置
+// t = t.Round(0) 去掉了单调时钟读数之前，Time 值不应该被用作 map 或数据库的键。一般来说，
+// 应该优先使用 t.Equal(u) 而不是 t == u，因为 t.Equal 使用可用的最精确的比较，并且正确地处理了
+// 只有一个参数具有单调时钟读数的情况。
 type Time struct {
 	// wall and ext encode the wall time seconds, wall time nanoseconds,
 	// and optional monotonic clock reading in nanoseconds.
@@ -129,6 +207,23 @@ type Time struct {
 	// If the hasMonotonic bit is 1, then the 33-bit field holds a 33-bit
 	// unsigned wall seconds since Jan 1 year 1885, and ext holds a
 	// signed 64-bit monotonic clock reading, nanoseconds since process start.
+	//
+	// wall 和 ext 编码了挂钟时间的秒数、挂钟时间的纳秒数和可选的以纳秒为单位的单调时钟读数。
+	//
+	// 从高位到低位，wall 编码了一个 1 位的标志（hasMonotonic）、一个 33 位的秒数字段和一个
+	// 30 位的挂钟时间纳秒数字段。纳秒数字段的范围是 [0, 999999999]。
+	// 如果 hasMonotonic 位为 0，则 33 位的字段必须为零，并且从公元 1 年 1 月 1 日开始的完整
+	// 的有符号 64 位挂钟秒数储存在 ext 中。
+	// 如果 hasMonotonic 位为 1，则 33 位的字段保存从 1885 年 1 月 1 日开始的 33 位无符号挂钟
+	// 秒数，并且 ext 保存有符号 64 位的单调时钟读数，即从进程启动开始的纳秒数。
+	//
+	// IMP: 两种编码
+	//
+	//	hasMonotonic == 0: wall = | 0 | 0 (33 位)          | nsec (30 位) |, ext = 从公元 1 年开始的秒数
+	//	hasMonotonic == 1: wall = | 1 | 从 1885 年开始的秒数 | nsec (30 位) |, ext = 单调时钟纳秒数
+	//
+	// 33 位秒数可以表示大约 272 年，所以只有 1885 年到 2157 年之间的时间才能携带单调时钟
+	// 读数，time.Now 返回的时间总是在这个范围内。
 	wall uint64
 	ext  int64
 
@@ -137,27 +232,41 @@ type Time struct {
 	// that correspond to this Time.
 	// The nil location means UTC.
 	// All UTC times are represented with loc==nil, never loc==&utcLoc.
+	//
+	// loc 指定了确定这个 Time 对应的分钟、小时、月、日和年时应该使用的 Location。
+	// nil 位置表示 UTC。
+	// 所有 UTC 时间都用 loc==nil 表示，永远不会是 loc==&utcLoc。
 	loc *Location
 }
 
 const (
 	hasMonotonic = 1 << 63
-	maxWall      = wallToInternal + (1<<33 - 1) // year 2157
-	minWall      = wallToInternal               // year 1885
-	nsecMask     = 1<<30 - 1
-	nsecShift    = 30
+	// 2157 年
+	maxWall = wallToInternal + (1<<33 - 1) // year 2157
+	// 1885 年
+	minWall   = wallToInternal // year 1885
+	nsecMask  = 1<<30 - 1
+	nsecShift = 30
 )
 
 // These helpers for manipulating the wall and monotonic clock readings
 // take pointer receivers, even when they don't modify the time,
 // to make them cheaper to call.
+//
+// 这些操作挂钟读数和单调时钟读数的辅助方法使用指针接收者，即使它们不修改时间，这是为了让它们
+// 的调用开销更小。
 
 // nsec returns the time's nanoseconds.
+//
+// nsec 返回时间的纳秒数。
 func (t *Time) nsec() int32 {
 	return int32(t.wall & nsecMask)
 }
 
 // sec returns the time's seconds since Jan 1 year 1.
+//
+// sec 返回时间从公元 1 年 1 月 1 日开始的秒数。
+// IMP: t.wall<<1>>(nsecShift+1) 先左移 1 位去掉 hasMonotonic 标志，再右移取出 33 位的秒数。
 func (t *Time) sec() int64 {
 	if t.wall&hasMonotonic != 0 {
 		return wallToInternal + int64(t.wall<<1>>(nsecShift+1))
@@ -166,9 +275,13 @@ func (t *Time) sec() int64 {
 }
 
 // unixSec returns the time's seconds since Jan 1 1970 (Unix time).
+//
+// unixSec 返回时间从 1970 年 1 月 1 日开始的秒数（Unix 时间）。
 func (t *Time) unixSec() int64 { return t.sec() + internalToUnix }
 
 // addSec adds d seconds to the time.
+//
+// addSec 将时间增加 d 秒。
 func (t *Time) addSec(d int64) {
 	if t.wall&hasMonotonic != 0 {
 		sec := int64(t.wall << 1 >> (nsecShift + 1))
@@ -179,14 +292,21 @@ func (t *Time) addSec(d int64) {
 		}
 		// Wall second now out of range for packed field.
 		// Move to ext.
+		//
+		// 挂钟秒数超出了压缩字段的范围。
+		// 将它移到 ext 中。
 		t.stripMono()
 	}
 
 	// TODO: Check for overflow.
+	//
+	// TODO: 检查溢出。
 	t.ext += d
 }
 
 // setLoc sets the location associated with the time.
+//
+// setLoc 设置与时间关联的位置。
 func (t *Time) setLoc(loc *Location) {
 	if loc == &utcLoc {
 		loc = nil
@@ -196,6 +316,9 @@ func (t *Time) setLoc(loc *Location) {
 }
 
 // stripMono strips the monotonic clock reading in t.
+//
+// stripMono 去掉 t 中的单调时钟读数。
+// IMP: 把秒数从 wall 的 33 位字段搬回 ext，并清除 wall 中除纳秒之外的所有位。
 func (t *Time) stripMono() {
 	if t.wall&hasMonotonic != 0 {
 		t.ext = t.sec()
@@ -207,6 +330,9 @@ func (t *Time) stripMono() {
 // If t cannot hold a monotonic clock reading,
 // because its wall time is too large,
 // setMono is a no-op.
+//
+// setMono 设置 t 中的单调时钟读数。
+// 如果 t 因为挂钟时间太大而无法保存单调时钟读数，setMono 什么也不做。
 func (t *Time) setMono(m int64) {
 	if t.wall&hasMonotonic == 0 {
 		sec := t.ext
@@ -223,6 +349,10 @@ func (t *Time) setMono(m int64) {
 // This function is used only for testing,
 // so it's OK that technically 0 is a valid
 // monotonic clock reading as well.
+//
+// mono 返回 t 的单调时钟读数。
+// 如果没有读数，它返回 0。
+// 这个函数只用于测试，所以 0 在技术上也是一个有效的单调时钟读数是没有问题的。
 func (t *Time) mono() int64 {
 	if t.wall&hasMonotonic == 0 {
 		return 0
@@ -231,6 +361,9 @@ func (t *Time) mono() int64 {
 }
 
 // After reports whether the time instant t is after u.
+//
+// After 报告时间点 t 是否在 u 之后。
+// IMP: t.wall&u.wall&hasMonotonic != 0 表示两者都有单调时钟读数，此时只比较 ext。
 func (t Time) After(u Time) bool {
 	if t.wall&u.wall&hasMonotonic != 0 {
 		return t.ext > u.ext
@@ -241,6 +374,8 @@ func (t Time) After(u Time) bool {
 }
 
 // Before reports whether the time instant t is before u.
+//
+// Before 报告时间点 t 是否在 u 之前。
 func (t Time) Before(u Time) bool {
 	if t.wall&u.wall&hasMonotonic != 0 {
 		return t.ext < u.ext
@@ -253,6 +388,10 @@ func (t Time) Before(u Time) bool {
 // For example, 6:00 +0200 CEST and 4:00 UTC are Equal.
 // See the documentation on the Time type for the pitfalls of using == with
 // Time values; most code should use Equal instead.
+//
+// Equal 报告 t 和 u 是否表示同一个时间点。
+// 即使两个时间位于不同的位置，它们也可以相等。例如，6:00 +0200 CEST 和 4:00 UTC 是相等的。
+// 关于对 Time 值使用 == 的陷阱，请参阅 Time 类型的文档；大多数代码应该使用 Equal。
 func (t Time) Equal(u Time) bool {
 	if t.wall&u.wall&hasMonotonic != 0 {
 		return t.ext == u.ext
@@ -628,6 +767,9 @@ func (t Time) YearDay() int {
 // A Duration represents the elapsed time between two instants
 // as an int64 nanosecond count. The representation limits the
 // largest representable duration to approximately 290 years.
+//
+// Duration 以 int64 纳秒计数的形式表示两个时间点之间经过的时间。这种表示方法将可表示的最大
+// 时长限制在大约 290 年。
 type Duration int64
 
 const (
@@ -646,6 +788,18 @@ const (
 //	seconds := 10
 //	fmt.Print(time.Duration(seconds)*time.Second) // prints 10s
 //
+// 常用的时长。为了避免在夏令时时区转换时产生混淆，没有定义天或者更大的单位。
+//
+// 要计算一个 Duration 中包含的单位数量，使用除法：
+//	second := time.Second
+//	fmt.Print(int64(second/time.Millisecond)) // prints 1000
+//
+// 要将整数数量的单位转换为 Duration，使用乘法：
+//	seconds := 10
+//	fmt.Print(time.Duration(seconds)*time.Second) // prints 10s
+//
+// IMP: 常见的错误是 time.Sleep(seconds * time.Second) 中 seconds 是 int 变量，这无法通过
+// 编译，必须写成 time.Duration(seconds) * time.Second。
 const (
 	Nanosecond  Duration = 1
 	Microsecond          = 1000 * Nanosecond
@@ -659,8 +813,16 @@ const (
 // Leading zero units are omitted. As a special case, durations less than one
 // second format use a smaller unit (milli-, micro-, or nanoseconds) to ensure
 // that the leading digit is non-zero. The zero duration formats as 0s.
+//
+// String 返回表示该时长的字符串，形式为 "72h3m0.5s"。开头为零的单位会被省略。作为一个特殊
+// 情况，小于一秒的时长会使用更小的单位（毫秒、微秒或纳秒）来格式化，以保证开头的数字不为零。
+// 零时长格式化为 0s。
+//
+// IMP: 和 strconv.formatBits 一样，从右往左填充 buf，最后返回 buf[w:]。
 func (d Duration) String() string {
 	// Largest time is 2540400h10m10.000000000s
+	//
+	// 最大的时间是 2540400h10m10.000000000s
 	var buf [32]byte
 	w := len(buf)
 
@@ -673,6 +835,8 @@ func (d Duration) String() string {
 	if u < uint64(Second) {
 		// Special case: if duration is smaller than a second,
 		// use smaller units, like 1.2ms
+		//
+		// 特殊情况：如果时长小于一秒，则使用更小的单位，例如 1.2ms
 		var prec int
 		w--
 		buf[w] = 's'
@@ -682,16 +846,25 @@ func (d Duration) String() string {
 			return "0s"
 		case u < uint64(Microsecond):
 			// print nanoseconds
+			//
+			// 打印纳秒
 			prec = 0
 			buf[w] = 'n'
 		case u < uint64(Millisecond):
 			// print microseconds
+			//
+			// 打印微秒
 			prec = 3
 			// U+00B5 'µ' micro sign == 0xC2 0xB5
+			//
+			// U+00B5 'µ' 微符号 == 0xC2 0xB5
+			// 需要两个字节的空间。
 			w-- // Need room for two bytes.
 			copy(buf[w:], "µ")
 		default:
 			// print milliseconds
+			//
+			// 打印毫秒
 			prec = 6
 			buf[w] = 'm'
 		}
@@ -704,10 +877,14 @@ func (d Duration) String() string {
 		w, u = fmtFrac(buf[:w], u, 9)
 
 		// u is now integer seconds
+		//
+		// 现在 u 是整数秒
 		w = fmtInt(buf[:w], u%60)
 		u /= 60
 
 		// u is now integer minutes
+		//
+		// 现在 u 是整数分钟
 		if u > 0 {
 			w--
 			buf[w] = 'm'
@@ -716,6 +893,9 @@ func (d Duration) String() string {
 
 			// u is now integer hours
 			// Stop at hours because days can be different lengths.
+			//
+			// 现在 u 是整数小时
+			// 在小时处停止，因为每天的长度可能不同。
 			if u > 0 {
 				w--
 				buf[w] = 'h'
@@ -736,8 +916,13 @@ func (d Duration) String() string {
 // tail of buf, omitting trailing zeros. It omits the decimal
 // point too when the fraction is 0. It returns the index where the
 // output bytes begin and the value v/10**prec.
+//
+// fmtFrac 将 v/10**prec 的小数部分（例如 ".12345"）格式化到 buf 的尾部，省略尾部的零。当小数
+// 部分为 0 时，它也会省略小数点。它返回输出字节开始的索引和值 v/10**prec。
 func fmtFrac(buf []byte, v uint64, prec int) (nw int, nv uint64) {
 	// Omit trailing zeros up to and including decimal point.
+	//
+	// 省略尾部的零，直到并包括小数点。
 	w := len(buf)
 	print := false
 	for i := 0; i < prec; i++ {
@@ -758,6 +943,9 @@ func fmtFrac(buf []byte, v uint64, prec int) (nw int, nv uint64) {
 
 // fmtInt formats v into the tail of buf.
 // It returns the index where the output begins.
+//
+// fmtInt 将 v 格式化到 buf 的尾部。
+// 它返回输出开始的索引。
 func fmtInt(buf []byte, v uint64) int {
 	w := len(buf)
 	if v == 0 {
@@ -774,6 +962,8 @@ func fmtInt(buf []byte, v uint64) int {
 }
 
 // Nanoseconds returns the duration as an integer nanosecond count.
+//
+// Nanoseconds 以整数纳秒计数的形式返回时长。
 func (d Duration) Nanoseconds() int64 { return int64(d) }
 
 // These methods return float64 because the dominant
@@ -784,8 +974,15 @@ func (d Duration) Nanoseconds() int64 { return int64(d) }
 // way that a pure integer conversion would have, even in cases
 // where, say, float64(d.Nanoseconds())/1e9 would have rounded
 // differently.
+//
+// 这些方法返回 float64，因为主要的用例是打印像 1.5s 这样的浮点数，截断为整数会使它们在这些
+// 情况下没有用处。自己拆分整数部分和小数部分，可以保证将返回的 float64 转换为整数时的舍入方式
+// 与纯整数转换的舍入方式相同，即使在 float64(d.Nanoseconds())/1e9 会以不同方式舍入的情况下
+// 也是如此。
 
 // Seconds returns the duration as a floating point number of seconds.
+//
+// Seconds 以浮点秒数的形式返回时长。
 func (d Duration) Seconds() float64 {
 	sec := d / Second
 	nsec := d % Second
@@ -793,6 +990,8 @@ func (d Duration) Seconds() float64 {
 }
 
 // Minutes returns the duration as a floating point number of minutes.
+//
+// Minutes 以浮点分钟数的形式返回时长。
 func (d Duration) Minutes() float64 {
 	min := d / Minute
 	nsec := d % Minute
@@ -800,6 +999,8 @@ func (d Duration) Minutes() float64 {
 }
 
 // Hours returns the duration as a floating point number of hours.
+//
+// Hours 以浮点小时数的形式返回时长。
 func (d Duration) Hours() float64 {
 	hour := d / Hour
 	nsec := d % Hour
@@ -808,6 +1009,9 @@ func (d Duration) Hours() float64 {
 
 // Truncate returns the result of rounding d toward zero to a multiple of m.
 // If m <= 0, Truncate returns d unchanged.
+//
+// Truncate 返回将 d 向零舍入到 m 的倍数的结果。
+// 如果 m <= 0，Truncate 原样返回 d。
 func (d Duration) Truncate(m Duration) Duration {
 	if m <= 0 {
 		return d
@@ -817,6 +1021,9 @@ func (d Duration) Truncate(m Duration) Duration {
 
 // lessThanHalf reports whether x+x < y but avoids overflow,
 // assuming x and y are both positive (Duration is signed).
+//
+// lessThanHalf 报告是否 x+x < y，但避免了溢出，假定 x 和 y 都是正数（Duration 是有符号的）。
+// IMP: 两个正的 int64 相加不会超出 uint64 的范围。
 func lessThanHalf(x, y Duration) bool {
 	return uint64(x)+uint64(x) < uint64(y)
 }
@@ -827,6 +1034,11 @@ func lessThanHalf(x, y Duration) bool {
 // value that can be stored in a Duration,
 // Round returns the maximum (or minimum) duration.
 // If m <= 0, Round returns d unchanged.
+//
+// Round 返回将 d 舍入到最接近的 m 的倍数的结果。
+// 对于中间值的舍入行为是远离零舍入。
+// 如果结果超出了 Duration 可以储存的最大（或最小）值，Round 返回最大（或最小）时长。
+// 如果 m <= 0，Round 原样返回 d。
 func (d Duration) Round(m Duration) Duration {
 	if m <= 0 {
 		return d
@@ -840,6 +1052,7 @@ func (d Duration) Round(m Duration) Duration {
 		if d1 := d - m + r; d1 < d {
 			return d1
 		}
+		// 溢出
 		return minDuration // overflow
 	}
 	if lessThanHalf(r, m) {
@@ -848,10 +1061,14 @@ func (d Duration) Round(m Duration) Duration {
 	if d1 := d + m - r; d1 > d {
 		return d1
 	}
+	// 溢出
 	return maxDuration // overflow
 }
 
 // Add returns the time t+d.
+//
+// Add 返回时间 t+d。
+// IMP: 挂钟读数和单调时钟读数都会加上 d。单调时钟读数溢出时只是去掉它，退化为只有挂钟读数。
 func (t Time) Add(d Duration) Time {
 	dsec := int64(d / 1e9)
 	nsec := t.nsec() + int32(d%1e9)
@@ -862,12 +1079,15 @@ func (t Time) Add(d Duration) Time {
 		dsec--
 		nsec += 1e9
 	}
+	// 更新纳秒数
 	t.wall = t.wall&^nsecMask | uint64(nsec) // update nsec
 	t.addSec(dsec)
 	if t.wall&hasMonotonic != 0 {
 		te := t.ext + int64(d)
 		if d < 0 && te > t.ext || d > 0 && te < t.ext {
 			// Monotonic clock reading now out of range; degrade to wall-only.
+			//
+			// 单调时钟读数现在超出了范围，降级为只有挂钟读数。
 			t.stripMono()
 		} else {
 			t.ext = te
@@ -880,39 +1100,58 @@ func (t Time) Add(d Duration) Time {
 // value that can be stored in a Duration, the maximum (or minimum) duration
 // will be returned.
 // To compute t-d for a duration d, use t.Add(-d).
+//
+// Sub 返回时长 t-u。如果结果超出了 Duration 可以储存的最大（或最小）值，将返回最大（或最小）
+// 时长。
+// 要计算 t-d（d 是一个时长），请使用 t.Add(-d)。
+// IMP: 两者都有单调时钟读数时只使用单调时钟计算，这就是挂钟被调整时 time.Since 仍然准确的原因。
 func (t Time) Sub(u Time) Duration {
 	if t.wall&u.wall&hasMonotonic != 0 {
 		te := t.ext
 		ue := u.ext
 		d := Duration(te - ue)
 		if d < 0 && te > ue {
+			// t - u 是正数并超出了范围
 			return maxDuration // t - u is positive out of range
 		}
 		if d > 0 && te < ue {
+			// t - u 是负数并超出了范围
 			return minDuration // t - u is negative out of range
 		}
 		return d
 	}
 	d := Duration(t.sec()-u.sec())*Second + Duration(t.nsec()-u.nsec())
 	// Check for overflow or underflow.
+	//
+	// 检查上溢或者下溢。
+	// IMP: 通过 u+d 是否等于 t 来判断 d 是否溢出了。
 	switch {
 	case u.Add(d).Equal(t):
+		// d 是正确的
 		return d // d is correct
 	case t.Before(u):
+		// t - u 是负数并超出了范围
 		return minDuration // t - u is negative out of range
 	default:
+		// t - u 是正数并超出了范围
 		return maxDuration // t - u is positive out of range
 	}
 }
 
 // Since returns the time elapsed since t.
 // It is shorthand for time.Now().Sub(t).
+//
+// Since 返回从 t 开始经过的时间。
+// 它是 time.Now().Sub(t) 的简写。
 func Since(t Time) Duration {
 	return Now().Sub(t)
 }
 
 // Until returns the duration until t.
 // It is shorthand for t.Sub(time.Now()).
+//
+// Until 返回到 t 为止的时长。
+// 它是 t.Sub(time.Now()) 的简写。
 func Until(t Time) Duration {
 	return t.Sub(Now())
 }
@@ -925,6 +1164,13 @@ func Until(t Time) Duration {
 // AddDate normalizes its result in the same way that Date does,
 // so, for example, adding one month to October 31 yields
 // December 1, the normalized form for November 31.
+//
+// AddDate 返回将 t 加上给定的年数、月数和天数后对应的时间。
+// 例如，对 2011 年 1 月 1 日应用 AddDate(-1, 2, 3) 返回 2010 年 3 月 4 日。
+//
+// AddDate 以与 Date 相同的方式规范化它的结果，所以，例如，10 月 31 日加上一个月得到 12 月 1 日，
+// 这是 11 月 31 日的规范化形式。
+// IMP: AddDate 通过 Date 重新构造时间，所以结果不带单调时钟读数。
 func (t Time) AddDate(years int, months int, days int) Time {
 	year, month, day := t.Date()
 	hour, min, sec := t.Clock()
@@ -1048,11 +1294,16 @@ func daysIn(m Month, year int) int {
 }
 
 // Provided by package runtime.
+//
+// 由 runtime 包提供。
+// IMP: sec 和 nsec 是挂钟时间（Unix 时间），mono 是单调时钟读数。
 func now() (sec int64, nsec int32, mono int64)
 
 // Now returns the current local time.
 //
 // Now 返回当前的本地时间。
+// IMP: 如果时间在 1885 年到 2157 年之间（33 位能表示的范围），则使用带单调时钟读数的编码，
+// 否则只能退化为不带单调时钟读数的编码。
 func Now() Time {
 	sec, nsec, mono := now()
 	sec += unixToInternal - minWall
@@ -1062,6 +1313,10 @@ func Now() Time {
 	return Time{hasMonotonic | uint64(sec)<<nsecShift | uint64(nsec), mono, Local}
 }
 
+// unixTime returns the local Time corresponding to the given Unix time,
+// without a monotonic clock reading.
+//
+// unixTime 返回给定的 Unix 时间对应的本地 Time，不带单调时钟读数。
 func unixTime(sec int64, nsec int32) Time {
 	return Time{uint64(nsec), sec + unixToInternal, Local}
 }
@@ -1406,6 +1661,12 @@ func Date(year int, month Month, day, hour, min, sec, nsec int, loc *Location) T
 // zero time; it does not operate on the presentation form of the
 // time. Thus, Truncate(Hour) may return a time with a non-zero
 // minute, depending on the time's Location.
+//
+// Truncate 返回将 t 向下舍入到 d 的倍数（从零时间开始计算）的结果。
+// 如果 d <= 0，Truncate 返回去掉了单调时钟读数但其他方面不变的 t。
+//
+// Truncate 将时间当作从零时间开始的绝对时长来操作，它不对时间的表示形式进行操作。因此，
+// 根据时间的 Location，Truncate(Hour) 可能返回一个分钟不为零的时间。
 func (t Time) Truncate(d Duration) Time {
 	t.stripMono()
 	if d <= 0 {
@@ -1423,6 +1684,14 @@ func (t Time) Truncate(d Duration) Time {
 // zero time; it does not operate on the presentation form of the
 // time. Thus, Round(Hour) may return a time with a non-zero
 // minute, depending on the time's Location.
+//
+// Round 返回将 t 舍入到最接近的 d 的倍数（从零时间开始计算）的结果。
+// 对于中间值的舍入行为是向上舍入。
+// 如果 d <= 0，Round 返回去掉了单调时钟读数但其他方面不变的 t。
+//
+// Round 将时间当作从零时间开始的绝对时长来操作，它不对时间的表示形式进行操作。因此，
+// 根据时间的 Location，Round(Hour) 可能返回一个分钟不为零的时间。
+// IMP: 这就是 t.Round(0) 是去掉单调时钟读数的规范方法的原因。
 func (t Time) Round(d Duration) Time {
 	t.stripMono()
 	if d <= 0 {
@@ -4,26 +4,53 @@
 
 // Package utf8 implements functions and constants to support text encoded in
 // UTF-8. It includes functions to translate between runes and UTF-8 byte sequences.
+//
+// utf8 包实现了支持 UTF-8 编码文本的函数和常量。它包括在文字和 UTF-8 字节序列之间进行
+// 转换的函数。
+//
+// IMP: UTF-8 的编码规则如下，x 是码点的有效位，后续字节都以 10 开头：
+//
+//	码点范围            字节数  编码
+//	U+0000-U+007F       1       0xxxxxxx
+//	U+0080-U+07FF       2       110xxxxx 10xxxxxx
+//	U+0800-U+FFFF       3       1110xxxx 10xxxxxx 10xxxxxx
+//	U+10000-U+10FFFF    4       11110xxx 10xxxxxx 10xxxxxx 10xxxxxx
+//
+// 其中 U+D800-U+DFFF 是 UTF-16 的代理区，不是合法的码点。每个码点只能使用最短的编码，
+// 例如 0xC0 0x80 虽然按规则可以解出 U+0000，但它是非法的。
 package utf8
 
 // The conditions RuneError==unicode.ReplacementChar and
 // MaxRune==unicode.MaxRune are verified in the tests.
 // Defining them locally avoids this package depending on package unicode.
+//
+// RuneError==unicode.ReplacementChar 和 MaxRune==unicode.MaxRune 这两个条件在测试中
+// 验证。在本地定义它们可以避免这个包依赖 unicode 包。
 
 // Numbers fundamental to the encoding.
+//
+// 编码的基本数值。
 const (
-	RuneError = '\uFFFD'     // the "error" Rune or "Unicode replacement character"
-	RuneSelf  = 0x80         // characters below Runeself are represented as themselves in a single byte.
-	MaxRune   = '\U0010FFFF' // Maximum valid Unicode code point.
-	UTFMax    = 4            // maximum number of bytes of a UTF-8 encoded Unicode character.
+	// “错误”文字，即“Unicode 替换字符”
+	RuneError = '\uFFFD' // the "error" Rune or "Unicode replacement character"
+	// 小于 RuneSelf 的字符用一个字节表示，这个字节就是它本身。
+	RuneSelf = 0x80 // characters below Runeself are represented as themselves in a single byte.
+	// 最大的合法 Unicode 码点。
+	MaxRune = '\U0010FFFF' // Maximum valid Unicode code point.
+	// UTF-8 编码的 Unicode 字符最多占用的字节数。
+	UTFMax = 4 // maximum number of bytes of a UTF-8 encoded Unicode character.
 )
 
 // Code points in the surrogate range are not valid for UTF-8.
+//
+// 代理区中的码点在 UTF-8 中是非法的。
 const (
 	surrogateMin = 0xD800
 	surrogateMax = 0xDFFF
 )
 
+// IMP: tx、t2、t3、t4 是各种字节的前缀，maskx、mask2、mask3、mask4 则用于取出对应字节中
+// 码点的有效位；runeNMax 是 N 个字节能编码的最大码点。
 const (
 	t1 = 0x00 // 0000 0000
 	tx = 0x80 // 1000 0000
@@ -42,6 +69,8 @@ const (
 	rune3Max = 1<<16 - 1
 
 	// The default lowest and highest continuation byte.
+	//
+	// 后续字节默认的最小值和最大值。
 	locb = 0x80 // 1000 0000
 	hicb = 0xBF // 1011 1111
 
@@ -49,6 +78,12 @@ const (
 	// table below. The first nibble is an index into acceptRanges or F for
 	// special one-byte cases. The second nibble is the Rune length or the
 	// Status for the special one-byte case.
+	//
+	// 选择这些常量的名字是为了让下面的表格对齐。高 4 位是 acceptRanges 的下标，对于特殊的
+	// 单字节情况则是 F。低 4 位是文字的长度，对于特殊的单字节情况则是其状态。
+	//
+	// IMP: 因此 x&7 就是编码的字节数，x>>4 就是第二个字节的合法范围在 acceptRanges 中的
+	// 下标。as 和 xx 只有最低位不同，DecodeRune 利用这一点避免了一次分支。
 	xx = 0xF1 // invalid: size 1
 	as = 0xF0 // ASCII: size 1
 	s1 = 0x02 // accept 0, size 2
@@ -61,6 +96,11 @@ const (
 )
 
 // first is information about the first byte in a UTF-8 sequence.
+//
+// first 是关于 UTF-8 序列第一个字节的信息。
+//
+// IMP: 0x80-0xBF 是后续字节，0xC0 和 0xC1 只能编码 U+0080 以下的码点（不是最短编码），
+// 0xF5-0xFF 编码的码点大于 MaxRune，所以它们都不能作为第一个字节。
 var first = [256]uint8{
 	//   1   2   3   4   5   6   7   8   9   A   B   C   D   E   F
 	as, as, as, as, as, as, as, as, as, as, as, as, as, as, as, as, // 0x00-0x0F
@@ -84,11 +124,21 @@ var first = [256]uint8{
 
 // acceptRange gives the range of valid values for the second byte in a UTF-8
 // sequence.
+//
+// acceptRange 给出了 UTF-8 序列中第二个字节的合法取值范围。
 type acceptRange struct {
+	// 第二个字节的最小值。
 	lo uint8 // lowest value for second byte.
+	// 第二个字节的最大值。
 	hi uint8 // highest value for second byte.
 }
 
+// IMP: 只有第二个字节的范围需要特殊处理：
+//
+//	1: 0xE0 后面不小于 0xA0，否则不是最短编码
+//	2: 0xED 后面不大于 0x9F，否则落在代理区 U+D800-U+DFFF 中
+//	3: 0xF0 后面不小于 0x90，否则不是最短编码
+//	4: 0xF4 后面不大于 0x8F，否则大于 MaxRune
 var acceptRanges = [...]acceptRange{
 	0: {locb, hicb},
 	1: {0xA0, hicb},
@@ -99,6 +149,9 @@ var acceptRanges = [...]acceptRange{
 
 // FullRune reports whether the bytes in p begin with a full UTF-8 encoding of a rune.
 // An invalid encoding is considered a full Rune since it will convert as a width-1 error rune.
+//
+// FullRune 报告 p 中的字节是否以一个文字的完整 UTF-8 编码开头。非法的编码被视为完整的
+// 文字，因为它会被转换成一个宽度为 1 的错误文字。
 func FullRune(p []byte) bool {
 	n := len(p)
 	if n == 0 {
@@ -106,9 +159,12 @@ func FullRune(p []byte) bool {
 	}
 	x := first[p[0]]
 	if n >= int(x&7) {
+		// ASCII、非法或者合法的编码。
 		return true // ASCII, invalid or valid.
 	}
 	// Must be short or invalid.
+	//
+	// 一定是不完整或者非法的编码。
 	accept := acceptRanges[x>>4]
 	if n > 1 && (p[1] < accept.lo || accept.hi < p[1]) {
 		return true
@@ -119,6 +175,8 @@ func FullRune(p []byte) bool {
 }
 
 // FullRuneInString is like FullRune but its input is a string.
+//
+// FullRuneInString 与 FullRune 相同，但它的输入是一个字符串。
 func FullRuneInString(s string) bool {
 	n := len(s)
 	if n == 0 {
@@ -126,9 +184,12 @@ func FullRuneInString(s string) bool {
 	}
 	x := first[s[0]]
 	if n >= int(x&7) {
+		// ASCII、非法或者合法的编码。
 		return true // ASCII, invalid, or valid.
 	}
 	// Must be short or invalid.
+	//
+	// 一定是不完整或者非法的编码。
 	accept := acceptRanges[x>>4]
 	if n > 1 && (s[1] < accept.lo || accept.hi < s[1]) {
 		return true
@@ -146,6 +207,16 @@ func FullRuneInString(s string) bool {
 // An encoding is invalid if it is incorrect UTF-8, encodes a rune that is
 // out of range, or is not the shortest possible UTF-8 encoding for the
 // value. No other validation is performed.
+//
+// DecodeRune 解开 p 中的第一个 UTF-8 编码，返回文字和它以字节为单位的宽度。如果 p 为空，
+// 它返回 (RuneError, 0)。否则，如果编码非法，它返回 (RuneError, 1)。对于正确的、非空的
+// UTF-8，这两种结果都是不可能出现的。
+//
+// 如果编码不是正确的 UTF-8、编码的文字超出范围或者不是该值最短的 UTF-8 编码，那么它就是
+// 非法的。不会进行其他的验证。
+//
+// IMP: bytes.Buffer 的 ReadRune 在第一个字节不小于 RuneSelf 时调用它。注意输入中真正的
+// U+FFFD 会返回 (RuneError, 3)，所以要靠 size 为 1 来判断编码是否非法。
 func DecodeRune(p []byte) (r rune, size int) {
 	n := len(p)
 	if n < 1 {
@@ -157,6 +228,14 @@ func DecodeRune(p []byte) (r rune, size int) {
 		// The following code simulates an additional check for x == xx and
 		// handling the ASCII and invalid cases accordingly. This mask-and-or
 		// approach prevents an additional branch.
+		//
+		// 下面的代码模拟了对 x == xx 的额外检查，并相应地处理 ASCII 和非法的情况。这种先掩码
+		// 再按位或的方法避免了一次额外的分支。
+		//
+		// IMP: x 的最低位在 as 时为 0，在 xx 时为 1。先左移 31 位再算术右移 31 位，就得到了
+		// 全 0 或全 1 的 mask，于是 ASCII 时返回 p[0]，非法时返回 RuneError。
+		//
+		// 创建 0x0000 或 0xFFFF。
 		mask := rune(x) << 31 >> 31 // Create 0x0000 or 0xFFFF.
 		return rune(p[0])&^mask | RuneError&mask, 1
 	}
@@ -194,6 +273,13 @@ func DecodeRune(p []byte) (r rune, size int) {
 // An encoding is invalid if it is incorrect UTF-8, encodes a rune that is
 // out of range, or is not the shortest possible UTF-8 encoding for the
 // value. No other validation is performed.
+//
+// DecodeRuneInString 与 DecodeRune 相同，但它的输入是一个字符串。如果 s 为空，它返回
+// (RuneError, 0)。否则，如果编码非法，它返回 (RuneError, 1)。对于正确的、非空的 UTF-8，
+// 这两种结果都是不可能出现的。
+//
+// 如果编码不是正确的 UTF-8、编码的文字超出范围或者不是该值最短的 UTF-8 编码，那么它就是
+// 非法的。不会进行其他的验证。
 func DecodeRuneInString(s string) (r rune, size int) {
 	n := len(s)
 	if n < 1 {
@@ -205,6 +291,11 @@ func DecodeRuneInString(s string) (r rune, size int) {
 		// The following code simulates an additional check for x == xx and
 		// handling the ASCII and invalid cases accordingly. This mask-and-or
 		// approach prevents an additional branch.
+		//
+		// 下面的代码模拟了对 x == xx 的额外检查，并相应地处理 ASCII 和非法的情况。这种先掩码
+		// 再按位或的方法避免了一次额外的分支。
+		//
+		// 创建 0x0000 或 0xFFFF。
 		mask := rune(x) << 31 >> 31 // Create 0x0000 or 0xFFFF.
 		return rune(s[0])&^mask | RuneError&mask, 1
 	}
@@ -242,6 +333,13 @@ func DecodeRuneInString(s string) (r rune, size int) {
 // An encoding is invalid if it is incorrect UTF-8, encodes a rune that is
 // out of range, or is not the shortest possible UTF-8 encoding for the
 // value. No other validation is performed.
+//
+// DecodeLastRune 解开 p 中的最后一个 UTF-8 编码，返回文字和它以字节为单位的宽度。如果 p
+// 为空，它返回 (RuneError, 0)。否则，如果编码非法，它返回 (RuneError, 1)。对于正确的、
+// 非空的 UTF-8，这两种结果都是不可能出现的。
+//
+// 如果编码不是正确的 UTF-8、编码的文字超出范围或者不是该值最短的 UTF-8 编码，那么它就是
+// 非法的。不会进行其他的验证。
 func DecodeLastRune(p []byte) (r rune, size int) {
 	end := len(p)
 	if end == 0 {
@@ -255,6 +353,11 @@ func DecodeLastRune(p []byte) (r rune, size int) {
 	// guard against O(n^2) behavior when traversing
 	// backwards through strings with long sequences of
 	// invalid UTF-8.
+	//
+	// 在反向遍历含有很长的非法 UTF-8 序列的字符串时，防止出现 O(n^2) 的行为。
+	//
+	// IMP: 最多向前看 UTFMax 个字节，找到一个可能的起始字节后再正向解码，解码出的编码必须
+	// 恰好结束在 end，否则最后一个字节是非法的。
 	lim := end - UTFMax
 	if lim < 0 {
 		lim = 0
@@ -282,6 +385,13 @@ func DecodeLastRune(p []byte) (r rune, size int) {
 // An encoding is invalid if it is incorrect UTF-8, encodes a rune that is
 // out of range, or is not the shortest possible UTF-8 encoding for the
 // value. No other validation is performed.
+//
+// DecodeLastRuneInString 与 DecodeLastRune 相同，但它的输入是一个字符串。如果 s 为空，
+// 它返回 (RuneError, 0)。否则，如果编码非法，它返回 (RuneError, 1)。对于正确的、非空的
+// UTF-8，这两种结果都是不可能出现的。
+//
+// 如果编码不是正确的 UTF-8、编码的文字超出范围或者不是该值最短的 UTF-8 编码，那么它就是
+// 非法的。不会进行其他的验证。
 func DecodeLastRuneInString(s string) (r rune, size int) {
 	end := len(s)
 	if end == 0 {
@@ -295,6 +405,8 @@ func DecodeLastRuneInString(s string) (r rune, size int) {
 	// guard against O(n^2) behavior when traversing
 	// backwards through strings with long sequences of
 	// invalid UTF-8.
+	//
+	// 在反向遍历含有很长的非法 UTF-8 序列的字符串时，防止出现 O(n^2) 的行为。
 	lim := end - UTFMax
 	if lim < 0 {
 		lim = 0
@@ -316,6 +428,9 @@ func DecodeLastRuneInString(s string) (r rune, size int) {
 
 // RuneLen returns the number of bytes required to encode the rune.
 // It returns -1 if the rune is not a valid value to encode in UTF-8.
+//
+// RuneLen 返回编码这个文字所需的字节数。如果这个文字不是可以用 UTF-8 编码的合法值，
+// 它返回 -1。
 func RuneLen(r rune) int {
 	switch {
 	case r < 0:
@@ -336,13 +451,23 @@ func RuneLen(r rune) int {
 
 // EncodeRune writes into p (which must be large enough) the UTF-8 encoding of the rune.
 // It returns the number of bytes written.
+//
+// EncodeRune 将文字的 UTF-8 编码写入 p（p 必须足够大）。它返回写入的字节数。
+//
+// IMP: 非法的文字会被编码成 RuneError，占用 3 个字节。bytes.Buffer 的 WriteRune 在写入
+// 之前预留了 UTFMax 个字节，所以 p 总是足够大的。
 func EncodeRune(p []byte, r rune) int {
 	// Negative values are erroneous. Making it unsigned addresses the problem.
+	//
+	// 负数是错误的值。将它转换成无符号数就解决了这个问题。
+	//
+	// IMP: 负数转换成 uint32 之后大于 MaxRune，会落到 RuneError 的分支中。
 	switch i := uint32(r); {
 	case i <= rune1Max:
 		p[0] = byte(r)
 		return 1
 	case i <= rune2Max:
+		// 消除边界检查
 		_ = p[1] // eliminate bounds checks
 		p[0] = t2 | byte(r>>6)
 		p[1] = tx | byte(r)&maskx
@@ -351,12 +476,14 @@ func EncodeRune(p []byte, r rune) int {
 		r = RuneError
 		fallthrough
 	case i <= rune3Max:
+		// 消除边界检查
 		_ = p[2] // eliminate bounds checks
 		p[0] = t3 | byte(r>>12)
 		p[1] = tx | byte(r>>6)&maskx
 		p[2] = tx | byte(r)&maskx
 		return 3
 	default:
+		// 消除边界检查
 		_ = p[3] // eliminate bounds checks
 		p[0] = t4 | byte(r>>18)
 		p[1] = tx | byte(r>>12)&maskx
@@ -368,6 +495,8 @@ func EncodeRune(p []byte, r rune) int {
 
 // RuneCount returns the number of runes in p. Erroneous and short
 // encodings are treated as single runes of width 1 byte.
+//
+// RuneCount 返回 p 中文字的个数。错误的和不完整的编码被视为宽度为 1 字节的单个文字。
 func RuneCount(p []byte) int {
 	np := len(p)
 	var n int
@@ -376,16 +505,20 @@ func RuneCount(p []byte) int {
 		c := p[i]
 		if c < RuneSelf {
 			// ASCII fast path
+			//
+			// ASCII 的快速路径
 			i++
 			continue
 		}
 		x := first[c]
 		if x == xx {
+			// 非法的编码。
 			i++ // invalid.
 			continue
 		}
 		size := int(x & 7)
 		if i+size > np {
+			// 不完整或者非法的编码。
 			i++ // Short or invalid.
 			continue
 		}
@@ -405,22 +538,28 @@ func RuneCount(p []byte) int {
 }
 
 // RuneCountInString is like RuneCount but its input is a string.
+//
+// RuneCountInString 与 RuneCount 相同，但它的输入是一个字符串。
 func RuneCountInString(s string) (n int) {
 	ns := len(s)
 	for i := 0; i < ns; n++ {
 		c := s[i]
 		if c < RuneSelf {
 			// ASCII fast path
+			//
+			// ASCII 的快速路径
 			i++
 			continue
 		}
 		x := first[c]
 		if x == xx {
+			// 非法的编码。
 			i++ // invalid.
 			continue
 		}
 		size := int(x & 7)
 		if i+size > ns {
+			// 不完整或者非法的编码。
 			i++ // Short or invalid.
 			continue
 		}
@@ -442,9 +581,14 @@ func RuneCountInString(s string) (n int) {
 // RuneStart reports whether the byte could be the first byte of an encoded,
 // possibly invalid rune. Second and subsequent bytes always have the top two
 // bits set to 10.
+//
+// RuneStart 报告这个字节是否可能是一个已编码的、可能非法的文字的第一个字节。第二个及
+// 之后的字节的最高两位总是 10。
 func RuneStart(b byte) bool { return b&0xC0 != 0x80 }
 
 // Valid reports whether p consists entirely of valid UTF-8-encoded runes.
+//
+// Valid 报告 p 是否完全由合法的 UTF-8 编码的文字组成。
 func Valid(p []byte) bool {
 	n := len(p)
 	for i := 0; i < n; {
@@ -455,10 +599,12 @@ func Valid(p []byte) bool {
 		}
 		x := first[pi]
 		if x == xx {
+			// 非法的起始字节。
 			return false // Illegal starter byte.
 		}
 		size := int(x & 7)
 		if i+size > n {
+			// 不完整或者非法的编码。
 			return false // Short or invalid.
 		}
 		accept := acceptRanges[x>>4]
@@ -477,6 +623,8 @@ func Valid(p []byte) bool {
 }
 
 // ValidString reports whether s consists entirely of valid UTF-8-encoded runes.
+//
+// ValidString 报告 s 是否完全由合法的 UTF-8 编码的文字组成。
 func ValidString(s string) bool {
 	n := len(s)
 	for i := 0; i < n; {
@@ -487,10 +635,12 @@ func ValidString(s string) bool {
 		}
 		x := first[si]
 		if x == xx {
+			// 非法的起始字节。
 			return false // Illegal starter byte.
 		}
 		size := int(x & 7)
 		if i+size > n {
+			// 不完整或者非法的编码。
 			return false // Short or invalid.
 		}
 		accept := acceptRanges[x>>4]
@@ -510,6 +660,8 @@ func ValidString(s string) bool {
 
 // ValidRune reports whether r can be legally encoded as UTF-8.
 // Code points that are out of range or a surrogate half are illegal.
+//
+// ValidRune 报告 r 是否可以合法地编码成 UTF-8。超出范围的码点和代理对的一半是非法的。
 func ValidRune(r rune) bool {
 	switch {
 	case 0 <= r && r < surrogateMin: